@@ -376,6 +376,18 @@ func VMIHasHotplugMemory(vmi *v1.VirtualMachineInstance) bool {
 	return vmiHasCondition(vmi, v1.VirtualMachineInstanceMemoryChange)
 }
 
+// VMIHasInPlaceMemoryChange returns true if the guest memory of a running VMI is resized
+// without a migration, because its launcher pod already requests enough memory.
+func VMIHasInPlaceMemoryChange(vmi *v1.VirtualMachineInstance) bool {
+	if !VMIHasHotplugMemory(vmi) {
+		return false
+	}
+	if _, exists := vmi.Labels[v1.VirtualMachinePodMemoryRequestsLabel]; !exists {
+		return false
+	}
+	return vmi.Status.MigrationState == nil || vmi.Status.MigrationState.Completed
+}
+
 func AttachmentPods(ownerPod *k8sv1.Pod, podInformer cache.SharedIndexInformer) ([]*k8sv1.Pod, error) {
 	objs, err := podInformer.GetIndexer().ByIndex(cache.NamespaceIndex, ownerPod.Namespace)
 	if err != nil {
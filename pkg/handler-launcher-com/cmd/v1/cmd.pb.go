@@ -40,6 +40,7 @@ It has these top-level messages:
 	SEVInfoResponse
 	LaunchMeasurementResponse
 	InjectLaunchSecretRequest
+	BackupRequest
 */
 package v1
 
@@ -409,8 +410,9 @@ type VirtualMachineOptions struct {
 	Topology              *Topology            `protobuf:"bytes,4,opt,name=topology" json:"topology,omitempty"`
 	DisksInfo             map[string]*DiskInfo `protobuf:"bytes,5,rep,name=DisksInfo" json:"DisksInfo,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Deprecated, use clusterConfig.ExpandDisksEnabled
-	ExpandDisksEnabled bool           `protobuf:"varint,6,opt,name=ExpandDisksEnabled" json:"ExpandDisksEnabled,omitempty"`
-	ClusterConfig      *ClusterConfig `protobuf:"bytes,7,opt,name=clusterConfig" json:"clusterConfig,omitempty"`
+	ExpandDisksEnabled   bool           `protobuf:"varint,6,opt,name=ExpandDisksEnabled" json:"ExpandDisksEnabled,omitempty"`
+	ClusterConfig        *ClusterConfig `protobuf:"bytes,7,opt,name=clusterConfig" json:"clusterConfig,omitempty"`
+	BalloonTargetPercent uint32         `protobuf:"varint,8,opt,name=BalloonTargetPercent" json:"BalloonTargetPercent,omitempty"`
 }
 
 func (m *VirtualMachineOptions) Reset()                    { *m = VirtualMachineOptions{} }
@@ -467,6 +469,13 @@ func (m *VirtualMachineOptions) GetClusterConfig() *ClusterConfig {
 	return nil
 }
 
+func (m *VirtualMachineOptions) GetBalloonTargetPercent() uint32 {
+	if m != nil {
+		return m.BalloonTargetPercent
+	}
+	return 0
+}
+
 type VMIRequest struct {
 	Vmi     *VMI                   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
 	Options *VirtualMachineOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
@@ -899,6 +908,30 @@ func (m *InjectLaunchSecretRequest) GetOptions() []byte {
 	return nil
 }
 
+type BackupRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *BackupRequest) Reset()                    { *m = BackupRequest{} }
+func (m *BackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()               {}
+func (*BackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *BackupRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *BackupRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*QemuVersionResponse)(nil), "kubevirt.cmd.v1.QemuVersionResponse")
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
@@ -930,6 +963,7 @@ func init() {
 	proto.RegisterType((*SEVInfoResponse)(nil), "kubevirt.cmd.v1.SEVInfoResponse")
 	proto.RegisterType((*LaunchMeasurementResponse)(nil), "kubevirt.cmd.v1.LaunchMeasurementResponse")
 	proto.RegisterType((*InjectLaunchSecretRequest)(nil), "kubevirt.cmd.v1.InjectLaunchSecretRequest")
+	proto.RegisterType((*BackupRequest)(nil), "kubevirt.cmd.v1.BackupRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -973,6 +1007,7 @@ type CmdClient interface {
 	GetSEVInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SEVInfoResponse, error)
 	GetLaunchMeasurement(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*LaunchMeasurementResponse, error)
 	InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecretRequest, opts ...grpc.CallOption) (*Response, error)
+	BackupVirtualMachine(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type cmdClient struct {
@@ -1253,6 +1288,15 @@ func (c *cmdClient) InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecr
 	return out, nil
 }
 
+func (c *cmdClient) BackupVirtualMachine(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/BackupVirtualMachine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cmd service
 
 type CmdServer interface {
@@ -1286,6 +1330,7 @@ type CmdServer interface {
 	GetSEVInfo(context.Context, *EmptyRequest) (*SEVInfoResponse, error)
 	GetLaunchMeasurement(context.Context, *VMIRequest) (*LaunchMeasurementResponse, error)
 	InjectLaunchSecret(context.Context, *InjectLaunchSecretRequest) (*Response, error)
+	BackupVirtualMachine(context.Context, *BackupRequest) (*Response, error)
 }
 
 func RegisterCmdServer(s *grpc.Server, srv CmdServer) {
@@ -1832,6 +1877,24 @@ func _Cmd_InjectLaunchSecret_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_BackupVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).BackupVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/BackupVirtualMachine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).BackupVirtualMachine(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubevirt.cmd.v1.Cmd",
 	HandlerType: (*CmdServer)(nil),
@@ -1956,6 +2019,10 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "InjectLaunchSecret",
 			Handler:    _Cmd_InjectLaunchSecret_Handler,
 		},
+		{
+			MethodName: "BackupVirtualMachine",
+			Handler:    _Cmd_BackupVirtualMachine_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/handler-launcher-com/cmd/v1/cmd.proto",
@@ -1964,111 +2031,113 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1688 bytes of a gzipped FileDescriptorProto
+	// 1719 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x5f, 0x6f, 0xdb, 0xc8,
-	0x11, 0xb7, 0x2c, 0xd9, 0x91, 0xc7, 0x7f, 0x2e, 0x59, 0xff, 0x29, 0xe3, 0x36, 0x89, 0xbb, 0x28,
-	0x02, 0x5f, 0x71, 0x67, 0x37, 0x69, 0xee, 0x50, 0x04, 0x45, 0x71, 0xb5, 0x2c, 0xfb, 0x7c, 0x17,
-	0x25, 0x3a, 0xca, 0x76, 0xd0, 0x6b, 0x0f, 0x87, 0x35, 0xb9, 0xa6, 0xb7, 0x26, 0x77, 0x59, 0xee,
-	0x52, 0x8d, 0xee, 0xa9, 0x40, 0x8b, 0x3e, 0x14, 0xe8, 0xe7, 0xe8, 0x47, 0xea, 0x27, 0x29, 0xd0,
-	0xc7, 0xc3, 0x2e, 0x97, 0x32, 0x25, 0x52, 0x71, 0x0c, 0xe9, 0xc9, 0xdc, 0x9d, 0x99, 0xdf, 0xcc,
-	0x0e, 0x67, 0x66, 0x7f, 0xa2, 0xe1, 0xe3, 0xf8, 0x3a, 0xd8, 0xbf, 0x22, 0xdc, 0x0f, 0x69, 0xf2,
-	0x69, 0x48, 0x52, 0xee, 0x5d, 0xd1, 0xe4, 0x53, 0x4f, 0x44, 0xfb, 0x5e, 0xe4, 0xef, 0xf7, 0x9f,
-	0xe9, 0x3f, 0x7b, 0x71, 0x22, 0x94, 0x40, 0x1f, 0x5d, 0xa7, 0x17, 0xb4, 0xcf, 0x12, 0xb5, 0xa7,
-	0xf7, 0xfa, 0xcf, 0xf0, 0x25, 0xac, 0x7f, 0x43, 0xa3, 0xf4, 0x9c, 0x26, 0x92, 0x09, 0xee, 0x52,
-	0x19, 0x0b, 0x2e, 0x29, 0xfa, 0x0c, 0x9a, 0x89, 0x7d, 0x76, 0x6a, 0x3b, 0xb5, 0xdd, 0xe5, 0xe7,
-	0x0f, 0xf7, 0xc6, 0x4c, 0xf7, 0x72, 0x65, 0x77, 0xa8, 0x8a, 0x1c, 0xb8, 0xd7, 0xcf, 0x90, 0x9c,
-	0xf9, 0x9d, 0xda, 0xee, 0x92, 0x9b, 0x2f, 0xf1, 0x13, 0xa8, 0x9f, 0x77, 0x4e, 0x8c, 0x42, 0xc4,
-	0xbe, 0x92, 0x82, 0x1b, 0xd8, 0x15, 0x37, 0x5f, 0xe2, 0x67, 0x50, 0x6f, 0x75, 0xcf, 0xd0, 0x1a,
-	0xcc, 0x33, 0xdf, 0xc8, 0x56, 0xdd, 0x79, 0xe6, 0xa3, 0x6d, 0x68, 0x4a, 0x76, 0x11, 0x32, 0x1e,
-	0x48, 0x67, 0x7e, 0xa7, 0xbe, 0xbb, 0xea, 0x0e, 0xd7, 0x78, 0x1f, 0xee, 0xf5, 0xb2, 0xe7, 0x92,
-	0xd9, 0x06, 0x2c, 0xf4, 0x49, 0x98, 0x52, 0x13, 0x46, 0xc3, 0xcd, 0x16, 0xb8, 0x0d, 0x0b, 0x5d,
-	0x12, 0x50, 0xa9, 0xc5, 0x9e, 0x48, 0xb9, 0x32, 0x16, 0x0d, 0x37, 0x5b, 0x20, 0x04, 0x8d, 0x94,
-	0x33, 0x65, 0x43, 0x37, 0xcf, 0x7a, 0x4f, 0xb2, 0x1f, 0xa8, 0x53, 0x37, 0xd0, 0xe6, 0x19, 0xbf,
-	0x80, 0xc5, 0x0e, 0x8d, 0x44, 0x32, 0x40, 0x5b, 0xb0, 0x48, 0xa2, 0x02, 0x90, 0x5d, 0x55, 0x21,
-	0xe1, 0xff, 0xd6, 0xa0, 0xd1, 0xa2, 0x61, 0x58, 0x8a, 0x75, 0x1f, 0x16, 0x23, 0x03, 0x67, 0xd4,
-	0x97, 0x9f, 0xff, 0xa4, 0x94, 0xe9, 0xcc, 0x9b, 0x6b, 0xd5, 0xd0, 0x27, 0xb0, 0x10, 0xeb, 0x63,
-	0x38, 0xf5, 0x9d, 0xfa, 0xee, 0xf2, 0xf3, 0xad, 0x92, 0xbe, 0x39, 0xa4, 0x9b, 0x29, 0xa1, 0xcf,
-	0x61, 0xc9, 0x67, 0x52, 0x11, 0xee, 0x51, 0xe9, 0x34, 0x8c, 0x85, 0x53, 0xb2, 0xb0, 0x79, 0x74,
-	0x6f, 0x54, 0xd1, 0x2e, 0x34, 0xbc, 0x38, 0x95, 0xce, 0x82, 0x31, 0xd9, 0x28, 0x99, 0xb4, 0xba,
-	0x67, 0xae, 0xd1, 0xc0, 0x5f, 0x40, 0xf3, 0x54, 0xc4, 0x22, 0x14, 0xc1, 0x00, 0xbd, 0x00, 0xe0,
-	0x69, 0x44, 0xbe, 0xf7, 0x68, 0x18, 0x4a, 0xa7, 0x66, 0x6c, 0x37, 0xcb, 0xb6, 0x34, 0x0c, 0xdd,
-	0x25, 0xad, 0xa8, 0x9f, 0x24, 0xfe, 0x57, 0x0d, 0x16, 0x7b, 0x9d, 0x03, 0x26, 0x24, 0xc2, 0xb0,
-	0x12, 0x11, 0x9e, 0x5e, 0x12, 0x4f, 0xa5, 0x09, 0x4d, 0x4c, 0x9e, 0x96, 0xdc, 0x91, 0x3d, 0x5d,
-	0x45, 0x71, 0x22, 0xfc, 0xd4, 0xcb, 0x33, 0x9c, 0x2f, 0x8b, 0x05, 0x58, 0x1f, 0x29, 0x40, 0x74,
-	0x1f, 0xea, 0xf2, 0x3a, 0x75, 0x1a, 0x66, 0x57, 0x3f, 0xea, 0x97, 0x77, 0x49, 0x22, 0x16, 0x0e,
-	0x9c, 0x05, 0xb3, 0x69, 0x57, 0xf8, 0x9f, 0x35, 0x68, 0x1e, 0x32, 0x79, 0x7d, 0xc2, 0x2f, 0x85,
-	0x51, 0x12, 0x49, 0x44, 0x94, 0x0d, 0xc4, 0xae, 0xd0, 0x0e, 0x2c, 0x5f, 0x10, 0xef, 0x9a, 0xf1,
-	0xe0, 0x88, 0x85, 0xd4, 0x86, 0x51, 0xdc, 0x42, 0x8f, 0x01, 0x74, 0xbc, 0x24, 0xec, 0xe5, 0xf5,
-	0xd3, 0x70, 0x0b, 0x3b, 0x1a, 0x41, 0xa7, 0x24, 0x57, 0x68, 0x18, 0x85, 0xe2, 0x16, 0xfe, 0x5f,
-	0x0d, 0x56, 0x5b, 0x61, 0x2a, 0x15, 0x4d, 0x5a, 0x82, 0x5f, 0xb2, 0x00, 0xed, 0x01, 0x6a, 0xbf,
-	0x8b, 0x09, 0xf7, 0x75, 0x7c, 0xb2, 0xcd, 0xc9, 0x45, 0x48, 0xb3, 0x52, 0x6a, 0xba, 0x15, 0x12,
-	0xf4, 0x5b, 0x78, 0x78, 0x94, 0x50, 0xaa, 0xeb, 0xc1, 0xa5, 0xb1, 0x48, 0x14, 0xe3, 0xc1, 0x21,
-	0x93, 0x99, 0xd9, 0xbc, 0x31, 0x9b, 0xac, 0x80, 0x5e, 0x82, 0x73, 0x20, 0xbc, 0x2b, 0x79, 0xc8,
-	0x64, 0x1c, 0x92, 0xc1, 0x91, 0x48, 0xda, 0x47, 0x27, 0xc7, 0x29, 0x95, 0x4a, 0x9a, 0xf3, 0x34,
-	0xdd, 0x89, 0x72, 0x6d, 0xdb, 0xa3, 0x09, 0x23, 0x61, 0x4b, 0x70, 0x29, 0x42, 0xfa, 0x4a, 0xdc,
-	0x38, 0x6e, 0x64, 0xb6, 0x93, 0xe4, 0xf8, 0x3f, 0x0d, 0xd8, 0x3c, 0xcf, 0xf2, 0xd0, 0x21, 0xde,
-	0x15, 0xe3, 0xf4, 0x4d, 0xac, 0x98, 0xe0, 0x12, 0x7d, 0x0d, 0x1b, 0xa3, 0x82, 0xac, 0x68, 0xec,
-	0x88, 0x2a, 0x37, 0x4e, 0x26, 0x76, 0x2b, 0x8d, 0xd0, 0x0b, 0xd8, 0xec, 0xd0, 0xe8, 0x80, 0x84,
-	0xa1, 0x10, 0xbc, 0xa7, 0x88, 0x92, 0x5d, 0x9a, 0x30, 0x91, 0x25, 0x66, 0xd5, 0xad, 0x16, 0xa2,
-	0x5f, 0xc1, 0x7a, 0x37, 0xa1, 0x7a, 0xdf, 0x23, 0x8a, 0xfa, 0xe7, 0x22, 0x4c, 0x23, 0xdb, 0x8a,
-	0x4b, 0x6e, 0x95, 0x48, 0xcf, 0x52, 0x65, 0xdb, 0xc3, 0x1c, 0xbd, 0x6a, 0x96, 0xe6, 0xfd, 0xe3,
-	0x0e, 0x55, 0x51, 0x0f, 0x96, 0xcc, 0xbb, 0xd4, 0x65, 0x68, 0x9b, 0xf0, 0xb3, 0x92, 0x5d, 0x65,
-	0x9a, 0xf6, 0x86, 0x76, 0x6d, 0xae, 0x92, 0x81, 0x7b, 0x83, 0x33, 0xa1, 0x80, 0x16, 0x27, 0x16,
-	0xd0, 0x21, 0xac, 0x7a, 0xc5, 0x0a, 0x74, 0xee, 0x99, 0x03, 0x3c, 0x2e, 0x77, 0x74, 0x51, 0xcb,
-	0x1d, 0x35, 0xda, 0x7e, 0x0b, 0x6b, 0xa3, 0x21, 0xe9, 0x6e, 0xbc, 0xa6, 0x03, 0xdb, 0x53, 0xfa,
-	0x11, 0xed, 0x17, 0x27, 0x76, 0x55, 0x8a, 0xf2, 0x96, 0xb4, 0xc3, 0xfc, 0xe5, 0xfc, 0x6f, 0x6a,
-	0xb8, 0x0f, 0x70, 0xde, 0x39, 0x71, 0xe9, 0x5f, 0x74, 0xd1, 0xa1, 0xa7, 0x50, 0xef, 0x47, 0xcc,
-	0x16, 0x43, 0x79, 0x60, 0x69, 0x4d, 0xad, 0x80, 0xbe, 0x80, 0x7b, 0x22, 0xcb, 0x94, 0x75, 0xf6,
-	0xf4, 0xc3, 0xf2, 0xea, 0xe6, 0x66, 0xf8, 0x14, 0xee, 0x77, 0x58, 0x90, 0x10, 0x65, 0xee, 0xcc,
-	0xbb, 0x79, 0x77, 0x46, 0xbd, 0xaf, 0xdc, 0xa0, 0xfe, 0xbd, 0x06, 0xcb, 0xed, 0x77, 0xd4, 0xcb,
-	0x11, 0x1f, 0x03, 0xf8, 0x22, 0x22, 0x8c, 0xbf, 0x26, 0x11, 0xb5, 0xb9, 0x2a, 0xec, 0x68, 0xa4,
-	0x96, 0x88, 0x22, 0xc2, 0xfd, 0x7c, 0x0c, 0xda, 0xa5, 0xbe, 0x7f, 0x7e, 0x9f, 0x04, 0x79, 0x55,
-	0x9a, 0x67, 0xf4, 0x14, 0xd6, 0x14, 0x8b, 0xa8, 0x48, 0x55, 0x8f, 0x7a, 0x82, 0xfb, 0xd2, 0x14,
-	0xe3, 0x82, 0x3b, 0xb6, 0x8b, 0xd7, 0x60, 0xa5, 0x1d, 0xc5, 0x6a, 0x60, 0xa3, 0xc0, 0xbf, 0x83,
-	0xa6, 0x5b, 0xb8, 0xdf, 0x65, 0xea, 0x79, 0x54, 0x4a, 0x3b, 0x74, 0xf2, 0xa5, 0x96, 0x44, 0x54,
-	0x4a, 0x12, 0xe4, 0xb3, 0x30, 0x5f, 0xe2, 0xef, 0x61, 0xed, 0xd0, 0xc4, 0x3c, 0x2d, 0xb9, 0xd8,
-	0x82, 0xc5, 0xec, 0xf0, 0xd6, 0x83, 0x5d, 0x61, 0x0e, 0xeb, 0x99, 0x03, 0xd3, 0xa6, 0xd3, 0x7a,
-	0xd9, 0x81, 0x65, 0xff, 0x06, 0x2d, 0x1f, 0xec, 0x85, 0x2d, 0xfc, 0x0e, 0x1e, 0x98, 0x21, 0x67,
-	0x8a, 0x71, 0x4a, 0x6f, 0x9f, 0xc0, 0x83, 0x60, 0x1c, 0xcb, 0xfa, 0x2c, 0x0b, 0xf0, 0x3f, 0x6a,
-	0xb0, 0x69, 0x5c, 0x9f, 0x49, 0x9a, 0xbc, 0x62, 0x52, 0x4d, 0xeb, 0xfe, 0x05, 0x6c, 0x06, 0x55,
-	0x78, 0x36, 0x84, 0x6a, 0x21, 0xfe, 0x77, 0x0d, 0x1c, 0x13, 0x86, 0xbe, 0xe7, 0xe4, 0x40, 0x2a,
-	0x1a, 0x4d, 0x9d, 0xf6, 0x97, 0xe0, 0x04, 0x13, 0x20, 0x6d, 0x30, 0x13, 0xe5, 0x78, 0x00, 0x2b,
-	0x59, 0xdb, 0x4c, 0x17, 0xc2, 0x36, 0x34, 0xe9, 0x3b, 0xa6, 0x5a, 0xc2, 0xcf, 0x5c, 0x2e, 0xb8,
-	0xc3, 0xb5, 0xae, 0x3d, 0xa9, 0xfc, 0x37, 0xa9, 0xb2, 0xb4, 0xc2, 0xae, 0xf0, 0xb7, 0x70, 0xdf,
-	0x64, 0xa2, 0xab, 0xc9, 0xd3, 0x07, 0xb6, 0x6d, 0xb9, 0x11, 0xe7, 0x2b, 0x1b, 0xf1, 0x2b, 0x5b,
-	0x67, 0x19, 0xf6, 0x54, 0x67, 0xc3, 0x02, 0x56, 0xf5, 0x3d, 0xff, 0x03, 0xbd, 0xeb, 0xb4, 0xfa,
-	0x1c, 0xb6, 0x52, 0x7e, 0x69, 0x4c, 0x4f, 0xab, 0x82, 0x9e, 0x20, 0xc5, 0x6f, 0xe1, 0x41, 0xc6,
-	0x5a, 0x0f, 0xd3, 0x28, 0xbe, 0xab, 0xd3, 0x6d, 0x68, 0xfa, 0x69, 0x14, 0x77, 0x89, 0xba, 0xb2,
-	0x2f, 0x7f, 0xb8, 0xc6, 0x17, 0xf0, 0x51, 0xaf, 0x7d, 0x3e, 0x8b, 0xde, 0xd3, 0xc3, 0x8c, 0xf6,
-	0xcd, 0xf5, 0x6a, 0x07, 0xb1, 0x5d, 0xe2, 0xbf, 0xd5, 0xe0, 0xe1, 0x2b, 0xf3, 0x3b, 0xaa, 0x43,
-	0x89, 0x4c, 0x13, 0x1a, 0x51, 0xae, 0x66, 0xd0, 0xea, 0xe1, 0x38, 0xa6, 0x75, 0x5c, 0x16, 0xe0,
-	0xef, 0xe0, 0xe1, 0x09, 0xff, 0x33, 0xf5, 0x54, 0x16, 0x47, 0x8f, 0x7a, 0x09, 0x55, 0x33, 0xbb,
-	0x6a, 0x9e, 0xff, 0x7f, 0x1d, 0xea, 0xad, 0xc8, 0x47, 0xaf, 0x01, 0xf5, 0x06, 0xdc, 0x1b, 0xbd,
-	0xee, 0xd0, 0x4f, 0x2b, 0x21, 0x33, 0xe7, 0xdb, 0x93, 0x0f, 0x8b, 0xe7, 0xd0, 0x1b, 0x58, 0xef,
-	0x92, 0x54, 0xd2, 0x99, 0x01, 0x7e, 0x03, 0x9b, 0x67, 0x3c, 0x9e, 0x29, 0x64, 0x0f, 0x36, 0xb2,
-	0x5e, 0x18, 0x43, 0x2c, 0x93, 0x9a, 0x91, 0x96, 0x79, 0x3f, 0xa8, 0x0b, 0x5b, 0x67, 0xb6, 0x13,
-	0x66, 0x16, 0xe8, 0x29, 0x38, 0x3d, 0x71, 0xa9, 0x5c, 0x7a, 0x21, 0x84, 0x9a, 0x19, 0xaa, 0x0b,
-	0x5b, 0xbd, 0xab, 0x54, 0xf9, 0xe2, 0xaf, 0x7c, 0x66, 0x98, 0xaf, 0x01, 0x7d, 0xcd, 0xc2, 0x70,
-	0x66, 0x78, 0x5d, 0xd8, 0x38, 0xa4, 0x21, 0x55, 0xb3, 0xcb, 0xe5, 0x5b, 0xd8, 0xcc, 0x18, 0xdb,
-	0x38, 0xe4, 0xcf, 0xcb, 0xbf, 0xb6, 0xc7, 0x98, 0xdd, 0xad, 0x15, 0xaf, 0x3b, 0x68, 0x68, 0x74,
-	0x4a, 0x92, 0x80, 0xaa, 0x29, 0x22, 0xfd, 0x03, 0x3c, 0x6a, 0xe9, 0x5f, 0xe0, 0x63, 0xd9, 0x1c,
-	0x3a, 0x98, 0xf2, 0xd5, 0xb3, 0x80, 0x93, 0x30, 0x0b, 0xb2, 0x2b, 0xfc, 0x56, 0x48, 0x09, 0x4f,
-	0xe3, 0x29, 0x30, 0xff, 0x08, 0x4f, 0x8e, 0x18, 0x27, 0x21, 0x1b, 0x2f, 0xfc, 0x59, 0x04, 0xfc,
-	0x1a, 0xd0, 0x97, 0x42, 0xc5, 0x61, 0x1a, 0x7c, 0x29, 0xa4, 0x3a, 0xa4, 0x7d, 0xe6, 0x51, 0x39,
-	0x05, 0x5e, 0x07, 0x96, 0x8e, 0xa9, 0xca, 0xd8, 0x22, 0x7a, 0x54, 0xd2, 0x2c, 0xf2, 0xde, 0xed,
-	0x27, 0xe5, 0x5f, 0x20, 0x23, 0x34, 0xd6, 0x14, 0xd5, 0xda, 0x10, 0xce, 0x70, 0xc3, 0xdb, 0x30,
-	0x7f, 0x31, 0x01, 0x73, 0x84, 0xb9, 0x9a, 0x11, 0xb5, 0x72, 0x4c, 0xd5, 0x90, 0x65, 0xde, 0x06,
-	0x8b, 0x4b, 0xe2, 0x12, 0x41, 0x35, 0xa0, 0xcd, 0x63, 0x6a, 0xd8, 0xdc, 0xad, 0x71, 0x3e, 0xad,
-	0x06, 0x2c, 0x31, 0xc1, 0x39, 0xf4, 0x27, 0x93, 0x82, 0x02, 0x2b, 0xbb, 0x0d, 0xfa, 0xe3, 0x6a,
-	0xe8, 0x2a, 0x5e, 0x37, 0x87, 0x0e, 0xa0, 0xa1, 0xd9, 0xcf, 0x6d, 0x98, 0xef, 0x7d, 0xe7, 0x6d,
-	0x68, 0x68, 0x76, 0x88, 0x7e, 0x56, 0xc6, 0xb8, 0xf9, 0xad, 0xb5, 0xfd, 0x68, 0x82, 0xb4, 0x30,
-	0x8c, 0x97, 0x86, 0x6c, 0xac, 0x62, 0x68, 0x8c, 0xb3, 0xc0, 0x49, 0xef, 0xa4, 0x48, 0xe6, 0x4c,
-	0xf7, 0x38, 0x63, 0x5d, 0x33, 0x24, 0x4d, 0x08, 0x4f, 0xf8, 0x0e, 0x58, 0x60, 0x54, 0xb7, 0xcd,
-	0x3c, 0xfd, 0x6e, 0x0a, 0x9f, 0x77, 0xef, 0x5e, 0x9e, 0x15, 0xdf, 0x86, 0xed, 0x1c, 0x29, 0xb1,
-	0x86, 0x56, 0xf7, 0x4c, 0x4e, 0x79, 0xd9, 0x95, 0x30, 0xed, 0x67, 0xd6, 0x69, 0xf8, 0x08, 0x1c,
-	0x53, 0x65, 0x09, 0xe3, 0x6d, 0xc7, 0xdf, 0x29, 0x7f, 0x3f, 0x1a, 0x65, 0x9a, 0x78, 0x0e, 0x11,
-	0xd8, 0x38, 0xa6, 0xaa, 0x44, 0x0e, 0xdf, 0x1f, 0xe2, 0x2f, 0x4b, 0xc2, 0x89, 0xec, 0x12, 0xcf,
-	0xa1, 0xef, 0x00, 0x95, 0xa9, 0x1f, 0x2a, 0x63, 0x4c, 0xe4, 0x87, 0xef, 0x4d, 0xc9, 0x41, 0xe3,
-	0xdb, 0xf9, 0xfe, 0xb3, 0x8b, 0x45, 0xf3, 0xff, 0x80, 0x5f, 0xff, 0x18, 0x00, 0x00, 0xff, 0xff,
-	0x6e, 0x62, 0xcb, 0xbc, 0x3c, 0x18, 0x00, 0x00,
+	0x11, 0xb7, 0x2c, 0xd9, 0x91, 0xc7, 0x7f, 0x2e, 0xd9, 0xd8, 0x2e, 0xe3, 0x36, 0x89, 0xbb, 0x28,
+	0x02, 0x5f, 0x71, 0x67, 0x37, 0x6e, 0xee, 0x50, 0x04, 0x45, 0x71, 0xb5, 0x2c, 0xfb, 0x7c, 0x17,
+	0x25, 0x0a, 0x65, 0x3b, 0xe8, 0xb5, 0x87, 0xc3, 0x9a, 0x5c, 0xcb, 0x5b, 0x93, 0xbb, 0x2c, 0x77,
+	0xa9, 0x46, 0x79, 0x2a, 0xd0, 0xa2, 0x0f, 0x05, 0xfa, 0xd1, 0xfa, 0xdc, 0x87, 0x7e, 0x8e, 0xbe,
+	0x17, 0xbb, 0x5c, 0xca, 0x94, 0x48, 0x59, 0x71, 0xa5, 0x27, 0x73, 0x76, 0x66, 0x7e, 0x33, 0x3b,
+	0x9c, 0xd9, 0xfd, 0x89, 0x86, 0x4f, 0xa3, 0xeb, 0xee, 0xde, 0x15, 0xe1, 0x7e, 0x40, 0xe3, 0xcf,
+	0x03, 0x92, 0x70, 0xef, 0x8a, 0xc6, 0x9f, 0x7b, 0x22, 0xdc, 0xf3, 0x42, 0x7f, 0xaf, 0xf7, 0x5c,
+	0xff, 0xd9, 0x8d, 0x62, 0xa1, 0x04, 0xfa, 0xe4, 0x3a, 0xb9, 0xa0, 0x3d, 0x16, 0xab, 0x5d, 0xbd,
+	0xd6, 0x7b, 0x8e, 0x2f, 0xe1, 0xe1, 0x5b, 0x1a, 0x26, 0xe7, 0x34, 0x96, 0x4c, 0x70, 0x97, 0xca,
+	0x48, 0x70, 0x49, 0xd1, 0x17, 0x50, 0x8f, 0xed, 0xb3, 0x53, 0xd9, 0xae, 0xec, 0x2c, 0xef, 0x3f,
+	0xda, 0x1d, 0x71, 0xdd, 0xcd, 0x8c, 0xdd, 0x81, 0x29, 0x72, 0xe0, 0x5e, 0x2f, 0x45, 0x72, 0xe6,
+	0xb7, 0x2b, 0x3b, 0x4b, 0x6e, 0x26, 0xe2, 0xa7, 0x50, 0x3d, 0x6f, 0x9d, 0x18, 0x83, 0x90, 0x7d,
+	0x23, 0x05, 0x37, 0xb0, 0x2b, 0x6e, 0x26, 0xe2, 0xe7, 0x50, 0x6d, 0xb4, 0xcf, 0xd0, 0x1a, 0xcc,
+	0x33, 0xdf, 0xe8, 0x56, 0xdd, 0x79, 0xe6, 0xa3, 0x2d, 0xa8, 0x4b, 0x76, 0x11, 0x30, 0xde, 0x95,
+	0xce, 0xfc, 0x76, 0x75, 0x67, 0xd5, 0x1d, 0xc8, 0x78, 0x0f, 0xee, 0x75, 0xd2, 0xe7, 0x82, 0xdb,
+	0x3a, 0x2c, 0xf4, 0x48, 0x90, 0x50, 0x93, 0x46, 0xcd, 0x4d, 0x05, 0xdc, 0x84, 0x85, 0x36, 0xe9,
+	0x52, 0xa9, 0xd5, 0x9e, 0x48, 0xb8, 0x32, 0x1e, 0x35, 0x37, 0x15, 0x10, 0x82, 0x5a, 0xc2, 0x99,
+	0xb2, 0xa9, 0x9b, 0x67, 0xbd, 0x26, 0xd9, 0x07, 0xea, 0x54, 0x0d, 0xb4, 0x79, 0xc6, 0x2f, 0x60,
+	0xb1, 0x45, 0x43, 0x11, 0xf7, 0xd1, 0x26, 0x2c, 0x92, 0x30, 0x07, 0x64, 0xa5, 0x32, 0x24, 0xfc,
+	0xef, 0x0a, 0xd4, 0x1a, 0x34, 0x08, 0x0a, 0xb9, 0xee, 0xc1, 0x62, 0x68, 0xe0, 0x8c, 0xf9, 0xf2,
+	0xfe, 0x8f, 0x0a, 0x95, 0x4e, 0xa3, 0xb9, 0xd6, 0x0c, 0x7d, 0x06, 0x0b, 0x91, 0xde, 0x86, 0x53,
+	0xdd, 0xae, 0xee, 0x2c, 0xef, 0x6f, 0x16, 0xec, 0xcd, 0x26, 0xdd, 0xd4, 0x08, 0x7d, 0x09, 0x4b,
+	0x3e, 0x93, 0x8a, 0x70, 0x8f, 0x4a, 0xa7, 0x66, 0x3c, 0x9c, 0x82, 0x87, 0xad, 0xa3, 0x7b, 0x63,
+	0x8a, 0x76, 0xa0, 0xe6, 0x45, 0x89, 0x74, 0x16, 0x8c, 0xcb, 0x7a, 0xc1, 0xa5, 0xd1, 0x3e, 0x73,
+	0x8d, 0x05, 0xfe, 0x0a, 0xea, 0xa7, 0x22, 0x12, 0x81, 0xe8, 0xf6, 0xd1, 0x0b, 0x00, 0x9e, 0x84,
+	0xe4, 0x07, 0x8f, 0x06, 0x81, 0x74, 0x2a, 0xc6, 0x77, 0xa3, 0xe8, 0x4b, 0x83, 0xc0, 0x5d, 0xd2,
+	0x86, 0xfa, 0x49, 0xe2, 0x7f, 0x54, 0x60, 0xb1, 0xd3, 0x3a, 0x60, 0x42, 0x22, 0x0c, 0x2b, 0x21,
+	0xe1, 0xc9, 0x25, 0xf1, 0x54, 0x12, 0xd3, 0xd8, 0xd4, 0x69, 0xc9, 0x1d, 0x5a, 0xd3, 0x5d, 0x14,
+	0xc5, 0xc2, 0x4f, 0xbc, 0xac, 0xc2, 0x99, 0x98, 0x6f, 0xc0, 0xea, 0x50, 0x03, 0xa2, 0xfb, 0x50,
+	0x95, 0xd7, 0x89, 0x53, 0x33, 0xab, 0xfa, 0x51, 0xbf, 0xbc, 0x4b, 0x12, 0xb2, 0xa0, 0xef, 0x2c,
+	0x98, 0x45, 0x2b, 0xe1, 0xbf, 0x57, 0xa0, 0x7e, 0xc8, 0xe4, 0xf5, 0x09, 0xbf, 0x14, 0xc6, 0x48,
+	0xc4, 0x21, 0x51, 0x36, 0x11, 0x2b, 0xa1, 0x6d, 0x58, 0xbe, 0x20, 0xde, 0x35, 0xe3, 0xdd, 0x23,
+	0x16, 0x50, 0x9b, 0x46, 0x7e, 0x09, 0x3d, 0x01, 0xd0, 0xf9, 0x92, 0xa0, 0x93, 0xf5, 0x4f, 0xcd,
+	0xcd, 0xad, 0x68, 0x04, 0x5d, 0x92, 0xcc, 0xa0, 0x66, 0x0c, 0xf2, 0x4b, 0xf8, 0xbf, 0x15, 0x58,
+	0x6d, 0x04, 0x89, 0x54, 0x34, 0x6e, 0x08, 0x7e, 0xc9, 0xba, 0x68, 0x17, 0x50, 0xf3, 0x7d, 0x44,
+	0xb8, 0xaf, 0xf3, 0x93, 0x4d, 0x4e, 0x2e, 0x02, 0x9a, 0xb6, 0x52, 0xdd, 0x2d, 0xd1, 0xa0, 0x5f,
+	0xc3, 0xa3, 0xa3, 0x98, 0x52, 0xdd, 0x0f, 0x2e, 0x8d, 0x44, 0xac, 0x18, 0xef, 0x1e, 0x32, 0x99,
+	0xba, 0xcd, 0x1b, 0xb7, 0xf1, 0x06, 0xe8, 0x25, 0x38, 0x07, 0xc2, 0xbb, 0x92, 0x87, 0x4c, 0x46,
+	0x01, 0xe9, 0x1f, 0x89, 0xb8, 0x79, 0x74, 0x72, 0x9c, 0x50, 0xa9, 0xa4, 0xd9, 0x4f, 0xdd, 0x1d,
+	0xab, 0xd7, 0xbe, 0x1d, 0x1a, 0x33, 0x12, 0x34, 0x04, 0x97, 0x22, 0xa0, 0xaf, 0xc4, 0x4d, 0xe0,
+	0x5a, 0xea, 0x3b, 0x4e, 0x8f, 0xff, 0x53, 0x83, 0x8d, 0xf3, 0xb4, 0x0e, 0x2d, 0xe2, 0x5d, 0x31,
+	0x4e, 0xdf, 0x44, 0x8a, 0x09, 0x2e, 0xd1, 0xb7, 0xb0, 0x3e, 0xac, 0x48, 0x9b, 0xc6, 0xa9, 0x8c,
+	0x19, 0x9c, 0x54, 0xed, 0x96, 0x3a, 0xa1, 0x17, 0xb0, 0xd1, 0xa2, 0xe1, 0x01, 0x09, 0x02, 0x21,
+	0x78, 0x47, 0x11, 0x25, 0xdb, 0x34, 0x66, 0x22, 0x2d, 0xcc, 0xaa, 0x5b, 0xae, 0x44, 0xbf, 0x80,
+	0x87, 0xed, 0x98, 0xea, 0x75, 0x8f, 0x28, 0xea, 0x9f, 0x8b, 0x20, 0x09, 0xed, 0x28, 0x2e, 0xb9,
+	0x65, 0x2a, 0x7d, 0x96, 0x2a, 0x3b, 0x1e, 0x4e, 0x6d, 0xcc, 0x59, 0x9a, 0xcd, 0x8f, 0x3b, 0x30,
+	0x45, 0x1d, 0x58, 0x32, 0xef, 0x52, 0xb7, 0xa1, 0x1d, 0xc2, 0x2f, 0x0a, 0x7e, 0xa5, 0x65, 0xda,
+	0x1d, 0xf8, 0x35, 0xb9, 0x8a, 0xfb, 0xee, 0x0d, 0xce, 0x98, 0x06, 0x5a, 0x1c, 0xdb, 0x40, 0x87,
+	0xb0, 0xea, 0xe5, 0x3b, 0xd0, 0xb9, 0x67, 0x36, 0xf0, 0xa4, 0x38, 0xd1, 0x79, 0x2b, 0x77, 0xd8,
+	0x09, 0xed, 0xc3, 0xba, 0xad, 0xe4, 0x29, 0x89, 0xbb, 0x54, 0xb5, 0x69, 0xec, 0x51, 0xae, 0x9c,
+	0xba, 0x29, 0x74, 0xa9, 0x6e, 0xeb, 0x1d, 0xac, 0x0d, 0x6f, 0x43, 0x4f, 0xf0, 0x35, 0xed, 0xdb,
+	0x39, 0xd4, 0x8f, 0x68, 0x2f, 0x7f, 0xca, 0x97, 0x95, 0x35, 0x1b, 0x63, 0x7b, 0x01, 0xbc, 0x9c,
+	0xff, 0x55, 0x05, 0xf7, 0x00, 0xce, 0x5b, 0x27, 0x2e, 0xfd, 0x93, 0x6e, 0x54, 0xf4, 0x0c, 0xaa,
+	0xbd, 0x90, 0xd9, 0x06, 0x2a, 0x1e, 0x72, 0xda, 0x52, 0x1b, 0xa0, 0xaf, 0xe0, 0x9e, 0x48, 0xab,
+	0x6b, 0x83, 0x3d, 0xfb, 0xb8, 0x77, 0xe1, 0x66, 0x6e, 0xf8, 0x14, 0xee, 0xb7, 0x58, 0x37, 0x26,
+	0xca, 0xdc, 0xb3, 0x77, 0x8b, 0xee, 0x0c, 0x47, 0x5f, 0xb9, 0x41, 0xfd, 0x6b, 0x05, 0x96, 0x9b,
+	0xef, 0xa9, 0x97, 0x21, 0x3e, 0x01, 0xf0, 0x45, 0x48, 0x18, 0x7f, 0x4d, 0x42, 0x6a, 0x6b, 0x95,
+	0x5b, 0xd1, 0x48, 0x0d, 0x11, 0x86, 0x84, 0xfb, 0xd9, 0xd1, 0x69, 0x45, 0x7d, 0x67, 0xfd, 0x36,
+	0xee, 0x66, 0x9d, 0x6c, 0x9e, 0xd1, 0x33, 0x58, 0x53, 0x2c, 0xa4, 0x22, 0x51, 0x1d, 0xea, 0x09,
+	0xee, 0x4b, 0xd3, 0xc0, 0x0b, 0xee, 0xc8, 0x2a, 0x5e, 0x83, 0x95, 0x66, 0x18, 0xa9, 0xbe, 0xcd,
+	0x02, 0xff, 0x06, 0xea, 0x6e, 0x8e, 0x13, 0xc8, 0xc4, 0xf3, 0xa8, 0x94, 0xf6, 0xa0, 0xca, 0x44,
+	0xad, 0x09, 0xa9, 0x94, 0xa4, 0x9b, 0x9d, 0x9f, 0x99, 0x88, 0x7f, 0x80, 0xb5, 0x43, 0x93, 0xf3,
+	0xb4, 0x84, 0x64, 0x13, 0x16, 0xd3, 0xcd, 0xdb, 0x08, 0x56, 0xc2, 0x1c, 0x1e, 0xa6, 0x01, 0xcc,
+	0x68, 0x4f, 0x1b, 0x65, 0x1b, 0x96, 0xfd, 0x1b, 0xb4, 0xec, 0x32, 0xc8, 0x2d, 0xe1, 0xf7, 0xf0,
+	0xc0, 0x1c, 0x8c, 0xa6, 0x19, 0xa7, 0x8c, 0xf6, 0x19, 0x3c, 0xe8, 0x8e, 0x62, 0xd9, 0x98, 0x45,
+	0x05, 0xfe, 0x5b, 0x05, 0x36, 0x4c, 0xe8, 0x33, 0x49, 0xe3, 0x57, 0x4c, 0xaa, 0x69, 0xc3, 0xbf,
+	0x80, 0x8d, 0x6e, 0x19, 0x9e, 0x4d, 0xa1, 0x5c, 0x89, 0xff, 0x59, 0x01, 0xc7, 0xa4, 0xa1, 0xef,
+	0x46, 0xd9, 0x97, 0x8a, 0x86, 0x53, 0x97, 0xfd, 0x25, 0x38, 0xdd, 0x31, 0x90, 0x36, 0x99, 0xb1,
+	0x7a, 0xdc, 0x87, 0x95, 0x74, 0x6c, 0xa6, 0x4b, 0x61, 0x0b, 0xea, 0xf4, 0x3d, 0x53, 0x0d, 0xe1,
+	0xa7, 0x21, 0x17, 0xdc, 0x81, 0xac, 0x7b, 0x4f, 0x2a, 0xff, 0x4d, 0xa2, 0x2c, 0x15, 0xb1, 0x12,
+	0xfe, 0x0e, 0xee, 0x9b, 0x4a, 0xb4, 0x35, 0xe1, 0xfa, 0xc8, 0xb1, 0x2d, 0x0e, 0xe2, 0x7c, 0xe9,
+	0x20, 0x7e, 0x03, 0x0f, 0x72, 0xd8, 0x53, 0xed, 0x0d, 0x0b, 0x58, 0xd5, 0xdc, 0xe0, 0x03, 0xbd,
+	0xeb, 0x69, 0xf5, 0x25, 0x6c, 0x26, 0xfc, 0xd2, 0xb8, 0x9e, 0x96, 0x25, 0x3d, 0x46, 0x8b, 0xdf,
+	0xc1, 0x83, 0x94, 0xe9, 0x1e, 0x26, 0x61, 0x74, 0xd7, 0xa0, 0x5b, 0x50, 0xf7, 0x93, 0x30, 0x6a,
+	0x13, 0x75, 0x65, 0x5f, 0xfe, 0x40, 0xc6, 0x17, 0xf0, 0x49, 0xa7, 0x79, 0x3e, 0x8b, 0xd9, 0xd3,
+	0x87, 0x19, 0xed, 0x99, 0x2b, 0xd9, 0x1e, 0xc4, 0x56, 0xc4, 0x7f, 0xa9, 0xc0, 0xa3, 0x57, 0xe6,
+	0xb7, 0x57, 0x8b, 0x12, 0x99, 0xc4, 0x34, 0xa4, 0x5c, 0xcd, 0x60, 0xd4, 0x83, 0x51, 0x4c, 0x1b,
+	0xb8, 0xa8, 0xc0, 0xdf, 0xc3, 0xa3, 0x13, 0xfe, 0x47, 0xea, 0xa9, 0x34, 0x8f, 0x0e, 0xf5, 0x62,
+	0xaa, 0x66, 0x77, 0xd5, 0xbc, 0x85, 0xd5, 0x03, 0xe2, 0x5d, 0x27, 0xd1, 0xcc, 0x20, 0xf7, 0xff,
+	0xb5, 0x0e, 0xd5, 0x46, 0xe8, 0xa3, 0xd7, 0x80, 0x3a, 0x7d, 0xee, 0x0d, 0xdf, 0xa0, 0xe8, 0xc7,
+	0xa5, 0x90, 0x69, 0xf0, 0xad, 0xf1, 0xf5, 0xc3, 0x73, 0xe8, 0x0d, 0x3c, 0x6c, 0x93, 0x44, 0xd2,
+	0x99, 0x01, 0xbe, 0x85, 0x8d, 0x33, 0x1e, 0xcd, 0x14, 0xb2, 0x03, 0xeb, 0xe9, 0x78, 0x8d, 0x20,
+	0x16, 0xb9, 0xd5, 0xd0, 0x14, 0xde, 0x0e, 0xea, 0xc2, 0xe6, 0x19, 0xbf, 0x2c, 0x83, 0xfd, 0xff,
+	0x13, 0x3d, 0x05, 0xa7, 0x23, 0x2e, 0x95, 0x4b, 0x2f, 0x84, 0x50, 0x33, 0x43, 0x75, 0x61, 0xb3,
+	0x73, 0x95, 0x28, 0x5f, 0xfc, 0x99, 0xcf, 0x0c, 0xf3, 0x35, 0xa0, 0x6f, 0x59, 0x10, 0xcc, 0x0c,
+	0xaf, 0x0d, 0xeb, 0x87, 0x34, 0xa0, 0x6a, 0x76, 0xb5, 0x7c, 0x07, 0x1b, 0x29, 0x09, 0x1c, 0x85,
+	0xfc, 0x69, 0xf1, 0x47, 0xff, 0x08, 0x59, 0x9c, 0xd8, 0xf1, 0x7a, 0x82, 0x06, 0x4e, 0x29, 0x99,
+	0x9e, 0x22, 0xd3, 0xdf, 0xc1, 0xe3, 0x86, 0xfe, 0x10, 0x30, 0x52, 0xcd, 0x41, 0x80, 0x29, 0x5f,
+	0x3d, 0xeb, 0x72, 0x12, 0x58, 0xc6, 0x2f, 0xfc, 0x46, 0x40, 0x09, 0x4f, 0xa2, 0x29, 0x30, 0x7f,
+	0x0f, 0x4f, 0x8f, 0x18, 0x27, 0x01, 0xfb, 0x40, 0x67, 0x9f, 0xf0, 0x6b, 0x40, 0x5f, 0x0b, 0x15,
+	0x05, 0x49, 0xf7, 0x6b, 0x21, 0xd5, 0x21, 0xed, 0x31, 0x8f, 0xca, 0x29, 0xf0, 0x5a, 0xb0, 0x74,
+	0x4c, 0x55, 0x4a, 0x40, 0xd1, 0xe3, 0x82, 0x65, 0x9e, 0x4a, 0x6f, 0x3d, 0x2d, 0xfe, 0xa8, 0x19,
+	0x62, 0xc6, 0xa6, 0xa9, 0xd6, 0x06, 0x70, 0x86, 0x6e, 0x4e, 0xc2, 0xfc, 0xd9, 0x18, 0xcc, 0x21,
+	0x32, 0x6c, 0x8e, 0xa8, 0x95, 0x63, 0xaa, 0x06, 0xc4, 0x75, 0x12, 0x2c, 0x2e, 0xa8, 0x0b, 0x9c,
+	0xd7, 0x80, 0xd6, 0x8f, 0xa9, 0x21, 0x88, 0x13, 0xf3, 0x7c, 0x56, 0x0e, 0x58, 0x20, 0x97, 0x73,
+	0xe8, 0x0f, 0xa6, 0x04, 0x39, 0xa2, 0x37, 0x09, 0xfa, 0xd3, 0x72, 0xe8, 0x32, 0xaa, 0x38, 0x87,
+	0x0e, 0xa0, 0xa6, 0x09, 0xd5, 0x24, 0xcc, 0x5b, 0xdf, 0x79, 0x13, 0x6a, 0x9a, 0x70, 0xa2, 0x9f,
+	0x14, 0x31, 0x6e, 0x7e, 0xbe, 0x6d, 0x3d, 0x1e, 0xa3, 0xcd, 0x1d, 0xc6, 0x4b, 0x03, 0x82, 0x57,
+	0x72, 0x68, 0x8c, 0x12, 0xcb, 0x2d, 0x7c, 0x9b, 0x49, 0x6e, 0x7a, 0x9c, 0x91, 0xa9, 0x19, 0xf0,
+	0x30, 0x84, 0xc7, 0x7c, 0x8e, 0xcc, 0x91, 0xb4, 0x49, 0x67, 0x9e, 0x7e, 0x37, 0xb9, 0xaf, 0xcc,
+	0x77, 0x6f, 0xcf, 0x92, 0x4f, 0xd4, 0xf6, 0x1c, 0x29, 0xb0, 0x86, 0x46, 0xfb, 0x4c, 0x4e, 0x79,
+	0xd9, 0x15, 0x30, 0xd3, 0x0d, 0x4f, 0xc5, 0x47, 0xe0, 0x98, 0x2a, 0xcb, 0x41, 0x27, 0x6d, 0x7f,
+	0xbb, 0xa0, 0x1e, 0x21, 0xaf, 0x78, 0x0e, 0x11, 0x58, 0x3f, 0xa6, 0xaa, 0xc0, 0x37, 0x6f, 0x4f,
+	0xf1, 0xe7, 0x05, 0xe5, 0x58, 0xc2, 0x8a, 0xe7, 0xd0, 0xf7, 0x80, 0x8a, 0x6c, 0x12, 0x15, 0x31,
+	0xc6, 0x52, 0xce, 0x89, 0xf4, 0x27, 0x65, 0x93, 0x13, 0xe9, 0xcf, 0x10, 0xe9, 0xbc, 0x15, 0xf4,
+	0xa0, 0xf6, 0xdd, 0x7c, 0xef, 0xf9, 0xc5, 0xa2, 0xf9, 0x5f, 0xc7, 0x2f, 0xff, 0x37, 0x00, 0xa5,
+	0xc9, 0x54, 0x6e, 0x18, 0x19, 0x00, 0x00,
 }
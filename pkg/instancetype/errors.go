@@ -19,6 +19,12 @@
 
 package instancetype
 
+import "errors"
+
+// errInferenceDeferred is returned when defaults are inferred from a containerDisk without an ImageLabelsFetcher,
+// the matcher is left unchanged so the VirtualMachine controller can complete the inference
+var errInferenceDeferred = errors.New("inference from containerDisk deferred")
+
 type IgnoreableInferenceError struct {
 	err error
 }
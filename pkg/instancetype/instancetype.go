@@ -46,6 +46,7 @@ type Methods interface {
 	ApplyToVmi(field *k8sfield.Path, instancetypespec *instancetypev1beta1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec, vmiMetadata *metav1.ObjectMeta) Conflicts
 	FindPreferenceSpec(vm *virtv1.VirtualMachine) (*instancetypev1beta1.VirtualMachinePreferenceSpec, error)
 	StoreControllerRevisions(vm *virtv1.VirtualMachine) error
+	UpgradeControllerRevisions(vm *virtv1.VirtualMachine, dryRun bool) error
 	InferDefaultInstancetype(vm *virtv1.VirtualMachine) error
 	InferDefaultPreference(vm *virtv1.VirtualMachine) error
 	CheckPreferenceRequirements(instancetypeSpec *instancetypev1beta1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec, vmiSpec *virtv1.VirtualMachineInstanceSpec) (Conflicts, error)
@@ -68,6 +69,10 @@ type InstancetypeMethods struct {
 	ClusterPreferenceStore   cache.Store
 	ControllerRevisionStore  cache.Store
 	Clientset                kubecli.KubevirtClient
+	// ImageLabelsFetcher is used to infer defaults from containerDisks. When it is nil, matchers inferring from a
+	// containerDisk are left unchanged, so that admission never contacts a registry, and the VirtualMachine
+	// controller completes the inference.
+	ImageLabelsFetcher ImageLabelsFetcher
 }
 
 var _ Methods = &InstancetypeMethods{}
@@ -301,6 +306,39 @@ func (m *InstancetypeMethods) StoreControllerRevisions(vm *virtv1.VirtualMachine
 	return nil
 }
 
+// UpgradeControllerRevisions re-resolves the instancetype and preference of the VirtualMachine to their latest generation.
+// The RevisionNames of the VirtualMachine are updated in place, it is up to the caller to persist them.
+// When dryRun is set, the ControllerRevisions are only created in memory and not stored.
+func (m *InstancetypeMethods) UpgradeControllerRevisions(vm *virtv1.VirtualMachine, dryRun bool) error {
+	if vm.Spec.Instancetype != nil {
+		instancetypeRevision, err := m.createInstancetypeRevision(vm)
+		if err != nil {
+			return err
+		}
+		if !dryRun {
+			if instancetypeRevision, err = storeRevision(instancetypeRevision, m.Clientset, false); err != nil {
+				return err
+			}
+		}
+		vm.Spec.Instancetype.RevisionName = instancetypeRevision.Name
+	}
+
+	if vm.Spec.Preference != nil {
+		preferenceRevision, err := m.createPreferenceRevision(vm)
+		if err != nil {
+			return err
+		}
+		if !dryRun {
+			if preferenceRevision, err = storeRevision(preferenceRevision, m.Clientset, true); err != nil {
+				return err
+			}
+		}
+		vm.Spec.Preference.RevisionName = preferenceRevision.Name
+	}
+
+	return nil
+}
+
 func CompareRevisions(revisionA *appsv1.ControllerRevision, revisionB *appsv1.ControllerRevision, isPreference bool) (bool, error) {
 	if err := decodeControllerRevision(revisionA, isPreference); err != nil {
 		return false, err
@@ -467,7 +505,8 @@ func (m *InstancetypeMethods) ApplyToVmi(field *k8sfield.Path, instancetypeSpec
 }
 
 func (m *InstancetypeMethods) FindPreferenceSpec(vm *virtv1.VirtualMachine) (*instancetypev1beta1.VirtualMachinePreferenceSpec, error) {
-	if vm.Spec.Preference == nil {
+	// There is nothing to find until the preference has been inferred
+	if vm.Spec.Preference == nil || vm.Spec.Preference.InferFromVolume != "" {
 		return nil, nil
 	}
 
@@ -611,7 +650,8 @@ func (m *InstancetypeMethods) findClusterPreferenceByClient(resourceName string)
 }
 
 func (m *InstancetypeMethods) FindInstancetypeSpec(vm *virtv1.VirtualMachine) (*instancetypev1beta1.VirtualMachineInstancetypeSpec, error) {
-	if vm.Spec.Instancetype == nil {
+	// There is nothing to find until the instancetype has been inferred
+	if vm.Spec.Instancetype == nil || vm.Spec.Instancetype.InferFromVolume != "" {
 		return nil, nil
 	}
 
@@ -735,6 +775,9 @@ func (m *InstancetypeMethods) InferDefaultInstancetype(vm *virtv1.VirtualMachine
 	if vm.Spec.Instancetype == nil {
 		return nil
 	}
+	// A failure of an earlier inference is only kept while the matcher remains cleared
+	delete(vm.Annotations, apiinstancetype.InstancetypeInferenceFailureAnnotation)
+
 	// Leave matcher unchanged when inference is disabled
 	if vm.Spec.Instancetype.InferFromVolume == "" {
 		return nil
@@ -744,16 +787,20 @@ func (m *InstancetypeMethods) InferDefaultInstancetype(vm *virtv1.VirtualMachine
 		*vm.Spec.Instancetype.InferFromVolumeFailurePolicy == virtv1.IgnoreInferFromVolumeFailure
 
 	defaultName, defaultKind, err := m.inferDefaultsFromVolumes(vm, vm.Spec.Instancetype.InferFromVolume, apiinstancetype.DefaultInstancetypeLabel, apiinstancetype.DefaultInstancetypeKindLabel)
+	if errors.Is(err, errInferenceDeferred) {
+		return nil
+	}
 	if err != nil {
 		var ignoreableInferenceErr *IgnoreableInferenceError
 		if errors.As(err, &ignoreableInferenceErr) && ignoreFailure {
 			//nolint:gomnd
 			log.Log.Object(vm).V(3).Info("Ignored error during inference of instancetype, clearing matcher.")
+			setInferenceFailure(vm, apiinstancetype.InstancetypeInferenceFailureAnnotation, err)
 			vm.Spec.Instancetype = nil
 			return nil
 		}
 
-		return err
+		return fmt.Errorf("unable to infer instancetype from volume %s: %w", vm.Spec.Instancetype.InferFromVolume, err)
 	}
 
 	if ignoreFailure {
@@ -771,12 +818,18 @@ func (m *InstancetypeMethods) InferDefaultPreference(vm *virtv1.VirtualMachine)
 	if vm.Spec.Preference == nil {
 		return nil
 	}
+	// A failure of an earlier inference is only kept while the matcher remains cleared
+	delete(vm.Annotations, apiinstancetype.PreferenceInferenceFailureAnnotation)
+
 	// Leave matcher unchanged when inference is disabled
 	if vm.Spec.Preference.InferFromVolume == "" {
 		return nil
 	}
 
 	defaultName, defaultKind, err := m.inferDefaultsFromVolumes(vm, vm.Spec.Preference.InferFromVolume, apiinstancetype.DefaultPreferenceLabel, apiinstancetype.DefaultPreferenceKindLabel)
+	if errors.Is(err, errInferenceDeferred) {
+		return nil
+	}
 	if err != nil {
 		var ignoreableInferenceErr *IgnoreableInferenceError
 		ignoreFailure := vm.Spec.Preference.InferFromVolumeFailurePolicy != nil &&
@@ -785,11 +838,12 @@ func (m *InstancetypeMethods) InferDefaultPreference(vm *virtv1.VirtualMachine)
 		if errors.As(err, &ignoreableInferenceErr) && ignoreFailure {
 			//nolint:gomnd
 			log.Log.Object(vm).V(3).Info("Ignored error during inference of preference, clearing matcher.")
+			setInferenceFailure(vm, apiinstancetype.PreferenceInferenceFailureAnnotation, err)
 			vm.Spec.Preference = nil
 			return nil
 		}
 
-		return err
+		return fmt.Errorf("unable to infer preference from volume %s: %w", vm.Spec.Preference.InferFromVolume, err)
 	}
 
 	vm.Spec.Preference = &virtv1.PreferenceMatcher{
@@ -799,9 +853,25 @@ func (m *InstancetypeMethods) InferDefaultPreference(vm *virtv1.VirtualMachine)
 	return nil
 }
 
+// IsInferencePending returns true when the instancetype or preference of the VirtualMachine still has to be
+// inferred from a volume, which is the case for containerDisks until the VirtualMachine controller inferred them
+func IsInferencePending(vm *virtv1.VirtualMachine) bool {
+	return (vm.Spec.Instancetype != nil && vm.Spec.Instancetype.InferFromVolume != "") ||
+		(vm.Spec.Preference != nil && vm.Spec.Preference.InferFromVolume != "")
+}
+
+// setInferenceFailure records why an ignored inference failed, the VirtualMachine controller reports it as a condition
+func setInferenceFailure(vm *virtv1.VirtualMachine, annotation string, err error) {
+	if vm.Annotations == nil {
+		vm.Annotations = make(map[string]string)
+	}
+	vm.Annotations[annotation] = err.Error()
+}
+
 /*
-Defaults will be inferred from the following combinations of DataVolumeSources, DataVolumeTemplates, DataSources and PVCs:
+Defaults will be inferred from the following combinations of ContainerDisks, DataVolumeSources, DataVolumeTemplates, DataSources and PVCs:
 
+Volume -> ContainerDiskSource -> Image labels and annotations
 Volume -> PersistentVolumeClaimVolumeSource -> PersistentVolumeClaim
 Volume -> DataVolumeSource -> DataVolume
 Volume -> DataVolumeSource -> DataVolumeSourcePVC -> PersistentVolumeClaim
@@ -810,6 +880,8 @@ Volume -> DataVolumeSource -> DataVolumeSourceRef -> DataSource -> PersistentVol
 Volume -> DataVolumeSource -> DataVolumeTemplate -> DataVolumeSourcePVC -> PersistentVolumeClaim
 Volume -> DataVolumeSource -> DataVolumeTemplate -> DataVolumeSourceRef -> DataSource
 Volume -> DataVolumeSource -> DataVolumeTemplate -> DataVolumeSourceRef -> DataSource -> PersistentVolumeClaim
+
+The labels of a PersistentVolumeClaim take precedence over its annotations.
 */
 func (m *InstancetypeMethods) inferDefaultsFromVolumes(vm *virtv1.VirtualMachine, inferFromVolumeName, defaultNameLabel, defaultKindLabel string) (defaultName, defaultKind string, err error) {
 	for _, volume := range vm.Spec.Template.Spec.Volumes {
@@ -822,6 +894,9 @@ func (m *InstancetypeMethods) inferDefaultsFromVolumes(vm *virtv1.VirtualMachine
 		if volume.DataVolume != nil {
 			return m.inferDefaultsFromDataVolume(vm, volume.DataVolume.Name, defaultNameLabel, defaultKindLabel)
 		}
+		if volume.ContainerDisk != nil {
+			return m.inferDefaultsFromContainerDisk(vm, volume.ContainerDisk, defaultNameLabel, defaultKindLabel)
+		}
 		return "", "", NewIgnoreableInferenceError(fmt.Errorf("unable to infer defaults from volume %s as type is not supported", inferFromVolumeName))
 	}
 	return "", "", fmt.Errorf("unable to find volume %s to infer defaults", inferFromVolumeName)
@@ -840,9 +915,46 @@ func (m *InstancetypeMethods) inferDefaultsFromPVC(pvcName, pvcNamespace, defaul
 	if err != nil {
 		return "", "", err
 	}
+	// PVCs imported from a registry carry the defaults of the image as annotations
+	if _, hasLabel := pvc.Labels[defaultNameLabel]; !hasLabel {
+		if _, hasAnnotation := pvc.Annotations[defaultNameLabel]; hasAnnotation {
+			return inferDefaultsFromLabels(pvc.Annotations, defaultNameLabel, defaultKindLabel)
+		}
+	}
 	return inferDefaultsFromLabels(pvc.Labels, defaultNameLabel, defaultKindLabel)
 }
 
+func (m *InstancetypeMethods) inferDefaultsFromContainerDisk(vm *virtv1.VirtualMachine, containerDisk *virtv1.ContainerDiskSource, defaultNameLabel, defaultKindLabel string) (defaultName, defaultKind string, err error) {
+	if m.ImageLabelsFetcher == nil {
+		return "", "", errInferenceDeferred
+	}
+
+	// virt-controller may not read secrets cluster wide, namespaces inferring from private images grant it
+	// get on their pull secrets with a Role
+	var pullSecret *k8sv1.Secret
+	if containerDisk.ImagePullSecret != "" {
+		pullSecret, err = m.Clientset.CoreV1().Secrets(vm.Namespace).Get(context.Background(), containerDisk.ImagePullSecret, metav1.GetOptions{})
+		if k8serrors.IsForbidden(err) {
+			return "", "", NewIgnoreableInferenceError(fmt.Errorf("not allowed to get image pull secret %s, grant the kubevirt-controller service account get on it with a Role in namespace %s", containerDisk.ImagePullSecret, vm.Namespace))
+		} else if err != nil {
+			return "", "", NewIgnoreableInferenceError(fmt.Errorf("unable to get image pull secret %s: %v", containerDisk.ImagePullSecret, err))
+		}
+	}
+
+	labels, err := m.ImageLabelsFetcher.ImageLabels(containerDisk.Image, vm.Spec.Template.Spec.Architecture, pullSecret)
+	if errors.Is(err, ErrImageLabelsPending) {
+		return "", "", err
+	}
+	if err != nil {
+		return "", "", NewIgnoreableInferenceError(fmt.Errorf("unable to look up labels of image %s: %v", containerDisk.Image, err))
+	}
+	defaultName, hasLabel := labels[defaultNameLabel]
+	if !hasLabel {
+		return "", "", NewIgnoreableInferenceError(fmt.Errorf("unable to find required %s label on image %s", defaultNameLabel, containerDisk.Image))
+	}
+	return defaultName, labels[defaultKindLabel], nil
+}
+
 func (m *InstancetypeMethods) inferDefaultsFromDataVolume(vm *virtv1.VirtualMachine, dvName, defaultNameLabel, defaultKindLabel string) (defaultName, defaultKind string, err error) {
 	if len(vm.Spec.DataVolumeTemplates) > 0 {
 		for _, dvt := range vm.Spec.DataVolumeTemplates {
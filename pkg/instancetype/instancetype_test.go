@@ -486,6 +486,44 @@ var _ = Describe("Instancetype and Preferences", func() {
 				Expect(instancetypeMethods.StoreControllerRevisions(vm)).To(MatchError(ContainSubstring("VM field conflicts with selected Instancetype")))
 			})
 
+			Context("upgrade", func() {
+				var upgradedInstancetype *instancetypev1beta1.VirtualMachineInstancetype
+
+				BeforeEach(func() {
+					vm.Spec.Instancetype.RevisionName = "previous-revision"
+
+					upgradedInstancetype = fakeInstancetype.DeepCopy()
+					upgradedInstancetype.Generation = resourceGeneration + 1
+					upgradedInstancetype.Spec.CPU.Guest = 4
+					Expect(instancetypeInformerStore.Update(upgradedInstancetype)).To(Succeed())
+				})
+
+				It("stores a ControllerRevision of the latest generation", func() {
+					instancetypeControllerRevision, err := instancetype.CreateControllerRevision(vm, upgradedInstancetype)
+					Expect(err).ToNot(HaveOccurred())
+
+					expectControllerRevisionCreation(instancetypeControllerRevision)
+
+					Expect(instancetypeMethods.UpgradeControllerRevisions(vm, false)).To(Succeed())
+					Expect(vm.Spec.Instancetype.RevisionName).To(Equal(instancetypeControllerRevision.Name))
+				})
+
+				It("does not store a ControllerRevision on dry run", func() {
+					Expect(instancetypeMethods.UpgradeControllerRevisions(vm, true)).To(Succeed())
+					Expect(vm.Spec.Instancetype.RevisionName).To(Equal(
+						instancetype.GetRevisionName(vm.Name, upgradedInstancetype.Name, upgradedInstancetype.UID, upgradedInstancetype.Generation)))
+					Expect(k8sClient.Actions()).To(BeEmpty())
+				})
+
+				It("fails if the latest generation conflicts with vm", func() {
+					vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{
+						Cores: 1,
+					}
+					Expect(instancetypeMethods.UpgradeControllerRevisions(vm, false)).To(MatchError(ContainSubstring("VM field conflicts with selected Instancetype")))
+					Expect(vm.Spec.Instancetype.RevisionName).To(Equal("previous-revision"))
+				})
+			})
+
 			It("find successfully decodes v1alpha1 VirtualMachineInstancetypeSpecRevision ControllerRevision without APIVersion set - bug #9261", func() {
 				fakeInstancetype.Spec.CPU = instancetypev1beta1.CPUInstancetype{
 					Guest: uint32(2),
@@ -871,6 +909,24 @@ var _ = Describe("Instancetype and Preferences", func() {
 
 				Expect(instancetypeMethods.StoreControllerRevisions(vm)).To(MatchError(ContainSubstring("found existing ControllerRevision with unexpected data")))
 			})
+
+			It("upgrade stores a ControllerRevision of the latest generation", func() {
+				vm.Spec.Preference.RevisionName = "previous-revision"
+
+				upgradedPreference := preference.DeepCopy()
+				upgradedPreference.Generation = resourceGeneration + 1
+				preferredCPUTopology := instancetypev1beta1.PreferCores
+				upgradedPreference.Spec.CPU.PreferredCPUTopology = &preferredCPUTopology
+				Expect(preferenceInformerStore.Update(upgradedPreference)).To(Succeed())
+
+				preferenceControllerRevision, err := instancetype.CreateControllerRevision(vm, upgradedPreference)
+				Expect(err).ToNot(HaveOccurred())
+
+				expectControllerRevisionCreation(preferenceControllerRevision)
+
+				Expect(instancetypeMethods.UpgradeControllerRevisions(vm, false)).To(Succeed())
+				Expect(vm.Spec.Preference.RevisionName).To(Equal(preferenceControllerRevision.Name))
+			})
 		})
 	})
 
@@ -0,0 +1,508 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package instancetype
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+)
+
+const (
+	dockerHubDomain   = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+	dockerHubIndex    = "https://index.docker.io/v1/"
+
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex       = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// registryLookupTimeout bounds the time of all requests needed to look up the labels of an image
+	registryLookupTimeout = 10 * time.Second
+	// Manifests and image configs are small, anything bigger than this is not what we are looking for
+	maxRegistryResponseSize = 4 * 1024 * 1024
+
+	// imageLabelsCacheTTL is how long looked up image labels are reused
+	imageLabelsCacheTTL = 10 * time.Minute
+	// imageLabelsErrorCacheTTL is how long a failed lookup is reported before the registry is asked again
+	imageLabelsErrorCacheTTL = 30 * time.Second
+)
+
+// ErrImageLabelsPending is returned by the CachedImageLabelsFetcher while the labels of an image are looked up
+var ErrImageLabelsPending = errors.New("image labels are being looked up")
+
+// ImageLabelsFetcher returns the labels and annotations of a container image, which are used to infer defaults from containerDisks
+type ImageLabelsFetcher interface {
+	ImageLabels(image, architecture string, pullSecret *k8sv1.Secret) (map[string]string, error)
+}
+
+// RegistryImageLabelsFetcher looks up the image labels and annotations with the registry HTTP API. Only the manifest
+// and the image config are fetched, the layers of the image are never downloaded. Only the registries returned by
+// AllowedRegistries are contacted. The lookup is done from the controller, registry mirrors and certificate authorities
+// configured on the nodes are not taken into account.
+type RegistryImageLabelsFetcher struct {
+	Client            *http.Client
+	AllowedRegistries func() []string
+}
+
+func NewRegistryImageLabelsFetcher(allowedRegistries func() []string) *RegistryImageLabelsFetcher {
+	return &RegistryImageLabelsFetcher{Client: &http.Client{}, AllowedRegistries: allowedRegistries}
+}
+
+// CachedImageLabelsFetcher looks up image labels in the background and caches the results, so that controller workers
+// never wait for a registry. ErrImageLabelsPending is returned until the lookup of an image finished, callers are
+// expected to retry later.
+type CachedImageLabelsFetcher struct {
+	fetcher ImageLabelsFetcher
+	lock    sync.Mutex
+	lookups map[string]*imageLabelsLookup
+}
+
+type imageLabelsLookup struct {
+	done    bool
+	labels  map[string]string
+	err     error
+	expires time.Time
+}
+
+func NewCachedImageLabelsFetcher(fetcher ImageLabelsFetcher) *CachedImageLabelsFetcher {
+	return &CachedImageLabelsFetcher{
+		fetcher: fetcher,
+		lookups: map[string]*imageLabelsLookup{},
+	}
+}
+
+func (f *CachedImageLabelsFetcher) ImageLabels(image, architecture string, pullSecret *k8sv1.Secret) (map[string]string, error) {
+	// Labels of private images are only shared by users of the same pull secret
+	key := image + "|" + architecture
+	if pullSecret != nil {
+		key += "|" + pullSecret.Namespace + "/" + pullSecret.Name + "@" + pullSecret.ResourceVersion
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	now := time.Now()
+	if lookup, exists := f.lookups[key]; exists {
+		if !lookup.done {
+			return nil, ErrImageLabelsPending
+		}
+		if now.Before(lookup.expires) {
+			return lookup.labels, lookup.err
+		}
+	}
+
+	f.removeExpiredLookups(now)
+	lookup := &imageLabelsLookup{}
+	f.lookups[key] = lookup
+	go func() {
+		labels, err := f.fetcher.ImageLabels(image, architecture, pullSecret)
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		lookup.done, lookup.labels, lookup.err = true, labels, err
+		if err != nil {
+			lookup.expires = time.Now().Add(imageLabelsErrorCacheTTL)
+		} else {
+			lookup.expires = time.Now().Add(imageLabelsCacheTTL)
+		}
+	}()
+	return nil, ErrImageLabelsPending
+}
+
+func (f *CachedImageLabelsFetcher) removeExpiredLookups(now time.Time) {
+	for key, lookup := range f.lookups {
+		if lookup.done && !now.Before(lookup.expires) {
+			delete(f.lookups, key)
+		}
+	}
+}
+
+// trustedRealmHosts are the hosts, besides the registry itself, which registries are allowed to send to for a token
+var trustedRealmHosts = map[string][]string{
+	dockerHubRegistry: {"auth.docker.io"},
+}
+
+type imageReference struct {
+	registry   string
+	repository string
+	reference  string
+}
+
+type imageDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+	} `json:"platform,omitempty"`
+}
+
+// imageManifest covers the fields of image manifests and image indexes, in both the OCI and the Docker format
+type imageManifest struct {
+	MediaType   string            `json:"mediaType"`
+	Config      *imageDescriptor  `json:"config,omitempty"`
+	Manifests   []imageDescriptor `json:"manifests,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type imageConfig struct {
+	Config struct {
+		Labels map[string]string `json:"Labels,omitempty"`
+	} `json:"config"`
+}
+
+type registryAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+type registrySession struct {
+	ctx      context.Context
+	client   *http.Client
+	ref      imageReference
+	username string
+	password string
+	token    string
+}
+
+// ImageLabels returns the annotations of the image manifest merged with the labels of the image config, the labels take
+// precedence. For multi-arch images the manifest matching the architecture is used.
+func (f *RegistryImageLabelsFetcher) ImageLabels(image, architecture string, pullSecret *k8sv1.Secret) (map[string]string, error) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return nil, err
+	}
+	if !f.isAllowedRegistry(ref.registry) {
+		return nil, fmt.Errorf("registry %s of image %s is not allowed for instancetype inference", ref.registry, image)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), registryLookupTimeout)
+	defer cancel()
+	session := &registrySession{ctx: ctx, client: f.Client, ref: ref}
+	if pullSecret != nil {
+		if session.username, session.password, err = credentialsFromPullSecret(pullSecret, ref.registry); err != nil {
+			return nil, err
+		}
+	}
+
+	labels := map[string]string{}
+	manifest, err := session.fetchManifest(ref.reference)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Manifests) > 0 {
+		mergeLabels(labels, manifest.Annotations)
+		descriptor := selectManifest(manifest.Manifests, architecture)
+		if descriptor == nil {
+			return nil, fmt.Errorf("image %s does not provide a manifest for architecture %s", image, architecture)
+		}
+		mergeLabels(labels, descriptor.Annotations)
+		if manifest, err = session.fetchManifest(descriptor.Digest); err != nil {
+			return nil, err
+		}
+	}
+	mergeLabels(labels, manifest.Annotations)
+
+	if manifest.Config == nil || manifest.Config.Digest == "" {
+		return labels, nil
+	}
+	body, err := session.get(fmt.Sprintf("/v2/%s/blobs/%s", ref.repository, manifest.Config.Digest), "")
+	if err != nil {
+		return nil, err
+	}
+	config := &imageConfig{}
+	if err := json.Unmarshal(body, config); err != nil {
+		return nil, fmt.Errorf("unable to parse the config of image %s: %v", image, err)
+	}
+	mergeLabels(labels, config.Config.Labels)
+	return labels, nil
+}
+
+func (f *RegistryImageLabelsFetcher) isAllowedRegistry(registry string) bool {
+	if f.AllowedRegistries == nil {
+		return false
+	}
+	for _, allowed := range f.AllowedRegistries() {
+		if normalizeRegistry(allowed) == registry {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeRegistry returns the registry in the form used by parseImageReference
+func normalizeRegistry(registry string) string {
+	registry = strings.TrimSuffix(strings.TrimPrefix(registry, "https://"), "/")
+	if registry == dockerHubDomain || registry == "index."+dockerHubDomain {
+		return dockerHubRegistry
+	}
+	return registry
+}
+
+func mergeLabels(target, source map[string]string) {
+	for key, value := range source {
+		target[key] = value
+	}
+}
+
+func selectManifest(manifests []imageDescriptor, architecture string) *imageDescriptor {
+	var fallback *imageDescriptor
+	for i := range manifests {
+		descriptor := &manifests[i]
+		if descriptor.Platform == nil {
+			continue
+		}
+		if descriptor.Platform.OS != "" && descriptor.Platform.OS != "linux" {
+			continue
+		}
+		if descriptor.Platform.Architecture == architecture {
+			return descriptor
+		}
+		if fallback == nil && architecture == "" {
+			fallback = descriptor
+		}
+	}
+	return fallback
+}
+
+func (s *registrySession) fetchManifest(reference string) (*imageManifest, error) {
+	accept := strings.Join([]string{mediaTypeOCIManifest, mediaTypeOCIIndex, mediaTypeDockerManifest, mediaTypeDockerList}, ", ")
+	body, err := s.get(fmt.Sprintf("/v2/%s/manifests/%s", s.ref.repository, reference), accept)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &imageManifest{}
+	if err := json.Unmarshal(body, manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse the manifest %s of image %s: %v", reference, s.ref.repository, err)
+	}
+	return manifest, nil
+}
+
+// get requests the path from the registry, a bearer token is requested when the registry asks for it
+func (s *registrySession) get(path, accept string) ([]byte, error) {
+	resp, err := s.do(path, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && s.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := s.authenticate(challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = s.do(path, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry %s returned %s for %s", s.ref.registry, resp.Status, path)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRegistryResponseSize))
+}
+
+func (s *registrySession) do(path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, "https://"+s.ref.registry+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	} else if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+	return s.client.Do(req)
+}
+
+func (s *registrySession) authenticate(challenge string) error {
+	scheme, params := parseAuthChallenge(challenge)
+	if !strings.EqualFold(scheme, "bearer") || params["realm"] == "" {
+		return fmt.Errorf("registry %s denied access to %s", s.ref.registry, s.ref.repository)
+	}
+
+	// The credentials of the pull secret are sent to the realm, it has to belong to the registry
+	realm, err := url.Parse(params["realm"])
+	if err != nil {
+		return fmt.Errorf("registry %s returned an invalid realm: %v", s.ref.registry, err)
+	}
+	if !s.isTrustedRealm(realm) {
+		return fmt.Errorf("registry %s returned the untrusted realm %s", s.ref.registry, realm.Redacted())
+	}
+
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", s.ref.repository))
+	realm.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to get a token for %s from %s: %s", s.ref.repository, realm.Host, resp.Status)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxRegistryResponseSize)).Decode(&token); err != nil {
+		return fmt.Errorf("unable to parse the token for %s: %v", s.ref.repository, err)
+	}
+	s.token = token.Token
+	if s.token == "" {
+		s.token = token.AccessToken
+	}
+	if s.token == "" {
+		return fmt.Errorf("registry %s returned an empty token for %s", s.ref.registry, s.ref.repository)
+	}
+	return nil
+}
+
+// isTrustedRealm only accepts realms served with https by the registry itself or by its known token service
+func (s *registrySession) isTrustedRealm(realm *url.URL) bool {
+	if realm.Scheme != "https" || realm.User != nil {
+		return false
+	}
+	if realm.Host == s.ref.registry {
+		return true
+	}
+	for _, host := range trustedRealmHosts[s.ref.registry] {
+		if realm.Host == host {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAuthChallenge parses a WWW-Authenticate header like `Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+	return scheme, params
+}
+
+// parseImageReference splits an image like quay.io/containerdisks/fedora:38 into registry, repository and tag or digest
+func parseImageReference(image string) (imageReference, error) {
+	ref := imageReference{reference: "latest"}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.reference = name[i+1:]
+		name = name[:i]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.reference = name[i+1:]
+		name = name[:i]
+	}
+
+	ref.registry = dockerHubDomain
+	if domain, remainder, found := strings.Cut(name, "/"); found && (strings.ContainsAny(domain, ".:") || domain == "localhost") {
+		ref.registry = domain
+		name = remainder
+	}
+	if ref.registry == dockerHubDomain {
+		ref.registry = dockerHubRegistry
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+	if name == "" || ref.reference == "" {
+		return imageReference{}, fmt.Errorf("invalid image reference %s", image)
+	}
+	ref.repository = name
+	return ref, nil
+}
+
+// credentialsFromPullSecret returns the credentials for the registry from a kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg secret
+func credentialsFromPullSecret(secret *k8sv1.Secret, registry string) (string, string, error) {
+	auths := map[string]registryAuth{}
+	if data, ok := secret.Data[k8sv1.DockerConfigJsonKey]; ok {
+		config := struct {
+			Auths map[string]registryAuth `json:"auths"`
+		}{}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return "", "", fmt.Errorf("unable to parse image pull secret %s: %v", secret.Name, err)
+		}
+		auths = config.Auths
+	} else if data, ok := secret.Data[k8sv1.DockerConfigKey]; ok {
+		if err := json.Unmarshal(data, &auths); err != nil {
+			return "", "", fmt.Errorf("unable to parse image pull secret %s: %v", secret.Name, err)
+		}
+	}
+
+	for key, auth := range auths {
+		if !matchesRegistry(key, registry) {
+			continue
+		}
+		if auth.Auth == "" {
+			return auth.Username, auth.Password, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("unable to decode the credentials for %s in image pull secret %s: %v", key, secret.Name, err)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return username, password, nil
+	}
+	return "", "", nil
+}
+
+func matchesRegistry(key, registry string) bool {
+	if registry == dockerHubRegistry && (key == dockerHubIndex || key == dockerHubDomain || key == "index.docker.io") {
+		return true
+	}
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	host, _, _ := strings.Cut(key, "/")
+	return host == registry
+}
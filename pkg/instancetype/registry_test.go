@@ -0,0 +1,290 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package instancetype_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiinstancetype "kubevirt.io/api/instancetype"
+
+	"kubevirt.io/kubevirt/pkg/instancetype"
+)
+
+var _ = Describe("RegistryImageLabelsFetcher", func() {
+	const (
+		repository = "containerdisks/fedora"
+		token      = "registry-token"
+	)
+
+	var (
+		server       *httptest.Server
+		fetcher      *instancetype.RegistryImageLabelsFetcher
+		image        string
+		realm        string
+		tokenUser    string
+		tokenRequest int
+	)
+
+	writeJSON := func(w http.ResponseWriter, obj interface{}) {
+		Expect(json.NewEncoder(w).Encode(obj)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		tokenUser = ""
+		tokenRequest = 0
+		mux := http.NewServeMux()
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			tokenRequest++
+			Expect(r.URL.Query().Get("scope")).To(Equal(fmt.Sprintf("repository:%s:pull", repository)))
+			Expect(r.URL.Query().Get("service")).To(Equal("test-registry"))
+			tokenUser, _, _ = r.BasicAuth()
+			writeJSON(w, map[string]string{"token": token})
+		})
+		mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s",service="test-registry"`, realm))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch strings.TrimPrefix(r.URL.Path, "/v2/"+repository) {
+			case "/manifests/latest":
+				writeJSON(w, map[string]interface{}{
+					"mediaType": "application/vnd.oci.image.index.v1+json",
+					"manifests": []map[string]interface{}{{
+						"digest":   "sha256:amd64",
+						"platform": map[string]string{"os": "linux", "architecture": "amd64"},
+					}, {
+						"digest":   "sha256:arm64",
+						"platform": map[string]string{"os": "linux", "architecture": "arm64"},
+					}},
+				})
+			case "/manifests/sha256:amd64", "/manifests/sha256:arm64":
+				arch := strings.TrimPrefix(r.URL.Path, "/v2/"+repository+"/manifests/sha256:")
+				writeJSON(w, map[string]interface{}{
+					"mediaType": "application/vnd.oci.image.manifest.v1+json",
+					"config":    map[string]string{"digest": "sha256:config-" + arch},
+					"annotations": map[string]string{
+						apiinstancetype.DefaultPreferenceLabel: "preference-" + arch,
+					},
+				})
+			case "/blobs/sha256:config-amd64", "/blobs/sha256:config-arm64":
+				arch := strings.TrimPrefix(r.URL.Path, "/v2/"+repository+"/blobs/sha256:config-")
+				writeJSON(w, map[string]interface{}{
+					"config": map[string]interface{}{
+						"Labels": map[string]string{
+							apiinstancetype.DefaultInstancetypeLabel: "instancetype-" + arch,
+						},
+					},
+				})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		server = httptest.NewTLSServer(mux)
+		DeferCleanup(server.Close)
+
+		realm = server.URL + "/token"
+		fetcher = &instancetype.RegistryImageLabelsFetcher{
+			Client:            server.Client(),
+			AllowedRegistries: func() []string { return []string{strings.TrimPrefix(server.URL, "https://")} },
+		}
+		image = strings.TrimPrefix(server.URL, "https://") + "/" + repository
+	})
+
+	DescribeTable("should return the config labels and manifest annotations", func(architecture string) {
+		labels, err := fetcher.ImageLabels(image+":latest", architecture, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(labels).To(HaveKeyWithValue(apiinstancetype.DefaultInstancetypeLabel, "instancetype-"+architecture))
+		Expect(labels).To(HaveKeyWithValue(apiinstancetype.DefaultPreferenceLabel, "preference-"+architecture))
+		Expect(tokenRequest).To(Equal(1))
+	},
+		Entry("for amd64", "amd64"),
+		Entry("for arm64", "arm64"),
+	)
+
+	It("should default to the latest tag", func() {
+		labels, err := fetcher.ImageLabels(image, "amd64", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(labels).To(HaveKeyWithValue(apiinstancetype.DefaultInstancetypeLabel, "instancetype-amd64"))
+	})
+
+	It("should look up an image by digest", func() {
+		labels, err := fetcher.ImageLabels(image+"@sha256:arm64", "amd64", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(labels).To(HaveKeyWithValue(apiinstancetype.DefaultInstancetypeLabel, "instancetype-arm64"))
+	})
+
+	It("should fail when the image does not provide the architecture", func() {
+		_, err := fetcher.ImageLabels(image, "s390x", nil)
+		Expect(err).To(MatchError(ContainSubstring("does not provide a manifest for architecture s390x")))
+	})
+
+	It("should fail when the image does not exist", func() {
+		_, err := fetcher.ImageLabels(image+":unknown", "amd64", nil)
+		Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+	})
+
+	DescribeTable("should not contact a registry which is not allowed", func(allowedRegistries func() []string) {
+		fetcher.AllowedRegistries = allowedRegistries
+		_, err := fetcher.ImageLabels(image, "amd64", nil)
+		Expect(err).To(MatchError(ContainSubstring("is not allowed for instancetype inference")))
+		Expect(tokenRequest).To(BeZero())
+	},
+		Entry("without allowed registries", nil),
+		Entry("with an empty list", func() []string { return nil }),
+		Entry("with other registries", func() []string { return []string{"quay.io", "docker.io"} }),
+	)
+
+	DescribeTable("should normalize the allowed Docker Hub registry", func(allowed string) {
+		fetcher.AllowedRegistries = func() []string { return []string{allowed} }
+		fetcher.Client = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("contacted %s", req.URL.Host)
+		})}
+		_, err := fetcher.ImageLabels("fedora", "amd64", nil)
+		Expect(err).To(MatchError(ContainSubstring("contacted registry-1.docker.io")))
+	},
+		Entry("docker.io", "docker.io"),
+		Entry("index.docker.io", "index.docker.io"),
+		Entry("registry-1.docker.io", "registry-1.docker.io"),
+	)
+
+	DescribeTable("should use the credentials of the image pull secret", func(secret *k8sv1.Secret) {
+		// The address of the registry is only known once the server runs
+		secret = secret.DeepCopy()
+		for key, data := range secret.Data {
+			secret.Data[key] = []byte(strings.ReplaceAll(string(data), "REGISTRY", strings.TrimPrefix(server.URL, "https://")))
+		}
+		_, err := fetcher.ImageLabels(image, "amd64", secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tokenUser).To(Equal("user"))
+	},
+		Entry("with dockerconfigjson", &k8sv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull-secret"},
+			Type:       k8sv1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				k8sv1.DockerConfigJsonKey: []byte(`{"auths":{"REGISTRY":{"auth":"` + base64.StdEncoding.EncodeToString([]byte("user:password")) + `"}}}`),
+			},
+		}),
+		Entry("with dockercfg", &k8sv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull-secret"},
+			Type:       k8sv1.SecretTypeDockercfg,
+			Data: map[string][]byte{
+				k8sv1.DockerConfigKey: []byte(`{"https://REGISTRY":{"username":"user","password":"password"}}`),
+			},
+		}),
+	)
+
+	DescribeTable("should not request a token from an untrusted realm", func(untrustedRealm func() string) {
+		realm = untrustedRealm()
+		_, err := fetcher.ImageLabels(image, "amd64", nil)
+		Expect(err).To(MatchError(ContainSubstring("returned the untrusted realm")))
+		Expect(tokenRequest).To(BeZero())
+	},
+		Entry("served without https", func() string { return strings.Replace(server.URL, "https://", "http://", 1) + "/token" }),
+		Entry("served by another host", func() string { return "https://auth.example.com/token" }),
+	)
+})
+
+var _ = Describe("CachedImageLabelsFetcher", func() {
+	var (
+		fetcher *countingImageLabelsFetcher
+		cached  *instancetype.CachedImageLabelsFetcher
+	)
+
+	BeforeEach(func() {
+		fetcher = &countingImageLabelsFetcher{labels: map[string]string{apiinstancetype.DefaultInstancetypeLabel: "instancetype"}}
+		cached = instancetype.NewCachedImageLabelsFetcher(fetcher)
+	})
+
+	It("should report the lookup as pending until the labels are fetched", func() {
+		_, err := cached.ImageLabels("image", "amd64", nil)
+		Expect(err).To(MatchError(instancetype.ErrImageLabelsPending))
+
+		Eventually(func() error {
+			_, err := cached.ImageLabels("image", "amd64", nil)
+			return err
+		}).Should(Succeed())
+		labels, err := cached.ImageLabels("image", "amd64", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(labels).To(HaveKeyWithValue(apiinstancetype.DefaultInstancetypeLabel, "instancetype"))
+		Expect(fetcher.calls()).To(Equal(1))
+	})
+
+	It("should cache failed lookups", func() {
+		fetcher.err = fmt.Errorf("registry unavailable")
+		Eventually(func() error {
+			_, err := cached.ImageLabels("image", "amd64", nil)
+			return err
+		}).Should(MatchError("registry unavailable"))
+		_, err := cached.ImageLabels("image", "amd64", nil)
+		Expect(err).To(MatchError("registry unavailable"))
+		Expect(fetcher.calls()).To(Equal(1))
+	})
+
+	It("should not share the labels of an image between pull secrets", func() {
+		secret := &k8sv1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pull-secret"}}
+		Eventually(func() error {
+			_, err := cached.ImageLabels("image", "amd64", secret)
+			return err
+		}).Should(Succeed())
+
+		_, err := cached.ImageLabels("image", "amd64", nil)
+		Expect(err).To(MatchError(instancetype.ErrImageLabelsPending))
+		Eventually(fetcher.calls).Should(Equal(2))
+	})
+})
+
+type countingImageLabelsFetcher struct {
+	lock   sync.Mutex
+	count  int
+	labels map[string]string
+	err    error
+}
+
+func (f *countingImageLabelsFetcher) ImageLabels(_, _ string, _ *k8sv1.Secret) (map[string]string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.count++
+	return f.labels, f.err
+}
+
+func (f *countingImageLabelsFetcher) calls() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.count
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
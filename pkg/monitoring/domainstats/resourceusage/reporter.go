@@ -0,0 +1,193 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package resourceusage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	k6tv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	vms "kubevirt.io/kubevirt/pkg/monitoring/domainstats"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+const (
+	// RefreshDuration is the interval in which the domain stats of pool VMIs are sampled
+	RefreshDuration = 15 * time.Second
+	// changeThreshold is the change in percentage points which leads to a status update
+	changeThreshold = 5
+	// maxReportAge is the time after which the utilization is reported again even if it did not change,
+	// so that consumers can tell a stable utilization from a stale one
+	maxReportAge = time.Minute
+)
+
+type sample struct {
+	stats     *stats.DomainStats
+	timestamp time.Time
+}
+
+// Scraper samples the domain stats of VMIs which belong to a VirtualMachinePool and reports
+// the utilization in between two samples in the VMI status.
+type Scraper struct {
+	virtClient kubecli.KubevirtClient
+
+	lock    sync.Mutex
+	samples map[types.UID]sample
+
+	getDomainStats func(socketFile string) (*stats.DomainStats, error)
+	now            func() time.Time
+}
+
+func NewScraper(virtClient kubecli.KubevirtClient) *Scraper {
+	return &Scraper{
+		virtClient:     virtClient,
+		samples:        map[types.UID]sample{},
+		getDomainStats: getDomainStats,
+		now:            time.Now,
+	}
+}
+
+func (s *Scraper) Scrape(socketFile string, vmi *k6tv1.VirtualMachineInstance) {
+	if !vmi.IsRunning() || !isPoolMember(vmi) {
+		return
+	}
+
+	cur, err := s.getDomainStats(socketFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).V(3).Info("failed to collect the domain stats")
+		return
+	}
+	now := s.now()
+
+	s.lock.Lock()
+	prev, exists := s.samples[vmi.UID]
+	s.samples[vmi.UID] = sample{stats: cur, timestamp: now}
+	s.lock.Unlock()
+	if !exists {
+		return
+	}
+
+	status := vms.ResourceUsage(prev.stats, cur, now.Sub(prev.timestamp))
+	status.LastSampleTime = metav1.NewTime(now)
+	if !needsUpdate(vmi.Status.ResourceUsage, status) {
+		return
+	}
+
+	vmiCopy := vmi.DeepCopy()
+	vmiCopy.Status.ResourceUsage = status
+	// Conflicts are not retried, the next sample is only a refresh period away
+	if _, err := s.virtClient.VirtualMachineInstance(vmi.Namespace).Update(context.Background(), vmiCopy); err != nil {
+		log.Log.Object(vmi).Reason(err).V(3).Info("failed to update the resource usage")
+	}
+}
+
+// prune forgets the samples of VMIs which are no longer handled by this node
+func (s *Scraper) prune(vmis []*k6tv1.VirtualMachineInstance) {
+	known := map[types.UID]struct{}{}
+	for _, vmi := range vmis {
+		known[vmi.UID] = struct{}{}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for uid := range s.samples {
+		if _, exists := known[uid]; !exists {
+			delete(s.samples, uid)
+		}
+	}
+}
+
+func isPoolMember(vmi *k6tv1.VirtualMachineInstance) bool {
+	_, exists := vmi.Labels[k6tv1.VirtualMachinePoolRevisionName]
+	return exists
+}
+
+func needsUpdate(old, cur *k6tv1.VirtualMachineInstanceResourceUsageStatus) bool {
+	if old == nil {
+		return true
+	}
+	if cur.LastSampleTime.Sub(old.LastSampleTime.Time) >= maxReportAge {
+		return true
+	}
+	return changed(old.CPUUtilizationPercentage, cur.CPUUtilizationPercentage) ||
+		changed(old.MemoryUtilizationPercentage, cur.MemoryUtilizationPercentage)
+}
+
+func changed(old, cur *int32) bool {
+	if old == nil || cur == nil {
+		return old != cur
+	}
+	diff := *cur - *old
+	return diff >= changeThreshold || diff <= -changeThreshold
+}
+
+func getDomainStats(socketFile string) (*stats.DomainStats, error) {
+	cli, err := cmdclient.NewClient(socketFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to cmd client socket: %v", err)
+	}
+	defer cli.Close()
+
+	domStats, exists, err := cli.GetDomainStats()
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("domain does not exist")
+	}
+	return domStats, nil
+}
+
+// RunResourceUsageCollector periodically reports the resource utilization of the pool VMIs on this node
+func RunResourceUsageCollector(context context.Context, virtClient kubecli.KubevirtClient, vmiInformer cache.SharedIndexInformer) {
+	scraper := NewScraper(virtClient)
+	collector := vms.NewConcurrentCollector(1)
+
+	go func() {
+		ticker := time.NewTicker(RefreshDuration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				vmis := []*k6tv1.VirtualMachineInstance{}
+				for _, obj := range vmiInformer.GetIndexer().List() {
+					vmis = append(vmis, obj.(*k6tv1.VirtualMachineInstance))
+				}
+				scraper.prune(vmis)
+				if len(vmis) == 0 {
+					continue
+				}
+				collector.Collect(vmis, scraper, vms.CollectionTimeout)
+			case <-context.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package resourceusage
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	k6tv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/api"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+var _ = Describe("Resource usage scraper", func() {
+	var (
+		vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+		scraper      *Scraper
+		vcpuTime     uint64
+		now          time.Time
+	)
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		virtClient.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+
+		vcpuTime = 0
+		now = time.Now()
+		scraper = NewScraper(virtClient)
+		scraper.getDomainStats = func(string) (*stats.DomainStats, error) {
+			return &stats.DomainStats{Vcpu: []stats.DomainStatsVcpu{{TimeSet: true, Time: vcpuTime}}}, nil
+		}
+		scraper.now = func() time.Time { return now }
+	})
+
+	newPoolVMI := func() *k6tv1.VirtualMachineInstance {
+		vmi := api.NewMinimalVMI("testvmi")
+		vmi.UID = "1234"
+		vmi.Labels = map[string]string{k6tv1.VirtualMachinePoolRevisionName: "revision"}
+		vmi.Status.Phase = k6tv1.Running
+		return vmi
+	}
+
+	// advance lets the vCPU run at the given utilization for ten seconds
+	advance := func(cpu uint64) {
+		vcpuTime += uint64(10*time.Second) * cpu / 100
+		now = now.Add(10 * time.Second)
+	}
+
+	expectUsage := func(cpu int32) {
+		vmiInterface.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, vmi *k6tv1.VirtualMachineInstance) (*k6tv1.VirtualMachineInstance, error) {
+			Expect(vmi.Status.ResourceUsage).ToNot(BeNil())
+			Expect(vmi.Status.ResourceUsage.CPUUtilizationPercentage).To(HaveValue(Equal(cpu)))
+			Expect(vmi.Status.ResourceUsage.LastSampleTime.Time).To(BeTemporally("==", now))
+			return vmi, nil
+		})
+	}
+
+	It("should report the utilization in between two samples", func() {
+		vmi := newPoolVMI()
+		scraper.Scrape("socket", vmi)
+
+		advance(40)
+		expectUsage(40)
+		scraper.Scrape("socket", vmi)
+	})
+
+	It("should ignore VMIs which do not belong to a pool", func() {
+		vmi := newPoolVMI()
+		vmi.Labels = nil
+		scraper.Scrape("socket", vmi)
+		advance(40)
+		scraper.Scrape("socket", vmi)
+	})
+
+	It("should only report changes above the threshold", func() {
+		vmi := newPoolVMI()
+		vmi.Status.ResourceUsage = &k6tv1.VirtualMachineInstanceResourceUsageStatus{
+			CPUUtilizationPercentage: pointer.Int32(40),
+			LastSampleTime:           metav1.NewTime(now),
+		}
+		scraper.Scrape("socket", vmi)
+
+		advance(42)
+		scraper.Scrape("socket", vmi)
+
+		advance(60)
+		expectUsage(60)
+		scraper.Scrape("socket", vmi)
+	})
+
+	It("should refresh an unchanged utilization after the maximum report age", func() {
+		vmi := newPoolVMI()
+		vmi.Status.ResourceUsage = &k6tv1.VirtualMachineInstanceResourceUsageStatus{
+			CPUUtilizationPercentage: pointer.Int32(40),
+			LastSampleTime:           metav1.NewTime(now.Add(-maxReportAge)),
+		}
+		scraper.Scrape("socket", vmi)
+
+		advance(40)
+		expectUsage(40)
+		scraper.Scrape("socket", vmi)
+	})
+
+	It("should forget the samples of VMIs which are gone", func() {
+		vmi := newPoolVMI()
+		scraper.Scrape("socket", vmi)
+		Expect(scraper.samples).To(HaveKey(vmi.UID))
+
+		scraper.prune(nil)
+		Expect(scraper.samples).To(BeEmpty())
+	})
+})
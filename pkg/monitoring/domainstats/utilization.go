@@ -0,0 +1,95 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vms
+
+import (
+	"time"
+
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+// ResourceUsage calculates the resource utilization of a domain from two
+// samples of its statistics taken elapsed apart.
+func ResourceUsage(prev, cur *stats.DomainStats, elapsed time.Duration) *k6tv1.VirtualMachineInstanceResourceUsageStatus {
+	usage := &k6tv1.VirtualMachineInstanceResourceUsageStatus{}
+	if cpu, ok := cpuUtilization(prev, cur, elapsed); ok {
+		usage.CPUUtilizationPercentage = &cpu
+	}
+	if memory, ok := memoryUtilization(cur); ok {
+		usage.MemoryUtilizationPercentage = &memory
+	}
+	return usage
+}
+
+// cpuUtilization is the time spent by all vCPUs in between the two samples,
+// relative to the time all vCPUs could have spent running.
+func cpuUtilization(prev, cur *stats.DomainStats, elapsed time.Duration) (int32, bool) {
+	if prev == nil || cur == nil || elapsed <= 0 {
+		return 0, false
+	}
+	prevTime, ok := vcpuTime(prev)
+	if !ok {
+		return 0, false
+	}
+	curTime, ok := vcpuTime(cur)
+	if !ok || curTime < prevTime || len(cur.Vcpu) == 0 {
+		return 0, false
+	}
+
+	capacity := uint64(elapsed.Nanoseconds()) * uint64(len(cur.Vcpu))
+	return percentage(curTime-prevTime, capacity), true
+}
+
+func vcpuTime(domStats *stats.DomainStats) (uint64, bool) {
+	if len(domStats.Vcpu) == 0 {
+		return 0, false
+	}
+	var total uint64
+	for _, vcpu := range domStats.Vcpu {
+		if !vcpu.TimeSet {
+			return 0, false
+		}
+		total += vcpu.Time
+	}
+	return total, true
+}
+
+// memoryUtilization is the memory used by the guest relative to the memory
+// available to it. Both are only reported when the guest runs a balloon driver.
+func memoryUtilization(domStats *stats.DomainStats) (int32, bool) {
+	if domStats == nil || domStats.Memory == nil {
+		return 0, false
+	}
+	memory := domStats.Memory
+	if !memory.AvailableSet || !memory.UsableSet || memory.Available == 0 || memory.Usable > memory.Available {
+		return 0, false
+	}
+	return percentage(memory.Available-memory.Usable, memory.Available), true
+}
+
+func percentage(value, total uint64) int32 {
+	result := value * 100 / total
+	if result > 100 {
+		result = 100
+	}
+	return int32(result)
+}
@@ -0,0 +1,92 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vms
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+var _ = Describe("ResourceUsage", func() {
+	newStats := func(vcpuTimes ...uint64) *stats.DomainStats {
+		domStats := &stats.DomainStats{}
+		for _, t := range vcpuTimes {
+			domStats.Vcpu = append(domStats.Vcpu, stats.DomainStatsVcpu{TimeSet: true, Time: t})
+		}
+		return domStats
+	}
+
+	It("should calculate the CPU utilization across all vCPUs", func() {
+		prev := newStats(1000, 2000)
+		cur := newStats(1000+uint64(time.Second), 2000+uint64(time.Second/2))
+
+		usage := ResourceUsage(prev, cur, time.Second)
+		Expect(usage.CPUUtilizationPercentage).ToNot(BeNil())
+		Expect(*usage.CPUUtilizationPercentage).To(Equal(int32(75)))
+		Expect(usage.MemoryUtilizationPercentage).To(BeNil())
+	})
+
+	It("should not report the CPU utilization if the vCPU times are missing", func() {
+		prev := newStats(1000)
+		cur := newStats(2000)
+		cur.Vcpu[0].TimeSet = false
+
+		usage := ResourceUsage(prev, cur, time.Second)
+		Expect(usage.CPUUtilizationPercentage).To(BeNil())
+	})
+
+	It("should not report the CPU utilization if the counters went backwards", func() {
+		usage := ResourceUsage(newStats(2000), newStats(1000), time.Second)
+		Expect(usage.CPUUtilizationPercentage).To(BeNil())
+	})
+
+	It("should cap the CPU utilization at 100 percent", func() {
+		usage := ResourceUsage(newStats(0), newStats(uint64(2*time.Second)), time.Second)
+		Expect(*usage.CPUUtilizationPercentage).To(Equal(int32(100)))
+	})
+
+	DescribeTable("should calculate the memory utilization", func(memory *stats.DomainStatsMemory, expected *int32) {
+		cur := newStats(1000)
+		cur.Memory = memory
+
+		usage := ResourceUsage(newStats(0), cur, time.Second)
+		if expected == nil {
+			Expect(usage.MemoryUtilizationPercentage).To(BeNil())
+		} else {
+			Expect(usage.MemoryUtilizationPercentage).To(Equal(expected))
+		}
+	},
+		Entry("with usable and available memory reported",
+			&stats.DomainStatsMemory{AvailableSet: true, Available: 4096, UsableSet: true, Usable: 1024}, int32Ptr(75)),
+		Entry("without usable memory reported",
+			&stats.DomainStatsMemory{AvailableSet: true, Available: 4096}, nil),
+		Entry("without available memory reported",
+			&stats.DomainStatsMemory{UsableSet: true, Usable: 1024}, nil),
+		Entry("without memory stats", nil, nil),
+	)
+})
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
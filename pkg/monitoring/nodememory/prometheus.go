@@ -0,0 +1,113 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package nodememory
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	nodeLabel   = "node"
+	policyLabel = "policy"
+
+	memoryPolicyInfo           = "memoryPolicyInfo"
+	ksmRunning                 = "ksmRunning"
+	memoryOvercommitPercent    = "memoryOvercommitPercent"
+	maxMemoryOvercommitPercent = "maxMemoryOvercommitPercent"
+)
+
+var (
+	metrics = map[string]prometheus.Opts{
+		memoryPolicyInfo: {
+			Name: "kubevirt_node_memory_policy_info",
+			Help: "The NodeMemoryPolicy applied on the node.",
+		},
+		ksmRunning: {
+			Name: "kubevirt_node_ksm_running",
+			Help: "Indicates whether KSM is running on the node.",
+		},
+		memoryOvercommitPercent: {
+			Name: "kubevirt_node_memory_overcommit_percent",
+			Help: "The guest memory of the VirtualMachineInstances on the node in percent of the allocatable memory of the node.",
+		},
+		maxMemoryOvercommitPercent: {
+			Name: "kubevirt_node_memory_overcommit_max_percent",
+			Help: "The maximum memory overcommit of the NodeMemoryPolicy applied on the node.",
+		},
+	}
+
+	memoryPolicyInfoVec           = newGaugeVec(memoryPolicyInfo, nodeLabel, policyLabel)
+	ksmRunningVec                 = newGaugeVec(ksmRunning, nodeLabel)
+	memoryOvercommitPercentVec    = newGaugeVec(memoryOvercommitPercent, nodeLabel)
+	maxMemoryOvercommitPercentVec = newGaugeVec(maxMemoryOvercommitPercent, nodeLabel)
+)
+
+func init() {
+	prometheus.MustRegister(
+		memoryPolicyInfoVec,
+		ksmRunningVec,
+		memoryOvercommitPercentVec,
+		maxMemoryOvercommitPercentVec,
+	)
+}
+
+func newGaugeVec(metric string, labels ...string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: metrics[metric].Name,
+			Help: metrics[metric].Help,
+		},
+		labels,
+	)
+}
+
+// SetMemoryPolicy reports the name of the NodeMemoryPolicy applied on the node,
+// an empty name removes the metric
+func SetMemoryPolicy(node string, policy string) {
+	memoryPolicyInfoVec.DeletePartialMatch(prometheus.Labels{nodeLabel: node})
+	if policy != "" {
+		memoryPolicyInfoVec.WithLabelValues(node, policy).Set(1)
+	}
+}
+
+func SetKSMRunning(node string, running bool) {
+	ksmRunningVec.WithLabelValues(node).Set(boolToFloat64(running))
+}
+
+func SetMemoryOvercommitPercent(node string, percent float64) {
+	memoryOvercommitPercentVec.WithLabelValues(node).Set(percent)
+}
+
+// SetMaxMemoryOvercommitPercent reports the configured maximum memory overcommit,
+// nil removes the metric
+func SetMaxMemoryOvercommitPercent(node string, percent *int32) {
+	if percent == nil {
+		maxMemoryOvercommitPercentVec.DeleteLabelValues(node)
+		return
+	}
+	maxMemoryOvercommitPercentVec.WithLabelValues(node).Set(float64(*percent))
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -5,6 +5,7 @@ import "github.com/machadovilaca/operator-observability/pkg/operatormetrics"
 var (
 	metrics = [][]operatormetrics.Metric{
 		operatorMetrics,
+		snapshotScheduleMetrics,
 	}
 )
 
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+)
+
+const (
+	SnapshotScheduleRunSucceeded = "succeeded"
+	SnapshotScheduleRunFailed    = "failed"
+)
+
+var (
+	snapshotScheduleMetrics = []operatormetrics.Metric{
+		snapshotScheduleRuns,
+		snapshotScheduleLastRunFailed,
+	}
+
+	snapshotScheduleRuns = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmsnapshot_schedule_runs_total",
+			Help: "The total number of completed VirtualMachineSnapshotSchedule runs, broken down by result.",
+		},
+		[]string{"namespace", "name", "result"},
+	)
+
+	snapshotScheduleLastRunFailed = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmsnapshot_schedule_last_run_failed",
+			Help: "Indication for a VirtualMachineSnapshotSchedule whose last completed run had at least one failed snapshot.",
+		},
+		[]string{"namespace", "name"},
+	)
+)
+
+func ReportSnapshotScheduleRun(namespace, name, result string) {
+	snapshotScheduleRuns.WithLabelValues(namespace, name, result).Inc()
+	if result == SnapshotScheduleRunFailed {
+		snapshotScheduleLastRunFailed.WithLabelValues(namespace, name).Set(1)
+	} else {
+		snapshotScheduleLastRunFailed.WithLabelValues(namespace, name).Set(0)
+	}
+}
+
+func DeleteSnapshotScheduleMetrics(namespace, name string) {
+	snapshotScheduleRuns.DeleteLabelValues(namespace, name, SnapshotScheduleRunSucceeded)
+	snapshotScheduleRuns.DeleteLabelValues(namespace, name, SnapshotScheduleRunFailed)
+	snapshotScheduleLastRunFailed.DeleteLabelValues(namespace, name)
+}
@@ -43,6 +43,8 @@ const (
 	errorSearchDomainNotValid = "Search domain is not valid"
 	errorSearchDomainTooLong  = "Search domains length exceeded allowable size"
 	errorNTPConfiguration     = "Could not parse NTP server as IPv4 address: %s"
+	errorDNSConfiguration     = "Could not parse DNS server as IP address: %s"
+	errorRouteConfiguration   = "Could not parse static route to %s via %s"
 )
 
 // simple domain validation regex. Put it here to avoid compiling each time.
@@ -110,6 +112,32 @@ func prepareDHCPOptions(
 	hostname string,
 	customDHCPOptions *v1.DHCPOptions) (dhcp.Options, error) {
 
+	if customDHCPOptions != nil {
+		customDNSIPs, err := customDNSServers(customDHCPOptions.DNSServers)
+		if err != nil {
+			return nil, err
+		}
+		if len(customDNSIPs) > 0 {
+			log.Log.Infof("Setting dhcp option DNS servers to %s", customDHCPOptions.DNSServers)
+			dnsIPs = customDNSIPs
+		}
+		if len(customDHCPOptions.SearchDomains) > 0 {
+			log.Log.Infof("Setting dhcp option search domains to %s", customDHCPOptions.SearchDomains)
+			searchDomains = customDHCPOptions.SearchDomains
+		}
+		if len(customDHCPOptions.Routes) > 0 {
+			customRoutes, err := customStaticRoutes(customDHCPOptions.Routes, routes, routerIP)
+			if err != nil {
+				return nil, err
+			}
+			routes = &customRoutes
+		}
+		if customDHCPOptions.MTU > 0 && customDHCPOptions.MTU < int32(mtu) {
+			log.Log.Infof("Setting dhcp option MTU to %d", customDHCPOptions.MTU)
+			mtu = uint16(customDHCPOptions.MTU)
+		}
+	}
+
 	mtuArray := make([]byte, 2)
 	binary.BigEndian.PutUint16(mtuArray, mtu)
 
@@ -143,6 +171,9 @@ func prepareDHCPOptions(
 
 	// Windows will ask for the domain name and use it for DNS resolution
 	domainName := dns.GetDomainName(searchDomains)
+	if customDHCPOptions != nil && customDHCPOptions.DomainName != "" {
+		domainName = customDHCPOptions.DomainName
+	}
 	if len(domainName) > 0 {
 		dhcpOptions[dhcp.OptionDomainName] = []byte(domainName)
 	}
@@ -186,6 +217,58 @@ func prepareDHCPOptions(
 	return dhcpOptions, nil
 }
 
+// customDNSServers returns the IPv4 addresses of the DNS servers, IPv6 servers are passed by the DHCPv6 server
+func customDNSServers(servers []string) ([][]byte, error) {
+	var dnsIPs [][]byte
+	for _, server := range servers {
+		ip := net.ParseIP(server)
+		if ip == nil {
+			return nil, fmt.Errorf(errorDNSConfiguration, server)
+		}
+		if ip.To4() != nil {
+			dnsIPs = append(dnsIPs, ip.To4())
+		}
+	}
+	return dnsIPs, nil
+}
+
+// customStaticRoutes adds the static routes to the routes of the pod network. Clients which
+// support option 121 ignore the router option, so when there is no default route yet, a
+// default route via the router is added as well.
+func customStaticRoutes(customRoutes []v1.DHCPRoute, routes *[]netlink.Route, routerIP net.IP) ([]netlink.Route, error) {
+	var staticRoutes []netlink.Route
+	if routes != nil {
+		staticRoutes = append(staticRoutes, *routes...)
+	}
+
+	for _, customRoute := range customRoutes {
+		_, dst, err := net.ParseCIDR(customRoute.Destination)
+		gateway := net.ParseIP(customRoute.Gateway).To4()
+		if err != nil || dst.IP.To4() == nil || gateway == nil {
+			return nil, fmt.Errorf(errorRouteConfiguration, customRoute.Destination, customRoute.Gateway)
+		}
+		route := netlink.Route{Dst: dst, Gw: gateway}
+		if ones, _ := dst.Mask.Size(); ones == 0 {
+			route.Dst = nil
+		}
+		staticRoutes = append(staticRoutes, route)
+	}
+
+	if len(routerIP) != 0 && !hasDefaultRoute(staticRoutes) {
+		staticRoutes = append(staticRoutes, netlink.Route{Gw: routerIP})
+	}
+	return staticRoutes, nil
+}
+
+func hasDefaultRoute(routes []netlink.Route) bool {
+	for _, route := range routes {
+		if route.Dst == nil {
+			return true
+		}
+	}
+	return false
+}
+
 type DHCPHandler struct {
 	serverIP      net.IP
 	clientIP      net.IP
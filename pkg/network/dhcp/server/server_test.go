@@ -212,6 +212,86 @@ var _ = Describe("DHCP Server", func() {
 			Expect(options[240]).To(Equal([]byte("private.options.kubevirt.io")))
 		})
 
+		It("should replace the DNS servers, search domains and domain name", func() {
+			ip := net.ParseIP("192.168.2.1")
+			dnsIPs := [][]byte{{10, 96, 0, 10}}
+			searchDomains := []string{"default.svc.cluster.local", "svc.cluster.local", "cluster.local"}
+
+			dhcpOptions := &v1.DHCPOptions{
+				DNSServers:    []string{"192.168.2.53", "fd10::53"},
+				SearchDomains: []string{"example.com"},
+				DomainName:    "lab.example.com",
+			}
+
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, dnsIPs, nil, searchDomains, 1500, "myhost", dhcpOptions)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionDomainNameServer]).To(Equal([]byte{192, 168, 2, 53}))
+			Expect(options[dhcp4.OptionDomainSearch]).To(Equal([]byte{7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0}))
+			Expect(options[dhcp4.OptionDomainName]).To(Equal([]byte("lab.example.com")))
+		})
+
+		It("should add the static routes and a default route via the router", func() {
+			ip := net.ParseIP("10.0.2.1")
+
+			dhcpOptions := &v1.DHCPOptions{
+				Routes: []v1.DHCPRoute{
+					{Destination: "192.168.100.0/24", Gateway: "10.0.2.254"},
+					{Destination: "172.16.0.0/12", Gateway: "10.0.2.253"},
+				},
+			}
+
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, nil, 1500, "myhost", dhcpOptions)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionClasslessRouteFormat]).To(Equal([]byte{
+				24, 192, 168, 100, 10, 0, 2, 254,
+				12, 172, 16, 10, 0, 2, 253,
+				0, 10, 0, 2, 1,
+			}))
+		})
+
+		It("should keep the default route of the pod network last", func() {
+			ip := net.ParseIP("10.0.2.1")
+			routes := []netlink.Route{{Gw: net.ParseIP("10.0.2.2")}}
+
+			dhcpOptions := &v1.DHCPOptions{
+				Routes: []v1.DHCPRoute{{Destination: "192.168.100.0/24", Gateway: "10.0.2.254"}},
+			}
+
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, &routes, nil, 1500, "myhost", dhcpOptions)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionClasslessRouteFormat]).To(Equal([]byte{
+				24, 192, 168, 100, 10, 0, 2, 254,
+				0, 10, 0, 2, 2,
+			}))
+		})
+
+		DescribeTable("should pass the MTU", func(customMTU int32, expectedMTU []byte) {
+			ip := net.ParseIP("10.0.2.1")
+
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, nil, 1500, "myhost", &v1.DHCPOptions{MTU: customMTU})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionInterfaceMTU]).To(Equal(expectedMTU))
+		},
+			Entry("of the pod network by default", int32(0), []byte{0x05, 0xdc}),
+			Entry("which is requested", int32(1400), []byte{0x05, 0x78}),
+			Entry("of the pod network if the requested one is larger", int32(9000), []byte{0x05, 0xdc}),
+		)
+
+		It("should reject IPv6 static routes", func() {
+			ip := net.ParseIP("10.0.2.1")
+
+			dhcpOptions := &v1.DHCPOptions{
+				Routes: []v1.DHCPRoute{{Destination: "fd10::/64", Gateway: "fd00::1"}},
+			}
+
+			_, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, nil, 1500, "myhost", dhcpOptions)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("expects the gateway as an IPv4 addresses", func() {
 			gw := net.ParseIP("192.168.2.1")
 			options, err := prepareDHCPOptions(gw.DefaultMask(), gw, nil, nil, nil, 1500, "myhost", nil)
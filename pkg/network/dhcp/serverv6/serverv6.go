@@ -28,6 +28,7 @@ import (
 	"github.com/insomniacslk/dhcp/dhcpv6/server6"
 	"github.com/insomniacslk/dhcp/iana"
 
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 )
 
@@ -40,7 +41,7 @@ type DHCPv6Handler struct {
 	modifiers []dhcpv6.Modifier
 }
 
-func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
+func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string, customDHCPOptions *v1.DHCPOptions) error {
 	log.Log.Info("Starting SingleClientDHCPv6Server")
 
 	iface, err := net.InterfaceByName(serverIfaceName)
@@ -48,7 +49,7 @@ func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
 		return fmt.Errorf("couldn't create DHCPv6 server, couldn't get the dhcp6 server interface: %v", err)
 	}
 
-	modifiers := prepareDHCPv6Modifiers(clientIP, iface.HardwareAddr)
+	modifiers := prepareDHCPv6Modifiers(clientIP, iface.HardwareAddr, customDHCPOptions)
 
 	handler := &DHCPv6Handler{
 		clientIP:  clientIP,
@@ -121,9 +122,34 @@ func (h *DHCPv6Handler) buildResponse(msg dhcpv6.DHCPv6) (*dhcpv6.Message, error
 	return response, nil
 }
 
-func prepareDHCPv6Modifiers(clientIP net.IP, serverInterfaceMac net.HardwareAddr) []dhcpv6.Modifier {
+func prepareDHCPv6Modifiers(clientIP net.IP, serverInterfaceMac net.HardwareAddr, customDHCPOptions *v1.DHCPOptions) []dhcpv6.Modifier {
 	optIAAddress := dhcpv6.OptIAAddress{IPv6Addr: clientIP, PreferredLifetime: infiniteLease, ValidLifetime: infiniteLease}
 	duid := &dhcpv6.DUIDLL{HWType: iana.HWTypeEthernet, LinkLayerAddr: serverInterfaceMac}
 
-	return []dhcpv6.Modifier{dhcpv6.WithIANA(optIAAddress), dhcpv6.WithServerID(duid)}
+	modifiers := []dhcpv6.Modifier{dhcpv6.WithIANA(optIAAddress), dhcpv6.WithServerID(duid)}
+
+	if customDHCPOptions != nil {
+		if dnsIPs := ipv6DNSServers(customDHCPOptions.DNSServers); len(dnsIPs) > 0 {
+			log.Log.Infof("Setting dhcpv6 option DNS servers to %s", dnsIPs)
+			modifiers = append(modifiers, dhcpv6.WithDNS(dnsIPs...))
+		}
+		if len(customDHCPOptions.SearchDomains) > 0 {
+			log.Log.Infof("Setting dhcpv6 option search domains to %s", customDHCPOptions.SearchDomains)
+			modifiers = append(modifiers, dhcpv6.WithDomainSearchList(customDHCPOptions.SearchDomains...))
+		}
+	}
+
+	return modifiers
+}
+
+// ipv6DNSServers returns the IPv6 addresses of the DNS servers, IPv4 servers are passed by the DHCP server
+func ipv6DNSServers(servers []string) []net.IP {
+	var dnsIPs []net.IP
+	for _, server := range servers {
+		ip := net.ParseIP(server)
+		if ip != nil && ip.To4() == nil {
+			dnsIPs = append(dnsIPs, ip)
+		}
+	}
+	return dnsIPs
 }
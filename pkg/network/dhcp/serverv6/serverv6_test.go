@@ -27,6 +27,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
 )
 
 var _ = Describe("DHCPv6", func() {
@@ -34,7 +36,7 @@ var _ = Describe("DHCPv6", func() {
 		It("should contain ianaAdrress and duid", func() {
 			clientIP := net.ParseIP("fd10:0:2::2")
 			serverInterfaceMac, _ := net.ParseMAC("12:34:56:78:9A:BC")
-			modifiers := prepareDHCPv6Modifiers(clientIP, serverInterfaceMac)
+			modifiers := prepareDHCPv6Modifiers(clientIP, serverInterfaceMac, nil)
 			Expect(modifiers).To(HaveLen(2))
 
 			msg := &dhcpv6.Message{
@@ -52,6 +54,25 @@ var _ = Describe("DHCPv6", func() {
 			modifiers[1](msg)
 			Expect(msg.GetOneOption(dhcpv6.OptionServerID).String()).To(Equal(expectedServerId.String()))
 		})
+		It("should contain the IPv6 DNS servers and the search domains", func() {
+			clientIP := net.ParseIP("fd10:0:2::2")
+			serverInterfaceMac, _ := net.ParseMAC("12:34:56:78:9A:BC")
+			dhcpOptions := &v1.DHCPOptions{
+				DNSServers:    []string{"192.168.2.53", "fd10::53"},
+				SearchDomains: []string{"example.com"},
+			}
+			modifiers := prepareDHCPv6Modifiers(clientIP, serverInterfaceMac, dhcpOptions)
+			Expect(modifiers).To(HaveLen(4))
+
+			msg := &dhcpv6.Message{
+				MessageType: dhcpv6.MessageTypeAdvertise,
+			}
+			for _, modifier := range modifiers {
+				modifier(msg)
+			}
+			Expect(msg.Options.DNS()).To(Equal([]net.IP{net.ParseIP("fd10::53")}))
+			Expect(msg.Options.DomainSearchList().Labels).To(Equal([]string{"example.com"}))
+		})
 	})
 	Context("buildResponse should build a response with", func() {
 		var handler *DHCPv6Handler
@@ -59,7 +80,7 @@ var _ = Describe("DHCPv6", func() {
 		BeforeEach(func() {
 			clientIP := net.ParseIP("fd10:0:2::2")
 			serverInterfaceMac, _ := net.ParseMAC("12:34:56:78:9A:BC")
-			modifiers := prepareDHCPv6Modifiers(clientIP, serverInterfaceMac)
+			modifiers := prepareDHCPv6Modifiers(clientIP, serverInterfaceMac, nil)
 
 			handler = &DHCPv6Handler{
 				clientIP:  clientIP,
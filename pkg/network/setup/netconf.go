@@ -38,7 +38,9 @@ import (
 	"kubevirt.io/kubevirt/pkg/network/istio"
 	"kubevirt.io/kubevirt/pkg/network/netns"
 	"kubevirt.io/kubevirt/pkg/network/setup/netpod"
+	"kubevirt.io/kubevirt/pkg/network/setup/netpod/firewall"
 	"kubevirt.io/kubevirt/pkg/network/setup/netpod/masquerade"
+	"kubevirt.io/kubevirt/pkg/network/setup/netpod/qos"
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
 )
 
@@ -51,6 +53,7 @@ type NetConf struct {
 	nsFactory        nsFactory
 	state            map[string]*netpod.State
 	configStateMutex *sync.RWMutex
+	qosTrafficMark   func() uint32
 }
 
 type nsFactory func(int) NSExecutor
@@ -59,11 +62,15 @@ type NSExecutor interface {
 	Do(func() error) error
 }
 
-func NewNetConf() *NetConf {
+// NewNetConf returns a NetConf, qosTrafficMark is consulted on every setup for the mark
+// of the guest traffic whose bandwidth is shaped.
+func NewNetConf(qosTrafficMark func() uint32) *NetConf {
 	var cacheFactory cache.CacheCreator
-	return NewNetConfWithCustomFactoryAndConfigState(func(pid int) NSExecutor {
+	netConf := NewNetConfWithCustomFactoryAndConfigState(func(pid int) NSExecutor {
 		return netns.New(pid)
 	}, cacheFactory, map[string]*netpod.State{})
+	netConf.qosTrafficMark = qosTrafficMark
+	return netConf
 }
 
 func NewNetConfWithCustomFactoryAndConfigState(nsFactory nsFactory, cacheCreator cacheCreator, state map[string]*netpod.State) *NetConf {
@@ -102,6 +109,10 @@ func (c *NetConf) Setup(vmi *v1.VirtualMachineInstance, networks []v1.Network, l
 		ownerID = util.NonRootUID
 	}
 	queuesCapacity := int(converter.NetworkQueuesCapacity(vmi))
+	podQoS := qos.New()
+	if c.qosTrafficMark != nil {
+		podQoS = qos.New(qos.WithGuestTrafficMark(c.qosTrafficMark()))
+	}
 	netpod := netpod.NewNetPod(
 		networks,
 		vmispec.FilterInterfacesByNetworks(vmi.Spec.Domain.Devices.Interfaces, networks),
@@ -111,6 +122,8 @@ func (c *NetConf) Setup(vmi *v1.VirtualMachineInstance, networks []v1.Network, l
 		queuesCapacity,
 		state,
 		netpod.WithMasqueradeAdapter(newMasqueradeAdapter(vmi)),
+		netpod.WithFirewallAdapter(newFirewallAdapter(vmi)),
+		netpod.WithQoSAdapter(podQoS),
 		netpod.WithCacheCreator(c.cacheCreator),
 	)
 
@@ -167,3 +180,10 @@ func newMasqueradeAdapter(vmi *v1.VirtualMachineInstance) masquerade.MasqPod {
 		)
 	}
 }
+
+func newFirewallAdapter(vmi *v1.VirtualMachineInstance) firewall.PodFirewall {
+	if vmi.Status.MigrationTransport == v1.MigrationTransportUnix {
+		return firewall.New()
+	}
+	return firewall.New(firewall.WithLegacyMigrationPorts())
+}
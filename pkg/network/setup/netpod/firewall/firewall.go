@@ -0,0 +1,257 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package firewall
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/driver/nft"
+	"kubevirt.io/kubevirt/pkg/network/driver/nmstate"
+)
+
+type nftable interface {
+	AddTable(family nft.IPFamily, name string) error
+	AddChain(family nft.IPFamily, table, name string, chainspec ...string) error
+	AddRule(family nft.IPFamily, table, chain string, rulespec ...string) error
+}
+
+// PodFirewall applies the firewall rules of the VMI interfaces in the pod network namespace.
+type PodFirewall struct {
+	nftable        nftable
+	migrationPorts []int
+}
+
+const (
+	filterTable = "filter"
+
+	forwardChain         = "forward"
+	inputChain           = "input"
+	outputChain          = "output"
+	kubevirtIngressChain = "KUBEVIRT_INGRESS"
+	kubevirtEgressChain  = "KUBEVIRT_EGRESS"
+)
+
+type option func(*PodFirewall)
+
+func New(opts ...option) PodFirewall {
+	f := PodFirewall{nftable: nft.NFTBin{}}
+	for _, opt := range opts {
+		opt(&f)
+	}
+	return f
+}
+
+func WithNftableAdapter(h nftable) option {
+	return func(f *PodFirewall) {
+		f.nftable = h
+	}
+}
+
+// WithLegacyMigrationPorts is used for legacy setups where migration ports are in use.
+// When set, the ingress rules are not applied to the reserved migration ports.
+func WithLegacyMigrationPorts() option {
+	const LibvirtDirectMigrationPort = 49152
+	const LibvirtBlockMigrationPort = 49153
+	return func(f *PodFirewall) {
+		f.migrationPorts = []int{LibvirtDirectMigrationPort, LibvirtBlockMigrationPort}
+	}
+}
+
+// SetupForwarded filters the traffic which is forwarded between the pod interface and the
+// bridge of the guest, as done by the masquerade binding.
+func (f PodFirewall) SetupForwarded(podIfaceSpec, bridgeIfaceSpec *nmstate.Interface, vmiIface v1.Interface) error {
+	for _, family := range enabledFamilies(bridgeIfaceSpec) {
+		if err := f.setupChains(family, vmiIface, forwardChain); err != nil {
+			return err
+		}
+		if err := f.nftable.AddRule(family, filterTable, forwardChain, "iifname", podIfaceSpec.Name, "oifname", bridgeIfaceSpec.Name, "counter", "jump", kubevirtIngressChain); err != nil {
+			return err
+		}
+		if err := f.nftable.AddRule(family, filterTable, forwardChain, "iifname", bridgeIfaceSpec.Name, "oifname", podIfaceSpec.Name, "counter", "jump", kubevirtEgressChain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetupLocal filters the traffic of the pod interface which is terminated in the pod,
+// as done by the passt binding. The pod shares the interface with the guest, the ingress
+// rules are therefore only applied to the ports passt forwards to the guest. Connections
+// to the other ports, like the ones of sidecars or probes, are not filtered.
+func (f PodFirewall) SetupLocal(podIfaceSpec *nmstate.Interface, vmiIface v1.Interface) error {
+	tcpPorts, udpPorts := forwardedPorts(vmiIface)
+	for _, family := range enabledFamilies(podIfaceSpec) {
+		if err := f.setupChains(family, vmiIface, inputChain, outputChain); err != nil {
+			return err
+		}
+		if len(f.migrationPorts) > 0 {
+			portsSpec := fmt.Sprintf("{ %s }", strings.Join(formatPorts(f.migrationPorts), ", "))
+			if err := f.nftable.AddRule(family, filterTable, inputChain, "tcp", "dport", portsSpec, "counter", "accept"); err != nil {
+				return err
+			}
+		}
+		if err := f.jumpToIngress(family, podIfaceSpec.Name, "tcp", tcpPorts); err != nil {
+			return err
+		}
+		if err := f.jumpToIngress(family, podIfaceSpec.Name, "udp", udpPorts); err != nil {
+			return err
+		}
+		if err := f.nftable.AddRule(family, filterTable, outputChain, "oifname", podIfaceSpec.Name, "counter", "jump", kubevirtEgressChain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f PodFirewall) jumpToIngress(family nft.IPFamily, podIfaceName, protocol string, ports []int) error {
+	if len(ports) == 0 {
+		return nil
+	}
+	portsSpec := fmt.Sprintf("{ %s }", strings.Join(formatPorts(ports), ", "))
+	return f.nftable.AddRule(family, filterTable, inputChain, "iifname", podIfaceName, protocol, "dport", portsSpec, "counter", "jump", kubevirtIngressChain)
+}
+
+// setupChains creates the hooked chains, which accept the replies to allowed traffic,
+// and the chains with the ingress and egress rules of the interface.
+func (f PodFirewall) setupChains(family nft.IPFamily, vmiIface v1.Interface, hooks ...string) error {
+	if err := f.nftable.AddTable(family, filterTable); err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		chainspec := fmt.Sprintf("{ type filter hook %s priority 0; }", hook)
+		if err := f.nftable.AddChain(family, filterTable, hook, chainspec); err != nil {
+			return err
+		}
+		if err := f.nftable.AddRule(family, filterTable, hook, "ct", "state", "established,related", "counter", "accept"); err != nil {
+			return err
+		}
+	}
+	if err := f.nftable.AddChain(family, filterTable, kubevirtIngressChain); err != nil {
+		return err
+	}
+	if err := f.nftable.AddChain(family, filterTable, kubevirtEgressChain); err != nil {
+		return err
+	}
+
+	if vmiIface.Firewall == nil {
+		return nil
+	}
+	if err := f.addRules(family, kubevirtIngressChain, "saddr", vmiIface.Firewall.Ingress); err != nil {
+		return err
+	}
+	return f.addRules(family, kubevirtEgressChain, "daddr", vmiIface.Firewall.Egress)
+}
+
+func (f PodFirewall) addRules(family nft.IPFamily, chain, remoteAddr string, rules []v1.FirewallRule) error {
+	for _, rule := range rules {
+		rulespec, err := ruleSpec(family, remoteAddr, rule)
+		if err != nil {
+			return err
+		}
+		if rulespec == nil {
+			continue
+		}
+		if err := f.nftable.AddRule(family, filterTable, chain, rulespec...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ruleSpec translates the rule to nft, rules with a CIDR of the other IP family are skipped.
+func ruleSpec(family nft.IPFamily, remoteAddr string, rule v1.FirewallRule) ([]string, error) {
+	var rulespec []string
+
+	if rule.CIDR != "" {
+		ip, _, err := net.ParseCIDR(rule.CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("invalid firewall rule CIDR %s: %v", rule.CIDR, err)
+		}
+		if (ip.To4() != nil) != (family == nft.IPv4) {
+			return nil, nil
+		}
+		rulespec = append(rulespec, string(family), remoteAddr, rule.CIDR)
+	}
+
+	protocol := strings.ToLower(rule.Protocol)
+	switch {
+	case protocol == "icmp":
+		if family == nft.IPv6 {
+			protocol = "ipv6-icmp"
+		}
+		rulespec = append(rulespec, "meta", "l4proto", protocol)
+	case protocol != "" && rule.Port != 0:
+		ports := strconv.Itoa(int(rule.Port))
+		if rule.EndPort > rule.Port {
+			ports = fmt.Sprintf("%d-%d", rule.Port, rule.EndPort)
+		}
+		rulespec = append(rulespec, protocol, "dport", ports)
+	case protocol != "":
+		rulespec = append(rulespec, "meta", "l4proto", protocol)
+	}
+
+	rulespec = append(rulespec, "counter")
+	switch rule.Action {
+	case v1.FirewallActionAllow:
+		rulespec = append(rulespec, "accept")
+	case v1.FirewallActionDeny:
+		rulespec = append(rulespec, "drop")
+	default:
+		return nil, fmt.Errorf("invalid firewall rule action %q", rule.Action)
+	}
+	return rulespec, nil
+}
+
+// forwardedPorts returns the TCP and UDP ports passt forwards to the guest
+func forwardedPorts(vmiIface v1.Interface) (tcpPorts, udpPorts []int) {
+	for _, port := range vmiIface.Ports {
+		switch strings.ToLower(port.Protocol) {
+		case "", "tcp":
+			tcpPorts = append(tcpPorts, int(port.Port))
+		case "udp":
+			udpPorts = append(udpPorts, int(port.Port))
+		}
+	}
+	return tcpPorts, udpPorts
+}
+
+func enabledFamilies(ifaceSpec *nmstate.Interface) []nft.IPFamily {
+	var families []nft.IPFamily
+	if ifaceSpec.IPv4.Enabled != nil && *ifaceSpec.IPv4.Enabled {
+		families = append(families, nft.IPv4)
+	}
+	if ifaceSpec.IPv6.Enabled != nil && *ifaceSpec.IPv6.Enabled {
+		families = append(families, nft.IPv6)
+	}
+	return families
+}
+
+func formatPorts(ports []int) []string {
+	var formattedPorts []string
+	for _, p := range ports {
+		formattedPorts = append(formattedPorts, strconv.Itoa(p))
+	}
+	return formattedPorts
+}
@@ -0,0 +1,248 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package firewall_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/driver/nft"
+	"kubevirt.io/kubevirt/pkg/network/driver/nmstate"
+	"kubevirt.io/kubevirt/pkg/network/setup/netpod/firewall"
+	"kubevirt.io/kubevirt/pkg/pointer"
+)
+
+var _ = Describe("pod firewall", func() {
+	var (
+		podIfaceSpec    *nmstate.Interface
+		bridgeIfaceSpec *nmstate.Interface
+		vmiIface        v1.Interface
+	)
+
+	BeforeEach(func() {
+		podIfaceSpec = &nmstate.Interface{
+			Name: "eth0",
+			IPv4: nmstate.IP{
+				Enabled: pointer.P(true),
+				Address: []nmstate.IPAddress{{IP: "10.222.222.1", PrefixLen: 30}},
+			},
+		}
+		bridgeIfaceSpec = &nmstate.Interface{
+			Name: "k6t-eth0",
+			IPv4: nmstate.IP{
+				Enabled: pointer.P(true),
+				Address: []nmstate.IPAddress{{IP: "10.0.2.1", PrefixLen: 24}},
+			},
+			IPv6: nmstate.IP{Enabled: pointer.P(false)},
+		}
+		vmiIface = v1.Interface{
+			Name: "default",
+			Firewall: &v1.InterfaceFirewall{
+				Ingress: []v1.FirewallRule{
+					{Action: v1.FirewallActionAllow, CIDR: "10.10.0.0/16", Protocol: "TCP", Port: 22},
+					{Action: v1.FirewallActionAllow, Protocol: "TCP", Port: 8000, EndPort: 8080},
+					{Action: v1.FirewallActionAllow, CIDR: "fd10::/64"},
+					{Action: v1.FirewallActionDeny},
+				},
+				Egress: []v1.FirewallRule{
+					{Action: v1.FirewallActionDeny, CIDR: "169.254.169.254/32"},
+					{Action: v1.FirewallActionDeny, Protocol: "ICMP"},
+				},
+			},
+		}
+	})
+
+	It("setup fails", func() {
+		testErr := errors.New("test error")
+		podFirewall := firewall.New(firewall.WithNftableAdapter(&nftableStub{
+			addTableErr: testErr,
+		}))
+
+		Expect(podFirewall.SetupForwarded(podIfaceSpec, bridgeIfaceSpec, vmiIface)).To(MatchError(testErr))
+	})
+
+	It("setup of forwarded traffic with IPv4", func() {
+		nftStub := &nftableStub{}
+		podFirewall := firewall.New(firewall.WithNftableAdapter(nftStub))
+
+		Expect(podFirewall.SetupForwarded(podIfaceSpec, bridgeIfaceSpec, vmiIface)).To(Succeed())
+		expectedConfig := `tables:
+family ip name filter
+chains:
+family ip table filter name forward chainspec [{ type filter hook forward priority 0; }]
+family ip table filter name KUBEVIRT_INGRESS chainspec []
+family ip table filter name KUBEVIRT_EGRESS chainspec []
+rules:
+family ip table filter chain forward rulespec [ct state established,related counter accept]
+family ip table filter chain KUBEVIRT_INGRESS rulespec [ip saddr 10.10.0.0/16 tcp dport 22 counter accept]
+family ip table filter chain KUBEVIRT_INGRESS rulespec [tcp dport 8000-8080 counter accept]
+family ip table filter chain KUBEVIRT_INGRESS rulespec [counter drop]
+family ip table filter chain KUBEVIRT_EGRESS rulespec [ip daddr 169.254.169.254/32 counter drop]
+family ip table filter chain KUBEVIRT_EGRESS rulespec [meta l4proto icmp counter drop]
+family ip table filter chain forward rulespec [iifname eth0 oifname k6t-eth0 counter jump KUBEVIRT_INGRESS]
+family ip table filter chain forward rulespec [iifname k6t-eth0 oifname eth0 counter jump KUBEVIRT_EGRESS]
+`
+		Expect(nftStub.String()).To(Equal(expectedConfig), fmt.Sprintf("actual:\n%s\n\nexpected:\n%s", nftStub.String(), expectedConfig))
+	})
+
+	It("setup of local traffic with IPv6 and legacy migration ports", func() {
+		nftStub := &nftableStub{}
+		podFirewall := firewall.New(firewall.WithNftableAdapter(nftStub), firewall.WithLegacyMigrationPorts())
+		podIfaceSpec.IPv4 = nmstate.IP{Enabled: pointer.P(false)}
+		podIfaceSpec.IPv6 = nmstate.IP{
+			Enabled: pointer.P(true),
+			Address: []nmstate.IPAddress{{IP: "fd10:0:2::2", PrefixLen: 120}},
+		}
+		vmiIface.Ports = []v1.Port{{Port: 22}, {Protocol: "TCP", Port: 8080}, {Protocol: "UDP", Port: 53}}
+
+		Expect(podFirewall.SetupLocal(podIfaceSpec, vmiIface)).To(Succeed())
+		expectedConfig := `tables:
+family ip6 name filter
+chains:
+family ip6 table filter name input chainspec [{ type filter hook input priority 0; }]
+family ip6 table filter name output chainspec [{ type filter hook output priority 0; }]
+family ip6 table filter name KUBEVIRT_INGRESS chainspec []
+family ip6 table filter name KUBEVIRT_EGRESS chainspec []
+rules:
+family ip6 table filter chain input rulespec [ct state established,related counter accept]
+family ip6 table filter chain output rulespec [ct state established,related counter accept]
+family ip6 table filter chain KUBEVIRT_INGRESS rulespec [tcp dport 8000-8080 counter accept]
+family ip6 table filter chain KUBEVIRT_INGRESS rulespec [ip6 saddr fd10::/64 counter accept]
+family ip6 table filter chain KUBEVIRT_INGRESS rulespec [counter drop]
+family ip6 table filter chain KUBEVIRT_EGRESS rulespec [meta l4proto ipv6-icmp counter drop]
+family ip6 table filter chain input rulespec [tcp dport { 49152, 49153 } counter accept]
+family ip6 table filter chain input rulespec [iifname eth0 tcp dport { 22, 8080 } counter jump KUBEVIRT_INGRESS]
+family ip6 table filter chain input rulespec [iifname eth0 udp dport { 53 } counter jump KUBEVIRT_INGRESS]
+family ip6 table filter chain output rulespec [oifname eth0 counter jump KUBEVIRT_EGRESS]
+`
+		Expect(nftStub.String()).To(Equal(expectedConfig), fmt.Sprintf("actual:\n%s\n\nexpected:\n%s", nftStub.String(), expectedConfig))
+	})
+
+	It("setup of local traffic does not filter the pod traffic to ports which are not forwarded", func() {
+		nftStub := &nftableStub{}
+		podFirewall := firewall.New(firewall.WithNftableAdapter(nftStub))
+		vmiIface.Ports = []v1.Port{{Port: 80}}
+
+		Expect(podFirewall.SetupLocal(podIfaceSpec, vmiIface)).To(Succeed())
+
+		// Connections of sidecars, hooks or probes to e.g. port 8443 pass the input chain
+		// without a jump and are accepted by its policy
+		var inputRules []string
+		for _, rule := range nftStub.Rules {
+			if rule.Chain.Name == "input" {
+				inputRules = append(inputRules, strings.Join(rule.Rulespec, " "))
+			}
+		}
+		Expect(inputRules).To(ConsistOf(
+			"ct state established,related counter accept",
+			"iifname eth0 tcp dport { 80 } counter jump KUBEVIRT_INGRESS",
+		))
+	})
+
+	It("setup of local traffic does not filter the ingress traffic without listed ports", func() {
+		nftStub := &nftableStub{}
+		podFirewall := firewall.New(firewall.WithNftableAdapter(nftStub))
+
+		Expect(podFirewall.SetupLocal(podIfaceSpec, vmiIface)).To(Succeed())
+
+		for _, rule := range nftStub.Rules {
+			if rule.Chain.Name == "input" {
+				Expect(rule.Rulespec).ToNot(ContainElement(kubevirtIngressChain))
+			}
+		}
+	})
+})
+
+const kubevirtIngressChain = "KUBEVIRT_INGRESS"
+
+type nftableStub struct {
+	addTableErr error
+	Tables      []tableData
+	Chains      []chainData
+	Rules       []ruleData
+}
+
+type tableData struct {
+	Family nft.IPFamily
+	Name   string
+}
+
+type chainData struct {
+	Table     tableData
+	Name      string
+	Chainspec []string
+}
+
+type ruleData struct {
+	Chain    chainData
+	Rulespec []string
+}
+
+func (n *nftableStub) AddTable(family nft.IPFamily, name string) error {
+	if n.addTableErr != nil {
+		return n.addTableErr
+	}
+	n.Tables = append(n.Tables, tableData{family, name})
+	return nil
+}
+
+func (n *nftableStub) AddChain(family nft.IPFamily, table string, name string, chainspec ...string) error {
+	n.Chains = append(n.Chains, chainData{
+		tableData{family, table},
+		name,
+		chainspec,
+	})
+	return nil
+}
+
+func (n *nftableStub) AddRule(family nft.IPFamily, table string, chain string, rulespec ...string) error {
+	n.Rules = append(n.Rules, ruleData{
+		Chain: chainData{
+			Table: tableData{Family: family, Name: table},
+			Name:  chain,
+		},
+		Rulespec: rulespec,
+	})
+	return nil
+}
+
+func (n *nftableStub) String() string {
+	var out string
+
+	out += "tables:\n"
+	for _, t := range n.Tables {
+		out += fmt.Sprintf("family %s name %s\n", t.Family, t.Name)
+	}
+	out += "chains:\n"
+	for _, c := range n.Chains {
+		out += fmt.Sprintf("family %s table %s name %s chainspec %s\n", c.Table.Family, c.Table.Name, c.Name, c.Chainspec)
+	}
+	out += "rules:\n"
+	for _, r := range n.Rules {
+		out += fmt.Sprintf("family %s table %s chain %s rulespec %s\n", r.Chain.Table.Family, r.Chain.Table.Name, r.Chain.Name, r.Rulespec)
+	}
+	return out
+}
@@ -36,7 +36,9 @@ import (
 	"kubevirt.io/kubevirt/pkg/network/link"
 	"kubevirt.io/kubevirt/pkg/network/namescheme"
 	"kubevirt.io/kubevirt/pkg/network/netmachinery"
+	"kubevirt.io/kubevirt/pkg/network/setup/netpod/firewall"
 	"kubevirt.io/kubevirt/pkg/network/setup/netpod/masquerade"
+	"kubevirt.io/kubevirt/pkg/network/setup/netpod/qos"
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -55,6 +57,15 @@ type masqueradeAdapter interface {
 	Setup(bridgeIfaceSpec, podIfaceSpec *nmstate.Interface, vmiIface v1.Interface) error
 }
 
+type firewallAdapter interface {
+	SetupForwarded(podIfaceSpec, bridgeIfaceSpec *nmstate.Interface, vmiIface v1.Interface) error
+	SetupLocal(podIfaceSpec *nmstate.Interface, vmiIface v1.Interface) error
+}
+
+type qosAdapter interface {
+	Setup(podIfaceName, tapIfaceName string, vmiIface v1.Interface) error
+}
+
 type cacheCreator interface {
 	New(filePath string) *cache.Cache
 }
@@ -73,6 +84,8 @@ type NetPod struct {
 
 	nmstateAdapter    nmstateAdapter
 	masqueradeAdapter masqueradeAdapter
+	firewallAdapter   firewallAdapter
+	qosAdapter        qosAdapter
 
 	cacheCreator cacheCreator
 	state        *State
@@ -92,6 +105,8 @@ func NewNetPod(vmiNetworks []v1.Network, vmiIfaces []v1.Interface, vmiUID string
 
 		nmstateAdapter:    nmstate.New(),
 		masqueradeAdapter: masquerade.New(),
+		firewallAdapter:   firewall.New(),
+		qosAdapter:        qos.New(),
 
 		cacheCreator: cache.CacheCreator{},
 	}
@@ -113,6 +128,18 @@ func WithMasqueradeAdapter(h masqueradeAdapter) option {
 	}
 }
 
+func WithFirewallAdapter(h firewallAdapter) option {
+	return func(n *NetPod) {
+		n.firewallAdapter = h
+	}
+}
+
+func WithQoSAdapter(h qosAdapter) option {
+	return func(n *NetPod) {
+		n.qosAdapter = h
+	}
+}
+
 func WithCacheCreator(c cacheCreator) option {
 	return func(n *NetPod) {
 		n.cacheCreator = c
@@ -221,7 +248,16 @@ func (n NetPod) config(currentStatus *nmstate.Status) error {
 
 	// Configuring NAT (nftables) is temporary done outside nmstate.
 	// This should be eventually embedded into the nmstate desired state and applied by it.
-	return n.setupNAT(desiredSpec, currentStatus)
+	if err = n.setupNAT(desiredSpec, currentStatus); err != nil {
+		return err
+	}
+
+	if err = n.setupFirewall(desiredSpec, currentStatus); err != nil {
+		return err
+	}
+
+	// The bandwidth is shaped here and not by libvirt, which lacks the privileges in the pod.
+	return n.setupQoS(desiredSpec, currentStatus)
 }
 
 func (n NetPod) composeDesiredSpec(currentStatus *nmstate.Status) (*nmstate.Spec, error) {
@@ -453,6 +489,70 @@ func (n NetPod) setupNAT(desiredSpec *nmstate.Spec, currentStatus *nmstate.Statu
 	return n.masqueradeAdapter.Setup(bridgeIfaceSpec, podIfaceSpec, vmiIface[0])
 }
 
+func (n NetPod) setupFirewall(desiredSpec *nmstate.Spec, currentStatus *nmstate.Status) error {
+	podIfaceNameByVMINetwork := createNetworkNameScheme(n.vmiSpecNets, currentStatus.Interfaces)
+	for _, vmiIface := range n.vmiSpecIfaces {
+		if vmiIface.Firewall == nil || vmiIface.State == v1.InterfaceStateAbsent {
+			continue
+		}
+		podIfaceName := podIfaceNameByVMINetwork[vmiIface.Name]
+		podIfaceSpec := nmstate.LookupInterface(currentStatus.Interfaces, func(i nmstate.Interface) bool {
+			return i.Name == podIfaceName
+		})
+		if podIfaceSpec == nil {
+			return fmt.Errorf("setup-firewall: pod link (%s) is missing", podIfaceName)
+		}
+
+		switch {
+		case vmiIface.Masquerade != nil:
+			vmiIfaceName := vmiIface.Name
+			bridgeIfaceSpec := nmstate.LookupInterface(desiredSpec.Interfaces, func(i nmstate.Interface) bool {
+				return i.Metadata != nil && i.Metadata.NetworkName == vmiIfaceName && i.TypeName == nmstate.TypeBridge
+			})
+			if bridgeIfaceSpec == nil {
+				return fmt.Errorf("setup-firewall: bridge of network %s is missing", vmiIfaceName)
+			}
+			if err := n.firewallAdapter.SetupForwarded(podIfaceSpec, bridgeIfaceSpec, vmiIface); err != nil {
+				return err
+			}
+		case vmiIface.Passt != nil:
+			if err := n.firewallAdapter.SetupLocal(podIfaceSpec, vmiIface); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (n NetPod) setupQoS(desiredSpec *nmstate.Spec, currentStatus *nmstate.Status) error {
+	podIfaceNameByVMINetwork := createNetworkNameScheme(n.vmiSpecNets, currentStatus.Interfaces)
+	for _, vmiIface := range n.vmiSpecIfaces {
+		if vmiIface.QoS == nil || vmiIface.State == v1.InterfaceStateAbsent {
+			continue
+		}
+		if vmiIface.Bridge == nil && vmiIface.Masquerade == nil {
+			continue
+		}
+		vmiIfaceName := vmiIface.Name
+		tapIfaceSpec := nmstate.LookupInterface(desiredSpec.Interfaces, func(i nmstate.Interface) bool {
+			return i.Metadata != nil && i.Metadata.NetworkName == vmiIfaceName && i.TypeName == nmstate.TypeTap
+		})
+		if tapIfaceSpec == nil {
+			return fmt.Errorf("setup-qos: tap of network %s is missing", vmiIfaceName)
+		}
+
+		// The bridge binding renames the pod link, which is then connected to the bridge.
+		podIfaceName := podIfaceNameByVMINetwork[vmiIfaceName]
+		if vmiIface.Bridge != nil {
+			podIfaceName = link.GenerateNewBridgedVmiInterfaceName(podIfaceName)
+		}
+		if err := n.qosAdapter.Setup(podIfaceName, tapIfaceSpec.Name, vmiIface); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (n NetPod) lookupMasquradeBridge(desiredIfacesSpec []nmstate.Interface) *nmstate.Interface {
 	masqueradeIfaces := vmispec.FilterInterfacesSpec(n.vmiSpecIfaces, func(i v1.Interface) bool {
 		return i.Masquerade != nil
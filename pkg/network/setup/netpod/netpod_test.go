@@ -28,6 +28,8 @@ import (
 
 	vishnetlink "github.com/vishvananda/netlink"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	dutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	kfs "kubevirt.io/kubevirt/pkg/os/fs"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -943,6 +945,105 @@ var _ = Describe("netpod", func() {
 		}))
 	})
 
+	DescribeTable("setup firewall", func(binding v1.InterfaceBindingMethod, expectedBridgeIfaceName string) {
+		nmstatestub := nmstateStub{status: nmstate.Status{
+			Interfaces: []nmstate.Interface{{
+				Name:       "eth0",
+				Index:      0,
+				TypeName:   nmstate.TypeVETH,
+				State:      nmstate.IfaceStateUp,
+				MacAddress: "12:34:56:78:90:ab",
+				MTU:        1500,
+				IPv4: nmstate.IP{
+					Enabled: pointer.P(true),
+					Address: []nmstate.IPAddress{{
+						IP:        primaryIPv4Address,
+						PrefixLen: 30,
+					}},
+				},
+			}},
+		}}
+		firewallstub := firewallStub{}
+
+		vmiIface := v1.Interface{
+			Name:                   defaultPodNetworkName,
+			InterfaceBindingMethod: binding,
+			Firewall: &v1.InterfaceFirewall{
+				Ingress: []v1.FirewallRule{{Action: v1.FirewallActionDeny}},
+			},
+		}
+		netPod := netpod.NewNetPod(
+			[]v1.Network{*v1.DefaultPodNetwork()},
+			[]v1.Interface{vmiIface},
+			vmiUID, 0, 0, 0, state,
+			netpod.WithNMStateAdapter(&nmstatestub),
+			netpod.WithMasqueradeAdapter(&masqueradeStub{}),
+			netpod.WithFirewallAdapter(&firewallstub),
+			netpod.WithCacheCreator(&baseCacheCreator),
+		)
+		Expect(netPod.Setup()).To(Succeed())
+		Expect(firewallstub.podIfaceSpec.Name).To(Equal("eth0"))
+		Expect(firewallstub.vmiIfaceSpec).To(Equal(vmiIface))
+		if expectedBridgeIfaceName == "" {
+			Expect(firewallstub.bridgeIfaceSpec).To(BeNil())
+		} else {
+			Expect(firewallstub.bridgeIfaceSpec.Name).To(Equal(expectedBridgeIfaceName))
+		}
+	},
+		Entry("of forwarded traffic with masquerade binding", v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, "k6t-eth0"),
+		Entry("of local traffic with passt binding", v1.InterfaceBindingMethod{Passt: &v1.InterfacePasst{}}, ""),
+	)
+
+	DescribeTable("setup QoS", func(binding v1.InterfaceBindingMethod, expectedPodIfaceName string) {
+		nmstatestub := nmstateStub{status: nmstate.Status{
+			Interfaces: []nmstate.Interface{{
+				Name:       "eth0",
+				Index:      0,
+				TypeName:   nmstate.TypeVETH,
+				State:      nmstate.IfaceStateUp,
+				MacAddress: "12:34:56:78:90:ab",
+				MTU:        1500,
+				IPv4: nmstate.IP{
+					Enabled: pointer.P(true),
+					Address: []nmstate.IPAddress{{
+						IP:        primaryIPv4Address,
+						PrefixLen: 30,
+					}},
+				},
+			}},
+			Routes: nmstate.Routes{Running: []nmstate.Route{{
+				Destination:      "0.0.0.0/0",
+				NextHopInterface: "eth0",
+				NextHopAddress:   "10.222.222.254",
+			}}},
+		}}
+		qosstub := qosStub{}
+
+		vmiIface := v1.Interface{
+			Name:                   defaultPodNetworkName,
+			InterfaceBindingMethod: binding,
+			QoS: &v1.InterfaceQoS{
+				Inbound: &v1.InterfaceBandwidth{Average: resource.MustParse("1Mi")},
+			},
+		}
+		netPod := netpod.NewNetPod(
+			[]v1.Network{*v1.DefaultPodNetwork()},
+			[]v1.Interface{vmiIface},
+			vmiUID, 0, 0, 0, state,
+			netpod.WithNMStateAdapter(&nmstatestub),
+			netpod.WithMasqueradeAdapter(&masqueradeStub{}),
+			netpod.WithQoSAdapter(&qosstub),
+			netpod.WithCacheCreator(&baseCacheCreator),
+		)
+		Expect(netPod.Setup()).To(Succeed())
+		Expect(qosstub.podIfaceName).To(Equal(expectedPodIfaceName))
+		Expect(qosstub.tapIfaceName).To(Equal("tap0"))
+		Expect(qosstub.vmiIfaceSpec).To(Equal(vmiIface))
+	},
+		Entry("with bridge binding", v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, "eth0-nic"),
+		Entry("with masquerade binding", v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, "eth0"),
+	)
+
 	DescribeTable("setup unhandled bindings", func(binding v1.InterfaceBindingMethod, expNmstateSpec nmstate.Spec) {
 		nmstatestub := nmstateStub{status: nmstate.Status{
 			Interfaces: []nmstate.Interface{
@@ -1630,6 +1731,38 @@ func (m *masqueradeStub) Setup(bridgeIfaceSpec, podIfaceSpec *nmstate.Interface,
 	return nil
 }
 
+type firewallStub struct {
+	podIfaceSpec    *nmstate.Interface
+	bridgeIfaceSpec *nmstate.Interface
+	vmiIfaceSpec    v1.Interface
+}
+
+func (f *firewallStub) SetupForwarded(podIfaceSpec, bridgeIfaceSpec *nmstate.Interface, vmiIfaceSpec v1.Interface) error {
+	f.podIfaceSpec = podIfaceSpec
+	f.bridgeIfaceSpec = bridgeIfaceSpec
+	f.vmiIfaceSpec = vmiIfaceSpec
+	return nil
+}
+
+func (f *firewallStub) SetupLocal(podIfaceSpec *nmstate.Interface, vmiIfaceSpec v1.Interface) error {
+	f.podIfaceSpec = podIfaceSpec
+	f.vmiIfaceSpec = vmiIfaceSpec
+	return nil
+}
+
+type qosStub struct {
+	podIfaceName string
+	tapIfaceName string
+	vmiIfaceSpec v1.Interface
+}
+
+func (q *qosStub) Setup(podIfaceName, tapIfaceName string, vmiIfaceSpec v1.Interface) error {
+	q.podIfaceName = podIfaceName
+	q.tapIfaceName = tapIfaceName
+	q.vmiIfaceSpec = vmiIfaceSpec
+	return nil
+}
+
 type tempCacheCreator struct {
 	once   sync.Once
 	tmpDir string
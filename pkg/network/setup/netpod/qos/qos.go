@@ -0,0 +1,247 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package qos
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	netdriver "kubevirt.io/kubevirt/pkg/network/driver/netlink"
+)
+
+type netlinkAdapter interface {
+	LinkByName(name string) (netlink.Link, error)
+	QdiscReplace(qdisc netlink.Qdisc) error
+	FilterReplace(filter netlink.Filter) error
+}
+
+// PodQoS shapes the bandwidth of the VMI interfaces in the pod network namespace.
+// The bandwidth is not rendered into the libvirt domain: libvirt applies <bandwidth> with
+// tc from the compute container, which lacks the CAP_NET_ADMIN capability this requires.
+type PodQoS struct {
+	netlink     netlinkAdapter
+	trafficMark uint32
+}
+
+const (
+	// When no burst is requested, a tenth of a second at the average rate can be sent at once.
+	defaultBurstDivisor = 10
+	// Traffic which waits for more than a twentieth of a second in the queue is dropped.
+	queueLatencyDivisor = 20
+	// The ethernet header, including a VLAN tag, is not part of the MTU.
+	ethernetHeaderLen = 18
+
+	// defaultGuestTrafficMark marks the packets sent by a masqueraded guest, they cannot be told
+	// apart from the traffic of the pod by their address once they are translated.
+	defaultGuestTrafficMark = 0x4b56
+)
+
+type option func(*PodQoS)
+
+func New(opts ...option) PodQoS {
+	q := PodQoS{netlink: netdriver.NetLink{}, trafficMark: defaultGuestTrafficMark}
+	for _, opt := range opts {
+		opt(&q)
+	}
+	return q
+}
+
+func WithNetlinkAdapter(h netlinkAdapter) option {
+	return func(q *PodQoS) {
+		q.netlink = h
+	}
+}
+
+// WithGuestTrafficMark sets the mark of the packets sent by a masqueraded guest,
+// it has to differ from the marks other components use in the pod network namespace.
+func WithGuestTrafficMark(mark uint32) option {
+	return func(q *PodQoS) {
+		q.trafficMark = mark
+	}
+}
+
+// Setup shapes the traffic sent by the guest on the pod link and the traffic received
+// by the guest on its tap device.
+// Both directions are shaped on the egress of a link, the ingress cannot be shaped.
+func (q PodQoS) Setup(podIfaceName, tapIfaceName string, vmiIface v1.Interface) error {
+	if vmiIface.QoS == nil {
+		return nil
+	}
+	if err := q.shapeOutbound(podIfaceName, tapIfaceName, vmiIface); err != nil {
+		return err
+	}
+	if vmiIface.QoS.Inbound == nil {
+		return nil
+	}
+	tapLink, err := q.linkByName(tapIfaceName)
+	if err != nil {
+		return err
+	}
+	return q.replaceQdisc(tapLink, newTbf(tapLink, netlink.HANDLE_ROOT, netlink.MakeHandle(1, 0), vmiIface.QoS.Inbound))
+}
+
+// shapeOutbound shapes the traffic sent by the guest. With the bridge binding, the pod link is
+// connected to the bridge and only carries the traffic of the guest, which is shaped as a whole.
+// With the masquerade binding, the pod link also carries the traffic of the pod itself, e.g.
+// migrations. The guest traffic is therefore marked when it enters the pod from the tap device
+// and only the marked traffic is shaped on the pod link.
+func (q PodQoS) shapeOutbound(podIfaceName, tapIfaceName string, vmiIface v1.Interface) error {
+	bandwidth := vmiIface.QoS.Outbound
+	if bandwidth == nil {
+		return nil
+	}
+	podLink, err := q.linkByName(podIfaceName)
+	if err != nil {
+		return err
+	}
+	if vmiIface.Masquerade == nil {
+		return q.replaceQdisc(podLink, newTbf(podLink, netlink.HANDLE_ROOT, netlink.MakeHandle(1, 0), bandwidth))
+	}
+
+	tapLink, err := q.linkByName(tapIfaceName)
+	if err != nil {
+		return err
+	}
+	ingressHandle := netlink.MakeHandle(0xffff, 0)
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: tapLink.Attrs().Index,
+			Handle:    ingressHandle,
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+	if err := q.replaceQdisc(tapLink, ingress); err != nil {
+		return err
+	}
+	mark := q.trafficMark
+	markAction := netlink.NewSkbEditAction()
+	markAction.Mark = &mark
+	markFilter := &netlink.MatchAll{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: tapLink.Attrs().Index,
+			Parent:    ingressHandle,
+			Priority:  1,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		Actions: []netlink.Action{markAction},
+	}
+	if err := q.replaceFilter(tapLink, markFilter); err != nil {
+		return err
+	}
+
+	// The pod traffic is sent to the second band of the priority qdisc, the marked guest
+	// traffic is classified into the first band, which is shaped.
+	prioHandle := netlink.MakeHandle(1, 0)
+	prio := &netlink.Prio{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: podLink.Attrs().Index,
+			Handle:    prioHandle,
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Bands: 2,
+	}
+	for i := range prio.PriorityMap {
+		prio.PriorityMap[i] = 1
+	}
+	if err := q.replaceQdisc(podLink, prio); err != nil {
+		return err
+	}
+	guestClass := netlink.MakeHandle(1, 1)
+	classFilter := &netlink.Fw{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: podLink.Attrs().Index,
+			Parent:    prioHandle,
+			Handle:    q.trafficMark,
+			Priority:  1,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		ClassId: guestClass,
+	}
+	if err := q.replaceFilter(podLink, classFilter); err != nil {
+		return err
+	}
+	return q.replaceQdisc(podLink, newTbf(podLink, guestClass, netlink.MakeHandle(10, 0), bandwidth))
+}
+
+func (q PodQoS) linkByName(linkName string) (netlink.Link, error) {
+	link, err := q.netlink.LinkByName(linkName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find link %s: %v", linkName, err)
+	}
+	return link, nil
+}
+
+func (q PodQoS) replaceQdisc(link netlink.Link, qdisc netlink.Qdisc) error {
+	if err := q.netlink.QdiscReplace(qdisc); err != nil {
+		return fmt.Errorf("failed to shape link %s: %v", link.Attrs().Name, err)
+	}
+	return nil
+}
+
+func (q PodQoS) replaceFilter(link netlink.Link, filter netlink.Filter) error {
+	if err := q.netlink.FilterReplace(filter); err != nil {
+		return fmt.Errorf("failed to classify the traffic of link %s: %v", link.Attrs().Name, err)
+	}
+	return nil
+}
+
+// newTbf returns a token bucket filter which shapes the traffic of the link to the bandwidth.
+func newTbf(link netlink.Link, parent, handle uint32, bandwidth *v1.InterfaceBandwidth) *netlink.Tbf {
+	maxFrameLen := uint32(link.Attrs().MTU + ethernetHeaderLen)
+
+	rate := uint64(bandwidth.Average.Value())
+	burst := clampUint32(rate / defaultBurstDivisor)
+	if bandwidth.Burst != nil {
+		burst = clampUint32(uint64(bandwidth.Burst.Value()))
+	}
+	if burst < maxFrameLen {
+		burst = maxFrameLen
+	}
+
+	tbf := &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    handle,
+			Parent:    parent,
+		},
+		Rate: rate,
+		// The kernel expects the bucket size as the time it takes to send it at the rate.
+		Buffer: netlink.Xmittime(rate, burst),
+		Limit:  clampUint32(uint64(burst) + rate/queueLatencyDivisor),
+	}
+	// The kernel rejects a peak rate which is not greater than the rate.
+	if bandwidth.Peak != nil && bandwidth.Peak.Cmp(bandwidth.Average) > 0 {
+		tbf.Peakrate = uint64(bandwidth.Peak.Value())
+		tbf.Minburst = maxFrameLen
+	}
+	return tbf
+}
+
+func clampUint32(value uint64) uint32 {
+	if value > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(value)
+}
@@ -0,0 +1,246 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package qos_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/setup/netpod/qos"
+)
+
+var _ = Describe("pod QoS", func() {
+	const (
+		podIfaceName = "eth0"
+		tapIfaceName = "tap0"
+
+		podIfaceIndex = 2
+		tapIfaceIndex = 3
+	)
+
+	var netlinkAdapter *netlinkStub
+
+	BeforeEach(func() {
+		netlinkAdapter = &netlinkStub{
+			links: map[string]netlink.Link{
+				podIfaceName: &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: podIfaceName, Index: podIfaceIndex, MTU: 1500}},
+				tapIfaceName: &netlink.Tuntap{LinkAttrs: netlink.LinkAttrs{Name: tapIfaceName, Index: tapIfaceIndex, MTU: 1500}},
+			},
+		}
+	})
+
+	It("does nothing without QoS", func() {
+		podQoS := qos.New(qos.WithNetlinkAdapter(netlinkAdapter))
+
+		Expect(podQoS.Setup(podIfaceName, tapIfaceName, v1.Interface{Name: "default"})).To(Succeed())
+		Expect(netlinkAdapter.qdiscs).To(BeEmpty())
+	})
+
+	It("shapes the traffic sent by the guest on the pod link and the received one on the tap", func() {
+		podQoS := qos.New(qos.WithNetlinkAdapter(netlinkAdapter))
+		vmiIface := v1.Interface{
+			Name: "default",
+			QoS: &v1.InterfaceQoS{
+				Inbound: &v1.InterfaceBandwidth{
+					Average: resource.MustParse("1Mi"),
+				},
+				Outbound: &v1.InterfaceBandwidth{
+					Average: resource.MustParse("100Ki"),
+					Peak:    resource.NewQuantity(200*1024, resource.BinarySI),
+					Burst:   resource.NewQuantity(64*1024, resource.BinarySI),
+				},
+			},
+		}
+
+		Expect(podQoS.Setup(podIfaceName, tapIfaceName, vmiIface)).To(Succeed())
+		Expect(netlinkAdapter.qdiscs).To(Equal([]netlink.Qdisc{
+			&netlink.Tbf{
+				QdiscAttrs: netlink.QdiscAttrs{LinkIndex: podIfaceIndex, Handle: netlink.MakeHandle(1, 0), Parent: netlink.HANDLE_ROOT},
+				Rate:       100 * 1024,
+				Buffer:     netlink.Xmittime(100*1024, 64*1024),
+				Limit:      64*1024 + 100*1024/20,
+				Peakrate:   200 * 1024,
+				Minburst:   1518,
+			},
+			&netlink.Tbf{
+				QdiscAttrs: netlink.QdiscAttrs{LinkIndex: tapIfaceIndex, Handle: netlink.MakeHandle(1, 0), Parent: netlink.HANDLE_ROOT},
+				Rate:       1024 * 1024,
+				Buffer:     netlink.Xmittime(1024*1024, 1024*1024/10),
+				Limit:      1024*1024/10 + 1024*1024/20,
+			},
+		}))
+	})
+
+	It("only shapes the traffic sent by a masqueraded guest on the pod link", func() {
+		podQoS := qos.New(qos.WithNetlinkAdapter(netlinkAdapter))
+		vmiIface := v1.Interface{
+			Name:                   "default",
+			InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+			QoS: &v1.InterfaceQoS{
+				Outbound: &v1.InterfaceBandwidth{
+					Average: resource.MustParse("1Mi"),
+				},
+			},
+		}
+
+		Expect(podQoS.Setup(podIfaceName, tapIfaceName, vmiIface)).To(Succeed())
+
+		mark := uint32(0x4b56)
+		markAction := netlink.NewSkbEditAction()
+		markAction.Mark = &mark
+		Expect(netlinkAdapter.filters).To(Equal([]netlink.Filter{
+			&netlink.MatchAll{
+				FilterAttrs: netlink.FilterAttrs{LinkIndex: tapIfaceIndex, Parent: netlink.MakeHandle(0xffff, 0), Priority: 1, Protocol: unix.ETH_P_ALL},
+				Actions:     []netlink.Action{markAction},
+			},
+			&netlink.Fw{
+				FilterAttrs: netlink.FilterAttrs{LinkIndex: podIfaceIndex, Parent: netlink.MakeHandle(1, 0), Handle: mark, Priority: 1, Protocol: unix.ETH_P_ALL},
+				ClassId:     netlink.MakeHandle(1, 1),
+			},
+		}))
+		Expect(netlinkAdapter.qdiscs).To(Equal([]netlink.Qdisc{
+			&netlink.Ingress{
+				QdiscAttrs: netlink.QdiscAttrs{LinkIndex: tapIfaceIndex, Handle: netlink.MakeHandle(0xffff, 0), Parent: netlink.HANDLE_INGRESS},
+			},
+			&netlink.Prio{
+				QdiscAttrs:  netlink.QdiscAttrs{LinkIndex: podIfaceIndex, Handle: netlink.MakeHandle(1, 0), Parent: netlink.HANDLE_ROOT},
+				Bands:       2,
+				PriorityMap: [netlink.PRIORITY_MAP_LEN]uint8{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+			},
+			&netlink.Tbf{
+				QdiscAttrs: netlink.QdiscAttrs{LinkIndex: podIfaceIndex, Handle: netlink.MakeHandle(10, 0), Parent: netlink.MakeHandle(1, 1)},
+				Rate:       1024 * 1024,
+				Buffer:     netlink.Xmittime(1024*1024, 1024*1024/10),
+				Limit:      1024*1024/10 + 1024*1024/20,
+			},
+		}))
+	})
+
+	It("marks the traffic of a masqueraded guest with the configured mark", func() {
+		podQoS := qos.New(qos.WithNetlinkAdapter(netlinkAdapter), qos.WithGuestTrafficMark(0x100))
+		vmiIface := v1.Interface{
+			Name:                   "default",
+			InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+			QoS: &v1.InterfaceQoS{
+				Outbound: &v1.InterfaceBandwidth{
+					Average: resource.MustParse("1Mi"),
+				},
+			},
+		}
+
+		Expect(podQoS.Setup(podIfaceName, tapIfaceName, vmiIface)).To(Succeed())
+
+		mark := uint32(0x100)
+		markAction := netlink.NewSkbEditAction()
+		markAction.Mark = &mark
+		Expect(netlinkAdapter.filters).To(Equal([]netlink.Filter{
+			&netlink.MatchAll{
+				FilterAttrs: netlink.FilterAttrs{LinkIndex: tapIfaceIndex, Parent: netlink.MakeHandle(0xffff, 0), Priority: 1, Protocol: unix.ETH_P_ALL},
+				Actions:     []netlink.Action{markAction},
+			},
+			&netlink.Fw{
+				FilterAttrs: netlink.FilterAttrs{LinkIndex: podIfaceIndex, Parent: netlink.MakeHandle(1, 0), Handle: mark, Priority: 1, Protocol: unix.ETH_P_ALL},
+				ClassId:     netlink.MakeHandle(1, 1),
+			},
+		}))
+	})
+
+	It("sends at least a whole frame at once", func() {
+		podQoS := qos.New(qos.WithNetlinkAdapter(netlinkAdapter))
+		vmiIface := v1.Interface{
+			Name: "default",
+			QoS: &v1.InterfaceQoS{
+				Outbound: &v1.InterfaceBandwidth{
+					Average: resource.MustParse("1Ki"),
+					Peak:    resource.NewQuantity(1024, resource.BinarySI),
+				},
+			},
+		}
+
+		Expect(podQoS.Setup(podIfaceName, tapIfaceName, vmiIface)).To(Succeed())
+		Expect(netlinkAdapter.qdiscs).To(Equal([]netlink.Qdisc{
+			&netlink.Tbf{
+				QdiscAttrs: netlink.QdiscAttrs{LinkIndex: podIfaceIndex, Handle: netlink.MakeHandle(1, 0), Parent: netlink.HANDLE_ROOT},
+				Rate:       1024,
+				Buffer:     netlink.Xmittime(1024, 1518),
+				Limit:      1518 + 1024/20,
+			},
+		}))
+	})
+
+	It("fails when the link is missing", func() {
+		podQoS := qos.New(qos.WithNetlinkAdapter(netlinkAdapter))
+		vmiIface := v1.Interface{
+			Name: "default",
+			QoS:  &v1.InterfaceQoS{Inbound: &v1.InterfaceBandwidth{Average: resource.MustParse("1Mi")}},
+		}
+
+		Expect(podQoS.Setup(podIfaceName, "tap1", vmiIface)).NotTo(Succeed())
+	})
+
+	It("fails when the qdisc cannot be replaced", func() {
+		testErr := errors.New("test error")
+		netlinkAdapter.qdiscReplaceErr = testErr
+		podQoS := qos.New(qos.WithNetlinkAdapter(netlinkAdapter))
+		vmiIface := v1.Interface{
+			Name: "default",
+			QoS:  &v1.InterfaceQoS{Inbound: &v1.InterfaceBandwidth{Average: resource.MustParse("1Mi")}},
+		}
+
+		Expect(podQoS.Setup(podIfaceName, tapIfaceName, vmiIface)).To(MatchError(ContainSubstring(testErr.Error())))
+	})
+})
+
+type netlinkStub struct {
+	links           map[string]netlink.Link
+	qdiscs          []netlink.Qdisc
+	filters         []netlink.Filter
+	qdiscReplaceErr error
+}
+
+func (n *netlinkStub) LinkByName(name string) (netlink.Link, error) {
+	link, exists := n.links[name]
+	if !exists {
+		return nil, netlink.LinkNotFoundError{}
+	}
+	return link, nil
+}
+
+func (n *netlinkStub) QdiscReplace(qdisc netlink.Qdisc) error {
+	if n.qdiscReplaceErr != nil {
+		return n.qdiscReplaceErr
+	}
+	n.qdiscs = append(n.qdiscs, qdisc)
+	return nil
+}
+
+func (n *netlinkStub) FilterReplace(filter netlink.Filter) error {
+	n.filters = append(n.filters, filter)
+	return nil
+}
@@ -81,7 +81,8 @@ func (c *NetStat) CachePodInterfaceVolatileData(vmi *v1.VirtualMachineInstance,
 //   - Pod interface cache: interfaces data (IP/s) collected from the cache (which was populated during the network setup).
 //   - domain.Spec: interfaces configuration as seen by the (libvirt) domain.
 //   - domain.Status.Interfaces: interfaces reported by the guest agent (empty if Qemu agent not running).
-//   - Multus status: Interfaces reported by multus on the pod annotation.
+//   - Multus status: Interfaces reported by multus on the pod annotation, including the IPs of bridge bound
+//     secondary interfaces, which are kept when neither the guest agent nor the pod interface cache report IPs.
 //     The virt-controller updates the VMI interfaces status my setting the infoSource field.
 //
 // Podnet nic has to be the first one in vmi.Status.Interfaces list to match vmi crd wide columns definition
@@ -139,21 +140,39 @@ func ifacesStatusFromMultus(
 	multusStatusNetworksByName map[string]v1.VirtualMachineInstanceNetworkInterface,
 	vmIfacesSpecByName map[string]v1.Interface,
 ) []v1.VirtualMachineInstanceNetworkInterface {
-	for multusIfaceName := range multusStatusNetworksByName {
+	for multusIfaceName, multusIfaceStatus := range multusStatusNetworksByName {
 		ifaceStatus := netvmispec.LookupInterfaceStatusByName(interfacesStatus, multusIfaceName)
-		_, existInSpec := vmIfacesSpecByName[multusIfaceName]
+		ifaceSpec, existInSpec := vmIfacesSpecByName[multusIfaceName]
 		if existInSpec && ifaceStatus == nil {
 			interfacesStatus = append(interfacesStatus, v1.VirtualMachineInstanceNetworkInterface{
 				Name:       multusIfaceName,
 				InfoSource: netvmispec.InfoSourceMultusStatus,
 			})
+			ifaceStatus = &interfacesStatus[len(interfacesStatus)-1]
 		} else if ifaceStatus != nil {
 			ifaceStatus.InfoSource = netvmispec.AddInfoSource(ifaceStatus.InfoSource, netvmispec.InfoSourceMultusStatus)
 		}
+
+		if existInSpec && ifaceSpec.Bridge != nil {
+			updateIfaceStatusIPsFromMultus(ifaceStatus, multusIfaceStatus)
+		}
 	}
 	return interfacesStatus
 }
 
+// updateIfaceStatusIPsFromMultus keeps the IPs reported by multus on the pod network-status annotation,
+// as long as neither the guest agent nor the pod interface cache report any IPs.
+// The multus IPs are set by the virt-controller, which has access to the pod.
+func updateIfaceStatusIPsFromMultus(ifaceStatus *v1.VirtualMachineInstanceNetworkInterface, multusIfaceStatus v1.VirtualMachineInstanceNetworkInterface) {
+	if ifaceStatus == nil || len(ifaceStatus.IPs) > 0 ||
+		netvmispec.ContainsInfoSource(ifaceStatus.InfoSource, netvmispec.InfoSourceGuestAgent) ||
+		netvmispec.ContainsInfoSource(multusIfaceStatus.InfoSource, netvmispec.InfoSourceGuestAgent) {
+		return
+	}
+	ifaceStatus.IP = multusIfaceStatus.IP
+	ifaceStatus.IPs = multusIfaceStatus.IPs
+}
+
 // updateIfacesStatusFromPodCache updates the provided interfaces statuses with data (IP/s) from the pod-cache.
 func (c *NetStat) updateIfacesStatusFromPodCache(ifacesStatus []v1.VirtualMachineInstanceNetworkInterface, ifacesSpec []v1.Interface, vmi *v1.VirtualMachineInstance) ([]v1.VirtualMachineInstanceNetworkInterface, error) {
 	for _, iface := range ifacesSpec {
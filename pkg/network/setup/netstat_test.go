@@ -667,6 +667,68 @@ var _ = Describe("netstat", func() {
 		}), "primary and secondary ifaces should exist in status, where secondary iface have multus-status only")
 	})
 
+	Context("secondary bridge interface IPs from multus status", func() {
+		const (
+			networkName  = "secondary"
+			mac          = "1C:CE:C0:01:BE:E7"
+			multusIPv4   = "10.10.10.2"
+			multusIPv6   = "fd10:10::2"
+			guestAgentIP = "10.10.10.3"
+		)
+
+		BeforeEach(func() {
+			setup.Vmi.Spec.Domain.Devices.Interfaces = append(setup.Vmi.Spec.Domain.Devices.Interfaces,
+				newVMISpecIfaceWithBridgeBinding(networkName))
+			setup.Vmi.Spec.Networks = append(setup.Vmi.Spec.Networks, newVMISpecMultusNetwork(networkName))
+			setup.Domain.Spec.Devices.Interfaces = append(setup.Domain.Spec.Devices.Interfaces, newDomainSpecIface(networkName, mac))
+		})
+
+		It("are reported when the guest agent is not active", func() {
+			setup.Vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+				newVMIStatusIface(networkName, []string{multusIPv4, multusIPv6}, "", "", netvmispec.InfoSourceMultusStatus, 0),
+			}
+
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+
+			Expect(setup.Vmi.Status.Interfaces).To(Equal([]v1.VirtualMachineInstanceNetworkInterface{
+				newVMIStatusIface(networkName, []string{multusIPv4, multusIPv6}, mac, "",
+					netvmispec.NewInfoSource(netvmispec.InfoSourceDomain, netvmispec.InfoSourceMultusStatus),
+					netsetup.DefaultInterfaceQueueCount),
+			}))
+		})
+
+		It("are overridden by the guest agent", func() {
+			setup.addGuestAgentInterfaces(newDomainStatusIface([]string{guestAgentIP}, mac, "eth1"))
+			setup.Vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+				newVMIStatusIface(networkName, []string{multusIPv4, multusIPv6}, "", "", netvmispec.InfoSourceMultusStatus, 0),
+			}
+
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+
+			Expect(setup.Vmi.Status.Interfaces).To(Equal([]v1.VirtualMachineInstanceNetworkInterface{
+				newVMIStatusIface(networkName, []string{guestAgentIP}, mac, "eth1",
+					netvmispec.NewInfoSource(netvmispec.InfoSourceDomain, netvmispec.InfoSourceGuestAgent, netvmispec.InfoSourceMultusStatus),
+					netsetup.DefaultInterfaceQueueCount),
+			}))
+		})
+
+		It("are not taken from a stale guest agent report", func() {
+			setup.Vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+				newVMIStatusIface(networkName, []string{guestAgentIP}, mac, "eth1",
+					netvmispec.NewInfoSource(netvmispec.InfoSourceDomain, netvmispec.InfoSourceGuestAgent, netvmispec.InfoSourceMultusStatus),
+					netsetup.DefaultInterfaceQueueCount),
+			}
+
+			Expect(setup.NetStat.UpdateStatus(setup.Vmi, setup.Domain)).To(Succeed())
+
+			Expect(setup.Vmi.Status.Interfaces).To(Equal([]v1.VirtualMachineInstanceNetworkInterface{
+				newVMIStatusIface(networkName, nil, mac, "",
+					netvmispec.NewInfoSource(netvmispec.InfoSourceDomain, netvmispec.InfoSourceMultusStatus),
+					netsetup.DefaultInterfaceQueueCount),
+			}))
+		})
+	})
+
 	It("run status and expect iface that doesn't exist in VMI spec to NOT be reported", func() {
 		const (
 			primaryNetworkName = "primary"
@@ -0,0 +1,361 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	v1 "kubevirt.io/api/core/v1"
+	quotav1alpha1 "kubevirt.io/api/quota/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+)
+
+// Checker verifies that a VirtualMachineInstance fits into the VirtualMachineQuotas of its namespace
+type Checker interface {
+	// Check returns an error if starting a VirtualMachineInstance with the given spec exceeds a quota.
+	// An existing VirtualMachineInstance called name is not counted, so restarting it is not limited by itself.
+	Check(namespace, name string, spec *v1.VirtualMachineInstanceSpec) error
+	// Reserve checks the guest resources of a new VirtualMachineInstance and adds them to the usage in the
+	// status of the quotas before it is admitted, so that parallel requests can not exceed a quota together.
+	Reserve(namespace string, spec *v1.VirtualMachineInstanceSpec) error
+	// CheckUpdate returns an error if the guest resources added by changing a running VirtualMachineInstance
+	// from oldSpec to newSpec, e.g. by hotplugging CPUs or memory, exceed a quota.
+	CheckUpdate(namespace string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error
+	// ReserveUpdate checks the guest resources added by the change like CheckUpdate and adds them to the
+	// usage in the status of the quotas.
+	ReserveUpdate(namespace string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error
+}
+
+// informerChecker looks the quotas up in an informer and relies on the usage which the quota
+// controller keeps in their status, so that admitting a VirtualMachineInstance does not list
+// all VirtualMachineInstances of the namespace
+type informerChecker struct {
+	client        kubecli.KubevirtClient
+	quotaInformer cache.SharedIndexInformer
+}
+
+func NewChecker(client kubecli.KubevirtClient, quotaInformer cache.SharedIndexInformer) Checker {
+	return &informerChecker{client: client, quotaInformer: quotaInformer}
+}
+
+func (c *informerChecker) Check(namespace, name string, spec *v1.VirtualMachineInstanceSpec) error {
+	quotas, err := c.quotas(namespace)
+	if err != nil || len(quotas) == 0 {
+		return err
+	}
+
+	used, err := c.usage(namespace, quotas)
+	if err != nil {
+		return err
+	}
+	requested := GuestResources(spec)
+	checkErr := checkQuotasWithUsage(quotas, used, requested)
+	if checkErr == nil {
+		return nil
+	}
+
+	// The usage includes the VirtualMachineInstance which is restarted, it must not limit itself.
+	// It is only looked up when a quota is exceeded, which is rare.
+	vmi, err := c.client.VirtualMachineInstance(namespace).Get(context.Background(), name, &metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return checkErr
+	} else if err != nil {
+		return fmt.Errorf("failed to get VirtualMachineInstance %s: %v", name, err)
+	}
+	if vmi.IsFinal() {
+		return checkErr
+	}
+	own := GuestResources(&vmi.Spec)
+	for i := range used {
+		subtract(used[i], own)
+	}
+	return checkQuotasWithUsage(quotas, used, requested)
+}
+
+func (c *informerChecker) Reserve(namespace string, spec *v1.VirtualMachineInstanceSpec) error {
+	return c.reserveAll(namespace, GuestResources(spec))
+}
+
+func (c *informerChecker) CheckUpdate(namespace string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error {
+	added := addedGuestResources(oldSpec, newSpec)
+	if len(added) == 0 {
+		return nil
+	}
+	quotas, err := c.quotas(namespace)
+	if err != nil || len(quotas) == 0 {
+		return err
+	}
+	used, err := c.usage(namespace, quotas)
+	if err != nil {
+		return err
+	}
+	return checkQuotasWithUsage(quotas, used, added)
+}
+
+func (c *informerChecker) ReserveUpdate(namespace string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error {
+	added := addedGuestResources(oldSpec, newSpec)
+	if len(added) == 0 {
+		return nil
+	}
+	return c.reserveAll(namespace, added)
+}
+
+func (c *informerChecker) quotas(namespace string) ([]quotav1alpha1.VirtualMachineQuota, error) {
+	objs, err := c.quotaInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up VirtualMachineQuotas: %v", err)
+	}
+	quotas := make([]quotav1alpha1.VirtualMachineQuota, 0, len(objs))
+	for _, obj := range objs {
+		quotas = append(quotas, *obj.(*quotav1alpha1.VirtualMachineQuota))
+	}
+	return quotas, nil
+}
+
+func (c *informerChecker) reserveAll(namespace string, requested k8sv1.ResourceList) error {
+	quotas, err := c.quotas(namespace)
+	if err != nil {
+		return err
+	}
+	for i := range quotas {
+		if err := c.reserve(&quotas[i], requested); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reserve adds the requested resources to the usage in the status of a quota. Like the ResourceQuota admission
+// of Kubernetes the update is guarded by the resourceVersion, so a concurrent reservation makes it fail with a
+// conflict and the check is repeated with the latest quota. The quota controller corrects the usage later on,
+// e.g. if the VirtualMachineInstance is not created after all.
+func (c *informerChecker) reserve(vmQuota *quotav1alpha1.VirtualMachineQuota, requested k8sv1.ResourceList) error {
+	latest := false
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if latest {
+			updated, err := c.client.VirtualMachineQuota(vmQuota.Namespace).Get(context.Background(), vmQuota.Name, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get VirtualMachineQuota %s: %v", vmQuota.Name, err)
+			}
+			vmQuota = updated
+		}
+		latest = true
+
+		quotas := []quotav1alpha1.VirtualMachineQuota{*vmQuota}
+		used, err := c.usage(vmQuota.Namespace, quotas)
+		if err != nil {
+			return err
+		}
+		if err := CheckQuotas(quotas, used[0], requested); err != nil {
+			return err
+		}
+		add(used[0], requested)
+
+		vmQuotaCopy := vmQuota.DeepCopy()
+		vmQuotaCopy.Status.Hard = vmQuota.Spec.Hard.DeepCopy()
+		vmQuotaCopy.Status.Used = k8sv1.ResourceList{}
+		for name := range vmQuota.Spec.Hard {
+			if quantity, ok := used[0][name]; ok {
+				vmQuotaCopy.Status.Used[name] = quantity
+			}
+		}
+		_, err = c.client.VirtualMachineQuota(vmQuota.Namespace).UpdateStatus(context.Background(), vmQuotaCopy, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// usage returns the usage of every quota from its status. The VirtualMachineInstances are only listed
+// if the quota controller did not yet calculate the usage of a quota for its current hard limits.
+func (c *informerChecker) usage(namespace string, quotas []quotav1alpha1.VirtualMachineQuota) ([]k8sv1.ResourceList, error) {
+	var listed k8sv1.ResourceList
+	used := make([]k8sv1.ResourceList, 0, len(quotas))
+	for _, q := range quotas {
+		if q.Status.Used != nil && equality.Semantic.DeepEqual(q.Status.Hard, q.Spec.Hard) {
+			used = append(used, q.Status.Used.DeepCopy())
+			continue
+		}
+		if listed == nil {
+			vmis, err := c.client.VirtualMachineInstance(namespace).List(context.Background(), &metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list VirtualMachineInstances: %v", err)
+			}
+			var all []*v1.VirtualMachineInstance
+			for i := range vmis.Items {
+				all = append(all, &vmis.Items[i])
+			}
+			listed = Usage(all)
+		}
+		used = append(used, listed.DeepCopy())
+	}
+	return used, nil
+}
+
+func checkQuotasWithUsage(quotas []quotav1alpha1.VirtualMachineQuota, used []k8sv1.ResourceList, requested k8sv1.ResourceList) error {
+	var violations []string
+	for i := range quotas {
+		if err := CheckQuotas(quotas[i:i+1], used[i], requested); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// GuestResources returns the resources of a VirtualMachineInstance which are visible to the guest
+func GuestResources(spec *v1.VirtualMachineInstanceSpec) k8sv1.ResourceList {
+	return k8sv1.ResourceList{
+		quotav1alpha1.ResourceVCPUs:                   *resource.NewQuantity(guestVCPUs(spec), resource.DecimalSI),
+		quotav1alpha1.ResourceGuestMemory:             guestMemory(spec),
+		quotav1alpha1.ResourceVirtualMachineInstances: *resource.NewQuantity(1, resource.DecimalSI),
+	}
+}
+
+// addedGuestResources returns the guest resources which grow when a VirtualMachineInstance changes from
+// oldSpec to newSpec. Resources which stay the same or shrink are left out, they are released by the quota
+// controller once the change is applied.
+func addedGuestResources(oldSpec, newSpec *v1.VirtualMachineInstanceSpec) k8sv1.ResourceList {
+	added := k8sv1.ResourceList{}
+	oldResources := GuestResources(oldSpec)
+	for name, quantity := range GuestResources(newSpec) {
+		diff := quantity.DeepCopy()
+		diff.Sub(oldResources[name])
+		if diff.Sign() > 0 {
+			added[name] = diff
+		}
+	}
+	return added
+}
+
+// guestVCPUs prefers the CPU topology and falls back to the CPU limits or requests, as virt-launcher does
+func guestVCPUs(spec *v1.VirtualMachineInstanceSpec) int64 {
+	if spec.Domain.CPU != nil {
+		if vcpus := hardware.GetNumberOfVCPUs(spec.Domain.CPU); vcpus > 0 {
+			return vcpus
+		}
+	}
+	if cpu, ok := spec.Domain.Resources.Limits[k8sv1.ResourceCPU]; ok {
+		return cpu.Value()
+	}
+	if cpu, ok := spec.Domain.Resources.Requests[k8sv1.ResourceCPU]; ok {
+		return cpu.Value()
+	}
+	return 1
+}
+
+func guestMemory(spec *v1.VirtualMachineInstanceSpec) resource.Quantity {
+	if spec.Domain.Memory != nil && spec.Domain.Memory.Guest != nil {
+		return spec.Domain.Memory.Guest.DeepCopy()
+	}
+	if memory, ok := spec.Domain.Resources.Requests[k8sv1.ResourceMemory]; ok {
+		return memory.DeepCopy()
+	}
+	if memory, ok := spec.Domain.Resources.Limits[k8sv1.ResourceMemory]; ok {
+		return memory.DeepCopy()
+	}
+	return *resource.NewQuantity(0, resource.BinarySI)
+}
+
+// Usage sums up the guest resources of the VirtualMachineInstances which did not reach a final phase
+func Usage(vmis []*v1.VirtualMachineInstance) k8sv1.ResourceList {
+	used := k8sv1.ResourceList{
+		quotav1alpha1.ResourceVCPUs:                   *resource.NewQuantity(0, resource.DecimalSI),
+		quotav1alpha1.ResourceGuestMemory:             *resource.NewQuantity(0, resource.BinarySI),
+		quotav1alpha1.ResourceVirtualMachineInstances: *resource.NewQuantity(0, resource.DecimalSI),
+	}
+	for _, vmi := range vmis {
+		if vmi.IsFinal() {
+			continue
+		}
+		add(used, GuestResources(&vmi.Spec))
+	}
+	return used
+}
+
+func add(list, other k8sv1.ResourceList) {
+	for name, quantity := range other {
+		sum := list[name]
+		sum.Add(quantity)
+		list[name] = sum
+	}
+}
+
+// subtract only touches the resources which are part of list
+func subtract(list, other k8sv1.ResourceList) {
+	for name, quantity := range other {
+		if diff, ok := list[name]; ok {
+			diff.Sub(quantity)
+			list[name] = diff
+		}
+	}
+}
+
+// CheckQuotas returns an error naming every quota and resource whose hard limit is exceeded by the requested resources
+func CheckQuotas(quotas []quotav1alpha1.VirtualMachineQuota, used, requested k8sv1.ResourceList) error {
+	var violations []string
+	for _, q := range quotas {
+		var names []string
+		for name := range q.Spec.Hard {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+
+		var requestedMsg, usedMsg, limitedMsg []string
+		for _, n := range names {
+			name := k8sv1.ResourceName(n)
+			hard := q.Spec.Hard[name]
+			req, ok := requested[name]
+			if !ok {
+				continue
+			}
+			total := used[name]
+			total.Add(req)
+			if total.Cmp(hard) <= 0 {
+				continue
+			}
+			current := used[name]
+			requestedMsg = append(requestedMsg, fmt.Sprintf("%s=%s", name, req.String()))
+			usedMsg = append(usedMsg, fmt.Sprintf("%s=%s", name, current.String()))
+			limitedMsg = append(limitedMsg, fmt.Sprintf("%s=%s", name, hard.String()))
+		}
+		if len(limitedMsg) > 0 {
+			violations = append(violations, fmt.Sprintf("exceeded VirtualMachineQuota: %s, requested: %s, used: %s, limited: %s",
+				q.Name, strings.Join(requestedMsg, ","), strings.Join(usedMsg, ","), strings.Join(limitedMsg, ",")))
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%s", strings.Join(violations, "; "))
+	}
+	return nil
+}
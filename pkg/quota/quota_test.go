@@ -0,0 +1,304 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gomegatypes "github.com/onsi/gomega/types"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+	quotav1alpha1 "kubevirt.io/api/quota/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+)
+
+var _ = Describe("VirtualMachineQuota", func() {
+	const namespace = "tenant"
+
+	newSpec := func(cores uint32, memory string) *v1.VirtualMachineInstanceSpec {
+		guest := resource.MustParse(memory)
+		return &v1.VirtualMachineInstanceSpec{
+			Domain: v1.DomainSpec{
+				CPU:    &v1.CPU{Cores: cores},
+				Memory: &v1.Memory{Guest: &guest},
+			},
+		}
+	}
+
+	newVMI := func(name string, cores uint32, memory string, phase v1.VirtualMachineInstancePhase) *v1.VirtualMachineInstance {
+		return &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       *newSpec(cores, memory),
+			Status:     v1.VirtualMachineInstanceStatus{Phase: phase},
+		}
+	}
+
+	newQuota := func(name string, hard k8sv1.ResourceList) *quotav1alpha1.VirtualMachineQuota {
+		return &quotav1alpha1.VirtualMachineQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       quotav1alpha1.VirtualMachineQuotaSpec{Hard: hard},
+		}
+	}
+
+	Context("GuestResources", func() {
+		DescribeTable("should count the guest vCPUs", func(spec *v1.VirtualMachineInstanceSpec, expected int64) {
+			vcpus := GuestResources(spec)[quotav1alpha1.ResourceVCPUs]
+			Expect(vcpus.Value()).To(Equal(expected))
+		},
+			Entry("from the CPU topology", &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{CPU: &v1.CPU{Sockets: 2, Cores: 2, Threads: 2}},
+			}, int64(8)),
+			Entry("from the CPU limits", &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{Resources: v1.ResourceRequirements{
+					Requests: k8sv1.ResourceList{k8sv1.ResourceCPU: resource.MustParse("2")},
+					Limits:   k8sv1.ResourceList{k8sv1.ResourceCPU: resource.MustParse("3")},
+				}},
+			}, int64(3)),
+			Entry("from the CPU requests", &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{Resources: v1.ResourceRequirements{
+					Requests: k8sv1.ResourceList{k8sv1.ResourceCPU: resource.MustParse("1500m")},
+				}},
+			}, int64(2)),
+			Entry("as a single vCPU by default", &v1.VirtualMachineInstanceSpec{}, int64(1)),
+		)
+
+		DescribeTable("should count the guest memory", func(spec *v1.VirtualMachineInstanceSpec, expected string) {
+			memory := GuestResources(spec)[quotav1alpha1.ResourceGuestMemory]
+			Expect(memory.Cmp(resource.MustParse(expected))).To(BeZero())
+		},
+			Entry("from the guest memory", newSpec(1, "2Gi"), "2Gi"),
+			Entry("from the memory requests", &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{Resources: v1.ResourceRequirements{
+					Requests: k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits:   k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("4Gi")},
+				}},
+			}, "1Gi"),
+			Entry("from the memory limits", &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{Resources: v1.ResourceRequirements{
+					Limits: k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("4Gi")},
+				}},
+			}, "4Gi"),
+			Entry("as zero without any memory", &v1.VirtualMachineInstanceSpec{}, "0"),
+		)
+	})
+
+	Context("Usage", func() {
+		It("should sum up the VMIs which are not final", func() {
+			used := Usage([]*v1.VirtualMachineInstance{
+				newVMI("running", 2, "2Gi", v1.Running),
+				newVMI("pending", 1, "1Gi", v1.Pending),
+				newVMI("failed", 8, "8Gi", v1.Failed),
+			})
+			Expect(used.Name(quotav1alpha1.ResourceVCPUs, resource.DecimalSI).Value()).To(Equal(int64(3)))
+			Expect(used.Name(quotav1alpha1.ResourceGuestMemory, resource.BinarySI).Cmp(resource.MustParse("3Gi"))).To(BeZero())
+			Expect(used.Name(quotav1alpha1.ResourceVirtualMachineInstances, resource.DecimalSI).Value()).To(Equal(int64(2)))
+		})
+
+		It("should report zero usage without VMIs", func() {
+			used := Usage(nil)
+			Expect(used).To(HaveLen(3))
+			for _, quantity := range used {
+				Expect(quantity.IsZero()).To(BeTrue())
+			}
+		})
+	})
+
+	Context("CheckQuotas", func() {
+		used := k8sv1.ResourceList{
+			quotav1alpha1.ResourceVCPUs:       resource.MustParse("4"),
+			quotav1alpha1.ResourceGuestMemory: resource.MustParse("4Gi"),
+		}
+
+		It("should allow requests within the quota", func() {
+			quotas := []quotav1alpha1.VirtualMachineQuota{*newQuota("quota", k8sv1.ResourceList{
+				quotav1alpha1.ResourceVCPUs:       resource.MustParse("6"),
+				quotav1alpha1.ResourceGuestMemory: resource.MustParse("8Gi"),
+			})}
+			Expect(CheckQuotas(quotas, used, GuestResources(newSpec(2, "4Gi")))).To(Succeed())
+		})
+
+		It("should name every exceeded quota and resource", func() {
+			quotas := []quotav1alpha1.VirtualMachineQuota{
+				*newQuota("cpu", k8sv1.ResourceList{quotav1alpha1.ResourceVCPUs: resource.MustParse("5")}),
+				*newQuota("all", k8sv1.ResourceList{
+					quotav1alpha1.ResourceVCPUs:       resource.MustParse("5"),
+					quotav1alpha1.ResourceGuestMemory: resource.MustParse("6Gi"),
+				}),
+			}
+			err := CheckQuotas(quotas, used, GuestResources(newSpec(2, "4Gi")))
+			Expect(err).To(MatchError("exceeded VirtualMachineQuota: cpu, requested: vcpus=2, used: vcpus=4, limited: vcpus=5; " +
+				"exceeded VirtualMachineQuota: all, requested: guest.memory=4Gi,vcpus=2, used: guest.memory=4Gi,vcpus=4, limited: guest.memory=6Gi,vcpus=5"))
+		})
+	})
+
+	Context("Checker", func() {
+		var (
+			virtClient    *kubecli.MockKubevirtClient
+			vmiInterface  *kubecli.MockVirtualMachineInstanceInterface
+			quotaInformer cache.SharedIndexInformer
+			checker       Checker
+		)
+
+		BeforeEach(func() {
+			ctrl := gomock.NewController(GinkgoT())
+			virtClient = kubecli.NewMockKubevirtClient(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+			virtClient.EXPECT().VirtualMachineInstance(namespace).Return(vmiInterface).AnyTimes()
+			quotaInformer = cache.NewSharedIndexInformer(nil, &quotav1alpha1.VirtualMachineQuota{}, 0, cache.Indexers{
+				cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+			})
+			checker = NewChecker(virtClient, quotaInformer)
+		})
+
+		addQuota := func(q *quotav1alpha1.VirtualMachineQuota, used k8sv1.ResourceList) {
+			if used != nil {
+				q.Status.Hard = q.Spec.Hard.DeepCopy()
+				q.Status.Used = used
+			}
+			Expect(quotaInformer.GetStore().Add(q)).To(Succeed())
+		}
+
+		vcpus := func(quantity string) k8sv1.ResourceList {
+			return k8sv1.ResourceList{quotav1alpha1.ResourceVCPUs: resource.MustParse(quantity)}
+		}
+
+		It("should not look up VMIs without a quota in the namespace", func() {
+			q := newQuota("quota", vcpus("1"))
+			q.Namespace = "other"
+			addQuota(q, vcpus("0"))
+			Expect(checker.Check(namespace, "testvmi", newSpec(64, "256Gi"))).To(Succeed())
+		})
+
+		It("should use the usage from the quota status without listing VMIs", func() {
+			addQuota(newQuota("quota", vcpus("4")), vcpus("2"))
+			Expect(checker.Check(namespace, "testvmi", newSpec(2, "1Gi"))).To(Succeed())
+		})
+
+		It("should reject a VMI exceeding the quota", func() {
+			addQuota(newQuota("quota", vcpus("4")), vcpus("2"))
+			vmiInterface.EXPECT().Get(gomock.Any(), "testvmi", gomock.Any()).Return(nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), "testvmi"))
+			Expect(checker.Check(namespace, "testvmi", newSpec(4, "1Gi"))).To(MatchError(ContainSubstring("exceeded VirtualMachineQuota: quota")))
+		})
+
+		It("should not count the VMI which is started", func() {
+			addQuota(newQuota("quota", vcpus("4")), vcpus("4"))
+			vmiInterface.EXPECT().Get(gomock.Any(), "testvmi", gomock.Any()).Return(newVMI("testvmi", 4, "1Gi", v1.Running), nil)
+			Expect(checker.Check(namespace, "testvmi", newSpec(4, "1Gi"))).To(Succeed())
+		})
+
+		It("should count the VMIs if the usage was not calculated yet", func() {
+			addQuota(newQuota("quota", vcpus("4")), nil)
+			vmiInterface.EXPECT().List(gomock.Any(), gomock.Any()).Return(&v1.VirtualMachineInstanceList{
+				Items: []v1.VirtualMachineInstance{*newVMI("other", 2, "1Gi", v1.Running)},
+			}, nil)
+			vmiInterface.EXPECT().Get(gomock.Any(), "testvmi", gomock.Any()).Return(nil, errors.NewNotFound(v1.Resource("virtualmachineinstance"), "testvmi"))
+			Expect(checker.Check(namespace, "testvmi", newSpec(4, "1Gi"))).To(MatchError(ContainSubstring("exceeded VirtualMachineQuota: quota")))
+		})
+
+		DescribeTable("should only check the guest resources added by an update", func(oldSpec, newSpec *v1.VirtualMachineInstanceSpec, matcher gomegatypes.GomegaMatcher) {
+			addQuota(newQuota("quota", vcpus("4")), vcpus("3"))
+			Expect(checker.CheckUpdate(namespace, oldSpec, newSpec)).To(matcher)
+		},
+			Entry("allowing an update within the quota", newSpec(2, "1Gi"), newSpec(3, "1Gi"), Succeed()),
+			Entry("rejecting an update exceeding the quota", newSpec(2, "1Gi"), newSpec(4, "1Gi"), MatchError(ContainSubstring("exceeded VirtualMachineQuota: quota"))),
+		)
+
+		It("should allow an update which does not add guest resources to an exceeded quota", func() {
+			addQuota(newQuota("quota", vcpus("4")), vcpus("6"))
+			Expect(checker.CheckUpdate(namespace, newSpec(2, "1Gi"), newSpec(1, "2Gi"))).To(Succeed())
+		})
+
+		Context("Reserve", func() {
+			var quotaClient *kubevirtfake.Clientset
+
+			addReservableQuota := func(q *quotav1alpha1.VirtualMachineQuota, used k8sv1.ResourceList) {
+				addQuota(q, used)
+				quotaClient = kubevirtfake.NewSimpleClientset(q)
+				virtClient.EXPECT().VirtualMachineQuota(namespace).Return(quotaClient.QuotaV1alpha1().VirtualMachineQuotas(namespace)).AnyTimes()
+			}
+
+			getUsedVCPUs := func() int64 {
+				q, err := quotaClient.QuotaV1alpha1().VirtualMachineQuotas(namespace).Get(context.Background(), "quota", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(q.Status.Used).To(HaveLen(1))
+				return q.Status.Used.Name(quotav1alpha1.ResourceVCPUs, resource.DecimalSI).Value()
+			}
+
+			It("should add the guest resources to the usage in the quota status", func() {
+				addReservableQuota(newQuota("quota", vcpus("4")), vcpus("1"))
+				Expect(checker.Reserve(namespace, newSpec(2, "1Gi"))).To(Succeed())
+				Expect(getUsedVCPUs()).To(Equal(int64(3)))
+			})
+
+			It("should not reserve guest resources exceeding the quota", func() {
+				addReservableQuota(newQuota("quota", vcpus("4")), vcpus("3"))
+				Expect(checker.Reserve(namespace, newSpec(2, "1Gi"))).To(MatchError(ContainSubstring("exceeded VirtualMachineQuota: quota")))
+				Expect(getUsedVCPUs()).To(Equal(int64(3)))
+			})
+
+			It("should add the guest resources added by an update to the usage in the quota status", func() {
+				addReservableQuota(newQuota("quota", vcpus("4")), vcpus("2"))
+				Expect(checker.ReserveUpdate(namespace, newSpec(2, "1Gi"), newSpec(3, "1Gi"))).To(Succeed())
+				Expect(getUsedVCPUs()).To(Equal(int64(3)))
+			})
+
+			It("should not reserve an update exceeding the quota", func() {
+				addReservableQuota(newQuota("quota", vcpus("4")), vcpus("3"))
+				Expect(checker.ReserveUpdate(namespace, newSpec(2, "1Gi"), newSpec(4, "1Gi"))).To(MatchError(ContainSubstring("exceeded VirtualMachineQuota: quota")))
+				Expect(getUsedVCPUs()).To(Equal(int64(3)))
+			})
+
+			It("should check the latest quota after a concurrent reservation", func() {
+				addReservableQuota(newQuota("quota", vcpus("4")), vcpus("1"))
+				// Another request reserved two vCPUs after the informer was updated
+				concurrent, err := quotaClient.QuotaV1alpha1().VirtualMachineQuotas(namespace).Get(context.Background(), "quota", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				concurrent.Status.Used = vcpus("3")
+				_, err = quotaClient.QuotaV1alpha1().VirtualMachineQuotas(namespace).UpdateStatus(context.Background(), concurrent, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				conflicted := false
+				quotaClient.PrependReactor("update", "virtualmachinequotas", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					if conflicted {
+						return false, nil, nil
+					}
+					conflicted = true
+					return true, nil, errors.NewConflict(quotav1alpha1.Resource("virtualmachinequotas"), "quota", fmt.Errorf("modified"))
+				})
+
+				Expect(checker.Reserve(namespace, newSpec(2, "1Gi"))).To(MatchError(ContainSubstring("exceeded VirtualMachineQuota: quota")))
+				Expect(conflicted).To(BeTrue())
+				Expect(getUsedVCPUs()).To(Equal(int64(3)))
+			})
+		})
+	})
+})
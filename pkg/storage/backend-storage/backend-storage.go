@@ -21,26 +21,51 @@ package backendstorage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
-	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
-
-	"k8s.io/apimachinery/pkg/api/errors"
-
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
 	corev1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
 	PVCPrefix = "persistent-state-for-"
-	PVCSize   = "10Mi"
+
+	// PVCLabel is set on every backend storage PVC, its value is the name of the VM the state belongs to
+	PVCLabel = "kubevirt.io/persistent-state-for"
+	// PVCOwnerUIDAnnotation records the UID of the VM, or of the standalone VMI, the state in the backend storage PVC
+	// belongs to. Retained PVCs outlive their VM, the UID keeps a new VM with the same name from adopting them.
+	PVCOwnerUIDAnnotation = "kubevirt.io/persistent-state-owner-uid"
+	// VolumeName identifies the backend storage PVC among the volume backups of a VirtualMachineSnapshot.
+	// It is not a valid disk name, so it can not collide with the volumes of the VM.
+	VolumeName = "persistent-state.kubevirt.io"
+
+	// TPMEncryptionVolumeName is the name of the volume carrying the Secret with the TPM state encryption key
+	TPMEncryptionVolumeName = "tpm-state-encryption"
+	// TPMEncryptionSecretKey is the Secret entry holding the TPM state encryption key
+	TPMEncryptionSecretKey = "key"
 )
 
 func PVCForVMI(vmi *corev1.VirtualMachineInstance) string {
-	return PVCPrefix + vmi.Name
+	return PVCForVMName(vmi.Name)
+}
+
+func PVCForVM(vm *corev1.VirtualMachine) string {
+	return PVCForVMName(vm.Name)
+}
+
+func PVCForVMName(name string) string {
+	return PVCPrefix + name
 }
 
 func HasPersistentTPMDevice(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
@@ -49,6 +74,12 @@ func HasPersistentTPMDevice(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
 		*vmiSpec.Domain.Devices.TPM.Persistent
 }
 
+func HasEncryptedTPMState(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
+	return HasPersistentTPMDevice(vmiSpec) &&
+		vmiSpec.Domain.Devices.TPM.Encryption != nil &&
+		vmiSpec.Domain.Devices.TPM.Encryption.SecretRef != nil
+}
+
 func HasPersistentEFI(vmiSpec *corev1.VirtualMachineInstanceSpec) bool {
 	return vmiSpec.Domain.Firmware != nil &&
 		vmiSpec.Domain.Firmware.Bootloader != nil &&
@@ -65,7 +96,140 @@ func IsBackendStorageNeededForVM(vm *corev1.VirtualMachine) bool {
 	if vm.Spec.Template == nil {
 		return false
 	}
-	return HasPersistentTPMDevice(&vm.Spec.Template.Spec)
+	return IsBackendStorageNeededForVMI(&vm.Spec.Template.Spec)
+}
+
+// ownerReferences returns the owners the backend storage PVC of the VMI should have.
+// If the VMI has no owner, then it did not originate from a VM.
+// In that case, we tie the PVC to the VMI, rendering it quite useless since it wont actually persist.
+// The alternative would allow the PVC to persist after the VMI is deleted,
+// however that would pose security and littering concerns.
+// PVCs of VMs are owned by the VM, unless the cluster retention policy asks to keep them.
+// Retained PVCs are left behind on purpose, they are never handed to a new VM with the same name, see ownerUID.
+func ownerReferences(vmi *corev1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig) []metav1.OwnerReference {
+	if len(vmi.OwnerReferences) == 0 {
+		return []metav1.OwnerReference{
+			*metav1.NewControllerRef(vmi, corev1.VirtualMachineInstanceGroupVersionKind),
+		}
+	}
+	if clusterConfig.GetVMStateRetentionPolicy() == corev1.VMStateRetentionPolicyRetain {
+		return nil
+	}
+	return vmi.OwnerReferences
+}
+
+// ownerUID returns the UID of the object the state of the VMI belongs to, the VM or the VMI itself if it has no owner
+func ownerUID(vmi *corev1.VirtualMachineInstance) types.UID {
+	if owner := metav1.GetControllerOf(vmi); owner != nil {
+		return owner.UID
+	}
+	return vmi.UID
+}
+
+// recordedOwnerUID returns the UID of the object the state in the PVC belongs to. PVCs created before the UID was
+// recorded fall back to their controller. PVCs restored from a snapshot have neither, they are adopted by the VM
+// they were restored for.
+func recordedOwnerUID(pvc *v1.PersistentVolumeClaim) types.UID {
+	if uid, exists := pvc.Annotations[PVCOwnerUIDAnnotation]; exists {
+		return types.UID(uid)
+	}
+	if owner := metav1.GetControllerOf(pvc); owner != nil {
+		return owner.UID
+	}
+	return ""
+}
+
+// accessModeForStorageClass picks the access mode of the backend storage PVC from the CDI StorageProfile of the
+// storage class. RWX is preferred since it keeps the VM migratable, RWO is used if the storage class has nothing better.
+func accessModeForStorageClass(storageClass string, client kubecli.KubevirtClient) (v1.PersistentVolumeAccessMode, error) {
+	storageProfile, err := client.CdiClient().CdiV1beta1().StorageProfiles().Get(context.Background(), storageClass, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return v1.ReadWriteMany, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	accessMode := v1.PersistentVolumeAccessMode("")
+	for _, claimPropertySet := range storageProfile.Status.ClaimPropertySets {
+		if claimPropertySet.VolumeMode != nil && *claimPropertySet.VolumeMode != v1.PersistentVolumeFilesystem {
+			continue
+		}
+		for _, mode := range claimPropertySet.AccessModes {
+			switch mode {
+			case v1.ReadWriteMany:
+				return v1.ReadWriteMany, nil
+			case v1.ReadWriteOnce:
+				accessMode = v1.ReadWriteOnce
+			}
+		}
+	}
+	if accessMode == "" {
+		return v1.ReadWriteMany, nil
+	}
+	return accessMode, nil
+}
+
+// reconcileMetadata makes sure an existing backend storage PVC carries the expected label, owner UID and owners.
+// PVCs holding the state of another VM or VMI with the same name are refused.
+func reconcileMetadata(pvc *v1.PersistentVolumeClaim, vmi *corev1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig, client kubecli.KubevirtClient) error {
+	uid := ownerUID(vmi)
+	if recordedUID := recordedOwnerUID(pvc); recordedUID != "" && recordedUID != uid {
+		return fmt.Errorf("backend storage PVC %s holds the state of a previous owner with UID %s, it has to be deleted before %s can start", pvc.Name, recordedUID, vmi.Name)
+	}
+
+	owners := ownerReferences(vmi, clusterConfig)
+	if pvc.Labels[PVCLabel] == vmi.Name && pvc.Annotations[PVCOwnerUIDAnnotation] == string(uid) &&
+		equality.Semantic.DeepEqual(pvc.OwnerReferences, owners) {
+		return nil
+	}
+
+	patchBytes, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":          map[string]string{PVCLabel: vmi.Name},
+			"annotations":     map[string]string{PVCOwnerUIDAnnotation: string(uid)},
+			"ownerReferences": owners,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Patch(context.Background(), pvc.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
+
+// expandIfNeeded grows an existing backend storage PVC to the configured size. PVCs are never shrunk,
+// and only expanded if their storage class allows volume expansion.
+func expandIfNeeded(pvc *v1.PersistentVolumeClaim, size resource.Quantity, client kubecli.KubevirtClient) error {
+	if current, exists := pvc.Spec.Resources.Requests[v1.ResourceStorage]; exists && current.Cmp(size) >= 0 {
+		return nil
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return nil
+	}
+
+	storageClass, err := client.StorageV1().StorageClasses().Get(context.Background(), *pvc.Spec.StorageClassName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		log.Log.Object(pvc).V(3).Infof("Not expanding the backend storage PVC to %s, storage class %s does not allow volume expansion", size.String(), storageClass.Name)
+		return nil
+	}
+
+	patchBytes, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"resources": map[string]interface{}{
+				"requests": map[string]string{string(v1.ResourceStorage): size.String()},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Patch(context.Background(), pvc.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+	return err
 }
 
 func CreateIfNeeded(vmi *corev1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig, client kubecli.KubevirtClient) error {
@@ -73,9 +237,12 @@ func CreateIfNeeded(vmi *corev1.VirtualMachineInstance, clusterConfig *virtconfi
 		return nil
 	}
 
-	_, err := client.CoreV1().PersistentVolumeClaims(vmi.Namespace).Get(context.Background(), PVCForVMI(vmi), metav1.GetOptions{})
+	existing, err := client.CoreV1().PersistentVolumeClaims(vmi.Namespace).Get(context.Background(), PVCForVMI(vmi), metav1.GetOptions{})
 	if err == nil {
-		return nil
+		if err := reconcileMetadata(existing, vmi, clusterConfig, client); err != nil {
+			return err
+		}
+		return expandIfNeeded(existing, clusterConfig.GetVMStateStorageSize(), client)
 	}
 	if !errors.IsNotFound(err) {
 		return err
@@ -86,25 +253,21 @@ func CreateIfNeeded(vmi *corev1.VirtualMachineInstance, clusterConfig *virtconfi
 	if storageClass == "" {
 		return fmt.Errorf("backend VM storage requires a backend storage class defined in the custom resource")
 	}
-	ownerReferences := vmi.OwnerReferences
-	if len(vmi.OwnerReferences) == 0 {
-		// If the VMI has no owner, then it did not originate from a VM.
-		// In that case, we tie the PVC to the VMI, rendering it quite useless since it wont actually persist.
-		// The alternative is to remove this `if` block, allowing the PVC to persist after the VMI is deleted.
-		// However, that would pose security and littering concerns.
-		ownerReferences = []metav1.OwnerReference{
-			*metav1.NewControllerRef(vmi, corev1.VirtualMachineInstanceGroupVersionKind),
-		}
+	accessMode, err := accessModeForStorageClass(storageClass, client)
+	if err != nil {
+		return err
 	}
 	pvc := &v1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            PVCForVMI(vmi),
-			OwnerReferences: ownerReferences,
+			Labels:          map[string]string{PVCLabel: vmi.Name},
+			Annotations:     map[string]string{PVCOwnerUIDAnnotation: string(ownerUID(vmi))},
+			OwnerReferences: ownerReferences(vmi, clusterConfig),
 		},
 		Spec: v1.PersistentVolumeClaimSpec{
-			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteMany},
+			AccessModes: []v1.PersistentVolumeAccessMode{accessMode},
 			Resources: v1.ResourceRequirements{
-				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(PVCSize)},
+				Requests: v1.ResourceList{v1.ResourceStorage: clusterConfig.GetVMStateStorageSize()},
 			},
 			StorageClassName: &storageClass,
 			VolumeMode:       &modeFile,
@@ -0,0 +1,281 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package backendstorage
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/api/core/v1"
+	cdifake "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Backend storage", func() {
+	const storageClass = "state-storage"
+
+	var (
+		k8sClient *k8sfake.Clientset
+		cdiClient *cdifake.Clientset
+		client    kubecli.KubevirtClient
+		vm        *v1.VirtualMachine
+		vmi       *v1.VirtualMachineInstance
+	)
+
+	newClusterConfig := func(policy v1.VMStateRetentionPolicy) *virtconfig.ClusterConfig {
+		config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			VMStateStorageClass:    storageClass,
+			VMStateRetentionPolicy: policy,
+		})
+		return config
+	}
+
+	newClusterConfigWithSize := func(size string) *virtconfig.ClusterConfig {
+		quantity := resource.MustParse(size)
+		config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			VMStateStorageClass: storageClass,
+			VMStateStorageSize:  &quantity,
+		})
+		return config
+	}
+
+	getPVC := func() *k8sv1.PersistentVolumeClaim {
+		pvc, err := k8sClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Get(context.Background(), PVCForVMI(vmi), metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return pvc
+	}
+
+	BeforeEach(func() {
+		k8sClient = k8sfake.NewSimpleClientset()
+		cdiClient = cdifake.NewSimpleClientset()
+		virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+		virtClient.EXPECT().CdiClient().Return(cdiClient).AnyTimes()
+		virtClient.EXPECT().StorageV1().Return(k8sClient.StorageV1()).AnyTimes()
+		client = virtClient
+
+		vm = &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "testvm",
+				Namespace: "default",
+				UID:       "vm-uid",
+			},
+		}
+		vmi = &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vm.Name,
+				Namespace: vm.Namespace,
+				UID:       "vmi-uid",
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind),
+				},
+			},
+		}
+		vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{Persistent: pointer.Bool(true)}
+	})
+
+	Context("CreateIfNeeded", func() {
+		It("should not create a PVC if the VMI does not need backend storage", func() {
+			vmi.Spec.Domain.Devices.TPM = nil
+			Expect(CreateIfNeeded(vmi, newClusterConfig(""), client)).To(Succeed())
+			Expect(k8sClient.Actions()).To(BeEmpty())
+		})
+
+		It("should create a labelled PVC owned by the VM", func() {
+			Expect(CreateIfNeeded(vmi, newClusterConfig(""), client)).To(Succeed())
+
+			pvc := getPVC()
+			Expect(pvc.Labels).To(HaveKeyWithValue(PVCLabel, vm.Name))
+			Expect(pvc.OwnerReferences).To(Equal(vmi.OwnerReferences))
+			Expect(pvc.Spec.StorageClassName).To(HaveValue(Equal(storageClass)))
+			Expect(pvc.Spec.AccessModes).To(ConsistOf(k8sv1.ReadWriteMany))
+			Expect(pvc.Spec.Resources.Requests).To(HaveKeyWithValue(k8sv1.ResourceStorage, resource.MustParse(virtconfig.DefaultVMStateStorageSize)))
+		})
+
+		It("should create the PVC with the configured size", func() {
+			Expect(CreateIfNeeded(vmi, newClusterConfigWithSize("20Mi"), client)).To(Succeed())
+			Expect(getPVC().Spec.Resources.Requests).To(HaveKeyWithValue(k8sv1.ResourceStorage, resource.MustParse("20Mi")))
+		})
+
+		DescribeTable("should reconcile the size of an existing PVC", func(allowVolumeExpansion *bool, existingSize, configuredSize, expectedSize string) {
+			_, err := k8sClient.StorageV1().StorageClasses().Create(context.Background(), &storagev1.StorageClass{
+				ObjectMeta:           metav1.ObjectMeta{Name: storageClass},
+				AllowVolumeExpansion: allowVolumeExpansion,
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			storageClassName := storageClass
+			_, err = k8sClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            PVCForVMI(vmi),
+					Namespace:       vmi.Namespace,
+					Labels:          map[string]string{PVCLabel: vmi.Name},
+					OwnerReferences: vmi.OwnerReferences,
+				},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					StorageClassName: &storageClassName,
+					Resources: k8sv1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse(existingSize)},
+					},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(CreateIfNeeded(vmi, newClusterConfigWithSize(configuredSize), client)).To(Succeed())
+			Expect(getPVC().Spec.Resources.Requests).To(HaveKeyWithValue(k8sv1.ResourceStorage, resource.MustParse(expectedSize)))
+		},
+			Entry("expanding it if the storage class allows it", pointer.Bool(true), "10Mi", "20Mi", "20Mi"),
+			Entry("not expanding it if the storage class does not allow it", nil, "10Mi", "20Mi", "10Mi"),
+			Entry("never shrinking it", pointer.Bool(true), "20Mi", "10Mi", "20Mi"),
+		)
+
+		It("should tie the PVC of a standalone VMI to the VMI", func() {
+			vmi.OwnerReferences = nil
+			Expect(CreateIfNeeded(vmi, newClusterConfig(v1.VMStateRetentionPolicyRetain), client)).To(Succeed())
+
+			pvc := getPVC()
+			Expect(pvc.OwnerReferences).To(HaveLen(1))
+			Expect(pvc.OwnerReferences[0].UID).To(Equal(vmi.UID))
+		})
+
+		It("should not set owners on the PVC with the Retain policy", func() {
+			Expect(CreateIfNeeded(vmi, newClusterConfig(v1.VMStateRetentionPolicyRetain), client)).To(Succeed())
+
+			pvc := getPVC()
+			Expect(pvc.Labels).To(HaveKeyWithValue(PVCLabel, vm.Name))
+			Expect(pvc.OwnerReferences).To(BeEmpty())
+		})
+
+		DescribeTable("should pick the access mode from the StorageProfile", func(accessModes []k8sv1.PersistentVolumeAccessMode, expectedAccessMode k8sv1.PersistentVolumeAccessMode) {
+			filesystem := k8sv1.PersistentVolumeFilesystem
+			_, err := cdiClient.CdiV1beta1().StorageProfiles().Create(context.Background(), &cdiv1.StorageProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: storageClass},
+				Status: cdiv1.StorageProfileStatus{
+					ClaimPropertySets: []cdiv1.ClaimPropertySet{{
+						AccessModes: accessModes,
+						VolumeMode:  &filesystem,
+					}},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(CreateIfNeeded(vmi, newClusterConfig(""), client)).To(Succeed())
+			Expect(getPVC().Spec.AccessModes).To(ConsistOf(expectedAccessMode))
+		},
+			Entry("preferring RWX", []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce, k8sv1.ReadWriteMany}, k8sv1.ReadWriteMany),
+			Entry("falling back to RWO", []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}, k8sv1.ReadWriteOnce),
+			Entry("defaulting to RWX", nil, k8sv1.ReadWriteMany),
+		)
+
+		DescribeTable("should reconcile the label and owners of an existing PVC", func(policy v1.VMStateRetentionPolicy, existingOwners, expectedOwners []metav1.OwnerReference) {
+			_, err := k8sClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            PVCForVMI(vmi),
+					Namespace:       vmi.Namespace,
+					OwnerReferences: existingOwners,
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(CreateIfNeeded(vmi, newClusterConfig(policy), client)).To(Succeed())
+
+			pvc := getPVC()
+			Expect(pvc.Labels).To(HaveKeyWithValue(PVCLabel, vm.Name))
+			if expectedOwners == nil {
+				Expect(pvc.OwnerReferences).To(BeEmpty())
+			} else {
+				Expect(pvc.OwnerReferences).To(Equal(expectedOwners))
+			}
+		},
+			Entry("adding the VM as owner with the Delete policy", v1.VMStateRetentionPolicyDelete, nil, []metav1.OwnerReference{
+				*metav1.NewControllerRef(&v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: "testvm", UID: "vm-uid"}}, v1.VirtualMachineGroupVersionKind),
+			}),
+			Entry("dropping the owners with the Retain policy", v1.VMStateRetentionPolicyRetain, []metav1.OwnerReference{
+				*metav1.NewControllerRef(&v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: "testvm", UID: "vm-uid"}}, v1.VirtualMachineGroupVersionKind),
+			}, nil),
+		)
+
+		It("should record the UID of the VM on the PVC", func() {
+			Expect(CreateIfNeeded(vmi, newClusterConfig(v1.VMStateRetentionPolicyRetain), client)).To(Succeed())
+			Expect(getPVC().Annotations).To(HaveKeyWithValue(PVCOwnerUIDAnnotation, string(vm.UID)))
+		})
+
+		DescribeTable("should not adopt the PVC of a previous VM with the same name", func(annotations map[string]string, owners []metav1.OwnerReference) {
+			_, err := k8sClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            PVCForVMI(vmi),
+					Namespace:       vmi.Namespace,
+					Labels:          map[string]string{PVCLabel: vmi.Name},
+					Annotations:     annotations,
+					OwnerReferences: owners,
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			k8sClient.ClearActions()
+
+			Expect(CreateIfNeeded(vmi, newClusterConfig(v1.VMStateRetentionPolicyRetain), client)).ToNot(Succeed())
+			Expect(k8sClient.Actions()).To(HaveLen(1))
+			Expect(k8sClient.Actions()[0].GetVerb()).To(Equal("get"))
+		},
+			Entry("retained with its UID", map[string]string{PVCOwnerUIDAnnotation: "old-vm-uid"}, nil),
+			Entry("still owned by it", nil, []metav1.OwnerReference{
+				*metav1.NewControllerRef(&v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: "testvm", UID: "old-vm-uid"}}, v1.VirtualMachineGroupVersionKind),
+			}),
+		)
+
+		It("should adopt a restored PVC without a recorded UID", func() {
+			_, err := k8sClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Create(context.Background(), &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      PVCForVMI(vmi),
+					Namespace: vmi.Namespace,
+					Labels:    map[string]string{PVCLabel: vmi.Name},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(CreateIfNeeded(vmi, newClusterConfig(v1.VMStateRetentionPolicyRetain), client)).To(Succeed())
+			Expect(getPVC().Annotations).To(HaveKeyWithValue(PVCOwnerUIDAnnotation, string(vm.UID)))
+		})
+	})
+
+	It("IsBackendStorageNeededForVM should consider persistent EFI", func() {
+		vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{}
+		Expect(IsBackendStorageNeededForVM(vm)).To(BeFalse())
+		vm.Spec.Template.Spec.Domain.Firmware = &v1.Firmware{
+			Bootloader: &v1.Bootloader{
+				EFI: &v1.EFI{Persistent: pointer.Bool(true)},
+			},
+		}
+		Expect(IsBackendStorageNeededForVM(vm)).To(BeTrue())
+	})
+})
@@ -121,6 +121,7 @@ const (
 	manifestData           = "manifest-data"
 	manifestsPath          = "/manifests/all"
 	secretManifestPath     = "/manifests/secret"
+	ovaPath                = "/bundles/ova"
 	externalHostKey        = "external_host"
 	internalHostKey        = "internal_host"
 	externalCaConfigMapKey = "external_ca_cm"
@@ -153,10 +154,26 @@ func rawGzipURI(pvc *corev1.PersistentVolumeClaim) string {
 	return path.Join(fmt.Sprintf("%s/%s/disk.img.gz", urlBasePath, pvc.Name))
 }
 
+func rawSha256URI(pvc *corev1.PersistentVolumeClaim) string {
+	return path.Join(fmt.Sprintf("%s/%s/disk.img.sha256", urlBasePath, pvc.Name))
+}
+
+func qcow2URI(pvc *corev1.PersistentVolumeClaim) string {
+	return path.Join(fmt.Sprintf("%s/%s/disk.qcow2", urlBasePath, pvc.Name))
+}
+
+func qcow2CompressedURI(pvc *corev1.PersistentVolumeClaim) string {
+	return path.Join(fmt.Sprintf("%s/%s/disk-compressed.qcow2", urlBasePath, pvc.Name))
+}
+
 func archiveURI(pvc *corev1.PersistentVolumeClaim) string {
 	return path.Join(fmt.Sprintf("%s/%s/disk.tar.gz", urlBasePath, pvc.Name))
 }
 
+func backupURI(pvc *corev1.PersistentVolumeClaim) string {
+	return path.Join(fmt.Sprintf("%s/%s/backup", urlBasePath, pvc.Name)) + "/"
+}
+
 func dirURI(pvc *corev1.PersistentVolumeClaim) string {
 	return path.Join(fmt.Sprintf("%s/%s/dir", urlBasePath, pvc.Name)) + "/"
 }
@@ -182,6 +199,7 @@ type VMExportController struct {
 	PVCInformer                 cache.SharedIndexInformer
 	VMSnapshotInformer          cache.SharedIndexInformer
 	VMSnapshotContentInformer   cache.SharedIndexInformer
+	VMBackupInformer            cache.SharedIndexInformer
 	PodInformer                 cache.SharedIndexInformer
 	DataVolumeInformer          cache.SharedIndexInformer
 	ConfigMapInformer           cache.SharedIndexInformer
@@ -306,6 +324,16 @@ func (ctrl *VMExportController) Init() error {
 	if err != nil {
 		return err
 	}
+	_, err = ctrl.VMBackupInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMBackup,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMBackup(newObj) },
+			DeleteFunc: ctrl.handleVMBackup,
+		},
+	)
+	if err != nil {
+		return err
+	}
 	_, err = ctrl.VMIInformer.AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    ctrl.handleVMI,
@@ -367,6 +395,7 @@ func (ctrl *VMExportController) Run(threadiness int, stopCh <-chan struct{}) err
 		ctrl.SecretInformer.HasSynced,
 		ctrl.VMSnapshotInformer.HasSynced,
 		ctrl.VMSnapshotContentInformer.HasSynced,
+		ctrl.VMBackupInformer.HasSynced,
 		ctrl.VMInformer.HasSynced,
 		ctrl.VMIInformer.HasSynced,
 		ctrl.CRDInformer.HasSynced,
@@ -513,6 +542,9 @@ func (ctrl *VMExportController) updateVMExport(vmExport *exportv1.VirtualMachine
 	if ctrl.isSourceVM(&vmExport.Spec) {
 		return ctrl.handleSource(vmExport, service, ctrl.getPVCFromSourceVM, ctrl.updateVMExportVMStatus)
 	}
+	if ctrl.isSourceVMBackup(&vmExport.Spec) {
+		return ctrl.handleSource(vmExport, service, ctrl.getPVCFromSourceVMBackup, ctrl.updateVMExportVMBackupStatus)
+	}
 	return 0, nil
 }
 
@@ -896,7 +928,11 @@ func (ctrl *VMExportController) createExporterPodManifest(vmExport *exportv1.Vir
 				},
 			},
 		})
-		ctrl.addVolumeEnvironmentVariables(&podManifest.Spec.Containers[0], pvc, i, mountPoint)
+		if ctrl.isSourceVMBackup(&vmExport.Spec) {
+			ctrl.addBackupEnvironmentVariables(&podManifest.Spec.Containers[0], pvc, i, mountPoint)
+		} else {
+			ctrl.addVolumeEnvironmentVariables(&podManifest.Spec.Containers[0], pvc, i, mountPoint)
+		}
 	}
 
 	// Add token and certs ENV variables
@@ -980,6 +1016,11 @@ func (ctrl *VMExportController) createDataManifestAndAddToPod(vmExport *exportv1
 		Name:      manifestData,
 		MountPath: "/manifest_data",
 	})
+	// The OVA bundle is generated from the VM definition in the data manifest
+	podManifest.Spec.Containers[0].Env = append(podManifest.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:  "EXPORT_OVA_URI",
+		Value: ovaPath,
+	})
 	podManifest.Spec.Volumes = append(podManifest.Spec.Volumes, corev1.Volume{
 		Name: manifestData,
 		VolumeSource: corev1.VolumeSource{
@@ -1097,6 +1138,15 @@ func (ctrl *VMExportController) addVolumeEnvironmentVariables(exportContainer *c
 		}, corev1.EnvVar{
 			Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_GZIP_URI", index),
 			Value: rawGzipURI(pvc),
+		}, corev1.EnvVar{
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_QCOW2_URI", index),
+			Value: qcow2URI(pvc),
+		}, corev1.EnvVar{
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_QCOW2_COMPRESSED_URI", index),
+			Value: qcow2CompressedURI(pvc),
+		}, corev1.EnvVar{
+			Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_SHA256_URI", index),
+			Value: rawSha256URI(pvc),
 		})
 	} else {
 		if ctrl.isKubevirtContentType(pvc) {
@@ -1106,6 +1156,15 @@ func (ctrl *VMExportController) addVolumeEnvironmentVariables(exportContainer *c
 			}, corev1.EnvVar{
 				Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_GZIP_URI", index),
 				Value: rawGzipURI(pvc),
+			}, corev1.EnvVar{
+				Name:  fmt.Sprintf("VOLUME%d_EXPORT_QCOW2_URI", index),
+				Value: qcow2URI(pvc),
+			}, corev1.EnvVar{
+				Name:  fmt.Sprintf("VOLUME%d_EXPORT_QCOW2_COMPRESSED_URI", index),
+				Value: qcow2CompressedURI(pvc),
+			}, corev1.EnvVar{
+				Name:  fmt.Sprintf("VOLUME%d_EXPORT_RAW_SHA256_URI", index),
+				Value: rawSha256URI(pvc),
 			})
 		} else {
 			exportContainer.Env = append(exportContainer.Env, corev1.EnvVar{
@@ -1119,6 +1178,18 @@ func (ctrl *VMExportController) addVolumeEnvironmentVariables(exportContainer *c
 	}
 }
 
+// addBackupEnvironmentVariables only exposes the qcow2 files of the backup, the PVC may contain
+// the files of other backups too
+func (ctrl *VMExportController) addBackupEnvironmentVariables(exportContainer *corev1.Container, pvc *corev1.PersistentVolumeClaim, index int, mountPoint string) {
+	exportContainer.Env = append(exportContainer.Env, corev1.EnvVar{
+		Name:  fmt.Sprintf("VOLUME%d_EXPORT_PATH", index),
+		Value: mountPoint,
+	}, corev1.EnvVar{
+		Name:  fmt.Sprintf("VOLUME%d_EXPORT_BACKUP_URI", index),
+		Value: backupURI(pvc),
+	})
+}
+
 func (ctrl *VMExportController) isKubevirtContentType(pvc *corev1.PersistentVolumeClaim) bool {
 	// Block volumes are assumed always KubevirtContentType
 	if types.IsPVCBlock(pvc.Spec.VolumeMode) {
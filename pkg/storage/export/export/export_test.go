@@ -106,6 +106,7 @@ var _ = Describe("Export controller", func() {
 		dvInformer                  cache.SharedIndexInformer
 		vmSnapshotInformer          cache.SharedIndexInformer
 		vmSnapshotContentInformer   cache.SharedIndexInformer
+		vmBackupInformer            cache.SharedIndexInformer
 		secretInformer              cache.SharedIndexInformer
 		vmInformer                  cache.SharedIndexInformer
 		vmiInformer                 cache.SharedIndexInformer
@@ -141,6 +142,7 @@ var _ = Describe("Export controller", func() {
 		go secretInformer.Run(stop)
 		go vmSnapshotInformer.Run(stop)
 		go vmSnapshotContentInformer.Run(stop)
+		go vmBackupInformer.Run(stop)
 		go vmInformer.Run(stop)
 		go vmiInformer.Run(stop)
 		go crdInformer.Run(stop)
@@ -163,6 +165,7 @@ var _ = Describe("Export controller", func() {
 			secretInformer.HasSynced,
 			vmSnapshotInformer.HasSynced,
 			vmSnapshotContentInformer.HasSynced,
+			vmBackupInformer.HasSynced,
 			vmInformer.HasSynced,
 			vmiInformer.HasSynced,
 			crdInformer.HasSynced,
@@ -195,6 +198,7 @@ var _ = Describe("Export controller", func() {
 		dvInformer, _ = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
 		vmSnapshotInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshot{})
 		vmSnapshotContentInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotContent{})
+		vmBackupInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineBackup{})
 		vmInformer, _ = testutils.NewFakeInformerFor(&virtv1.VirtualMachine{})
 		vmiInformer, _ = testutils.NewFakeInformerFor(&virtv1.VirtualMachineInstance{})
 		routeInformer, _ := testutils.NewFakeInformerFor(&routev1.Route{})
@@ -242,6 +246,7 @@ var _ = Describe("Export controller", func() {
 			SecretInformer:              secretInformer,
 			VMSnapshotInformer:          vmSnapshotInformer,
 			VMSnapshotContentInformer:   vmSnapshotContentInformer,
+			VMBackupInformer:            vmBackupInformer,
 			VolumeSnapshotProvider:      fakeVolumeSnapshotProvider,
 			VMInformer:                  vmInformer,
 			VMIInformer:                 vmiInformer,
@@ -1202,6 +1207,10 @@ var _ = Describe("Export controller", func() {
 		err = controller.createDataManifestAndAddToPod(testVMExport, vm, testPod, service)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(testVMExport.Status).ToNot(BeNil())
+		Expect(testPod.Spec.Containers[0].Env).To(ContainElement(k8sv1.EnvVar{
+			Name:  "EXPORT_OVA_URI",
+			Value: ovaPath,
+		}))
 	})
 
 	createVM := func() *virtv1.VirtualMachine {
@@ -1351,48 +1360,67 @@ func verifyLinksInternal(vmExport *exportv1.VirtualMachineExport, expectedVolume
 	Expect(vmExport.Status.Links).ToNot(BeNil())
 	Expect(vmExport.Status.Links.Internal).NotTo(BeNil())
 	Expect(vmExport.Status.Links.Internal.Cert).NotTo(BeEmpty())
-	Expect(vmExport.Status.Links.Internal.Volumes).To(HaveLen(len(expectedVolumeFormats) / 2))
+	volumeFormats := []exportv1.VirtualMachineExportVolumeFormat{}
 	for _, volume := range vmExport.Status.Links.Internal.Volumes {
-		Expect(volume.Formats).To(HaveLen(2))
-		Expect(expectedVolumeFormats).To(ContainElements(volume.Formats))
+		volumeFormats = append(volumeFormats, volume.Formats...)
 	}
+	Expect(volumeFormats).To(ConsistOf(expectedVolumeFormats))
 }
 
-func verifyLinksExternal(vmExport *exportv1.VirtualMachineExport, link1Format exportv1.ExportVolumeFormat, link1Url string, link2Format exportv1.ExportVolumeFormat, link2Url string) {
+func verifyLinksExternal(vmExport *exportv1.VirtualMachineExport, expectedVolumeFormats ...exportv1.VirtualMachineExportVolumeFormat) {
 	Expect(vmExport.Status.Links.External).ToNot(BeNil())
 	Expect(vmExport.Status.Links.External.Cert).To(BeEmpty())
 	Expect(vmExport.Status.Links.External.Volumes).To(HaveLen(1))
-	Expect(vmExport.Status.Links.External.Volumes[0].Formats).To(HaveLen(2))
-	Expect(vmExport.Status.Links.External.Volumes[0].Formats).To(ContainElements(exportv1.VirtualMachineExportVolumeFormat{
-		Format: link1Format,
-		Url:    link1Url,
-	}, exportv1.VirtualMachineExportVolumeFormat{
-		Format: link2Format,
-		Url:    link2Url,
-	}))
+	Expect(vmExport.Status.Links.External.Volumes[0].Formats).To(ConsistOf(expectedVolumeFormats))
+}
+
+func kubevirtVolumeFormats(volumeUrl string) []exportv1.VirtualMachineExportVolumeFormat {
+	return []exportv1.VirtualMachineExportVolumeFormat{
+		{
+			Format: exportv1.KubeVirtRaw,
+			Url:    volumeUrl + "/disk.img",
+		},
+		{
+			Format: exportv1.KubeVirtGz,
+			Url:    volumeUrl + "/disk.img.gz",
+		},
+		{
+			Format: exportv1.KubeVirtQcow2,
+			Url:    volumeUrl + "/disk.qcow2",
+		},
+		{
+			Format: exportv1.KubeVirtQcow2Compressed,
+			Url:    volumeUrl + "/disk-compressed.qcow2",
+		},
+	}
 }
 
 func verifyKubevirtInternal(vmExport *exportv1.VirtualMachineExport, exportName, namespace string, volumeNames ...string) {
 	exportVolumeFormats := make([]exportv1.VirtualMachineExportVolumeFormat, 0)
 	for _, volumeName := range volumeNames {
-		exportVolumeFormats = append(exportVolumeFormats, exportv1.VirtualMachineExportVolumeFormat{
-			Format: exportv1.KubeVirtRaw,
-			Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName),
-		})
-		exportVolumeFormats = append(exportVolumeFormats, exportv1.VirtualMachineExportVolumeFormat{
-			Format: exportv1.KubeVirtGz,
-			Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img.gz", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName),
-		})
+		exportVolumeFormats = append(exportVolumeFormats, kubevirtVolumeFormats(fmt.Sprintf("https://%s.%s.svc/volumes/%s", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName))...)
 	}
 	verifyLinksInternal(vmExport, exportVolumeFormats...)
+	verifyChecksums(vmExport.Status.Links.Internal.Volumes)
 }
 
 func verifyKubevirtExternal(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName string) {
-	verifyLinksExternal(vmExport,
-		exportv1.KubeVirtRaw,
-		fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/v1alpha1/namespaces/%s/virtualmachineexports/%s/volumes/%s/disk.img", namespace, exportName, volumeName),
-		exportv1.KubeVirtGz,
-		fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/v1alpha1/namespaces/%s/virtualmachineexports/%s/volumes/%s/disk.img.gz", namespace, exportName, volumeName))
+	verifyLinksExternal(vmExport, kubevirtVolumeFormats(fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/v1alpha1/namespaces/%s/virtualmachineexports/%s/volumes/%s", namespace, exportName, volumeName))...)
+	verifyChecksums(vmExport.Status.Links.External.Volumes)
+}
+
+// verifyChecksums checks that the checksum of every volume is next to its raw image
+func verifyChecksums(volumes []exportv1.VirtualMachineExportVolume) {
+	for _, volume := range volumes {
+		for _, format := range volume.Formats {
+			if format.Format == exportv1.KubeVirtRaw {
+				Expect(volume.Checksums).To(ConsistOf(exportv1.VirtualMachineExportVolumeChecksum{
+					Algorithm: exportv1.SHA256,
+					Url:       format.Url + ".sha256",
+				}))
+			}
+		}
+	}
 }
 
 func verifyArchiveInternal(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName string) {
@@ -1404,6 +1432,7 @@ func verifyArchiveInternal(vmExport *exportv1.VirtualMachineExport, exportName,
 			Format: exportv1.ArchiveGz,
 			Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.tar.gz", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName),
 		})
+	Expect(vmExport.Status.Links.Internal.Volumes[0].Checksums).To(BeEmpty())
 }
 
 func routeToHostAndService(serviceName string) *routev1.Route {
@@ -1468,10 +1497,13 @@ func ingressToHost() *networkingv1.Ingress {
 
 func verifyArchiveExternal(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName string) {
 	verifyLinksExternal(vmExport,
-		exportv1.Dir,
-		fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/v1alpha1/namespaces/%s/virtualmachineexports/%s/volumes/%s/dir", namespace, exportName, volumeName),
-		exportv1.ArchiveGz,
-		fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/v1alpha1/namespaces/%s/virtualmachineexports/%s/volumes/%s/disk.tar.gz", namespace, exportName, volumeName))
+		exportv1.VirtualMachineExportVolumeFormat{
+			Format: exportv1.Dir,
+			Url:    fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/v1alpha1/namespaces/%s/virtualmachineexports/%s/volumes/%s/dir", namespace, exportName, volumeName),
+		}, exportv1.VirtualMachineExportVolumeFormat{
+			Format: exportv1.ArchiveGz,
+			Url:    fmt.Sprintf("https://virt-exportproxy-kubevirt.apps-crc.testing/api/export.kubevirt.io/v1alpha1/namespaces/%s/virtualmachineexports/%s/volumes/%s/disk.tar.gz", namespace, exportName, volumeName),
+		})
 }
 
 func writeCertsToDir(dir string) {
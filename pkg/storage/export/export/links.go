@@ -89,10 +89,20 @@ func (ctrl *VMExportController) getLinks(pvcs []*corev1.PersistentVolumeClaim, e
 			},
 		},
 	}
+	if ctrl.isSourceVM(&export.Spec) || ctrl.isSourceVMSnapshot(&export.Spec) {
+		exportLink.Bundles = []exportv1.VirtualMachineExportBundle{
+			{
+				Format: exportv1.OVA,
+				Url:    scheme + path.Join(hostAndBase, ovaPath),
+			},
+		}
+	}
 	for _, pvc := range pvcs {
 		if pvc != nil && exporterPod != nil && exporterPod.Status.Phase == corev1.PodRunning {
 
-			if ctrl.isKubevirtContentType(pvc) {
+			if ctrl.isSourceVMBackup(&export.Spec) {
+				exportLink.Volumes = append(exportLink.Volumes, ctrl.getVMBackupLinks(pvc, export, scheme, hostAndBase)...)
+			} else if ctrl.isKubevirtContentType(pvc) {
 				exportLink.Volumes = append(exportLink.Volumes, exportv1.VirtualMachineExportVolume{
 					Name: getVolumeName(pvc, export),
 					Formats: []exportv1.VirtualMachineExportVolumeFormat{
@@ -104,6 +114,20 @@ func (ctrl *VMExportController) getLinks(pvcs []*corev1.PersistentVolumeClaim, e
 							Format: exportv1.KubeVirtGz,
 							Url:    scheme + path.Join(hostAndBase, rawGzipURI(pvc)),
 						},
+						{
+							Format: exportv1.KubeVirtQcow2,
+							Url:    scheme + path.Join(hostAndBase, qcow2URI(pvc)),
+						},
+						{
+							Format: exportv1.KubeVirtQcow2Compressed,
+							Url:    scheme + path.Join(hostAndBase, qcow2CompressedURI(pvc)),
+						},
+					},
+					Checksums: []exportv1.VirtualMachineExportVolumeChecksum{
+						{
+							Algorithm: exportv1.SHA256,
+							Url:       scheme + path.Join(hostAndBase, rawSha256URI(pvc)),
+						},
 					},
 				})
 			} else {
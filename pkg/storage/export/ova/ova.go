@@ -0,0 +1,108 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	virtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/sparse"
+)
+
+// Disk is a raw image of a VM volume which is added to the OVA
+type Disk struct {
+	// Name is the name of the volume in the VM spec
+	Name string
+	// Path is the path to the raw image, either a file or a block device
+	Path string
+}
+
+// Write streams an OVA of the VM to w. The OVA is a tar archive which contains the
+// OVF descriptor generated from the VM spec, followed by a streamOptimized VMDK of
+// every disk. The tar headers need the size of the VMDKs in advance, it is derived
+// from the holes of the disks, so writing starts without reading the disks first.
+func Write(w io.Writer, vm *virtv1.VirtualMachine, disks []Disk) error {
+	files := make([]*os.File, 0, len(disks))
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	images := make([]diskImage, 0, len(disks))
+	for _, d := range disks {
+		f, err := os.Open(d.Path)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+
+		image := diskImage{
+			fileName: fmt.Sprintf("%s-%s.vmdk", vm.Name, d.Name),
+		}
+		// stat does not report the size of block devices
+		if image.capacity, err = f.Seek(0, io.SeekEnd); err != nil {
+			return err
+		}
+		if image.size, err = streamOptimizedVMDKSize(sparse.NewHoleDetector(f), image.capacity, image.fileName); err != nil {
+			return err
+		}
+		images = append(images, image)
+	}
+
+	ovf, err := generateOVF(vm, images)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	tw := tar.NewWriter(w)
+	if err := tw.WriteHeader(tarHeader(vm.Name+".ovf", int64(len(ovf)), now)); err != nil {
+		return err
+	}
+	if _, err := tw.Write(ovf); err != nil {
+		return err
+	}
+	for i, image := range images {
+		if err := tw.WriteHeader(tarHeader(image.fileName, image.size, now)); err != nil {
+			return err
+		}
+		if err := writeStreamOptimizedVMDK(tw, files[i], sparse.NewHoleDetector(files[i]), image.capacity, image.fileName); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func tarHeader(name string, size int64, modTime time.Time) *tar.Header {
+	return &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  modTime,
+		Format:   tar.FormatUSTAR,
+	}
+}
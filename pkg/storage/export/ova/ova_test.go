@@ -0,0 +1,189 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/api/core/v1"
+)
+
+// parsedEnvelope resolves the namespace prefixes of the generated OVF descriptor
+type parsedEnvelope struct {
+	References []struct {
+		Href string `xml:"http://schemas.dmtf.org/ovf/envelope/1 href,attr"`
+		Size int64  `xml:"http://schemas.dmtf.org/ovf/envelope/1 size,attr"`
+	} `xml:"References>File"`
+	Disks []struct {
+		Capacity int64  `xml:"http://schemas.dmtf.org/ovf/envelope/1 capacity,attr"`
+		Format   string `xml:"http://schemas.dmtf.org/ovf/envelope/1 format,attr"`
+	} `xml:"DiskSection>Disk"`
+	Networks []struct {
+		Name string `xml:"http://schemas.dmtf.org/ovf/envelope/1 name,attr"`
+	} `xml:"NetworkSection>Network"`
+	Name  string `xml:"VirtualSystem>Name"`
+	Items []struct {
+		Connection      string `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Connection"`
+		HostResource    string `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData HostResource"`
+		Parent          string `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData Parent"`
+		ResourceSubType string `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData ResourceSubType"`
+		ResourceType    int    `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData ResourceType"`
+		VirtualQuantity string `xml:"http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData VirtualQuantity"`
+	} `xml:"VirtualSystem>VirtualHardwareSection>Item"`
+}
+
+var _ = Describe("OVA", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+	})
+
+	newVM := func() *virtv1.VirtualMachine {
+		return &virtv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "testvm",
+				Namespace: "default",
+			},
+			Spec: virtv1.VirtualMachineSpec{
+				Template: &virtv1.VirtualMachineInstanceTemplateSpec{
+					Spec: virtv1.VirtualMachineInstanceSpec{
+						Domain: virtv1.DomainSpec{
+							CPU: &virtv1.CPU{Sockets: 2, Cores: 2},
+							Resources: virtv1.ResourceRequirements{
+								Requests: k8sv1.ResourceList{
+									k8sv1.ResourceMemory: resource.MustParse("2Gi"),
+								},
+							},
+							Devices: virtv1.Devices{
+								Interfaces: []virtv1.Interface{
+									{Name: "default", Model: "virtio"},
+									{Name: "secondary", Model: "e1000"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	writeDisk := func(name string, data []byte) Disk {
+		path := filepath.Join(tempDir, name)
+		Expect(os.WriteFile(path, data, 0644)).To(Succeed())
+		return Disk{Name: name, Path: path}
+	}
+
+	It("should generate an OVF descriptor from the VM spec", func() {
+		vm := newVM()
+		vm.Spec.Template.Spec.Domain.Memory = &virtv1.Memory{Guest: resource.NewQuantity(1024*1024*1024, resource.BinarySI)}
+		ovf, err := generateOVF(vm, []diskImage{
+			{fileName: "testvm-rootdisk.vmdk", capacity: 10 * 1024 * 1024, size: 1234},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		env := parsedEnvelope{}
+		Expect(xml.Unmarshal(ovf, &env)).To(Succeed())
+		Expect(env.References).To(HaveLen(1))
+		Expect(env.References[0].Href).To(Equal("testvm-rootdisk.vmdk"))
+		Expect(env.References[0].Size).To(Equal(int64(1234)))
+		Expect(env.Disks).To(HaveLen(1))
+		Expect(env.Disks[0].Capacity).To(Equal(int64(10 * 1024 * 1024)))
+		Expect(env.Disks[0].Format).To(Equal(vmdkFormatURL))
+		Expect(env.Networks).To(HaveLen(2))
+		Expect(env.Name).To(Equal("testvm"))
+
+		items := env.Items
+		Expect(items).To(HaveLen(6))
+		Expect(items[0].ResourceType).To(Equal(resourceTypeProcessor))
+		Expect(items[0].VirtualQuantity).To(Equal("4"))
+		Expect(items[1].ResourceType).To(Equal(resourceTypeMemory))
+		Expect(items[1].VirtualQuantity).To(Equal("1024"))
+		Expect(items[2].ResourceType).To(Equal(resourceTypeSCSIController))
+		Expect(items[3].ResourceType).To(Equal(resourceTypeDisk))
+		Expect(items[3].HostResource).To(Equal("ovf:/disk/vmdisk1"))
+		Expect(items[3].Parent).To(Equal("3"))
+		Expect(items[4].ResourceType).To(Equal(resourceTypeEthernet))
+		Expect(items[4].ResourceSubType).To(Equal("VmxNet3"))
+		Expect(items[4].Connection).To(Equal("default"))
+		Expect(items[5].ResourceSubType).To(Equal("E1000"))
+	})
+
+	It("should fail to generate the OVF descriptor without memory", func() {
+		vm := newVM()
+		vm.Spec.Template.Spec.Domain.Resources = virtv1.ResourceRequirements{}
+		_, err := generateOVF(vm, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should write a tar archive with the OVF descriptor first followed by the disks", func() {
+		rootdisk := bytes.Repeat([]byte{1, 2, 3, 4}, grainSize)
+		datadisk := make([]byte, 3*grainSize)
+		datadisk[2*grainSize] = 42
+		disks := []Disk{
+			writeDisk("rootdisk", rootdisk),
+			writeDisk("datadisk", datadisk),
+		}
+
+		out := &bytes.Buffer{}
+		Expect(Write(out, newVM(), disks)).To(Succeed())
+
+		tr := tar.NewReader(out)
+		hdr, err := tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hdr.Name).To(Equal("testvm.ovf"))
+		ovf, err := io.ReadAll(tr)
+		Expect(err).ToNot(HaveOccurred())
+		env := parsedEnvelope{}
+		Expect(xml.Unmarshal(ovf, &env)).To(Succeed())
+		Expect(env.References).To(HaveLen(2))
+
+		for i, expected := range [][]byte{rootdisk, datadisk} {
+			hdr, err = tr.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hdr.Name).To(Equal(env.References[i].Href))
+			Expect(hdr.Size).To(Equal(env.References[i].Size))
+			Expect(env.Disks[i].Capacity).To(Equal(int64(len(expected))))
+			data, err := io.ReadAll(tr)
+			Expect(err).ToNot(HaveOccurred())
+			_, raw := readStreamOptimizedVMDK(data)
+			Expect(raw).To(Equal(expected))
+		}
+		_, err = tr.Next()
+		Expect(err).To(MatchError(io.EOF))
+	})
+
+	It("should fail if a disk does not exist", func() {
+		err := Write(io.Discard, newVM(), []Disk{{Name: "missing", Path: filepath.Join(tempDir, "missing")}})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,293 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	virtv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+)
+
+// CIM resource types used in the virtual hardware section
+const (
+	resourceTypeProcessor      = 3
+	resourceTypeMemory         = 4
+	resourceTypeSCSIController = 6
+	resourceTypeEthernet       = 10
+	resourceTypeDisk           = 17
+
+	scsiControllerInstanceID = 3
+
+	ovfNamespace  = "http://schemas.dmtf.org/ovf/envelope/1"
+	rasdNamespace = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData"
+	vssdNamespace = "http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_VirtualSystemSettingData"
+	xsiNamespace  = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+// The element names carry the namespace prefixes declared on the envelope, this way
+// the output matches the layout which is expected by most hypervisor importers.
+type envelope struct {
+	XMLName        xml.Name       `xml:"Envelope"`
+	Xmlns          string         `xml:"xmlns,attr"`
+	XmlnsOvf       string         `xml:"xmlns:ovf,attr"`
+	XmlnsRasd      string         `xml:"xmlns:rasd,attr"`
+	XmlnsVssd      string         `xml:"xmlns:vssd,attr"`
+	XmlnsXsi       string         `xml:"xmlns:xsi,attr"`
+	References     []file         `xml:"References>File"`
+	DiskSection    diskSection    `xml:"DiskSection"`
+	NetworkSection networkSection `xml:"NetworkSection"`
+	VirtualSystem  virtualSystem  `xml:"VirtualSystem"`
+}
+
+type file struct {
+	Href string `xml:"ovf:href,attr"`
+	ID   string `xml:"ovf:id,attr"`
+	Size int64  `xml:"ovf:size,attr"`
+}
+
+type diskSection struct {
+	Info  string `xml:"Info"`
+	Disks []disk `xml:"Disk"`
+}
+
+type disk struct {
+	Capacity int64  `xml:"ovf:capacity,attr"`
+	DiskID   string `xml:"ovf:diskId,attr"`
+	FileRef  string `xml:"ovf:fileRef,attr"`
+	Format   string `xml:"ovf:format,attr"`
+}
+
+type networkSection struct {
+	Info     string    `xml:"Info"`
+	Networks []network `xml:"Network"`
+}
+
+type network struct {
+	Name        string `xml:"ovf:name,attr"`
+	Description string `xml:"Description"`
+}
+
+type virtualSystem struct {
+	ID                     string                 `xml:"ovf:id,attr"`
+	Info                   string                 `xml:"Info"`
+	Name                   string                 `xml:"Name"`
+	OperatingSystemSection operatingSystemSection `xml:"OperatingSystemSection"`
+	VirtualHardwareSection virtualHardwareSection `xml:"VirtualHardwareSection"`
+}
+
+type operatingSystemSection struct {
+	ID   int    `xml:"ovf:id,attr"`
+	Info string `xml:"Info"`
+}
+
+type virtualHardwareSection struct {
+	Info   string `xml:"Info"`
+	System system `xml:"System"`
+	Items  []item `xml:"Item"`
+}
+
+type system struct {
+	ElementName             string `xml:"vssd:ElementName"`
+	InstanceID              int    `xml:"vssd:InstanceID"`
+	VirtualSystemIdentifier string `xml:"vssd:VirtualSystemIdentifier"`
+	VirtualSystemType       string `xml:"vssd:VirtualSystemType"`
+}
+
+// item is a CIM_ResourceAllocationSettingData, its properties have to be in alphabetical order
+type item struct {
+	Address             string `xml:"rasd:Address,omitempty"`
+	AddressOnParent     string `xml:"rasd:AddressOnParent,omitempty"`
+	AllocationUnits     string `xml:"rasd:AllocationUnits,omitempty"`
+	AutomaticAllocation string `xml:"rasd:AutomaticAllocation,omitempty"`
+	Connection          string `xml:"rasd:Connection,omitempty"`
+	Description         string `xml:"rasd:Description,omitempty"`
+	ElementName         string `xml:"rasd:ElementName"`
+	HostResource        string `xml:"rasd:HostResource,omitempty"`
+	InstanceID          int    `xml:"rasd:InstanceID"`
+	Parent              string `xml:"rasd:Parent,omitempty"`
+	ResourceSubType     string `xml:"rasd:ResourceSubType,omitempty"`
+	ResourceType        int    `xml:"rasd:ResourceType"`
+	VirtualQuantity     string `xml:"rasd:VirtualQuantity,omitempty"`
+}
+
+// diskImage describes a VMDK which is part of the OVA
+type diskImage struct {
+	fileName string
+	capacity int64
+	size     int64
+}
+
+// generateOVF creates the OVF descriptor of the VM, the disks are attached to a
+// single SCSI controller in the order they are passed in.
+func generateOVF(vm *virtv1.VirtualMachine, disks []diskImage) ([]byte, error) {
+	spec := vm.Spec.Template.Spec
+	env := envelope{
+		Xmlns:     ovfNamespace,
+		XmlnsOvf:  ovfNamespace,
+		XmlnsRasd: rasdNamespace,
+		XmlnsVssd: vssdNamespace,
+		XmlnsXsi:  xsiNamespace,
+		DiskSection: diskSection{
+			Info: "Virtual disk information",
+		},
+		NetworkSection: networkSection{
+			Info: "The list of logical networks",
+		},
+		VirtualSystem: virtualSystem{
+			ID:   vm.Name,
+			Info: "A virtual machine",
+			Name: vm.Name,
+			OperatingSystemSection: operatingSystemSection{
+				// Other
+				ID:   1,
+				Info: "The kind of installed guest operating system",
+			},
+			VirtualHardwareSection: virtualHardwareSection{
+				Info: "Virtual hardware requirements",
+				System: system{
+					ElementName:             "Virtual Hardware Family",
+					VirtualSystemIdentifier: vm.Name,
+					VirtualSystemType:       "vmx-07",
+				},
+			},
+		},
+	}
+
+	vcpus := int64(1)
+	if spec.Domain.CPU != nil {
+		if n := hardware.GetNumberOfVCPUs(spec.Domain.CPU); n > 0 {
+			vcpus = n
+		}
+	}
+	memory, err := guestMemoryMiB(vm)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []item{
+		{
+			AllocationUnits: "hertz * 10^6",
+			Description:     "Number of Virtual CPUs",
+			ElementName:     fmt.Sprintf("%d virtual CPU(s)", vcpus),
+			InstanceID:      1,
+			ResourceType:    resourceTypeProcessor,
+			VirtualQuantity: strconv.FormatInt(vcpus, 10),
+		},
+		{
+			AllocationUnits: "byte * 2^20",
+			Description:     "Memory Size",
+			ElementName:     fmt.Sprintf("%dMB of memory", memory),
+			InstanceID:      2,
+			ResourceType:    resourceTypeMemory,
+			VirtualQuantity: strconv.FormatInt(memory, 10),
+		},
+		{
+			Address:         "0",
+			Description:     "SCSI Controller",
+			ElementName:     "SCSI Controller 0",
+			InstanceID:      scsiControllerInstanceID,
+			ResourceSubType: "lsilogic",
+			ResourceType:    resourceTypeSCSIController,
+		},
+	}
+	instanceID := scsiControllerInstanceID + 1
+
+	for i, d := range disks {
+		fileID := fmt.Sprintf("file%d", i+1)
+		diskID := fmt.Sprintf("vmdisk%d", i+1)
+		env.References = append(env.References, file{
+			Href: d.fileName,
+			ID:   fileID,
+			Size: d.size,
+		})
+		env.DiskSection.Disks = append(env.DiskSection.Disks, disk{
+			Capacity: d.capacity,
+			DiskID:   diskID,
+			FileRef:  fileID,
+			Format:   vmdkFormatURL,
+		})
+		// unit 7 is reserved for the SCSI controller itself
+		unit := i
+		if unit >= 7 {
+			unit++
+		}
+		items = append(items, item{
+			AddressOnParent: strconv.Itoa(unit),
+			ElementName:     fmt.Sprintf("Hard Disk %d", i+1),
+			HostResource:    "ovf:/disk/" + diskID,
+			InstanceID:      instanceID,
+			Parent:          strconv.Itoa(scsiControllerInstanceID),
+			ResourceType:    resourceTypeDisk,
+		})
+		instanceID++
+	}
+
+	for _, iface := range spec.Domain.Devices.Interfaces {
+		env.NetworkSection.Networks = append(env.NetworkSection.Networks, network{
+			Name:        iface.Name,
+			Description: fmt.Sprintf("The %s network", iface.Name),
+		})
+		items = append(items, item{
+			AutomaticAllocation: "true",
+			Connection:          iface.Name,
+			ElementName:         fmt.Sprintf("Ethernet adapter on '%s'", iface.Name),
+			InstanceID:          instanceID,
+			ResourceSubType:     ethernetSubType(iface.Model),
+			ResourceType:        resourceTypeEthernet,
+		})
+		instanceID++
+	}
+	env.VirtualSystem.VirtualHardwareSection.Items = items
+
+	data, err := xml.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// guestMemoryMiB returns the guest memory, falling back to the requested memory
+func guestMemoryMiB(vm *virtv1.VirtualMachine) (int64, error) {
+	domain := vm.Spec.Template.Spec.Domain
+	if domain.Memory != nil && domain.Memory.Guest != nil {
+		return domain.Memory.Guest.Value() / (1024 * 1024), nil
+	}
+	if memory, ok := domain.Resources.Requests["memory"]; ok {
+		return memory.Value() / (1024 * 1024), nil
+	}
+	return 0, fmt.Errorf("unable to determine the guest memory of VM %s", vm.Name)
+}
+
+func ethernetSubType(model string) string {
+	switch model {
+	case "e1000":
+		return "E1000"
+	case "e1000e":
+		return "E1000e"
+	case "pcnet":
+		return "PCNet32"
+	default:
+		return "VmxNet3"
+	}
+}
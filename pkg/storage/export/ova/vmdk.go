@@ -0,0 +1,331 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/adler32"
+	"io"
+	"math"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/sparse"
+)
+
+// The streamOptimized VMDK layout is described in the "Virtual Disk Format 5.0"
+// specification. The image only contains the allocated grains, every grain table
+// and the grain directory follow the data they describe, which allows writing the
+// image to a non seekable stream.
+const (
+	sectorSize = 512
+
+	vmdkMagic   = 0x564d444b
+	vmdkVersion = 3
+	// valid newline detection, compressed grains and markers
+	vmdkFlags = 0x1 | 0x10000 | 0x20000
+
+	grainSectors    = 128
+	grainSize       = grainSectors * sectorSize
+	gtEntries       = 512
+	overheadSectors = 128
+	gdAtEnd         = 0xffffffffffffffff
+	compressDeflate = 1
+
+	markerEOS    = 0
+	markerGT     = 1
+	markerGD     = 2
+	markerFooter = 3
+
+	vmdkFormatURL = "http://www.vmware.com/interfaces/specifications/vmdk.html#streamOptimized"
+
+	zlibHeaderSize        = 2
+	maxStoredBlockSize    = 0xffff
+	storedBlockHeaderSize = 5
+)
+
+type sparseExtentHeader struct {
+	MagicNumber        uint32
+	Version            uint32
+	Flags              uint32
+	Capacity           uint64
+	GrainSize          uint64
+	DescriptorOffset   uint64
+	DescriptorSize     uint64
+	NumGTEsPerGT       uint32
+	RgdOffset          uint64
+	GdOffset           uint64
+	OverHead           uint64
+	UncleanShutdown    uint8
+	SingleEndLineChar  uint8
+	NonEndLineChar     uint8
+	DoubleEndLineChar1 uint8
+	DoubleEndLineChar2 uint8
+	CompressAlgorithm  uint16
+	Pad                [433]uint8
+}
+
+type metadataMarker struct {
+	NumSectors uint64
+	Size       uint32
+	Type       uint32
+	Pad        [496]uint8
+}
+
+// sectorWriter keeps track of the position in the image
+type sectorWriter struct {
+	w      io.Writer
+	offset uint64
+}
+
+func (s *sectorWriter) Write(p []byte) (int, error) {
+	n, err := s.w.Write(p)
+	s.offset += uint64(n)
+	return n, err
+}
+
+// skip advances the position without writing, it is only used to calculate the size of an image
+func (s *sectorWriter) skip(n int) {
+	s.offset += uint64(n)
+}
+
+func (s *sectorWriter) sector() uint64 {
+	return s.offset / sectorSize
+}
+
+// sector32 returns the current sector for a grain table or grain directory entry,
+// which only hold 32 bit sector offsets
+func (s *sectorWriter) sector32() (uint32, error) {
+	return toSector32(s.sector())
+}
+
+func toSector32(sector uint64) (uint32, error) {
+	if sector > math.MaxUint32 {
+		return 0, fmt.Errorf("sector %d is beyond the 2TiB a VMDK can address", sector)
+	}
+	return uint32(sector), nil
+}
+
+// padToSector fills the current sector with zeros
+func (s *sectorWriter) padToSector() error {
+	if rem := s.offset % sectorSize; rem != 0 {
+		_, err := s.Write(make([]byte, sectorSize-rem))
+		return err
+	}
+	return nil
+}
+
+func (s *sectorWriter) writeMetadata(markerType uint32, data interface{}) (uint64, error) {
+	buf := &bytes.Buffer{}
+	if err := binary.Write(buf, binary.LittleEndian, data); err != nil {
+		return 0, err
+	}
+	marker := metadataMarker{
+		NumSectors: sectorsFor(uint64(buf.Len())),
+		Type:       markerType,
+	}
+	if err := binary.Write(s, binary.LittleEndian, marker); err != nil {
+		return 0, err
+	}
+	offset := s.sector()
+	if _, err := s.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return offset, s.padToSector()
+}
+
+func sectorsFor(size uint64) uint64 {
+	return (size + sectorSize - 1) / sectorSize
+}
+
+func vmdkDescriptor(fileName string, capacitySectors uint64, adapterType string) string {
+	cylinders := capacitySectors / (16 * 63)
+	if cylinders > 16383 {
+		cylinders = 16383
+	}
+	return fmt.Sprintf(`# Disk DescriptorFile
+version=1
+CID=fffffffe
+parentCID=ffffffff
+createType="streamOptimized"
+
+# Extent description
+RW %d SPARSE "%s"
+
+# The Disk Data Base
+#DDB
+
+ddb.virtualHWVersion = "4"
+ddb.adapterType = "%s"
+ddb.geometry.cylinders = "%d"
+ddb.geometry.heads = "16"
+ddb.geometry.sectors = "63"
+`, capacitySectors, fileName, adapterType, cylinders)
+}
+
+// streamOptimizedVMDKSize returns the size of the streamOptimized VMDK which
+// writeStreamOptimizedVMDK writes for an image with the same holes, without reading the image
+func streamOptimizedVMDKSize(holes *sparse.HoleDetector, capacity int64, fileName string) (int64, error) {
+	w := &sectorWriter{w: io.Discard}
+	if err := writeVMDK(w, nil, holes, capacity, fileName); err != nil {
+		return 0, err
+	}
+	return int64(w.offset), nil
+}
+
+// writeStreamOptimizedVMDK converts the raw image read from in, which has the size
+// capacity, to the streamOptimized VMDK fileName. Grains in holes of the image are left
+// out, all other grains are stored without compression, so the size of the output is
+// known in advance and does not depend on the content of the image.
+func writeStreamOptimizedVMDK(out io.Writer, in io.ReaderAt, holes *sparse.HoleDetector, capacity int64, fileName string) error {
+	return writeVMDK(&sectorWriter{w: out}, in, holes, capacity, fileName)
+}
+
+// writeVMDK writes the VMDK to w, if in is nil only the layout is written and the grain data is skipped
+func writeVMDK(w *sectorWriter, in io.ReaderAt, holes *sparse.HoleDetector, capacity int64, fileName string) error {
+	capacitySectors := sectorsFor(uint64(capacity))
+	if capacitySectors > math.MaxUint32 {
+		return fmt.Errorf("disk of %d bytes exceeds the 2TiB a VMDK can address", capacity)
+	}
+	descriptor := vmdkDescriptor(fileName, capacitySectors, "lsilogic")
+
+	header := sparseExtentHeader{
+		MagicNumber:        vmdkMagic,
+		Version:            vmdkVersion,
+		Flags:              vmdkFlags,
+		Capacity:           capacitySectors,
+		GrainSize:          grainSectors,
+		DescriptorOffset:   1,
+		DescriptorSize:     sectorsFor(uint64(len(descriptor))),
+		NumGTEsPerGT:       gtEntries,
+		GdOffset:           gdAtEnd,
+		OverHead:           overheadSectors,
+		SingleEndLineChar:  '\n',
+		NonEndLineChar:     ' ',
+		DoubleEndLineChar1: '\r',
+		DoubleEndLineChar2: '\n',
+		CompressAlgorithm:  compressDeflate,
+	}
+	if 1+header.DescriptorSize > overheadSectors {
+		return fmt.Errorf("descriptor of %d sectors does not fit into the overhead", header.DescriptorSize)
+	}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, descriptor); err != nil {
+		return err
+	}
+	if _, err := w.Write(make([]byte, overheadSectors*sectorSize-w.offset)); err != nil {
+		return err
+	}
+
+	numGrains := (capacitySectors + grainSectors - 1) / grainSectors
+	gd := make([]uint32, (numGrains+gtEntries-1)/gtEntries)
+	gt := make([]uint32, gtEntries)
+	grain := make([]byte, grainSize)
+	stored := make([]byte, 0, storedZlibSize(grainSize))
+
+	for i := uint64(0); i < numGrains; i++ {
+		offset := int64(i * grainSize)
+		length := capacity - offset
+		if length > grainSize {
+			length = grainSize
+		}
+
+		if !holes.Hole(offset, length) {
+			sector, err := w.sector32()
+			if err != nil {
+				return err
+			}
+			gt[i%gtEntries] = sector
+			if err := binary.Write(w, binary.LittleEndian, struct {
+				Lba  uint64
+				Size uint32
+			}{i * grainSectors, uint32(storedZlibSize(grainSize))}); err != nil {
+				return err
+			}
+			if in == nil {
+				w.skip(storedZlibSize(grainSize))
+			} else {
+				n, err := in.ReadAt(grain[:length], offset)
+				if err != nil && !errors.Is(err, io.EOF) {
+					return err
+				}
+				for j := n; j < grainSize; j++ {
+					grain[j] = 0
+				}
+				if _, err := w.Write(appendStoredZlib(stored[:0], grain)); err != nil {
+					return err
+				}
+			}
+			if err := w.padToSector(); err != nil {
+				return err
+			}
+		}
+
+		if i%gtEntries == gtEntries-1 || i == numGrains-1 {
+			offset, err := w.writeMetadata(markerGT, gt)
+			if err != nil {
+				return err
+			}
+			if gd[i/gtEntries], err = toSector32(offset); err != nil {
+				return err
+			}
+			gt = make([]uint32, gtEntries)
+		}
+	}
+
+	gdOffset, err := w.writeMetadata(markerGD, gd)
+	if err != nil {
+		return err
+	}
+	header.GdOffset = gdOffset
+	if _, err := w.writeMetadata(markerFooter, header); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, metadataMarker{Type: markerEOS})
+}
+
+// storedZlibSize is the size of a zlib stream which stores n bytes in uncompressed deflate blocks
+func storedZlibSize(n int) int {
+	blocks := (n + maxStoredBlockSize - 1) / maxStoredBlockSize
+	return zlibHeaderSize + blocks*storedBlockHeaderSize + n + adler32.Size
+}
+
+// appendStoredZlib appends a zlib stream with data in uncompressed deflate blocks to buf
+func appendStoredZlib(buf []byte, data []byte) []byte {
+	// 32K window, no preset dictionary, fastest compression
+	buf = append(buf, 0x78, 0x01)
+	for remaining := data; len(remaining) > 0; {
+		n := len(remaining)
+		final := byte(1)
+		if n > maxStoredBlockSize {
+			n = maxStoredBlockSize
+			final = 0
+		}
+		buf = append(buf, final)
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(n))
+		buf = binary.LittleEndian.AppendUint16(buf, ^uint16(n))
+		buf = append(buf, remaining[:n]...)
+		remaining = remaining[n:]
+	}
+	return binary.BigEndian.AppendUint32(buf, adler32.Checksum(data))
+}
@@ -0,0 +1,165 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package ova
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/sparse"
+)
+
+// readStreamOptimizedVMDK reconstructs the raw image from the grain directory
+// referenced by the footer of a streamOptimized VMDK
+func readStreamOptimizedVMDK(data []byte) (sparseExtentHeader, []byte) {
+	header := sparseExtentHeader{}
+	ExpectWithOffset(1, binary.Read(bytes.NewReader(data), binary.LittleEndian, &header)).To(Succeed())
+	ExpectWithOffset(1, header.MagicNumber).To(Equal(uint32(vmdkMagic)))
+	ExpectWithOffset(1, header.GdOffset).To(Equal(uint64(gdAtEnd)))
+
+	ExpectWithOffset(1, len(data)%sectorSize).To(BeZero())
+	eos := metadataMarker{}
+	ExpectWithOffset(1, binary.Read(bytes.NewReader(data[len(data)-sectorSize:]), binary.LittleEndian, &eos)).To(Succeed())
+	ExpectWithOffset(1, eos).To(Equal(metadataMarker{Type: markerEOS}))
+	footerMarker := metadataMarker{}
+	ExpectWithOffset(1, binary.Read(bytes.NewReader(data[len(data)-3*sectorSize:]), binary.LittleEndian, &footerMarker)).To(Succeed())
+	ExpectWithOffset(1, footerMarker.Type).To(Equal(uint32(markerFooter)))
+	footer := sparseExtentHeader{}
+	ExpectWithOffset(1, binary.Read(bytes.NewReader(data[len(data)-2*sectorSize:]), binary.LittleEndian, &footer)).To(Succeed())
+
+	numGrains := (footer.Capacity + grainSectors - 1) / grainSectors
+	gd := make([]uint32, (numGrains+gtEntries-1)/gtEntries)
+	ExpectWithOffset(1, binary.Read(bytes.NewReader(data[footer.GdOffset*sectorSize:]), binary.LittleEndian, gd)).To(Succeed())
+
+	raw := make([]byte, numGrains*grainSize)
+	for i, gtOffset := range gd {
+		gt := make([]uint32, gtEntries)
+		ExpectWithOffset(1, binary.Read(bytes.NewReader(data[uint64(gtOffset)*sectorSize:]), binary.LittleEndian, gt)).To(Succeed())
+		for j, grainOffset := range gt {
+			if grainOffset == 0 {
+				continue
+			}
+			marker := struct {
+				Lba  uint64
+				Size uint32
+			}{}
+			r := bytes.NewReader(data[uint64(grainOffset)*sectorSize:])
+			ExpectWithOffset(1, binary.Read(r, binary.LittleEndian, &marker)).To(Succeed())
+			lba := uint64(i*gtEntries+j) * grainSectors
+			ExpectWithOffset(1, marker.Lba).To(Equal(lba))
+			zr, err := zlib.NewReader(io.LimitReader(r, int64(marker.Size)))
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			_, err = io.ReadFull(zr, raw[lba*sectorSize:lba*sectorSize+grainSize])
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		}
+	}
+	return footer, raw[:footer.Capacity*sectorSize]
+}
+
+var _ = Describe("streamOptimized VMDK", func() {
+	convert := func(raw []byte) []byte {
+		out := &bytes.Buffer{}
+		Expect(writeStreamOptimizedVMDK(out, bytes.NewReader(raw), nil, int64(len(raw)), "disk.vmdk")).To(Succeed())
+		return out.Bytes()
+	}
+
+	It("should contain the embedded descriptor", func() {
+		data := convert(make([]byte, grainSize))
+		header, _ := readStreamOptimizedVMDK(data)
+		Expect(header.DescriptorOffset).To(Equal(uint64(1)))
+		descriptor := string(data[sectorSize : sectorSize+header.DescriptorSize*sectorSize])
+		Expect(descriptor).To(ContainSubstring(`createType="streamOptimized"`))
+		Expect(descriptor).To(ContainSubstring(`RW 128 SPARSE "disk.vmdk"`))
+	})
+
+	It("should skip grains in holes of sparse files", func() {
+		raw := make([]byte, 64*grainSize)
+		raw[40*grainSize+10] = 1
+		f, err := os.Create(filepath.Join(GinkgoT().TempDir(), "disk.img"))
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		_, err = f.WriteAt(raw[40*grainSize:41*grainSize], 40*grainSize)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Truncate(int64(len(raw)))).To(Succeed())
+
+		out := &bytes.Buffer{}
+		Expect(writeStreamOptimizedVMDK(out, f, sparse.NewHoleDetector(f), int64(len(raw)), "disk.vmdk")).To(Succeed())
+		header, converted := readStreamOptimizedVMDK(out.Bytes())
+		Expect(header.Capacity).To(Equal(uint64(64 * grainSectors)))
+		Expect(converted).To(Equal(raw))
+		Expect(out.Len()).To(BeNumerically("<", 4*grainSize))
+	})
+
+	It("should convert images spanning multiple grain tables with a partial last grain", func() {
+		raw := make([]byte, (gtEntries+1)*grainSize+3*sectorSize)
+		for i := range raw {
+			if (i/grainSize)%3 == 0 {
+				raw[i] = byte(i % 251)
+			}
+		}
+		raw[len(raw)-1] = 0xff
+
+		header, converted := readStreamOptimizedVMDK(convert(raw))
+		Expect(header.Capacity).To(Equal(uint64(len(raw) / sectorSize)))
+		Expect(converted).To(Equal(raw))
+	})
+
+	It("should round the capacity up to full sectors", func() {
+		raw := []byte("not sector aligned")
+		header, converted := readStreamOptimizedVMDK(convert(raw))
+		Expect(header.Capacity).To(Equal(uint64(1)))
+		Expect(converted[:len(raw)]).To(Equal(raw))
+	})
+
+	DescribeTable("should know the size of the image without reading it", func(capacity int) {
+		raw := bytes.Repeat([]byte("kubevirt"), capacity/8)
+		size, err := streamOptimizedVMDKSize(nil, int64(len(raw)), "disk.vmdk")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(size).To(Equal(int64(len(convert(raw)))))
+	},
+		Entry("with a single grain", grainSize),
+		Entry("with a partial last grain", 3*grainSize+sectorSize),
+		Entry("with multiple grain tables", (gtEntries+2)*grainSize),
+	)
+
+	It("should refuse disks beyond the 2TiB a VMDK can address", func() {
+		_, err := streamOptimizedVMDKSize(nil, (math.MaxUint32+1)*sectorSize, "disk.vmdk")
+		Expect(err).To(MatchError(ContainSubstring("exceeds the 2TiB a VMDK can address")))
+	})
+
+	It("should refuse grains stored beyond the 2TiB a VMDK can address", func() {
+		w := &sectorWriter{w: io.Discard, offset: math.MaxUint32 * sectorSize}
+		sector, err := w.sector32()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sector).To(Equal(uint32(math.MaxUint32)))
+
+		w.skip(sectorSize)
+		_, err = w.sector32()
+		Expect(err).To(MatchError(ContainSubstring("beyond the 2TiB a VMDK can address")))
+	})
+})
@@ -0,0 +1,438 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package qcow2
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"kubevirt.io/kubevirt/pkg/storage/export/sparse"
+)
+
+// The layout of the image is described in docs/interop/qcow2.txt of the QEMU
+// repository. All metadata is placed in front of the data clusters, so the image
+// can be written to a non seekable stream once the allocated clusters are known.
+const (
+	magic         = 0x514649fb
+	version       = 3
+	headerLength  = 104
+	clusterBits   = 16
+	clusterSize   = 1 << clusterBits
+	refcountOrder = 4
+
+	l2Entries            = clusterSize / 8
+	refcountBlockEntries = clusterSize * 8 / (1 << refcountOrder)
+	refcountTableEntries = clusterSize / 8
+
+	oflagCopied     = uint64(1) << 63
+	oflagCompressed = uint64(1) << 62
+	// csizeShift is the position of the compressed sector count in a compressed cluster descriptor
+	csizeShift  = 62 - (clusterBits - 8)
+	sectorShift = 9
+
+	// windowSize is the deflate window used by QEMU for compressed clusters
+	windowSize = 4096
+)
+
+// finalBlock is an empty deflate block with fixed Huffman codes and the final bit set
+var finalBlock = []byte{0x03, 0x00}
+
+type header struct {
+	Magic                 uint32
+	Version               uint32
+	BackingFileOffset     uint64
+	BackingFileSize       uint32
+	ClusterBits           uint32
+	Size                  uint64
+	CryptMethod           uint32
+	L1Size                uint32
+	L1TableOffset         uint64
+	RefcountTableOffset   uint64
+	RefcountTableClusters uint32
+	NbSnapshots           uint32
+	SnapshotsOffset       uint64
+	IncompatibleFeatures  uint64
+	CompatibleFeatures    uint64
+	AutoclearFeatures     uint64
+	RefcountOrder         uint32
+	HeaderLength          uint32
+}
+
+// Image is a qcow2 image of a raw disk image. Only the clusters which contain data are
+// allocated. Since the metadata is written before the data, the raw image is read once
+// when the image is created to find the clusters which only contain zeros and to get the
+// size of every compressed cluster, and a second time when the image is written.
+type Image struct {
+	r          io.ReaderAt
+	size       int64
+	compressed bool
+
+	// clusters contains the stored size of every guest cluster, 0 for clusters which
+	// are not allocated and clusterSize for clusters which are not compressed
+	clusters []uint32
+
+	l1Size                int64
+	l1Clusters            int64
+	refcountTableClusters int64
+	refcountBlocks        int64
+	l2Tables              int64
+	dataClusters          int64
+}
+
+// NewImage plans the qcow2 image of the raw image r with the given size. Clusters
+// which are holes in a sparse file are skipped without reading them, the other clusters
+// are read and only allocated if they contain data. If compressed is set, the allocated
+// clusters are compressed with deflate.
+func NewImage(r io.ReaderAt, size int64, compressed bool) (*Image, error) {
+	img := &Image{
+		r:          r,
+		size:       size,
+		compressed: compressed,
+		clusters:   make([]uint32, (size+clusterSize-1)/clusterSize),
+	}
+	if err := img.scan(); err != nil {
+		return nil, err
+	}
+	img.layout()
+	return img, nil
+}
+
+// Size returns the size of the qcow2 image
+func (img *Image) Size() int64 {
+	return (img.metadataClusters() + img.dataClusters) * clusterSize
+}
+
+func (img *Image) scan() error {
+	var holes *sparse.HoleDetector
+	if f, ok := img.r.(*os.File); ok {
+		holes = sparse.NewHoleDetector(f)
+	}
+	buf := make([]byte, clusterSize)
+	compressed := &bytes.Buffer{}
+	for i := range img.clusters {
+		offset := int64(i) * clusterSize
+		if holes.Hole(offset, clusterSize) {
+			continue
+		}
+		// Block devices and file systems without SEEK_DATA have no holes, their
+		// unused regions are only recognized by reading them
+		data, err := img.readCluster(i, buf)
+		if err != nil {
+			return err
+		}
+		if isZero(data) {
+			continue
+		}
+		img.clusters[i] = clusterSize
+		if !img.compressed {
+			continue
+		}
+		if err := compress(compressed, data); err != nil {
+			return err
+		}
+		if compressed.Len() < clusterSize {
+			img.clusters[i] = uint32(compressed.Len())
+		}
+	}
+	return nil
+}
+
+// layout calculates the number of clusters needed for the metadata. The number
+// of refcount blocks depends on the size of the image, which in turn depends on
+// the number of refcount blocks and refcount table clusters.
+func (img *Image) layout() {
+	img.l1Size = (int64(len(img.clusters)) + l2Entries - 1) / l2Entries
+	if img.l1Size == 0 {
+		img.l1Size = 1
+	}
+	img.l1Clusters = (img.l1Size*8 + clusterSize - 1) / clusterSize
+
+	img.l2Tables = 0
+	for l1Index := int64(0); l1Index < img.l1Size; l1Index++ {
+		if img.hasL2Table(l1Index) {
+			img.l2Tables++
+		}
+	}
+
+	dataSize := int64(0)
+	img.forEachDataCluster(0, func(_ int, offset int64, size uint32) {
+		dataSize = offset + int64(size)
+	})
+	img.dataClusters = (dataSize + clusterSize - 1) / clusterSize
+
+	img.refcountBlocks, img.refcountTableClusters = 1, 1
+	for {
+		total := img.metadataClusters() + img.dataClusters
+		blocks := (total + refcountBlockEntries - 1) / refcountBlockEntries
+		tableClusters := (blocks + refcountTableEntries - 1) / refcountTableEntries
+		if blocks == img.refcountBlocks && tableClusters == img.refcountTableClusters {
+			return
+		}
+		img.refcountBlocks, img.refcountTableClusters = blocks, tableClusters
+	}
+}
+
+func (img *Image) metadataClusters() int64 {
+	return 1 + img.l1Clusters + img.refcountTableClusters + img.refcountBlocks + img.l2Tables
+}
+
+func (img *Image) hasL2Table(l1Index int64) bool {
+	for i := l1Index * l2Entries; i < (l1Index+1)*l2Entries && i < int64(len(img.clusters)); i++ {
+		if img.clusters[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachDataCluster calls fn with the host offset of every allocated cluster. Compressed
+// clusters are packed, while uncompressed clusters have to be aligned to the cluster size.
+func (img *Image) forEachDataCluster(dataOffset int64, fn func(index int, offset int64, size uint32)) {
+	offset := dataOffset
+	for i, size := range img.clusters {
+		if size == 0 {
+			continue
+		}
+		if size == clusterSize {
+			offset = alignUp(offset)
+		}
+		fn(i, offset, size)
+		offset += int64(size)
+	}
+}
+
+func (img *Image) readCluster(index int, buf []byte) ([]byte, error) {
+	offset := int64(index) * clusterSize
+	length := int64(clusterSize)
+	if offset+length > img.size {
+		length = img.size - offset
+	}
+	n, err := img.r.ReadAt(buf[:length], offset)
+	if err != nil && !(err == io.EOF && int64(n) == length) {
+		return nil, err
+	}
+	for j := length; j < clusterSize; j++ {
+		buf[j] = 0
+	}
+	return buf, nil
+}
+
+// WriteTo writes the qcow2 image to w
+func (img *Image) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	err := img.write(cw)
+	return cw.n, err
+}
+
+func (img *Image) write(w *countingWriter) error {
+	l1Offset := int64(clusterSize)
+	refcountTableOffset := l1Offset + img.l1Clusters*clusterSize
+	refcountBlocksOffset := refcountTableOffset + img.refcountTableClusters*clusterSize
+	l2Offset := refcountBlocksOffset + img.refcountBlocks*clusterSize
+	dataOffset := img.metadataClusters() * clusterSize
+
+	hdr := header{
+		Magic:                 magic,
+		Version:               version,
+		ClusterBits:           clusterBits,
+		Size:                  uint64(img.size),
+		L1Size:                uint32(img.l1Size),
+		L1TableOffset:         uint64(l1Offset),
+		RefcountTableOffset:   uint64(refcountTableOffset),
+		RefcountTableClusters: uint32(img.refcountTableClusters),
+		RefcountOrder:         refcountOrder,
+		HeaderLength:          headerLength,
+	}
+	if err := binary.Write(w, binary.BigEndian, hdr); err != nil {
+		return err
+	}
+	if err := w.padToCluster(); err != nil {
+		return err
+	}
+
+	l1 := make([]uint64, img.l1Size)
+	next := l2Offset
+	for l1Index := range l1 {
+		if img.hasL2Table(int64(l1Index)) {
+			l1[l1Index] = uint64(next) | oflagCopied
+			next += clusterSize
+		}
+	}
+	if err := img.writeTable(w, l1); err != nil {
+		return err
+	}
+
+	refcountTable := make([]uint64, img.refcountBlocks)
+	for i := range refcountTable {
+		refcountTable[i] = uint64(refcountBlocksOffset + int64(i)*clusterSize)
+	}
+	if err := img.writeTable(w, refcountTable); err != nil {
+		return err
+	}
+	if err := img.writeTable(w, img.refcounts(dataOffset)); err != nil {
+		return err
+	}
+
+	l2 := make([]uint64, img.l2Tables*l2Entries)
+	l2Index := int64(-1)
+	lastL1Index := int64(-1)
+	img.forEachDataCluster(dataOffset, func(index int, offset int64, size uint32) {
+		if l1Index := int64(index) / l2Entries; l1Index != lastL1Index {
+			lastL1Index = l1Index
+			l2Index++
+		}
+		l2[l2Index*l2Entries+int64(index)%l2Entries] = clusterDescriptor(offset, size)
+	})
+	if err := img.writeTable(w, l2); err != nil {
+		return err
+	}
+
+	return img.writeData(w, dataOffset)
+}
+
+func (img *Image) writeData(w *countingWriter, dataOffset int64) error {
+	buf := make([]byte, clusterSize)
+	compressed := &bytes.Buffer{}
+	var err error
+	img.forEachDataCluster(dataOffset, func(index int, offset int64, size uint32) {
+		if err != nil {
+			return
+		}
+		if padding := offset - w.n; padding > 0 {
+			if _, err = w.Write(make([]byte, padding)); err != nil {
+				return
+			}
+		}
+		var data []byte
+		if data, err = img.readCluster(index, buf); err != nil {
+			return
+		}
+		if size != clusterSize {
+			if err = compress(compressed, data); err != nil {
+				return
+			}
+			data = compressed.Bytes()
+		}
+		if uint32(len(data)) != size {
+			err = fmt.Errorf("cluster %d changed while writing the image", index)
+			return
+		}
+		_, err = w.Write(data)
+	})
+	if err != nil {
+		return err
+	}
+	return w.padToCluster()
+}
+
+// refcounts returns the reference count of every host cluster. Compressed clusters
+// reference all host clusters they span.
+func (img *Image) refcounts(dataOffset int64) []uint16 {
+	refcounts := make([]uint16, img.refcountBlocks*refcountBlockEntries)
+	for i := int64(0); i < img.metadataClusters(); i++ {
+		refcounts[i] = 1
+	}
+	img.forEachDataCluster(dataOffset, func(_ int, offset int64, size uint32) {
+		for c := offset / clusterSize; c <= (offset+int64(size)-1)/clusterSize; c++ {
+			refcounts[c]++
+		}
+	})
+	return refcounts
+}
+
+func (img *Image) writeTable(w *countingWriter, table interface{}) error {
+	if err := binary.Write(w, binary.BigEndian, table); err != nil {
+		return err
+	}
+	return w.padToCluster()
+}
+
+func clusterDescriptor(offset int64, size uint32) uint64 {
+	if size == clusterSize {
+		return uint64(offset) | oflagCopied
+	}
+	sectors := uint64(((offset + int64(size) - 1) >> sectorShift) - (offset >> sectorShift))
+	return uint64(offset) | oflagCompressed | sectors<<csizeShift
+}
+
+// compress deflates the cluster without references to data further back than 4KiB,
+// since QEMU inflates compressed clusters with a window of that size. The deflate
+// implementation of the standard library does not allow limiting the window, so the
+// dictionary is reset for every 4KiB of the cluster instead. The blocks of every part
+// end with a sync flush and the stream is terminated with an empty final block.
+func compress(buf *bytes.Buffer, data []byte) error {
+	buf.Reset()
+	fw, err := flate.NewWriter(buf, flate.DefaultCompression)
+	if err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		n := windowSize
+		if n > len(data) {
+			n = len(data)
+		}
+		fw.Reset(buf)
+		if _, err := fw.Write(data[:n]); err != nil {
+			return err
+		}
+		if err := fw.Flush(); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	buf.Write(finalBlock)
+	return nil
+}
+
+func alignUp(offset int64) int64 {
+	return (offset + clusterSize - 1) / clusterSize * clusterSize
+}
+
+func isZero(buf []byte) bool {
+	for _, b := range buf {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingWriter) padToCluster() error {
+	if rem := c.n % clusterSize; rem != 0 {
+		_, err := c.Write(make([]byte, clusterSize-rem))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package qcow2
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const offsetMask = uint64(1)<<56 - 1
+
+// readImage reconstructs the raw image and verifies the reference counts, like qemu-img check does
+func readImage(data []byte) (header, []byte) {
+	hdr := header{}
+	ExpectWithOffset(1, binary.Read(bytes.NewReader(data), binary.BigEndian, &hdr)).To(Succeed())
+	ExpectWithOffset(1, hdr.Magic).To(Equal(uint32(magic)))
+	ExpectWithOffset(1, hdr.Version).To(Equal(uint32(version)))
+	ExpectWithOffset(1, len(data)%clusterSize).To(BeZero())
+
+	refcounts := make([]uint16, len(data)/clusterSize)
+	reference := func(offset, length uint64) {
+		for c := offset / clusterSize; c <= (offset+length-1)/clusterSize; c++ {
+			refcounts[c]++
+		}
+	}
+	reference(0, clusterSize)
+	reference(hdr.L1TableOffset, uint64(hdr.L1Size)*8)
+
+	refcountTable := make([]uint64, uint64(hdr.RefcountTableClusters)*clusterSize/8)
+	ExpectWithOffset(1, binary.Read(bytes.NewReader(data[hdr.RefcountTableOffset:]), binary.BigEndian, refcountTable)).To(Succeed())
+	reference(hdr.RefcountTableOffset, uint64(len(refcountTable))*8)
+	stored := []uint16{}
+	for _, blockOffset := range refcountTable {
+		if blockOffset == 0 {
+			continue
+		}
+		reference(blockOffset, clusterSize)
+		block := make([]uint16, refcountBlockEntries)
+		ExpectWithOffset(1, binary.Read(bytes.NewReader(data[blockOffset:]), binary.BigEndian, block)).To(Succeed())
+		stored = append(stored, block...)
+	}
+
+	l1 := make([]uint64, hdr.L1Size)
+	ExpectWithOffset(1, binary.Read(bytes.NewReader(data[hdr.L1TableOffset:]), binary.BigEndian, l1)).To(Succeed())
+	raw := make([]byte, uint64(hdr.L1Size)*l2Entries*clusterSize)
+	for l1Index, l1Entry := range l1 {
+		if l1Entry == 0 {
+			continue
+		}
+		l2Offset := l1Entry & offsetMask
+		reference(l2Offset, clusterSize)
+		l2 := make([]uint64, l2Entries)
+		ExpectWithOffset(1, binary.Read(bytes.NewReader(data[l2Offset:]), binary.BigEndian, l2)).To(Succeed())
+		for l2Index, l2Entry := range l2 {
+			if l2Entry == 0 {
+				continue
+			}
+			guestOffset := (uint64(l1Index)*l2Entries + uint64(l2Index)) * clusterSize
+			cluster := raw[guestOffset : guestOffset+clusterSize]
+			if l2Entry&oflagCompressed == 0 {
+				hostOffset := l2Entry & offsetMask
+				ExpectWithOffset(1, hostOffset%clusterSize).To(BeZero())
+				reference(hostOffset, clusterSize)
+				copy(cluster, data[hostOffset:])
+				continue
+			}
+			hostOffset := l2Entry & (uint64(1)<<csizeShift - 1)
+			sectors := (l2Entry&^oflagCompressed)>>csizeShift + 1
+			length := sectors*512 - hostOffset%512
+			reference(hostOffset, length)
+			_, err := io.ReadFull(flate.NewReader(bytes.NewReader(data[hostOffset:hostOffset+length])), cluster)
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		}
+	}
+	ExpectWithOffset(1, stored[:len(refcounts)]).To(Equal(refcounts))
+	return hdr, raw[:hdr.Size]
+}
+
+type failingReader struct{}
+
+func (failingReader) ReadAt([]byte, int64) (int, error) {
+	return 0, errors.New("unexpected read")
+}
+
+var _ = Describe("qcow2", func() {
+	convert := func(raw []byte, compressed bool) []byte {
+		img, err := NewImage(bytes.NewReader(raw), int64(len(raw)), compressed)
+		Expect(err).ToNot(HaveOccurred())
+		out := &bytes.Buffer{}
+		n, err := img.WriteTo(out)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(img.Size()))
+		Expect(int64(out.Len())).To(Equal(img.Size()))
+		return out.Bytes()
+	}
+
+	newRaw := func(size int, dataClusters ...int) []byte {
+		raw := make([]byte, size)
+		for _, c := range dataClusters {
+			for i := c * clusterSize; i < (c+1)*clusterSize && i < size; i++ {
+				raw[i] = byte(i % 253)
+			}
+		}
+		return raw
+	}
+
+	DescribeTable("should convert the raw image", func(compressed bool) {
+		raw := newRaw(10*clusterSize+1000, 0, 3, 10)
+		hdr, converted := readImage(convert(raw, compressed))
+		Expect(hdr.Size).To(Equal(uint64(len(raw))))
+		Expect(converted).To(Equal(raw))
+	},
+		Entry("uncompressed", false),
+		Entry("compressed", true),
+	)
+
+	DescribeTable("should convert images which need several L2 tables", func(compressed bool) {
+		raw := newRaw((l2Entries+2)*clusterSize, 1, l2Entries+1)
+		hdr, converted := readImage(convert(raw, compressed))
+		Expect(hdr.L1Size).To(Equal(uint32(2)))
+		Expect(converted).To(Equal(raw))
+	},
+		Entry("uncompressed", false),
+		Entry("compressed", true),
+	)
+
+	DescribeTable("should only allocate clusters which contain data", func(compressed bool) {
+		// the reader does not support SEEK_DATA, like a block device
+		raw := newRaw(100*clusterSize, 50)
+		data := convert(raw, compressed)
+		// header, L1 table, refcount table, refcount block, L2 table and a single data cluster
+		Expect(data).To(HaveLen(6 * clusterSize))
+		_, converted := readImage(data)
+		Expect(converted).To(Equal(raw))
+	},
+		Entry("uncompressed", false),
+		Entry("compressed", true),
+	)
+
+	It("should fail if the raw image can not be read", func() {
+		_, err := NewImage(failingReader{}, 3*clusterSize, false)
+		Expect(err).To(MatchError("unexpected read"))
+	})
+
+	It("should reduce the size of compressible clusters", func() {
+		raw := bytes.Repeat([]byte("aaaaaaab"), 4*clusterSize/8)
+		compressed := convert(raw, true)
+		Expect(len(compressed)).To(BeNumerically("<", len(convert(raw, false))))
+		_, converted := readImage(compressed)
+		Expect(converted).To(Equal(raw))
+	})
+
+	It("should compress clusters with references within the window", func() {
+		buf := &bytes.Buffer{}
+		Expect(compress(buf, bytes.Repeat([]byte("aaaaaaab"), clusterSize/8))).To(Succeed())
+		// Huffman coding alone needs more than one bit per byte
+		Expect(buf.Len()).To(BeNumerically("<", clusterSize/16))
+		inflated, err := io.ReadAll(flate.NewReader(buf))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inflated).To(Equal(bytes.Repeat([]byte("aaaaaaab"), clusterSize/8)))
+	})
+
+	It("should convert an empty image", func() {
+		hdr, converted := readImage(convert(newRaw(3*clusterSize), true))
+		Expect(hdr.Size).To(Equal(uint64(3 * clusterSize)))
+		Expect(converted).To(Equal(newRaw(3 * clusterSize)))
+	})
+
+	It("should fail if the raw image changed after it was scanned", func() {
+		raw := bytes.Repeat([]byte("aaaaaaab"), 2*clusterSize/8)
+		img, err := NewImage(bytes.NewReader(raw), int64(len(raw)), true)
+		Expect(err).ToNot(HaveOccurred())
+		copy(raw, make([]byte, clusterSize/2))
+		_, err = img.WriteTo(io.Discard)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should convert sparse files", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "disk.img")
+		f, err := os.Create(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		raw := newRaw(64*clusterSize, 40)
+		_, err = f.WriteAt(raw[40*clusterSize:41*clusterSize], 40*clusterSize)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Truncate(int64(len(raw)))).To(Succeed())
+
+		img, err := NewImage(f, int64(len(raw)), false)
+		Expect(err).ToNot(HaveOccurred())
+		out := &bytes.Buffer{}
+		_, err = img.WriteTo(out)
+		Expect(err).ToNot(HaveOccurred())
+		_, converted := readImage(out.Bytes())
+		Expect(converted).To(Equal(raw))
+	})
+})
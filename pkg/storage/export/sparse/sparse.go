@@ -0,0 +1,74 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package sparse
+
+import (
+	"errors"
+	"math"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// HoleDetector uses SEEK_DATA and SEEK_HOLE to find unallocated regions of sparse
+// files, which do not need to be read. If the file system or device does not
+// support it, no holes are reported and unused regions have to be recognized by
+// reading them.
+type HoleDetector struct {
+	f        *os.File
+	disabled bool
+	// the next data region at or after the last queried offset
+	dataStart int64
+	dataEnd   int64
+}
+
+// NewHoleDetector returns a HoleDetector for the file, queries are expected
+// in ascending order of the offset
+func NewHoleDetector(f *os.File) *HoleDetector {
+	return &HoleDetector{f: f}
+}
+
+// Hole reports whether the region is known to be unallocated
+func (h *HoleDetector) Hole(offset, length int64) bool {
+	if h == nil || h.disabled {
+		return false
+	}
+	if offset < h.dataEnd {
+		return offset+length <= h.dataStart
+	}
+	start, err := h.f.Seek(offset, unix.SEEK_DATA)
+	if err != nil {
+		if errors.Is(err, syscall.ENXIO) {
+			// no data after offset
+			h.dataStart, h.dataEnd = math.MaxInt64, math.MaxInt64
+			return true
+		}
+		h.disabled = true
+		return false
+	}
+	end, err := h.f.Seek(start, unix.SEEK_HOLE)
+	if err != nil {
+		h.disabled = true
+		return false
+	}
+	h.dataStart, h.dataEnd = start, end
+	return offset+length <= start
+}
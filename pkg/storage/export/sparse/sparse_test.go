@@ -0,0 +1,50 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package sparse
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HoleDetector", func() {
+	const blockSize = 64 * 1024
+
+	It("should never report allocated regions as holes", func() {
+		f, err := os.Create(filepath.Join(GinkgoT().TempDir(), "disk.img"))
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		_, err = f.WriteAt([]byte{1}, 40*blockSize)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Truncate(64 * blockSize)).To(Succeed())
+
+		holes := NewHoleDetector(f)
+		Expect(holes.Hole(39*blockSize, 2*blockSize)).To(BeFalse())
+		Expect(holes.Hole(40*blockSize, blockSize)).To(BeFalse())
+	})
+
+	It("should treat everything as data without a file", func() {
+		var holes *HoleDetector
+		Expect(holes.Hole(0, blockSize)).To(BeFalse())
+	})
+})
@@ -23,7 +23,9 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	goflag "flag"
@@ -34,7 +36,9 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	flag "github.com/spf13/pflag"
@@ -50,6 +54,8 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/service"
+	"kubevirt.io/kubevirt/pkg/storage/export/ova"
+	"kubevirt.io/kubevirt/pkg/storage/export/qcow2"
 )
 
 const (
@@ -69,13 +75,18 @@ const (
 type TokenGetterFunc func() (string, error)
 
 type VolumeInfo struct {
-	Path       string
-	ArchiveURI string
-	DirURI     string
-	RawURI     string
-	RawGzURI   string
-	VMURI      string
-	SecretURI  string
+	Path               string
+	ArchiveURI         string
+	DirURI             string
+	RawURI             string
+	RawGzURI           string
+	Qcow2URI           string
+	Qcow2CompressedURI string
+	RawSha256URI       string
+	BackupURI          string
+	VMURI              string
+	SecretURI          string
+	OvaURI             string
 }
 type ExportServerConfig struct {
 	Deadline time.Time
@@ -93,8 +104,12 @@ type ExportServerConfig struct {
 	DirHandler         func(string, string) http.Handler
 	FileHandler        func(string) http.Handler
 	GzipHandler        func(string) http.Handler
+	Qcow2Handler       func(string, bool) http.Handler
+	ChecksumHandler    func(string) http.Handler
+	BackupHandler      func(string, string) http.Handler
 	VmHandler          func(string, []VolumeInfo, func() (string, error), func() (*corev1.ConfigMap, error)) http.Handler
 	TokenSecretHandler func(TokenGetterFunc) http.Handler
+	OvaHandler         func([]VolumeInfo) http.Handler
 
 	TokenGetter TokenGetterFunc
 }
@@ -110,6 +125,17 @@ type exportServer struct {
 	handler http.Handler
 }
 
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
 func (er *execReader) Read(p []byte) (int, error) {
 	n, err := er.stdout.Read(p)
 	if err == io.EOF {
@@ -144,6 +170,9 @@ func (s *exportServer) initHandler() {
 				mux.Handle(filepath.Join(internal, vi.SecretURI), tokenChecker(s.TokenGetter, s.TokenSecretHandler(s.TokenGetter)))
 				mux.Handle(filepath.Join(external, vi.SecretURI), tokenChecker(s.TokenGetter, s.TokenSecretHandler(s.TokenGetter)))
 			}
+			if vi.OvaURI != "" {
+				mux.Handle(vi.OvaURI, tokenChecker(s.TokenGetter, s.OvaHandler(s.Volumes)))
+			}
 		}
 	}
 
@@ -175,6 +204,10 @@ func (s *exportServer) getHandlerMap(vi VolumeInfo) map[string]http.Handler {
 		result[vi.DirURI] = s.DirHandler(vi.DirURI, vi.Path)
 	}
 
+	if vi.BackupURI != "" {
+		result[vi.BackupURI] = s.BackupHandler(vi.BackupURI, vi.Path)
+	}
+
 	p := vi.Path
 	if fi.IsDir() {
 		p = path.Join(p, "disk.img")
@@ -188,6 +221,18 @@ func (s *exportServer) getHandlerMap(vi VolumeInfo) map[string]http.Handler {
 		result[vi.RawGzURI] = s.GzipHandler(p)
 	}
 
+	if vi.Qcow2URI != "" {
+		result[vi.Qcow2URI] = s.Qcow2Handler(p, false)
+	}
+
+	if vi.Qcow2CompressedURI != "" {
+		result[vi.Qcow2CompressedURI] = s.Qcow2Handler(p, true)
+	}
+
+	if vi.RawSha256URI != "" {
+		result[vi.RawSha256URI] = s.ChecksumHandler(p)
+	}
+
 	return result
 }
 
@@ -247,6 +292,18 @@ func NewExportServer(config ExportServerConfig) service.Service {
 		es.GzipHandler = gzipHandler
 	}
 
+	if es.Qcow2Handler == nil {
+		es.Qcow2Handler = qcow2Handler
+	}
+
+	if es.ChecksumHandler == nil {
+		es.ChecksumHandler = checksumHandler
+	}
+
+	if es.BackupHandler == nil {
+		es.BackupHandler = backupHandler
+	}
+
 	if es.VmHandler == nil {
 		es.VmHandler = vmHandler
 	}
@@ -255,6 +312,10 @@ func NewExportServer(config ExportServerConfig) service.Service {
 		es.TokenSecretHandler = secretHandler
 	}
 
+	if es.OvaHandler == nil {
+		es.OvaHandler = ovaHandler
+	}
+
 	if es.TokenGetter == nil {
 		es.TokenGetter = func() (string, error) {
 			return getToken(es.TokenFile)
@@ -515,6 +576,145 @@ func gzipHandler(filePath string) http.Handler {
 	})
 }
 
+// qcow2Handler streams a qcow2 image of the raw image. The metadata depends on which clusters
+// contain data and on the size of every compressed cluster, so the image is planned in the
+// background when it is requested for the first time, requests made before the image is ready
+// are answered with 202 and a Retry-After header.
+func qcow2Handler(filePath string, compressed bool) http.Handler {
+	var (
+		img *qcow2.Image
+		err error
+	)
+	plan := newBackgroundTask(func() {
+		// The raw image stays open for the lifetime of the export server, the image
+		// is read from it by every request
+		img, _, err = openQcow2Image(filePath, compressed)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !plan.Done() {
+			w.Header().Set("Retry-After", retryAfterSeconds)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.FormatInt(img.Size(), 10))
+		n, err := img.WriteTo(w)
+		if err != nil {
+			log.Log.Reason(err).Error("error writing response body")
+		}
+		log.Log.Infof("Wrote %d bytes\n", n)
+	})
+}
+
+func openQcow2Image(filePath string, compressed bool) (*qcow2.Image, *os.File, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		log.Log.Reason(err).Errorf("error opening %s", filePath)
+		return nil, nil, err
+	}
+	// Stat does not return the size of block devices
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		f.Close()
+		log.Log.Reason(err).Errorf("error getting the size of %s", filePath)
+		return nil, nil, err
+	}
+	img, err := qcow2.NewImage(f, size, compressed)
+	if err != nil {
+		f.Close()
+		log.Log.Reason(err).Errorf("error scanning %s", filePath)
+		return nil, nil, err
+	}
+	return img, f, nil
+}
+
+// retryAfterSeconds is the time clients are asked to wait for a response which is still being prepared
+const retryAfterSeconds = "5"
+
+// backgroundTask runs a function in the background the first time its result is asked for,
+// so exports which are never downloaded in a format do not read the whole volume for it
+type backgroundTask struct {
+	start sync.Once
+	done  chan struct{}
+	fn    func()
+}
+
+func newBackgroundTask(fn func()) *backgroundTask {
+	return &backgroundTask{done: make(chan struct{}), fn: fn}
+}
+
+// Done starts the task if it is not running yet and reports whether it has finished
+func (t *backgroundTask) Done() bool {
+	t.start.Do(func() {
+		go func() {
+			defer close(t.done)
+			t.fn()
+		}()
+	})
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// checksumHandler returns the SHA-256 digest of the raw image in the format of sha256sum. The
+// digest is calculated in the background when it is requested for the first time, requests made
+// before it is ready are answered with 202 and a Retry-After header.
+func checksumHandler(filePath string) http.Handler {
+	var (
+		checksum string
+		err      error
+	)
+	calculate := newBackgroundTask(func() {
+		checksum, err = calculateChecksum(filePath)
+		if err != nil {
+			log.Log.Reason(err).Errorf("error calculating the checksum of %s", filePath)
+		}
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !calculate.Done() {
+			w.Header().Set("Retry-After", retryAfterSeconds)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		if _, err := fmt.Fprintf(w, "%s  disk.img\n", checksum); err != nil {
+			log.Log.Reason(err).Error("error writing response body")
+		}
+	})
+}
+
+func calculateChecksum(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func vmHandler(filePath string, vi []VolumeInfo, getBasePath func() (string, error), getCmFunc func() (*corev1.ConfigMap, error)) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		if req.Method != http.MethodGet {
@@ -609,6 +809,82 @@ func vmHandler(filePath string, vi []VolumeInfo, getBasePath func() (string, err
 	})
 }
 
+// getOvaDisks returns the raw images of the VM volumes in the order of the VM spec. When
+// exporting snapshots, the restored PVCs are prefixed with the name of the export.
+func getOvaDisks(vm *virtv1.VirtualMachine, vi []VolumeInfo, exportName string) ([]ova.Disk, error) {
+	disks := make([]ova.Disk, 0)
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		name := ""
+		if volume.DataVolume != nil {
+			name = volume.DataVolume.Name
+		} else if volume.PersistentVolumeClaim != nil {
+			name = volume.PersistentVolumeClaim.ClaimName
+		}
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, info := range vi {
+			pvcName := filepath.Base(info.Path)
+			if info.RawURI == "" || (pvcName != name && pvcName != fmt.Sprintf("%s-%s", exportName, name)) {
+				continue
+			}
+			p := info.Path
+			if fi, err := os.Stat(p); err == nil && fi.IsDir() {
+				p = path.Join(p, "disk.img")
+			}
+			disks = append(disks, ova.Disk{Name: volume.Name, Path: p})
+			found = true
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("volume %s is not a disk image available in the export", volume.Name)
+		}
+	}
+	return disks, nil
+}
+
+func ovaHandler(vi []VolumeInfo) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		exportName, err := getExportName()
+		if err != nil {
+			log.Log.Reason(err).Error("error reading export name")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		vm := getExpandedVM()
+		if vm == nil {
+			log.Log.Error("error getting VM definition")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		disks, err := getOvaDisks(vm, vi, exportName)
+		if err != nil {
+			log.Log.Reason(err).Error("error collecting the OVA disks")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-tar")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", vm.Name+".ova"))
+		// The disks are opened and their sizes calculated before anything is
+		// written, so failures up to then can still be reported with the status code
+		cw := &countingWriter{w: w}
+		if err := ova.Write(cw, vm, disks); err != nil {
+			log.Log.Reason(err).Error("error writing OVA")
+			if cw.n == 0 {
+				w.Header().Del("Content-Disposition")
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			return
+		}
+		log.Log.Infof("Wrote %d bytes\n", cw.n)
+	})
+}
+
 func resourceToBytesJson(resources []runtime.Object) ([]byte, error) {
 	list := corev1.List{
 		TypeMeta: metav1.TypeMeta{
@@ -644,6 +920,35 @@ func dirHandler(uri, mountPoint string) http.Handler {
 	return http.StripPrefix(uri, http.FileServer(http.Dir(mountPoint)))
 }
 
+// backupHandler serves the qcow2 files written by VirtualMachineBackups to the root of the
+// mount point, ranges are supported to resume interrupted downloads
+func backupHandler(uri, mountPoint string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		name := strings.TrimPrefix(req.URL.Path, uri)
+		if name == "" || strings.Contains(name, "/") || filepath.Ext(name) != ".qcow2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		filePath := filepath.Join(mountPoint, name)
+		f, err := os.Open(filePath)
+		if errors.Is(err, os.ErrNotExist) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Log.Reason(err).Errorf("error opening %s", filePath)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, req, name, time.Time{}, f)
+	})
+}
+
 func fileHandler(file string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		f, err := os.Open(file)
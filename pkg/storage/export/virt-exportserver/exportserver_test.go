@@ -20,12 +20,18 @@
 package virtexportserver
 
 import (
+	"archive/tar"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -61,12 +67,24 @@ func newTestServer(token string) *exportServer {
 		GzipHandler: func(string) http.Handler {
 			return http.HandlerFunc(successHandler)
 		},
+		Qcow2Handler: func(string, bool) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
+		ChecksumHandler: func(string) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
+		BackupHandler: func(string, string) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
 		VmHandler: func(string, []VolumeInfo, func() (string, error), func() (*v1.ConfigMap, error)) http.Handler {
 			return http.HandlerFunc(successHandler)
 		},
 		TokenSecretHandler: func(tgf TokenGetterFunc) http.Handler {
 			return http.HandlerFunc(successHandler)
 		},
+		OvaHandler: func([]VolumeInfo) http.Handler {
+			return http.HandlerFunc(successHandler)
+		},
 		TokenGetter: func() (string, error) {
 			return token, nil
 		},
@@ -113,6 +131,22 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", RawGzURI: "/volume/v1/disk.img.gz"},
 			"/volume/v1/disk.img.gz",
 		),
+		Entry("qcow2 URI",
+			VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2",
+		),
+		Entry("compressed qcow2 URI",
+			VolumeInfo{Path: "/tmp", Qcow2CompressedURI: "/volume/v1/disk-compressed.qcow2"},
+			"/volume/v1/disk-compressed.qcow2",
+		),
+		Entry("raw checksum URI",
+			VolumeInfo{Path: "/tmp", RawSha256URI: "/volume/v1/disk.img.sha256"},
+			"/volume/v1/disk.img.sha256",
+		),
+		Entry("backup URI",
+			VolumeInfo{Path: "/tmp", BackupURI: "/volume/v1/backup/"},
+			"/volume/v1/backup/backup-disk.qcow2",
+		),
 		Entry("VM definition URI",
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest"},
 			"/internal/manifest",
@@ -121,6 +155,10 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest/secret"},
 			"/internal/manifest/secret",
 		),
+		Entry("OVA URI",
+			VolumeInfo{Path: "/tmp", OvaURI: "/bundles/ova"},
+			"/bundles/ova",
+		),
 	)
 
 	DescribeTable("should handle (query param version)", func(vi VolumeInfo, uri string) {
@@ -159,6 +197,22 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", RawGzURI: "/volume/v1/disk.img.gz"},
 			"/volume/v1/disk.img.gz",
 		),
+		Entry("qcow2 URI",
+			VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2",
+		),
+		Entry("compressed qcow2 URI",
+			VolumeInfo{Path: "/tmp", Qcow2CompressedURI: "/volume/v1/disk-compressed.qcow2"},
+			"/volume/v1/disk-compressed.qcow2",
+		),
+		Entry("raw checksum URI",
+			VolumeInfo{Path: "/tmp", RawSha256URI: "/volume/v1/disk.img.sha256"},
+			"/volume/v1/disk.img.sha256",
+		),
+		Entry("backup URI",
+			VolumeInfo{Path: "/tmp", BackupURI: "/volume/v1/backup/"},
+			"/volume/v1/backup/backup-disk.qcow2",
+		),
 		Entry("VM definition URI",
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest"},
 			"/internal/manifest",
@@ -167,6 +221,10 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest/secret"},
 			"/internal/manifest/secret",
 		),
+		Entry("OVA URI",
+			VolumeInfo{Path: "/tmp", OvaURI: "/bundles/ova"},
+			"/bundles/ova",
+		),
 	)
 
 	DescribeTable("should fail bad token", func(vi VolumeInfo, uri string) {
@@ -202,6 +260,22 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", RawGzURI: "/volume/v1/disk.img.gz"},
 			"/volume/v1/disk.img.gz",
 		),
+		Entry("qcow2 URI",
+			VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2",
+		),
+		Entry("compressed qcow2 URI",
+			VolumeInfo{Path: "/tmp", Qcow2CompressedURI: "/volume/v1/disk-compressed.qcow2"},
+			"/volume/v1/disk-compressed.qcow2",
+		),
+		Entry("raw checksum URI",
+			VolumeInfo{Path: "/tmp", RawSha256URI: "/volume/v1/disk.img.sha256"},
+			"/volume/v1/disk.img.sha256",
+		),
+		Entry("backup URI",
+			VolumeInfo{Path: "/tmp", BackupURI: "/volume/v1/backup/"},
+			"/volume/v1/backup/backup-disk.qcow2",
+		),
 		Entry("VM definition URI",
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest"},
 			"/external/manifest",
@@ -210,6 +284,10 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest/secret"},
 			"/external/manifest/secret",
 		),
+		Entry("OVA URI",
+			VolumeInfo{Path: "/tmp", OvaURI: "/bundles/ova"},
+			"/bundles/ova",
+		),
 	)
 
 	DescribeTable("should fail bad token (query param version)", func(vi VolumeInfo, uri string) {
@@ -244,6 +322,22 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", RawGzURI: "/volume/v1/disk.img.gz"},
 			"/volume/v1/disk.img.gz",
 		),
+		Entry("qcow2 URI",
+			VolumeInfo{Path: "/tmp", Qcow2URI: "/volume/v1/disk.qcow2"},
+			"/volume/v1/disk.qcow2",
+		),
+		Entry("compressed qcow2 URI",
+			VolumeInfo{Path: "/tmp", Qcow2CompressedURI: "/volume/v1/disk-compressed.qcow2"},
+			"/volume/v1/disk-compressed.qcow2",
+		),
+		Entry("raw checksum URI",
+			VolumeInfo{Path: "/tmp", RawSha256URI: "/volume/v1/disk.img.sha256"},
+			"/volume/v1/disk.img.sha256",
+		),
+		Entry("backup URI",
+			VolumeInfo{Path: "/tmp", BackupURI: "/volume/v1/backup/"},
+			"/volume/v1/backup/backup-disk.qcow2",
+		),
 		Entry("VM definition URI",
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest"},
 			"/external/manifest",
@@ -252,6 +346,10 @@ var _ = Describe("exportserver", func() {
 			VolumeInfo{Path: "/tmp", VMURI: "/manifest/secret"},
 			"/internal/manifest/secret",
 		),
+		Entry("OVA URI",
+			VolumeInfo{Path: "/tmp", OvaURI: "/bundles/ova"},
+			"/bundles/ova",
+		),
 	)
 
 	Context("Vm handler", func() {
@@ -729,4 +827,376 @@ var _ = Describe("exportserver", func() {
 			verifySecret(string(list.Items[0].Raw))
 		})
 	})
+
+	Context("qcow2 handler", func() {
+		DescribeTable("should return error on non GET", func(verb string) {
+			req, err := http.NewRequest(verb, "https://test.blah.invalid/volume/v1/disk.qcow2", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			qcow2Handler("/tmp/disk.img", false).ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusBadRequest))
+		},
+			Entry("POST", "POST"),
+			Entry("PUT", "PUT"),
+			Entry("PATCH", "PATCH"),
+			Entry("DELETE", "DELETE"),
+		)
+
+		serveQcow2 := func(handler http.Handler) *httptest.ResponseRecorder {
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/volume/v1/disk.qcow2", nil)
+			Expect(err).ToNot(HaveOccurred())
+			var resp *httptest.ResponseRecorder
+			Eventually(func() int {
+				resp = httptest.NewRecorder()
+				handler.ServeHTTP(resp, req)
+				return resp.Code
+			}).WithTimeout(5 * time.Second).WithPolling(10 * time.Millisecond).ShouldNot(Equal(http.StatusAccepted))
+			return resp
+		}
+
+		DescribeTable("should return 500 if the image cannot be opened", func(compressed bool) {
+			resp := serveQcow2(qcow2Handler(filepath.Join(GinkgoT().TempDir(), "missing.img"), compressed))
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusInternalServerError))
+		},
+			Entry("uncompressed", false),
+			Entry("compressed", true),
+		)
+
+		DescribeTable("should stream a qcow2 image of the volume", func(compressed bool) {
+			imagePath := filepath.Join(GinkgoT().TempDir(), "disk.img")
+			Expect(os.WriteFile(imagePath, []byte(strings.Repeat("disk data", 1024)), 0644)).To(Succeed())
+			resp := serveQcow2(qcow2Handler(imagePath, compressed))
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusOK))
+			Expect(resp.Header().Get("Content-Length")).To(Equal(strconv.Itoa(resp.Body.Len())))
+			Expect(resp.Body.Bytes()[:4]).To(Equal([]byte{'Q', 'F', 'I', 0xfb}))
+		},
+			Entry("uncompressed", false),
+			Entry("compressed", true),
+		)
+
+		DescribeTable("should not read the volume before the image is requested", func(compressed bool) {
+			imagePath := filepath.Join(GinkgoT().TempDir(), "disk.img")
+			handler := qcow2Handler(imagePath, compressed)
+			Expect(os.WriteFile(imagePath, []byte(strings.Repeat("disk data", 1024)), 0644)).To(Succeed())
+			Expect(serveQcow2(handler).Code).To(BeEquivalentTo(http.StatusOK))
+		},
+			Entry("uncompressed", false),
+			Entry("compressed", true),
+		)
+
+		It("should ask the client to retry while the image is being planned", func() {
+			pipePath := filepath.Join(GinkgoT().TempDir(), "disk.img")
+			Expect(syscall.Mkfifo(pipePath, 0644)).To(Succeed())
+			handler := qcow2Handler(pipePath, true)
+
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/volume/v1/disk.qcow2", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusAccepted))
+			Expect(resp.Header().Get("Retry-After")).To(Equal(retryAfterSeconds))
+
+			// Opening the pipe unblocks the planning, which fails as pipes are not seekable
+			pipe, err := os.OpenFile(pipePath, os.O_WRONLY, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipe.Close()).To(Succeed())
+			Expect(serveQcow2(handler).Code).To(BeEquivalentTo(http.StatusInternalServerError))
+		})
+	})
+
+	Context("backup handler", func() {
+		var backupDir string
+
+		BeforeEach(func() {
+			backupDir = GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(backupDir, "backup-disk.qcow2"), []byte("0123456789"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(backupDir, "notes.txt"), []byte("private"), 0644)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(backupDir, "sub"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(backupDir, "sub", "other.qcow2"), []byte("other"), 0644)).To(Succeed())
+		})
+
+		serveBackup := func(verb, uri, byteRange string) *httptest.ResponseRecorder {
+			req, err := http.NewRequest(verb, "https://test.blah.invalid"+uri, nil)
+			Expect(err).ToNot(HaveOccurred())
+			if byteRange != "" {
+				req.Header.Set("Range", byteRange)
+			}
+			resp := httptest.NewRecorder()
+			backupHandler("/volume/v1/backup/", backupDir).ServeHTTP(resp, req)
+			return resp
+		}
+
+		It("should serve a backup file", func() {
+			resp := serveBackup("GET", "/volume/v1/backup/backup-disk.qcow2", "")
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusOK))
+			Expect(resp.Header().Get("Content-Type")).To(Equal("application/octet-stream"))
+			Expect(resp.Body.String()).To(Equal("0123456789"))
+		})
+
+		It("should serve a range of a backup file", func() {
+			resp := serveBackup("GET", "/volume/v1/backup/backup-disk.qcow2", "bytes=4-")
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusPartialContent))
+			Expect(resp.Header().Get("Content-Range")).To(Equal("bytes 4-9/10"))
+			Expect(resp.Body.String()).To(Equal("456789"))
+		})
+
+		DescribeTable("should only serve qcow2 files in the root of the volume", func(uri string) {
+			resp := serveBackup("GET", uri, "")
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusNotFound))
+		},
+			Entry("directory", "/volume/v1/backup/"),
+			Entry("other file", "/volume/v1/backup/notes.txt"),
+			Entry("subdirectory", "/volume/v1/backup/sub/other.qcow2"),
+			Entry("missing file", "/volume/v1/backup/missing.qcow2"),
+		)
+
+		DescribeTable("should return error on non GET", func(verb string) {
+			resp := serveBackup(verb, "/volume/v1/backup/backup-disk.qcow2", "")
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusBadRequest))
+		},
+			Entry("POST", "POST"),
+			Entry("PUT", "PUT"),
+			Entry("PATCH", "PATCH"),
+			Entry("DELETE", "DELETE"),
+		)
+	})
+
+	Context("raw handler", func() {
+		It("should serve a range of the image", func() {
+			imagePath := filepath.Join(GinkgoT().TempDir(), "disk.img")
+			Expect(os.WriteFile(imagePath, []byte("0123456789"), 0644)).To(Succeed())
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/volume/v1/disk.img", nil)
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Range", "bytes=4-")
+			resp := httptest.NewRecorder()
+			fileHandler(imagePath).ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusPartialContent))
+			Expect(resp.Header().Get("Content-Range")).To(Equal("bytes 4-9/10"))
+			Expect(resp.Body.String()).To(Equal("456789"))
+		})
+	})
+
+	Context("checksum handler", func() {
+		DescribeTable("should return error on non GET", func(verb string) {
+			req, err := http.NewRequest(verb, "https://test.blah.invalid/volume/v1/disk.img.sha256", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			checksumHandler("/tmp/disk.img").ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusBadRequest))
+		},
+			Entry("POST", "POST"),
+			Entry("PUT", "PUT"),
+			Entry("PATCH", "PATCH"),
+			Entry("DELETE", "DELETE"),
+		)
+
+		serveChecksum := func(handler http.Handler) *httptest.ResponseRecorder {
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/volume/v1/disk.img.sha256", nil)
+			Expect(err).ToNot(HaveOccurred())
+			var resp *httptest.ResponseRecorder
+			Eventually(func() int {
+				resp = httptest.NewRecorder()
+				handler.ServeHTTP(resp, req)
+				return resp.Code
+			}).WithTimeout(5 * time.Second).WithPolling(10 * time.Millisecond).ShouldNot(Equal(http.StatusAccepted))
+			return resp
+		}
+
+		It("should return 500 if the image cannot be opened", func() {
+			resp := serveChecksum(checksumHandler(filepath.Join(GinkgoT().TempDir(), "missing.img")))
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusInternalServerError))
+		})
+
+		It("should not read the volume before the checksum is requested", func() {
+			imagePath := filepath.Join(GinkgoT().TempDir(), "disk.img")
+			handler := checksumHandler(imagePath)
+			Expect(os.WriteFile(imagePath, []byte("hello world\n"), 0644)).To(Succeed())
+			resp := serveChecksum(handler)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal("a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447  disk.img\n"))
+		})
+
+		It("should return the checksum of the image and only calculate it once", func() {
+			imagePath := filepath.Join(GinkgoT().TempDir(), "disk.img")
+			Expect(os.WriteFile(imagePath, []byte("hello world\n"), 0644)).To(Succeed())
+			handler := checksumHandler(imagePath)
+			const expected = "a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447  disk.img\n"
+
+			resp := serveChecksum(handler)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal(expected))
+
+			Expect(os.Remove(imagePath)).To(Succeed())
+			resp = serveChecksum(handler)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal(expected))
+		})
+
+		It("should ask the client to retry while the checksum is being calculated", func() {
+			pipePath := filepath.Join(GinkgoT().TempDir(), "disk.img")
+			Expect(syscall.Mkfifo(pipePath, 0644)).To(Succeed())
+			handler := checksumHandler(pipePath)
+
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/volume/v1/disk.img.sha256", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusAccepted))
+			Expect(resp.Header().Get("Retry-After")).To(Equal(retryAfterSeconds))
+
+			pipe, err := os.OpenFile(pipePath, os.O_WRONLY, 0)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = pipe.Write([]byte("hello world\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipe.Close()).To(Succeed())
+
+			resp = serveChecksum(handler)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal("a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447  disk.img\n"))
+		})
+	})
+
+	Context("Ova handler", func() {
+		var (
+			tempDir          string
+			orgGetExportName = getExportName
+			orgGetExpandedVM = getExpandedVM
+		)
+
+		newTestVm := func(claimNames ...string) *virtv1.VirtualMachine {
+			vm := &virtv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vm",
+					Namespace: testNamespace,
+				},
+				Spec: virtv1.VirtualMachineSpec{
+					Template: &virtv1.VirtualMachineInstanceTemplateSpec{
+						Spec: virtv1.VirtualMachineInstanceSpec{
+							Domain: virtv1.DomainSpec{
+								Resources: virtv1.ResourceRequirements{
+									Requests: v1.ResourceList{
+										v1.ResourceMemory: resource.MustParse("1Gi"),
+									},
+								},
+							},
+							Volumes: []virtv1.Volume{
+								{
+									Name: "cloudinit",
+									VolumeSource: virtv1.VolumeSource{
+										CloudInitNoCloud: &virtv1.CloudInitNoCloudSource{},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			for i, claimName := range claimNames {
+				vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, virtv1.Volume{
+					Name: fmt.Sprintf("disk%d", i),
+					VolumeSource: virtv1.VolumeSource{
+						PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{
+								ClaimName: claimName,
+							},
+						},
+					},
+				})
+			}
+			return vm
+		}
+
+		newVolume := func(pvcName string) VolumeInfo {
+			volumePath := filepath.Join(tempDir, pvcName)
+			Expect(os.Mkdir(volumePath, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(volumePath, "disk.img"), []byte("disk data"), 0644)).To(Succeed())
+			return VolumeInfo{Path: volumePath, RawURI: fmt.Sprintf("/volumes/%s/disk.img", pvcName)}
+		}
+
+		BeforeEach(func() {
+			tempDir = GinkgoT().TempDir()
+			getExportName = func() (string, error) {
+				return "test-export", nil
+			}
+		})
+
+		AfterEach(func() {
+			getExportName = orgGetExportName
+			getExpandedVM = orgGetExpandedVM
+		})
+
+		DescribeTable("should return error on non GET", func(verb string) {
+			req, err := http.NewRequest(verb, "https://test.blah.invalid/bundles/ova", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			ovaHandler([]VolumeInfo{}).ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusBadRequest))
+		},
+			Entry("POST", "POST"),
+			Entry("PUT", "PUT"),
+			Entry("PATCH", "PATCH"),
+			Entry("DELETE", "DELETE"),
+		)
+
+		It("should match the volumes of the VM, including restored snapshot volumes", func() {
+			volumes := []VolumeInfo{
+				newVolume("test-export-pvc1"),
+				newVolume("pvc0"),
+				{Path: filepath.Join(tempDir, "archive"), ArchiveURI: "/volumes/archive/disk.tar.gz"},
+			}
+			disks, err := getOvaDisks(newTestVm("pvc0", "pvc1"), volumes, "test-export")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(disks).To(HaveLen(2))
+			Expect(disks[0].Name).To(Equal("disk0"))
+			Expect(disks[0].Path).To(Equal(filepath.Join(tempDir, "pvc0", "disk.img")))
+			Expect(disks[1].Name).To(Equal("disk1"))
+			Expect(disks[1].Path).To(Equal(filepath.Join(tempDir, "test-export-pvc1", "disk.img")))
+		})
+
+		It("should return 500 if a volume of the VM is not exported as disk image", func() {
+			getExpandedVM = func() *virtv1.VirtualMachine {
+				return newTestVm("pvc0", "pvc1")
+			}
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/bundles/ova", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			ovaHandler([]VolumeInfo{newVolume("pvc0")}).ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusInternalServerError))
+		})
+
+		It("should return 500 if the VM definition is not available", func() {
+			getExpandedVM = func() *virtv1.VirtualMachine {
+				return nil
+			}
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/bundles/ova", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			ovaHandler([]VolumeInfo{}).ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusInternalServerError))
+		})
+
+		It("should stream an OVA of the VM", func() {
+			getExpandedVM = func() *virtv1.VirtualMachine {
+				return newTestVm("pvc0")
+			}
+			req, err := http.NewRequest("GET", "https://test.blah.invalid/bundles/ova", nil)
+			Expect(err).ToNot(HaveOccurred())
+			resp := httptest.NewRecorder()
+			ovaHandler([]VolumeInfo{newVolume("pvc0")}).ServeHTTP(resp, req)
+			Expect(resp.Code).To(BeEquivalentTo(http.StatusOK))
+			Expect(resp.Header().Get("Content-Disposition")).To(Equal(`attachment; filename="test-vm.ova"`))
+
+			tr := tar.NewReader(resp.Body)
+			names := []string{}
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).ToNot(HaveOccurred())
+				names = append(names, hdr.Name)
+			}
+			Expect(names).To(Equal([]string{"test-vm.ovf", "test-vm-disk0.vmdk"}))
+		})
+	})
 })
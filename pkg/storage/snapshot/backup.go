@@ -0,0 +1,505 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	watchutil "kubevirt.io/kubevirt/pkg/virt-controller/watch/util"
+)
+
+const (
+	vmBackupFinalizer = "snapshot.kubevirt.io/vmbackup-protection"
+
+	vmBackupVMIndex = "vm"
+
+	vmBackupStartedEvent   = "BackupStarted"
+	vmBackupSucceededEvent = "BackupSucceeded"
+	vmBackupFailedEvent    = "BackupFailed"
+)
+
+// VMBackupController is responsible for backing up the disks of running VMs
+type VMBackupController struct {
+	Client kubecli.KubevirtClient
+
+	VMBackupInformer cache.SharedIndexInformer
+	VMInformer       cache.SharedIndexInformer
+	VMIInformer      cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	vmBackupQueue workqueue.RateLimitingInterface
+}
+
+// Init initializes the backup controller
+func (ctrl *VMBackupController) Init() error {
+	ctrl.vmBackupQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-vmbackup")
+
+	_, err := ctrl.VMBackupInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMBackup,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMBackup(newObj) },
+			DeleteFunc: ctrl.handleVMBackup,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.VMIInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMI,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMI(newObj) },
+			DeleteFunc: ctrl.handleVMI,
+		},
+	)
+	return err
+}
+
+// Run the controller
+func (ctrl *VMBackupController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmBackupQueue.ShutDown()
+
+	log.Log.Info("Starting backup controller.")
+	defer log.Log.Info("Shutting down backup controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMBackupInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmBackupWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMBackupController) vmBackupWorker() {
+	for ctrl.processVMBackupWorkItem() {
+	}
+}
+
+func (ctrl *VMBackupController) processVMBackupWorkItem() bool {
+	return watchutil.ProcessWorkItem(ctrl.vmBackupQueue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmBackup worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMBackupInformer.GetStore().GetByKey(key)
+		if !exists || err != nil {
+			return 0, err
+		}
+
+		vmBackup, ok := storeObj.(*snapshotv1.VirtualMachineBackup)
+		if !ok {
+			return 0, fmt.Errorf(unexpectedResourceFmt, storeObj)
+		}
+
+		return 0, ctrl.updateVMBackup(vmBackup.DeepCopy())
+	})
+}
+
+func (ctrl *VMBackupController) handleVMBackup(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmBackup, ok := obj.(*snapshotv1.VirtualMachineBackup); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(vmBackup)
+		if err != nil {
+			log.Log.Errorf(failedKeyFromObjectFmt, err, vmBackup)
+			return
+		}
+		log.Log.V(3).Infof(enqueuedForSyncFmt, objName)
+		ctrl.vmBackupQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMBackupController) handleVMI(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmi, ok := obj.(*kubevirtv1.VirtualMachineInstance); ok {
+		keys, err := ctrl.VMBackupInformer.GetIndexer().IndexKeys(vmBackupVMIndex, cacheKeyFunc(vmi.Namespace, vmi.Name))
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+		for _, key := range keys {
+			log.Log.V(3).Infof(enqueuedForSyncFmt, key)
+			ctrl.vmBackupQueue.Add(key)
+		}
+	}
+}
+
+func vmBackupFinished(vmBackup *snapshotv1.VirtualMachineBackup) bool {
+	return vmBackup.Status != nil &&
+		(vmBackup.Status.Phase == snapshotv1.BackupSucceeded || vmBackup.Status.Phase == snapshotv1.BackupFailed)
+}
+
+func vmBackupInProgress(vmBackup *snapshotv1.VirtualMachineBackup) bool {
+	return vmBackup.Status != nil && vmBackup.Status.Phase == snapshotv1.BackupInProgress
+}
+
+func (ctrl *VMBackupController) updateVMBackup(vmBackup *snapshotv1.VirtualMachineBackup) error {
+	log.Log.V(3).Infof("Updating VirtualMachineBackup %s/%s", vmBackup.Namespace, vmBackup.Name)
+
+	vmi, err := ctrl.getVMI(vmBackup)
+	if err != nil {
+		return err
+	}
+
+	if vmBackup.DeletionTimestamp != nil || vmBackupFinished(vmBackup) {
+		if vmi != nil && hasBackupVolume(vmi, vmBackup) {
+			if err := ctrl.patchVMIBackupVolume(vmi, vmBackup.Spec.PVCName, nil); err != nil {
+				return err
+			}
+		}
+		return ctrl.removeFinalizer(vmBackup)
+	}
+
+	status := &snapshotv1.VirtualMachineBackupStatus{
+		Phase: snapshotv1.BackupPending,
+	}
+	if vmBackup.Status != nil {
+		status = vmBackup.Status.DeepCopy()
+	}
+
+	if vmBackupInProgress(vmBackup) {
+		ctrl.updateInProgressStatus(vmBackup, vmi, status)
+		return ctrl.updateStatus(vmBackup, status)
+	}
+
+	if err := ctrl.startBackup(vmBackup, vmi, status); err != nil {
+		return err
+	}
+
+	return ctrl.updateStatus(vmBackup, status)
+}
+
+// startBackup hotplugs the backup volume into the VMI once the source is running
+// and no other backup of the same VM is in progress
+func (ctrl *VMBackupController) startBackup(vmBackup *snapshotv1.VirtualMachineBackup, vmi *kubevirtv1.VirtualMachineInstance, status *snapshotv1.VirtualMachineBackupStatus) error {
+	if vmi == nil || !vmi.IsRunning() {
+		status.Conditions = updateCondition(status.Conditions, newProgressingCondition(corev1.ConditionFalse, "Source not running"), true)
+		status.Conditions = updateCondition(status.Conditions, newReadyCondition(corev1.ConditionFalse, "Source not running"), true)
+		return nil
+	}
+
+	backups, err := ctrl.getVMBackups(vmBackup)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range backups {
+		if b.Name != vmBackup.Name && vmBackupInProgress(b) {
+			status.Conditions = updateCondition(status.Conditions, newProgressingCondition(corev1.ConditionFalse, fmt.Sprintf("Waiting for backup %s to finish", b.Name)), true)
+			return nil
+		}
+	}
+
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == vmBackup.Spec.PVCName {
+			status.Phase = snapshotv1.BackupFailed
+			status.Error = &snapshotv1.Error{
+				Time:    currentTime(),
+				Message: pointerString(fmt.Sprintf("volume %s already exists in the VirtualMachineInstance", volume.Name)),
+			}
+			status.Conditions = updateCondition(status.Conditions, newProgressingCondition(corev1.ConditionFalse, "Operation failed"), true)
+			status.Conditions = updateCondition(status.Conditions, newReadyCondition(corev1.ConditionFalse, "Operation failed"), true)
+			ctrl.Recorder.Eventf(vmBackup, corev1.EventTypeWarning, vmBackupFailedEvent, *status.Error.Message)
+			return nil
+		}
+	}
+
+	status.Type = snapshotv1.FullBackup
+	status.BaseCheckpointName = nil
+	if vmBackup.Spec.Type != nil && *vmBackup.Spec.Type == snapshotv1.IncrementalBackup {
+		if base := latestSucceededBackup(backups); base != nil {
+			status.Type = snapshotv1.IncrementalBackup
+			status.BaseCheckpointName = pointerString(*base.Status.CheckpointName)
+		}
+	}
+
+	if err := ctrl.addFinalizer(vmBackup); err != nil {
+		return err
+	}
+
+	backupVolume := newBackupVolume(vmBackup, stringValue(status.BaseCheckpointName))
+	if err := ctrl.patchVMIBackupVolume(vmi, vmBackup.Spec.PVCName, backupVolume); err != nil {
+		return err
+	}
+
+	uid := vmi.UID
+	status.SourceUID = &uid
+	status.Phase = snapshotv1.BackupInProgress
+	status.CheckpointName = pointerString(vmBackup.Name)
+	status.StartTime = currentTime()
+	status.Conditions = updateCondition(status.Conditions, newProgressingCondition(corev1.ConditionTrue, "Backup in progress"), true)
+	status.Conditions = updateCondition(status.Conditions, newReadyCondition(corev1.ConditionFalse, "Not ready"), true)
+	ctrl.Recorder.Eventf(vmBackup, corev1.EventTypeNormal, vmBackupStartedEvent, "Started %s backup of VirtualMachine %s", status.Type, vmBackup.Spec.Source.Name)
+
+	return nil
+}
+
+// updateInProgressStatus tracks the progress of the backup reported in the volume status of the VMI
+func (ctrl *VMBackupController) updateInProgressStatus(vmBackup *snapshotv1.VirtualMachineBackup, vmi *kubevirtv1.VirtualMachineInstance, status *snapshotv1.VirtualMachineBackupStatus) {
+	if vmi == nil || (status.SourceUID != nil && vmi.UID != *status.SourceUID) {
+		ctrl.setFailed(vmBackup, status, "Source VirtualMachineInstance stopped during the backup")
+		return
+	}
+
+	volumeStatus := backupVolumeStatus(vmi, vmBackup)
+	if volumeStatus == nil {
+		return
+	}
+
+	switch volumeStatus.Phase {
+	case kubevirtv1.BackupVolumeCompleted:
+		status.Phase = snapshotv1.BackupSucceeded
+		status.CompletionTime = currentTime()
+		status.Disks = nil
+		for _, disk := range volumeStatus.BackupVolume.Disks {
+			status.Disks = append(status.Disks, snapshotv1.BackupDisk{
+				VolumeName:  disk.Name,
+				FileName:    disk.TargetFileName,
+				Incremental: disk.Incremental,
+			})
+		}
+		if volumeStatus.BackupVolume.IncrementalFrom == "" {
+			// the base checkpoint was gone, virt-launcher fell back to a full backup
+			status.Type = snapshotv1.FullBackup
+			status.BaseCheckpointName = nil
+		}
+		status.Conditions = updateCondition(status.Conditions, newProgressingCondition(corev1.ConditionFalse, "Operation complete"), true)
+		status.Conditions = updateCondition(status.Conditions, newReadyCondition(corev1.ConditionTrue, "Operation complete"), true)
+		ctrl.Recorder.Eventf(vmBackup, corev1.EventTypeNormal, vmBackupSucceededEvent, "Successfully completed %s backup of VirtualMachine %s", status.Type, vmBackup.Spec.Source.Name)
+	case kubevirtv1.BackupVolumeFailed:
+		ctrl.setFailed(vmBackup, status, volumeStatus.Message)
+	}
+}
+
+func (ctrl *VMBackupController) setFailed(vmBackup *snapshotv1.VirtualMachineBackup, status *snapshotv1.VirtualMachineBackupStatus, message string) {
+	status.Phase = snapshotv1.BackupFailed
+	status.CompletionTime = currentTime()
+	status.Error = &snapshotv1.Error{
+		Time:    currentTime(),
+		Message: pointerString(message),
+	}
+	status.Conditions = updateCondition(status.Conditions, newProgressingCondition(corev1.ConditionFalse, "Operation failed"), true)
+	status.Conditions = updateCondition(status.Conditions, newReadyCondition(corev1.ConditionFalse, "Operation failed"), true)
+	ctrl.Recorder.Eventf(vmBackup, corev1.EventTypeWarning, vmBackupFailedEvent, "Backup of VirtualMachine %s failed: %s", vmBackup.Spec.Source.Name, message)
+}
+
+func backupVolumeStatus(vmi *kubevirtv1.VirtualMachineInstance, vmBackup *snapshotv1.VirtualMachineBackup) *kubevirtv1.VolumeStatus {
+	for i, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.Name == vmBackup.Spec.PVCName &&
+			volumeStatus.BackupVolume != nil &&
+			volumeStatus.BackupVolume.BackupName == vmBackup.Name {
+			return &vmi.Status.VolumeStatus[i]
+		}
+	}
+	return nil
+}
+
+func hasBackupVolume(vmi *kubevirtv1.VirtualMachineInstance, vmBackup *snapshotv1.VirtualMachineBackup) bool {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == vmBackup.Spec.PVCName && volume.Backup != nil && volume.Backup.BackupName == vmBackup.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// latestSucceededBackup returns the latest succeeded backup which can serve as base for an incremental backup
+func latestSucceededBackup(backups []*snapshotv1.VirtualMachineBackup) *snapshotv1.VirtualMachineBackup {
+	var latest *snapshotv1.VirtualMachineBackup
+	for _, b := range backups {
+		if b.DeletionTimestamp != nil || b.Status == nil ||
+			b.Status.Phase != snapshotv1.BackupSucceeded ||
+			b.Status.CheckpointName == nil || b.Status.CompletionTime == nil {
+			continue
+		}
+		if latest == nil || latest.Status.CompletionTime.Before(b.Status.CompletionTime) {
+			latest = b
+		}
+	}
+	return latest
+}
+
+func newBackupVolume(vmBackup *snapshotv1.VirtualMachineBackup, incrementalFrom string) *kubevirtv1.Volume {
+	return &kubevirtv1.Volume{
+		Name: vmBackup.Spec.PVCName,
+		VolumeSource: kubevirtv1.VolumeSource{
+			Backup: &kubevirtv1.BackupVolumeSource{
+				PersistentVolumeClaimVolumeSource: kubevirtv1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: vmBackup.Spec.PVCName,
+					},
+					Hotpluggable: true,
+				},
+				BackupName:      vmBackup.Name,
+				IncrementalFrom: incrementalFrom,
+			},
+		},
+	}
+}
+
+// patchVMIBackupVolume replaces the volume with the given name in the VMI spec,
+// a nil backupVolume removes it
+func (ctrl *VMBackupController) patchVMIBackupVolume(vmi *kubevirtv1.VirtualMachineInstance, volumeName string, backupVolume *kubevirtv1.Volume) error {
+	patchVerb := "add"
+	if len(vmi.Spec.Volumes) > 0 {
+		patchVerb = "replace"
+	}
+
+	newVolumes := []kubevirtv1.Volume{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name != volumeName {
+			newVolumes = append(newVolumes, volume)
+		}
+	}
+	if backupVolume != nil {
+		newVolumes = append(newVolumes, *backupVolume)
+	}
+
+	oldJson, err := json.Marshal(vmi.Spec.Volumes)
+	if err != nil {
+		return err
+	}
+
+	newJson, err := json.Marshal(newVolumes)
+	if err != nil {
+		return err
+	}
+
+	test := fmt.Sprintf(`{ "op": "test", "path": "/spec/volumes", "value": %s}`, string(oldJson))
+	update := fmt.Sprintf(`{ "op": "%s", "path": "/spec/volumes", "value": %s}`, patchVerb, string(newJson))
+	patch := fmt.Sprintf("[%s, %s]", test, update)
+
+	_, err = ctrl.Client.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, []byte(patch), &metav1.PatchOptions{})
+	return err
+}
+
+func (ctrl *VMBackupController) addFinalizer(vmBackup *snapshotv1.VirtualMachineBackup) error {
+	if controller.HasFinalizer(vmBackup, vmBackupFinalizer) {
+		return nil
+	}
+
+	vmBackupCopy := vmBackup.DeepCopy()
+	controller.AddFinalizer(vmBackupCopy, vmBackupFinalizer)
+	_, err := ctrl.Client.VirtualMachineBackup(vmBackupCopy.Namespace).Update(context.Background(), vmBackupCopy, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	vmBackup.ObjectMeta = vmBackupCopy.ObjectMeta
+	return nil
+}
+
+func (ctrl *VMBackupController) removeFinalizer(vmBackup *snapshotv1.VirtualMachineBackup) error {
+	if !controller.HasFinalizer(vmBackup, vmBackupFinalizer) {
+		return nil
+	}
+
+	vmBackupCopy := vmBackup.DeepCopy()
+	controller.RemoveFinalizer(vmBackupCopy, vmBackupFinalizer)
+	_, err := ctrl.Client.VirtualMachineBackup(vmBackupCopy.Namespace).Update(context.Background(), vmBackupCopy, metav1.UpdateOptions{})
+	return err
+}
+
+func (ctrl *VMBackupController) updateStatus(vmBackup *snapshotv1.VirtualMachineBackup, status *snapshotv1.VirtualMachineBackupStatus) error {
+	if equality.Semantic.DeepEqual(vmBackup.Status, status) {
+		return nil
+	}
+
+	vmBackupCopy := vmBackup.DeepCopy()
+	vmBackupCopy.Status = status
+	_, err := ctrl.Client.VirtualMachineBackup(vmBackupCopy.Namespace).UpdateStatus(context.Background(), vmBackupCopy, metav1.UpdateOptions{})
+	return err
+}
+
+func (ctrl *VMBackupController) getVMI(vmBackup *snapshotv1.VirtualMachineBackup) (*kubevirtv1.VirtualMachineInstance, error) {
+	obj, exists, err := ctrl.VMIInformer.GetStore().GetByKey(cacheKeyFunc(vmBackup.Namespace, vmBackup.Spec.Source.Name))
+	if !exists || err != nil {
+		return nil, err
+	}
+
+	vmi, ok := obj.(*kubevirtv1.VirtualMachineInstance)
+	if !ok {
+		return nil, fmt.Errorf(unexpectedResourceFmt, obj)
+	}
+
+	return vmi, nil
+}
+
+func (ctrl *VMBackupController) getVMBackups(vmBackup *snapshotv1.VirtualMachineBackup) ([]*snapshotv1.VirtualMachineBackup, error) {
+	objs, err := ctrl.VMBackupInformer.GetIndexer().ByIndex(vmBackupVMIndex, cacheKeyFunc(vmBackup.Namespace, vmBackup.Spec.Source.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []*snapshotv1.VirtualMachineBackup
+	for _, obj := range objs {
+		b, ok := obj.(*snapshotv1.VirtualMachineBackup)
+		if !ok {
+			return nil, fmt.Errorf(unexpectedResourceFmt, obj)
+		}
+		backups = append(backups, b)
+	}
+
+	return backups, nil
+}
+
+func pointerString(s string) *string {
+	return &s
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
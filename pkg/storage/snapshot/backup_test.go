@@ -0,0 +1,318 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+
+	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Backup controller", func() {
+	const (
+		vmName     = "testvm"
+		backupName = "backup"
+		pvcName    = "backup-pvc"
+	)
+
+	var (
+		controller       *VMBackupController
+		vmBackupInformer cache.SharedIndexInformer
+		vmInformer       cache.SharedIndexInformer
+		vmiInformer      cache.SharedIndexInformer
+		vmiInterface     *kubecli.MockVirtualMachineInstanceInterface
+		recorder         *record.FakeRecorder
+		client           *kubevirtfake.Clientset
+		now              time.Time
+	)
+
+	newBackup := func(name string, backupType snapshotv1.BackupType) *snapshotv1.VirtualMachineBackup {
+		return &snapshotv1.VirtualMachineBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+			},
+			Spec: snapshotv1.VirtualMachineBackupSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: pointer.String("kubevirt.io"),
+					Kind:     "VirtualMachine",
+					Name:     vmName,
+				},
+				PVCName: pvcName,
+				Type:    &backupType,
+			},
+		}
+	}
+
+	newRunningVMI := func() *v1.VirtualMachineInstance {
+		return &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: testNamespace,
+				UID:       "vmi-uid",
+			},
+			Spec: v1.VirtualMachineInstanceSpec{
+				Volumes: []v1.Volume{
+					{
+						Name: "disk0",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: "disk0-pvc",
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase: v1.Running,
+			},
+		}
+	}
+
+	addBackup := func(vmBackup *snapshotv1.VirtualMachineBackup) {
+		Expect(vmBackupInformer.GetStore().Add(vmBackup)).To(Succeed())
+		_, err := client.SnapshotV1alpha1().VirtualMachineBackups(vmBackup.Namespace).Create(context.Background(), vmBackup, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getBackup := func(name string) *snapshotv1.VirtualMachineBackup {
+		vmBackup, err := client.SnapshotV1alpha1().VirtualMachineBackups(testNamespace).Get(context.Background(), name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return vmBackup
+	}
+
+	expectVolumePatch := func(contains string) {
+		vmiInterface.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, gomock.Any(), &metav1.PatchOptions{}).
+			DoAndReturn(func(ctx context.Context, name string, patchType types.PatchType, body []byte, opts *metav1.PatchOptions, _ ...string) (*v1.VirtualMachineInstance, error) {
+				Expect(string(body)).To(ContainSubstring(contains))
+				return nil, nil
+			})
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC)
+		currentTime = func() *metav1.Time {
+			return &metav1.Time{Time: now}
+		}
+
+		vmBackupInformer, _ = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineBackup{}, virtcontroller.GetVirtualMachineBackupInformerIndexers())
+		vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		recorder = record.NewFakeRecorder(100)
+
+		ctrl := gomock.NewController(GinkgoT())
+		client = kubevirtfake.NewSimpleClientset()
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		virtClient.EXPECT().VirtualMachineBackup(testNamespace).
+			Return(client.SnapshotV1alpha1().VirtualMachineBackups(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstance(testNamespace).Return(vmiInterface).AnyTimes()
+
+		controller = &VMBackupController{
+			Client:           virtClient,
+			VMBackupInformer: vmBackupInformer,
+			VMInformer:       vmInformer,
+			VMIInformer:      vmiInformer,
+			Recorder:         recorder,
+		}
+		Expect(controller.Init()).To(Succeed())
+	})
+
+	It("should stay pending while the source is not running", func() {
+		vmBackup := newBackup(backupName, snapshotv1.FullBackup)
+		addBackup(vmBackup)
+
+		Expect(controller.updateVMBackup(vmBackup)).To(Succeed())
+
+		status := getBackup(backupName).Status
+		Expect(status.Phase).To(Equal(snapshotv1.BackupPending))
+		Expect(status.Conditions).To(ContainElement(HaveField("Reason", "Source not running")))
+	})
+
+	It("should hotplug the backup volume and start a full backup", func() {
+		Expect(vmiInformer.GetStore().Add(newRunningVMI())).To(Succeed())
+		vmBackup := newBackup(backupName, snapshotv1.FullBackup)
+		addBackup(vmBackup)
+		expectVolumePatch(`"backup":{"claimName":"backup-pvc","hotpluggable":true,"backupName":"backup"}`)
+
+		Expect(controller.updateVMBackup(vmBackup)).To(Succeed())
+
+		updated := getBackup(backupName)
+		Expect(updated.Finalizers).To(ContainElement(vmBackupFinalizer))
+		Expect(updated.Status.Phase).To(Equal(snapshotv1.BackupInProgress))
+		Expect(updated.Status.Type).To(Equal(snapshotv1.FullBackup))
+		Expect(*updated.Status.CheckpointName).To(Equal(backupName))
+		Expect(updated.Status.BaseCheckpointName).To(BeNil())
+		Expect(updated.Status.StartTime.Time).To(Equal(now))
+		Expect(recorder.Events).To(Receive(ContainSubstring(vmBackupStartedEvent)))
+	})
+
+	It("should base an incremental backup on the latest succeeded backup", func() {
+		Expect(vmiInformer.GetStore().Add(newRunningVMI())).To(Succeed())
+		for i, name := range []string{"older", "latest"} {
+			b := newBackup(name, snapshotv1.FullBackup)
+			b.Status = &snapshotv1.VirtualMachineBackupStatus{
+				Phase:          snapshotv1.BackupSucceeded,
+				CheckpointName: pointer.String(name),
+				CompletionTime: &metav1.Time{Time: now.Add(time.Duration(i-2) * time.Hour)},
+			}
+			Expect(vmBackupInformer.GetStore().Add(b)).To(Succeed())
+		}
+		vmBackup := newBackup(backupName, snapshotv1.IncrementalBackup)
+		addBackup(vmBackup)
+		expectVolumePatch(`"incrementalFrom":"latest"`)
+
+		Expect(controller.updateVMBackup(vmBackup)).To(Succeed())
+
+		status := getBackup(backupName).Status
+		Expect(status.Phase).To(Equal(snapshotv1.BackupInProgress))
+		Expect(status.Type).To(Equal(snapshotv1.IncrementalBackup))
+		Expect(*status.BaseCheckpointName).To(Equal("latest"))
+	})
+
+	It("should wait for another backup of the same VM to finish", func() {
+		Expect(vmiInformer.GetStore().Add(newRunningVMI())).To(Succeed())
+		running := newBackup("running", snapshotv1.FullBackup)
+		running.Status = &snapshotv1.VirtualMachineBackupStatus{Phase: snapshotv1.BackupInProgress}
+		Expect(vmBackupInformer.GetStore().Add(running)).To(Succeed())
+		vmBackup := newBackup(backupName, snapshotv1.FullBackup)
+		addBackup(vmBackup)
+
+		Expect(controller.updateVMBackup(vmBackup)).To(Succeed())
+
+		Expect(getBackup(backupName).Status.Phase).To(Equal(snapshotv1.BackupPending))
+	})
+
+	Context("in progress", func() {
+		var vmBackup *snapshotv1.VirtualMachineBackup
+
+		BeforeEach(func() {
+			vmBackup = newBackup(backupName, snapshotv1.IncrementalBackup)
+			vmBackup.Finalizers = []string{vmBackupFinalizer}
+			uid := types.UID("vmi-uid")
+			vmBackup.Status = &snapshotv1.VirtualMachineBackupStatus{
+				Phase:              snapshotv1.BackupInProgress,
+				SourceUID:          &uid,
+				Type:               snapshotv1.IncrementalBackup,
+				CheckpointName:     pointer.String(backupName),
+				BaseCheckpointName: pointer.String("base"),
+			}
+			addBackup(vmBackup)
+		})
+
+		addVMIWithBackupStatus := func(phase v1.VolumePhase, incrementalFrom string) {
+			vmi := newRunningVMI()
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, *newBackupVolume(vmBackup, "base"))
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name:    pvcName,
+					Phase:   phase,
+					Message: "backup job failed",
+					BackupVolume: &v1.DomainBackupInfo{
+						BackupName:      backupName,
+						IncrementalFrom: incrementalFrom,
+						Disks: []v1.BackupDiskInfo{
+							{Name: "disk0", TargetFileName: "backup-disk0.qcow2", Incremental: incrementalFrom != ""},
+						},
+					},
+				},
+			}
+			Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+		}
+
+		It("should succeed once the backup volume completed", func() {
+			addVMIWithBackupStatus(v1.BackupVolumeCompleted, "base")
+
+			Expect(controller.updateVMBackup(vmBackup)).To(Succeed())
+
+			status := getBackup(backupName).Status
+			Expect(status.Phase).To(Equal(snapshotv1.BackupSucceeded))
+			Expect(status.CompletionTime.Time).To(Equal(now))
+			Expect(status.Type).To(Equal(snapshotv1.IncrementalBackup))
+			Expect(status.Disks).To(Equal([]snapshotv1.BackupDisk{
+				{VolumeName: "disk0", FileName: "backup-disk0.qcow2", Incremental: true},
+			}))
+			Expect(recorder.Events).To(Receive(ContainSubstring(vmBackupSucceededEvent)))
+		})
+
+		It("should report a full backup when the base checkpoint was gone", func() {
+			addVMIWithBackupStatus(v1.BackupVolumeCompleted, "")
+
+			Expect(controller.updateVMBackup(vmBackup)).To(Succeed())
+
+			status := getBackup(backupName).Status
+			Expect(status.Phase).To(Equal(snapshotv1.BackupSucceeded))
+			Expect(status.Type).To(Equal(snapshotv1.FullBackup))
+			Expect(status.BaseCheckpointName).To(BeNil())
+		})
+
+		It("should fail when the backup volume failed", func() {
+			addVMIWithBackupStatus(v1.BackupVolumeFailed, "base")
+
+			Expect(controller.updateVMBackup(vmBackup)).To(Succeed())
+
+			status := getBackup(backupName).Status
+			Expect(status.Phase).To(Equal(snapshotv1.BackupFailed))
+			Expect(*status.Error.Message).To(Equal("backup job failed"))
+			Expect(recorder.Events).To(Receive(ContainSubstring(vmBackupFailedEvent)))
+		})
+
+		It("should fail when the source stopped", func() {
+			Expect(controller.updateVMBackup(vmBackup)).To(Succeed())
+
+			Expect(getBackup(backupName).Status.Phase).To(Equal(snapshotv1.BackupFailed))
+		})
+	})
+
+	It("should unplug the backup volume and remove the finalizer once finished", func() {
+		vmBackup := newBackup(backupName, snapshotv1.FullBackup)
+		vmBackup.Finalizers = []string{vmBackupFinalizer}
+		vmBackup.Status = &snapshotv1.VirtualMachineBackupStatus{Phase: snapshotv1.BackupSucceeded}
+		addBackup(vmBackup)
+		vmi := newRunningVMI()
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, *newBackupVolume(vmBackup, ""))
+		Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+		expectVolumePatch(`{ "op": "replace", "path": "/spec/volumes", "value": [{"name":"disk0","persistentVolumeClaim":{"claimName":"disk0-pvc"}}]}`)
+
+		Expect(controller.updateVMBackup(vmBackup)).To(Succeed())
+
+		Expect(getBackup(backupName).Finalizers).To(BeEmpty())
+	})
+})
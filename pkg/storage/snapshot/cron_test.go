@@ -0,0 +1,75 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package snapshot
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron schedule", func() {
+	// a Wednesday
+	start := time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC)
+
+	DescribeTable("should calculate the next run", func(spec string, expected time.Time) {
+		schedule, err := ParseCronSchedule(spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(start)).To(Equal(expected))
+	},
+		Entry("every minute", "* * * * *", time.Date(2023, time.March, 15, 10, 31, 0, 0, time.UTC)),
+		Entry("every 15 minutes", "*/15 * * * *", time.Date(2023, time.March, 15, 10, 45, 0, 0, time.UTC)),
+		Entry("a list of hours", "0 3,12 * * *", time.Date(2023, time.March, 15, 12, 0, 0, 0, time.UTC)),
+		Entry("a range with a step", "0 1-9/4 * * *", time.Date(2023, time.March, 16, 1, 0, 0, 0, time.UTC)),
+		Entry("a start with a step", "20/30 * * * *", time.Date(2023, time.March, 15, 10, 50, 0, 0, time.UTC)),
+		Entry("a day of week name", "0 0 * * sun", time.Date(2023, time.March, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("sunday as 7", "0 0 * * 7", time.Date(2023, time.March, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("a month name", "0 0 1 jun *", time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month or day of week", "0 0 1 * mon", time.Date(2023, time.March, 20, 0, 0, 0, 0, time.UTC)),
+		Entry("a stepped day of month with a day of week", "0 0 */2 * mon", time.Date(2023, time.March, 27, 0, 0, 0, 0, time.UTC)),
+		Entry("the 29th of february", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("@hourly", "@hourly", time.Date(2023, time.March, 15, 11, 0, 0, 0, time.UTC)),
+		Entry("@daily", "@daily", time.Date(2023, time.March, 16, 0, 0, 0, 0, time.UTC)),
+		Entry("@weekly", "@weekly", time.Date(2023, time.March, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("@monthly", "@monthly", time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("@yearly", "@yearly", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	It("should return the zero time if the schedule never matches", func() {
+		schedule, err := ParseCronSchedule("0 0 30 2 *")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(start).IsZero()).To(BeTrue())
+	})
+
+	DescribeTable("should reject", func(spec string) {
+		_, err := ParseCronSchedule(spec)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("too few fields", "* * * *"),
+		Entry("too many fields", "* * * * * *"),
+		Entry("an out of range minute", "60 * * * *"),
+		Entry("an out of range day of month", "0 0 0 * *"),
+		Entry("an inverted range", "0 10-5 * * *"),
+		Entry("a zero step", "*/0 * * * *"),
+		Entry("an unknown name", "0 0 * foo *"),
+		Entry("an unknown macro", "@often"),
+	)
+})
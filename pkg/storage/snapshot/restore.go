@@ -44,6 +44,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/instancetype"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	watchutil "kubevirt.io/kubevirt/pkg/virt-controller/watch/util"
 )
 
@@ -98,6 +99,15 @@ func restoreDVName(vmRestore *snapshotv1.VirtualMachineRestore, name string) str
 	return restorePVCName(vmRestore, name)
 }
 
+// restoreVolumePVCName returns the name of the PVC a volume backup is restored to.
+// The backend storage PVC is looked up by the name of the VM, so it has to be restored under that name.
+func restoreVolumePVCName(vmRestore *snapshotv1.VirtualMachineRestore, volumeName string) string {
+	if volumeName == backendstorage.VolumeName {
+		return backendstorage.PVCForVMName(vmRestore.Spec.Target.Name)
+	}
+	return restorePVCName(vmRestore, volumeName)
+}
+
 func VmRestoreProgressing(vmRestore *snapshotv1.VirtualMachineRestore) bool {
 	return vmRestore.Status == nil || vmRestore.Status.Complete == nil || !*vmRestore.Status.Complete
 }
@@ -268,7 +278,7 @@ func (ctrl *VMRestoreController) reconcileVolumeRestores(vmRestore *snapshotv1.V
 
 			vr := snapshotv1.VolumeRestore{
 				VolumeName:                vb.VolumeName,
-				PersistentVolumeClaimName: restorePVCName(vmRestore, vb.VolumeName),
+				PersistentVolumeClaimName: restoreVolumePVCName(vmRestore, vb.VolumeName),
 				VolumeSnapshotName:        *vb.VolumeSnapshotName,
 			}
 			restores = append(restores, vr)
@@ -301,6 +311,9 @@ func (ctrl *VMRestoreController) reconcileVolumeRestores(vmRestore *snapshotv1.V
 				return false, err
 			}
 			createdPVC = true
+		} else if restore.VolumeName == backendstorage.VolumeName && pvc.Annotations[restoreNameAnnotation] != vmRestore.Name {
+			// backend storage PVC of the target VM, it is replaced once the target is ready
+			continue
 		} else if pvc.Status.Phase == corev1.ClaimPending {
 			bindingMode, err := ctrl.getBindingMode(pvc)
 			if err != nil {
@@ -373,7 +386,7 @@ func (t *vmRestoreTarget) UpdateRestoreInProgress() error {
 
 func (t *vmRestoreTarget) Ready() (bool, error) {
 	if !t.doesTargetVMExist() {
-		return true, nil
+		return t.removeStaleBackendStoragePVC()
 	}
 
 	log.Log.Object(t.vmRestore).V(3).Info("Checking VM ready")
@@ -393,11 +406,43 @@ func (t *vmRestoreTarget) Ready() (bool, error) {
 	}
 
 	_, exists, err := t.controller.VMIInformer.GetStore().GetByKey(vmiKey)
-	if err != nil {
+	if err != nil || exists {
 		return false, err
 	}
 
-	return !exists, nil
+	return t.removeStaleBackendStoragePVC()
+}
+
+// removeStaleBackendStoragePVC deletes the backend storage PVC of the stopped target VM, so that the one
+// from the snapshot can be restored in its place. It returns true once no stale PVC is left.
+func (t *vmRestoreTarget) removeStaleBackendStoragePVC() (bool, error) {
+	for _, vr := range t.vmRestore.Status.Restores {
+		if vr.VolumeName != backendstorage.VolumeName {
+			continue
+		}
+
+		pvc, err := t.controller.getPVC(t.vmRestore.Namespace, vr.PersistentVolumeClaimName)
+		if err != nil {
+			return false, err
+		}
+		if pvc == nil || pvc.Annotations[restoreNameAnnotation] == t.vmRestore.Name {
+			return true, nil
+		}
+
+		if !t.doesTargetVMExist() {
+			return false, fmt.Errorf("backend storage PVC %s/%s already exists", pvc.Namespace, pvc.Name)
+		}
+		if pvc.DeletionTimestamp == nil {
+			log.Log.Object(t.vmRestore).Infof("Deleting backend storage PVC %s/%s", pvc.Namespace, pvc.Name)
+			err = t.controller.Client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(context.Background(), pvc.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	return true, nil
 }
 
 func (t *vmRestoreTarget) Reconcile() (bool, error) {
@@ -974,6 +1019,11 @@ func (ctrl *VMRestoreController) createRestorePVC(
 		return fmt.Errorf("missing volumeRestore")
 	}
 	pvc := CreateRestorePVCDefFromVMRestore(vmRestore.Name, volumeRestore.PersistentVolumeClaimName, volumeSnapshot, volumeBackup, sourceVmName, sourceVmNamespace)
+	if volumeRestore.VolumeName == backendstorage.VolumeName {
+		pvc.Labels[backendstorage.PVCLabel] = vmRestore.Spec.Target.Name
+		// the state is handed to the target VM, which might not exist yet, it records its UID once it starts
+		delete(pvc.Annotations, backendstorage.PVCOwnerUIDAnnotation)
+	}
 	target.Own(pvc)
 
 	_, err = ctrl.Client.CoreV1().PersistentVolumeClaims(vmRestore.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
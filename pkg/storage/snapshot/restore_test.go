@@ -35,6 +35,7 @@ import (
 
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/instancetype"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/util/status"
 )
@@ -531,6 +532,47 @@ var _ = Describe("Restore controller", func() {
 				controller.processVMRestoreWorkItem()
 			})
 
+			It("should hand the restored backend storage PVC to the target VM", func() {
+				r := createRestoreWithOwner()
+				vm := createModifiedVM()
+				vmSource.Add(vm)
+				vs := createVolumeSnapshot("vmsnapshot-snapshot-uid-volume-"+backendstorage.VolumeName, resource.MustParse("10Mi"))
+				fakeVolumeSnapshotProvider.Add(vs)
+				backup := &snapshotv1.VolumeBackup{
+					VolumeName: backendstorage.VolumeName,
+					PersistentVolumeClaim: snapshotv1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        backendstorage.PVCForVMName("source-vm"),
+							Labels:      map[string]string{backendstorage.PVCLabel: "source-vm"},
+							Annotations: map[string]string{backendstorage.PVCOwnerUIDAnnotation: "source-vm-uid"},
+						},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Mi")},
+							},
+						},
+					},
+					VolumeSnapshotName: &vs.Name,
+				}
+				volumeRestore := &snapshotv1.VolumeRestore{
+					VolumeName:                backendstorage.VolumeName,
+					PersistentVolumeClaimName: backendstorage.PVCForVMName(r.Spec.Target.Name),
+				}
+
+				var createdPVC *corev1.PersistentVolumeClaim
+				k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					createdPVC = action.(testing.CreateAction).GetObject().(*corev1.PersistentVolumeClaim)
+					return true, createdPVC, nil
+				})
+
+				target, err := controller.getTarget(r)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(controller.createRestorePVC(r, target, backup, volumeRestore, "source-vm", testNamespace)).To(Succeed())
+				Expect(createdPVC).ToNot(BeNil())
+				Expect(createdPVC.Labels).To(HaveKeyWithValue(backendstorage.PVCLabel, r.Spec.Target.Name))
+				Expect(createdPVC.Annotations).ToNot(HaveKey(backendstorage.PVCOwnerUIDAnnotation))
+			})
+
 			It("should wait for bound", func() {
 				r := createRestoreWithOwner()
 				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
@@ -812,6 +854,51 @@ var _ = Describe("Restore controller", func() {
 				Entry("return true when dv doesnt exists", false, cdiv1.PhaseUnset, true),
 			)
 
+			DescribeTable("Ready should replace the backend storage PVC of the target", func(restored bool, expectedReady bool) {
+				r := createRestoreWithOwner()
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Restores: []snapshotv1.VolumeRestore{
+						{
+							VolumeName:                backendstorage.VolumeName,
+							PersistentVolumeClaimName: backendstorage.PVCForVMName(r.Spec.Target.Name),
+							VolumeSnapshotName:        "vmsnapshot-snapshot-uid-volume-" + backendstorage.VolumeName,
+						},
+					},
+				}
+				vm := createModifiedVM()
+				pvc := &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      backendstorage.PVCForVM(vm),
+						Labels:    map[string]string{backendstorage.PVCLabel: vm.Name},
+					},
+				}
+				if restored {
+					pvc.Annotations = map[string]string{restoreNameAnnotation: r.Name}
+				}
+				pvcSource.Add(pvc)
+
+				deleted := false
+				k8sClient.Fake.PrependReactor("delete", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					Expect(action.(testing.DeleteAction).GetName()).To(Equal(pvc.Name))
+					deleted = true
+					return true, nil, nil
+				})
+
+				vmRestoreSource.Add(r)
+				addVM(vm)
+				target, err := controller.getTarget(r)
+				Expect(err).ShouldNot(HaveOccurred())
+				target.UpdateTarget(vm)
+				ready, err := target.Ready()
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(ready).To(Equal(expectedReady))
+				Expect(deleted).To(Equal(!restored))
+			},
+				Entry("deleting a stale PVC", false, false),
+				Entry("keeping the restored PVC", true, true),
+			)
+
 			Context("target VM is different than source VM", func() {
 
 				It("should be able to restore to a new VM", func() {
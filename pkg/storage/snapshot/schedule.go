@@ -0,0 +1,491 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/library-go/pkg/build/naming"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	"kubevirt.io/api/core"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/monitoring/virt-controller/metrics"
+	watchutil "kubevirt.io/kubevirt/pkg/virt-controller/watch/util"
+)
+
+const (
+	// the time of the run a scheduled snapshot was created for
+	scheduleTimeAnnotation = "snapshot.kubevirt.io/schedule-time"
+
+	scheduleIndex = "schedule"
+
+	snapshotScheduledEvent         = "SnapshotScheduled"
+	snapshotScheduleFailedEvent    = "SnapshotScheduleFailed"
+	snapshotScheduleSucceededEvent = "SnapshotScheduleSucceeded"
+	snapshotRetentionDeletedEvent  = "SnapshotRetentionDeleted"
+)
+
+// VMSnapshotScheduleController is responsible for periodically snapshotting VMs
+type VMSnapshotScheduleController struct {
+	Client kubecli.KubevirtClient
+
+	VMSnapshotScheduleInformer cache.SharedIndexInformer
+	VMSnapshotInformer         cache.SharedIndexInformer
+	VMInformer                 cache.SharedIndexInformer
+
+	Recorder record.EventRecorder
+
+	vmSnapshotScheduleQueue workqueue.RateLimitingInterface
+}
+
+// Init initializes the snapshot schedule controller
+func (ctrl *VMSnapshotScheduleController) Init() error {
+	ctrl.vmSnapshotScheduleQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-snapshot-vmsnapshotschedule")
+
+	_, err := ctrl.VMSnapshotScheduleInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMSnapshotSchedule,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleVMSnapshotSchedule(newObj) },
+			DeleteFunc: ctrl.handleVMSnapshotSchedule,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.VMSnapshotInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleScheduledVMSnapshot,
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.handleScheduledVMSnapshot(newObj) },
+			DeleteFunc: ctrl.handleScheduledVMSnapshot,
+		},
+	)
+	return err
+}
+
+// Run the controller
+func (ctrl *VMSnapshotScheduleController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.vmSnapshotScheduleQueue.ShutDown()
+
+	log.Log.Info("Starting snapshot schedule controller.")
+	defer log.Log.Info("Shutting down snapshot schedule controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMSnapshotScheduleInformer.HasSynced,
+		ctrl.VMSnapshotInformer.HasSynced,
+		ctrl.VMInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.vmSnapshotScheduleWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMSnapshotScheduleController) vmSnapshotScheduleWorker() {
+	for ctrl.processVMSnapshotScheduleWorkItem() {
+	}
+}
+
+func (ctrl *VMSnapshotScheduleController) processVMSnapshotScheduleWorkItem() bool {
+	return watchutil.ProcessWorkItem(ctrl.vmSnapshotScheduleQueue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmSnapshotSchedule worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMSnapshotScheduleInformer.GetStore().GetByKey(key)
+		if err != nil {
+			return 0, err
+		}
+
+		if !exists {
+			namespace, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				return 0, err
+			}
+			metrics.DeleteSnapshotScheduleMetrics(namespace, name)
+			return 0, nil
+		}
+
+		schedule, ok := storeObj.(*snapshotv1.VirtualMachineSnapshotSchedule)
+		if !ok {
+			return 0, fmt.Errorf(unexpectedResourceFmt, storeObj)
+		}
+
+		return ctrl.updateVMSnapshotSchedule(schedule.DeepCopy())
+	})
+}
+
+func (ctrl *VMSnapshotScheduleController) handleVMSnapshotSchedule(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if schedule, ok := obj.(*snapshotv1.VirtualMachineSnapshotSchedule); ok {
+		objName, err := cache.DeletionHandlingMetaNamespaceKeyFunc(schedule)
+		if err != nil {
+			log.Log.Errorf(failedKeyFromObjectFmt, err, schedule)
+			return
+		}
+		log.Log.V(3).Infof(enqueuedForSyncFmt, objName)
+		ctrl.vmSnapshotScheduleQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMSnapshotScheduleController) handleScheduledVMSnapshot(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmSnapshot, ok := obj.(*snapshotv1.VirtualMachineSnapshot); ok {
+		scheduleName, ok := vmSnapshot.Labels[snapshotv1.VirtualMachineSnapshotScheduleLabel]
+		if !ok {
+			return
+		}
+		objName := cacheKeyFunc(vmSnapshot.Namespace, scheduleName)
+		log.Log.V(3).Infof(enqueuedForSyncFmt, objName)
+		ctrl.vmSnapshotScheduleQueue.Add(objName)
+	}
+}
+
+func (ctrl *VMSnapshotScheduleController) updateVMSnapshotSchedule(schedule *snapshotv1.VirtualMachineSnapshotSchedule) (time.Duration, error) {
+	log.Log.V(3).Infof("Updating VirtualMachineSnapshotSchedule %s/%s", schedule.Namespace, schedule.Name)
+
+	if schedule.DeletionTimestamp != nil {
+		return 0, nil
+	}
+
+	status := &snapshotv1.VirtualMachineSnapshotScheduleStatus{}
+	if schedule.Status != nil {
+		status = schedule.Status.DeepCopy()
+	}
+
+	cron, err := ParseCronSchedule(schedule.Spec.Schedule)
+	if err != nil {
+		condition := newReadyCondition(corev1.ConditionFalse, "Invalid schedule")
+		condition.Message = err.Error()
+		status.Conditions = updateCondition(status.Conditions, condition, true)
+		status.NextScheduleTime = nil
+		return 0, ctrl.updateStatus(schedule, status)
+	}
+
+	snapshots, err := ctrl.getScheduledVMSnapshots(schedule)
+	if err != nil {
+		return 0, err
+	}
+
+	ctrl.evaluateLastRun(schedule, status, snapshots)
+
+	now := currentTime().Time
+	suspended := schedule.Spec.Suspend != nil && *schedule.Spec.Suspend
+	next := cron.Next(lastScheduleTime(schedule, status))
+	if !suspended && !next.IsZero() && !next.After(now) {
+		// only the latest missed run is executed
+		scheduled := next
+		for n := cron.Next(scheduled); !n.IsZero() && !n.After(now); n = cron.Next(n) {
+			scheduled = n
+		}
+
+		created, err := ctrl.runSchedule(schedule, scheduled)
+		if err != nil {
+			return 0, err
+		}
+
+		status.LastScheduleTime = &metav1.Time{Time: scheduled}
+		if created == 0 {
+			// nothing matched the selector, the run is complete already
+			status.LastSuccessfulTime = status.LastScheduleTime.DeepCopy()
+		}
+		next = cron.Next(now)
+	}
+
+	if err := ctrl.applyRetention(schedule, status, snapshots); err != nil {
+		return 0, err
+	}
+
+	var requeue time.Duration
+	if suspended || next.IsZero() {
+		status.NextScheduleTime = nil
+		status.Conditions = updateCondition(status.Conditions, newReadyCondition(corev1.ConditionFalse, "Not scheduled"), true)
+	} else {
+		status.NextScheduleTime = &metav1.Time{Time: next}
+		status.Conditions = updateCondition(status.Conditions, newReadyCondition(corev1.ConditionTrue, "Scheduled"), true)
+		requeue = next.Sub(now)
+	}
+
+	return requeue, ctrl.updateStatus(schedule, status)
+}
+
+func lastScheduleTime(schedule *snapshotv1.VirtualMachineSnapshotSchedule, status *snapshotv1.VirtualMachineSnapshotScheduleStatus) time.Time {
+	if status.LastScheduleTime != nil {
+		return status.LastScheduleTime.Time
+	}
+	return schedule.CreationTimestamp.Time
+}
+
+func isSameTime(a, b *metav1.Time) bool {
+	return a != nil && b != nil && a.Equal(b)
+}
+
+// evaluateLastRun records the result of the last run once all of its snapshots are done
+func (ctrl *VMSnapshotScheduleController) evaluateLastRun(schedule *snapshotv1.VirtualMachineSnapshotSchedule, status *snapshotv1.VirtualMachineSnapshotScheduleStatus, snapshots []*snapshotv1.VirtualMachineSnapshot) {
+	if status.LastScheduleTime == nil ||
+		isSameTime(status.LastScheduleTime, status.LastSuccessfulTime) ||
+		isSameTime(status.LastScheduleTime, status.LastFailedTime) {
+		return
+	}
+
+	runSnapshots := snapshotsOfRun(snapshots, status.LastScheduleTime.Time)
+	if len(runSnapshots) == 0 {
+		return
+	}
+
+	var failedVMs []string
+	for _, s := range runSnapshots {
+		if s.Status == nil {
+			return
+		}
+		switch s.Status.Phase {
+		case snapshotv1.Succeeded:
+		case snapshotv1.Failed:
+			failedVMs = append(failedVMs, s.Spec.Source.Name)
+		default:
+			return
+		}
+	}
+
+	if len(failedVMs) > 0 {
+		sort.Strings(failedVMs)
+		message := fmt.Sprintf("Snapshots of VirtualMachines %s failed", strings.Join(failedVMs, ", "))
+		condition := newFailureCondition(corev1.ConditionTrue, "Snapshot failed")
+		condition.Message = message
+		status.Conditions = updateCondition(status.Conditions, condition, true)
+		status.LastFailedTime = status.LastScheduleTime.DeepCopy()
+		ctrl.Recorder.Event(schedule, corev1.EventTypeWarning, snapshotScheduleFailedEvent, message)
+		metrics.ReportSnapshotScheduleRun(schedule.Namespace, schedule.Name, metrics.SnapshotScheduleRunFailed)
+		return
+	}
+
+	status.Conditions = updateCondition(status.Conditions, newFailureCondition(corev1.ConditionFalse, "Snapshots succeeded"), true)
+	status.LastSuccessfulTime = status.LastScheduleTime.DeepCopy()
+	ctrl.Recorder.Eventf(schedule, corev1.EventTypeNormal, snapshotScheduleSucceededEvent, "Successfully created %d scheduled snapshots", len(runSnapshots))
+	metrics.ReportSnapshotScheduleRun(schedule.Namespace, schedule.Name, metrics.SnapshotScheduleRunSucceeded)
+}
+
+// runSchedule creates a snapshot of every VM matched by the schedule and returns the number of snapshots
+func (ctrl *VMSnapshotScheduleController) runSchedule(schedule *snapshotv1.VirtualMachineSnapshotSchedule, scheduled time.Time) (int, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&schedule.Spec.Selector)
+	if err != nil {
+		return 0, err
+	}
+
+	var vms []*kubevirtv1.VirtualMachine
+	err = cache.ListAllByNamespace(ctrl.VMInformer.GetIndexer(), schedule.Namespace, selector, func(obj interface{}) {
+		if vm, ok := obj.(*kubevirtv1.VirtualMachine); ok && vm.DeletionTimestamp == nil {
+			vms = append(vms, vm)
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, vm := range vms {
+		vmSnapshot := newScheduledVMSnapshot(schedule, vm, scheduled)
+		_, err := ctrl.Client.VirtualMachineSnapshot(schedule.Namespace).Create(context.Background(), vmSnapshot, metav1.CreateOptions{})
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return 0, err
+		}
+		ctrl.Recorder.Eventf(schedule, corev1.EventTypeNormal, snapshotScheduledEvent, "Created VirtualMachineSnapshot %s", vmSnapshot.Name)
+	}
+
+	return len(vms), nil
+}
+
+// scheduledVMSnapshotName returns the name of the snapshot of the VM for the scheduled run. Long
+// schedule and VM names are truncated and a hash is added, so the name stays unique and valid.
+func scheduledVMSnapshotName(schedule *snapshotv1.VirtualMachineSnapshotSchedule, vm *kubevirtv1.VirtualMachine, scheduled time.Time) string {
+	return naming.GetName(schedule.Name+"-"+vm.Name, strconv.FormatInt(scheduled.Unix()/60, 10), validation.DNS1123SubdomainMaxLength)
+}
+
+func newScheduledVMSnapshot(schedule *snapshotv1.VirtualMachineSnapshotSchedule, vm *kubevirtv1.VirtualMachine, scheduled time.Time) *snapshotv1.VirtualMachineSnapshot {
+	apiGroup := core.GroupName
+	return &snapshotv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scheduledVMSnapshotName(schedule, vm, scheduled),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				snapshotv1.VirtualMachineSnapshotScheduleLabel: schedule.Name,
+			},
+			Annotations: map[string]string{
+				scheduleTimeAnnotation: scheduled.UTC().Format(time.RFC3339),
+			},
+		},
+		Spec: snapshotv1.VirtualMachineSnapshotSpec{
+			Source: corev1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     "VirtualMachine",
+				Name:     vm.Name,
+			},
+			DeletionPolicy:  schedule.Spec.DeletionPolicy,
+			FailureDeadline: schedule.Spec.FailureDeadline,
+		},
+	}
+}
+
+func (ctrl *VMSnapshotScheduleController) getScheduledVMSnapshots(schedule *snapshotv1.VirtualMachineSnapshotSchedule) ([]*snapshotv1.VirtualMachineSnapshot, error) {
+	objs, err := ctrl.VMSnapshotInformer.GetIndexer().ByIndex(scheduleIndex, cacheKeyFunc(schedule.Namespace, schedule.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*snapshotv1.VirtualMachineSnapshot
+	for _, obj := range objs {
+		vmSnapshot, ok := obj.(*snapshotv1.VirtualMachineSnapshot)
+		if !ok {
+			return nil, fmt.Errorf(unexpectedResourceFmt, obj)
+		}
+		snapshots = append(snapshots, vmSnapshot)
+	}
+
+	return snapshots, nil
+}
+
+func snapshotsOfRun(snapshots []*snapshotv1.VirtualMachineSnapshot, scheduled time.Time) []*snapshotv1.VirtualMachineSnapshot {
+	runTime := scheduled.UTC().Format(time.RFC3339)
+	var result []*snapshotv1.VirtualMachineSnapshot
+	for _, s := range snapshots {
+		if s.Annotations[scheduleTimeAnnotation] == runTime {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// applyRetention deletes the scheduled snapshots which are not selected by the retention policy.
+// Snapshots in progress and failed snapshots of the last run are always kept.
+func (ctrl *VMSnapshotScheduleController) applyRetention(schedule *snapshotv1.VirtualMachineSnapshotSchedule, status *snapshotv1.VirtualMachineSnapshotScheduleStatus, snapshots []*snapshotv1.VirtualMachineSnapshot) error {
+	if schedule.Spec.Retention == nil {
+		return nil
+	}
+
+	var lastRun string
+	if status.LastScheduleTime != nil {
+		lastRun = status.LastScheduleTime.UTC().Format(time.RFC3339)
+	}
+
+	byVM := map[string][]*snapshotv1.VirtualMachineSnapshot{}
+	for _, s := range snapshots {
+		if s.DeletionTimestamp != nil {
+			continue
+		}
+		byVM[s.Spec.Source.Name] = append(byVM[s.Spec.Source.Name], s)
+	}
+
+	for _, vmSnapshots := range byVM {
+		for _, s := range snapshotsToDelete(schedule.Spec.Retention, vmSnapshots, lastRun) {
+			err := ctrl.Client.VirtualMachineSnapshot(s.Namespace).Delete(context.Background(), s.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			ctrl.Recorder.Eventf(schedule, corev1.EventTypeNormal, snapshotRetentionDeletedEvent, "Deleted VirtualMachineSnapshot %s", s.Name)
+		}
+	}
+
+	return nil
+}
+
+func snapshotsToDelete(retention *snapshotv1.SnapshotRetentionPolicy, snapshots []*snapshotv1.VirtualMachineSnapshot, lastRun string) []*snapshotv1.VirtualMachineSnapshot {
+	var succeeded, toDelete []*snapshotv1.VirtualMachineSnapshot
+	for _, s := range snapshots {
+		if s.Status == nil {
+			continue
+		}
+		switch s.Status.Phase {
+		case snapshotv1.Succeeded:
+			succeeded = append(succeeded, s)
+		case snapshotv1.Failed:
+			if s.Annotations[scheduleTimeAnnotation] != lastRun {
+				toDelete = append(toDelete, s)
+			}
+		}
+	}
+
+	sort.Slice(succeeded, func(i, j int) bool {
+		return succeeded[j].CreationTimestamp.Before(&succeeded[i].CreationTimestamp)
+	})
+
+	var keepLast, keepDaily int
+	if retention.KeepLast != nil {
+		keepLast = int(*retention.KeepLast)
+	}
+	if retention.KeepDaily != nil {
+		keepDaily = int(*retention.KeepDaily)
+	}
+
+	days := map[string]struct{}{}
+	for i, s := range succeeded {
+		keep := i < keepLast
+		day := s.CreationTimestamp.UTC().Format("2006-01-02")
+		if _, seen := days[day]; !seen && len(days) < keepDaily {
+			days[day] = struct{}{}
+			keep = true
+		}
+		if !keep {
+			toDelete = append(toDelete, s)
+		}
+	}
+
+	return toDelete
+}
+
+func (ctrl *VMSnapshotScheduleController) updateStatus(schedule *snapshotv1.VirtualMachineSnapshotSchedule, status *snapshotv1.VirtualMachineSnapshotScheduleStatus) error {
+	if equality.Semantic.DeepEqual(schedule.Status, status) {
+		return nil
+	}
+
+	scheduleCpy := schedule.DeepCopy()
+	scheduleCpy.Status = status
+	_, err := ctrl.Client.VirtualMachineSnapshotSchedule(schedule.Namespace).UpdateStatus(context.Background(), scheduleCpy, metav1.UpdateOptions{})
+	return err
+}
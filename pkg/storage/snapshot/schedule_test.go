@@ -0,0 +1,389 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+
+	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Snapshot schedule controller", func() {
+	const scheduleName = "hourly"
+
+	var (
+		controller                 *VMSnapshotScheduleController
+		vmSnapshotScheduleInformer cache.SharedIndexInformer
+		vmSnapshotInformer         cache.SharedIndexInformer
+		vmInformer                 cache.SharedIndexInformer
+		recorder                   *record.FakeRecorder
+		client                     *kubevirtfake.Clientset
+		now                        time.Time
+	)
+
+	newSchedule := func() *snapshotv1.VirtualMachineSnapshotSchedule {
+		return &snapshotv1.VirtualMachineSnapshotSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              scheduleName,
+				Namespace:         testNamespace,
+				CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+			},
+			Spec: snapshotv1.VirtualMachineSnapshotScheduleSpec{
+				Schedule: "@hourly",
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"backup": "true"},
+				},
+			},
+		}
+	}
+
+	newVM := func(name string, labels map[string]string) *v1.VirtualMachine {
+		vm := createVirtualMachine(testNamespace, name)
+		vm.Labels = labels
+		return vm
+	}
+
+	newScheduledSnapshot := func(vmName string, scheduled time.Time, phase snapshotv1.VirtualMachineSnapshotPhase) *snapshotv1.VirtualMachineSnapshot {
+		s := newScheduledVMSnapshot(newSchedule(), newVM(vmName, nil), scheduled)
+		s.CreationTimestamp = metav1.NewTime(scheduled)
+		s.Status = &snapshotv1.VirtualMachineSnapshotStatus{Phase: phase}
+		return s
+	}
+
+	addSchedule := func(schedule *snapshotv1.VirtualMachineSnapshotSchedule) {
+		Expect(vmSnapshotScheduleInformer.GetStore().Add(schedule)).To(Succeed())
+		_, err := client.SnapshotV1alpha1().VirtualMachineSnapshotSchedules(schedule.Namespace).Create(context.Background(), schedule, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		client.ClearActions()
+	}
+
+	getSchedule := func() *snapshotv1.VirtualMachineSnapshotSchedule {
+		schedule, err := client.SnapshotV1alpha1().VirtualMachineSnapshotSchedules(testNamespace).Get(context.Background(), scheduleName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return schedule
+	}
+
+	actionsOf := func(verb, resource string) []testing.Action {
+		var actions []testing.Action
+		for _, action := range client.Actions() {
+			if action.GetVerb() == verb && action.GetResource().Resource == resource {
+				actions = append(actions, action)
+			}
+		}
+		return actions
+	}
+
+	findCondition := func(status *snapshotv1.VirtualMachineSnapshotScheduleStatus, conditionType snapshotv1.ConditionType) *snapshotv1.Condition {
+		for i := range status.Conditions {
+			if status.Conditions[i].Type == conditionType {
+				return &status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC)
+		currentTime = func() *metav1.Time {
+			return &metav1.Time{Time: now}
+		}
+
+		vmSnapshotScheduleInformer, _ = testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineSnapshotSchedule{})
+		vmSnapshotInformer, _ = testutils.NewFakeInformerWithIndexersFor(&snapshotv1.VirtualMachineSnapshot{}, virtcontroller.GetVirtualMachineSnapshotInformerIndexers())
+		vmInformer, _ = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, virtcontroller.GetVirtualMachineInformerIndexers())
+		recorder = record.NewFakeRecorder(100)
+
+		client = kubevirtfake.NewSimpleClientset()
+		virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient.EXPECT().VirtualMachineSnapshot(testNamespace).
+			Return(client.SnapshotV1alpha1().VirtualMachineSnapshots(testNamespace)).AnyTimes()
+		virtClient.EXPECT().VirtualMachineSnapshotSchedule(testNamespace).
+			Return(client.SnapshotV1alpha1().VirtualMachineSnapshotSchedules(testNamespace)).AnyTimes()
+
+		controller = &VMSnapshotScheduleController{
+			Client:                     virtClient,
+			VMSnapshotScheduleInformer: vmSnapshotScheduleInformer,
+			VMSnapshotInformer:         vmSnapshotInformer,
+			VMInformer:                 vmInformer,
+			Recorder:                   recorder,
+		}
+		Expect(controller.Init()).To(Succeed())
+	})
+
+	It("should snapshot the selected VMs once the schedule is due", func() {
+		Expect(vmInformer.GetStore().Add(newVM("selected", map[string]string{"backup": "true"}))).To(Succeed())
+		Expect(vmInformer.GetStore().Add(newVM("other", map[string]string{"backup": "false"}))).To(Succeed())
+		schedule := newSchedule()
+		addSchedule(schedule)
+
+		requeue, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(Equal(30 * time.Minute))
+
+		creates := actionsOf("create", "virtualmachinesnapshots")
+		Expect(creates).To(HaveLen(1))
+		vmSnapshot := creates[0].(testing.CreateAction).GetObject().(*snapshotv1.VirtualMachineSnapshot)
+		scheduled := time.Date(2023, time.March, 15, 10, 0, 0, 0, time.UTC)
+		Expect(vmSnapshot.Name).To(Equal(fmt.Sprintf("%s-selected-%d", scheduleName, scheduled.Unix()/60)))
+		Expect(vmSnapshot.Spec.Source.Name).To(Equal("selected"))
+		Expect(vmSnapshot.Labels).To(HaveKeyWithValue(snapshotv1.VirtualMachineSnapshotScheduleLabel, scheduleName))
+		Expect(vmSnapshot.Annotations).To(HaveKeyWithValue(scheduleTimeAnnotation, "2023-03-15T10:00:00Z"))
+
+		status := getSchedule().Status
+		Expect(status.LastScheduleTime.Time).To(Equal(scheduled))
+		Expect(status.NextScheduleTime.Time).To(Equal(scheduled.Add(time.Hour)))
+		Expect(status.LastSuccessfulTime).To(BeNil())
+		Expect(findCondition(status, snapshotv1.ConditionReady).Status).To(Equal(corev1.ConditionTrue))
+		Expect(recorder.Events).To(Receive(ContainSubstring(snapshotScheduledEvent)))
+	})
+
+	It("should cap the name of snapshots of VMs with long names", func() {
+		scheduled := time.Date(2023, time.March, 15, 10, 0, 0, 0, time.UTC)
+		longName := strings.Repeat("a", validation.DNS1123SubdomainMaxLength)
+		name := newScheduledVMSnapshot(newSchedule(), newVM(longName, nil), scheduled).Name
+		Expect(len(name)).To(BeNumerically("<=", validation.DNS1123SubdomainMaxLength))
+		Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+		Expect(name).To(HaveSuffix(fmt.Sprintf("-%d", scheduled.Unix()/60)))
+
+		otherName := newScheduledVMSnapshot(newSchedule(), newVM(longName+"b", nil), scheduled).Name
+		Expect(otherName).ToNot(Equal(name))
+	})
+
+	It("should not snapshot before the schedule is due", func() {
+		Expect(vmInformer.GetStore().Add(newVM("selected", map[string]string{"backup": "true"}))).To(Succeed())
+		schedule := newSchedule()
+		schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+			LastScheduleTime: &metav1.Time{Time: time.Date(2023, time.March, 15, 10, 0, 0, 0, time.UTC)},
+		}
+		addSchedule(schedule)
+
+		requeue, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(Equal(30 * time.Minute))
+		Expect(actionsOf("create", "virtualmachinesnapshots")).To(BeEmpty())
+	})
+
+	It("should not snapshot a suspended schedule", func() {
+		Expect(vmInformer.GetStore().Add(newVM("selected", map[string]string{"backup": "true"}))).To(Succeed())
+		schedule := newSchedule()
+		schedule.Spec.Suspend = pointer.Bool(true)
+		addSchedule(schedule)
+
+		requeue, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requeue).To(BeZero())
+		Expect(actionsOf("create", "virtualmachinesnapshots")).To(BeEmpty())
+
+		status := getSchedule().Status
+		Expect(status.NextScheduleTime).To(BeNil())
+		Expect(findCondition(status, snapshotv1.ConditionReady).Status).To(Equal(corev1.ConditionFalse))
+	})
+
+	It("should mark an invalid schedule as not ready", func() {
+		schedule := newSchedule()
+		schedule.Spec.Schedule = "not a schedule"
+		addSchedule(schedule)
+
+		_, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+
+		condition := findCondition(getSchedule().Status, snapshotv1.ConditionReady)
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("Invalid schedule"))
+		Expect(condition.Message).To(ContainSubstring("expected 5 fields"))
+	})
+
+	It("should complete a run without selected VMs right away", func() {
+		schedule := newSchedule()
+		addSchedule(schedule)
+
+		_, err := controller.updateVMSnapshotSchedule(schedule)
+		Expect(err).ToNot(HaveOccurred())
+
+		status := getSchedule().Status
+		Expect(status.LastSuccessfulTime).ToNot(BeNil())
+		Expect(status.LastSuccessfulTime.Equal(status.LastScheduleTime)).To(BeTrue())
+	})
+
+	Context("with a finished run", func() {
+		var lastRun time.Time
+
+		BeforeEach(func() {
+			lastRun = time.Date(2023, time.March, 15, 10, 0, 0, 0, time.UTC)
+		})
+
+		newScheduleWithRun := func() *snapshotv1.VirtualMachineSnapshotSchedule {
+			schedule := newSchedule()
+			schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+				LastScheduleTime: &metav1.Time{Time: lastRun},
+			}
+			return schedule
+		}
+
+		It("should record the success of the run", func() {
+			Expect(vmSnapshotInformer.GetStore().Add(newScheduledSnapshot("vm1", lastRun, snapshotv1.Succeeded))).To(Succeed())
+			Expect(vmSnapshotInformer.GetStore().Add(newScheduledSnapshot("vm2", lastRun, snapshotv1.Succeeded))).To(Succeed())
+			schedule := newScheduleWithRun()
+			addSchedule(schedule)
+
+			_, err := controller.updateVMSnapshotSchedule(schedule)
+			Expect(err).ToNot(HaveOccurred())
+
+			status := getSchedule().Status
+			Expect(status.LastSuccessfulTime.Time).To(Equal(lastRun))
+			Expect(status.LastFailedTime).To(BeNil())
+			Expect(findCondition(status, snapshotv1.ConditionFailure).Status).To(Equal(corev1.ConditionFalse))
+			Expect(recorder.Events).To(Receive(ContainSubstring(snapshotScheduleSucceededEvent)))
+		})
+
+		It("should record the failure of the run", func() {
+			Expect(vmSnapshotInformer.GetStore().Add(newScheduledSnapshot("vm1", lastRun, snapshotv1.Succeeded))).To(Succeed())
+			Expect(vmSnapshotInformer.GetStore().Add(newScheduledSnapshot("vm2", lastRun, snapshotv1.Failed))).To(Succeed())
+			schedule := newScheduleWithRun()
+			addSchedule(schedule)
+
+			_, err := controller.updateVMSnapshotSchedule(schedule)
+			Expect(err).ToNot(HaveOccurred())
+
+			status := getSchedule().Status
+			Expect(status.LastFailedTime.Time).To(Equal(lastRun))
+			Expect(status.LastSuccessfulTime).To(BeNil())
+			condition := findCondition(status, snapshotv1.ConditionFailure)
+			Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("vm2"))
+			Expect(recorder.Events).To(Receive(ContainSubstring(snapshotScheduleFailedEvent)))
+		})
+
+		It("should wait for snapshots in progress", func() {
+			Expect(vmSnapshotInformer.GetStore().Add(newScheduledSnapshot("vm1", lastRun, snapshotv1.InProgress))).To(Succeed())
+			Expect(vmSnapshotInformer.GetStore().Add(newScheduledSnapshot("vm2", lastRun, snapshotv1.Failed))).To(Succeed())
+			schedule := newScheduleWithRun()
+			addSchedule(schedule)
+
+			_, err := controller.updateVMSnapshotSchedule(schedule)
+			Expect(err).ToNot(HaveOccurred())
+
+			status := getSchedule().Status
+			Expect(status.LastFailedTime).To(BeNil())
+			Expect(status.LastSuccessfulTime).To(BeNil())
+		})
+	})
+
+	Context("with retention", func() {
+		var deleted []string
+
+		addSnapshots := func(snapshots ...*snapshotv1.VirtualMachineSnapshot) {
+			for _, s := range snapshots {
+				Expect(vmSnapshotInformer.GetStore().Add(s)).To(Succeed())
+			}
+		}
+
+		runRetention := func(retention *snapshotv1.SnapshotRetentionPolicy) {
+			schedule := newSchedule()
+			schedule.Spec.Retention = retention
+			schedule.Status = &snapshotv1.VirtualMachineSnapshotScheduleStatus{
+				LastScheduleTime: &metav1.Time{Time: time.Date(2023, time.March, 15, 10, 0, 0, 0, time.UTC)},
+			}
+			addSchedule(schedule)
+
+			_, err := controller.updateVMSnapshotSchedule(schedule)
+			Expect(err).ToNot(HaveOccurred())
+
+			deleted = nil
+			for _, action := range actionsOf("delete", "virtualmachinesnapshots") {
+				deleted = append(deleted, action.(testing.DeleteAction).GetName())
+			}
+		}
+
+		day := func(d, h int) time.Time {
+			return time.Date(2023, time.March, d, h, 0, 0, 0, time.UTC)
+		}
+
+		nameOf := func(vmName string, scheduled time.Time) string {
+			return fmt.Sprintf("%s-%s-%d", scheduleName, vmName, scheduled.Unix()/60)
+		}
+
+		BeforeEach(func() {
+			addSnapshots(
+				newScheduledSnapshot("vm1", day(15, 10), snapshotv1.Succeeded),
+				newScheduledSnapshot("vm1", day(15, 9), snapshotv1.Succeeded),
+				newScheduledSnapshot("vm1", day(15, 8), snapshotv1.Succeeded),
+				newScheduledSnapshot("vm1", day(14, 23), snapshotv1.Succeeded),
+				newScheduledSnapshot("vm1", day(14, 22), snapshotv1.Failed),
+				newScheduledSnapshot("vm1", day(13, 23), snapshotv1.Succeeded),
+				newScheduledSnapshot("vm2", day(15, 10), snapshotv1.Succeeded),
+			)
+		})
+
+		It("should keep everything without a retention policy", func() {
+			runRetention(nil)
+			Expect(deleted).To(BeEmpty())
+		})
+
+		It("should keep the last snapshots of every VM", func() {
+			runRetention(&snapshotv1.SnapshotRetentionPolicy{KeepLast: pointer.Int32(2)})
+			Expect(deleted).To(ConsistOf(
+				nameOf("vm1", day(15, 8)),
+				nameOf("vm1", day(14, 23)),
+				nameOf("vm1", day(14, 22)),
+				nameOf("vm1", day(13, 23)),
+			))
+		})
+
+		It("should keep the last snapshot of every day", func() {
+			runRetention(&snapshotv1.SnapshotRetentionPolicy{KeepDaily: pointer.Int32(2)})
+			Expect(deleted).To(ConsistOf(
+				nameOf("vm1", day(15, 9)),
+				nameOf("vm1", day(15, 8)),
+				nameOf("vm1", day(14, 22)),
+				nameOf("vm1", day(13, 23)),
+			))
+		})
+
+		It("should combine the retention rules", func() {
+			runRetention(&snapshotv1.SnapshotRetentionPolicy{KeepLast: pointer.Int32(2), KeepDaily: pointer.Int32(3)})
+			Expect(deleted).To(ConsistOf(
+				nameOf("vm1", day(15, 8)),
+				nameOf("vm1", day(14, 22)),
+			))
+		})
+	})
+})
@@ -39,9 +39,11 @@ import (
 
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/instancetype"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/status"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
@@ -1042,6 +1044,45 @@ var _ = Describe("Snapshot controlleer", func() {
 				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
 			})
 
+			It("should create VirtualMachineSnapshotContent with backend storage", func() {
+				storageClass := createStorageClass()
+				volumeSnapshotClass := createVolumeSnapshotClasses()[0]
+
+				vmSnapshot := createVMSnapshotInProgress()
+				vm := createLockedVM()
+				vm.Spec.Template.Spec.Domain.Devices.TPM = &v1.TPMDevice{Persistent: &t}
+				pvcs := createPersistentVolumeClaims()
+				pvcs = addBackendStoragePVC(pvcs, vm)
+				vmSnapshotContent := createVirtualMachineSnapshotContent(vmSnapshot, vm, pvcs)
+				Expect(vmSnapshotContent.Spec.VolumeBackups).To(ContainElement(HaveField("VolumeName", backendstorage.VolumeName)))
+
+				vmSource.Add(vm)
+				storageClassSource.Add(storageClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+				expectVMSnapshotContentCreate(vmSnapshotClient, vmSnapshotContent)
+				vmSnapshotSource.Add(vmSnapshot)
+				addVolumeSnapshotClass(volumeSnapshotClass)
+
+				updatedSnapshot := vmSnapshot.DeepCopy()
+				updatedSnapshot.ResourceVersion = "1"
+				updatedSnapshot.Status = &snapshotv1.VirtualMachineSnapshotStatus{
+					SourceUID:  &vmUID,
+					ReadyToUse: &f,
+					Phase:      snapshotv1.InProgress,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Source locked and operation in progress"),
+						newReadyCondition(corev1.ConditionFalse, "Not ready"),
+					},
+					Indications: []snapshotv1.Indication{},
+				}
+				expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+
+				controller.processVMSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVirtualMachineSnapshotContentCreate")
+			})
+
 			It("should update VirtualMachineSnapshotStatus", func() {
 				vmSnapshotContent := createReadyVMSnapshotContent()
 
@@ -2355,6 +2396,9 @@ func createVirtualMachineSnapshotContent(vmSnapshot *snapshotv1.VirtualMachineSn
 		if pvc.Name == "memorydump" {
 			diskName = pvc.Name
 		}
+		if strings.HasPrefix(pvc.Name, backendstorage.PVCPrefix) {
+			diskName = backendstorage.VolumeName
+		}
 		volumeSnapshotName := fmt.Sprintf("vmsnapshot-%s-volume-%s", vmSnapshot.UID, diskName)
 		vb := snapshotv1.VolumeBackup{
 			VolumeName: diskName,
@@ -2450,6 +2494,29 @@ func addMemoryDumpPVC(pvcs []corev1.PersistentVolumeClaim) []corev1.PersistentVo
 	return pvcs
 }
 
+func addBackendStoragePVC(pvcs []corev1.PersistentVolumeClaim, vm *v1.VirtualMachine) []corev1.PersistentVolumeClaim {
+	pvc := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       testNamespace,
+			Name:            backendstorage.PVCForVM(vm),
+			ResourceVersion: "2",
+			Labels:          map[string]string{backendstorage.PVCLabel: vm.Name},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			VolumeName: "backend-storage",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceName(corev1.ResourceStorage): resource.MustParse(virtconfig.DefaultVMStateStorageSize),
+				},
+			},
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			StorageClassName: &storageClassName,
+		},
+	}
+	pvcs = append(pvcs, pvc)
+	return pvcs
+}
+
 func updateVMWithMemoryDump(vm *v1.VirtualMachine) *v1.VirtualMachine {
 	vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
 		Name: "memorydump",
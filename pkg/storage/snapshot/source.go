@@ -38,6 +38,7 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/controller"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	utils "kubevirt.io/kubevirt/pkg/util"
 	watchutil "kubevirt.io/kubevirt/pkg/virt-controller/watch/util"
@@ -362,11 +363,21 @@ func (s *vmSnapshotSource) Unfreeze() error {
 }
 
 func (s *vmSnapshotSource) PersistentVolumeClaims() (map[string]string, error) {
-	return storagetypes.GetPVCsFromVolumes(s.vm.Spec.Template.Spec.Volumes), nil
+	return s.persistentVolumeClaims(), nil
 }
 
-func (s *vmSnapshotSource) pvcNames() sets.String {
+// persistentVolumeClaims returns the PVCs of the VM volumes, together with the backend storage PVC
+// keeping the VM state, if the VM needs one
+func (s *vmSnapshotSource) persistentVolumeClaims() map[string]string {
 	pvcs := storagetypes.GetPVCsFromVolumes(s.vm.Spec.Template.Spec.Volumes)
+	if backendstorage.IsBackendStorageNeededForVM(s.vm) {
+		pvcs[backendstorage.VolumeName] = backendstorage.PVCForVM(s.vm)
+	}
+	return pvcs
+}
+
+func (s *vmSnapshotSource) pvcNames() sets.String {
+	pvcs := s.persistentVolumeClaims()
 	ss := sets.NewString()
 	for _, pvc := range pvcs {
 		ss.Insert(pvc)
@@ -15,6 +15,7 @@ type MockInstancetypeMethods struct {
 	ApplyToVmiFunc                  func(field *k8sfield.Path, instancetypespec *instancetypev1beta1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec, vmiSpec *v1.VirtualMachineInstanceSpec, vmiMetadata *metav1.ObjectMeta) instancetype.Conflicts
 	FindPreferenceSpecFunc          func(vm *v1.VirtualMachine) (*instancetypev1beta1.VirtualMachinePreferenceSpec, error)
 	StoreControllerRevisionsFunc    func(vm *v1.VirtualMachine) error
+	UpgradeControllerRevisionsFunc  func(vm *v1.VirtualMachine, dryRun bool) error
 	InferDefaultInstancetypeFunc    func(vm *v1.VirtualMachine) error
 	InferDefaultPreferenceFunc      func(vm *v1.VirtualMachine) error
 	CheckPreferenceRequirementsFunc func(instancetypeSpec *instancetypev1beta1.VirtualMachineInstancetypeSpec, preferenceSpec *instancetypev1beta1.VirtualMachinePreferenceSpec, vmiSpec *v1.VirtualMachineInstanceSpec) (instancetype.Conflicts, error)
@@ -38,6 +39,10 @@ func (m *MockInstancetypeMethods) StoreControllerRevisions(vm *v1.VirtualMachine
 	return m.StoreControllerRevisionsFunc(vm)
 }
 
+func (m *MockInstancetypeMethods) UpgradeControllerRevisions(vm *v1.VirtualMachine, dryRun bool) error {
+	return m.UpgradeControllerRevisionsFunc(vm, dryRun)
+}
+
 func (m *MockInstancetypeMethods) InferDefaultInstancetype(vm *v1.VirtualMachine) error {
 	return m.InferDefaultInstancetypeFunc(vm)
 }
@@ -64,6 +69,9 @@ func NewMockInstancetypeMethods() *MockInstancetypeMethods {
 		StoreControllerRevisionsFunc: func(_ *v1.VirtualMachine) error {
 			return nil
 		},
+		UpgradeControllerRevisionsFunc: func(_ *v1.VirtualMachine, _ bool) error {
+			return nil
+		},
 		InferDefaultInstancetypeFunc: func(_ *v1.VirtualMachine) error {
 			return nil
 		},
@@ -0,0 +1,50 @@
+package testutils
+
+import (
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/quota"
+)
+
+type MockQuotaChecker struct {
+	CheckFunc   func(namespace, name string, spec *v1.VirtualMachineInstanceSpec) error
+	ReserveFunc func(namespace string, spec *v1.VirtualMachineInstanceSpec) error
+
+	CheckUpdateFunc   func(namespace string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error
+	ReserveUpdateFunc func(namespace string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error
+}
+
+var _ quota.Checker = &MockQuotaChecker{}
+
+func (m *MockQuotaChecker) Check(namespace, name string, spec *v1.VirtualMachineInstanceSpec) error {
+	return m.CheckFunc(namespace, name, spec)
+}
+
+func (m *MockQuotaChecker) Reserve(namespace string, spec *v1.VirtualMachineInstanceSpec) error {
+	return m.ReserveFunc(namespace, spec)
+}
+
+func (m *MockQuotaChecker) CheckUpdate(namespace string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error {
+	return m.CheckUpdateFunc(namespace, oldSpec, newSpec)
+}
+
+func (m *MockQuotaChecker) ReserveUpdate(namespace string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error {
+	return m.ReserveUpdateFunc(namespace, oldSpec, newSpec)
+}
+
+func NewMockQuotaChecker() *MockQuotaChecker {
+	return &MockQuotaChecker{
+		CheckFunc: func(_, _ string, _ *v1.VirtualMachineInstanceSpec) error {
+			return nil
+		},
+		ReserveFunc: func(_ string, _ *v1.VirtualMachineInstanceSpec) error {
+			return nil
+		},
+		CheckUpdateFunc: func(_ string, _, _ *v1.VirtualMachineInstanceSpec) error {
+			return nil
+		},
+		ReserveUpdateFunc: func(_ string, _, _ *v1.VirtualMachineInstanceSpec) error {
+			return nil
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package migrations
 
 import (
+	k8sv1 "k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
@@ -83,3 +84,43 @@ func VMIMigratableOnEviction(clusterConfig *virtconfig.ClusterConfig, vmi *v1.Vi
 	}
 	return false
 }
+
+// IsMigratingVolumes returns true if the ongoing or last migration of the VMI copies volumes to new claims
+func IsMigratingVolumes(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Status.MigrationState != nil && len(vmi.Status.MigrationState.MigratedVolumes) > 0
+}
+
+// IsBlockMigration returns true if the storage of some volumes has to be copied during the migration
+func IsBlockMigration(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Status.MigrationMethod == v1.BlockMigration || IsMigratingVolumes(vmi)
+}
+
+// MigratedVolumeByName returns the migrated volume info matching the volume name, or nil if the volume is not migrated
+func MigratedVolumeByName(vmi *v1.VirtualMachineInstance, volumeName string) *v1.StorageMigratedVolumeInfo {
+	if vmi.Status.MigrationState == nil {
+		return nil
+	}
+	for i, volume := range vmi.Status.MigrationState.MigratedVolumes {
+		if volume.VolumeName == volumeName {
+			return &vmi.Status.MigrationState.MigratedVolumes[i]
+		}
+	}
+	return nil
+}
+
+// VolumeWithClaim returns a copy of the volume backed by the given PersistentVolumeClaim
+func VolumeWithClaim(volume *v1.Volume, claimName string) v1.Volume {
+	newVolume := volume.DeepCopy()
+	if newVolume.PersistentVolumeClaim != nil {
+		newVolume.PersistentVolumeClaim.ClaimName = claimName
+		return *newVolume
+	}
+	newVolume.VolumeSource = v1.VolumeSource{
+		PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+			PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	}
+	return *newVolume
+}
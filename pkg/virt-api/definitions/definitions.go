@@ -42,7 +42,11 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 	exportv1 "kubevirt.io/api/export/v1alpha1"
 	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
+	"kubevirt.io/api/memory"
+	memoryv1alpha1 "kubevirt.io/api/memory/v1alpha1"
 	poolv1alpha1 "kubevirt.io/api/pool/v1alpha1"
+	"kubevirt.io/api/quota"
+	quotav1alpha1 "kubevirt.io/api/quota/v1alpha1"
 	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
 
 	mime "kubevirt.io/kubevirt/pkg/rest"
@@ -64,6 +68,8 @@ func ComposeAPIDefinitions() []*restful.WebService {
 		migrationPoliciesApiServiceDefinitions,
 		poolApiServiceDefinitions,
 		vmCloneDefinitions,
+		vmQuotaDefinitions,
+		nodeMemoryPolicyDefinitions,
 	} {
 		result = append(result, f()...)
 	}
@@ -125,6 +131,8 @@ func snapshotApiServiceDefinitions() []*restful.WebService {
 	vmsGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshots")
 	vmscGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshotcontents")
 	vmrGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinerestores")
+	vmssGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshotschedules")
+	vmbGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinebackups")
 
 	ws, err := groupVersionProxyBase(schema.GroupVersion{Group: snapshotv1.SchemeGroupVersion.Group, Version: snapshotv1.SchemeGroupVersion.Version})
 	if err != nil {
@@ -146,6 +154,16 @@ func snapshotApiServiceDefinitions() []*restful.WebService {
 		panic(err)
 	}
 
+	ws, err = genericNamespacedResourceProxy(ws, vmssGVR, &snapshotv1.VirtualMachineSnapshotSchedule{}, "VirtualMachineSnapshotSchedule", &snapshotv1.VirtualMachineSnapshotScheduleList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws, err = genericNamespacedResourceProxy(ws, vmbGVR, &snapshotv1.VirtualMachineBackup{}, "VirtualMachineBackup", &snapshotv1.VirtualMachineBackupList{})
+	if err != nil {
+		panic(err)
+	}
+
 	ws2, err := resourceProxyAutodiscovery(vmsGVR)
 	if err != nil {
 		panic(err)
@@ -175,6 +193,7 @@ func exportApiServiceDefinitions() []*restful.WebService {
 
 func migrationPoliciesApiServiceDefinitions() []*restful.WebService {
 	mpGVR := migrationsv1.SchemeGroupVersion.WithResource(migrations.ResourceMigrationPolicies)
+	neGVR := migrationsv1.SchemeGroupVersion.WithResource(migrations.ResourceNodeEvacuations)
 
 	ws, err := groupVersionProxyBase(schema.GroupVersion{Group: migrationsv1.SchemeGroupVersion.Group, Version: migrationsv1.SchemeGroupVersion.Version})
 	if err != nil {
@@ -186,6 +205,11 @@ func migrationPoliciesApiServiceDefinitions() []*restful.WebService {
 		panic(err)
 	}
 
+	ws, err = genericClusterResourceProxy(ws, neGVR, &migrationsv1.NodeEvacuation{}, migrationsv1.NodeEvacuationKind.Kind, &migrationsv1.NodeEvacuationList{})
+	if err != nil {
+		panic(err)
+	}
+
 	ws2, err := resourceProxyAutodiscovery(mpGVR)
 	if err != nil {
 		panic(err)
@@ -273,6 +297,46 @@ func vmCloneDefinitions() []*restful.WebService {
 	return []*restful.WebService{ws, ws2}
 }
 
+func vmQuotaDefinitions() []*restful.WebService {
+	vmQuotaGVR := quotav1alpha1.SchemeGroupVersion.WithResource(quota.ResourceVMQuotaPlural)
+
+	ws, err := groupVersionProxyBase(schema.GroupVersion{Group: quotav1alpha1.SchemeGroupVersion.Group, Version: quotav1alpha1.SchemeGroupVersion.Version})
+	if err != nil {
+		panic(err)
+	}
+
+	ws, err = genericNamespacedResourceProxy(ws, vmQuotaGVR, &quotav1alpha1.VirtualMachineQuota{}, quotav1alpha1.VirtualMachineQuotaKind.Kind, &quotav1alpha1.VirtualMachineQuotaList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws2, err := resourceProxyAutodiscovery(vmQuotaGVR)
+	if err != nil {
+		panic(err)
+	}
+	return []*restful.WebService{ws, ws2}
+}
+
+func nodeMemoryPolicyDefinitions() []*restful.WebService {
+	nodeMemoryPolicyGVR := memoryv1alpha1.SchemeGroupVersion.WithResource(memory.ResourceNodeMemoryPolicyPlural)
+
+	ws, err := groupVersionProxyBase(schema.GroupVersion{Group: memoryv1alpha1.SchemeGroupVersion.Group, Version: memoryv1alpha1.SchemeGroupVersion.Version})
+	if err != nil {
+		panic(err)
+	}
+
+	ws, err = genericClusterResourceProxy(ws, nodeMemoryPolicyGVR, &memoryv1alpha1.NodeMemoryPolicy{}, memoryv1alpha1.NodeMemoryPolicyKind.Kind, &memoryv1alpha1.NodeMemoryPolicyList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws2, err := resourceProxyAutodiscovery(nodeMemoryPolicyGVR)
+	if err != nil {
+		panic(err)
+	}
+	return []*restful.WebService{ws, ws2}
+}
+
 func groupVersionProxyBase(gv schema.GroupVersion) (*restful.WebService, error) {
 	ws := new(restful.WebService)
 	ws.Doc("The KubeVirt API, a virtual machine management.")
@@ -69,6 +69,7 @@ const (
 	vmiNotPaused                 = "VMI is not paused"
 	vmiGuestAgentErr             = "VMI does not have guest agent connected"
 	vmiNoAttestationErr          = "Attestation not requested for VMI"
+	vmiNoSNPErr                  = "VMI does not use SEV-SNP"
 	prepConnectionErrFmt         = "Cannot prepare connection %s"
 	getRequestErrFmt             = "Cannot GET request %s"
 	pvcVolumeModeErr             = "pvc should be filesystem pvc"
@@ -1624,6 +1625,28 @@ func (app *SubresourceAPIApp) SEVFetchCertChainRequestHandler(request *restful.R
 	app.httpGetRequestHandler(request, response, validate, getURL, v1.SEVPlatformInfo{})
 }
 
+func (app *SubresourceAPIApp) SNPFetchCertChainRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.ensureSEVEnabled(response) {
+		return
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if !vmi.IsScheduled() && !vmi.IsRunning() {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not assigned to a node yet"))
+		}
+		if !kutil.IsSEVSNPVMI(vmi) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf(vmiNoSNPErr))
+		}
+		return nil
+	}
+
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SNPFetchCertChainURI(vmi)
+	}
+
+	app.httpGetRequestHandler(request, response, validate, getURL, v1.SEVPlatformInfo{})
+}
+
 func (app *SubresourceAPIApp) SEVQueryLaunchMeasurementHandler(request *restful.Request, response *restful.Response) {
 	if !app.ensureSEVEnabled(response) {
 		return
@@ -2344,6 +2344,46 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		})
 	})
 
+	Context("Subresource api - AMD SEV-SNP attestation", func() {
+		withSNP := func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
+				SNP: &v1.SEVSNP{},
+			}
+		}
+
+		BeforeEach(func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+		})
+
+		It("Should allow to fetch certificates chain when VMI is running", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/snp/fetchcertchain"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.SEVPlatformInfo{ChipID: "AAABBB"}),
+				),
+			)
+			response.SetRequestAccepts(restful.MIME_JSON)
+
+			expectVMI(Running, UnPaused, withSNP)
+			app.SNPFetchCertChainRequestHandler(request, response)
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail to fetch certificates chain when the VMI does not use SEV-SNP", func() {
+			expectVMI(Running, UnPaused)
+			app.SNPFetchCertChainRequestHandler(request, response)
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("Should fail to fetch certificates chain when the feature gate is disabled", func() {
+			disableFeatureGates()
+			app.SNPFetchCertChainRequestHandler(request, response)
+			Expect(response.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+	})
+
 	AfterEach(func() {
 		backend.Close()
 		disableFeatureGates()
@@ -0,0 +1,133 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/emicklei/go-restful/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+)
+
+// UpgradeInstancetypeVMRequestHandler re-resolves the instancetype and preference of a VirtualMachine to their latest
+// generation and points the VirtualMachine at the ControllerRevisions of those generations.
+func (app *SubresourceAPIApp) UpgradeInstancetypeVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	bodyStruct := &v1.UpgradeInstancetypeOptions{}
+	if request.Request.Body != nil {
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(&bodyStruct)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf(unmarshalRequestErrFmt, err)), response)
+			return
+		}
+	}
+	dryRun := len(bodyStruct.DryRun) > 0 && bodyStruct.DryRun[0] == k8smetav1.DryRunAll
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	if vm.Spec.Instancetype == nil && vm.Spec.Preference == nil {
+		writeError(errors.NewBadRequest("VirtualMachine does not reference an instancetype or preference"), response)
+		return
+	}
+
+	upgradedVM := vm.DeepCopy()
+	if err := app.instancetypeMethods.UpgradeControllerRevisions(upgradedVM, dryRun); err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("unable to upgrade instancetype and preference: %v", err)), response)
+		return
+	}
+
+	if !dryRun {
+		revisionNamePatch, err := generateRevisionNameUpgradePatch(vm, upgradedVM)
+		if err != nil {
+			writeError(errors.NewInternalError(err), response)
+			return
+		}
+		if revisionNamePatch != nil {
+			upgradedVM, err = app.virtCli.VirtualMachine(namespace).Patch(context.Background(), name, types.JSONPatchType, revisionNamePatch, &k8smetav1.PatchOptions{})
+			if err != nil {
+				writeError(errors.NewInternalError(fmt.Errorf("unable to update the revision names of vm [%s]: %v", name, err)), response)
+				return
+			}
+		}
+	}
+
+	if err := response.WriteEntity(upgradedVM); err != nil {
+		log.Log.Reason(err).Error("Failed to write http response.")
+	}
+}
+
+// generateRevisionNameUpgradePatch replaces the revision names which changed during the upgrade, the test operations
+// make sure the VirtualMachine was not pointed at other revisions in the meantime.
+func generateRevisionNameUpgradePatch(vm, upgradedVM *v1.VirtualMachine) ([]byte, error) {
+	var patches []patch.PatchOperation
+
+	if vm.Spec.Instancetype != nil && vm.Spec.Instancetype.RevisionName != upgradedVM.Spec.Instancetype.RevisionName {
+		patches = append(patches, revisionNameUpgradePatch("/spec/instancetype/revisionName",
+			vm.Spec.Instancetype.RevisionName, upgradedVM.Spec.Instancetype.RevisionName)...)
+	}
+
+	if vm.Spec.Preference != nil && vm.Spec.Preference.RevisionName != upgradedVM.Spec.Preference.RevisionName {
+		patches = append(patches, revisionNameUpgradePatch("/spec/preference/revisionName",
+			vm.Spec.Preference.RevisionName, upgradedVM.Spec.Preference.RevisionName)...)
+	}
+
+	if len(patches) == 0 {
+		return nil, nil
+	}
+	return patch.GeneratePatchPayload(patches...)
+}
+
+func revisionNameUpgradePatch(path, oldRevisionName, newRevisionName string) []patch.PatchOperation {
+	var oldValue interface{}
+	if oldRevisionName != "" {
+		oldValue = oldRevisionName
+	}
+	return []patch.PatchOperation{
+		{
+			Op:    patch.PatchTestOp,
+			Path:  path,
+			Value: oldValue,
+		},
+		{
+			Op:    patch.PatchAddOp,
+			Path:  path,
+			Value: newRevisionName,
+		},
+	}
+}
@@ -0,0 +1,213 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubevirtcore "kubevirt.io/api/core"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Instancetype upgrade subresource", func() {
+	const (
+		vmName      = "test-vm"
+		vmNamespace = "test-namespace"
+
+		previousInstancetypeRevision = "previous-instancetype-revision"
+		latestInstancetypeRevision   = "latest-instancetype-revision"
+		latestPreferenceRevision     = "latest-preference-revision"
+	)
+
+	var (
+		vmClient            *kubecli.MockVirtualMachineInterface
+		virtClient          *kubecli.MockKubevirtClient
+		instancetypeMethods *testutils.MockInstancetypeMethods
+		app                 *SubresourceAPIApp
+
+		request  *restful.Request
+		recorder *httptest.ResponseRecorder
+		response *restful.Response
+
+		vm *v1.VirtualMachine
+	)
+
+	callUpgradeInstancetypeApi := func(options *v1.UpgradeInstancetypeOptions) *httptest.ResponseRecorder {
+		request.PathParameters()["name"] = vmName
+		request.PathParameters()["namespace"] = vmNamespace
+
+		if options != nil {
+			optionsJson, err := json.Marshal(options)
+			Expect(err).ToNot(HaveOccurred())
+			request.Request.Body = io.NopCloser(bytes.NewBuffer(optionsJson))
+		}
+
+		app.UpgradeInstancetypeVMRequestHandler(request, response)
+		return recorder
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		vmClient = kubecli.NewMockVirtualMachineInterface(ctrl)
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		virtClient.EXPECT().GeneratedKubeVirtClient().Return(fake.NewSimpleClientset()).AnyTimes()
+		virtClient.EXPECT().VirtualMachine(vmNamespace).Return(vmClient).AnyTimes()
+
+		instancetypeMethods = testutils.NewMockInstancetypeMethods()
+		instancetypeMethods.UpgradeControllerRevisionsFunc = func(vm *v1.VirtualMachine, _ bool) error {
+			if vm.Spec.Instancetype != nil {
+				vm.Spec.Instancetype.RevisionName = latestInstancetypeRevision
+			}
+			if vm.Spec.Preference != nil {
+				vm.Spec.Preference.RevisionName = latestPreferenceRevision
+			}
+			return nil
+		}
+
+		app = NewSubresourceAPIApp(virtClient, 0, nil, nil)
+		app.instancetypeMethods = instancetypeMethods
+
+		request = restful.NewRequest(&http.Request{})
+		recorder = httptest.NewRecorder()
+		response = restful.NewResponse(recorder)
+		response.SetRequestAccepts(restful.MIME_JSON)
+
+		vm = &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: vmNamespace,
+			},
+			Spec: v1.VirtualMachineSpec{
+				Instancetype: &v1.InstancetypeMatcher{
+					Name:         "instancetype",
+					RevisionName: previousInstancetypeRevision,
+				},
+				Template: &v1.VirtualMachineInstanceTemplateSpec{},
+			},
+		}
+		vmClient.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil).AnyTimes()
+	})
+
+	It("should point the VM at the latest revisions", func() {
+		vm.Spec.Preference = &v1.PreferenceMatcher{
+			Name: "preference",
+		}
+
+		expectedPatch := fmt.Sprintf(`[{"op":"test","path":"/spec/instancetype/revisionName","value":"%s"},`+
+			`{"op":"add","path":"/spec/instancetype/revisionName","value":"%s"},`+
+			`{"op":"test","path":"/spec/preference/revisionName","value":null},`+
+			`{"op":"add","path":"/spec/preference/revisionName","value":"%s"}]`,
+			previousInstancetypeRevision, latestInstancetypeRevision, latestPreferenceRevision)
+
+		upgradedVM := vm.DeepCopy()
+		upgradedVM.Spec.Instancetype.RevisionName = latestInstancetypeRevision
+		upgradedVM.Spec.Preference.RevisionName = latestPreferenceRevision
+		vmClient.EXPECT().Patch(context.Background(), vmName, types.JSONPatchType, []byte(expectedPatch), &metav1.PatchOptions{}).Return(upgradedVM, nil)
+
+		recorder := callUpgradeInstancetypeApi(&v1.UpgradeInstancetypeOptions{})
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		responseVM := &v1.VirtualMachine{}
+		Expect(json.NewDecoder(recorder.Body).Decode(responseVM)).To(Succeed())
+		Expect(responseVM).To(Equal(upgradedVM))
+	})
+
+	It("should not patch the VM on dry run", func() {
+		var dryRun bool
+		instancetypeMethods.UpgradeControllerRevisionsFunc = func(vm *v1.VirtualMachine, isDryRun bool) error {
+			dryRun = isDryRun
+			vm.Spec.Instancetype.RevisionName = latestInstancetypeRevision
+			return nil
+		}
+
+		recorder := callUpgradeInstancetypeApi(&v1.UpgradeInstancetypeOptions{DryRun: []string{metav1.DryRunAll}})
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(dryRun).To(BeTrue())
+
+		responseVM := &v1.VirtualMachine{}
+		Expect(json.NewDecoder(recorder.Body).Decode(responseVM)).To(Succeed())
+		Expect(responseVM.Spec.Instancetype.RevisionName).To(Equal(latestInstancetypeRevision))
+	})
+
+	It("should not patch the VM when it already uses the latest revisions", func() {
+		vm.Spec.Instancetype.RevisionName = latestInstancetypeRevision
+
+		recorder := callUpgradeInstancetypeApi(nil)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		responseVM := &v1.VirtualMachine{}
+		Expect(json.NewDecoder(recorder.Body).Decode(responseVM)).To(Succeed())
+		Expect(responseVM).To(Equal(vm))
+	})
+
+	It("should fail if the VM does not reference an instancetype or preference", func() {
+		vm.Spec.Instancetype = nil
+
+		recorder := callUpgradeInstancetypeApi(nil)
+		statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		Expect(statusErr.Status().Message).To(Equal("VirtualMachine does not reference an instancetype or preference"))
+	})
+
+	It("should fail if the upgrade fails", func() {
+		instancetypeMethods.UpgradeControllerRevisionsFunc = func(_ *v1.VirtualMachine, _ bool) error {
+			return fmt.Errorf("VM field conflicts with selected Instancetype")
+		}
+
+		recorder := callUpgradeInstancetypeApi(nil)
+		statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		Expect(statusErr.Status().Message).To(ContainSubstring("VM field conflicts with selected Instancetype"))
+	})
+
+	It("should fail if the VM does not exist", func() {
+		request.PathParameters()["name"] = "nonexistent-vm"
+		request.PathParameters()["namespace"] = vmNamespace
+
+		vmClient.EXPECT().Get(context.Background(), "nonexistent-vm", gomock.Any()).Return(nil, errors.NewNotFound(
+			schema.GroupResource{
+				Group:    kubevirtcore.GroupName,
+				Resource: "VirtualMachine",
+			},
+			"",
+		))
+
+		app.UpgradeInstancetypeVMRequestHandler(request, response)
+		statusErr := ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+		Expect(statusErr.Status().Message).To(Equal("virtualmachine.kubevirt.io \"nonexistent-vm\" not found"))
+	})
+})
@@ -42,7 +42,7 @@ func FuzzAdmitter(f *testing.F) {
 			gvk:     webhooks.VirtualMachineInstanceGroupVersionResource,
 			objType: &v1.VirtualMachineInstance{},
 			admit: func(config *virtconfig.ClusterConfig, request *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
-				adm := &admitters.VMICreateAdmitter{ClusterConfig: config}
+				adm := &admitters.VMICreateAdmitter{ClusterConfig: config, QuotaChecker: testutils.NewMockQuotaChecker()}
 				return adm.Admit(request)
 			},
 			fuzzFuncs: fuzzFuncs(withSyntaxErrors),
@@ -52,7 +52,7 @@ func FuzzAdmitter(f *testing.F) {
 			gvk:     webhooks.VirtualMachineInstanceGroupVersionResource,
 			objType: &v1.VirtualMachineInstance{},
 			admit: func(config *virtconfig.ClusterConfig, request *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
-				adm := &admitters.VMICreateAdmitter{ClusterConfig: config}
+				adm := &admitters.VMICreateAdmitter{ClusterConfig: config, QuotaChecker: testutils.NewMockQuotaChecker()}
 				return adm.Admit(request)
 			},
 			fuzzFuncs: fuzzFuncs(),
@@ -65,6 +65,7 @@ func FuzzAdmitter(f *testing.F) {
 				adm := &admitters.VMsAdmitter{
 					ClusterConfig:       config,
 					InstancetypeMethods: testutils.NewMockInstancetypeMethods(),
+					QuotaChecker:        testutils.NewMockQuotaChecker(),
 				}
 				return adm.Admit(request)
 			},
@@ -78,6 +79,7 @@ func FuzzAdmitter(f *testing.F) {
 				adm := &admitters.VMsAdmitter{
 					ClusterConfig:       config,
 					InstancetypeMethods: testutils.NewMockInstancetypeMethods(),
+					QuotaChecker:        testutils.NewMockQuotaChecker(),
 				}
 				return adm.Admit(request)
 			},
@@ -209,7 +209,8 @@ func (mutator *VMsMutator) setPreferenceStorageClassName(vm *v1.VirtualMachine,
 }
 
 func (mutator *VMsMutator) setDefaultInstancetypeKind(vm *v1.VirtualMachine) {
-	if vm.Spec.Instancetype == nil {
+	// The kind is provided by the inference, which is completed by the VM controller for containerDisks
+	if vm.Spec.Instancetype == nil || vm.Spec.Instancetype.InferFromVolume != "" {
 		return
 	}
 
@@ -219,7 +220,8 @@ func (mutator *VMsMutator) setDefaultInstancetypeKind(vm *v1.VirtualMachine) {
 }
 
 func (mutator *VMsMutator) setDefaultPreferenceKind(vm *v1.VirtualMachine) {
-	if vm.Spec.Preference == nil {
+	// The kind is provided by the inference, which is completed by the VM controller for containerDisks
+	if vm.Spec.Preference == nil || vm.Spec.Preference.InferFromVolume != "" {
 		return
 	}
 
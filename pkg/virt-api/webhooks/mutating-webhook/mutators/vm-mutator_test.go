@@ -30,11 +30,13 @@ import (
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
 	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/api/core/v1"
@@ -1816,6 +1818,222 @@ var _ = Describe("VirtualMachine Mutator", func() {
 			}))
 		})
 
+		It("should infer defaults from PersistentVolumeClaim annotations", func() {
+			vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+				InferFromVolume: inferVolumeName,
+			}
+			vm.Spec.Preference = &v1.PreferenceMatcher{
+				InferFromVolume: inferVolumeName,
+			}
+			pvcWithAnnotations := &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name:      "pvcWithAnnotations",
+					Namespace: vm.Namespace,
+					Annotations: map[string]string{
+						apiinstancetype.DefaultInstancetypeLabel:     defaultInferedNameFromPVC,
+						apiinstancetype.DefaultInstancetypeKindLabel: defaultInferedKindFromPVC,
+						apiinstancetype.DefaultPreferenceLabel:       defaultInferedNameFromPVC,
+						apiinstancetype.DefaultPreferenceKindLabel:   defaultInferedKindFromPVC,
+					},
+				},
+			}
+			_, err := virtClient.CoreV1().PersistentVolumeClaims(vm.Namespace).Create(context.Background(), pvcWithAnnotations, k8smetav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			vm.Spec.Template.Spec.Volumes = []v1.Volume{{
+				Name: inferVolumeName,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: pvcWithAnnotations.Name,
+						},
+					},
+				},
+			}}
+			vmSpec, _ := getVMSpecMetaFromResponse(rt.GOARCH)
+			Expect(vmSpec.Instancetype).To(Equal(&v1.InstancetypeMatcher{
+				Name: defaultInferedNameFromPVC,
+				Kind: defaultInferedKindFromPVC,
+			}))
+			Expect(vmSpec.Preference).To(Equal(&v1.PreferenceMatcher{
+				Name: defaultInferedNameFromPVC,
+				Kind: defaultInferedKindFromPVC,
+			}))
+		})
+
+		It("should leave inference from a ContainerDisk to the VM controller", func() {
+			vm.Spec.Template.Spec.Volumes = []v1.Volume{{
+				Name: inferVolumeName,
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{
+						Image: "quay.io/containerdisks/fedora:latest",
+					},
+				},
+			}}
+			vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+				InferFromVolume: inferVolumeName,
+			}
+			vm.Spec.Preference = &v1.PreferenceMatcher{
+				InferFromVolume: inferVolumeName,
+			}
+			vmSpec, _ := getVMSpecMetaFromResponse(rt.GOARCH)
+			Expect(vmSpec.Instancetype).To(Equal(&v1.InstancetypeMatcher{
+				InferFromVolume: inferVolumeName,
+			}))
+			Expect(vmSpec.Preference).To(Equal(&v1.PreferenceMatcher{
+				InferFromVolume: inferVolumeName,
+			}))
+		})
+
+		// The VM controller infers defaults from ContainerDisks with an ImageLabelsFetcher
+		Context("from a ContainerDisk with an ImageLabelsFetcher", func() {
+			const (
+				image                    = "quay.io/containerdisks/fedora:latest"
+				defaultInferedNameFromCD = "defaultInferedNameFromCD"
+				defaultInferedKindFromCD = "defaultInferedKindFromCD"
+			)
+
+			var fetcher *fakeImageLabelsFetcher
+
+			BeforeEach(func() {
+				fetcher = &fakeImageLabelsFetcher{
+					labels: map[string]string{
+						apiinstancetype.DefaultInstancetypeLabel:     defaultInferedNameFromCD,
+						apiinstancetype.DefaultInstancetypeKindLabel: defaultInferedKindFromCD,
+						apiinstancetype.DefaultPreferenceLabel:       defaultInferedNameFromCD,
+						apiinstancetype.DefaultPreferenceKindLabel:   defaultInferedKindFromCD,
+					},
+				}
+				mutator.InstancetypeMethods = &instancetype.InstancetypeMethods{Clientset: virtClient, ImageLabelsFetcher: fetcher}
+				vm.Spec.Template.Spec.Volumes = []v1.Volume{{
+					Name: inferVolumeName,
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{
+							Image: image,
+						},
+					},
+				}}
+			})
+
+			It("should infer defaults from the image labels", func() {
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					InferFromVolume: inferVolumeName,
+				}
+				vm.Spec.Preference = &v1.PreferenceMatcher{
+					InferFromVolume: inferVolumeName,
+				}
+				vmSpec, _ := getVMSpecMetaFromResponse(rt.GOARCH)
+				Expect(vmSpec.Instancetype).To(Equal(&v1.InstancetypeMatcher{
+					Name: defaultInferedNameFromCD,
+					Kind: defaultInferedKindFromCD,
+				}))
+				Expect(vmSpec.Preference).To(Equal(&v1.PreferenceMatcher{
+					Name: defaultInferedNameFromCD,
+					Kind: defaultInferedKindFromCD,
+				}))
+				Expect(fetcher.image).To(Equal(image))
+				Expect(fetcher.architecture).To(Equal(rt.GOARCH))
+				Expect(fetcher.pullSecret).To(BeNil())
+			})
+
+			It("should pass the image pull secret to the registry lookup", func() {
+				secret := &k8sv1.Secret{
+					ObjectMeta: k8smetav1.ObjectMeta{
+						Name:      "pull-secret",
+						Namespace: vm.Namespace,
+					},
+				}
+				_, err := virtClient.CoreV1().Secrets(vm.Namespace).Create(context.Background(), secret, k8smetav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				vm.Spec.Template.Spec.Volumes[0].ContainerDisk.ImagePullSecret = secret.Name
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					InferFromVolume: inferVolumeName,
+				}
+				vmSpec, _ := getVMSpecMetaFromResponse(rt.GOARCH)
+				Expect(vmSpec.Instancetype.Name).To(Equal(defaultInferedNameFromCD))
+				Expect(fetcher.pullSecret).ToNot(BeNil())
+				Expect(fetcher.pullSecret.Name).To(Equal(secret.Name))
+			})
+
+			It("should ignore a missing image pull secret when failures are ignored", func() {
+				vm.Spec.Template.Spec.Volumes[0].ContainerDisk.ImagePullSecret = "unknown-secret"
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					InferFromVolume:              inferVolumeName,
+					InferFromVolumeFailurePolicy: &ignoreInferFromVolumeFailure,
+				}
+				vmSpec, vmMeta := getVMSpecMetaFromResponse(rt.GOARCH)
+				Expect(vmSpec.Instancetype).To(BeNil())
+				Expect(vmMeta.Annotations).To(HaveKeyWithValue(apiinstancetype.InstancetypeInferenceFailureAnnotation, ContainSubstring("unable to get image pull secret unknown-secret")))
+			})
+
+			It("should ask for a namespaced grant when virt-controller may not get the image pull secret", func() {
+				k8sClient.Fake.PrependReactor("get", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, k8serrors.NewForbidden(k8sv1.Resource("secrets"), "pull-secret", fmt.Errorf("not allowed"))
+				})
+				vm.Spec.Template.Spec.Volumes[0].ContainerDisk.ImagePullSecret = "pull-secret"
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					InferFromVolume:              inferVolumeName,
+					InferFromVolumeFailurePolicy: &ignoreInferFromVolumeFailure,
+				}
+				vmSpec, vmMeta := getVMSpecMetaFromResponse(rt.GOARCH)
+				Expect(vmSpec.Instancetype).To(BeNil())
+				Expect(vmMeta.Annotations).To(HaveKeyWithValue(apiinstancetype.InstancetypeInferenceFailureAnnotation, ContainSubstring("grant the kubevirt-controller service account get on it with a Role")))
+				Expect(fetcher.image).To(BeEmpty())
+			})
+
+			DescribeTable("should fail to infer defaults", func(labels map[string]string, fetchErr error, expectedMessage string) {
+				fetcher.labels = labels
+				fetcher.err = fetchErr
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					InferFromVolume: inferVolumeName,
+				}
+				resp := admitVM(rt.GOARCH)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("unable to infer instancetype from volume %s", inferVolumeName))
+				Expect(resp.Result.Message).To(ContainSubstring(expectedMessage))
+			},
+				Entry("when the image has no labels", nil, nil,
+					fmt.Sprintf("unable to find required %s label on image %s", apiinstancetype.DefaultInstancetypeLabel, image),
+				),
+				Entry("when the registry lookup fails", nil, fmt.Errorf("connection refused"),
+					fmt.Sprintf("unable to look up labels of image %s: connection refused", image),
+				),
+			)
+
+			DescribeTable("should record the failure reason when ignoring a failed inference", func(instancetypeMatcher *v1.InstancetypeMatcher, preferenceMatcher *v1.PreferenceMatcher, annotation string) {
+				fetcher.labels = nil
+				vm.Spec.Instancetype = instancetypeMatcher
+				vm.Spec.Preference = preferenceMatcher
+				vmSpec, vmMeta := getVMSpecMetaFromResponse(rt.GOARCH)
+				Expect(vmSpec.Instancetype).To(BeNil())
+				Expect(vmSpec.Preference).To(BeNil())
+				Expect(vmMeta.Annotations).To(HaveKeyWithValue(annotation, ContainSubstring("unable to find required")))
+			},
+				Entry("for InstancetypeMatcher",
+					&v1.InstancetypeMatcher{
+						InferFromVolume:              inferVolumeName,
+						InferFromVolumeFailurePolicy: &ignoreInferFromVolumeFailure,
+					}, nil, apiinstancetype.InstancetypeInferenceFailureAnnotation,
+				),
+				Entry("for PreferenceMatcher",
+					nil, &v1.PreferenceMatcher{
+						InferFromVolume:              inferVolumeName,
+						InferFromVolumeFailurePolicy: &ignoreInferFromVolumeFailure,
+					}, apiinstancetype.PreferenceInferenceFailureAnnotation,
+				),
+			)
+
+			It("should remove the failure reason once the inference succeeds", func() {
+				vm.Annotations = map[string]string{
+					apiinstancetype.InstancetypeInferenceFailureAnnotation: "unable to find required label",
+				}
+				vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+					InferFromVolume: inferVolumeName,
+				}
+				_, vmMeta := getVMSpecMetaFromResponse(rt.GOARCH)
+				Expect(vmMeta.Annotations).ToNot(HaveKey(apiinstancetype.InstancetypeInferenceFailureAnnotation))
+			})
+		})
+
 		DescribeTable("When inference was successful", func(failurePolicy v1.InferFromVolumeFailurePolicy, expectMemoryCleared bool) {
 			By("Setting guest memory")
 			guestMemory := resource.MustParse("512Mi")
@@ -1931,3 +2149,18 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		)
 	})
 })
+
+type fakeImageLabelsFetcher struct {
+	labels       map[string]string
+	err          error
+	image        string
+	architecture string
+	pullSecret   *k8sv1.Secret
+}
+
+func (f *fakeImageLabelsFetcher) ImageLabels(image, architecture string, pullSecret *k8sv1.Secret) (map[string]string, error) {
+	f.image = image
+	f.architecture = architecture
+	f.pullSecret = pullSecret
+	return f.labels, f.err
+}
@@ -96,6 +96,14 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 			log.Log.V(4).Info("Add SEV-ES node label selector")
 			addNodeSelector(newVMI, v1.SEVESLabel)
 		}
+		if util.IsSEVSNPVMI(newVMI) {
+			log.Log.V(4).Info("Add SEV-SNP node label selector")
+			addNodeSelector(newVMI, v1.SEVSNPLabel)
+		}
+		if util.IsTDXVMI(newVMI) {
+			log.Log.V(4).Info("Add TDX node label selector")
+			addNodeSelector(newVMI, v1.TDXLabel)
+		}
 
 		// Add foreground finalizer
 		newVMI.Finalizers = append(newVMI.Finalizers, v1.VirtualMachineInstanceFinalizer)
@@ -1152,6 +1152,24 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 					},
 				},
 			}),
+		Entry("It should add SEV-SNP node label selector with SEV-SNP workload",
+			map[string]string{v1.NodeSchedulable: "true"},
+			map[string]string{
+				v1.NodeSchedulable: "true",
+				v1.SEVSNPLabel:     "",
+			},
+			&v1.LaunchSecurity{
+				SNP: &v1.SEVSNP{},
+			}),
+		Entry("It should add TDX node label selector with TDX workload",
+			map[string]string{v1.NodeSchedulable: "true"},
+			map[string]string{
+				v1.NodeSchedulable: "true",
+				v1.TDXLabel:        "",
+			},
+			&v1.LaunchSecurity{
+				TDX: &v1.TDX{},
+			}),
 	)
 
 	DescribeTable("evictionStrategy should match the", func(f func(*v1.VirtualMachineInstanceSpec) v1.EvictionStrategy) {
@@ -23,17 +23,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	unversionedvalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/labels"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 
+	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -44,16 +47,70 @@ type MigrationCreateAdmitter struct {
 	VirtClient    kubecli.KubevirtClient
 }
 
-func isMigratable(vmi *v1.VirtualMachineInstance) error {
+func isMigratable(vmi *v1.VirtualMachineInstance, migratedVolumes []v1.MigratedVolume) error {
 	for _, c := range vmi.Status.Conditions {
 		if c.Type == v1.VirtualMachineInstanceIsMigratable &&
 			c.Status == k8sv1.ConditionFalse {
+			// Volumes which are not shared can still be migrated by copying them to new claims
+			if c.Reason == v1.VirtualMachineInstanceReasonDisksNotMigratable && len(migratedVolumes) > 0 {
+				if err := isStorageLiveMigratable(vmi); err != nil {
+					return err
+				}
+				if err := areVolumesMigratable(vmi, migratedVolumes); err != nil {
+					return fmt.Errorf("Cannot migrate VMI, Reason: %s, Message: %s", c.Reason, err.Error())
+				}
+				continue
+			}
 			return fmt.Errorf("Cannot migrate VMI, Reason: %s, Message: %s", c.Reason, c.Message)
 		}
 	}
 	return nil
 }
 
+// isStorageLiveMigratable checks that nothing but its volumes prevents the VMI from being live migrated
+func isStorageLiveMigratable(vmi *v1.VirtualMachineInstance) error {
+	for _, c := range vmi.Status.Conditions {
+		if c.Type != v1.VirtualMachineInstanceIsStorageLiveMigratable {
+			continue
+		}
+		if c.Status == k8sv1.ConditionTrue {
+			return nil
+		}
+		return fmt.Errorf("Cannot migrate VMI, Reason: %s, Message: %s", c.Reason, c.Message)
+	}
+	return fmt.Errorf("Cannot migrate VMI, the %s condition is not reported yet", v1.VirtualMachineInstanceIsStorageLiveMigratable)
+}
+
+// areVolumesMigratable checks that every volume which is not copied to a new claim can be shared
+// between the source and the target of the migration
+func areVolumesMigratable(vmi *v1.VirtualMachineInstance, migratedVolumes []v1.MigratedVolume) error {
+	migrated := make(map[string]bool, len(migratedVolumes))
+	for _, migratedVolume := range migratedVolumes {
+		migrated[migratedVolume.VolumeName] = true
+	}
+	volumeStatusMap := make(map[string]v1.VolumeStatus, len(vmi.Status.VolumeStatus))
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		volumeStatusMap[volumeStatus.Name] = volumeStatus
+	}
+
+	for _, volume := range vmi.Spec.Volumes {
+		if migrated[volume.Name] {
+			continue
+		}
+		if volume.PersistentVolumeClaim != nil || volume.DataVolume != nil {
+			claimName := storagetypes.PVCNameFromVirtVolume(&volume)
+			volumeStatus, ok := volumeStatusMap[volume.Name]
+			if !ok || volumeStatus.PersistentVolumeClaimInfo == nil ||
+				!storagetypes.HasSharedAccessMode(volumeStatus.PersistentVolumeClaimInfo.AccessModes) {
+				return fmt.Errorf("PVC %s is not shared, live migration requires that all PVCs which are not migrated must be shared (using ReadWriteMany access mode)", claimName)
+			}
+		} else if volume.HostDisk != nil && (volume.HostDisk.Shared == nil || !*volume.HostDisk.Shared) {
+			return fmt.Errorf("cannot migrate VMI with non-shared HostDisk")
+		}
+	}
+	return nil
+}
+
 func EnsureNoMigrationConflict(virtClient kubecli.KubevirtClient, vmiName string, namespace string) error {
 	labelSelector, err := labels.Parse(fmt.Sprintf("%s in (%s)", v1.MigrationSelectorLabel, vmiName))
 	if err != nil {
@@ -92,6 +149,10 @@ func (admitter *MigrationCreateAdmitter) Admit(ar *admissionv1.AdmissionReview)
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	if len(migration.Spec.MigratedVolumes) > 0 && !admitter.ClusterConfig.VolumeMigrationEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("%s feature gate is not enabled", virtconfig.VolumeMigration))
+	}
+
 	vmi, err := admitter.VirtClient.VirtualMachineInstance(migration.Namespace).Get(context.Background(), migration.Spec.VMIName, &metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		// ensure VMI exists for the migration
@@ -105,12 +166,26 @@ func (admitter *MigrationCreateAdmitter) Admit(ar *admissionv1.AdmissionReview)
 		return webhookutils.ToAdmissionResponseError(fmt.Errorf("Cannot migrate VMI in finalized state."))
 	}
 
+	// Reject volumes which cannot be copied to the requested claims
+	causes, err = admitter.validateMigratedVolumes(k8sfield.NewPath("spec", "migratedVolumes"), migration.Spec.MigratedVolumes, vmi)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	} else if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
 	// Reject migration jobs for non-migratable VMIs
-	err = isMigratable(vmi)
+	err = isMigratable(vmi, migration.Spec.MigratedVolumes)
 	if err != nil {
 		return webhookutils.ToAdmissionResponseError(err)
 	}
 
+	// Reject additional node selectors which can never be satisfied together with the VMI's own constraints
+	causes = validateAddedNodeSelectorAgainstVMI(k8sfield.NewPath("spec", "addedNodeSelector"), migration.Spec.AddedNodeSelector, vmi)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
 	// Don't allow new migration jobs to be introduced when previous migration jobs
 	// are already in flight.
 	err = EnsureNoMigrationConflict(admitter.VirtClient, migration.Spec.VMIName, migration.Namespace)
@@ -161,5 +236,223 @@ func ValidateVirtualMachineInstanceMigrationSpec(field *k8sfield.Path, spec *v1.
 		})
 	}
 
+	for _, validationErr := range unversionedvalidation.ValidateLabels(spec.AddedNodeSelector, field.Child("addedNodeSelector")) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: validationErr.Error(),
+			Field:   validationErr.Field,
+		})
+	}
+
+	volumeNames := map[string]bool{}
+	destinationClaimNames := map[string]bool{}
+	for i, migratedVolume := range spec.MigratedVolumes {
+		volumeField := field.Child("migratedVolumes").Index(i)
+		if migratedVolume.VolumeName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "volumeName is missing",
+				Field:   volumeField.Child("volumeName").String(),
+			})
+		} else if volumeNames[migratedVolume.VolumeName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("volume %s is migrated more than once", migratedVolume.VolumeName),
+				Field:   volumeField.Child("volumeName").String(),
+			})
+		}
+		volumeNames[migratedVolume.VolumeName] = true
+
+		if migratedVolume.DestinationClaimName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "destinationClaimName is missing",
+				Field:   volumeField.Child("destinationClaimName").String(),
+			})
+		} else if destinationClaimNames[migratedVolume.DestinationClaimName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("claim %s is the destination of more than one volume", migratedVolume.DestinationClaimName),
+				Field:   volumeField.Child("destinationClaimName").String(),
+			})
+		}
+		destinationClaimNames[migratedVolume.DestinationClaimName] = true
+	}
+
 	return causes
 }
+
+func (admitter *MigrationCreateAdmitter) validateMigratedVolumes(field *k8sfield.Path, migratedVolumes []v1.MigratedVolume, vmi *v1.VirtualMachineInstance) ([]metav1.StatusCause, error) {
+	var causes []metav1.StatusCause
+
+	volumes := map[string]*v1.Volume{}
+	claimsInUse := map[string]bool{}
+	for i, volume := range vmi.Spec.Volumes {
+		volumes[volume.Name] = &vmi.Spec.Volumes[i]
+		if claimName := storagetypes.PVCNameFromVirtVolume(&volume); claimName != "" {
+			claimsInUse[claimName] = true
+		}
+	}
+	claimInfos := map[string]*v1.PersistentVolumeClaimInfo{}
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		claimInfos[volumeStatus.Name] = volumeStatus.PersistentVolumeClaimInfo
+	}
+
+	for i, migratedVolume := range migratedVolumes {
+		volumeField := field.Index(i)
+		volume, exists := volumes[migratedVolume.VolumeName]
+		if !exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %s does not exist on the VMI", migratedVolume.VolumeName),
+				Field:   volumeField.Child("volumeName").String(),
+			})
+			continue
+		}
+		if (volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.Hotpluggable) &&
+			(volume.DataVolume == nil || volume.DataVolume.Hotpluggable) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("volume %s must be a persistentVolumeClaim or a dataVolume which is not hotpluggable", migratedVolume.VolumeName),
+				Field:   volumeField.Child("volumeName").String(),
+			})
+			continue
+		}
+		if claimsInUse[migratedVolume.DestinationClaimName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("claim %s is already used by the VMI", migratedVolume.DestinationClaimName),
+				Field:   volumeField.Child("destinationClaimName").String(),
+			})
+			continue
+		}
+
+		pvc, err := admitter.VirtClient.CoreV1().PersistentVolumeClaims(vmi.Namespace).Get(context.Background(), migratedVolume.DestinationClaimName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("claim %s does not exist", migratedVolume.DestinationClaimName),
+				Field:   volumeField.Child("destinationClaimName").String(),
+			})
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		claimInfo := claimInfos[migratedVolume.VolumeName]
+		if claimInfo == nil {
+			continue
+		}
+		if storagetypes.IsPVCBlock(claimInfo.VolumeMode) != storagetypes.IsPVCBlock(pvc.Spec.VolumeMode) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("claim %s must have the same volume mode as the source of volume %s", migratedVolume.DestinationClaimName, migratedVolume.VolumeName),
+				Field:   volumeField.Child("destinationClaimName").String(),
+			})
+			continue
+		}
+		destinationSize, exists := pvc.Status.Capacity[k8sv1.ResourceStorage]
+		if !exists {
+			destinationSize = pvc.Spec.Resources.Requests[k8sv1.ResourceStorage]
+		}
+		if sourceSize, exists := claimInfo.Capacity[k8sv1.ResourceStorage]; exists && destinationSize.Cmp(sourceSize) < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("claim %s is smaller than the source of volume %s", migratedVolume.DestinationClaimName, migratedVolume.VolumeName),
+				Field:   volumeField.Child("destinationClaimName").String(),
+			})
+		}
+	}
+
+	return causes, nil
+}
+
+func validateAddedNodeSelectorAgainstVMI(field *k8sfield.Path, addedNodeSelector map[string]string, vmi *v1.VirtualMachineInstance) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	for key, value := range addedNodeSelector {
+		if vmiValue, exists := vmi.Spec.NodeSelector[key]; exists && vmiValue != value {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("addedNodeSelector %s=%s conflicts with the VMI node selector %s=%s", key, value, key, vmiValue),
+				Field:   field.Key(key).String(),
+			})
+		}
+	}
+
+	if vmi.Spec.Affinity == nil || vmi.Spec.Affinity.NodeAffinity == nil ||
+		vmi.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return causes
+	}
+
+	// NodeSelectorTerms are ORed, the added node selector is acceptable as long as one term can still be satisfied
+	terms := vmi.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return causes
+	}
+	for _, term := range terms {
+		if nodeSelectorTermAllowsLabels(term, addedNodeSelector) {
+			return causes
+		}
+	}
+
+	return append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "addedNodeSelector conflicts with the required node affinity of the VMI",
+		Field:   field.String(),
+	})
+}
+
+// nodeSelectorTermAllowsLabels checks if a node carrying the given labels could still match the term.
+// Only the expressions on keys which are part of the given labels are taken into account.
+func nodeSelectorTermAllowsLabels(term k8sv1.NodeSelectorTerm, nodeLabels map[string]string) bool {
+	for _, requirement := range term.MatchExpressions {
+		value, exists := nodeLabels[requirement.Key]
+		if !exists {
+			continue
+		}
+		if !nodeSelectorRequirementAllowsValue(requirement, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeSelectorRequirementAllowsValue(requirement k8sv1.NodeSelectorRequirement, value string) bool {
+	switch requirement.Operator {
+	case k8sv1.NodeSelectorOpIn:
+		return containsString(requirement.Values, value)
+	case k8sv1.NodeSelectorOpNotIn:
+		return !containsString(requirement.Values, value)
+	case k8sv1.NodeSelectorOpExists:
+		return true
+	case k8sv1.NodeSelectorOpDoesNotExist:
+		return false
+	case k8sv1.NodeSelectorOpGt, k8sv1.NodeSelectorOpLt:
+		if len(requirement.Values) != 1 {
+			return false
+		}
+		labelValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		requirementValue, err := strconv.ParseInt(requirement.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if requirement.Operator == k8sv1.NodeSelectorOpGt {
+			return labelValue > requirementValue
+		}
+		return labelValue < requirementValue
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
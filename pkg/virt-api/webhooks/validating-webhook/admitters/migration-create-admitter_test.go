@@ -28,8 +28,10 @@ import (
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 
 	"kubevirt.io/client-go/api"
 
@@ -330,5 +332,296 @@ var _ = Describe("Validating MigrationCreate Admitter", func() {
 				migrationCreateAdmitter.Admit,
 			),
 		)
+
+		Context("with addedNodeSelector", func() {
+			admitMigrationForVMI := func(vmi *v1.VirtualMachineInstance, addedNodeSelector map[string]string) *admissionv1.AdmissionResponse {
+				mockVMIClient.EXPECT().Get(context.Background(), vmi.Name, gomock.Any()).Return(vmi, nil).MaxTimes(1)
+
+				migration := v1.VirtualMachineInstanceMigration{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vmi.Namespace,
+					},
+					Spec: v1.VirtualMachineInstanceMigrationSpec{
+						VMIName:           vmi.Name,
+						AddedNodeSelector: addedNodeSelector,
+					},
+				}
+				migrationBytes, _ := json.Marshal(&migration)
+
+				ar := &admissionv1.AdmissionReview{
+					Request: &admissionv1.AdmissionRequest{
+						Resource: webhooks.MigrationGroupVersionResource,
+						Object: runtime.RawExtension{
+							Raw: migrationBytes,
+						},
+					},
+				}
+				return migrationCreateAdmitter.Admit(ar)
+			}
+
+			newNodeAffinity := func(terms ...k8sv1.NodeSelectorTerm) *k8sv1.Affinity {
+				return &k8sv1.Affinity{
+					NodeAffinity: &k8sv1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{
+							NodeSelectorTerms: terms,
+						},
+					},
+				}
+			}
+
+			newTerm := func(key string, operator k8sv1.NodeSelectorOperator, values ...string) k8sv1.NodeSelectorTerm {
+				return k8sv1.NodeSelectorTerm{
+					MatchExpressions: []k8sv1.NodeSelectorRequirement{
+						{Key: key, Operator: operator, Values: values},
+					},
+				}
+			}
+
+			It("should accept an addedNodeSelector which does not collide with the VMI", func() {
+				vmi := api.NewMinimalVMI("testvmi")
+				vmi.Spec.NodeSelector = map[string]string{"zone": "a"}
+
+				resp := admitMigrationForVMI(vmi, map[string]string{"kubernetes.io/hostname": "node02", "zone": "a"})
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
+			It("should reject an invalid addedNodeSelector label", func() {
+				vmi := api.NewMinimalVMI("testvmi")
+
+				resp := admitMigrationForVMI(vmi, map[string]string{"kubernetes.io/hostname": "not a valid value"})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes).To(HaveLen(1))
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.addedNodeSelector"))
+			})
+
+			It("should reject an addedNodeSelector conflicting with the VMI node selector", func() {
+				vmi := api.NewMinimalVMI("testvmi")
+				vmi.Spec.NodeSelector = map[string]string{"zone": "a"}
+
+				resp := admitMigrationForVMI(vmi, map[string]string{"zone": "b"})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes).To(HaveLen(1))
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.addedNodeSelector[zone]"))
+			})
+
+			DescribeTable("with a required node affinity on the VMI", func(affinity *k8sv1.Affinity, addedNodeSelector map[string]string, allowed bool) {
+				vmi := api.NewMinimalVMI("testvmi")
+				vmi.Spec.Affinity = affinity
+
+				resp := admitMigrationForVMI(vmi, addedNodeSelector)
+				Expect(resp.Allowed).To(Equal(allowed))
+			},
+				Entry("should accept a value matching an In expression",
+					newNodeAffinity(newTerm("zone", k8sv1.NodeSelectorOpIn, "a", "b")), map[string]string{"zone": "b"}, true),
+				Entry("should reject a value not matching an In expression",
+					newNodeAffinity(newTerm("zone", k8sv1.NodeSelectorOpIn, "a", "b")), map[string]string{"zone": "c"}, false),
+				Entry("should reject a value matching a NotIn expression",
+					newNodeAffinity(newTerm("zone", k8sv1.NodeSelectorOpNotIn, "a")), map[string]string{"zone": "a"}, false),
+				Entry("should reject a key required not to exist",
+					newNodeAffinity(newTerm("zone", k8sv1.NodeSelectorOpDoesNotExist)), map[string]string{"zone": "a"}, false),
+				Entry("should accept a value satisfying a Gt expression",
+					newNodeAffinity(newTerm("generation", k8sv1.NodeSelectorOpGt, "3")), map[string]string{"generation": "4"}, true),
+				Entry("should reject a value not satisfying a Lt expression",
+					newNodeAffinity(newTerm("generation", k8sv1.NodeSelectorOpLt, "3")), map[string]string{"generation": "4"}, false),
+				Entry("should accept if at least one term can be satisfied",
+					newNodeAffinity(newTerm("zone", k8sv1.NodeSelectorOpIn, "a"), newTerm("zone", k8sv1.NodeSelectorOpIn, "b")), map[string]string{"zone": "b"}, true),
+				Entry("should accept keys not mentioned by the affinity",
+					newNodeAffinity(newTerm("zone", k8sv1.NodeSelectorOpIn, "a")), map[string]string{"kubernetes.io/hostname": "node02"}, true),
+			)
+		})
+
+		Context("with migratedVolumes", func() {
+			var kubeClient *fake.Clientset
+
+			BeforeEach(func() {
+				kubeClient = fake.NewSimpleClientset()
+				virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+				enableFeatureGate(virtconfig.VolumeMigration)
+			})
+
+			AfterEach(func() {
+				disableFeatureGates()
+			})
+
+			addVolume := func(vmi *v1.VirtualMachineInstance, volumeName, claimName string, accessMode k8sv1.PersistentVolumeAccessMode) {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: volumeName,
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: claimName,
+							},
+						},
+					},
+				})
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+					Name: volumeName,
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						AccessModes: []k8sv1.PersistentVolumeAccessMode{accessMode},
+						Capacity: k8sv1.ResourceList{
+							k8sv1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				})
+			}
+
+			newVMIWithNonSharedVolume := func() *v1.VirtualMachineInstance {
+				vmi := api.NewMinimalVMI("testvmi")
+				addVolume(vmi, "disk0", "src-pvc", k8sv1.ReadWriteOnce)
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{
+						Type:   v1.VirtualMachineInstanceIsMigratable,
+						Status: k8sv1.ConditionFalse,
+						Reason: v1.VirtualMachineInstanceReasonDisksNotMigratable,
+					},
+					{
+						Type:   v1.VirtualMachineInstanceIsStorageLiveMigratable,
+						Status: k8sv1.ConditionTrue,
+					},
+				}
+				return vmi
+			}
+
+			createPVC := func(namespace, name, size string, volumeMode k8sv1.PersistentVolumeMode) {
+				pvc := &k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      name,
+					},
+					Spec: k8sv1.PersistentVolumeClaimSpec{
+						VolumeMode: &volumeMode,
+						Resources: k8sv1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{
+								k8sv1.ResourceStorage: resource.MustParse(size),
+							},
+						},
+					},
+				}
+				_, err := kubeClient.CoreV1().PersistentVolumeClaims(namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			admitMigrationWithVolumes := func(vmi *v1.VirtualMachineInstance, migratedVolumes ...v1.MigratedVolume) *admissionv1.AdmissionResponse {
+				mockVMIClient.EXPECT().Get(context.Background(), vmi.Name, gomock.Any()).Return(vmi, nil).MaxTimes(1)
+
+				migration := v1.VirtualMachineInstanceMigration{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vmi.Namespace,
+					},
+					Spec: v1.VirtualMachineInstanceMigrationSpec{
+						VMIName:         vmi.Name,
+						MigratedVolumes: migratedVolumes,
+					},
+				}
+				migrationBytes, _ := json.Marshal(&migration)
+
+				ar := &admissionv1.AdmissionReview{
+					Request: &admissionv1.AdmissionRequest{
+						Resource: webhooks.MigrationGroupVersionResource,
+						Object: runtime.RawExtension{
+							Raw: migrationBytes,
+						},
+					},
+				}
+				return migrationCreateAdmitter.Admit(ar)
+			}
+
+			It("should accept migrating a non-shared volume to an existing claim", func() {
+				vmi := newVMIWithNonSharedVolume()
+				createPVC(vmi.Namespace, "dst-pvc", "2Gi", k8sv1.PersistentVolumeFilesystem)
+
+				resp := admitMigrationWithVolumes(vmi, v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "dst-pvc"})
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
+			It("should reject migrated volumes if the feature gate is not enabled", func() {
+				disableFeatureGates()
+				vmi := newVMIWithNonSharedVolume()
+				createPVC(vmi.Namespace, "dst-pvc", "2Gi", k8sv1.PersistentVolumeFilesystem)
+
+				resp := admitMigrationWithVolumes(vmi, v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "dst-pvc"})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring(virtconfig.VolumeMigration))
+			})
+
+			DescribeTable("should reject migrated volumes if the VMI is not storage live migratable", func(conditions []v1.VirtualMachineInstanceCondition, expectedMessage string) {
+				vmi := newVMIWithNonSharedVolume()
+				vmi.Status.Conditions = append(vmi.Status.Conditions[:1], conditions...)
+				createPVC(vmi.Namespace, "dst-pvc", "2Gi", k8sv1.PersistentVolumeFilesystem)
+
+				resp := admitMigrationWithVolumes(vmi, v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "dst-pvc"})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring(expectedMessage))
+			},
+				Entry("because something else prevents the migration", []v1.VirtualMachineInstanceCondition{{
+					Type:   v1.VirtualMachineInstanceIsStorageLiveMigratable,
+					Status: k8sv1.ConditionFalse,
+					Reason: v1.VirtualMachineInstanceReasonHostDeviceNotMigratable,
+				}}, v1.VirtualMachineInstanceReasonHostDeviceNotMigratable),
+				Entry("because the condition is not reported", nil, string(v1.VirtualMachineInstanceIsStorageLiveMigratable)),
+			)
+
+			It("should reject the migration if a non-shared volume is not migrated", func() {
+				vmi := newVMIWithNonSharedVolume()
+				addVolume(vmi, "disk1", "other-pvc", k8sv1.ReadWriteOnce)
+				createPVC(vmi.Namespace, "dst-pvc", "2Gi", k8sv1.PersistentVolumeFilesystem)
+
+				resp := admitMigrationWithVolumes(vmi, v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "dst-pvc"})
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("other-pvc"))
+			})
+
+			It("should accept the migration if the volumes which are not migrated are shared", func() {
+				vmi := newVMIWithNonSharedVolume()
+				addVolume(vmi, "disk1", "other-pvc", k8sv1.ReadWriteMany)
+				createPVC(vmi.Namespace, "dst-pvc", "1Gi", k8sv1.PersistentVolumeFilesystem)
+
+				resp := admitMigrationWithVolumes(vmi, v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "dst-pvc"})
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
+			It("should reject the migration if the VMI is not migratable for another reason", func() {
+				vmi := newVMIWithNonSharedVolume()
+				vmi.Status.Conditions[0].Reason = v1.VirtualMachineInstanceReasonInterfaceNotMigratable
+				createPVC(vmi.Namespace, "dst-pvc", "2Gi", k8sv1.PersistentVolumeFilesystem)
+
+				resp := admitMigrationWithVolumes(vmi, v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "dst-pvc"})
+				Expect(resp.Allowed).To(BeFalse())
+			})
+
+			DescribeTable("should reject invalid migrated volumes", func(expectedField string, migratedVolumes ...v1.MigratedVolume) {
+				vmi := newVMIWithNonSharedVolume()
+				addVolume(vmi, "disk1", "other-pvc", k8sv1.ReadWriteMany)
+				createPVC(vmi.Namespace, "dst-pvc", "2Gi", k8sv1.PersistentVolumeFilesystem)
+				createPVC(vmi.Namespace, "small-pvc", "512Mi", k8sv1.PersistentVolumeFilesystem)
+				createPVC(vmi.Namespace, "block-pvc", "2Gi", k8sv1.PersistentVolumeBlock)
+
+				resp := admitMigrationWithVolumes(vmi, migratedVolumes...)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes).To(HaveLen(1))
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal(expectedField))
+			},
+				Entry("with a missing volume name", "spec.migratedVolumes[0].volumeName",
+					v1.MigratedVolume{DestinationClaimName: "dst-pvc"}),
+				Entry("with a missing destination claim name", "spec.migratedVolumes[0].destinationClaimName",
+					v1.MigratedVolume{VolumeName: "disk0"}),
+				Entry("with a volume migrated twice", "spec.migratedVolumes[1].volumeName",
+					v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "dst-pvc"},
+					v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "block-pvc"}),
+				Entry("with a destination claim used twice", "spec.migratedVolumes[1].destinationClaimName",
+					v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "dst-pvc"},
+					v1.MigratedVolume{VolumeName: "disk1", DestinationClaimName: "dst-pvc"}),
+				Entry("with a volume which does not exist on the VMI", "spec.migratedVolumes[0].volumeName",
+					v1.MigratedVolume{VolumeName: "unknown", DestinationClaimName: "dst-pvc"}),
+				Entry("with a destination claim which is used by the VMI", "spec.migratedVolumes[0].destinationClaimName",
+					v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "other-pvc"}),
+				Entry("with a destination claim which does not exist", "spec.migratedVolumes[0].destinationClaimName",
+					v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "unknown-pvc"}),
+				Entry("with a destination claim smaller than the source", "spec.migratedVolumes[0].destinationClaimName",
+					v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "small-pvc"}),
+				Entry("with a destination claim with a different volume mode", "spec.migratedVolumes[0].destinationClaimName",
+					v1.MigratedVolume{VolumeName: "disk0", DestinationClaimName: "block-pvc"}),
+			)
+		})
 	})
 })
@@ -21,6 +21,7 @@ package admitters
 
 import (
 	"fmt"
+	"net"
 
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
 
@@ -75,6 +76,149 @@ func validateInterfaceBinding(field *k8sfield.Path, spec *v1.VirtualMachineInsta
 	return causes
 }
 
+func validateInterfaceQoS(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.QoS == nil {
+			continue
+		}
+		qosField := field.Child("domain", "devices", "interfaces").Index(idx).Child("qos")
+		if iface.Bridge == nil && iface.Masquerade == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%q interface's QoS is supported only for bridge and masquerade bindings", iface.Name),
+				Field:   qosField.String(),
+			})
+			continue
+		}
+		causes = append(causes, validateInterfaceBandwidth(qosField.Child("inbound"), iface.QoS.Inbound)...)
+		causes = append(causes, validateInterfaceBandwidth(qosField.Child("outbound"), iface.QoS.Outbound)...)
+	}
+	return causes
+}
+
+func validateInterfaceBandwidth(field *k8sfield.Path, bandwidth *v1.InterfaceBandwidth) []metav1.StatusCause {
+	if bandwidth == nil {
+		return nil
+	}
+	var causes []metav1.StatusCause
+	if bandwidth.Average.Sign() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than zero", field.Child("average").String()),
+			Field:   field.Child("average").String(),
+		})
+	}
+	if bandwidth.Peak != nil && bandwidth.Peak.Cmp(bandwidth.Average) < 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not be lower than the average", field.Child("peak").String()),
+			Field:   field.Child("peak").String(),
+		})
+	}
+	if bandwidth.Burst != nil && bandwidth.Burst.Sign() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than zero", field.Child("burst").String()),
+			Field:   field.Child("burst").String(),
+		})
+	}
+	return causes
+}
+
+func validateInterfaceFirewall(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Firewall == nil {
+			continue
+		}
+		firewallField := field.Child("domain", "devices", "interfaces").Index(idx).Child("firewall")
+		if iface.Masquerade == nil && iface.Passt == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%q interface's firewall is supported only for masquerade and passt bindings", iface.Name),
+				Field:   firewallField.String(),
+			})
+			continue
+		}
+		if iface.Passt != nil && len(iface.Firewall.Ingress) > 0 && len(iface.Ports) == 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%q interface's ingress firewall rules require the ports forwarded by the passt binding to be listed", iface.Name),
+				Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("ports").String(),
+			})
+		}
+		for ruleIdx, rule := range iface.Firewall.Ingress {
+			causes = append(causes, validateFirewallRule(firewallField.Child("ingress").Index(ruleIdx), rule)...)
+		}
+		for ruleIdx, rule := range iface.Firewall.Egress {
+			causes = append(causes, validateFirewallRule(firewallField.Child("egress").Index(ruleIdx), rule)...)
+		}
+	}
+	return causes
+}
+
+func validateFirewallRule(field *k8sfield.Path, rule v1.FirewallRule) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if rule.Action != v1.FirewallActionAllow && rule.Action != v1.FirewallActionDeny {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be one of %s, %s", field.Child("action").String(), v1.FirewallActionAllow, v1.FirewallActionDeny),
+			Field:   field.Child("action").String(),
+		})
+	}
+	if rule.CIDR != "" {
+		if _, _, err := net.ParseCIDR(rule.CIDR); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be a valid CIDR", field.Child("cidr").String()),
+				Field:   field.Child("cidr").String(),
+			})
+		}
+	}
+	switch rule.Protocol {
+	case "", "TCP", "UDP", "SCTP", "ICMP":
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be one of TCP, UDP, SCTP, ICMP", field.Child("protocol").String()),
+			Field:   field.Child("protocol").String(),
+		})
+	}
+	if rule.Port == 0 {
+		if rule.EndPort != 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is required when the end port is set", field.Child("port").String()),
+				Field:   field.Child("port").String(),
+			})
+		}
+		return causes
+	}
+	if rule.Protocol != "TCP" && rule.Protocol != "UDP" && rule.Protocol != "SCTP" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is supported only with the TCP, UDP and SCTP protocols", field.Child("port").String()),
+			Field:   field.Child("port").String(),
+		})
+	}
+	if rule.Port < 1 || rule.Port > 65535 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be in range 1 to 65535", field.Child("port").String()),
+			Field:   field.Child("port").String(),
+		})
+	}
+	if rule.EndPort != 0 && (rule.EndPort < rule.Port || rule.EndPort > 65535) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be in range of the port to 65535", field.Child("endPort").String()),
+			Field:   field.Child("endPort").String(),
+		})
+	}
+	return causes
+}
+
 func hasInterfaceBindingMethod(iface v1.Interface) bool {
 	return iface.InterfaceBindingMethod.Bridge != nil ||
 		iface.InterfaceBindingMethod.Slirp != nil ||
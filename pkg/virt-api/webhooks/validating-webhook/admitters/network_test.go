@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -120,4 +121,170 @@ var _ = Describe("Validating VMI network spec", func() {
 		}}
 		Expect(validateInterfaceBinding(k8sfield.NewPath("fake"), &vm.Spec)).To(BeEmpty())
 	})
+
+	Context("network interface QoS", func() {
+		quantity := func(value string) *resource.Quantity {
+			q := resource.MustParse(value)
+			return &q
+		}
+
+		DescribeTable("is valid", func(binding v1.InterfaceBindingMethod, qos *v1.InterfaceQoS) {
+			vm := api.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "foo",
+				InterfaceBindingMethod: binding,
+				QoS:                    qos,
+			}}
+			Expect(validateInterfaceQoS(k8sfield.NewPath("fake"), &vm.Spec)).To(BeEmpty())
+		},
+			Entry("when it is not set", v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}, nil),
+			Entry("with bridge binding", v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				&v1.InterfaceQoS{Inbound: &v1.InterfaceBandwidth{Average: resource.MustParse("1Mi"), Peak: quantity("2Mi"), Burst: quantity("1Mi")}}),
+			Entry("with masquerade binding", v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+				&v1.InterfaceQoS{Outbound: &v1.InterfaceBandwidth{Average: resource.MustParse("1Mi"), Peak: quantity("1Mi")}}),
+			Entry("with both directions", v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				&v1.InterfaceQoS{Inbound: &v1.InterfaceBandwidth{Average: resource.MustParse("1Mi")}, Outbound: &v1.InterfaceBandwidth{Average: resource.MustParse("1Mi")}}),
+		)
+
+		DescribeTable("is not supported", func(binding v1.InterfaceBindingMethod) {
+			vm := api.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "foo",
+				InterfaceBindingMethod: binding,
+				QoS:                    &v1.InterfaceQoS{Inbound: &v1.InterfaceBandwidth{Average: resource.MustParse("1Mi")}},
+			}}
+			Expect(validateInterfaceQoS(k8sfield.NewPath("fake"), &vm.Spec)).To(
+				ConsistOf(metav1.StatusCause{
+					Type:    "FieldValueInvalid",
+					Message: "\"foo\" interface's QoS is supported only for bridge and masquerade bindings",
+					Field:   "fake.domain.devices.interfaces[0].qos",
+				}))
+		},
+			Entry("with slirp binding", v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}),
+			Entry("with SR-IOV binding", v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}),
+			Entry("with passt binding", v1.InterfaceBindingMethod{Passt: &v1.InterfacePasst{}}),
+			Entry("with macvtap binding", v1.InterfaceBindingMethod{Macvtap: &v1.InterfaceMacvtap{}}),
+			Entry("without an interface binding method", v1.InterfaceBindingMethod{}),
+		)
+
+		It("rejects invalid bandwidth values", func() {
+			vm := api.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "foo",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				QoS: &v1.InterfaceQoS{
+					Inbound:  &v1.InterfaceBandwidth{Average: resource.MustParse("0"), Burst: quantity("-1")},
+					Outbound: &v1.InterfaceBandwidth{Average: resource.MustParse("2Mi"), Peak: quantity("1Mi")},
+				},
+			}}
+			Expect(validateInterfaceQoS(k8sfield.NewPath("fake"), &vm.Spec)).To(ConsistOf(
+				metav1.StatusCause{
+					Type:    "FieldValueInvalid",
+					Message: "fake.domain.devices.interfaces[0].qos.inbound.average must be greater than zero",
+					Field:   "fake.domain.devices.interfaces[0].qos.inbound.average",
+				},
+				metav1.StatusCause{
+					Type:    "FieldValueInvalid",
+					Message: "fake.domain.devices.interfaces[0].qos.inbound.burst must be greater than zero",
+					Field:   "fake.domain.devices.interfaces[0].qos.inbound.burst",
+				},
+				metav1.StatusCause{
+					Type:    "FieldValueInvalid",
+					Message: "fake.domain.devices.interfaces[0].qos.outbound.peak must not be lower than the average",
+					Field:   "fake.domain.devices.interfaces[0].qos.outbound.peak",
+				},
+			))
+		})
+	})
+
+	Context("network interface firewall", func() {
+		DescribeTable("is valid", func(binding v1.InterfaceBindingMethod, ports []v1.Port, firewall *v1.InterfaceFirewall) {
+			vm := api.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "foo",
+				InterfaceBindingMethod: binding,
+				Ports:                  ports,
+				Firewall:               firewall,
+			}}
+			Expect(validateInterfaceFirewall(k8sfield.NewPath("fake"), &vm.Spec)).To(BeEmpty())
+		},
+			Entry("when it is not set", v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, nil, nil),
+			Entry("with masquerade binding", v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, nil,
+				&v1.InterfaceFirewall{
+					Ingress: []v1.FirewallRule{
+						{Action: v1.FirewallActionAllow, CIDR: "10.10.0.0/16", Protocol: "TCP", Port: 22},
+						{Action: v1.FirewallActionDeny},
+					},
+					Egress: []v1.FirewallRule{{Action: v1.FirewallActionDeny, CIDR: "fd10::/64", Protocol: "ICMP"}},
+				}),
+			Entry("with passt binding", v1.InterfaceBindingMethod{Passt: &v1.InterfacePasst{}}, []v1.Port{{Protocol: "UDP", Port: 5000}},
+				&v1.InterfaceFirewall{Ingress: []v1.FirewallRule{{Action: v1.FirewallActionAllow, Protocol: "UDP", Port: 5000, EndPort: 5010}}}),
+			Entry("with passt binding and only egress rules", v1.InterfaceBindingMethod{Passt: &v1.InterfacePasst{}}, nil,
+				&v1.InterfaceFirewall{Egress: []v1.FirewallRule{{Action: v1.FirewallActionDeny, Protocol: "ICMP"}}}),
+		)
+
+		It("requires the forwarded ports of a passt interface with ingress rules", func() {
+			vm := api.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "foo",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Passt: &v1.InterfacePasst{}},
+				Firewall:               &v1.InterfaceFirewall{Ingress: []v1.FirewallRule{{Action: v1.FirewallActionDeny}}},
+			}}
+			Expect(validateInterfaceFirewall(k8sfield.NewPath("fake"), &vm.Spec)).To(
+				ConsistOf(metav1.StatusCause{
+					Type:    "FieldValueRequired",
+					Message: "\"foo\" interface's ingress firewall rules require the ports forwarded by the passt binding to be listed",
+					Field:   "fake.domain.devices.interfaces[0].ports",
+				}))
+		})
+
+		DescribeTable("is not supported", func(binding v1.InterfaceBindingMethod) {
+			vm := api.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "foo",
+				InterfaceBindingMethod: binding,
+				Firewall:               &v1.InterfaceFirewall{Ingress: []v1.FirewallRule{{Action: v1.FirewallActionDeny}}},
+			}}
+			Expect(validateInterfaceFirewall(k8sfield.NewPath("fake"), &vm.Spec)).To(
+				ConsistOf(metav1.StatusCause{
+					Type:    "FieldValueInvalid",
+					Message: "\"foo\" interface's firewall is supported only for masquerade and passt bindings",
+					Field:   "fake.domain.devices.interfaces[0].firewall",
+				}))
+		},
+			Entry("with bridge binding", v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}),
+			Entry("with SR-IOV binding", v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}),
+			Entry("with macvtap binding", v1.InterfaceBindingMethod{Macvtap: &v1.InterfaceMacvtap{}}),
+		)
+
+		DescribeTable("rejects an invalid rule", func(rule v1.FirewallRule, expectedFields ...string) {
+			vm := api.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "foo",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+				Firewall:               &v1.InterfaceFirewall{Egress: []v1.FirewallRule{rule}},
+			}}
+			causes := validateInterfaceFirewall(k8sfield.NewPath("fake"), &vm.Spec)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(ConsistOf(expectedFields))
+		},
+			Entry("without an action", v1.FirewallRule{},
+				"fake.domain.devices.interfaces[0].firewall.egress[0].action"),
+			Entry("with an invalid CIDR", v1.FirewallRule{Action: v1.FirewallActionDeny, CIDR: "10.10.0.0"},
+				"fake.domain.devices.interfaces[0].firewall.egress[0].cidr"),
+			Entry("with an unsupported protocol", v1.FirewallRule{Action: v1.FirewallActionDeny, Protocol: "GRE"},
+				"fake.domain.devices.interfaces[0].firewall.egress[0].protocol"),
+			Entry("with a port without protocol", v1.FirewallRule{Action: v1.FirewallActionDeny, Port: 22},
+				"fake.domain.devices.interfaces[0].firewall.egress[0].port"),
+			Entry("with a port out of range", v1.FirewallRule{Action: v1.FirewallActionDeny, Protocol: "TCP", Port: 70000},
+				"fake.domain.devices.interfaces[0].firewall.egress[0].port"),
+			Entry("with an end port without port", v1.FirewallRule{Action: v1.FirewallActionDeny, Protocol: "TCP", EndPort: 22},
+				"fake.domain.devices.interfaces[0].firewall.egress[0].port"),
+			Entry("with an end port lower than the port", v1.FirewallRule{Action: v1.FirewallActionDeny, Protocol: "TCP", Port: 80, EndPort: 22},
+				"fake.domain.devices.interfaces[0].firewall.egress[0].endPort"),
+		)
+	})
 })
@@ -0,0 +1,186 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"kubevirt.io/api/core"
+	v1 "kubevirt.io/api/core/v1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
+
+	"kubevirt.io/kubevirt/pkg/pointer"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineBackup Admitter", func() {
+	config, _, kvInformer := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+
+	newBackup := func() *snapshotv1.VirtualMachineBackup {
+		return &snapshotv1.VirtualMachineBackup{
+			Spec: snapshotv1.VirtualMachineBackupSpec{
+				Source: corev1.TypedLocalObjectReference{
+					APIGroup: pointer.P(core.GroupName),
+					Kind:     "VirtualMachine",
+					Name:     "vm",
+				},
+				PVCName: "backup-pvc",
+			},
+		}
+	}
+
+	Context("With feature gate disabled", func() {
+		It("should reject anything", func() {
+			ar := createBackupAdmissionReview(newBackup(), nil)
+			resp := NewVMBackupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(Equal("IncrementalBackup feature gate not enabled"))
+		})
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						DeveloperConfiguration: &v1.DeveloperConfiguration{
+							FeatureGates: []string{virtconfig.IncrementalBackupGate},
+						},
+					},
+				},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{})
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := NewVMBackupAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		DescribeTable("should accept a valid backup", func(backupType *snapshotv1.BackupType) {
+			backup := newBackup()
+			backup.Spec.Type = backupType
+
+			resp := NewVMBackupAdmitter(config).Admit(createBackupAdmissionReview(backup, nil))
+			Expect(resp.Allowed).To(BeTrue())
+		},
+			Entry("without type", nil),
+			Entry("of type Full", pointer.P(snapshotv1.FullBackup)),
+			Entry("of type Incremental", pointer.P(snapshotv1.IncrementalBackup)),
+		)
+
+		DescribeTable("should reject", func(update func(*snapshotv1.VirtualMachineBackup), field string) {
+			backup := newBackup()
+			update(backup)
+
+			resp := NewVMBackupAdmitter(config).Admit(createBackupAdmissionReview(backup, nil))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			Entry("a missing apiGroup", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.Source.APIGroup = nil
+			}, "spec.source.apiGroup"),
+			Entry("an invalid apiGroup", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.Source.APIGroup = pointer.P("foo.bar")
+			}, "spec.source.apiGroup"),
+			Entry("an invalid kind", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.Source.Kind = "VirtualMachineInstance"
+			}, "spec.source.kind"),
+			Entry("a missing source name", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.Source.Name = ""
+			}, "spec.source.name"),
+			Entry("a missing pvcName", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.PVCName = ""
+			}, "spec.pvcName"),
+			Entry("an unknown type", func(b *snapshotv1.VirtualMachineBackup) {
+				b.Spec.Type = pointer.P(snapshotv1.BackupType("Differential"))
+			}, "spec.type"),
+		)
+
+		It("should reject spec update", func() {
+			oldBackup := newBackup()
+			backup := newBackup()
+			backup.Spec.PVCName = "other-pvc"
+
+			resp := NewVMBackupAdmitter(config).Admit(createBackupAdmissionReview(backup, oldBackup))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+
+		It("should allow metadata update", func() {
+			oldBackup := newBackup()
+			backup := newBackup()
+			backup.Finalizers = []string{"finalizer"}
+
+			resp := NewVMBackupAdmitter(config).Admit(createBackupAdmissionReview(backup, oldBackup))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+})
+
+func createBackupAdmissionReview(backup, oldBackup *snapshotv1.VirtualMachineBackup) *admissionv1.AdmissionReview {
+	bytes, _ := json.Marshal(backup)
+
+	ar := &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: "default",
+			Resource: metav1.GroupVersionResource{
+				Group:    "snapshot.kubevirt.io",
+				Resource: "virtualmachinebackups",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+
+	if oldBackup != nil {
+		oldBytes, _ := json.Marshal(oldBackup)
+		ar.Request.Operation = admissionv1.Update
+		ar.Request.OldObject = runtime.RawExtension{
+			Raw: oldBytes,
+		}
+	}
+
+	return ar
+}
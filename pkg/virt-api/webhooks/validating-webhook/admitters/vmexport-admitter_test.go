@@ -118,6 +118,14 @@ var _ = Describe("Validating VirtualMachineExport Admitter", func() {
 			}
 		}
 
+		createBlankVMBackupObjectRef := func() corev1.TypedLocalObjectReference {
+			return corev1.TypedLocalObjectReference{
+				APIGroup: &snapshotApiGroup,
+				Kind:     vmBackupKind,
+				Name:     "",
+			}
+		}
+
 		createBlankVMObjectRef := func() corev1.TypedLocalObjectReference {
 			return corev1.TypedLocalObjectReference{
 				APIGroup: &kubevirtApiGroup,
@@ -139,6 +147,7 @@ var _ = Describe("Validating VirtualMachineExport Admitter", func() {
 		},
 			Entry("persistent volume claim", createBlankPVCObjectRef, "PVC name must not be empty"),
 			Entry("virtual machine snapshot", createBlankVMSnapshotObjectRef, "VMSnapshot name must not be empty"),
+			Entry("virtual machine backup", createBlankVMBackupObjectRef, "VMBackup name must not be empty"),
 			Entry("virtual machine", createBlankVMObjectRef, "Virtual Machine name must not be empty"),
 		)
 
@@ -234,6 +243,7 @@ var _ = Describe("Validating VirtualMachineExport Admitter", func() {
 		},
 			Entry("persistent volume claim blank", "", pvc),
 			Entry("virtual machine snapshot", snapshotApiGroup, vmSnapshotKind),
+			Entry("virtual machine backup", snapshotApiGroup, vmBackupKind),
 			Entry("virtual machine", kubevirtApiGroup, vmKind),
 		)
 
@@ -254,6 +264,7 @@ var _ = Describe("Validating VirtualMachineExport Admitter", func() {
 		},
 			Entry("persistent volume claim", "invalid", pvc),
 			Entry("virtual machine snapshot", "invalid", vmSnapshotKind),
+			Entry("virtual machine backup", "invalid", vmBackupKind),
 			Entry("virtual machine", "invalid", vmKind),
 		)
 	})
@@ -46,6 +46,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/downwardmetrics"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/network/link"
+	"kubevirt.io/kubevirt/pkg/quota"
 	"kubevirt.io/kubevirt/pkg/storage/reservation"
 	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
@@ -71,6 +72,10 @@ const (
 	maxDNSNameservers     = 3
 	maxDNSSearchPaths     = 6
 	maxDNSSearchListChars = 256
+
+	// The MTU passed via DHCP option 26 has to be at least the minimum IPv4 MTU
+	minDHCPMTU = 68
+	maxDHCPMTU = 65535
 )
 
 var validInterfaceModels = map[string]*struct{}{"e1000": nil, "e1000e": nil, "ne2k_pci": nil, "pcnet": nil, "rtl8139": nil, v1.VirtIO: nil}
@@ -94,6 +99,7 @@ const (
 
 type VMICreateAdmitter struct {
 	ClusterConfig *virtconfig.ClusterConfig
+	QuotaChecker  quota.Checker
 }
 
 func (admitter *VMICreateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
@@ -122,6 +128,24 @@ func (admitter *VMICreateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	// The guest resources are accounted before the virt-launcher pod is created. They are reserved in the
+	// quota status right away, a dry run only checks them.
+	if admitter.ClusterConfig.VirtualMachineQuotaEnabled() {
+		var err error
+		if ar.Request.DryRun != nil && *ar.Request.DryRun {
+			err = admitter.QuotaChecker.Check(ar.Request.Namespace, vmi.Name, &vmi.Spec)
+		} else {
+			err = admitter.QuotaChecker.Reserve(ar.Request.Namespace, &vmi.Spec)
+		}
+		if err != nil {
+			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: err.Error(),
+				Field:   k8sfield.NewPath("spec").String(),
+			}})
+		}
+	}
+
 	reviewResponse := admissionv1.AdmissionResponse{}
 	reviewResponse.Allowed = true
 	return &reviewResponse
@@ -198,6 +222,8 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateNetworksAssignedToInterfaces(field, spec, networkInterfaceMap)...)
 	causes = append(causes, validateInterfaceStateValue(field, spec)...)
 	causes = append(causes, validateInterfaceBinding(field, spec)...)
+	causes = append(causes, validateInterfaceQoS(field, spec)...)
+	causes = append(causes, validateInterfaceFirewall(field, spec)...)
 
 	causes = append(causes, validateInputDevices(field, spec)...)
 	causes = append(causes, validateIOThreadsPolicy(field, spec)...)
@@ -228,6 +254,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateVSOCK(field, spec, config)...)
 	causes = append(causes, validatePersistentReservation(field, spec, config)...)
 	causes = append(causes, validatePersistentState(field, spec, config)...)
+	causes = append(causes, validateTPMStateEncryption(field, spec)...)
 	causes = append(causes, validateDownwardMetrics(field, spec, config)...)
 
 	return causes
@@ -263,7 +290,7 @@ func validateVirtualMachineInstanceSpecVolumeDisks(field *k8sfield.Path, spec *v
 
 	// Validate that volumes match disks and filesystems correctly
 	for idx, volume := range spec.Volumes {
-		if volume.MemoryDump != nil {
+		if volume.MemoryDump != nil || volume.Backup != nil {
 			continue
 		}
 		if _, matchingDiskExists := diskAndFilesystemNames[volume.Name]; !matchingDiskExists {
@@ -314,6 +341,10 @@ func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachi
 		}
 
 		causes = append(causes, validateDHCPNTPServersAreValidIPv4Addresses(field, iface, idx)...)
+		causes = append(causes, validateDHCPRoutes(field, iface, idx)...)
+		causes = append(causes, validateDHCPDNSServers(field, iface, idx)...)
+		causes = append(causes, validateDHCPSearchDomains(field, iface, idx)...)
+		causes = append(causes, validateDHCPMTU(field, iface, idx)...)
 	}
 	return networkInterfaceMap, causes, done
 }
@@ -378,6 +409,93 @@ func validateDHCPNTPServersAreValidIPv4Addresses(field *k8sfield.Path, iface v1.
 	return causes
 }
 
+func validateDHCPRoutes(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.DHCPOptions == nil {
+		return causes
+	}
+	for index, route := range iface.DHCPOptions.Routes {
+		routeField := field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions", "routes").Index(index)
+		if ip, _, err := net.ParseCIDR(route.Destination); err != nil || ip.To4() == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "The destination of a DHCP route must be a valid IPv4 CIDR.",
+				Field:   routeField.Child("destination").String(),
+			})
+		}
+		if net.ParseIP(route.Gateway).To4() == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "The gateway of a DHCP route must be a valid IPv4 address.",
+				Field:   routeField.Child("gateway").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateDHCPDNSServers(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.DHCPOptions == nil {
+		return causes
+	}
+	for index, ip := range iface.DHCPOptions.DNSServers {
+		if net.ParseIP(ip) == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "DNS servers must be a list of valid IP addresses.",
+				Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions", "dnsServers").Index(index).String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateDHCPSearchDomains(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.DHCPOptions == nil {
+		return causes
+	}
+	dhcpOptionsField := field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions")
+	if len(iface.DHCPOptions.SearchDomains) > maxDNSSearchPaths {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("must not have more than %v search domains", maxDNSSearchPaths),
+			Field:   dhcpOptionsField.Child("searchDomains").String(),
+		})
+	}
+	for index, search := range iface.DHCPOptions.SearchDomains {
+		for _, msg := range validation.IsDNS1123Subdomain(search) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: msg,
+				Field:   dhcpOptionsField.Child("searchDomains").Index(index).String(),
+			})
+		}
+	}
+	if iface.DHCPOptions.DomainName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(iface.DHCPOptions.DomainName) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: msg,
+				Field:   dhcpOptionsField.Child("domainName").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateDHCPMTU(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.DHCPOptions == nil || iface.DHCPOptions.MTU == 0 {
+		return causes
+	}
+	if iface.DHCPOptions.MTU < minDHCPMTU || iface.DHCPOptions.MTU > maxDHCPMTU {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("The DHCP MTU must be in range %d to %d.", minDHCPMTU, maxDHCPMTU),
+			Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions", "mtu").String(),
+		})
+	}
+	return causes
+}
+
 func validateDHCPPrivateOptionsWithinRange(field *k8sfield.Path, DHCPPrivateOption v1.DHCPPrivateOptions) (causes []metav1.StatusCause) {
 	if !(DHCPPrivateOption.Option >= 224 && DHCPPrivateOption.Option <= 254) {
 		causes = append(causes, metav1.StatusCause{
@@ -911,30 +1029,57 @@ func validateSoundDevices(field *k8sfield.Path, spec *v1.VirtualMachineInstanceS
 
 func validateLaunchSecurity(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
 	launchSecurity := spec.Domain.LaunchSecurity
-	if launchSecurity != nil && !config.WorkloadEncryptionSEVEnabled() {
+	if launchSecurity == nil {
+		return causes
+	}
+
+	technologies := 0
+	for _, set := range []bool{launchSecurity.SEV != nil, launchSecurity.SNP != nil, launchSecurity.TDX != nil} {
+		if set {
+			technologies++
+		}
+	}
+	if technologies > 1 {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "only one of sev, snp or tdx can be set",
+			Field:   field.Child("launchSecurity").String(),
+		})
+	}
+
+	// SEV-SNP shares the SEV device plugin and feature gate, TDX is gated separately
+	technology, featureGate, enabled := "SEV", virtconfig.WorkloadEncryptionSEV, config.WorkloadEncryptionSEVEnabled()
+	switch {
+	case launchSecurity.SNP != nil:
+		technology = "SEV-SNP"
+	case launchSecurity.TDX != nil:
+		technology, featureGate, enabled = "TDX", virtconfig.WorkloadEncryptionTDX, config.WorkloadEncryptionTDXEnabled()
+	}
+
+	if !enabled {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.WorkloadEncryptionSEV),
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", featureGate),
 			Field:   field.Child("launchSecurity").String(),
 		})
-	} else if launchSecurity != nil && launchSecurity.SEV != nil {
+	} else if technologies == 1 {
 		firmware := spec.Domain.Firmware
 		if firmware == nil || firmware.Bootloader == nil || firmware.Bootloader.EFI == nil {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: "SEV requires OVMF (UEFI)",
+				Message: fmt.Sprintf("%s requires OVMF (UEFI)", technology),
 				Field:   field.Child("launchSecurity").String(),
 			})
 		} else if firmware.Bootloader.EFI.SecureBoot == nil || *firmware.Bootloader.EFI.SecureBoot {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: "SEV does not work along with SecureBoot",
+				Message: fmt.Sprintf("%s does not work along with SecureBoot", technology),
 				Field:   field.Child("launchSecurity").String(),
 			})
 		}
 
 		startStrategy := spec.StartStrategy
-		if launchSecurity.SEV.Attestation != nil && (startStrategy == nil || *startStrategy != v1.StartStrategyPaused) {
+		if launchSecurity.SEV != nil && launchSecurity.SEV.Attestation != nil && (startStrategy == nil || *startStrategy != v1.StartStrategyPaused) {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf("SEV attestation requires VMI StartStrategy '%s'", v1.StartStrategyPaused),
@@ -946,7 +1091,7 @@ func validateLaunchSecurity(field *k8sfield.Path, spec *v1.VirtualMachineInstanc
 			if iface.BootOrder != nil {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("SEV does not work with bootable NICs: %s", iface.Name),
+					Message: fmt.Sprintf("%s does not work with bootable NICs: %s", technology, iface.Name),
 					Field:   field.Child("launchSecurity").String(),
 				})
 			}
@@ -2056,6 +2201,7 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 	serviceAccountVolumeCount := 0
 	downwardMetricVolumeCount := 0
 	memoryDumpVolumeCount := 0
+	backupVolumeCount := 0
 
 	for idx, volume := range volumes {
 		// verify name is unique
@@ -2135,6 +2281,10 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			memoryDumpVolumeCount++
 			volumeSourceSetCount++
 		}
+		if volume.Backup != nil {
+			backupVolumeCount++
+			volumeSourceSetCount++
+		}
 
 		if volumeSourceSetCount != 1 {
 			causes = append(causes, metav1.StatusCause{
@@ -2348,6 +2498,13 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			Field:   field.String(),
 		})
 	}
+	if backupVolumeCount > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must have max one backup volume set", field.String()),
+			Field:   field.String(),
+		})
+	}
 
 	return causes
 }
@@ -2608,11 +2765,100 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 				}
 			}
 		}
+
+		if disk.IOTune != nil {
+			causes = append(causes, validateDiskIOTune(field.Index(idx).Child("ioTune"), disk.IOTune)...)
+		}
 	}
 
 	return causes
 }
 
+func validateDiskIOTune(field *k8sfield.Path, ioTune *v1.DiskIOTune) (causes []metav1.StatusCause) {
+	causes = append(causes, validateDiskIOLimits(field.Child("total"), ioTune.Total)...)
+	causes = append(causes, validateDiskIOLimits(field.Child("read"), ioTune.Read)...)
+	causes = append(causes, validateDiskIOLimits(field.Child("write"), ioTune.Write)...)
+
+	if ioTune.Total == nil {
+		return causes
+	}
+	// The total limit can't be combined with a read or write limit of the same kind
+	for _, limits := range []struct {
+		name   string
+		limits *v1.DiskIOLimits
+	}{{"read", ioTune.Read}, {"write", ioTune.Write}} {
+		if limits.limits == nil {
+			continue
+		}
+		if ioTune.Total.BytesPerSecond != nil && limits.limits.BytesPerSecond != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can't be combined with %s", field.Child(limits.name, "bytesPerSecond").String(), field.Child("total", "bytesPerSecond").String()),
+				Field:   field.Child(limits.name, "bytesPerSecond").String(),
+			})
+		}
+		if ioTune.Total.IOPS != nil && limits.limits.IOPS != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can't be combined with %s", field.Child(limits.name, "iops").String(), field.Child("total", "iops").String()),
+				Field:   field.Child(limits.name, "iops").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateDiskIOLimits(field *k8sfield.Path, limits *v1.DiskIOLimits) (causes []metav1.StatusCause) {
+	if limits == nil {
+		return nil
+	}
+	if limits.BytesPerSecond != nil && limits.BytesPerSecond.Sign() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than zero", field.Child("bytesPerSecond").String()),
+			Field:   field.Child("bytesPerSecond").String(),
+		})
+	}
+	if limits.BurstBytesPerSecond != nil {
+		if limits.BytesPerSecond == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s requires %s", field.Child("burstBytesPerSecond").String(), field.Child("bytesPerSecond").String()),
+				Field:   field.Child("bytesPerSecond").String(),
+			})
+		} else if limits.BurstBytesPerSecond.Cmp(*limits.BytesPerSecond) < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be lower than %s", field.Child("burstBytesPerSecond").String(), field.Child("bytesPerSecond").String()),
+				Field:   field.Child("burstBytesPerSecond").String(),
+			})
+		}
+	}
+	if limits.IOPS != nil && *limits.IOPS <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than zero", field.Child("iops").String()),
+			Field:   field.Child("iops").String(),
+		})
+	}
+	if limits.BurstIOPS != nil {
+		if limits.IOPS == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s requires %s", field.Child("burstIOPS").String(), field.Child("iops").String()),
+				Field:   field.Child("iops").String(),
+			})
+		} else if *limits.BurstIOPS < *limits.IOPS {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be lower than %s", field.Child("burstIOPS").String(), field.Child("iops").String()),
+				Field:   field.Child("burstIOPS").String(),
+			})
+		}
+	}
+	return causes
+}
+
 // Rejects kernel boot defined with initrd/kernel path but without an image
 func validateKernelBoot(field *k8sfield.Path, kernelBoot *v1.KernelBoot) (causes []metav1.StatusCause) {
 	if kernelBoot == nil {
@@ -2758,6 +3004,31 @@ func validatePersistentState(field *k8sfield.Path, spec *v1.VirtualMachineInstan
 	return
 }
 
+func validateTPMStateEncryption(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	tpm := spec.Domain.Devices.TPM
+	if tpm == nil || tpm.Encryption == nil {
+		return
+	}
+
+	encryptionField := field.Child("domain", "devices", "tpm", "encryption")
+	if !backendstorage.HasPersistentTPMDevice(spec) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "TPM state encryption requires a persistent TPM device",
+			Field:   encryptionField.String(),
+		})
+	}
+	if tpm.Encryption.SecretRef == nil || tpm.Encryption.SecretRef.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "TPM state encryption requires a secret holding the key",
+			Field:   encryptionField.Child("secretRef", "name").String(),
+		})
+	}
+
+	return
+}
+
 func validateCPUHotplug(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if spec.Domain.CPU != nil && spec.Domain.CPU.MaxSockets != 0 {
 		if spec.Domain.CPU.Sockets > spec.Domain.CPU.MaxSockets {
@@ -70,7 +70,8 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		},
 	}
 	config, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(kv)
-	vmiCreateAdmitter := &VMICreateAdmitter{ClusterConfig: config}
+	quotaChecker := testutils.NewMockQuotaChecker()
+	vmiCreateAdmitter := &VMICreateAdmitter{ClusterConfig: config, QuotaChecker: quotaChecker}
 
 	dnsConfigTestOption := "test"
 	enableFeatureGate := func(featureGate string) {
@@ -149,6 +150,62 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		Expect(resp.Result.Message).To(ContainSubstring("no memory requested"))
 	})
 
+	It("should reject VMIs which exceed a VirtualMachineQuota", func() {
+		quotaErr := fmt.Errorf("exceeded VirtualMachineQuota: tenant, requested: vcpus=2, used: vcpus=9, limited: vcpus=10")
+		var namespace string
+		quotaChecker.ReserveFunc = func(ns string, _ *v1.VirtualMachineInstanceSpec) error {
+			namespace = ns
+			return quotaErr
+		}
+		defer func() { quotaChecker.ReserveFunc = testutils.NewMockQuotaChecker().ReserveFunc }()
+		enableFeatureGate(virtconfig.VirtualMachineQuotaGate)
+
+		vmi := api.NewMinimalVMI("testvmi")
+		vmiBytes, _ := json.Marshal(&vmi)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Namespace: "tenant",
+				Resource:  webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmiBytes,
+				},
+			},
+		}
+		resp := vmiCreateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Message).To(Equal(quotaErr.Error()))
+		Expect(namespace).To(Equal("tenant"))
+	})
+
+	It("should only check the VirtualMachineQuotas of a dry run", func() {
+		reserved := false
+		quotaChecker.ReserveFunc = func(_ string, _ *v1.VirtualMachineInstanceSpec) error {
+			reserved = true
+			return nil
+		}
+		defer func() { quotaChecker.ReserveFunc = testutils.NewMockQuotaChecker().ReserveFunc }()
+		enableFeatureGate(virtconfig.VirtualMachineQuotaGate)
+
+		vmi := api.NewMinimalVMI("testvmi")
+		vmiBytes, _ := json.Marshal(&vmi)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Namespace: "tenant",
+				Resource:  webhooks.VirtualMachineInstanceGroupVersionResource,
+				DryRun:    pointer.Bool(true),
+				Object: runtime.RawExtension{
+					Raw: vmiBytes,
+				},
+			},
+		}
+		resp := vmiCreateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(reserved).To(BeFalse())
+	})
+
 	It("should allow Clock without Timer", func() {
 		vmi := api.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Clock = &v1.Clock{
@@ -1977,6 +2034,56 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(HaveLen(2))
 		})
 
+		It("should accept valid DHCP routes, DNS servers, search domains and MTU", func() {
+			vmi := api.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces[0].DHCPOptions = &v1.DHCPOptions{
+				Routes:        []v1.DHCPRoute{{Destination: "192.168.100.0/24", Gateway: "10.0.2.254"}},
+				DNSServers:    []string{"10.0.2.53", "fd10::53"},
+				SearchDomains: []string{"example.com", "lab.example.com"},
+				DomainName:    "lab.example.com",
+				MTU:           1400,
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		DescribeTable("should reject invalid DHCP options", func(dhcpOptions *v1.DHCPOptions, expectedField string) {
+			vmi := api.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces[0].DHCPOptions = dhcpOptions
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(expectedField))
+		},
+			Entry("with an IPv6 route destination",
+				&v1.DHCPOptions{Routes: []v1.DHCPRoute{{Destination: "fd10::/64", Gateway: "10.0.2.254"}}},
+				"fake.domain.devices.interfaces[0].dhcpOptions.routes[0].destination"),
+			Entry("with an invalid route gateway",
+				&v1.DHCPOptions{Routes: []v1.DHCPRoute{{Destination: "192.168.100.0/24", Gateway: "gateway"}}},
+				"fake.domain.devices.interfaces[0].dhcpOptions.routes[0].gateway"),
+			Entry("with an invalid DNS server",
+				&v1.DHCPOptions{DNSServers: []string{"10.0.2.53", "dns"}},
+				"fake.domain.devices.interfaces[0].dhcpOptions.dnsServers[1]"),
+			Entry("with an invalid search domain",
+				&v1.DHCPOptions{SearchDomains: []string{"-example.com"}},
+				"fake.domain.devices.interfaces[0].dhcpOptions.searchDomains[0]"),
+			Entry("with too many search domains",
+				&v1.DHCPOptions{SearchDomains: []string{"a.com", "b.com", "c.com", "d.com", "e.com", "f.com", "g.com"}},
+				"fake.domain.devices.interfaces[0].dhcpOptions.searchDomains"),
+			Entry("with an invalid domain name",
+				&v1.DHCPOptions{DomainName: "Example_com"},
+				"fake.domain.devices.interfaces[0].dhcpOptions.domainName"),
+			Entry("with a too small MTU",
+				&v1.DHCPOptions{MTU: 67},
+				"fake.domain.devices.interfaces[0].dhcpOptions.mtu"),
+			Entry("with a too large MTU",
+				&v1.DHCPOptions{MTU: 65536},
+				"fake.domain.devices.interfaces[0].dhcpOptions.mtu"),
+		)
+
 		It("should accept valid DHCPPrivateOptions", func() {
 			vmi := api.NewMinimalVMI("testvm")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
@@ -3229,6 +3336,61 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Entry("enospace", v1.DiskErrorPolicyEnospace),
 		)
 
+		DescribeTable("should reject disk with invalid I/O limits", func(ioTune *v1.DiskIOTune, field, message string) {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", IOTune: ioTune, DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{}}})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(field))
+			Expect(causes[0].Message).To(Equal(message))
+		},
+			Entry("with zero bytes per second",
+				&v1.DiskIOTune{Total: &v1.DiskIOLimits{BytesPerSecond: kubevirtpointer.P(resource.MustParse("0"))}},
+				"fake[0].ioTune.total.bytesPerSecond", "fake[0].ioTune.total.bytesPerSecond must be greater than zero"),
+			Entry("with negative IOPS",
+				&v1.DiskIOTune{Read: &v1.DiskIOLimits{IOPS: kubevirtpointer.P(int64(-1))}},
+				"fake[0].ioTune.read.iops", "fake[0].ioTune.read.iops must be greater than zero"),
+			Entry("with burst bytes per second but without bytes per second",
+				&v1.DiskIOTune{Write: &v1.DiskIOLimits{BurstBytesPerSecond: kubevirtpointer.P(resource.MustParse("1Mi"))}},
+				"fake[0].ioTune.write.bytesPerSecond", "fake[0].ioTune.write.burstBytesPerSecond requires fake[0].ioTune.write.bytesPerSecond"),
+			Entry("with burst IOPS lower than IOPS",
+				&v1.DiskIOTune{Total: &v1.DiskIOLimits{IOPS: kubevirtpointer.P(int64(100)), BurstIOPS: kubevirtpointer.P(int64(50))}},
+				"fake[0].ioTune.total.burstIOPS", "fake[0].ioTune.total.burstIOPS must not be lower than fake[0].ioTune.total.iops"),
+			Entry("with total and read bytes per second",
+				&v1.DiskIOTune{
+					Total: &v1.DiskIOLimits{BytesPerSecond: kubevirtpointer.P(resource.MustParse("1Mi"))},
+					Read:  &v1.DiskIOLimits{BytesPerSecond: kubevirtpointer.P(resource.MustParse("1Mi"))},
+				},
+				"fake[0].ioTune.read.bytesPerSecond", "fake[0].ioTune.read.bytesPerSecond can't be combined with fake[0].ioTune.total.bytesPerSecond"),
+			Entry("with total and write IOPS",
+				&v1.DiskIOTune{
+					Total: &v1.DiskIOLimits{IOPS: kubevirtpointer.P(int64(100))},
+					Write: &v1.DiskIOLimits{IOPS: kubevirtpointer.P(int64(100))},
+				},
+				"fake[0].ioTune.write.iops", "fake[0].ioTune.write.iops can't be combined with fake[0].ioTune.total.iops"),
+		)
+
+		It("should accept a disk with valid I/O limits", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}},
+				IOTune: &v1.DiskIOTune{
+					Total: &v1.DiskIOLimits{
+						BytesPerSecond:      kubevirtpointer.P(resource.MustParse("10Mi")),
+						BurstBytesPerSecond: kubevirtpointer.P(resource.MustParse("20Mi")),
+					},
+					Read:  &v1.DiskIOLimits{IOPS: kubevirtpointer.P(int64(100)), BurstIOPS: kubevirtpointer.P(int64(200))},
+					Write: &v1.DiskIOLimits{IOPS: kubevirtpointer.P(int64(50))},
+				},
+			})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(BeEmpty())
+		})
+
 		It("should reject invalid SN characters", func() {
 			vmi := api.NewMinimalVMI("testvmi")
 			order := uint(1)
@@ -3982,6 +4144,65 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		})
 	})
 
+	Context("with SEV-SNP and TDX LaunchSecurity", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{
+						SecureBoot: pointer.Bool(false),
+					},
+				},
+			}
+		})
+
+		DescribeTable("should accept when the feature gate is enabled and OVMF is configured", func(launchSecurity *v1.LaunchSecurity, featureGate string) {
+			enableFeatureGate(featureGate)
+			vmi.Spec.Domain.LaunchSecurity = launchSecurity
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		},
+			Entry("SEV-SNP", &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}, virtconfig.WorkloadEncryptionSEV),
+			Entry("TDX", &v1.LaunchSecurity{TDX: &v1.TDX{}}, virtconfig.WorkloadEncryptionTDX),
+		)
+
+		DescribeTable("should reject when the feature gate is disabled", func(launchSecurity *v1.LaunchSecurity, enabledFeatureGate, requiredFeatureGate string) {
+			enableFeatureGate(enabledFeatureGate)
+			vmi.Spec.Domain.LaunchSecurity = launchSecurity
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring(fmt.Sprintf("%s feature gate is not enabled", requiredFeatureGate)))
+		},
+			Entry("SEV-SNP", &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}, virtconfig.WorkloadEncryptionTDX, virtconfig.WorkloadEncryptionSEV),
+			Entry("TDX", &v1.LaunchSecurity{TDX: &v1.TDX{}}, virtconfig.WorkloadEncryptionSEV, virtconfig.WorkloadEncryptionTDX),
+		)
+
+		DescribeTable("should reject when UEFI is not configured", func(launchSecurity *v1.LaunchSecurity, featureGate, message string) {
+			enableFeatureGate(featureGate)
+			vmi.Spec.Domain.LaunchSecurity = launchSecurity
+			vmi.Spec.Domain.Firmware = nil
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring(message))
+		},
+			Entry("SEV-SNP", &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}, virtconfig.WorkloadEncryptionSEV, "SEV-SNP requires OVMF"),
+			Entry("TDX", &v1.LaunchSecurity{TDX: &v1.TDX{}}, virtconfig.WorkloadEncryptionTDX, "TDX requires OVMF"),
+		)
+
+		It("should reject when more than one technology is requested", func() {
+			enableFeatureGate(virtconfig.WorkloadEncryptionSEV)
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
+				SEV: &v1.SEV{},
+				SNP: &v1.SEVSNP{},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(Equal("only one of sev, snp or tdx can be set"))
+		})
+	})
+
 	Context("with vsocks defined", func() {
 		var vmi *v1.VirtualMachineInstance
 		BeforeEach(func() {
@@ -4828,6 +5049,31 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 				Expect(causes).To(BeEmpty())
 			})
+			It("should accept vmi with encrypted persistent TPM state", func() {
+				addPersistentTPM()
+				vmi.Spec.Domain.Devices.TPM.Encryption = &v1.TPMStateEncryption{
+					SecretRef: &k8sv1.LocalObjectReference{Name: "tpm-key"},
+				}
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+			DescribeTable("should reject invalid TPM state encryption", func(tpm *v1.TPMDevice, field, message string) {
+				vmi.Spec.Domain.Devices.TPM = tpm
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(field))
+				Expect(causes[0].Message).To(Equal(message))
+			},
+				Entry("without a persistent TPM",
+					&v1.TPMDevice{Encryption: &v1.TPMStateEncryption{SecretRef: &k8sv1.LocalObjectReference{Name: "tpm-key"}}},
+					"fake.domain.devices.tpm.encryption", "TPM state encryption requires a persistent TPM device"),
+				Entry("without a key source",
+					&v1.TPMDevice{Persistent: pointer.BoolPtr(true), Encryption: &v1.TPMStateEncryption{}},
+					"fake.domain.devices.tpm.encryption.secretRef.name", "TPM state encryption requires a secret holding the key"),
+				Entry("with an empty secret name",
+					&v1.TPMDevice{Persistent: pointer.BoolPtr(true), Encryption: &v1.TPMStateEncryption{SecretRef: &k8sv1.LocalObjectReference{}}},
+					"fake.domain.devices.tpm.encryption.secretRef.name", "TPM state encryption requires a secret holding the key"),
+			)
 		})
 		Context("feature gate disabled", func() {
 			DescribeTable("should reject when the feature gate is disabled", func(persist func()) {
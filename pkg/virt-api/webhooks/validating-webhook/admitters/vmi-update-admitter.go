@@ -32,11 +32,14 @@ import (
 
 	v1 "kubevirt.io/api/core/v1"
 
+	"kubevirt.io/kubevirt/pkg/quota"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 )
 
 type VMIUpdateAdmitter struct {
 	ClusterConfig *virtconfig.ClusterConfig
+	QuotaChecker  quota.Checker
 }
 
 func (admitter *VMIUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
@@ -58,6 +61,9 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 			if hotplugResponse != nil {
 				return hotplugResponse
 			}
+			if quotaResponse := admitter.admitQuotaUpdate(ar, oldVMI, newVMI); quotaResponse != nil {
+				return quotaResponse
+			}
 		} else {
 			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 				{
@@ -77,14 +83,36 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 	return &reviewResponse
 }
 
+// admitQuotaUpdate reserves the guest resources which are added to a running VirtualMachineInstance, like
+// hotplugged CPUs or memory, in the VirtualMachineQuotas of its namespace. A dry run only checks them.
+func (admitter *VMIUpdateAdmitter) admitQuotaUpdate(ar *admissionv1.AdmissionReview, oldVMI, newVMI *v1.VirtualMachineInstance) *admissionv1.AdmissionResponse {
+	if !admitter.ClusterConfig.VirtualMachineQuotaEnabled() {
+		return nil
+	}
+	var err error
+	if ar.Request.DryRun != nil && *ar.Request.DryRun {
+		err = admitter.QuotaChecker.CheckUpdate(ar.Request.Namespace, &oldVMI.Spec, &newVMI.Spec)
+	} else {
+		err = admitter.QuotaChecker.ReserveUpdate(ar.Request.Namespace, &oldVMI.Spec, &newVMI.Spec)
+	}
+	if err != nil {
+		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: err.Error(),
+			Field:   k8sfield.NewPath("spec").String(),
+		}})
+	}
+	return nil
+}
+
 func getExpectedDisks(newVolumes []v1.Volume) int {
-	numMemoryDumpVolumes := 0
+	numDisklessVolumes := 0
 	for _, volume := range newVolumes {
-		if volume.MemoryDump != nil {
-			numMemoryDumpVolumes = numMemoryDumpVolumes + 1
+		if volume.MemoryDump != nil || volume.Backup != nil {
+			numDisklessVolumes = numDisklessVolumes + 1
 		}
 	}
-	return len(newVolumes) - numMemoryDumpVolumes
+	return len(newVolumes) - numDisklessVolumes
 }
 
 // admitHotplugStorage compares the old and new volumes and disks, and ensures that they match and are valid.
@@ -106,7 +134,7 @@ func admitHotplugStorage(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks
 	newDiskMap := getDiskMap(newDisks)
 	oldDiskMap := getDiskMap(oldDisks)
 
-	permanentAr := verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap, newDiskMap, oldDiskMap)
+	permanentAr := verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap, newDiskMap, oldDiskMap, getMigratedVolumes(oldPermanentVolumeMap, newVMI))
 	if permanentAr != nil {
 		return permanentAr
 	}
@@ -136,7 +164,7 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 					},
 				})
 			}
-			if v.MemoryDump == nil {
+			if v.MemoryDump == nil && v.Backup == nil {
 				if _, ok := newDisks[k]; !ok {
 					return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 						{
@@ -145,7 +173,7 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 						},
 					})
 				}
-				if !equality.Semantic.DeepEqual(newDisks[k], oldDisks[k]) {
+				if !equalDisksIgnoringIOTune(newDisks[k], oldDisks[k]) {
 					return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 						{
 							Type:    metav1.CauseTypeFieldValueInvalid,
@@ -155,8 +183,8 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 				}
 			}
 		} else {
-			// This is a new volume, ensure that the volume is either DV, PVC or memoryDumpVolume
-			if v.DataVolume == nil && v.PersistentVolumeClaim == nil && v.MemoryDump == nil {
+			// This is a new volume, ensure that the volume is either DV, PVC, memoryDumpVolume or backupVolume
+			if v.DataVolume == nil && v.PersistentVolumeClaim == nil && v.MemoryDump == nil && v.Backup == nil {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 					{
 						Type:    metav1.CauseTypeFieldValueInvalid,
@@ -164,7 +192,7 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 					},
 				})
 			}
-			if v.MemoryDump == nil {
+			if v.MemoryDump == nil && v.Backup == nil {
 				// Also ensure the matching new disk exists and is of type scsi
 				if _, ok := newDisks[k]; !ok {
 					return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
@@ -198,7 +226,22 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 	return nil
 }
 
-func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[string]v1.Volume, newDisks, oldDisks map[string]v1.Disk) *admissionv1.AdmissionResponse {
+// getMigratedVolumes returns the permanent volumes switched to their destination claims, once a volume migration succeeded
+func getMigratedVolumes(permanentVolumeMap map[string]v1.Volume, vmi *v1.VirtualMachineInstance) map[string]v1.Volume {
+	migratedVolumes := map[string]v1.Volume{}
+	migrationState := vmi.Status.MigrationState
+	if migrationState == nil || !migrationState.Completed || migrationState.Failed {
+		return migratedVolumes
+	}
+	for _, migratedVolume := range migrationState.MigratedVolumes {
+		if volume, ok := permanentVolumeMap[migratedVolume.VolumeName]; ok {
+			migratedVolumes[migratedVolume.VolumeName] = migrations.VolumeWithClaim(&volume, migratedVolume.DestinationClaimName)
+		}
+	}
+	return migratedVolumes
+}
+
+func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[string]v1.Volume, newDisks, oldDisks map[string]v1.Disk, migratedVolumes map[string]v1.Volume) *admissionv1.AdmissionResponse {
 	if len(newPermanentVolumeMap) != len(oldPermanentVolumeMap) {
 		// Removed one of the permanent volumes, reject admission.
 		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
@@ -220,7 +263,9 @@ func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[str
 				},
 			})
 		}
-		if !equality.Semantic.DeepEqual(v, oldPermanentVolumeMap[k]) {
+		migratedVolume, migrated := migratedVolumes[k]
+		if !equality.Semantic.DeepEqual(v, oldPermanentVolumeMap[k]) &&
+			!(migrated && equality.Semantic.DeepEqual(v, migratedVolume)) {
 			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 				{
 					Type:    metav1.CauseTypeFieldValueInvalid,
@@ -228,7 +273,7 @@ func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[str
 				},
 			})
 		}
-		if !equality.Semantic.DeepEqual(newDisks[k], oldDisks[k]) {
+		if !equalDisksIgnoringIOTune(newDisks[k], oldDisks[k]) {
 			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 				{
 					Type:    metav1.CauseTypeFieldValueInvalid,
@@ -240,6 +285,13 @@ func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[str
 	return nil
 }
 
+// equalDisksIgnoringIOTune compares the disks without their I/O limits, which can be changed on a running VMI
+func equalDisksIgnoringIOTune(newDisk, oldDisk v1.Disk) bool {
+	newDisk.IOTune = nil
+	oldDisk.IOTune = nil
+	return equality.Semantic.DeepEqual(newDisk, oldDisk)
+}
+
 func getDiskMap(disks []v1.Disk) map[string]v1.Disk {
 	newDiskMap := make(map[string]v1.Disk, 0)
 	for _, disk := range disks {
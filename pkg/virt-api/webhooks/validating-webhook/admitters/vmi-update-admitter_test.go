@@ -28,6 +28,7 @@ import (
 	"github.com/onsi/gomega/types"
 	admissionv1 "k8s.io/api/admission/v1"
 	authv1 "k8s.io/api/authentication/v1"
+	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,9 +38,11 @@ import (
 
 	v1 "kubevirt.io/api/core/v1"
 
+	kubevirtpointer "kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 )
 
@@ -58,8 +61,9 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 			Phase: v1.KubeVirtPhaseDeploying,
 		},
 	}
-	config, _, _ := testutils.NewFakeClusterConfigUsingKV(kv)
-	vmiUpdateAdmitter := &VMIUpdateAdmitter{config}
+	config, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(kv)
+	quotaChecker := testutils.NewMockQuotaChecker()
+	vmiUpdateAdmitter := &VMIUpdateAdmitter{ClusterConfig: config, QuotaChecker: quotaChecker}
 
 	DescribeTable("should reject documents containing unknown or missing fields for", func(data string, validationResult string, gvr metav1.GroupVersionResource, review func(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse) {
 		input := map[string]interface{}{}
@@ -424,6 +428,14 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		return res
 	}
 
+	makeDisksWithIOTune := func(indexes ...int) []v1.Disk {
+		res := makeDisks(indexes...)
+		for i := range res {
+			res[i].IOTune = &v1.DiskIOTune{Total: &v1.DiskIOLimits{IOPS: kubevirtpointer.P(int64(100))}}
+		}
+		return res
+	}
+
 	makeDisksNoVolume := func(indexes ...int) []v1.Disk {
 		res := make([]v1.Disk, 0)
 		for _, index := range indexes {
@@ -526,6 +538,13 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 			makeDisks(0, 1),
 			makeStatus(1, 0),
 			makeExpected("permanent disk volume-name-0, changed", "")),
+		Entry("Should accept if the I/O limits of the disks changed",
+			makeVolumes(0, 1),
+			makeVolumes(0, 1),
+			makeDisksWithIOTune(0, 1),
+			makeDisks(0, 1),
+			makeStatus(2, 1),
+			nil),
 		Entry("Should reject if a hotplug volume changed",
 			makeInvalidVolumes(2, 1),
 			makeVolumes(0, 1),
@@ -631,6 +650,66 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		Entry("Should reject regular user", "system:serviceaccount:someNamespace:someUser", BeFalse()),
 	)
 
+	DescribeTable("Switching migrated volumes to their destination claims", func(migrationState *v1.VirtualMachineInstanceMigrationState, expected types.GomegaMatcher) {
+		vmi := api.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.CPU = &v1.CPU{}
+		vmi.Spec.Volumes = makeVolumes(0, 1)
+		vmi.Spec.Domain.Devices.Disks = makeDisks(0, 1)
+		vmi.Status.VolumeStatus = makeStatus(2, 0)
+		vmi.Status.MigrationState = migrationState
+		updateVmi := vmi.DeepCopy()
+		updateVmi.Spec.Volumes[1].VolumeSource = v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "dst-pvc",
+				},
+			},
+		}
+
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "system:serviceaccount:kubevirt:" + components.ControllerServiceAccountName},
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: admissionv1.Update,
+			},
+		}
+		resp := vmiUpdateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(expected)
+	},
+		Entry("should allow the switch after a successful migration",
+			&v1.VirtualMachineInstanceMigrationState{
+				Completed:       true,
+				MigratedVolumes: []v1.StorageMigratedVolumeInfo{{VolumeName: "volume-name-1", SourceClaimName: "dv-name-1", DestinationClaimName: "dst-pvc"}},
+			},
+			BeTrue()),
+		Entry("should reject the switch after a failed migration",
+			&v1.VirtualMachineInstanceMigrationState{
+				Completed:       true,
+				Failed:          true,
+				MigratedVolumes: []v1.StorageMigratedVolumeInfo{{VolumeName: "volume-name-1", SourceClaimName: "dv-name-1", DestinationClaimName: "dst-pvc"}},
+			},
+			BeFalse()),
+		Entry("should reject the switch to a claim which is not the destination of the migration",
+			&v1.VirtualMachineInstanceMigrationState{
+				Completed:       true,
+				MigratedVolumes: []v1.StorageMigratedVolumeInfo{{VolumeName: "volume-name-1", SourceClaimName: "dv-name-1", DestinationClaimName: "other-pvc"}},
+			},
+			BeFalse()),
+		Entry("should reject the switch without volume migration",
+			&v1.VirtualMachineInstanceMigrationState{
+				Completed: true,
+			},
+			BeFalse()),
+	)
+
 	DescribeTable("Updates in CPU topology", func(oldCPUTopology, newCPUTopology *v1.CPU, expected types.GomegaMatcher) {
 		vmi := api.NewMinimalVMI("testvmi")
 		updateVmi := vmi.DeepCopy()
@@ -696,4 +775,70 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		resp := vmiUpdateAdmitter.Admit(ar)
 		Expect(resp.Allowed).To(BeFalse())
 	})
+
+	Context("with VirtualMachineQuotas", func() {
+		var reserved, checked [][]uint32
+
+		BeforeEach(func() {
+			reserved, checked = nil, nil
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.VirtualMachineQuotaGate}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+			DeferCleanup(testutils.UpdateFakeKubeVirtClusterConfig, kvInformer, kv)
+
+			quotaChecker.ReserveUpdateFunc = func(_ string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error {
+				reserved = append(reserved, []uint32{oldSpec.Domain.CPU.Sockets, newSpec.Domain.CPU.Sockets})
+				return fmt.Errorf("exceeded VirtualMachineQuota: tenant, requested: vcpus=1, used: vcpus=10, limited: vcpus=10")
+			}
+			quotaChecker.CheckUpdateFunc = func(_ string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error {
+				checked = append(checked, []uint32{oldSpec.Domain.CPU.Sockets, newSpec.Domain.CPU.Sockets})
+				return nil
+			}
+			DeferCleanup(func() {
+				mock := testutils.NewMockQuotaChecker()
+				quotaChecker.ReserveUpdateFunc, quotaChecker.CheckUpdateFunc = mock.ReserveUpdateFunc, mock.CheckUpdateFunc
+			})
+		})
+
+		newCPUHotplugReview := func(dryRun bool) *admissionv1.AdmissionReview {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 1, MaxSockets: 4}
+			updateVmi := vmi.DeepCopy()
+			updateVmi.Spec.Domain.CPU.Sockets = 2
+
+			newVMIBytes, _ := json.Marshal(&updateVmi)
+			oldVMIBytes, _ := json.Marshal(&vmi)
+			return &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UserInfo:  authv1.UserInfo{Username: "system:serviceaccount:kubevirt:" + components.ControllerServiceAccountName},
+					Namespace: "tenant",
+					Resource:  webhooks.VirtualMachineInstanceGroupVersionResource,
+					DryRun:    kubevirtpointer.P(dryRun),
+					Object: runtime.RawExtension{
+						Raw: newVMIBytes,
+					},
+					OldObject: runtime.RawExtension{
+						Raw: oldVMIBytes,
+					},
+					Operation: admissionv1.Update,
+				},
+			}
+		}
+
+		It("should reject a CPU hotplug which exceeds a VirtualMachineQuota", func() {
+			resp := vmiUpdateAdmitter.Admit(newCPUHotplugReview(false))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("exceeded VirtualMachineQuota: tenant"))
+			Expect(reserved).To(Equal([][]uint32{{1, 2}}))
+			Expect(checked).To(BeEmpty())
+		})
+
+		It("should only check the VirtualMachineQuotas of a dry run", func() {
+			resp := vmiUpdateAdmitter.Admit(newCPUHotplugReview(true))
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(checked).To(Equal([][]uint32{{1, 2}}))
+			Expect(reserved).To(BeEmpty())
+		})
+	})
 })
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	poolv1 "kubevirt.io/api/pool/v1alpha1"
@@ -116,6 +117,23 @@ func ValidateVMPoolSpec(ar *admissionv1.AdmissionReview, field *k8sfield.Path, p
 		})
 	}
 
+	causes = append(causes, validateVMPoolUpdateStrategy(field.Child("updateStrategy"), spec.UpdateStrategy)...)
+
+	if spec.ScaleInPolicy != nil {
+		switch spec.ScaleInPolicy.Order {
+		case "", poolv1.VirtualMachinePoolScaleInRandom, poolv1.VirtualMachinePoolScaleInOldest, poolv1.VirtualMachinePoolScaleInNewest:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("invalid scale-in order %s, supported orders are %s, %s and %s", spec.ScaleInPolicy.Order,
+					poolv1.VirtualMachinePoolScaleInRandom, poolv1.VirtualMachinePoolScaleInOldest, poolv1.VirtualMachinePoolScaleInNewest),
+				Field: field.Child("scaleInPolicy", "order").String(),
+			})
+		}
+	}
+
+	causes = append(causes, validateVMPoolAutoscaler(field.Child("autoscaler"), spec.Autoscaler)...)
+
 	if ar.Request.Operation == admissionv1.Update {
 		oldPool := &poolv1.VirtualMachinePool{}
 		if err := json.Unmarshal(ar.Request.OldObject.Raw, oldPool); err != nil {
@@ -135,3 +153,102 @@ func ValidateVMPoolSpec(ar *admissionv1.AdmissionReview, field *k8sfield.Path, p
 	}
 	return causes
 }
+
+func validateVMPoolUpdateStrategy(field *k8sfield.Path, strategy *poolv1.VirtualMachinePoolUpdateStrategy) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if strategy == nil {
+		return causes
+	}
+
+	switch strategy.Type {
+	case "", poolv1.VirtualMachinePoolProactiveUpdate, poolv1.VirtualMachinePoolOpportunisticUpdate, poolv1.VirtualMachinePoolLiveUpdate:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("invalid update strategy type %s, supported types are %s, %s and %s", strategy.Type,
+				poolv1.VirtualMachinePoolProactiveUpdate, poolv1.VirtualMachinePoolOpportunisticUpdate, poolv1.VirtualMachinePoolLiveUpdate),
+			Field: field.Child("type").String(),
+		})
+	}
+
+	if strategy.MaxUnavailable != nil {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(strategy.MaxUnavailable, 100, false)
+		if err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("invalid maxUnavailable: %v", err),
+				Field:   field.Child("maxUnavailable").String(),
+			})
+		} else if maxUnavailable <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "maxUnavailable must be greater than zero",
+				Field:   field.Child("maxUnavailable").String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+func validateVMPoolAutoscaler(field *k8sfield.Path, autoscaler *poolv1.VirtualMachinePoolAutoscaler) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if autoscaler == nil {
+		return causes
+	}
+
+	minReplicas := int32(1)
+	if autoscaler.MinReplicas != nil {
+		minReplicas = *autoscaler.MinReplicas
+		if minReplicas < 1 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "minReplicas must be greater than zero",
+				Field:   field.Child("minReplicas").String(),
+			})
+		}
+	}
+	if autoscaler.MaxReplicas < minReplicas {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("maxReplicas must be greater than or equal to minReplicas %d", minReplicas),
+			Field:   field.Child("maxReplicas").String(),
+		})
+	}
+
+	if autoscaler.TargetCPUUtilizationPercentage == nil && autoscaler.TargetMemoryUtilizationPercentage == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "at least one of targetCPUUtilizationPercentage and targetMemoryUtilizationPercentage must be set",
+			Field:   field.String(),
+		})
+	}
+	targets := []struct {
+		name  string
+		value *int32
+	}{
+		{"targetCPUUtilizationPercentage", autoscaler.TargetCPUUtilizationPercentage},
+		{"targetMemoryUtilizationPercentage", autoscaler.TargetMemoryUtilizationPercentage},
+	}
+	for _, target := range targets {
+		if target.value != nil && (*target.value <= 0 || *target.value > 100) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be between 1 and 100", target.name),
+				Field:   field.Child(target.name).String(),
+			})
+		}
+	}
+
+	if autoscaler.ScaleDownStabilizationSeconds != nil && *autoscaler.ScaleDownStabilizationSeconds < 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "scaleDownStabilizationSeconds must not be negative",
+			Field:   field.Child("scaleDownStabilizationSeconds").String(),
+		})
+	}
+
+	return causes
+}
@@ -27,6 +27,8 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/api/core/v1"
 	virtv1 "kubevirt.io/api/core/v1"
@@ -41,6 +43,7 @@ var _ = Describe("Validating Pool Admitter", func() {
 	poolAdmitter := &VMPoolAdmitter{ClusterConfig: config}
 
 	always := v1.RunStrategyAlways
+	zeroMaxUnavailable := intstr.FromString("0%")
 
 	DescribeTable("should reject documents containing unknown or missing fields for", func(data string, validationResult string, gvr metav1.GroupVersionResource, review func(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse) {
 		input := map[string]interface{}{}
@@ -130,6 +133,79 @@ var _ = Describe("Validating Pool Admitter", func() {
 			"spec.virtualMachineTemplate.spec.running",
 			"spec.selector",
 		}),
+		Entry("with invalid update strategy and scale-in policy", &poolv1.VirtualMachinePool{
+			Spec: poolv1.VirtualMachinePoolSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"match": "me"},
+				},
+				VirtualMachineTemplate: &poolv1.VirtualMachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"match": "me"},
+					},
+					Spec: v1.VirtualMachineSpec{
+						RunStrategy: &always,
+						Template: newVirtualMachineBuilder().
+							WithDisk(v1.Disk{
+								Name: "testdisk",
+							}).
+							WithVolume(v1.Volume{
+								Name: "testdisk",
+								VolumeSource: v1.VolumeSource{
+									ContainerDisk: testutils.NewFakeContainerDiskSource(),
+								},
+							}).
+							BuildTemplate(),
+					},
+				},
+				UpdateStrategy: &poolv1.VirtualMachinePoolUpdateStrategy{
+					Type:           "Unknown",
+					MaxUnavailable: &zeroMaxUnavailable,
+				},
+				ScaleInPolicy: &poolv1.VirtualMachinePoolScaleInPolicy{
+					Order: "Unknown",
+				},
+			},
+		}, []string{
+			"spec.updateStrategy.type",
+			"spec.updateStrategy.maxUnavailable",
+			"spec.scaleInPolicy.order",
+		}),
+		Entry("with invalid autoscaler", &poolv1.VirtualMachinePool{
+			Spec: poolv1.VirtualMachinePoolSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"match": "me"},
+				},
+				VirtualMachineTemplate: &poolv1.VirtualMachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"match": "me"},
+					},
+					Spec: v1.VirtualMachineSpec{
+						RunStrategy: &always,
+						Template: newVirtualMachineBuilder().
+							WithDisk(v1.Disk{
+								Name: "testdisk",
+							}).
+							WithVolume(v1.Volume{
+								Name: "testdisk",
+								VolumeSource: v1.VolumeSource{
+									ContainerDisk: testutils.NewFakeContainerDiskSource(),
+								},
+							}).
+							BuildTemplate(),
+					},
+				},
+				Autoscaler: &poolv1.VirtualMachinePoolAutoscaler{
+					MinReplicas:                       pointer.Int32(3),
+					MaxReplicas:                       2,
+					TargetMemoryUtilizationPercentage: pointer.Int32(120),
+					ScaleDownStabilizationSeconds:     pointer.Int32(-1),
+				},
+			},
+		}, []string{
+			"spec.autoscaler.maxReplicas",
+			"spec.autoscaler.targetMemoryUtilizationPercentage",
+			"spec.autoscaler.scaleDownStabilizationSeconds",
+		}),
 	)
 	It("should accept valid vm spec", func() {
 		pool := &poolv1.VirtualMachinePool{
@@ -0,0 +1,118 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	"kubevirt.io/api/quota"
+	quotav1alpha1 "kubevirt.io/api/quota/v1alpha1"
+
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var supportedQuotaResources = []string{
+	string(quotav1alpha1.ResourceVCPUs),
+	string(quotav1alpha1.ResourceGuestMemory),
+	string(quotav1alpha1.ResourceVirtualMachineInstances),
+}
+
+// VMQuotaAdmitter validates VirtualMachineQuotas
+type VMQuotaAdmitter struct {
+	Config *virtconfig.ClusterConfig
+}
+
+// NewVMQuotaAdmitter creates a VMQuotaAdmitter
+func NewVMQuotaAdmitter(config *virtconfig.ClusterConfig) *VMQuotaAdmitter {
+	return &VMQuotaAdmitter{
+		Config: config,
+	}
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMQuotaAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request.Resource.Group != quotav1alpha1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != quota.ResourceVMQuotaPlural {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == admissionv1.Create && !admitter.Config.VirtualMachineQuotaEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("%s feature gate not enabled", virtconfig.VirtualMachineQuotaGate))
+	}
+
+	vmQuota := &quotav1alpha1.VirtualMachineQuota{}
+	err := json.Unmarshal(ar.Request.Object.Raw, vmQuota)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	causes := validateVMQuotaSpec(k8sfield.NewPath("spec"), &vmQuota.Spec)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := admissionv1.AdmissionResponse{
+		Allowed: true,
+	}
+	return &reviewResponse
+}
+
+func validateVMQuotaSpec(field *k8sfield.Path, spec *quotav1alpha1.VirtualMachineQuotaSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	var names []string
+	for name := range spec.Hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		name := corev1.ResourceName(n)
+		quantity := spec.Hard[name]
+		hardField := field.Child("hard").Key(string(name))
+		switch name {
+		case quotav1alpha1.ResourceVCPUs, quotav1alpha1.ResourceGuestMemory, quotav1alpha1.ResourceVirtualMachineInstances:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("unsupported resource %s, supported resources are %v", name, supportedQuotaResources),
+				Field:   hardField.String(),
+			})
+			continue
+		}
+		if quantity.Sign() < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be negative", name),
+				Field:   hardField.String(),
+			})
+		}
+	}
+
+	return causes
+}
@@ -0,0 +1,131 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/api/core/v1"
+	quotav1alpha1 "kubevirt.io/api/quota/v1alpha1"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineQuota Admitter", func() {
+	config, _, kvInformer := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+
+	newQuota := func() *quotav1alpha1.VirtualMachineQuota {
+		return &quotav1alpha1.VirtualMachineQuota{
+			Spec: quotav1alpha1.VirtualMachineQuotaSpec{
+				Hard: corev1.ResourceList{
+					quotav1alpha1.ResourceVCPUs:                   resource.MustParse("10"),
+					quotav1alpha1.ResourceGuestMemory:             resource.MustParse("40Gi"),
+					quotav1alpha1.ResourceVirtualMachineInstances: resource.MustParse("5"),
+				},
+			},
+		}
+	}
+
+	Context("With feature gate disabled", func() {
+		It("should reject creation", func() {
+			resp := NewVMQuotaAdmitter(config).Admit(createVMQuotaAdmissionReview(newQuota()))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(Equal("VirtualMachineQuota feature gate not enabled"))
+		})
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						DeveloperConfiguration: &v1.DeveloperConfiguration{
+							FeatureGates: []string{virtconfig.VirtualMachineQuotaGate},
+						},
+					},
+				},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{})
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := NewVMQuotaAdmitter(config).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		It("should accept a valid quota", func() {
+			resp := NewVMQuotaAdmitter(config).Admit(createVMQuotaAdmissionReview(newQuota()))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		DescribeTable("should reject", func(name corev1.ResourceName, quantity string, causeType metav1.CauseType) {
+			vmQuota := newQuota()
+			vmQuota.Spec.Hard[name] = resource.MustParse(quantity)
+
+			resp := NewVMQuotaAdmitter(config).Admit(createVMQuotaAdmissionReview(vmQuota))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Type).To(Equal(causeType))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.hard[" + string(name) + "]"))
+		},
+			Entry("an unsupported resource", corev1.ResourceCPU, "4", metav1.CauseTypeFieldValueNotSupported),
+			Entry("a negative limit", quotav1alpha1.ResourceVCPUs, "-1", metav1.CauseTypeFieldValueInvalid),
+		)
+	})
+})
+
+func createVMQuotaAdmissionReview(vmQuota *quotav1alpha1.VirtualMachineQuota) *admissionv1.AdmissionReview {
+	bytes, _ := json.Marshal(vmQuota)
+
+	return &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: "default",
+			Resource: metav1.GroupVersionResource{
+				Group:    "quota.kubevirt.io",
+				Resource: "virtualmachinequotas",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
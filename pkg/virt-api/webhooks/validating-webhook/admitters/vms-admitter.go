@@ -45,6 +45,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/quota"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -59,6 +60,7 @@ type VMsAdmitter struct {
 	DataSourceInformer  cache.SharedIndexInformer
 	NamespaceInformer   cache.SharedIndexInformer
 	InstancetypeMethods instancetype.Methods
+	QuotaChecker        quota.Checker
 	ClusterConfig       *virtconfig.ClusterConfig
 	cloneAuthFunc       CloneAuthFunc
 }
@@ -104,6 +106,7 @@ func NewVMsAdmitter(clusterConfig *virtconfig.ClusterConfig, client kubecli.Kube
 		DataSourceInformer:  informers.DataSourceInformer,
 		NamespaceInformer:   informers.NamespaceInformer,
 		InstancetypeMethods: &instancetype.InstancetypeMethods{Clientset: client},
+		QuotaChecker:        quota.NewChecker(client, informers.VMQuotaInformer),
 		ClusterConfig:       clusterConfig,
 		cloneAuthFunc: func(dv *cdiv1.DataVolume, requestNamespace, requestName string, proxy cdiv1.AuthorizationHelperProxy, saNamespace, saName string) (bool, string, error) {
 			response, err := dv.AuthorizeSA(requestNamespace, requestName, proxy, saNamespace, saName)
@@ -193,20 +196,27 @@ func (admitter *VMsAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	var oldVM *v1.VirtualMachine
 	if ar.Request.Operation == admissionv1.Update {
-		oldVM := v1.VirtualMachine{}
-		if err := json.Unmarshal(ar.Request.OldObject.Raw, &oldVM); err != nil {
+		oldVM = &v1.VirtualMachine{}
+		if err := json.Unmarshal(ar.Request.OldObject.Raw, oldVM); err != nil {
 			return webhookutils.ToAdmissionResponseError(err)
 		}
 
 		if !equality.Semantic.DeepEqual(&oldVM.Spec, &vm.Spec) {
-			causes = admitter.validateVMUpdate(&oldVM, &vm)
+			causes = admitter.validateVMUpdate(oldVM, &vm)
 			if len(causes) > 0 {
 				return webhookutils.ToAdmissionResponse(causes)
 			}
 		}
 	}
 
+	// The copy is used as it has the instancetype applied, which provides the guest resources
+	causes = admitter.validateQuota(ar.Request.Namespace, oldVM, vmCopy)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
 	reviewResponse := admissionv1.AdmissionResponse{}
 	reviewResponse.Allowed = true
 
@@ -826,6 +836,57 @@ func validateSnapshotStatus(ar *admissionv1.AdmissionRequest, vm *v1.VirtualMach
 	return nil
 }
 
+// validateQuota rejects a request which starts the VirtualMachine, or adds guest resources to the running
+// VirtualMachine, when its guest resources exceed a VirtualMachineQuota of the namespace
+func (admitter *VMsAdmitter) validateQuota(namespace string, oldVM, vm *v1.VirtualMachine) []metav1.StatusCause {
+	if !admitter.ClusterConfig.VirtualMachineQuotaEnabled() {
+		return nil
+	}
+	if !requestsStart(vm) {
+		return nil
+	}
+
+	var err error
+	if oldVM != nil && requestsStart(oldVM) {
+		err = admitter.checkQuotaUpdate(namespace, oldVM, vm)
+	} else {
+		err = admitter.QuotaChecker.Check(namespace, vm.Name, &vm.Spec.Template.Spec)
+	}
+	if err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: err.Error(),
+			Field:   k8sfield.NewPath("spec").String(),
+		}}
+	}
+	return nil
+}
+
+// checkQuotaUpdate checks the guest resources which a change of the running VirtualMachine adds, e.g. by a live
+// update of its CPUs or memory. They are only reserved once virt-controller applies the change to the
+// VirtualMachineInstance, reserving them here as well would count them twice.
+func (admitter *VMsAdmitter) checkQuotaUpdate(namespace string, oldVM, vm *v1.VirtualMachine) error {
+	// If the old guest resources can not be determined, the change is still checked once it is applied
+	// to the VirtualMachineInstance
+	oldVMCopy := oldVM.DeepCopy()
+	if _, _, causes := admitter.applyInstancetypeToVm(oldVMCopy); len(causes) > 0 {
+		return nil
+	}
+	if webhooks.SetDefaultVirtualMachine(admitter.ClusterConfig, oldVMCopy) != nil {
+		return nil
+	}
+	return admitter.QuotaChecker.CheckUpdate(namespace, &oldVMCopy.Spec.Template.Spec, &vm.Spec.Template.Spec)
+}
+
+// requestsStart returns true if the run strategy of the VirtualMachine starts a VirtualMachineInstance
+func requestsStart(vm *v1.VirtualMachine) bool {
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return false
+	}
+	return runStrategy == v1.RunStrategyAlways || runStrategy == v1.RunStrategyRerunOnFailure || runStrategy == v1.RunStrategyOnce
+}
+
 func (admitter *VMsAdmitter) validateVMUpdate(oldVM, newVM *v1.VirtualMachine) []metav1.StatusCause {
 	if newVM.Status.Ready {
 		if !equality.Semantic.DeepEqual(&oldVM.Spec.LiveUpdateFeatures, &newVM.Spec.LiveUpdateFeatures) {
@@ -920,6 +981,11 @@ func (admitter *VMsAdmitter) shouldAllowMemoryHotPlug(vm *v1.VirtualMachine) err
 		return fmt.Errorf("cannot set less memory than what the guest booted with")
 	}
 
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.MaxGuest != nil &&
+		vm.Spec.Template.Spec.Domain.Memory.Guest.Cmp(*vmi.Spec.Domain.Memory.MaxGuest) > 0 {
+		return fmt.Errorf("cannot set more memory than the maximum guest memory (%s) the VM was started with", vmi.Spec.Domain.Memory.MaxGuest.String())
+	}
+
 	for _, c := range vmi.Status.Conditions {
 		if c.Type == v1.VirtualMachineInstanceMemoryChange &&
 			c.Status == corev1.ConditionTrue {
@@ -134,6 +134,7 @@ var _ = Describe("Validating VM Admitter", func() {
 			NamespaceInformer:   namespaceInformer,
 			ClusterConfig:       config,
 			InstancetypeMethods: instancetypeMethods,
+			QuotaChecker:        testutils.NewMockQuotaChecker(),
 			cloneAuthFunc: func(dv *cdiv1.DataVolume, requestNamespace, requestName string, proxy cdiv1.AuthorizationHelperProxy, saNamespace, saName string) (bool, string, error) {
 				return true, "", nil
 			},
@@ -1645,6 +1646,138 @@ var _ = Describe("Validating VM Admitter", func() {
 		}, false),
 	)
 
+	Context("VirtualMachineQuota", func() {
+		var (
+			vm         *v1.VirtualMachine
+			quotaErr   error
+			namespaces []string
+			names      []string
+			updates    [][]uint32
+		)
+
+		admitVmUpdate := func(oldVM, vm *v1.VirtualMachine) *admissionv1.AdmissionResponse {
+			oldVMBytes, err := json.Marshal(oldVM)
+			Expect(err).ToNot(HaveOccurred())
+			vmBytes, err := json.Marshal(vm)
+			Expect(err).ToNot(HaveOccurred())
+			return vmsAdmitter.Admit(&admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					Namespace: vm.Namespace,
+					Resource:  webhooks.VirtualMachineGroupVersionResource,
+					OldObject: runtime.RawExtension{Raw: oldVMBytes},
+					Object:    runtime.RawExtension{Raw: vmBytes},
+				},
+			})
+		}
+
+		BeforeEach(func() {
+			enableFeatureGate(virtconfig.VirtualMachineQuotaGate)
+			quotaErr = fmt.Errorf("exceeded VirtualMachineQuota: tenant")
+			namespaces, names, updates = nil, nil, nil
+			vmsAdmitter.QuotaChecker = &testutils.MockQuotaChecker{
+				CheckFunc: func(namespace, name string, _ *v1.VirtualMachineInstanceSpec) error {
+					namespaces = append(namespaces, namespace)
+					names = append(names, name)
+					return quotaErr
+				},
+				CheckUpdateFunc: func(namespace string, oldSpec, newSpec *v1.VirtualMachineInstanceSpec) error {
+					namespaces = append(namespaces, namespace)
+					updates = append(updates, []uint32{oldSpec.Domain.CPU.Sockets, newSpec.Domain.CPU.Sockets})
+					return quotaErr
+				},
+			}
+
+			vmi := api.NewMinimalVMI("testvmi")
+			vm = &v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testvm",
+					Namespace: "default",
+				},
+				Spec: v1.VirtualMachineSpec{
+					Running: &notRunning,
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: vmi.Spec,
+					},
+				},
+			}
+		})
+
+		It("should reject a VM which is created running when the quota is exceeded", func() {
+			vm.Spec.Running = pointer.P(true)
+			resp := admitVm(vmsAdmitter, vm)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("exceeded VirtualMachineQuota: tenant"))
+			Expect(names).To(ConsistOf(vm.Name))
+		})
+
+		AfterEach(func() {
+			disableFeatureGates()
+		})
+
+		It("should not check the quota with the feature gate disabled", func() {
+			disableFeatureGates()
+			vm.Spec.Running = pointer.P(true)
+			resp := admitVm(vmsAdmitter, vm)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(names).To(BeEmpty())
+		})
+
+		It("should not check the quota of a stopped VM", func() {
+			resp := admitVm(vmsAdmitter, vm)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(names).To(BeEmpty())
+		})
+
+		DescribeTable("should reject starting a VM when the quota is exceeded", func(runStrategy v1.VirtualMachineRunStrategy) {
+			oldVM := vm.DeepCopy()
+			vm.Spec.Running = nil
+			vm.Spec.RunStrategy = &runStrategy
+			resp := admitVmUpdate(oldVM, vm)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("exceeded VirtualMachineQuota: tenant"))
+		},
+			Entry("with RunStrategyAlways", v1.RunStrategyAlways),
+			Entry("with RunStrategyRerunOnFailure", v1.RunStrategyRerunOnFailure),
+			Entry("with RunStrategyOnce", v1.RunStrategyOnce),
+		)
+
+		It("should allow starting a VM within the quota", func() {
+			quotaErr = nil
+			oldVM := vm.DeepCopy()
+			vm.Spec.Running = pointer.P(true)
+			resp := admitVmUpdate(oldVM, vm)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(names).To(ConsistOf(vm.Name))
+			Expect(namespaces).To(ConsistOf(vm.Namespace))
+		})
+
+		It("should not check the full quota of a VM which is already running", func() {
+			quotaErr = nil
+			vm.Spec.Running = pointer.P(true)
+			oldVM := vm.DeepCopy()
+			vm.Labels = map[string]string{"updated": "true"}
+			resp := admitVmUpdate(oldVM, vm)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(names).To(BeEmpty())
+		})
+
+		It("should reject a live update of a running VM which exceeds the quota", func() {
+			vm.Spec.Running = pointer.P(true)
+			vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{Sockets: 1}
+			oldVM := vm.DeepCopy()
+			vm.Spec.Template.Spec.Domain.CPU.Sockets = 2
+			resp := admitVmUpdate(oldVM, vm)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("exceeded VirtualMachineQuota: tenant"))
+			Expect(updates).To(Equal([][]uint32{{1, 2}}))
+			Expect(namespaces).To(ConsistOf(vm.Namespace))
+			Expect(names).To(BeEmpty())
+		})
+	})
+
 	Context("Instancetype", func() {
 		var (
 			vm *v1.VirtualMachine
@@ -2340,6 +2473,17 @@ var _ = Describe("Validating VM Admitter", func() {
 					Field:   "spec.template.spec.domain.memory.guest",
 					Message: "cannot set less memory than what the guest booted with",
 				}),
+				Entry("trying to set more memory than the maximum guest memory of the running VMI", func(vm *v1.VirtualMachine, vmi *v1.VirtualMachineInstance) {
+					vmiMaxGuest := resource.MustParse("96Mi")
+					vmi.Spec.Domain.Memory = &v1.Memory{MaxGuest: &vmiMaxGuest}
+
+					newGuest := resource.MustParse("128Mi")
+					vm.Spec.Template.Spec.Domain.Memory.Guest = &newGuest
+				}, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Field:   "spec.template.spec.domain.memory.guest",
+					Message: "cannot set more memory than the maximum guest memory (96Mi) the VM was started with",
+				}),
 			)
 		})
 	})
@@ -493,5 +493,9 @@ func validateConfig(config *v1.KubeVirtConfiguration) error {
 		return fmt.Errorf("invalid default-network-interface in config: %v", config.NetworkConfiguration.NetworkInterface)
 	}
 
+	if mark := config.NetworkConfiguration.QoSTrafficMark; mark != nil && *mark == 0 {
+		return fmt.Errorf("invalid network.qosTrafficMark in config: the mark has to be non-zero")
+	}
+
 	return nil
 }
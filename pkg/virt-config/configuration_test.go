@@ -90,6 +90,19 @@ var _ = Describe("test configuration", func() {
 		Entry("when invalid, GetDefaultNetworkInterface should return the default", "invalid", "bridge"),
 	)
 
+	DescribeTable(" when qosTrafficMark", func(value *uint32, result uint32) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			NetworkConfiguration: &v1.NetworkConfiguration{
+				QoSTrafficMark: value,
+			},
+		})
+		Expect(clusterConfig.GetQoSTrafficMark()).To(Equal(result))
+	},
+		Entry("is set, GetQoSTrafficMark should return it", pointer.Uint32(0x100), uint32(0x100)),
+		Entry("when unset, GetQoSTrafficMark should return the default", nil, uint32(0x4b56)),
+		Entry("when zero, GetQoSTrafficMark should return the default", pointer.Uint32(0), uint32(0x4b56)),
+	)
+
 	DescribeTable(" when imagePullPolicy", func(value string, result kubev1.PullPolicy) {
 		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
 			ImagePullPolicy: kubev1.PullPolicy(value),
@@ -367,6 +380,16 @@ var _ = Describe("test configuration", func() {
 		Expect(result.BandwidthPerMigration.String()).To(Equal("0"))
 	})
 
+	DescribeTable("should return the backup timeout", func(timeout *int64, expected int64) {
+		clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			VMBackupTimeoutSeconds: timeout,
+		})
+		Expect(clusterConfig.GetVMBackupTimeoutSeconds()).To(Equal(expected))
+	},
+		Entry("defaulting to 12 hours", nil, int64(43200)),
+		Entry("from the config", pointer.Int64(600), int64(600)),
+	)
+
 	It("Should update the config if a newer version is available", func() {
 		oldValue := uint32(10)
 		clusterConfig, _, kvInformer := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
@@ -81,6 +81,18 @@ const (
 	//
 	// CommonInstancetypesDeploymentGate enables the deployment of common-instancetypes by virt-operator
 	CommonInstancetypesDeploymentGate = "CommonInstancetypesDeploymentGate"
+
+	// VolumeMigration enables copying the storage of running VMI volumes to new PersistentVolumeClaims during a live migration
+	VolumeMigration = "VolumeMigration"
+
+	// IncrementalBackupGate enables full and incremental VM backups based on QEMU dirty bitmaps and libvirt checkpoints
+	IncrementalBackupGate = "IncrementalBackup"
+
+	// VirtualMachineQuotaGate enables the enforcement of VirtualMachineQuotas on the guest resources of VMs and VMIs
+	VirtualMachineQuotaGate = "VirtualMachineQuota"
+
+	// WorkloadEncryptionTDX enables running VMIs as Intel TDX trust domains
+	WorkloadEncryptionTDX = "WorkloadEncryptionTDX"
 )
 
 var deprecatedFeatureGates = [...]string{
@@ -204,6 +216,10 @@ func (config *ClusterConfig) WorkloadEncryptionSEVEnabled() bool {
 	return config.isFeatureGateEnabled(WorkloadEncryptionSEV)
 }
 
+func (config *ClusterConfig) WorkloadEncryptionTDXEnabled() bool {
+	return config.isFeatureGateEnabled(WorkloadEncryptionTDX)
+}
+
 func (config *ClusterConfig) DockerSELinuxMCSWorkaroundEnabled() bool {
 	return config.isFeatureGateEnabled(DockerSELinuxMCSWorkaround)
 }
@@ -259,3 +275,15 @@ func (config *ClusterConfig) AutoResourceLimitsEnabled() bool {
 func (config *ClusterConfig) CommonInstancetypesDeploymentEnabled() bool {
 	return config.isFeatureGateEnabled(CommonInstancetypesDeploymentGate)
 }
+
+func (config *ClusterConfig) VolumeMigrationEnabled() bool {
+	return config.isFeatureGateEnabled(VolumeMigration)
+}
+
+func (config *ClusterConfig) IncrementalBackupEnabled() bool {
+	return config.isFeatureGateEnabled(IncrementalBackupGate)
+}
+
+func (config *ClusterConfig) VirtualMachineQuotaEnabled() bool {
+	return config.isFeatureGateEnabled(VirtualMachineQuotaGate)
+}
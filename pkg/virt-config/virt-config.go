@@ -52,8 +52,11 @@ const (
 	DefaultAARCH64EmulatedMachines                  = "virt*"
 	DefaultLessPVCSpaceToleration                   = 10
 	DefaultMinimumReservePVCBytes                   = 131072
+	DefaultVMStateStorageSize                       = "10Mi"
+	DefaultVMBackupTimeoutSeconds            int64  = 43200
 	DefaultNodeSelectors                            = ""
 	DefaultNetworkInterface                         = "bridge"
+	DefaultQoSTrafficMark                    uint32 = 0x4b56
 	DefaultImagePullPolicy                          = k8sv1.PullIfNotPresent
 	DefaultAllowEmulation                           = false
 	DefaultUnsafeMigrationOverride                  = false
@@ -189,6 +192,13 @@ func (c *ClusterConfig) GetDefaultNetworkInterface() string {
 	return c.GetConfig().NetworkConfiguration.NetworkInterface
 }
 
+func (c *ClusterConfig) GetQoSTrafficMark() uint32 {
+	if mark := c.GetConfig().NetworkConfiguration.QoSTrafficMark; mark != nil {
+		return *mark
+	}
+	return DefaultQoSTrafficMark
+}
+
 func (c *ClusterConfig) GetDefaultArchitecture() string {
 	return c.GetConfig().ArchitectureConfiguration.DefaultArchitecture
 }
@@ -426,10 +436,35 @@ func (c *ClusterConfig) GetDeveloperConfigurationUseEmulation() bool {
 	return false
 }
 
+func (c *ClusterConfig) GetInstancetypeInferenceRegistries() []string {
+	return c.GetConfig().InstancetypeInferenceRegistries
+}
+
 func (c *ClusterConfig) GetVMStateStorageClass() string {
 	return c.GetConfig().VMStateStorageClass
 }
 
+func (c *ClusterConfig) GetVMStateRetentionPolicy() v1.VMStateRetentionPolicy {
+	if policy := c.GetConfig().VMStateRetentionPolicy; policy != "" {
+		return policy
+	}
+	return v1.VMStateRetentionPolicyDelete
+}
+
+func (c *ClusterConfig) GetVMStateStorageSize() resource.Quantity {
+	if size := c.GetConfig().VMStateStorageSize; size != nil {
+		return *size
+	}
+	return resource.MustParse(DefaultVMStateStorageSize)
+}
+
+func (c *ClusterConfig) GetVMBackupTimeoutSeconds() int64 {
+	if timeout := c.GetConfig().VMBackupTimeoutSeconds; timeout != nil {
+		return *timeout
+	}
+	return DefaultVMBackupTimeoutSeconds
+}
+
 func (c *ClusterConfig) IsFreePageReportingDisabled() bool {
 	return c.GetConfig().VirtualMachineOptions != nil && c.GetConfig().VirtualMachineOptions.DisableFreePageReporting != nil
 }
@@ -126,15 +126,9 @@ func newMultusAnnotationData(namespace string, interfaces []v1.Interface, networ
 
 func NonDefaultMultusNetworksIndexedByIfaceName(pod *k8sv1.Pod) map[string]networkv1.NetworkStatus {
 	indexedNetworkStatus := map[string]networkv1.NetworkStatus{}
-	podNetworkStatus, found := pod.Annotations[networkv1.NetworkStatusAnnot]
-
-	if !found {
-		return indexedNetworkStatus
-	}
-
-	var networkStatus []networkv1.NetworkStatus
-	if err := json.Unmarshal([]byte(podNetworkStatus), &networkStatus); err != nil {
-		log.Log.Errorf("failed to unmarshall pod network status: %v", err)
+	networkStatus, err := multusNetworkStatus(pod)
+	if err != nil {
+		log.Log.Object(pod).Reason(err).Error("failed to read the pod network status")
 		return indexedNetworkStatus
 	}
 
@@ -147,3 +141,32 @@ func NonDefaultMultusNetworksIndexedByIfaceName(pod *k8sv1.Pod) map[string]netwo
 
 	return indexedNetworkStatus
 }
+
+// DefaultMultusNetworkStatus returns the status of the default network of the pod, as reported by multus
+func DefaultMultusNetworkStatus(pod *k8sv1.Pod) (*networkv1.NetworkStatus, error) {
+	networkStatus, err := multusNetworkStatus(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range networkStatus {
+		if networkStatus[i].Default {
+			return &networkStatus[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// multusNetworkStatus parses the network status multus reported on the pod
+func multusNetworkStatus(pod *k8sv1.Pod) ([]networkv1.NetworkStatus, error) {
+	podNetworkStatus, found := pod.Annotations[networkv1.NetworkStatusAnnot]
+	if !found {
+		return nil, nil
+	}
+
+	var networkStatus []networkv1.NetworkStatus
+	if err := json.Unmarshal([]byte(podNetworkStatus), &networkStatus); err != nil {
+		return nil, fmt.Errorf("failed to unmarshall pod network status: %v", err)
+	}
+	return networkStatus, nil
+}
@@ -372,9 +372,9 @@ func GetMemoryOverhead(vmi *v1.VirtualMachineInstance, cpuArch string, additiona
 
 	addProbeOverheads(vmi, &overhead)
 
-	// Consider memory overhead for SEV guests.
+	// Consider memory overhead for SEV, SEV-SNP and TDX guests.
 	// Additional information can be found here: https://libvirt.org/kbase/launch_security_sev.html#memory
-	if util.IsSEVVMI(vmi) {
+	if util.IsLaunchSecurityVMI(vmi) {
 		overhead.Add(resource.MustParse("256Mi"))
 	}
 
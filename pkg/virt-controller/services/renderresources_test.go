@@ -617,24 +617,21 @@ var _ = Describe("GetMemoryOverhead calculation", func() {
 		)
 	})
 
-	When("the vmi requests AMD SEV", func() {
-		BeforeEach(func() {
-			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
-				SEV: &v1.SEV{},
-			}
-		})
-
-		It("should add SEV overhead", func() {
-			expected := resource.NewScaledQuantity(0, resource.Kilo)
-			expected.Add(*baseOverhead)
-			expected.Add(*staticOverhead)
-			expected.Add(*videoRAMOverhead)
-			expected.Add(*coresOverhead)
-			expected.Add(*sevOverhead)
-			overhead := GetMemoryOverhead(vmi, "amd64", nil)
-			Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
-		})
-	})
+	DescribeTable("should add launch security overhead when the vmi requests", func(launchSecurity *v1.LaunchSecurity) {
+		vmi.Spec.Domain.LaunchSecurity = launchSecurity
+		expected := resource.NewScaledQuantity(0, resource.Kilo)
+		expected.Add(*baseOverhead)
+		expected.Add(*staticOverhead)
+		expected.Add(*videoRAMOverhead)
+		expected.Add(*coresOverhead)
+		expected.Add(*sevOverhead)
+		overhead := GetMemoryOverhead(vmi, "amd64", nil)
+		Expect(overhead.Value()).To(BeEquivalentTo(expected.Value()))
+	},
+		Entry("AMD SEV", &v1.LaunchSecurity{SEV: &v1.SEV{}}),
+		Entry("AMD SEV-SNP", &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}),
+		Entry("Intel TDX", &v1.LaunchSecurity{TDX: &v1.TDX{}}),
+	)
 
 	When("the vmi requests TPM device", func() {
 		BeforeEach(func() {
@@ -19,6 +19,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/network/sriov"
 	"kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/launchsecurity"
 	"kubevirt.io/kubevirt/pkg/virtiofs"
 )
 
@@ -332,6 +333,10 @@ func withAccessCredentials(accessCredentials []v1.AccessCredential) VolumeRender
 	}
 }
 
+func BackendStorageVolumeName(vmi *v1.VirtualMachineInstance) string {
+	return vmi.Name + "-state"
+}
+
 func PathForSwtpm(vmi *v1.VirtualMachineInstance) string {
 	swtpmPath := "/var/lib/libvirt/swtpm"
 	if util.IsNonRootVMI(vmi) {
@@ -365,7 +370,7 @@ func withBackendStorage(vmi *v1.VirtualMachineInstance) VolumeRendererOption {
 			return nil
 		}
 
-		volumeName := vmi.Name + "-state"
+		volumeName := BackendStorageVolumeName(vmi)
 		pvcName := backendstorage.PVCForVMI(vmi)
 		renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
 			Name: volumeName,
@@ -412,6 +417,22 @@ func withBackendStorage(vmi *v1.VirtualMachineInstance) VolumeRendererOption {
 			})
 		}
 
+		if backendstorage.HasEncryptedTPMState(&vmi.Spec) {
+			renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
+				Name: backendstorage.TPMEncryptionVolumeName,
+				VolumeSource: k8sv1.VolumeSource{
+					Secret: &k8sv1.SecretVolumeSource{
+						SecretName: vmi.Spec.Domain.Devices.TPM.Encryption.SecretRef.Name,
+					},
+				},
+			})
+			renderer.podVolumeMounts = append(renderer.podVolumeMounts, k8sv1.VolumeMount{
+				Name:      backendstorage.TPMEncryptionVolumeName,
+				MountPath: config.GetSecretSourcePath(backendstorage.TPMEncryptionVolumeName),
+				ReadOnly:  true,
+			})
+		}
+
 		if backendstorage.HasPersistentEFI(&vmi.Spec) {
 			renderer.podVolumeMounts = append(renderer.podVolumeMounts, k8sv1.VolumeMount{
 				Name:      volumeName,
@@ -446,6 +467,27 @@ func withVirioFS() VolumeRendererOption {
 	}
 }
 
+func withTDXQuoteGenerationService() VolumeRendererOption {
+	return func(renderer *VolumeRenderer) error {
+		// The host QGS socket lets TDX guests turn their TD reports into attestation quotes
+		hostPathType := k8sv1.HostPathDirectory
+		renderer.podVolumes = append(renderer.podVolumes, k8sv1.Volume{
+			Name: "tdx-qgs",
+			VolumeSource: k8sv1.VolumeSource{
+				HostPath: &k8sv1.HostPathVolumeSource{
+					Path: launchsecurity.TDXQuoteGenerationDir,
+					Type: &hostPathType,
+				},
+			},
+		})
+		renderer.podVolumeMounts = append(renderer.podVolumeMounts, k8sv1.VolumeMount{
+			Name:      "tdx-qgs",
+			MountPath: launchsecurity.TDXQuoteGenerationDir,
+		})
+		return nil
+	}
+}
+
 func withHugepages() VolumeRendererOption {
 	return func(renderer *VolumeRenderer) error {
 		hugepagesBasePath := "/dev/hugepages"
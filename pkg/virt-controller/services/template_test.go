@@ -50,6 +50,7 @@ import (
 	k6tconfig "kubevirt.io/kubevirt/pkg/config"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/network/istio"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/util"
@@ -959,6 +960,58 @@ var _ = Describe("Template", func() {
 				Expect(ok).To(BeTrue())
 			})
 		})
+		Context("with persistent TPM", func() {
+			var vmi *v1.VirtualMachineInstance
+
+			swtpmMount := func(pod *k8sv1.Pod) *k8sv1.VolumeMount {
+				for _, mount := range pod.Spec.Containers[0].VolumeMounts {
+					if mount.MountPath == PathForSwtpm(vmi) {
+						return &mount
+					}
+				}
+				return nil
+			}
+
+			BeforeEach(func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi = api.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{Persistent: pointer.Bool(true)}
+			})
+
+			It("should mount the same TPM state on the migration target as on the source", func() {
+				sourcePod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				targetPod, err := svc.RenderMigrationManifest(vmi, sourcePod)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, pod := range []*k8sv1.Pod{sourcePod, targetPod} {
+					mount := swtpmMount(pod)
+					Expect(mount).ToNot(BeNil())
+					Expect(mount.Name).To(Equal(BackendStorageVolumeName(vmi)))
+					Expect(mount.SubPath).To(Equal("swtpm"))
+				}
+			})
+
+			It("should add the secret holding the TPM state encryption key", func() {
+				vmi.Spec.Domain.Devices.TPM.Encryption = &v1.TPMStateEncryption{
+					SecretRef: &k8sv1.LocalObjectReference{Name: "tpm-key"},
+				}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Volumes).To(ContainElement(k8sv1.Volume{
+					Name: backendstorage.TPMEncryptionVolumeName,
+					VolumeSource: k8sv1.VolumeSource{
+						Secret: &k8sv1.SecretVolumeSource{SecretName: "tpm-key"},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(k8sv1.VolumeMount{
+					Name:      backendstorage.TPMEncryptionVolumeName,
+					MountPath: k6tconfig.GetSecretSourcePath(backendstorage.TPMEncryptionVolumeName),
+					ReadOnly:  true,
+				}))
+			})
+		})
 		Context("with multus annotation", func() {
 			It("should add multus networks in the pod annotation", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
@@ -4414,6 +4467,33 @@ var _ = Describe("Template", func() {
 			Expect(ok).To(BeTrue())
 			Expect(int(sev.Value())).To(Equal(1))
 		})
+
+		It("should request the SEV device resource for SEV-SNP", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
+				SNP: &v1.SEVSNP{},
+			}
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod.Spec.Containers[0].Resources.Limits).To(HaveKey(k8sv1.ResourceName(SevDevice)))
+		})
+	})
+
+	Context("Intel TDX LaunchSecurity", func() {
+		It("should mount the TDX quote generation service directory", func() {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
+				TDX: &v1.TDX{},
+			}
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pod.Spec.Containers[0].Resources.Limits).ToNot(HaveKey(k8sv1.ResourceName(SevDevice)))
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(k8sv1.VolumeMount{
+				Name:      "tdx-qgs",
+				MountPath: "/var/run/tdx-qgs",
+			}))
+			Expect(pod.Spec.Volumes).To(ContainElement(HaveField("HostPath.Path", "/var/run/tdx-qgs")))
+		})
 	})
 
 	Context("with VSOCK enabled", func() {
@@ -41,6 +41,7 @@ import (
 	clonev1alpha1 "kubevirt.io/api/clone/v1alpha1"
 
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/clone"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/quota"
 
 	"kubevirt.io/kubevirt/pkg/instancetype"
 
@@ -185,6 +186,8 @@ type VirtControllerApp struct {
 	exportController             *export.VMExportController
 	snapshotController           *snapshot.VMSnapshotController
 	restoreController            *snapshot.VMRestoreController
+	snapshotScheduleController   *snapshot.VMSnapshotScheduleController
+	backupController             *snapshot.VMBackupController
 	vmExportInformer             cache.SharedIndexInformer
 	routeCache                   cache.Store
 	ingressCache                 cache.Store
@@ -192,6 +195,8 @@ type VirtControllerApp struct {
 	vmSnapshotInformer           cache.SharedIndexInformer
 	vmSnapshotContentInformer    cache.SharedIndexInformer
 	vmRestoreInformer            cache.SharedIndexInformer
+	vmSnapshotScheduleInformer   cache.SharedIndexInformer
+	vmBackupInformer             cache.SharedIndexInformer
 	storageClassInformer         cache.SharedIndexInformer
 	allPodInformer               cache.SharedIndexInformer
 	resourceQuotaInformer        cache.SharedIndexInformer
@@ -200,9 +205,14 @@ type VirtControllerApp struct {
 
 	migrationPolicyInformer cache.SharedIndexInformer
 
+	nodeEvacuationInformer cache.SharedIndexInformer
+
 	vmCloneInformer   cache.SharedIndexInformer
 	vmCloneController *clone.VMCloneController
 
+	vmQuotaInformer   cache.SharedIndexInformer
+	vmQuotaController *quota.VMQuotaController
+
 	instancetypeInformer        cache.SharedIndexInformer
 	clusterInstancetypeInformer cache.SharedIndexInformer
 	preferenceInformer          cache.SharedIndexInformer
@@ -245,8 +255,11 @@ type VirtControllerApp struct {
 	exportControllerThreads           int
 	snapshotControllerThreads         int
 	restoreControllerThreads          int
+	snapshotScheduleControllerThreads int
+	backupControllerThreads           int
 	snapshotControllerResyncPeriod    time.Duration
 	cloneControllerThreads            int
+	vmQuotaControllerThreads          int
 
 	caConfigMapName          string
 	promCertFilePath         string
@@ -375,6 +388,8 @@ func Execute() {
 	app.vmSnapshotInformer = app.informerFactory.VirtualMachineSnapshot()
 	app.vmSnapshotContentInformer = app.informerFactory.VirtualMachineSnapshotContent()
 	app.vmRestoreInformer = app.informerFactory.VirtualMachineRestore()
+	app.vmSnapshotScheduleInformer = app.informerFactory.VirtualMachineSnapshotSchedule()
+	app.vmBackupInformer = app.informerFactory.VirtualMachineBackup()
 	app.storageClassInformer = app.informerFactory.StorageClass()
 	app.caExportConfigMapInformer = app.informerFactory.KubeVirtExportCAConfigMap()
 	app.exportRouteConfigMapInformer = app.informerFactory.ExportRouteConfigMap()
@@ -413,9 +428,12 @@ func Execute() {
 	}
 	app.ingressCache = app.informerFactory.Ingress().GetStore()
 	app.migrationPolicyInformer = app.informerFactory.MigrationPolicy()
+	app.nodeEvacuationInformer = app.informerFactory.NodeEvacuation()
 
 	app.vmCloneInformer = app.informerFactory.VirtualMachineClone()
 
+	app.vmQuotaInformer = app.informerFactory.VirtualMachineQuota()
+
 	app.instancetypeInformer = app.informerFactory.VirtualMachineInstancetype()
 	app.clusterInstancetypeInformer = app.informerFactory.VirtualMachineClusterInstancetype()
 	app.preferenceInformer = app.informerFactory.VirtualMachinePreference()
@@ -431,9 +449,12 @@ func Execute() {
 	app.initEvacuationController()
 	app.initSnapshotController()
 	app.initRestoreController()
+	app.initSnapshotScheduleController()
+	app.initBackupController()
 	app.initExportController()
 	app.initWorkloadUpdaterController()
 	app.initCloneController()
+	app.initVMQuotaController()
 	go app.Run()
 
 	<-app.reInitChan
@@ -551,6 +572,16 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 				log.Log.Warningf("error running the restore controller: %v", err)
 			}
 		}()
+		go func() {
+			if err := vca.snapshotScheduleController.Run(vca.snapshotScheduleControllerThreads, stop); err != nil {
+				log.Log.Warningf("error running the snapshot schedule controller: %v", err)
+			}
+		}()
+		go func() {
+			if err := vca.backupController.Run(vca.backupControllerThreads, stop); err != nil {
+				log.Log.Warningf("error running the backup controller: %v", err)
+			}
+		}()
 		go func() {
 			if err := vca.exportController.Run(vca.exportControllerThreads, stop); err != nil {
 				log.Log.Warningf("error running the export controller: %v", err)
@@ -563,6 +594,11 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 				log.Log.Warningf("error running the clone controller: %v", err)
 			}
 		}()
+		go func() {
+			if err := vca.vmQuotaController.Run(vca.vmQuotaControllerThreads, stop); err != nil {
+				log.Log.Warningf("error running the VirtualMachineQuota controller: %v", err)
+			}
+		}()
 
 		cache.WaitForCacheSync(stop, vca.persistentVolumeClaimInformer.HasSynced, vca.namespaceInformer.HasSynced, vca.resourceQuotaInformer.HasSynced)
 		close(vca.readyChan)
@@ -690,6 +726,7 @@ func (vca *VirtControllerApp) initVirtualMachines() {
 		ClusterPreferenceStore:   vca.clusterPreferenceInformer.GetStore(),
 		ControllerRevisionStore:  vca.controllerRevisionInformer.GetStore(),
 		Clientset:                vca.clientSet,
+		ImageLabelsFetcher:       instancetype.NewCachedImageLabelsFetcher(instancetype.NewRegistryImageLabelsFetcher(vca.clusterConfig.GetInstancetypeInferenceRegistries)),
 	}
 
 	vca.vmController, err = NewVMController(
@@ -752,6 +789,7 @@ func (vca *VirtControllerApp) initEvacuationController() {
 		vca.migrationInformer,
 		vca.nodeInformer,
 		vca.kvPodInformer,
+		vca.nodeEvacuationInformer,
 		recorder,
 		vca.clientSet,
 		vca.clusterConfig,
@@ -804,6 +842,34 @@ func (vca *VirtControllerApp) initRestoreController() {
 	}
 }
 
+func (vca *VirtControllerApp) initSnapshotScheduleController() {
+	recorder := vca.newRecorder(k8sv1.NamespaceAll, "snapshot-schedule-controller")
+	vca.snapshotScheduleController = &snapshot.VMSnapshotScheduleController{
+		Client:                     vca.clientSet,
+		VMSnapshotScheduleInformer: vca.vmSnapshotScheduleInformer,
+		VMSnapshotInformer:         vca.vmSnapshotInformer,
+		VMInformer:                 vca.vmInformer,
+		Recorder:                   recorder,
+	}
+	if err := vca.snapshotScheduleController.Init(); err != nil {
+		panic(err)
+	}
+}
+
+func (vca *VirtControllerApp) initBackupController() {
+	recorder := vca.newRecorder(k8sv1.NamespaceAll, "backup-controller")
+	vca.backupController = &snapshot.VMBackupController{
+		Client:           vca.clientSet,
+		VMBackupInformer: vca.vmBackupInformer,
+		VMInformer:       vca.vmInformer,
+		VMIInformer:      vca.vmiInformer,
+		Recorder:         recorder,
+	}
+	if err := vca.backupController.Init(); err != nil {
+		panic(err)
+	}
+}
+
 func (vca *VirtControllerApp) initExportController() {
 	recorder := vca.newRecorder(k8sv1.NamespaceAll, "export-controller")
 	vca.exportController = &export.VMExportController{
@@ -824,6 +890,7 @@ func (vca *VirtControllerApp) initExportController() {
 		VolumeSnapshotProvider:      vca.snapshotController,
 		VMSnapshotInformer:          vca.vmSnapshotInformer,
 		VMSnapshotContentInformer:   vca.vmSnapshotContentInformer,
+		VMBackupInformer:            vca.vmBackupInformer,
 		VMInformer:                  vca.vmInformer,
 		VMIInformer:                 vca.vmiInformer,
 		CRDInformer:                 vca.crdInformer,
@@ -850,6 +917,17 @@ func (vca *VirtControllerApp) initCloneController() {
 	}
 }
 
+func (vca *VirtControllerApp) initVMQuotaController() {
+	vca.vmQuotaController = &quota.VMQuotaController{
+		Client:          vca.clientSet,
+		VMQuotaInformer: vca.vmQuotaInformer,
+		VMIInformer:     vca.vmiInformer,
+	}
+	if err := vca.vmQuotaController.Init(); err != nil {
+		panic(err)
+	}
+}
+
 func (vca *VirtControllerApp) leaderProbe(_ *restful.Request, response *restful.Response) {
 	res := map[string]interface{}{}
 
@@ -941,6 +1019,12 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.IntVar(&vca.restoreControllerThreads, "restore-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for restore controller")
 
+	flag.IntVar(&vca.snapshotScheduleControllerThreads, "snapshot-schedule-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for snapshot schedule controller")
+
+	flag.IntVar(&vca.backupControllerThreads, "backup-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for backup controller")
+
 	flag.IntVar(&vca.exportControllerThreads, "export-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for virtual machine export controller")
 
@@ -958,6 +1042,9 @@ func (vca *VirtControllerApp) AddFlags() {
 
 	flag.IntVar(&vca.cloneControllerThreads, "clone-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for clone controller")
+
+	flag.IntVar(&vca.vmQuotaControllerThreads, "vm-quota-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for VirtualMachineQuota controller")
 }
 
 func (vca *VirtControllerApp) setupLeaderElector() (err error) {
@@ -3,6 +3,8 @@ package evacuation
 import (
 	"fmt"
 	"math"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -35,18 +37,21 @@ const (
 	FailedCreateVirtualMachineInstanceMigrationReason = "FailedCreate"
 	// SuccessfulCreateVirtualMachineInstanceMigrationReason is added in an event if creating a VirtualMachineInstanceMigration succeeded.
 	SuccessfulCreateVirtualMachineInstanceMigrationReason = "SuccessfulCreate"
+	// InvalidEvacuationPriorityReason is added in an event if the evacuation priority annotation of a VirtualMachineInstance can not be parsed.
+	InvalidEvacuationPriorityReason = "InvalidEvacuationPriority"
 )
 
 type EvacuationController struct {
-	clientset             kubecli.KubevirtClient
-	Queue                 workqueue.RateLimitingInterface
-	vmiInformer           cache.SharedIndexInformer
-	vmiPodInformer        cache.SharedIndexInformer
-	migrationInformer     cache.SharedIndexInformer
-	recorder              record.EventRecorder
-	migrationExpectations *controller.UIDTrackingControllerExpectations
-	nodeInformer          cache.SharedIndexInformer
-	clusterConfig         *virtconfig.ClusterConfig
+	clientset              kubecli.KubevirtClient
+	Queue                  workqueue.RateLimitingInterface
+	vmiInformer            cache.SharedIndexInformer
+	vmiPodInformer         cache.SharedIndexInformer
+	migrationInformer      cache.SharedIndexInformer
+	recorder               record.EventRecorder
+	migrationExpectations  *controller.UIDTrackingControllerExpectations
+	nodeInformer           cache.SharedIndexInformer
+	nodeEvacuationInformer cache.SharedIndexInformer
+	clusterConfig          *virtconfig.ClusterConfig
 }
 
 func NewEvacuationController(
@@ -54,21 +59,23 @@ func NewEvacuationController(
 	migrationInformer cache.SharedIndexInformer,
 	nodeInformer cache.SharedIndexInformer,
 	vmiPodInformer cache.SharedIndexInformer,
+	nodeEvacuationInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
 	clusterConfig *virtconfig.ClusterConfig,
 ) (*EvacuationController, error) {
 
 	c := &EvacuationController{
-		Queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-evacuation"),
-		vmiInformer:           vmiInformer,
-		migrationInformer:     migrationInformer,
-		nodeInformer:          nodeInformer,
-		vmiPodInformer:        vmiPodInformer,
-		recorder:              recorder,
-		clientset:             clientset,
-		migrationExpectations: controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
-		clusterConfig:         clusterConfig,
+		Queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-evacuation"),
+		vmiInformer:            vmiInformer,
+		migrationInformer:      migrationInformer,
+		nodeInformer:           nodeInformer,
+		vmiPodInformer:         vmiPodInformer,
+		nodeEvacuationInformer: nodeEvacuationInformer,
+		recorder:               recorder,
+		clientset:              clientset,
+		migrationExpectations:  controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		clusterConfig:          clusterConfig,
 	}
 
 	_, err := c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -283,7 +290,7 @@ func (c *EvacuationController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting evacuation controller.")
 
 	// Wait for cache sync before we start the node controller
-	cache.WaitForCacheSync(stopCh, c.migrationInformer.HasSynced, c.vmiInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.migrationInformer.HasSynced, c.vmiInformer.HasSynced, c.nodeEvacuationInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -357,7 +364,7 @@ func getMarkedForEvictionVMIs(vmis []*virtv1.VirtualMachineInstance) []*virtv1.V
 	return evictionCandidates
 }
 
-func GenerateNewMigration(vmiName string, key string) *virtv1.VirtualMachineInstanceMigration {
+func GenerateNewMigration(vmiName string, key string, targetNodeSelector map[string]string) *virtv1.VirtualMachineInstanceMigration {
 
 	annotations := map[string]string{
 		virtv1.EvacuationMigrationAnnotation: key,
@@ -368,11 +375,101 @@ func GenerateNewMigration(vmiName string, key string) *virtv1.VirtualMachineInst
 			GenerateName: "kubevirt-evacuation-",
 		},
 		Spec: virtv1.VirtualMachineInstanceMigrationSpec{
-			VMIName: vmiName,
+			VMIName:           vmiName,
+			AddedNodeSelector: targetNodeSelector,
 		},
 	}
 }
 
+// evacuationTargetNodeSelector returns the part of the cluster wide evacuation target node selector which
+// still has to be added to the migration of the VMI. Keys the VMI already selects with the same value are
+// dropped. A key with a different value can never be satisfied, in that case false is returned.
+func evacuationTargetNodeSelector(vmi *virtv1.VirtualMachineInstance, targetNodeSelector map[string]string) (map[string]string, bool) {
+	var added map[string]string
+	for key, value := range targetNodeSelector {
+		vmiValue, exists := vmi.Spec.NodeSelector[key]
+		if exists && vmiValue != value {
+			return nil, false
+		}
+		if exists {
+			continue
+		}
+		if added == nil {
+			added = map[string]string{}
+		}
+		added[key] = value
+	}
+	return added, true
+}
+
+// filterTargetNodeSelectorConflicts splits off the VMIs whose node selector conflicts with the evacuation
+// target node selector, they can not be evacuated as long as the selector is configured
+func filterTargetNodeSelectorConflicts(vmis []*virtv1.VirtualMachineInstance, targetNodeSelector map[string]string) (candidates []*virtv1.VirtualMachineInstance, conflicting []*virtv1.VirtualMachineInstance) {
+	for _, vmi := range vmis {
+		if _, ok := evacuationTargetNodeSelector(vmi, targetNodeSelector); !ok {
+			conflicting = append(conflicting, vmi)
+			continue
+		}
+		candidates = append(candidates, vmi)
+	}
+	return candidates, conflicting
+}
+
+// evacuationPriority returns the priority set by the EvacuationPriorityAnnotation, VMIs without
+// the annotation get the default priority 0
+func evacuationPriority(vmi *virtv1.VirtualMachineInstance) (int, error) {
+	value, exists := vmi.Annotations[virtv1.EvacuationPriorityAnnotation]
+	if !exists {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func guestMemory(vmi *virtv1.VirtualMachineInstance) int64 {
+	if vmi.Status.Memory != nil && vmi.Status.Memory.GuestCurrent != nil {
+		return vmi.Status.Memory.GuestCurrent.Value()
+	}
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		return vmi.Spec.Domain.Memory.Guest.Value()
+	}
+	if memory, ok := vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory]; ok {
+		return memory.Value()
+	}
+	if memory, ok := vmi.Spec.Domain.Resources.Limits[k8sv1.ResourceMemory]; ok {
+		return memory.Value()
+	}
+	return 0
+}
+
+// sortMigrationCandidates orders the candidates by their evacuation priority, highest first.
+// Candidates with the same priority are ordered according to the configured EvacuationOrder.
+// Candidates with an invalid priority get the default priority 0.
+func (c *EvacuationController) sortMigrationCandidates(candidates []*virtv1.VirtualMachineInstance, order virtv1.EvacuationOrder) {
+	priorities := make(map[*virtv1.VirtualMachineInstance]int, len(candidates))
+	for _, vmi := range candidates {
+		priority, err := evacuationPriority(vmi)
+		if err != nil {
+			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, InvalidEvacuationPriorityReason, "Ignoring invalid %s annotation: %v", virtv1.EvacuationPriorityAnnotation, err)
+		}
+		priorities[vmi] = priority
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		priorityI, priorityJ := priorities[candidates[i]], priorities[candidates[j]]
+		if priorityI != priorityJ {
+			return priorityI > priorityJ
+		}
+		switch order {
+		case virtv1.EvacuationOrderSmallestMemoryFirst:
+			return guestMemory(candidates[i]) < guestMemory(candidates[j])
+		case virtv1.EvacuationOrderLargestMemoryFirst:
+			return guestMemory(candidates[i]) > guestMemory(candidates[j])
+		default:
+			return false
+		}
+	})
+}
+
 func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.VirtualMachineInstance, activeMigrations []*virtv1.VirtualMachineInstanceMigration) error {
 	// If the node has no drain taint, we have nothing to do
 	taintKey := *c.clusterConfig.GetMigrationConfiguration().NodeDrainTaintKey
@@ -381,13 +478,27 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 		Effect: k8sv1.TaintEffectNoSchedule,
 	}
 
+	// Reporting the progress is best effort, it must not block the evacuation itself
+	if err := c.updateNodeEvacuation(node, vmisOnNode, activeMigrations, taint); err != nil {
+		log.Log.Object(node).Reason(err).Warning("Failed to report the node evacuation progress")
+	}
+
 	vmisToMigrate := vmisToMigrate(node, vmisOnNode, taint)
 	if len(vmisToMigrate) == 0 {
 		return nil
 	}
 
 	migrationCandidates, nonMigrateable := c.filterRunningNonMigratingVMIs(vmisToMigrate, activeMigrations)
+	migrationConfig := c.clusterConfig.GetMigrationConfiguration()
+	migrationCandidates, conflicting := filterTargetNodeSelectorConflicts(migrationCandidates, migrationConfig.EvacuationTargetNodeSelector)
+	for _, vmi := range conflicting {
+		c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedCreateVirtualMachineInstanceMigrationReason, "VirtualMachineInstance node selector conflicts with the evacuation target node selector")
+	}
 	if len(migrationCandidates) == 0 && len(nonMigrateable) == 0 {
+		if len(conflicting) > 0 {
+			// the conflict can be resolved by changing the cluster configuration, which does not requeue the node
+			c.Queue.AddAfter(node.Name, 1*time.Minute)
+		}
 		return nil
 	}
 
@@ -426,7 +537,11 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 		return nil
 	}
 
-	// TODO: should the order be randomized?
+	evacuationOrder := virtv1.EvacuationOrderDefault
+	if migrationConfig.EvacuationOrder != nil {
+		evacuationOrder = *migrationConfig.EvacuationOrder
+	}
+	c.sortMigrationCandidates(migrationCandidates, evacuationOrder)
 	selectedCandidates := migrationCandidates[0:diff]
 
 	log.DefaultLogger().Infof("node: %v, migrations: %v, candidates: %v, selected: %v", node.Name, len(activeMigrations), len(migrationCandidates), len(selectedCandidates))
@@ -440,7 +555,8 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 	for _, vmi := range selectedCandidates {
 		go func(vmi *virtv1.VirtualMachineInstance) {
 			defer wg.Done()
-			createdMigration, err := c.clientset.VirtualMachineInstanceMigration(vmi.Namespace).Create(GenerateNewMigration(vmi.Name, node.Name), &v1.CreateOptions{})
+			targetNodeSelector, _ := evacuationTargetNodeSelector(vmi, migrationConfig.EvacuationTargetNodeSelector)
+			createdMigration, err := c.clientset.VirtualMachineInstanceMigration(vmi.Namespace).Create(GenerateNewMigration(vmi.Name, node.Name, targetNodeSelector), &v1.CreateOptions{})
 			if err != nil {
 				c.migrationExpectations.CreationObserved(node.Name)
 				c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedCreateVirtualMachineInstanceMigrationReason, "Error creating a Migration: %v", err)
@@ -1,11 +1,13 @@
 package evacuation_test
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	v12 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
@@ -17,6 +19,8 @@ import (
 	"kubevirt.io/client-go/api"
 
 	v1 "kubevirt.io/api/core/v1"
+	migrationsv1 "kubevirt.io/api/migrations/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
 
 	"kubevirt.io/kubevirt/pkg/testutils"
@@ -41,9 +45,12 @@ var _ = Describe("Evacuation", func() {
 	var migrationSource *framework.FakeControllerSource
 	var podInformer cache.SharedIndexInformer
 	var podSource *framework.FakeControllerSource
+	var nodeEvacuationInformer cache.SharedIndexInformer
+	var nodeEvacuationSource *framework.FakeControllerSource
 	var recorder *record.FakeRecorder
 	var mockQueue *testutils.MockWorkQueue
 	var kubeClient *fake.Clientset
+	var kubevirtClient *kubevirtfake.Clientset
 	var migrationFeeder *testutils.MigrationFeeder
 	var vmiFeeder *testutils.VirtualMachineFeeder
 
@@ -54,12 +61,14 @@ var _ = Describe("Evacuation", func() {
 		go migrationInformer.Run(stop)
 		go nodeInformer.Run(stop)
 		go podInformer.Run(stop)
+		go nodeEvacuationInformer.Run(stop)
 
 		Expect(cache.WaitForCacheSync(stop,
 			vmiInformer.HasSynced,
 			migrationInformer.HasSynced,
 			nodeInformer.HasSynced,
 			podInformer.HasSynced,
+			nodeEvacuationInformer.HasSynced,
 		)).To(BeTrue())
 	}
 
@@ -84,11 +93,12 @@ var _ = Describe("Evacuation", func() {
 		migrationInformer, migrationSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceMigration{})
 		nodeInformer, nodeSource = testutils.NewFakeInformerFor(&v12.Node{})
 		podInformer, podSource = testutils.NewFakeInformerFor(&v12.Pod{})
+		nodeEvacuationInformer, nodeEvacuationSource = testutils.NewFakeInformerFor(&migrationsv1.NodeEvacuation{})
 		recorder = record.NewFakeRecorder(100)
 		recorder.IncludeObject = true
 		config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
 
-		controller, _ = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, recorder, virtClient, config)
+		controller, _ = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, nodeEvacuationInformer, recorder, virtClient, config)
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 		controller.Queue = mockQueue
 		migrationFeeder = testutils.NewMigrationFeeder(mockQueue, migrationSource)
@@ -99,6 +109,8 @@ var _ = Describe("Evacuation", func() {
 		kubeClient = fake.NewSimpleClientset()
 		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
 		virtClient.EXPECT().PolicyV1().Return(kubeClient.PolicyV1()).AnyTimes()
+		kubevirtClient = kubevirtfake.NewSimpleClientset()
+		virtClient.EXPECT().NodeEvacuation().Return(kubevirtClient.MigrationsV1alpha1().NodeEvacuations()).AnyTimes()
 
 		// Make sure that all unexpected calls to kubeClient will fail
 		kubeClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
@@ -110,9 +122,15 @@ var _ = Describe("Evacuation", func() {
 
 	Context("migration object creation", func() {
 		It("should have expected values and annotations", func() {
-			migration := evacuation.GenerateNewMigration("my-vmi", "somenode")
+			migration := evacuation.GenerateNewMigration("my-vmi", "somenode", nil)
 			Expect(migration.Spec.VMIName).To(Equal("my-vmi"))
 			Expect(migration.Annotations[v1.EvacuationMigrationAnnotation]).To(Equal("somenode"))
+			Expect(migration.Spec.AddedNodeSelector).To(BeEmpty())
+		})
+
+		It("should restrict the target nodes", func() {
+			migration := evacuation.GenerateNewMigration("my-vmi", "somenode", map[string]string{"maintenance": "false"})
+			Expect(migration.Spec.AddedNodeSelector).To(Equal(map[string]string{"maintenance": "false"}))
 		})
 
 	})
@@ -318,6 +336,7 @@ var _ = Describe("Evacuation", func() {
 					migrationInformer,
 					nodeInformer,
 					podInformer,
+					nodeEvacuationInformer,
 					recorder,
 					virtClient,
 					config)
@@ -406,7 +425,7 @@ var _ = Describe("Evacuation", func() {
 			config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
 				EvictionStrategy: newEvictionStrategyLiveMigrate(),
 			})
-			controller, _ = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, recorder, virtClient, config)
+			controller, _ = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, nodeEvacuationInformer, recorder, virtClient, config)
 
 			node := newNode("testnode")
 			node1 := newNode("anothernode")
@@ -427,7 +446,7 @@ var _ = Describe("Evacuation", func() {
 			config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
 				EvictionStrategy: newEvictionStrategyLiveMigrate(),
 			})
-			controller, _ = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, recorder, virtClient, config)
+			controller, _ = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, nodeEvacuationInformer, recorder, virtClient, config)
 
 			node := newNode("testnode")
 			node1 := newNode("anothernode")
@@ -460,6 +479,7 @@ var _ = Describe("Evacuation", func() {
 					migrationInformer,
 					nodeInformer,
 					podInformer,
+					nodeEvacuationInformer,
 					recorder,
 					virtClient,
 					config)
@@ -510,6 +530,7 @@ var _ = Describe("Evacuation", func() {
 					migrationInformer,
 					nodeInformer,
 					podInformer,
+					nodeEvacuationInformer,
 					recorder,
 					virtClient,
 					config)
@@ -542,6 +563,257 @@ var _ = Describe("Evacuation", func() {
 		})
 	})
 
+	Context("evacuation order", func() {
+
+		newController := func(migrationConfig *v1.MigrationConfiguration) {
+			migrationConfig.ParallelOutboundMigrationsPerNode = pointer.P(uint32(1))
+			config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				MigrationConfiguration: migrationConfig,
+			})
+			controller, _ = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, nodeEvacuationInformer, recorder, virtClient, config)
+		}
+
+		expectMigrationFor := func(vmiName string) *gomock.Call {
+			return migrationInterface.EXPECT().Create(gomock.Any(), &v13.CreateOptions{}).DoAndReturn(
+				func(migration *v1.VirtualMachineInstanceMigration, _ *v13.CreateOptions) (*v1.VirtualMachineInstanceMigration, error) {
+					Expect(migration.Spec.VMIName).To(Equal(vmiName))
+					return &v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil
+				})
+		}
+
+		It("should migrate the VMI with the highest evacuation priority first", func() {
+			newController(&v1.MigrationConfiguration{})
+			node := newNode("testnode")
+			addNode(node)
+
+			vmiFeeder.Add(newVirtualMachineMarkedForEviction("testvmi1", node.Name))
+			vmi2 := newVirtualMachineMarkedForEviction("testvmi2", node.Name)
+			vmi2.Annotations = map[string]string{v1.EvacuationPriorityAnnotation: "10"}
+			vmiFeeder.Add(vmi2)
+			vmi3 := newVirtualMachineMarkedForEviction("testvmi3", node.Name)
+			vmi3.Annotations = map[string]string{v1.EvacuationPriorityAnnotation: "invalid"}
+			vmiFeeder.Add(vmi3)
+
+			expectMigrationFor(vmi2.Name)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.InvalidEvacuationPriorityReason)
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+		})
+
+		DescribeTable("should order VMIs with the same priority by their guest memory", func(order v1.EvacuationOrder, expectedVMI string) {
+			newController(&v1.MigrationConfiguration{EvacuationOrder: &order})
+			node := newNode("testnode")
+			addNode(node)
+
+			for name, memory := range map[string]string{"small": "1Gi", "large": "4Gi", "medium": "2Gi"} {
+				vmi := newVirtualMachineMarkedForEviction(name, node.Name)
+				vmi.Spec.Domain.Resources.Requests[v12.ResourceMemory] = resource.MustParse(memory)
+				vmiFeeder.Add(vmi)
+			}
+
+			expectMigrationFor(expectedVMI)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+		},
+			Entry("smallest first", v1.EvacuationOrderSmallestMemoryFirst, "small"),
+			Entry("largest first", v1.EvacuationOrderLargestMemoryFirst, "large"),
+		)
+
+		It("should prefer the evacuation priority over the guest memory", func() {
+			newController(&v1.MigrationConfiguration{EvacuationOrder: pointer.P(v1.EvacuationOrderLargestMemoryFirst)})
+			node := newNode("testnode")
+			addNode(node)
+
+			vmi1 := newVirtualMachineMarkedForEviction("testvmi1", node.Name)
+			vmi1.Spec.Domain.Resources.Requests[v12.ResourceMemory] = resource.MustParse("4Gi")
+			vmiFeeder.Add(vmi1)
+			vmi2 := newVirtualMachineMarkedForEviction("testvmi2", node.Name)
+			vmi2.Spec.Domain.Resources.Requests[v12.ResourceMemory] = resource.MustParse("1Gi")
+			vmi2.Annotations = map[string]string{v1.EvacuationPriorityAnnotation: "1"}
+			vmiFeeder.Add(vmi2)
+
+			expectMigrationFor(vmi2.Name)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+		})
+
+		It("should add the evacuation target node selector to the migration", func() {
+			newController(&v1.MigrationConfiguration{EvacuationTargetNodeSelector: map[string]string{"maintenance": "false"}})
+			node := newNode("testnode")
+			addNode(node)
+			vmiFeeder.Add(newVirtualMachineMarkedForEviction("testvmi", node.Name))
+
+			migrationInterface.EXPECT().Create(gomock.Any(), &v13.CreateOptions{}).DoAndReturn(
+				func(migration *v1.VirtualMachineInstanceMigration, _ *v13.CreateOptions) (*v1.VirtualMachineInstanceMigration, error) {
+					Expect(migration.Spec.AddedNodeSelector).To(Equal(map[string]string{"maintenance": "false"}))
+					return &v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil
+				})
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+		})
+
+		It("should only add the evacuation target node selector keys the VMI does not select on", func() {
+			newController(&v1.MigrationConfiguration{EvacuationTargetNodeSelector: map[string]string{"maintenance": "false", "zone": "a"}})
+			node := newNode("testnode")
+			addNode(node)
+			vmi := newVirtualMachineMarkedForEviction("testvmi", node.Name)
+			vmi.Spec.NodeSelector = map[string]string{"zone": "a"}
+			vmiFeeder.Add(vmi)
+
+			migrationInterface.EXPECT().Create(gomock.Any(), &v13.CreateOptions{}).DoAndReturn(
+				func(migration *v1.VirtualMachineInstanceMigration, _ *v13.CreateOptions) (*v1.VirtualMachineInstanceMigration, error) {
+					Expect(migration.Spec.AddedNodeSelector).To(Equal(map[string]string{"maintenance": "false"}))
+					return &v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil
+				})
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+		})
+
+		It("should not evacuate VMIs with a node selector conflicting with the evacuation target node selector", func() {
+			newController(&v1.MigrationConfiguration{EvacuationTargetNodeSelector: map[string]string{"zone": "a"}})
+			node := newNode("testnode")
+			addNode(node)
+			vmi := newVirtualMachineMarkedForEviction("testvmi", node.Name)
+			vmi.Spec.NodeSelector = map[string]string{"zone": "b"}
+			vmiFeeder.Add(vmi)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.FailedCreateVirtualMachineInstanceMigrationReason)
+
+			nodeEvacuation, err := kubevirtClient.MigrationsV1alpha1().NodeEvacuations().Get(context.Background(), node.Name, v13.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodeEvacuation.Status.PendingVMIs).To(BeZero())
+			Expect(nodeEvacuation.Status.NodeSelectorConflictVMIs).To(ConsistOf("default/testvmi"))
+		})
+	})
+
+	Context("node evacuation status", func() {
+
+		addNodeEvacuation := func(nodeEvacuation *migrationsv1.NodeEvacuation) {
+			_, err := kubevirtClient.MigrationsV1alpha1().NodeEvacuations().Create(context.Background(), nodeEvacuation, v13.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			nodeEvacuationSource.Add(nodeEvacuation)
+			// the NodeEvacuation informer has no event handler, wait until the store caught up
+			Eventually(func() bool {
+				_, exists, _ := nodeEvacuationInformer.GetStore().GetByKey(nodeEvacuation.Name)
+				return exists
+			}).Should(BeTrue())
+		}
+
+		getNodeEvacuation := func(name string) *migrationsv1.NodeEvacuation {
+			nodeEvacuation, err := kubevirtClient.MigrationsV1alpha1().NodeEvacuations().Get(context.Background(), name, v13.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			return nodeEvacuation
+		}
+
+		It("should not create a NodeEvacuation if the node is not drained", func() {
+			node := newNode("testnode")
+			addNode(node)
+			vmiFeeder.Add(newVirtualMachine("testvmi", node.Name))
+
+			controller.Execute()
+
+			list, err := kubevirtClient.MigrationsV1alpha1().NodeEvacuations().List(context.Background(), v13.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(list.Items).To(BeEmpty())
+		})
+
+		It("should report the progress of a drained node", func() {
+			node := newNode("testnode")
+			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
+			addNode(node)
+
+			vmi1 := newVirtualMachineMarkedForEviction("testvmi1", node.Name)
+			vmiFeeder.Add(vmi1)
+			migrationFeeder.Add(newMigration("mig1", vmi1.Name, v1.MigrationRunning))
+			vmi2 := newVirtualMachineMarkedForEviction("testvmi2", node.Name)
+			vmi2.Status.Conditions = []v1.VirtualMachineInstanceCondition{{Type: v1.VirtualMachineInstanceIsMigratable, Status: v12.ConditionFalse}}
+			vmiFeeder.Add(vmi2)
+			vmi3 := newVirtualMachineMarkedForEviction("testvmi3", node.Name)
+			vmiFeeder.Add(vmi3)
+
+			migrationInterface.EXPECT().Create(gomock.Any(), &v13.CreateOptions{}).Return(&v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+
+			nodeEvacuation := getNodeEvacuation(node.Name)
+			Expect(nodeEvacuation.OwnerReferences).To(HaveLen(1))
+			Expect(nodeEvacuation.OwnerReferences[0].Kind).To(Equal("Node"))
+			Expect(nodeEvacuation.OwnerReferences[0].Name).To(Equal(node.Name))
+			Expect(nodeEvacuation.Status.Phase).To(Equal(migrationsv1.NodeEvacuationEvacuating))
+			Expect(nodeEvacuation.Status.StartTimestamp).ToNot(BeNil())
+			Expect(nodeEvacuation.Status.CompletionTimestamp).To(BeNil())
+			Expect(nodeEvacuation.Status.TotalVMIs).To(Equal(3))
+			Expect(nodeEvacuation.Status.EvacuatedVMIs).To(Equal(0))
+			Expect(nodeEvacuation.Status.MigratingVMIs).To(Equal(1))
+			Expect(nodeEvacuation.Status.PendingVMIs).To(Equal(1))
+			Expect(nodeEvacuation.Status.NonMigratableVMIs).To(ConsistOf("default/testvmi2"))
+		})
+
+		It("should count the VMIs which left the node as evacuated", func() {
+			node := newNode("testnode")
+			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
+			addNode(node)
+
+			startTimestamp := v13.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+			nodeEvacuation := &migrationsv1.NodeEvacuation{
+				ObjectMeta: v13.ObjectMeta{Name: node.Name},
+				Status: migrationsv1.NodeEvacuationStatus{
+					Phase:          migrationsv1.NodeEvacuationEvacuating,
+					StartTimestamp: &startTimestamp,
+					TotalVMIs:      3,
+				},
+			}
+			addNodeEvacuation(nodeEvacuation)
+
+			vmi := newVirtualMachineMarkedForEviction("testvmi", node.Name)
+			vmiFeeder.Add(vmi)
+			migrationFeeder.Add(newMigration("mig1", vmi.Name, v1.MigrationRunning))
+
+			controller.Execute()
+
+			nodeEvacuation = getNodeEvacuation(node.Name)
+			Expect(nodeEvacuation.Status.Phase).To(Equal(migrationsv1.NodeEvacuationEvacuating))
+			Expect(nodeEvacuation.Status.StartTimestamp.Equal(&startTimestamp)).To(BeTrue())
+			Expect(nodeEvacuation.Status.TotalVMIs).To(Equal(3))
+			Expect(nodeEvacuation.Status.EvacuatedVMIs).To(Equal(2))
+			Expect(nodeEvacuation.Status.MigratingVMIs).To(Equal(1))
+			Expect(nodeEvacuation.Status.PendingVMIs).To(Equal(0))
+		})
+
+		It("should complete the evacuation once no VMI is left on the node", func() {
+			node := newNode("testnode")
+			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
+			addNode(node)
+
+			nodeEvacuation := &migrationsv1.NodeEvacuation{
+				ObjectMeta: v13.ObjectMeta{Name: node.Name},
+				Status: migrationsv1.NodeEvacuationStatus{
+					Phase:         migrationsv1.NodeEvacuationEvacuating,
+					TotalVMIs:     2,
+					EvacuatedVMIs: 1,
+					MigratingVMIs: 1,
+				},
+			}
+			addNodeEvacuation(nodeEvacuation)
+
+			controller.Execute()
+
+			nodeEvacuation = getNodeEvacuation(node.Name)
+			Expect(nodeEvacuation.Status.Phase).To(Equal(migrationsv1.NodeEvacuationCompleted))
+			Expect(nodeEvacuation.Status.CompletionTimestamp).ToNot(BeNil())
+			Expect(nodeEvacuation.Status.TotalVMIs).To(Equal(2))
+			Expect(nodeEvacuation.Status.EvacuatedVMIs).To(Equal(2))
+			Expect(nodeEvacuation.Status.MigratingVMIs).To(Equal(0))
+		})
+	})
+
 	AfterEach(func() {
 		close(stop)
 		// Ensure that we add checks for expected events to every test
@@ -0,0 +1,139 @@
+package evacuation
+
+import (
+	"context"
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	migrationsv1 "kubevirt.io/api/migrations/v1alpha1"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+	migrationutils "kubevirt.io/kubevirt/pkg/util/migrations"
+)
+
+// evacuatingVMIs returns the VMIs which still have to leave the node, including the ones
+// which are currently migrating
+func (c *EvacuationController) evacuatingVMIs(node *k8sv1.Node, vmisOnNode []*virtv1.VirtualMachineInstance, taint *k8sv1.Taint) []*virtv1.VirtualMachineInstance {
+	tainted := nodeHasTaint(taint, node)
+
+	var evacuating []*virtv1.VirtualMachineInstance
+	for _, vmi := range vmisOnNode {
+		if vmi.IsFinal() || vmi.DeletionTimestamp != nil {
+			continue
+		}
+		if !migrationutils.VMIMigratableOnEviction(c.clusterConfig, vmi) {
+			continue
+		}
+		if tainted || (vmi.IsMarkedForEviction() && !hasMigratedOnEviction(vmi)) {
+			evacuating = append(evacuating, vmi)
+		}
+	}
+	return evacuating
+}
+
+func calculateNodeEvacuationStatus(oldStatus *migrationsv1.NodeEvacuationStatus, evacuating []*virtv1.VirtualMachineInstance, activeMigrations []*virtv1.VirtualMachineInstanceMigration, targetNodeSelector map[string]string, now v1.Time) *migrationsv1.NodeEvacuationStatus {
+	status := &migrationsv1.NodeEvacuationStatus{}
+
+	if len(evacuating) == 0 {
+		if oldStatus == nil || oldStatus.Phase != migrationsv1.NodeEvacuationEvacuating {
+			return oldStatus
+		}
+		status.Phase = migrationsv1.NodeEvacuationCompleted
+		status.StartTimestamp = oldStatus.StartTimestamp
+		status.CompletionTimestamp = &now
+		status.TotalVMIs = oldStatus.TotalVMIs
+		status.EvacuatedVMIs = oldStatus.TotalVMIs
+		return status
+	}
+
+	status.Phase = migrationsv1.NodeEvacuationEvacuating
+	status.TotalVMIs = len(evacuating)
+	if oldStatus != nil && oldStatus.Phase == migrationsv1.NodeEvacuationEvacuating {
+		status.StartTimestamp = oldStatus.StartTimestamp
+		if oldStatus.TotalVMIs > status.TotalVMIs {
+			status.TotalVMIs = oldStatus.TotalVMIs
+		}
+	} else {
+		status.StartTimestamp = &now
+	}
+	status.EvacuatedVMIs = status.TotalVMIs - len(evacuating)
+
+	lookup := map[string]bool{}
+	for _, migration := range activeMigrations {
+		lookup[migration.Namespace+"/"+migration.Spec.VMIName] = true
+	}
+
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+	for _, vmi := range evacuating {
+		key := vmi.Namespace + "/" + vmi.Name
+		switch {
+		case lookup[key] || migrationutils.IsMigrating(vmi):
+			status.MigratingVMIs++
+		case !conditionManager.HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceIsMigratable, k8sv1.ConditionTrue):
+			status.NonMigratableVMIs = append(status.NonMigratableVMIs, key)
+		case !targetNodeSelectorSatisfiable(vmi, targetNodeSelector):
+			status.NodeSelectorConflictVMIs = append(status.NodeSelectorConflictVMIs, key)
+		default:
+			status.PendingVMIs++
+		}
+	}
+
+	return status
+}
+
+func targetNodeSelectorSatisfiable(vmi *virtv1.VirtualMachineInstance, targetNodeSelector map[string]string) bool {
+	_, ok := evacuationTargetNodeSelector(vmi, targetNodeSelector)
+	return ok
+}
+
+// updateNodeEvacuation reports the drain progress of the node in the NodeEvacuation object of the same name
+func (c *EvacuationController) updateNodeEvacuation(node *k8sv1.Node, vmisOnNode []*virtv1.VirtualMachineInstance, activeMigrations []*virtv1.VirtualMachineInstanceMigration, taint *k8sv1.Taint) error {
+	obj, exists, err := c.nodeEvacuationInformer.GetStore().GetByKey(node.Name)
+	if err != nil {
+		return err
+	}
+
+	var oldStatus *migrationsv1.NodeEvacuationStatus
+	if exists {
+		oldStatus = &obj.(*migrationsv1.NodeEvacuation).Status
+	}
+
+	evacuating := c.evacuatingVMIs(node, vmisOnNode, taint)
+	newStatus := calculateNodeEvacuationStatus(oldStatus, evacuating, activeMigrations, c.clusterConfig.GetMigrationConfiguration().EvacuationTargetNodeSelector, v1.Now())
+	// timestamps are only set on phase changes, unchanged progress results in an equal status
+	if newStatus == nil || (oldStatus != nil && equality.Semantic.DeepEqual(oldStatus, newStatus)) {
+		return nil
+	}
+
+	if !exists {
+		nodeEvacuation := &migrationsv1.NodeEvacuation{
+			ObjectMeta: v1.ObjectMeta{
+				Name: node.Name,
+				OwnerReferences: []v1.OwnerReference{
+					*v1.NewControllerRef(node, k8sv1.SchemeGroupVersion.WithKind("Node")),
+				},
+			},
+		}
+		nodeEvacuation, err = c.clientset.NodeEvacuation().Create(context.Background(), nodeEvacuation, v1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			// the informer did not see our own creation yet
+			nodeEvacuation, err = c.clientset.NodeEvacuation().Get(context.Background(), node.Name, v1.GetOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create NodeEvacuation for node %s: %v", node.Name, err)
+		}
+		obj = nodeEvacuation
+	}
+
+	nodeEvacuation := obj.(*migrationsv1.NodeEvacuation).DeepCopy()
+	nodeEvacuation.Status = *newStatus
+	if _, err := c.clientset.NodeEvacuation().UpdateStatus(context.Background(), nodeEvacuation, v1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update NodeEvacuation status for node %s: %v", node.Name, err)
+	}
+	return nil
+}
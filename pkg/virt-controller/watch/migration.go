@@ -30,6 +30,7 @@ import (
 	"sync"
 	"time"
 
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"github.com/opencontainers/selinux/go-selinux"
 
 	"kubevirt.io/api/migrations/v1alpha1"
@@ -61,6 +62,8 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/network/vmispec"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
@@ -370,6 +373,26 @@ func (c *MigrationController) execute(key string) error {
 	return nil
 }
 
+// isBackendStorageShared checks if the backend storage PVC of the VMI, if there is any, can be mounted by the target pod.
+// The persistent TPM and EFI state is not copied during a migration, source and target pod mount the same PVC
+// and the target picks up the state the source left behind. VMIs whose state is kept on a ReadWriteOnce PVC are
+// therefore not migratable.
+func (c *MigrationController) isBackendStorageShared(vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	if !backendstorage.IsBackendStorageNeededForVMI(&vmi.Spec) {
+		return true, nil
+	}
+
+	obj, exists, err := c.pvcInformer.GetStore().GetByKey(controller.NamespacedKey(vmi.Namespace, backendstorage.PVCForVMI(vmi)))
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return true, nil
+	}
+
+	return storagetypes.HasSharedAccessMode(obj.(*k8sv1.PersistentVolumeClaim).Spec.AccessModes), nil
+}
+
 func (c *MigrationController) canMigrateVMI(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) (bool, error) {
 
 	if vmi.Status.MigrationState == nil {
@@ -507,7 +530,16 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 				return err
 			}
 
-			if canMigrate {
+			sharedBackendStorage, err := c.isBackendStorageShared(vmi)
+			if err != nil {
+				return err
+			}
+
+			if !sharedBackendStorage {
+				migrationCopy.Status.Phase = virtv1.MigrationFailed
+				c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "VMI is not eligible for migration because the migration target has to mount the backend storage PVC %s holding its TPM/EFI state, which is ReadWriteOnce. A vmStateStorageClass supporting ReadWriteMany is required.", backendstorage.PVCForVMI(vmi))
+				log.Log.Object(migration).Errorf("Migration object not eligible for migration because the target can not mount the ReadWriteOnce backend storage PVC %s", backendstorage.PVCForVMI(vmi))
+			} else if canMigrate {
 				migrationCopy.Status.Phase = virtv1.MigrationPending
 			} else {
 				// can not migrate because there is an active migration already
@@ -585,6 +617,7 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 		}
 	}
 
+	migrationCopy.Status.MigratedVolumes = migratedVolumesStatus(migrationCopy, vmi)
 	controller.SetVMIMigrationPhaseTransitionTimestamp(migration, migrationCopy)
 
 	if !equality.Semantic.DeepEqual(migration.Status, migrationCopy.Status) {
@@ -603,6 +636,56 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 	return nil
 }
 
+// migratedVolumesStatus reports the progress of the volumes the migration copies to new claims
+func migratedVolumesStatus(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) []virtv1.MigratedVolumeStatus {
+	if len(migration.Spec.MigratedVolumes) == 0 {
+		return nil
+	}
+
+	previousStatus := map[string]virtv1.MigratedVolumeStatus{}
+	for _, volumeStatus := range migration.Status.MigratedVolumes {
+		previousStatus[volumeStatus.VolumeName] = volumeStatus
+	}
+	vmiClaims := map[string]string{}
+	var migrationState *virtv1.VirtualMachineInstanceMigrationState
+	if vmi != nil {
+		for i := range vmi.Spec.Volumes {
+			vmiClaims[vmi.Spec.Volumes[i].Name] = storagetypes.PVCNameFromVirtVolume(&vmi.Spec.Volumes[i])
+		}
+		if vmi.Status.MigrationState != nil && vmi.Status.MigrationState.MigrationUID == migration.UID {
+			migrationState = vmi.Status.MigrationState
+		}
+	}
+
+	var volumesStatus []virtv1.MigratedVolumeStatus
+	for _, migratedVolume := range migration.Spec.MigratedVolumes {
+		volumeStatus := virtv1.MigratedVolumeStatus{
+			VolumeName:           migratedVolume.VolumeName,
+			SourceClaimName:      previousStatus[migratedVolume.VolumeName].SourceClaimName,
+			DestinationClaimName: migratedVolume.DestinationClaimName,
+		}
+		vmiClaim := vmiClaims[migratedVolume.VolumeName]
+		if volumeStatus.SourceClaimName == "" && vmiClaim != migratedVolume.DestinationClaimName {
+			volumeStatus.SourceClaimName = vmiClaim
+		}
+
+		switch {
+		case vmiClaim != "" && vmiClaim == migratedVolume.DestinationClaimName:
+			volumeStatus.Phase = virtv1.MigratedVolumeSwitched
+		case migration.Status.Phase == virtv1.MigrationFailed || (migrationState != nil && migrationState.Failed):
+			volumeStatus.Phase = virtv1.MigratedVolumeFailed
+		case migration.Status.Phase == virtv1.MigrationSucceeded || (migrationState != nil && migrationState.Completed):
+			volumeStatus.Phase = virtv1.MigratedVolumeCopied
+		case migrationState != nil && migrationState.StartTimestamp != nil:
+			volumeStatus.Phase = virtv1.MigratedVolumeCopying
+		default:
+			volumeStatus.Phase = virtv1.MigratedVolumePending
+		}
+		volumesStatus = append(volumesStatus, volumeStatus)
+	}
+	return volumesStatus
+}
+
 func setTargetPodSELinuxLevel(pod *k8sv1.Pod, vmiSeContext string) error {
 	// The target pod may share resources with the sources pod (RWX disks for example)
 	// Therefore, it needs to share the same SELinux categories to inherit the same permissions
@@ -634,8 +717,166 @@ func setTargetPodSELinuxLevel(pod *k8sv1.Pod, vmiSeContext string) error {
 	return nil
 }
 
+// applyAddedNodeSelector restricts the target pod to the nodes matching the migration's
+// additional node selector. Keys already present on the rendered pod come from the VMI
+// and take precedence, so the migration can only narrow the set of allowed target nodes.
+func applyAddedNodeSelector(pod *k8sv1.Pod, addedNodeSelector map[string]string) {
+	if len(addedNodeSelector) == 0 {
+		return
+	}
+	if pod.Spec.NodeSelector == nil {
+		pod.Spec.NodeSelector = map[string]string{}
+	}
+	for key, value := range addedNodeSelector {
+		if _, exists := pod.Spec.NodeSelector[key]; !exists {
+			pod.Spec.NodeSelector[key] = value
+		}
+	}
+}
+
+// requestSourcePodNetworkAddresses selects the default network of the target pod with the IPs and the MAC
+// address of the source pod, so that a guest which is bound to the pod network with a bridge keeps its
+// addresses after the migration. The CNI has to support assigning the same addresses to both pods.
+func requestSourcePodNetworkAddresses(pod *k8sv1.Pod, vmi *virtv1.VirtualMachineInstance, sourcePod *k8sv1.Pod) error {
+	podNetwork := vmispec.LookupPodNetwork(vmi.Spec.Networks)
+	if podNetwork == nil {
+		return nil
+	}
+	podIface := vmispec.LookupInterfaceByName(vmi.Spec.Domain.Devices.Interfaces, podNetwork.Name)
+	if podIface == nil || podIface.Bridge == nil {
+		return nil
+	}
+
+	defaultNetworkStatus, err := services.DefaultMultusNetworkStatus(sourcePod)
+	if err != nil {
+		return err
+	}
+	if defaultNetworkStatus == nil {
+		return fmt.Errorf("the source pod %s does not report the status of its default network", sourcePod.Name)
+	}
+
+	// The guest keeps the MAC address of the source pod interface, unless it is set in the spec
+	mac := defaultNetworkStatus.Mac
+	if ifaceStatus := vmispec.LookupInterfaceStatusByName(vmi.Status.Interfaces, podNetwork.Name); ifaceStatus != nil && ifaceStatus.MAC != "" {
+		mac = ifaceStatus.MAC
+	} else if podIface.MacAddress != "" {
+		mac = podIface.MacAddress
+	}
+
+	var ips []string
+	for _, podIP := range sourcePod.Status.PodIPs {
+		ips = append(ips, podIP.IP)
+	}
+	if len(ips) == 0 {
+		ips = defaultNetworkStatus.IPs
+	}
+
+	networkNamespace, networkName, err := lookupDefaultNetworkAttachment(pod, sourcePod, defaultNetworkStatus)
+	if err != nil {
+		return err
+	}
+	defaultNetwork, err := json.Marshal([]networkv1.NetworkSelectionElement{{
+		Namespace:  networkNamespace,
+		Name:       networkName,
+		IPRequest:  ips,
+		MacRequest: mac,
+	}})
+	if err != nil {
+		return err
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[services.MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION] = string(defaultNetwork)
+	return nil
+}
+
+// lookupDefaultNetworkAttachment returns the namespace and the name of the network attachment definition multus
+// uses for the default network of the pods. A default network requested by the pod annotation is kept. Otherwise
+// the network status of the source pod only names a network attachment definition if the multus cluster default
+// network is one, a network configured by a CNI configuration file on the node cannot be requested.
+func lookupDefaultNetworkAttachment(pod, sourcePod *k8sv1.Pod, defaultNetworkStatus *networkv1.NetworkStatus) (string, string, error) {
+	for _, p := range []*k8sv1.Pod{pod, sourcePod} {
+		if annotation := p.Annotations[services.MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION]; annotation != "" {
+			return parseDefaultNetworkAnnotation(annotation)
+		}
+	}
+	if parts := strings.SplitN(defaultNetworkStatus.Name, "/", 2); len(parts) == 2 {
+		return parts[0], parts[1], nil
+	}
+	return "", "", fmt.Errorf("the default network %s of the source pod %s is not a network attachment definition, "+
+		"request one with the %s annotation to preserve the pod network addresses",
+		defaultNetworkStatus.Name, sourcePod.Name, services.MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION)
+}
+
+// parseDefaultNetworkAnnotation parses the multus default network annotation, which is either the
+// [namespace/]name of a network attachment definition or a list with a single network selection element
+func parseDefaultNetworkAnnotation(annotation string) (string, string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(annotation), "[") {
+		if parts := strings.SplitN(annotation, "/", 2); len(parts) == 2 {
+			return parts[0], parts[1], nil
+		}
+		return "", annotation, nil
+	}
+	var networks []networkv1.NetworkSelectionElement
+	if err := json.Unmarshal([]byte(annotation), &networks); err != nil {
+		return "", "", fmt.Errorf("failed to parse the %s annotation: %v", services.MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION, err)
+	}
+	if len(networks) != 1 {
+		return "", "", fmt.Errorf("the %s annotation must select a single network", services.MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION)
+	}
+	return networks[0].Namespace, networks[0].Name, nil
+}
+
+// vmiWithMigratedVolumes returns a copy of the VMI whose migrated volumes are backed by their destination claims
+func vmiWithMigratedVolumes(vmi *virtv1.VirtualMachineInstance, migratedVolumes []virtv1.MigratedVolume) *virtv1.VirtualMachineInstance {
+	if len(migratedVolumes) == 0 {
+		return vmi
+	}
+	vmiCopy := vmi.DeepCopy()
+	vmiCopy.Spec.Volumes = volumesWithMigratedClaims(vmiCopy.Spec.Volumes, migratedVolumes)
+	return vmiCopy
+}
+
+// volumesWithMigratedClaims returns a copy of the volumes whose migrated volumes are backed by their destination claims
+func volumesWithMigratedClaims(volumes []virtv1.Volume, migratedVolumes []virtv1.MigratedVolume) []virtv1.Volume {
+	destinationClaims := map[string]string{}
+	for _, migratedVolume := range migratedVolumes {
+		destinationClaims[migratedVolume.VolumeName] = migratedVolume.DestinationClaimName
+	}
+	newVolumes := make([]virtv1.Volume, 0, len(volumes))
+	for _, volume := range volumes {
+		if claimName, ok := destinationClaims[volume.Name]; ok {
+			volume = migrations.VolumeWithClaim(&volume, claimName)
+		}
+		newVolumes = append(newVolumes, volume)
+	}
+	return newVolumes
+}
+
+// dataVolumeTemplatesWithoutMigratedVolumes returns the dataVolumeTemplates of the VM
+// which are not the source of a migrated volume
+func dataVolumeTemplatesWithoutMigratedVolumes(vm *virtv1.VirtualMachine, migratedVolumes []virtv1.MigratedVolume) []virtv1.DataVolumeTemplateSpec {
+	migratedDataVolumes := map[string]struct{}{}
+	for _, migratedVolume := range migratedVolumes {
+		for _, volume := range vm.Spec.Template.Spec.Volumes {
+			if volume.Name == migratedVolume.VolumeName && volume.DataVolume != nil {
+				migratedDataVolumes[volume.DataVolume.Name] = struct{}{}
+			}
+		}
+	}
+	dataVolumeTemplates := []virtv1.DataVolumeTemplateSpec{}
+	for _, dataVolumeTemplate := range vm.Spec.DataVolumeTemplates {
+		if _, ok := migratedDataVolumes[dataVolumeTemplate.Name]; !ok {
+			dataVolumeTemplates = append(dataVolumeTemplates, dataVolumeTemplate)
+		}
+	}
+	return dataVolumeTemplates
+}
+
 func (c *MigrationController) createTargetPod(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, sourcePod *k8sv1.Pod) error {
-	templatePod, err := c.templateService.RenderMigrationManifest(vmi, sourcePod)
+	templatePod, err := c.templateService.RenderMigrationManifest(vmiWithMigratedVolumes(vmi, migration.Spec.MigratedVolumes), sourcePod)
 	if err != nil {
 		return fmt.Errorf("failed to render launch manifest: %v", err)
 	}
@@ -662,6 +903,15 @@ func (c *MigrationController) createTargetPod(migration *virtv1.VirtualMachineIn
 		templatePod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(templatePod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, antiAffinityTerm)
 	}
 
+	applyAddedNodeSelector(templatePod, migration.Spec.AddedNodeSelector)
+
+	if _, preserve := vmi.Annotations[virtv1.PreservePodNetworkAddressesAnnotation]; preserve {
+		if err := requestSourcePodNetworkAddresses(templatePod, vmi, sourcePod); err != nil {
+			c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedCreatePodReason, "Unable to preserve the pod network addresses: %v", err)
+			return err
+		}
+	}
+
 	templatePod.ObjectMeta.Labels[virtv1.MigrationJobLabel] = string(migration.UID)
 	templatePod.ObjectMeta.Annotations[virtv1.MigrationJobNameAnnotation] = migration.Name
 
@@ -863,6 +1113,8 @@ func (c *MigrationController) handleTargetPodHandoff(migration *virtv1.VirtualMa
 		TargetPod:    pod.Name,
 	}
 
+	vmiCopy.Status.MigrationState.MigratedVolumes = getMigratedVolumesInfo(vmi, migration.Spec.MigratedVolumes)
+
 	// By setting this label, virt-handler on the target node will receive
 	// the vmi and prepare the local environment for the migration
 	vmiCopy.ObjectMeta.Labels[virtv1.MigrationTargetNodeNameLabel] = pod.Spec.NodeName
@@ -918,6 +1170,87 @@ func (c *MigrationController) handleTargetPodHandoff(migration *virtv1.VirtualMa
 	return nil
 }
 
+func getMigratedVolumesInfo(vmi *virtv1.VirtualMachineInstance, migratedVolumes []virtv1.MigratedVolume) []virtv1.StorageMigratedVolumeInfo {
+	var migratedVolumesInfo []virtv1.StorageMigratedVolumeInfo
+	sourceClaims := storagetypes.GetPVCsFromVolumes(vmi.Spec.Volumes)
+	for _, migratedVolume := range migratedVolumes {
+		migratedVolumesInfo = append(migratedVolumesInfo, virtv1.StorageMigratedVolumeInfo{
+			VolumeName:           migratedVolume.VolumeName,
+			SourceClaimName:      sourceClaims[migratedVolume.VolumeName],
+			DestinationClaimName: migratedVolume.DestinationClaimName,
+		})
+	}
+	return migratedVolumesInfo
+}
+
+// handleMigratedVolumesSwap switches the migrated volumes of the VMI, and of the VM owning it,
+// to their destination claims once the migration succeeded
+func (c *MigrationController) handleMigratedVolumesSwap(vmi *virtv1.VirtualMachineInstance) error {
+	migrationState := vmi.Status.MigrationState
+	if !migrationState.Completed || migrationState.Failed || len(migrationState.MigratedVolumes) == 0 {
+		return nil
+	}
+
+	var migratedVolumes []virtv1.MigratedVolume
+	for _, migratedVolume := range migrationState.MigratedVolumes {
+		migratedVolumes = append(migratedVolumes, virtv1.MigratedVolume{
+			VolumeName:           migratedVolume.VolumeName,
+			DestinationClaimName: migratedVolume.DestinationClaimName,
+		})
+	}
+
+	newVolumes := volumesWithMigratedClaims(vmi.Spec.Volumes, migratedVolumes)
+	if !equality.Semantic.DeepEqual(vmi.Spec.Volumes, newVolumes) {
+		patchBytes, err := patch.GenerateTestReplacePatch("/spec/volumes", vmi.Spec.Volumes, newVolumes)
+		if err != nil {
+			return err
+		}
+		if _, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, patchBytes, &v1.PatchOptions{}); err != nil {
+			return fmt.Errorf("failed to switch the volumes of vmi %s/%s to the migrated claims: %v", vmi.Namespace, vmi.Name, err)
+		}
+		log.Log.Object(vmi).Infof("Switched migrated volumes of vmi %s/%s to their destination claims", vmi.Namespace, vmi.Name)
+	}
+
+	owner := v1.GetControllerOf(vmi)
+	if owner == nil || owner.Kind != virtv1.VirtualMachineGroupVersionKind.Kind {
+		return nil
+	}
+	vm, err := c.clientset.VirtualMachine(vmi.Namespace).Get(context.Background(), owner.Name, &v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if vm.UID != owner.UID || vm.Spec.Template == nil {
+		return nil
+	}
+	newVolumes = volumesWithMigratedClaims(vm.Spec.Template.Spec.Volumes, migratedVolumes)
+	if equality.Semantic.DeepEqual(vm.Spec.Template.Spec.Volumes, newVolumes) {
+		return nil
+	}
+	patchOps := []patch.PatchOperation{
+		{Op: patch.PatchTestOp, Path: "/spec/template/spec/volumes", Value: vm.Spec.Template.Spec.Volumes},
+		{Op: patch.PatchReplaceOp, Path: "/spec/template/spec/volumes", Value: newVolumes},
+	}
+	// The VM admitter rejects dataVolumeTemplates no volume refers to, drop the
+	// templates of the migrated DataVolumes together with their volumes
+	newDataVolumeTemplates := dataVolumeTemplatesWithoutMigratedVolumes(vm, migratedVolumes)
+	if len(newDataVolumeTemplates) != len(vm.Spec.DataVolumeTemplates) {
+		patchOps = append(patchOps,
+			patch.PatchOperation{Op: patch.PatchTestOp, Path: "/spec/dataVolumeTemplates", Value: vm.Spec.DataVolumeTemplates},
+			patch.PatchOperation{Op: patch.PatchReplaceOp, Path: "/spec/dataVolumeTemplates", Value: newDataVolumeTemplates},
+		)
+	}
+	patchBytes, err := patch.GeneratePatchPayload(patchOps...)
+	if err != nil {
+		return err
+	}
+	if _, err = c.clientset.VirtualMachine(vm.Namespace).Patch(context.Background(), vm.Name, types.JSONPatchType, patchBytes, &v1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to switch the volumes of vm %s/%s to the migrated claims: %v", vm.Namespace, vm.Name, err)
+	}
+	return nil
+}
+
 func (c *MigrationController) markMigrationAbortInVmiStatus(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
 
 	if vmi.Status.MigrationState == nil {
@@ -1222,7 +1555,7 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 
 	if migrationFinalizedOnVMI := vmi.Status.MigrationState != nil && vmi.Status.MigrationState.MigrationUID == migration.UID &&
 		vmi.Status.MigrationState.EndTimestamp != nil; migrationFinalizedOnVMI {
-		return nil
+		return c.handleMigratedVolumesSwap(vmi)
 	}
 
 	canMigrate, err := c.canMigrateVMI(migration, vmi)
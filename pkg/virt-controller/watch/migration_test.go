@@ -40,6 +40,7 @@ import (
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 
 	"github.com/golang/mock/gomock"
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
@@ -63,6 +64,7 @@ import (
 	fakenetworkclient "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
 
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
@@ -852,6 +854,130 @@ var _ = Describe("Migration watcher", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
 		})
 
+		It("should create target pod with the added node selector without overriding the VMI node selector", func() {
+			vmi := newVirtualMachine("testvmi", virtv1.Running)
+			vmi.Spec.NodeSelector = map[string]string{
+				"zone": "a",
+			}
+			migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPending)
+			migration.Spec.AddedNodeSelector = map[string]string{
+				"kubernetes.io/hostname": "node02",
+				"zone":                   "b",
+			}
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				update, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+				pod := update.GetObject().(*k8sv1.Pod)
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue("kubernetes.io/hostname", "node02"))
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue("zone", "a"))
+				return true, update.GetObject(), nil
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		Context("with preserved pod network addresses", func() {
+			newBridgeVirtualMachine := func() *virtv1.VirtualMachineInstance {
+				vmi := newVirtualMachine("testvmi", virtv1.Running)
+				vmi.Annotations[virtv1.PreservePodNetworkAddressesAnnotation] = ""
+				vmi.Spec.Domain.Devices.Interfaces = []virtv1.Interface{*virtv1.DefaultBridgeNetworkInterface()}
+				vmi.Spec.Networks = []virtv1.Network{*virtv1.DefaultPodNetwork()}
+				vmi.Status.Interfaces = []virtv1.VirtualMachineInstanceNetworkInterface{{Name: "default", MAC: "02:00:00:00:00:01"}}
+				return vmi
+			}
+
+			addVirtualMachineInstanceWithSourcePod := func(vmi *virtv1.VirtualMachineInstance, sourcePod *k8sv1.Pod) {
+				Expect(podInformer.GetStore().Add(sourcePod)).To(Succeed())
+				mockQueue.ExpectAdds(1)
+				vmiSource.Add(vmi)
+				mockQueue.Wait()
+			}
+
+			It("should create target pod requesting the IPs and the MAC address of the source pod", func() {
+				vmi := newBridgeVirtualMachine()
+				migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPending)
+				sourcePod := newSourcePodForVirtualMachine(vmi)
+				sourcePod.Annotations[networkv1.NetworkStatusAnnot] = `[{"name":"kube-system/pod-network","interface":"eth0","ips":["10.244.0.5"],"mac":"0a:58:0a:f4:00:05","default":true}]`
+				sourcePod.Status.PodIPs = []k8sv1.PodIP{{IP: "10.244.0.5"}, {IP: "fd00:10:244::5"}}
+
+				addMigration(migration)
+				addVirtualMachineInstanceWithSourcePod(vmi, sourcePod)
+
+				kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+					update, ok := action.(testing.CreateAction)
+					Expect(ok).To(BeTrue())
+					pod := update.GetObject().(*k8sv1.Pod)
+					Expect(pod.Annotations).To(HaveKeyWithValue(services.MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION,
+						`[{"name":"pod-network","namespace":"kube-system","ips":["10.244.0.5","fd00:10:244::5"],"mac":"02:00:00:00:00:01"}]`))
+					return true, update.GetObject(), nil
+				})
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			})
+
+			It("should keep the requested default network when the network status does not name a network attachment definition", func() {
+				vmi := newBridgeVirtualMachine()
+				vmi.Annotations[services.MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION] = "kube-system/pod-network"
+				migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPending)
+				sourcePod := newSourcePodForVirtualMachine(vmi)
+				sourcePod.Annotations[services.MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION] = "kube-system/pod-network"
+				sourcePod.Annotations[networkv1.NetworkStatusAnnot] = `[{"name":"ovn-kubernetes","interface":"eth0","ips":["10.244.0.5"],"mac":"0a:58:0a:f4:00:05","default":true}]`
+				sourcePod.Status.PodIPs = []k8sv1.PodIP{{IP: "10.244.0.5"}}
+
+				addMigration(migration)
+				addVirtualMachineInstanceWithSourcePod(vmi, sourcePod)
+
+				kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+					update, ok := action.(testing.CreateAction)
+					Expect(ok).To(BeTrue())
+					pod := update.GetObject().(*k8sv1.Pod)
+					Expect(pod.Annotations).To(HaveKeyWithValue(services.MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION,
+						`[{"name":"pod-network","namespace":"kube-system","ips":["10.244.0.5"],"mac":"02:00:00:00:00:01"}]`))
+					return true, update.GetObject(), nil
+				})
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			})
+
+			It("should not create target pod when the default network is not a network attachment definition", func() {
+				vmi := newBridgeVirtualMachine()
+				migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPending)
+				sourcePod := newSourcePodForVirtualMachine(vmi)
+				sourcePod.Annotations[networkv1.NetworkStatusAnnot] = `[{"name":"ovn-kubernetes","interface":"eth0","ips":["10.244.0.5"],"mac":"0a:58:0a:f4:00:05","default":true}]`
+
+				addMigration(migration)
+				addVirtualMachineInstanceWithSourcePod(vmi, sourcePod)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedCreatePodReason)
+				Expect(kubeClient.Actions()).To(BeEmpty())
+			})
+
+			It("should not create target pod when the source pod does not report its default network", func() {
+				vmi := newBridgeVirtualMachine()
+				migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPending)
+
+				addMigration(migration)
+				addVirtualMachineInstance(vmi)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedCreatePodReason)
+				Expect(kubeClient.Actions()).To(BeEmpty())
+			})
+		})
+
 		It("should place migration in scheduling state if pod exists", func() {
 			vmi := newVirtualMachine("testvmi", virtv1.Running)
 			migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPending)
@@ -1083,6 +1209,30 @@ var _ = Describe("Migration watcher", func() {
 			Entry("in scheduling state", virtv1.MigrationScheduling),
 			Entry("in target ready state", virtv1.MigrationTargetReady),
 		)
+		It("the backend storage PVC is not shared", func() {
+			vmi := newVirtualMachine("testvmi", virtv1.Running)
+			vmi.Spec.Domain.Devices.TPM = &virtv1.TPMDevice{Persistent: pointer.Bool(true)}
+			migration := newMigration("testmigration", vmi.Name, virtv1.MigrationPhaseUnset)
+			pvc := &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      backendstorage.PVCForVMI(vmi),
+					Namespace: vmi.Namespace,
+				},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce},
+				},
+			}
+			Expect(pvcInformer.GetStore().Add(pvc)).To(Succeed())
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectMigrationFailedState(migration)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, "which is ReadWriteOnce")
+		})
 		DescribeTable("VMI's migrate state moves to final state", func(phase virtv1.VirtualMachineInstanceMigrationPhase) {
 			vmi := newVirtualMachine("testvmi", virtv1.Running)
 			migration := newMigration("testmigration", vmi.Name, phase)
@@ -1577,6 +1727,268 @@ var _ = Describe("Migration watcher", func() {
 		)
 	})
 
+	Context("Migration with migrated volumes", func() {
+		var vmInterface *kubecli.MockVirtualMachineInterface
+
+		newVMIWithVolume := func() *virtv1.VirtualMachineInstance {
+			vmi := newVirtualMachine("testvmi", virtv1.Running)
+			vmi.Spec.Volumes = []virtv1.Volume{{
+				Name: "disk0",
+				VolumeSource: virtv1.VolumeSource{
+					PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "src-pvc",
+						},
+					},
+				},
+			}}
+			return vmi
+		}
+
+		newMigrationWithVolumes := func(vmi *virtv1.VirtualMachineInstance, phase virtv1.VirtualMachineInstanceMigrationPhase) *virtv1.VirtualMachineInstanceMigration {
+			migration := newMigration("testmigration", vmi.Name, phase)
+			migration.Spec.MigratedVolumes = []virtv1.MigratedVolume{{
+				VolumeName:           "disk0",
+				DestinationClaimName: "dst-pvc",
+			}}
+			return migration
+		}
+
+		migratedVolumes := []virtv1.StorageMigratedVolumeInfo{{
+			VolumeName:           "disk0",
+			SourceClaimName:      "src-pvc",
+			DestinationClaimName: "dst-pvc",
+		}}
+
+		shouldExpectMigratedVolumesPhase := func(phase virtv1.MigratedVolumePhase) {
+			migrationInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) (interface{}, interface{}) {
+				Expect(arg.(*virtv1.VirtualMachineInstanceMigration).Status.MigratedVolumes).To(Equal([]virtv1.MigratedVolumeStatus{{
+					VolumeName:           "disk0",
+					SourceClaimName:      "src-pvc",
+					DestinationClaimName: "dst-pvc",
+					Phase:                phase,
+				}}))
+				return arg, nil
+			})
+		}
+
+		BeforeEach(func() {
+			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			virtClient.EXPECT().VirtualMachine(k8sv1.NamespaceDefault).Return(vmInterface).AnyTimes()
+		})
+
+		It("should create the target pod with the destination claims", func() {
+			vmi := newVMIWithVolume()
+			migration := newMigrationWithVolumes(vmi, virtv1.MigrationPending)
+			for _, claimName := range []string{"src-pvc", "dst-pvc"} {
+				Expect(pvcInformer.GetStore().Add(&k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Namespace: vmi.Namespace, Name: claimName},
+				})).To(Succeed())
+			}
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				update, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+				var claimNames []string
+				for _, volume := range update.GetObject().(*k8sv1.Pod).Spec.Volumes {
+					if volume.PersistentVolumeClaim != nil {
+						claimNames = append(claimNames, volume.PersistentVolumeClaim.ClaimName)
+					}
+				}
+				Expect(claimNames).To(ConsistOf("dst-pvc"))
+				return true, update.GetObject(), nil
+			})
+			shouldExpectMigratedVolumesPhase(virtv1.MigratedVolumePending)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		It("should hand the migrated volumes over to the target virt-handler", func() {
+			vmi := newVMIWithVolume()
+			vmi.Status.NodeName = "node02"
+			migration := newMigrationWithVolumes(vmi, virtv1.MigrationScheduled)
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node01"
+			pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+				Name: "compute", State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}},
+			}}
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			patch := fmt.Sprintf(`[{ "op": "add", "path": "/status/migrationState", "value": {"targetNode":"node01","targetPod":"%s","sourceNode":"node02","migrationUid":"testmigration",%s,"migratedVolumes":[{"volumeName":"disk0","sourceClaimName":"src-pvc","destinationClaimName":"dst-pvc"}]} }, { "op": "test", "path": "/metadata/labels", "value": {} }, { "op": "replace", "path": "/metadata/labels", "value": {"kubevirt.io/migrationTargetNodeName":"node01"} }]`, pod.Name, getMigrationConfigPatch())
+			shouldExpectVirtualMachineInstancePatch(vmi, patch)
+			shouldExpectMigratedVolumesPhase(virtv1.MigratedVolumePending)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulHandOverPodReason)
+		})
+
+		DescribeTable("once the migration is finalized", func(failed bool, expectedPhase virtv1.MigratedVolumePhase) {
+			vmi := newVMIWithVolume()
+			vmi.Status.NodeName = "node02"
+			vm := &virtv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: vmi.Name, Namespace: vmi.Namespace, UID: "vm-uid"},
+				Spec: virtv1.VirtualMachineSpec{
+					Template: &virtv1.VirtualMachineInstanceTemplateSpec{
+						Spec: *vmi.Spec.DeepCopy(),
+					},
+				},
+			}
+			vmi.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(vm, virtv1.VirtualMachineGroupVersionKind),
+			}
+			migration := newMigrationWithVolumes(vmi, virtv1.MigrationSucceeded)
+			if failed {
+				migration.Status.Phase = virtv1.MigrationFailed
+			}
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node01"
+
+			vmi.Status.MigrationState = &virtv1.VirtualMachineInstanceMigrationState{
+				MigrationUID:      migration.UID,
+				TargetNode:        "node01",
+				SourceNode:        "node02",
+				TargetNodeAddress: "10.10.10.10:1234",
+				StartTimestamp:    now(),
+				EndTimestamp:      now(),
+				Failed:            failed,
+				Completed:         true,
+				MigratedVolumes:   migratedVolumes,
+			}
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			if !failed {
+				newVolumes := []virtv1.Volume{{
+					Name: "disk0",
+					VolumeSource: virtv1.VolumeSource{
+						PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "dst-pvc",
+							},
+						},
+					},
+				}}
+				vmiPatch, err := patch.GenerateTestReplacePatch("/spec/volumes", vmi.Spec.Volumes, newVolumes)
+				Expect(err).ToNot(HaveOccurred())
+				shouldExpectVirtualMachineInstancePatch(vmi, string(vmiPatch))
+
+				vmPatch, err := patch.GenerateTestReplacePatch("/spec/template/spec/volumes", vm.Spec.Template.Spec.Volumes, newVolumes)
+				Expect(err).ToNot(HaveOccurred())
+				vmInterface.EXPECT().Get(context.Background(), vm.Name, gomock.Any()).Return(vm, nil)
+				vmInterface.EXPECT().Patch(context.Background(), vm.Name, types.JSONPatchType, vmPatch, &metav1.PatchOptions{}).Return(vm, nil)
+			}
+			migrationInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) (interface{}, interface{}) {
+				updatedMigration := arg.(*virtv1.VirtualMachineInstanceMigration)
+				Expect(updatedMigration.Status.MigrationState).To(Equal(vmi.Status.MigrationState))
+				Expect(updatedMigration.Finalizers).To(BeEmpty())
+				Expect(updatedMigration.Status.MigratedVolumes).To(HaveLen(1))
+				Expect(updatedMigration.Status.MigratedVolumes[0].Phase).To(Equal(expectedPhase))
+				return arg, nil
+			})
+
+			controller.Execute()
+		},
+			Entry("should switch the VMI and VM volumes to the destination claims on success", false, virtv1.MigratedVolumeCopied),
+			Entry("should keep the source claims on failure", true, virtv1.MigratedVolumeFailed),
+		)
+
+		It("should drop the dataVolumeTemplate of a migrated DataVolume from the VM", func() {
+			vmi := newVMIWithVolume()
+			vmi.Status.NodeName = "node02"
+			vmi.Spec.Volumes[0].VolumeSource = virtv1.VolumeSource{
+				DataVolume: &virtv1.DataVolumeSource{Name: "src-pvc"},
+			}
+			vm := &virtv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: vmi.Name, Namespace: vmi.Namespace, UID: "vm-uid"},
+				Spec: virtv1.VirtualMachineSpec{
+					DataVolumeTemplates: []virtv1.DataVolumeTemplateSpec{
+						{ObjectMeta: metav1.ObjectMeta{Name: "src-pvc"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "other-dv"}},
+					},
+					Template: &virtv1.VirtualMachineInstanceTemplateSpec{
+						Spec: *vmi.Spec.DeepCopy(),
+					},
+				},
+			}
+			vmi.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(vm, virtv1.VirtualMachineGroupVersionKind),
+			}
+			migration := newMigrationWithVolumes(vmi, virtv1.MigrationSucceeded)
+			vmi.Status.MigrationState = &virtv1.VirtualMachineInstanceMigrationState{
+				MigrationUID:    migration.UID,
+				TargetNode:      "node01",
+				SourceNode:      "node02",
+				StartTimestamp:  now(),
+				EndTimestamp:    now(),
+				Completed:       true,
+				MigratedVolumes: migratedVolumes,
+			}
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			newVolumes := []virtv1.Volume{{
+				Name: "disk0",
+				VolumeSource: virtv1.VolumeSource{
+					PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "dst-pvc",
+						},
+					},
+				},
+			}}
+			vmiPatch, err := patch.GenerateTestReplacePatch("/spec/volumes", vmi.Spec.Volumes, newVolumes)
+			Expect(err).ToNot(HaveOccurred())
+			shouldExpectVirtualMachineInstancePatch(vmi, string(vmiPatch))
+
+			vmPatch, err := patch.GeneratePatchPayload(
+				patch.PatchOperation{Op: patch.PatchTestOp, Path: "/spec/template/spec/volumes", Value: vm.Spec.Template.Spec.Volumes},
+				patch.PatchOperation{Op: patch.PatchReplaceOp, Path: "/spec/template/spec/volumes", Value: newVolumes},
+				patch.PatchOperation{Op: patch.PatchTestOp, Path: "/spec/dataVolumeTemplates", Value: vm.Spec.DataVolumeTemplates},
+				patch.PatchOperation{Op: patch.PatchReplaceOp, Path: "/spec/dataVolumeTemplates", Value: vm.Spec.DataVolumeTemplates[1:]},
+			)
+			Expect(err).ToNot(HaveOccurred())
+			vmInterface.EXPECT().Get(context.Background(), vm.Name, gomock.Any()).Return(vm, nil)
+			vmInterface.EXPECT().Patch(context.Background(), vm.Name, types.JSONPatchType, vmPatch, &metav1.PatchOptions{}).Return(vm, nil)
+			shouldExpectMigratedVolumesPhase(virtv1.MigratedVolumeCopied)
+
+			controller.Execute()
+		})
+
+		It("should report the migrated volumes as switched once the VMI uses the destination claims", func() {
+			vmi := newVMIWithVolume()
+			vmi.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = "dst-pvc"
+			migration := newMigrationWithVolumes(vmi, virtv1.MigrationSucceeded)
+			migration.Finalizers = []string{}
+			migration.Status.MigratedVolumes = []virtv1.MigratedVolumeStatus{{
+				VolumeName:           "disk0",
+				SourceClaimName:      "src-pvc",
+				DestinationClaimName: "dst-pvc",
+				Phase:                virtv1.MigratedVolumeCopied,
+			}}
+			vmi.Status.MigrationState = &virtv1.VirtualMachineInstanceMigrationState{
+				MigrationUID:    migration.UID,
+				Completed:       true,
+				MigratedVolumes: migratedVolumes,
+			}
+			migration.Status.MigrationState = vmi.Status.MigrationState
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectMigratedVolumesPhase(virtv1.MigratedVolumeSwitched)
+
+			controller.Execute()
+		})
+	})
+
 	Context("Migration with protected VMI (PDB)", func() {
 		It("should update PDB before starting the migration", func() {
 			vmi := newVirtualMachine("testvmi", virtv1.Running)
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -13,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 	"k8s.io/utils/trace"
 
@@ -50,6 +52,7 @@ type PoolController struct {
 	expectations     *controller.UIDTrackingControllerExpectations
 	burstReplicas    uint
 	statusUpdater    *status.VMPStatusUpdater
+	recommendations  *autoscalerRecommendations
 }
 
 const (
@@ -90,6 +93,7 @@ func NewPoolController(clientset kubecli.KubevirtClient,
 		expectations:     controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
 		burstReplicas:    burstReplicas,
 		statusUpdater:    status.NewVMPStatusUpdater(clientset),
+		recommendations:  newAutoscalerRecommendations(),
 	}
 
 	_, err := c.poolInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -525,10 +529,10 @@ func (c *PoolController) scaleIn(pool *poolv1.VirtualMachinePool, vms []*virtv1.
 		count = len(elgibleVMs)
 	}
 
-	// random delete strategy
-	rand.Shuffle(len(elgibleVMs), func(i, j int) {
-		elgibleVMs[i], elgibleVMs[j] = elgibleVMs[j], elgibleVMs[i]
-	})
+	// the order only matters when some of the VMs are kept
+	if count < len(elgibleVMs) {
+		c.sortScaleInCandidates(pool, elgibleVMs)
+	}
 
 	log.Log.Object(pool).Infof("Removing %d VMs from pool", count)
 
@@ -568,6 +572,112 @@ func (c *PoolController) scaleIn(pool *poolv1.VirtualMachinePool, vms []*virtv1.
 	return nil
 }
 
+const (
+	// maxParallelUserListRequests bounds the guest agent user list requests made while ordering scale-in candidates
+	maxParallelUserListRequests = 8
+	// userListTimeout bounds a single guest agent user list request, a slow agent must not stall the scale-in
+	userListTimeout = 5 * time.Second
+)
+
+type scaleInCandidate struct {
+	vm            *virtv1.VirtualMachine
+	deletionCost  int
+	ready         bool
+	loggedInUsers bool
+}
+
+// sortScaleInCandidates orders the VMs according to the scale-in policy of the pool,
+// the VMs which should be removed first come first.
+func (c *PoolController) sortScaleInCandidates(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine) {
+	policy := pool.Spec.ScaleInPolicy
+	if policy == nil {
+		policy = &poolv1.VirtualMachinePoolScaleInPolicy{}
+	}
+
+	// VMs with equal preference are removed in random order unless another order is requested
+	rand.Shuffle(len(vms), func(i, j int) {
+		vms[i], vms[j] = vms[j], vms[i]
+	})
+
+	candidates := make([]scaleInCandidate, len(vms))
+	for i, vm := range vms {
+		candidates[i] = scaleInCandidate{
+			vm:           vm,
+			deletionCost: deletionCost(vm),
+		}
+		if policy.PreferNotReady {
+			candidates[i].ready = controller.NewVirtualMachineConditionManager().HasConditionWithStatus(vm, virtv1.VirtualMachineConditionType(k8score.PodReady), k8score.ConditionTrue)
+		}
+	}
+	if policy.PreferNoLoggedInUsers {
+		// Every lookup is a round trip to the guest agent, do them in parallel
+		workqueue.ParallelizeUntil(context.Background(), maxParallelUserListRequests, len(candidates), func(i int) {
+			candidates[i].loggedInUsers = c.hasLoggedInUsers(candidates[i].vm)
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.deletionCost != b.deletionCost {
+			return a.deletionCost < b.deletionCost
+		}
+		if a.ready != b.ready {
+			return !a.ready
+		}
+		if a.loggedInUsers != b.loggedInUsers {
+			return !a.loggedInUsers
+		}
+		switch policy.Order {
+		case poolv1.VirtualMachinePoolScaleInOldest:
+			return a.vm.CreationTimestamp.Before(&b.vm.CreationTimestamp)
+		case poolv1.VirtualMachinePoolScaleInNewest:
+			return b.vm.CreationTimestamp.Before(&a.vm.CreationTimestamp)
+		}
+		return false
+	})
+
+	for i := range candidates {
+		vms[i] = candidates[i].vm
+	}
+}
+
+func deletionCost(vm *virtv1.VirtualMachine) int {
+	value, exists := vm.Annotations[poolv1.VirtualMachinePoolDeletionCostAnnotation]
+	if !exists {
+		return 0
+	}
+	cost, err := strconv.Atoi(value)
+	if err != nil {
+		log.Log.Object(vm).Warningf("Ignoring invalid %s annotation %q", poolv1.VirtualMachinePoolDeletionCostAnnotation, value)
+		return 0
+	}
+	return cost
+}
+
+// hasLoggedInUsers checks with the guest agent whether users are logged into the VM.
+// VMs without a connected agent are treated as having logged in users, VMs whose agent
+// does not answer in time as having none.
+func (c *PoolController) hasLoggedInUsers(vm *virtv1.VirtualMachine) bool {
+	obj, exists, _ := c.vmiInformer.GetStore().GetByKey(controller.NamespacedKey(vm.Namespace, vm.Name))
+	if !exists {
+		return false
+	}
+	vmi := obj.(*virtv1.VirtualMachineInstance)
+
+	if !controller.NewVirtualMachineInstanceConditionManager().HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceAgentConnected, k8score.ConditionTrue) {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), userListTimeout)
+	defer cancel()
+	userList, err := c.clientset.VirtualMachineInstance(vmi.Namespace).UserList(ctx, vmi.Name)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("Failed to fetch the logged in guest users")
+		return false
+	}
+	return len(userList.Items) > 0
+}
+
 func generateVMName(index int, baseName string) string {
 	return fmt.Sprintf("%s-%d", baseName, index)
 }
@@ -874,34 +984,125 @@ func (c *PoolController) opportunisticUpdate(pool *poolv1.VirtualMachinePool, vm
 	return nil
 }
 
-func (c *PoolController) proactiveUpdate(pool *poolv1.VirtualMachinePool, vmUpdatedList []*virtv1.VirtualMachine) error {
-	var wg sync.WaitGroup
-	wg.Add(len(vmUpdatedList))
-	errChan := make(chan error, len(vmUpdatedList))
-	for i := 0; i < len(vmUpdatedList); i++ {
-		go func(idx int) {
-			defer wg.Done()
-			vm := vmUpdatedList[idx]
+type proactiveVMIUpdate struct {
+	vm         *virtv1.VirtualMachine
+	vmi        *virtv1.VirtualMachineInstance
+	updateType proactiveUpdateType
+}
 
-			vmiKey := controller.NamespacedKey(vm.Namespace, vm.Name)
-			obj, exists, _ := c.vmiInformer.GetStore().GetByKey(vmiKey)
-			if !exists {
-				// no VMI to update
-				return
-			}
-			vmi := obj.(*virtv1.VirtualMachineInstance)
-			if vmi.DeletionTimestamp != nil {
-				// ignore VMIs which are already deleting
-				return
+func updateStrategyType(pool *poolv1.VirtualMachinePool) poolv1.VirtualMachinePoolUpdateStrategyType {
+	if pool.Spec.UpdateStrategy == nil || pool.Spec.UpdateStrategy.Type == "" {
+		return poolv1.VirtualMachinePoolProactiveUpdate
+	}
+	return pool.Spec.UpdateStrategy.Type
+}
+
+// allowedRestarts returns how many outdated VMIs can be restarted without exceeding
+// the maxUnavailable of the update strategy. A negative value means no limit.
+func (c *PoolController) allowedRestarts(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine) (int, error) {
+	if pool.Spec.UpdateStrategy == nil || pool.Spec.UpdateStrategy.MaxUnavailable == nil {
+		return -1, nil
+	}
+
+	replicas := int(pointer.Int32Deref(pool.Spec.Replicas, 1))
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(pool.Spec.UpdateStrategy.MaxUnavailable, replicas, false)
+	if err != nil {
+		return 0, err
+	}
+	if maxUnavailable < 1 {
+		// always allow progress
+		maxUnavailable = 1
+	}
+
+	unavailable := len(vms) - len(c.filterReadyVMs(vms))
+	if unavailable >= maxUnavailable {
+		return 0, nil
+	}
+	return maxUnavailable - unavailable, nil
+}
+
+// collectProactiveUpdates determines which of the VMIs of the given VMs have to be updated
+// and how, according to the update strategy of the pool.
+func (c *PoolController) collectProactiveUpdates(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine) ([]proactiveVMIUpdate, error) {
+	strategy := updateStrategyType(pool)
+
+	var updates []proactiveVMIUpdate
+	for _, vm := range vms {
+		vmiKey := controller.NamespacedKey(vm.Namespace, vm.Name)
+		obj, exists, _ := c.vmiInformer.GetStore().GetByKey(vmiKey)
+		if !exists {
+			// no VMI to update
+			continue
+		}
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		if vmi.DeletionTimestamp != nil {
+			// ignore VMIs which are already deleting
+			continue
+		}
+
+		updateType, err := c.isOutdatedVMI(vm, vmi)
+		if err != nil {
+			return nil, err
+		}
+
+		if updateType == proactiveUpdateTypeRestart {
+			switch strategy {
+			case poolv1.VirtualMachinePoolOpportunisticUpdate:
+				// the VMI picks up the new template on its next restart
+				continue
+			case poolv1.VirtualMachinePoolLiveUpdate:
+				liveUpdatable, err := c.isLiveUpdatableVMI(vm, vmi)
+				if err != nil {
+					return nil, err
+				}
+				if liveUpdatable {
+					// the VM controller applies the changes to the running VMI
+					updateType = proactiveUpdateTypePatchRevisionLabel
+				}
 			}
+		}
 
-			updateType, err := c.isOutdatedVMI(vm, vmi)
-			if err != nil {
-				errChan <- err
-				return
+		if updateType != proactiveUpdateTypeNone {
+			updates = append(updates, proactiveVMIUpdate{vm: vm, vmi: vmi, updateType: updateType})
+		}
+	}
+
+	return updates, nil
+}
+
+func (c *PoolController) proactiveUpdate(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine, vmUpdatedList []*virtv1.VirtualMachine) error {
+	updates, err := c.collectProactiveUpdates(pool, vmUpdatedList)
+	if err != nil {
+		return err
+	}
 
+	allowedRestarts, err := c.allowedRestarts(pool, vms)
+	if err != nil {
+		return err
+	}
+
+	var updateList []proactiveVMIUpdate
+	for _, update := range updates {
+		if update.updateType == proactiveUpdateTypeRestart {
+			if allowedRestarts == 0 {
+				log.Log.Object(pool).V(4).Infof("Postponing restart of outdated vmi %s/%s due to maxUnavailable", update.vm.Namespace, update.vm.Name)
+				continue
 			}
-			switch updateType {
+			allowedRestarts--
+		}
+		updateList = append(updateList, update)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(updateList))
+	errChan := make(chan error, len(updateList))
+	for i := 0; i < len(updateList); i++ {
+		go func(idx int) {
+			defer wg.Done()
+			vm := updateList[idx].vm
+			vmi := updateList[idx].vmi
+
+			switch updateList[idx].updateType {
 			case proactiveUpdateTypeRestart:
 				err := c.clientset.VirtualMachineInstance(vm.ObjectMeta.Namespace).Delete(context.Background(), vmi.ObjectMeta.Name, &v1.DeleteOptions{})
 				if err != nil {
@@ -1054,6 +1255,58 @@ func (c *PoolController) isOutdatedVMI(vm *virtv1.VirtualMachine, vmi *virtv1.Vi
 	return proactiveUpdateTypePatchRevisionLabel, nil
 }
 
+// isLiveUpdatableVMI checks whether the differences between the VMI templates of the pool
+// revisions used by the VM and the VMI are limited to fields the VM controller can apply
+// to the running VMI, according to the liveUpdateFeatures of the VM.
+func (c *PoolController) isLiveUpdatableVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	if vm.Spec.LiveUpdateFeatures == nil {
+		return false, nil
+	}
+
+	poolSpecRevisionForVM, exists, err := c.getControllerRevision(vm.Namespace, vm.Labels[virtv1.VirtualMachinePoolRevisionName])
+	if err != nil || !exists {
+		return false, err
+	}
+	poolSpecRevisionForVMI, exists, err := c.getControllerRevision(vm.Namespace, vmi.Labels[virtv1.VirtualMachinePoolRevisionName])
+	if err != nil || !exists {
+		return false, err
+	}
+	if poolSpecRevisionForVM.VirtualMachineTemplate.Spec.Template == nil || poolSpecRevisionForVMI.VirtualMachineTemplate.Spec.Template == nil {
+		return false, nil
+	}
+
+	expectedVMITemplate := poolSpecRevisionForVM.VirtualMachineTemplate.Spec.Template.DeepCopy()
+	currentVMITemplate := poolSpecRevisionForVMI.VirtualMachineTemplate.Spec.Template.DeepCopy()
+	clearLiveUpdatableFields(vm.Spec.LiveUpdateFeatures, expectedVMITemplate)
+	clearLiveUpdatableFields(vm.Spec.LiveUpdateFeatures, currentVMITemplate)
+
+	return equality.Semantic.DeepEqual(currentVMITemplate, expectedVMITemplate), nil
+}
+
+func clearLiveUpdatableFields(features *virtv1.LiveUpdateFeatures, template *virtv1.VirtualMachineInstanceTemplateSpec) {
+	if features.CPU != nil && template.Spec.Domain.CPU != nil {
+		template.Spec.Domain.CPU.Sockets = 0
+		if equality.Semantic.DeepEqual(*template.Spec.Domain.CPU, virtv1.CPU{}) {
+			template.Spec.Domain.CPU = nil
+		}
+	}
+	if features.Memory != nil && template.Spec.Domain.Memory != nil {
+		template.Spec.Domain.Memory.Guest = nil
+		if equality.Semantic.DeepEqual(*template.Spec.Domain.Memory, virtv1.Memory{}) {
+			template.Spec.Domain.Memory = nil
+		}
+	}
+	if features.Affinity != nil {
+		template.Spec.NodeSelector = nil
+		template.Spec.Affinity = nil
+	}
+	if features.IOTune != nil {
+		for i := range template.Spec.Domain.Devices.Disks {
+			template.Spec.Domain.Devices.Disks[i].IOTune = nil
+		}
+	}
+}
+
 func (c *PoolController) isOutdatedVM(pool *poolv1.VirtualMachinePool, vm *virtv1.VirtualMachine) (bool, error) {
 
 	if vm.Labels == nil {
@@ -1136,6 +1389,37 @@ func (c *PoolController) pruneUnusedRevisions(pool *poolv1.VirtualMachinePool, v
 	return nil
 }
 
+// calcUpdateStatus returns the name of the revision the VMs are updated to and the
+// number of VMs which are running with it.
+func (c *PoolController) calcUpdateStatus(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine) (string, int32) {
+	updateRevision := ""
+	updatedReplicas := int32(0)
+
+	for _, vm := range vms {
+		revisionName, exists := vm.Labels[virtv1.VirtualMachinePoolRevisionName]
+		if !exists {
+			continue
+		}
+		poolSpec, exists, err := c.getControllerRevision(vm.Namespace, revisionName)
+		if err != nil || !exists || !equality.Semantic.DeepEqual(poolSpec.VirtualMachineTemplate, pool.Spec.VirtualMachineTemplate) {
+			continue
+		}
+		if updateRevision == "" || revisionName == getRevisionName(pool) {
+			updateRevision = revisionName
+		}
+
+		vmiKey := controller.NamespacedKey(vm.Namespace, vm.Name)
+		obj, exists, _ := c.vmiInformer.GetStore().GetByKey(vmiKey)
+		if exists && obj.(*virtv1.VirtualMachineInstance).Labels[virtv1.VirtualMachinePoolRevisionName] != revisionName {
+			// the VMI still runs an older revision
+			continue
+		}
+		updatedReplicas++
+	}
+
+	return updateRevision, updatedReplicas
+}
+
 func (c *PoolController) update(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine) (syncError, bool) {
 	// List of VMs that need to be updated
 	vmOutdatedList := []*virtv1.VirtualMachine{}
@@ -1160,7 +1444,7 @@ func (c *PoolController) update(pool *poolv1.VirtualMachinePool, vms []*virtv1.V
 		return &syncErrorImpl{fmt.Errorf("Error during VM update: %v", err), FailedUpdateReason}, false
 	}
 
-	err = c.proactiveUpdate(pool, vmUpdatedList)
+	err = c.proactiveUpdate(pool, vms, vmUpdatedList)
 	if err != nil {
 		return &syncErrorImpl{fmt.Errorf("Error during VMI update: %v", err), FailedUpdateReason}, false
 	}
@@ -1249,6 +1533,7 @@ func (c *PoolController) updateStatus(origPool *poolv1.VirtualMachinePool, vms [
 
 	pool.Status.Replicas = int32(len(vms))
 	pool.Status.ReadyReplicas = int32(len(c.filterReadyVMs(vms)))
+	pool.Status.UpdateRevision, pool.Status.UpdatedReplicas = c.calcUpdateStatus(pool, vms)
 
 	if !equality.Semantic.DeepEqual(pool.Status, origPool.Status) || pool.Status.Replicas != pool.Status.ReadyReplicas {
 		err := c.statusUpdater.UpdateStatus(pool)
@@ -1277,9 +1562,15 @@ func (c *PoolController) execute(key string) error {
 		logger = logger.Object(pool)
 	} else {
 		c.expectations.DeleteExpectations(key)
+		c.recommendations.delete(key)
 		return nil
 	}
 
+	if pool.Spec.Autoscaler != nil && pool.DeletionTimestamp == nil {
+		// the utilization of the VMIs is not watched, reevaluate it periodically
+		c.queue.AddAfter(key, autoscalerSyncPeriod)
+	}
+
 	selector, err := metav1.LabelSelectorAsSelector(pool.Spec.Selector)
 	if err != nil {
 		logger.Reason(err).Error("Invalid selector on pool, will not re-enqueue.")
@@ -1335,6 +1626,17 @@ func (c *PoolController) execute(key string) error {
 			// handle pruning revisions after scale and update operations are satisfied
 			syncErr = c.pruneUnusedRevisions(pool, vms)
 		}
+
+		needsSync = c.expectations.SatisfiedExpectations(key)
+		if needsSync && syncErr == nil && scaleIsStable && pool.Spec.Autoscaler != nil {
+			// autoscale only once the VMs of the pool match the replica count
+			var scaledPool *poolv1.VirtualMachinePool
+			scaledPool, syncErr = c.autoscale(pool, vms)
+			if scaledPool != nil {
+				// the changed replica count triggers another sync which takes care of the remaining status
+				return c.updateLastScaleTime(scaledPool)
+			}
+		}
 		virtControllerPoolWorkQueueTracer.StepTrace(key, "sync", trace.Field{Key: "VMPool Name", Value: pool.Name})
 	} else if pool.DeletionTimestamp != nil {
 		syncErr = c.pruneUnusedRevisions(pool, vms)
@@ -31,11 +31,14 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	framework "k8s.io/client-go/tools/cache/testing"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/api/core/v1"
 	virtv1 "kubevirt.io/api/core/v1"
@@ -267,6 +270,8 @@ var _ = Describe("Pool", func() {
 			pool.Status.Replicas = 1
 			pool.Status.ReadyReplicas = 1
 			poolRevision := createPoolRevision(pool)
+			pool.Status.UpdateRevision = poolRevision.Name
+			pool.Status.UpdatedReplicas = 1
 
 			pool.Generation = 123
 			newPoolRevision := createPoolRevision(pool)
@@ -335,6 +340,7 @@ var _ = Describe("Pool", func() {
 			pool.Spec.VirtualMachineTemplate.Spec.Template.ObjectMeta.Labels = map[string]string{}
 			pool.Spec.VirtualMachineTemplate.Spec.Template.ObjectMeta.Labels["newkey"] = "newval"
 			newPoolRevision := createPoolRevision(pool)
+			pool.Status.UpdateRevision = newPoolRevision.Name
 
 			vm = injectPoolRevisionLabelsIntoVM(vm, newPoolRevision.Name)
 			vm.Name = fmt.Sprintf("%s-0", pool.Name)
@@ -381,6 +387,8 @@ var _ = Describe("Pool", func() {
 
 			pool.Status.Replicas = 1
 			pool.Status.ReadyReplicas = 1
+			pool.Status.UpdateRevision = poolRevision.Name
+			pool.Status.UpdatedReplicas = 1
 			addPool(pool)
 			addVM(vm)
 			addCR(poolRevision)
@@ -401,6 +409,8 @@ var _ = Describe("Pool", func() {
 
 			pool.Status.Replicas = 1
 			pool.Status.ReadyReplicas = 1
+			pool.Status.UpdateRevision = poolRevision.Name
+			pool.Status.UpdatedReplicas = 1
 			addPool(pool)
 			addVM(vm)
 			addCR(poolRevision)
@@ -410,6 +420,158 @@ var _ = Describe("Pool", func() {
 			controller.Execute()
 		})
 
+		Context("with an update strategy", func() {
+			// addOutdatedReplicas adds VMs which are up to date with the pool
+			// and VMIs which still run the old pool revision
+			addOutdatedReplicas := func(pool *poolv1.VirtualMachinePool, vm *v1.VirtualMachine, oldPoolRevision, newPoolRevision *appsv1.ControllerRevision) {
+				addPool(pool)
+				for i := 0; i < int(*pool.Spec.Replicas); i++ {
+					vmCopy := injectPoolRevisionLabelsIntoVM(vm.DeepCopy(), newPoolRevision.Name)
+					vmCopy.Name = fmt.Sprintf("%s-%d", pool.Name, i)
+					vmCopy.UID = types.UID(vmCopy.Name)
+					markVmAsReady(vmCopy)
+
+					vmi := api.NewMinimalVMI(vmCopy.Name)
+					vmi.Spec = vmCopy.Spec.Template.Spec
+					vmi.Namespace = vmCopy.Namespace
+					vmi.Labels = mapCopy(vmCopy.Spec.Template.ObjectMeta.Labels)
+					vmi.Labels[virtv1.VirtualMachinePoolRevisionName] = oldPoolRevision.Name
+					vmi.OwnerReferences = []metav1.OwnerReference{{
+						APIVersion:         virtv1.VirtualMachineGroupVersionKind.GroupVersion().String(),
+						Kind:               virtv1.VirtualMachineGroupVersionKind.Kind,
+						Name:               vmCopy.ObjectMeta.Name,
+						UID:                vmCopy.ObjectMeta.UID,
+						Controller:         &t,
+						BlockOwnerDeletion: &t,
+					}}
+					markAsReady(vmi)
+
+					addVM(vmCopy)
+					addVMI(vmi, true)
+				}
+				addCR(oldPoolRevision)
+				addCR(newPoolRevision)
+			}
+
+			newPoolWithTemplateChange := func(replicas int32, change func(pool *poolv1.VirtualMachinePool)) (*poolv1.VirtualMachinePool, *v1.VirtualMachine, *appsv1.ControllerRevision, *appsv1.ControllerRevision) {
+				pool, vm := DefaultPool(replicas)
+				oldPoolRevision := createPoolRevision(pool)
+
+				pool.Generation = 123
+				change(pool)
+				vm.Spec = *pool.Spec.VirtualMachineTemplate.Spec.DeepCopy()
+				newPoolRevision := createPoolRevision(pool)
+
+				pool.Status.Replicas = replicas
+				pool.Status.ReadyReplicas = replicas
+				pool.Status.UpdateRevision = newPoolRevision.Name
+				return pool, vm, oldPoolRevision, newPoolRevision
+			}
+
+			changeTemplateLabels := func(pool *poolv1.VirtualMachinePool) {
+				pool.Spec.VirtualMachineTemplate.Spec.Template.ObjectMeta.Labels = map[string]string{"newkey": "newval"}
+			}
+
+			It("should not restart outdated VMIs with the Opportunistic strategy", func() {
+				pool, vm, oldPoolRevision, newPoolRevision := newPoolWithTemplateChange(2, changeTemplateLabels)
+				pool.Spec.UpdateStrategy = &poolv1.VirtualMachinePoolUpdateStrategy{
+					Type: poolv1.VirtualMachinePoolOpportunisticUpdate,
+				}
+				addOutdatedReplicas(pool, vm, oldPoolRevision, newPoolRevision)
+
+				vmiInterface.EXPECT().Delete(context.Background(), gomock.Any(), gomock.Any()).Times(0)
+
+				controller.Execute()
+			})
+
+			It("should restart at most maxUnavailable outdated VMIs with the Proactive strategy", func() {
+				pool, vm, oldPoolRevision, newPoolRevision := newPoolWithTemplateChange(3, changeTemplateLabels)
+				maxUnavailable := intstr.FromInt(2)
+				pool.Spec.UpdateStrategy = &poolv1.VirtualMachinePoolUpdateStrategy{
+					Type:           poolv1.VirtualMachinePoolProactiveUpdate,
+					MaxUnavailable: &maxUnavailable,
+				}
+				addOutdatedReplicas(pool, vm, oldPoolRevision, newPoolRevision)
+
+				vmiInterface.EXPECT().Delete(context.Background(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+				testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			})
+
+			It("should not allow restarts when maxUnavailable is exhausted", func() {
+				pool, vm := DefaultPool(4)
+				maxUnavailable := intstr.FromString("50%")
+				pool.Spec.UpdateStrategy = &poolv1.VirtualMachinePoolUpdateStrategy{
+					MaxUnavailable: &maxUnavailable,
+				}
+				readyVM := vm.DeepCopy()
+				markVmAsReady(readyVM)
+
+				allowed, err := controller.allowedRestarts(pool, []*v1.VirtualMachine{readyVM, readyVM, readyVM, vm})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(allowed).To(Equal(1))
+
+				allowed, err = controller.allowedRestarts(pool, []*v1.VirtualMachine{readyVM, readyVM, vm, vm})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(allowed).To(BeZero())
+			})
+
+			It("should patch VMIs which can be live updated with the LiveUpdate strategy", func() {
+				pool, vm, oldPoolRevision, newPoolRevision := newPoolWithTemplateChange(2, func(pool *poolv1.VirtualMachinePool) {
+					pool.Spec.VirtualMachineTemplate.Spec.Template.Spec.Domain.CPU = &v1.CPU{Sockets: 2}
+				})
+				pool.Spec.UpdateStrategy = &poolv1.VirtualMachinePoolUpdateStrategy{
+					Type: poolv1.VirtualMachinePoolLiveUpdate,
+				}
+				vm.Spec.LiveUpdateFeatures = &v1.LiveUpdateFeatures{CPU: &v1.LiveUpdateCPU{}}
+				addOutdatedReplicas(pool, vm, oldPoolRevision, newPoolRevision)
+
+				vmiInterface.EXPECT().Delete(context.Background(), gomock.Any(), gomock.Any()).Times(0)
+				vmiInterface.EXPECT().Patch(context.Background(), gomock.Any(), types.JSONPatchType, gomock.Any(), &metav1.PatchOptions{}).Times(2).
+					Do(func(ctx context.Context, name string, patchType types.PatchType, body []byte, opts *metav1.PatchOptions, _ ...string) {
+						Expect(string(body)).To(ContainSubstring(newPoolRevision.Name))
+					}).Return(nil, nil)
+
+				controller.Execute()
+			})
+
+			It("should restart VMIs which can't be live updated with the LiveUpdate strategy", func() {
+				pool, vm, oldPoolRevision, newPoolRevision := newPoolWithTemplateChange(1, changeTemplateLabels)
+				pool.Spec.UpdateStrategy = &poolv1.VirtualMachinePoolUpdateStrategy{
+					Type: poolv1.VirtualMachinePoolLiveUpdate,
+				}
+				vm.Spec.LiveUpdateFeatures = &v1.LiveUpdateFeatures{CPU: &v1.LiveUpdateCPU{}}
+				addOutdatedReplicas(pool, vm, oldPoolRevision, newPoolRevision)
+
+				vmiInterface.EXPECT().Delete(context.Background(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			})
+
+			It("should report the updated replicas in the status", func() {
+				pool, vm, oldPoolRevision, newPoolRevision := newPoolWithTemplateChange(1, changeTemplateLabels)
+				addOutdatedReplicas(pool, vm, oldPoolRevision, newPoolRevision)
+
+				vms, err := controller.listVMsFromNamespace(pool.Namespace)
+				Expect(err).ToNot(HaveOccurred())
+				updateRevision, updatedReplicas := controller.calcUpdateStatus(pool, vms)
+				Expect(updateRevision).To(Equal(newPoolRevision.Name))
+				Expect(updatedReplicas).To(BeZero())
+
+				vmi := api.NewMinimalVMI(vms[0].Name)
+				vmi.Labels = map[string]string{virtv1.VirtualMachinePoolRevisionName: newPoolRevision.Name}
+				Expect(vmiInformer.GetStore().Update(vmi)).To(Succeed())
+				updateRevision, updatedReplicas = controller.calcUpdateStatus(pool, vms)
+				Expect(updateRevision).To(Equal(newPoolRevision.Name))
+				Expect(updatedReplicas).To(Equal(int32(1)))
+			})
+		})
+
 		It("should not create missing VMs when it is paused and add paused condition", func() {
 			pool, _ := DefaultPool(3)
 			pool.Spec.Paused = true
@@ -590,6 +752,234 @@ var _ = Describe("Pool", func() {
 			}
 		})
 
+		Context("with a scale-in policy", func() {
+			newPoolVMs := func(vm *v1.VirtualMachine, count int) []*v1.VirtualMachine {
+				var vms []*v1.VirtualMachine
+				for x := 0; x < count; x++ {
+					newVM := vm.DeepCopy()
+					newVM.Name = fmt.Sprintf("my-pool-%d", x)
+					newVM.CreationTimestamp = metav1.NewTime(time.Date(2023, time.March, 15, x, 0, 0, 0, time.UTC))
+					vms = append(vms, newVM)
+				}
+				return vms
+			}
+
+			vmNames := func(vms []*v1.VirtualMachine) []string {
+				var names []string
+				for _, vm := range vms {
+					names = append(names, vm.Name)
+				}
+				return names
+			}
+
+			It("should remove the VM with the lowest deletion cost", func() {
+				pool, vm := DefaultPool(2)
+				addPool(pool)
+
+				vms := newPoolVMs(vm, 3)
+				vms[0].Annotations[poolv1.VirtualMachinePoolDeletionCostAnnotation] = "10"
+				vms[1].Annotations[poolv1.VirtualMachinePoolDeletionCostAnnotation] = "-5"
+				for _, newVM := range vms {
+					addVM(newVM)
+				}
+
+				client.Fake.PrependReactor("update", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					return true, action.(testing.UpdateAction).GetObject(), nil
+				})
+
+				vmInterface.EXPECT().Delete(context.Background(), "my-pool-1", gomock.Any()).Return(nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			})
+
+			DescribeTable("should order the VMs", func(order poolv1.VirtualMachinePoolScaleInOrder, expected []string) {
+				pool, vm := DefaultPool(0)
+				pool.Spec.ScaleInPolicy = &poolv1.VirtualMachinePoolScaleInPolicy{Order: order}
+
+				vms := newPoolVMs(vm, 3)
+				controller.sortScaleInCandidates(pool, vms)
+				Expect(vmNames(vms)).To(Equal(expected))
+			},
+				Entry("oldest first", poolv1.VirtualMachinePoolScaleInOldest, []string{"my-pool-0", "my-pool-1", "my-pool-2"}),
+				Entry("newest first", poolv1.VirtualMachinePoolScaleInNewest, []string{"my-pool-2", "my-pool-1", "my-pool-0"}),
+			)
+
+			It("should prefer not ready VMs", func() {
+				pool, vm := DefaultPool(0)
+				pool.Spec.ScaleInPolicy = &poolv1.VirtualMachinePoolScaleInPolicy{
+					Order:          poolv1.VirtualMachinePoolScaleInOldest,
+					PreferNotReady: true,
+				}
+
+				vms := newPoolVMs(vm, 3)
+				markVmAsReady(vms[0])
+				markVmAsReady(vms[2])
+				controller.sortScaleInCandidates(pool, vms)
+				Expect(vmNames(vms)).To(Equal([]string{"my-pool-1", "my-pool-0", "my-pool-2"}))
+			})
+
+			It("should prefer VMs without logged in users", func() {
+				pool, vm := DefaultPool(0)
+				pool.Spec.ScaleInPolicy = &poolv1.VirtualMachinePoolScaleInPolicy{
+					Order:                 poolv1.VirtualMachinePoolScaleInOldest,
+					PreferNoLoggedInUsers: true,
+				}
+
+				vms := newPoolVMs(vm, 3)
+				for _, newVM := range vms {
+					vmi := api.NewMinimalVMI(newVM.Name)
+					vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+						Type:   v1.VirtualMachineInstanceAgentConnected,
+						Status: k8sv1.ConditionTrue,
+					}}
+					Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+				}
+				vmiInterface.EXPECT().UserList(gomock.Any(), "my-pool-0").Return(v1.VirtualMachineInstanceGuestOSUserList{
+					Items: []v1.VirtualMachineInstanceGuestOSUser{{UserName: "user"}},
+				}, nil)
+				vmiInterface.EXPECT().UserList(gomock.Any(), "my-pool-1").Return(v1.VirtualMachineInstanceGuestOSUserList{}, fmt.Errorf("agent not responding"))
+				vmiInterface.EXPECT().UserList(gomock.Any(), "my-pool-2").Return(v1.VirtualMachineInstanceGuestOSUserList{}, nil)
+
+				controller.sortScaleInCandidates(pool, vms)
+				Expect(vmNames(vms)).To(Equal([]string{"my-pool-1", "my-pool-2", "my-pool-0"}))
+			})
+		})
+
+		Context("with an autoscaler", func() {
+			newAutoscaledPool := func(replicas int32) (*poolv1.VirtualMachinePool, *v1.VirtualMachine, *appsv1.ControllerRevision) {
+				pool, vm := DefaultPool(replicas)
+				pool.Spec.Autoscaler = &poolv1.VirtualMachinePoolAutoscaler{
+					MaxReplicas:                    4,
+					TargetCPUUtilizationPercentage: pointer.Int32(50),
+				}
+				poolRevision := createPoolRevision(pool)
+				pool.Status.Replicas = replicas
+				pool.Status.ReadyReplicas = replicas
+				pool.Status.UpdateRevision = poolRevision.Name
+				pool.Status.UpdatedReplicas = replicas
+				return pool, vm, poolRevision
+			}
+
+			addRunningReplicas := func(pool *poolv1.VirtualMachinePool, vm *v1.VirtualMachine, poolRevision *appsv1.ControllerRevision, usage *v1.VirtualMachineInstanceResourceUsageStatus) {
+				addPool(pool)
+				for i := 0; i < int(*pool.Spec.Replicas); i++ {
+					vmCopy := injectPoolRevisionLabelsIntoVM(vm.DeepCopy(), poolRevision.Name)
+					vmCopy.Name = fmt.Sprintf("%s-%d", pool.Name, i)
+					markVmAsReady(vmCopy)
+					addVM(vmCopy)
+
+					vmi := api.NewMinimalVMI(vmCopy.Name)
+					vmi.Spec = vmCopy.Spec.Template.Spec
+					vmi.Labels = mapCopy(vmCopy.Spec.Template.ObjectMeta.Labels)
+					vmi.Status.Phase = v1.Running
+					vmi.Status.ResourceUsage = usage.DeepCopy()
+					Expect(vmiInformer.GetStore().Add(vmi)).To(Succeed())
+				}
+				addCR(poolRevision)
+			}
+
+			cpuUsage := func(cpu int32, sampled time.Time) *v1.VirtualMachineInstanceResourceUsageStatus {
+				return &v1.VirtualMachineInstanceResourceUsageStatus{
+					CPUUtilizationPercentage: pointer.Int32(cpu),
+					LastSampleTime:           metav1.NewTime(sampled),
+				}
+			}
+
+			It("should scale out when the utilization is above the target", func() {
+				pool, vm, poolRevision := newAutoscaledPool(2)
+				addRunningReplicas(pool, vm, poolRevision, cpuUsage(100, time.Now()))
+
+				client.Fake.PrependReactor("patch", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					patch, ok := action.(testing.PatchAction)
+					Expect(ok).To(BeTrue())
+					Expect(string(patch.GetPatch())).To(Equal(`{"spec":{"replicas":4}}`))
+					scaled := pool.DeepCopy()
+					scaled.Spec.Replicas = pointer.Int32(4)
+					return true, scaled, nil
+				})
+				client.Fake.PrependReactor("update", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					updated := action.(testing.UpdateAction).GetObject().(*poolv1.VirtualMachinePool)
+					Expect(updated.Status.LastScaleTime).ToNot(BeNil())
+					return true, updated, nil
+				})
+
+				addAfterCount := mockQueue.GetAddAfterEnqueueCount()
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulRescaleReason)
+				Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(addAfterCount + 1))
+			})
+
+			It("should not scale when the utilization is within the tolerance", func() {
+				pool, vm, poolRevision := newAutoscaledPool(2)
+				addRunningReplicas(pool, vm, poolRevision, cpuUsage(53, time.Now()))
+
+				client.Fake.PrependReactor("update", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					return true, action.(testing.UpdateAction).GetObject(), nil
+				})
+
+				controller.Execute()
+
+				Expect(recorder.Events).To(BeEmpty())
+			})
+
+			It("should ignore stale utilization reports", func() {
+				pool, vm, poolRevision := newAutoscaledPool(2)
+				addRunningReplicas(pool, vm, poolRevision, cpuUsage(100, time.Now().Add(-2*resourceUsageMaxAge)))
+
+				client.Fake.PrependReactor("update", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					return true, action.(testing.UpdateAction).GetObject(), nil
+				})
+
+				controller.Execute()
+
+				Expect(recorder.Events).To(BeEmpty())
+			})
+
+			DescribeTable("should calculate the desired replicas", func(autoscaler *poolv1.VirtualMachinePoolAutoscaler, replicas int32, usages []v1.VirtualMachineInstanceResourceUsageStatus, expected int32) {
+				Expect(clampReplicas(autoscaler, desiredReplicas(autoscaler, replicas, usages))).To(Equal(expected))
+			},
+				Entry("scaling out on high CPU utilization",
+					&poolv1.VirtualMachinePoolAutoscaler{MaxReplicas: 10, TargetCPUUtilizationPercentage: pointer.Int32(50)}, int32(2),
+					[]v1.VirtualMachineInstanceResourceUsageStatus{{CPUUtilizationPercentage: pointer.Int32(90)}, {CPUUtilizationPercentage: pointer.Int32(70)}}, int32(4)),
+				Entry("scaling in on low CPU utilization",
+					&poolv1.VirtualMachinePoolAutoscaler{MaxReplicas: 10, TargetCPUUtilizationPercentage: pointer.Int32(50)}, int32(4),
+					[]v1.VirtualMachineInstanceResourceUsageStatus{{CPUUtilizationPercentage: pointer.Int32(10)}, {CPUUtilizationPercentage: pointer.Int32(10)}, {CPUUtilizationPercentage: pointer.Int32(10)}, {CPUUtilizationPercentage: pointer.Int32(10)}}, int32(1)),
+				Entry("assuming the target utilization for VMIs without metrics",
+					&poolv1.VirtualMachinePoolAutoscaler{MaxReplicas: 10, TargetCPUUtilizationPercentage: pointer.Int32(50)}, int32(4),
+					[]v1.VirtualMachineInstanceResourceUsageStatus{{CPUUtilizationPercentage: pointer.Int32(0)}}, int32(3)),
+				Entry("using the highest replica count of all metrics",
+					&poolv1.VirtualMachinePoolAutoscaler{MaxReplicas: 10, TargetCPUUtilizationPercentage: pointer.Int32(50), TargetMemoryUtilizationPercentage: pointer.Int32(50)}, int32(2),
+					[]v1.VirtualMachineInstanceResourceUsageStatus{
+						{CPUUtilizationPercentage: pointer.Int32(10), MemoryUtilizationPercentage: pointer.Int32(100)},
+						{CPUUtilizationPercentage: pointer.Int32(10), MemoryUtilizationPercentage: pointer.Int32(100)},
+					}, int32(4)),
+				Entry("keeping the replicas without matching metrics",
+					&poolv1.VirtualMachinePoolAutoscaler{MaxReplicas: 10, TargetMemoryUtilizationPercentage: pointer.Int32(50)}, int32(3),
+					[]v1.VirtualMachineInstanceResourceUsageStatus{{CPUUtilizationPercentage: pointer.Int32(100)}}, int32(3)),
+				Entry("respecting maxReplicas",
+					&poolv1.VirtualMachinePoolAutoscaler{MaxReplicas: 3, TargetCPUUtilizationPercentage: pointer.Int32(10)}, int32(2),
+					[]v1.VirtualMachineInstanceResourceUsageStatus{{CPUUtilizationPercentage: pointer.Int32(100)}, {CPUUtilizationPercentage: pointer.Int32(100)}}, int32(3)),
+				Entry("respecting minReplicas",
+					&poolv1.VirtualMachinePoolAutoscaler{MinReplicas: pointer.Int32(2), MaxReplicas: 10, TargetCPUUtilizationPercentage: pointer.Int32(50)}, int32(3),
+					[]v1.VirtualMachineInstanceResourceUsageStatus{{CPUUtilizationPercentage: pointer.Int32(0)}, {CPUUtilizationPercentage: pointer.Int32(0)}, {CPUUtilizationPercentage: pointer.Int32(0)}}, int32(2)),
+			)
+
+			It("should only scale in after the stabilization window", func() {
+				recommendations := newAutoscalerRecommendations()
+				window := 5 * time.Minute
+				start := time.Now()
+
+				Expect(recommendations.stabilize("pool", 3, 1, window, start)).To(Equal(int32(3)))
+				Expect(recommendations.stabilize("pool", 3, 2, window, start.Add(time.Minute))).To(Equal(int32(3)))
+				Expect(recommendations.stabilize("pool", 3, 1, window, start.Add(6*time.Minute))).To(Equal(int32(2)))
+				Expect(recommendations.stabilize("pool", 3, 1, window, start.Add(7*time.Minute))).To(Equal(int32(1)))
+				Expect(recommendations.stabilize("pool", 1, 5, window, start.Add(8*time.Minute))).To(Equal(int32(5)))
+			})
+		})
+
 		It("should not delete vms which are already marked deleted", func() {
 
 			pool, vm := DefaultPool(0)
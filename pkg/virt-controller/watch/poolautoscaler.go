@@ -0,0 +1,243 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	k8score "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	poolv1 "kubevirt.io/api/pool/v1alpha1"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	SuccessfulRescaleReason = "SuccessfulRescale"
+	FailedRescaleReason     = "FailedRescale"
+
+	// autoscalerSyncPeriod is the interval in which the utilization of pools with an autoscaler is reevaluated
+	autoscalerSyncPeriod = 15 * time.Second
+	// autoscalerTolerance is the relative deviation from the target utilization which does not lead to scaling
+	autoscalerTolerance = 0.1
+
+	defaultScaleDownStabilizationSeconds = 300
+
+	// resourceUsageMaxAge is the age after which the utilization reported in the VMI status is considered stale,
+	// virt-handler refreshes the report at least every minute
+	resourceUsageMaxAge = 3 * time.Minute
+)
+
+type timestampedRecommendation struct {
+	replicas  int32
+	timestamp time.Time
+}
+
+// autoscalerRecommendations keeps the recent replica recommendations per pool, scaling in
+// only happens when all recommendations within the stabilization window agree.
+type autoscalerRecommendations struct {
+	lock            sync.Mutex
+	recommendations map[string][]timestampedRecommendation
+}
+
+func newAutoscalerRecommendations() *autoscalerRecommendations {
+	return &autoscalerRecommendations{
+		recommendations: map[string][]timestampedRecommendation{},
+	}
+}
+
+// stabilize records the recommendation and returns the highest recommendation within the window.
+// Pools seen for the first time start with the current replica count as recommendation, to avoid
+// scaling in right after the controller started.
+func (r *autoscalerRecommendations) stabilize(key string, current int32, recommendation int32, window time.Duration, now time.Time) int32 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	history, exists := r.recommendations[key]
+	if !exists {
+		history = []timestampedRecommendation{{replicas: current, timestamp: now}}
+	}
+
+	stabilized := recommendation
+	recent := []timestampedRecommendation{{replicas: recommendation, timestamp: now}}
+	for _, rec := range history {
+		if now.Sub(rec.timestamp) > window {
+			continue
+		}
+		recent = append(recent, rec)
+		if rec.replicas > stabilized {
+			stabilized = rec.replicas
+		}
+	}
+	r.recommendations[key] = recent
+	return stabilized
+}
+
+func (r *autoscalerRecommendations) delete(key string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.recommendations, key)
+}
+
+// autoscale calculates the desired replica count of the pool based on the utilization of its
+// VMIs and updates the pool accordingly. The updated pool is returned if it got scaled.
+func (c *PoolController) autoscale(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine) (*poolv1.VirtualMachinePool, syncError) {
+	autoscaler := pool.Spec.Autoscaler
+
+	key, err := controller.KeyFunc(pool)
+	if err != nil {
+		return nil, &syncErrorImpl{err, FailedRescaleReason}
+	}
+
+	current := int32(1)
+	if pool.Spec.Replicas != nil {
+		current = *pool.Spec.Replicas
+	}
+
+	recommendation := current
+	usages := c.collectResourceUsage(vms)
+	if len(usages) > 0 {
+		recommendation = desiredReplicas(autoscaler, int32(len(vms)), usages)
+	}
+	recommendation = clampReplicas(autoscaler, recommendation)
+
+	window := time.Duration(defaultScaleDownStabilizationSeconds) * time.Second
+	if autoscaler.ScaleDownStabilizationSeconds != nil {
+		window = time.Duration(*autoscaler.ScaleDownStabilizationSeconds) * time.Second
+	}
+	desired := c.recommendations.stabilize(key, current, recommendation, window, time.Now())
+
+	if desired == current {
+		return nil, nil
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"replicas":%d}}`, desired)
+	scaledPool, err := c.clientset.VirtualMachinePool(pool.Namespace).Patch(context.Background(), pool.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return nil, &syncErrorImpl{fmt.Errorf("failed to rescale pool from %d to %d replicas: %v", current, desired, err), FailedRescaleReason}
+	}
+	log.Log.Object(pool).Infof("Rescaled pool from %d to %d replicas", current, desired)
+	c.recorder.Eventf(pool, k8score.EventTypeNormal, SuccessfulRescaleReason, "New size: %d", desired)
+
+	return scaledPool, nil
+}
+
+// collectResourceUsage reads the resource utilization virt-handler reported for the running VMIs of the pool.
+// Reports older than resourceUsageMaxAge are ignored, the VMI is then assumed to be at the target utilization.
+func (c *PoolController) collectResourceUsage(vms []*virtv1.VirtualMachine) []virtv1.VirtualMachineInstanceResourceUsageStatus {
+	usages := []virtv1.VirtualMachineInstanceResourceUsageStatus{}
+	now := time.Now()
+
+	for _, vm := range vms {
+		obj, exists, _ := c.vmiInformer.GetStore().GetByKey(controller.NamespacedKey(vm.Namespace, vm.Name))
+		if !exists {
+			continue
+		}
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		if vmi.Status.Phase != virtv1.Running || vmi.Status.ResourceUsage == nil {
+			continue
+		}
+		if now.Sub(vmi.Status.ResourceUsage.LastSampleTime.Time) > resourceUsageMaxAge {
+			continue
+		}
+		usages = append(usages, *vmi.Status.ResourceUsage)
+	}
+
+	return usages
+}
+
+// desiredReplicas calculates the replica count needed to reach the target utilization, in the same
+// way as the HorizontalPodAutoscaler does. VMs which did not report their utilization are assumed
+// to be at the target utilization. If several targets are set, the highest replica count wins.
+func desiredReplicas(autoscaler *poolv1.VirtualMachinePoolAutoscaler, replicas int32, usages []virtv1.VirtualMachineInstanceResourceUsageStatus) int32 {
+	desired := int32(0)
+	found := false
+
+	calc := func(target *int32, utilization func(usage virtv1.VirtualMachineInstanceResourceUsageStatus) *int32) {
+		if target == nil || *target <= 0 {
+			return
+		}
+		var total, reported int64
+		for _, usage := range usages {
+			if value := utilization(usage); value != nil {
+				total += int64(*value)
+				reported++
+			}
+		}
+		if reported == 0 {
+			return
+		}
+		if missing := int64(replicas) - reported; missing > 0 {
+			total += missing * int64(*target)
+		}
+
+		count := replicas
+		ratio := float64(total) / float64(int64(replicas)*int64(*target))
+		if math.Abs(1.0-ratio) > autoscalerTolerance {
+			count = int32(math.Ceil(ratio * float64(replicas)))
+		}
+		if !found || count > desired {
+			desired = count
+			found = true
+		}
+	}
+
+	calc(autoscaler.TargetCPUUtilizationPercentage, func(usage virtv1.VirtualMachineInstanceResourceUsageStatus) *int32 {
+		return usage.CPUUtilizationPercentage
+	})
+	calc(autoscaler.TargetMemoryUtilizationPercentage, func(usage virtv1.VirtualMachineInstanceResourceUsageStatus) *int32 {
+		return usage.MemoryUtilizationPercentage
+	})
+
+	if !found {
+		return replicas
+	}
+	return desired
+}
+
+func clampReplicas(autoscaler *poolv1.VirtualMachinePoolAutoscaler, replicas int32) int32 {
+	minReplicas := int32(1)
+	if autoscaler.MinReplicas != nil {
+		minReplicas = *autoscaler.MinReplicas
+	}
+	if replicas < minReplicas {
+		return minReplicas
+	}
+	if replicas > autoscaler.MaxReplicas {
+		return autoscaler.MaxReplicas
+	}
+	return replicas
+}
+
+// updateLastScaleTime records the time the autoscaler changed the replica count of the pool.
+func (c *PoolController) updateLastScaleTime(pool *poolv1.VirtualMachinePool) error {
+	pool = pool.DeepCopy()
+	now := metav1.Now()
+	pool.Status.LastScaleTime = &now
+	return c.statusUpdater.UpdateStatus(pool)
+}
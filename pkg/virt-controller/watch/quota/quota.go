@@ -0,0 +1,223 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	quotav1alpha1 "kubevirt.io/api/quota/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+
+	"kubevirt.io/kubevirt/pkg/quota"
+	watchutil "kubevirt.io/kubevirt/pkg/virt-controller/watch/util"
+)
+
+// quotaResyncPeriod is the interval in which the usage of a quota is recalculated from the
+// VirtualMachineInstances, which drops reservations of VirtualMachineInstances that were not created
+const quotaResyncPeriod = 5 * time.Minute
+
+// VMQuotaController keeps the status of VirtualMachineQuotas in sync with the guest resources
+// of the VirtualMachineInstances in their namespace. The quota itself is enforced by virt-api, which
+// reserves the guest resources of new VirtualMachineInstances in the status when admitting them.
+type VMQuotaController struct {
+	Client kubecli.KubevirtClient
+
+	VMQuotaInformer cache.SharedIndexInformer
+	VMIInformer     cache.SharedIndexInformer
+
+	queue workqueue.RateLimitingInterface
+}
+
+// Init initializes the quota controller
+func (ctrl *VMQuotaController) Init() error {
+	ctrl.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-vmquota")
+
+	_, err := ctrl.VMQuotaInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    ctrl.handleVMQuota,
+			UpdateFunc: ctrl.updateVMQuota,
+			DeleteFunc: ctrl.handleVMQuota,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.VMIInformer.AddEventHandler(
+		// New VMIs are already accounted by the reservation of virt-api. Recalculating the usage
+		// when they show up could drop the reservations of VMIs which are not yet in the informer.
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: ctrl.updateVMI,
+			DeleteFunc: ctrl.handleVMI,
+		},
+	)
+	return err
+}
+
+// Run the controller
+func (ctrl *VMQuotaController) Run(threadiness int, stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer ctrl.queue.ShutDown()
+
+	log.Log.Info("Starting vm quota controller.")
+	defer log.Log.Info("Shutting down vm quota controller.")
+
+	if !cache.WaitForCacheSync(
+		stopCh,
+		ctrl.VMQuotaInformer.HasSynced,
+		ctrl.VMIInformer.HasSynced,
+	) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(ctrl.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+
+	return nil
+}
+
+func (ctrl *VMQuotaController) runWorker() {
+	for ctrl.Execute() {
+	}
+}
+
+func (ctrl *VMQuotaController) Execute() bool {
+	return watchutil.ProcessWorkItem(ctrl.queue, func(key string) (time.Duration, error) {
+		log.Log.V(3).Infof("vmQuota worker processing key [%s]", key)
+
+		storeObj, exists, err := ctrl.VMQuotaInformer.GetStore().GetByKey(key)
+		if err != nil || !exists {
+			return 0, err
+		}
+
+		vmQuota, ok := storeObj.(*quotav1alpha1.VirtualMachineQuota)
+		if !ok {
+			return 0, fmt.Errorf("unexpected resource %+v", storeObj)
+		}
+
+		if err := ctrl.updateStatus(vmQuota); err != nil {
+			return 0, err
+		}
+		return quotaResyncPeriod, nil
+	})
+}
+
+func (ctrl *VMQuotaController) handleVMQuota(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	if vmQuota, ok := obj.(*quotav1alpha1.VirtualMachineQuota); ok {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(vmQuota)
+		if err != nil {
+			log.Log.Errorf("failed to get key from object: %v, %v", err, vmQuota)
+			return
+		}
+		ctrl.queue.Add(key)
+	}
+}
+
+// updateVMQuota only enqueues a quota if its status does not track the current hard limits. Status
+// updates, like the reservations of virt-api, must not make the controller recalculate the usage.
+func (ctrl *VMQuotaController) updateVMQuota(oldObj, newObj interface{}) {
+	if vmQuota, ok := newObj.(*quotav1alpha1.VirtualMachineQuota); ok &&
+		vmQuota.Status.Used != nil && equality.Semantic.DeepEqual(vmQuota.Spec.Hard, vmQuota.Status.Hard) {
+		return
+	}
+	ctrl.handleVMQuota(newObj)
+}
+
+// updateVMI only enqueues the quotas if the accounted resources of the VMI changed
+func (ctrl *VMQuotaController) updateVMI(oldObj, newObj interface{}) {
+	oldVMI, oldOk := oldObj.(*virtv1.VirtualMachineInstance)
+	newVMI, newOk := newObj.(*virtv1.VirtualMachineInstance)
+	if oldOk && newOk && oldVMI.IsFinal() == newVMI.IsFinal() &&
+		equality.Semantic.DeepEqual(quota.GuestResources(&oldVMI.Spec), quota.GuestResources(&newVMI.Spec)) {
+		return
+	}
+	ctrl.handleVMI(newObj)
+}
+
+// handleVMI enqueues all quotas in the namespace of the VMI
+func (ctrl *VMQuotaController) handleVMI(obj interface{}) {
+	if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok && unknown.Obj != nil {
+		obj = unknown.Obj
+	}
+
+	vmi, ok := obj.(*virtv1.VirtualMachineInstance)
+	if !ok {
+		return
+	}
+	keys, err := ctrl.VMQuotaInformer.GetIndexer().IndexKeys(cache.NamespaceIndex, vmi.Namespace)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to look up the VirtualMachineQuotas of the namespace")
+		return
+	}
+	for _, key := range keys {
+		ctrl.queue.Add(key)
+	}
+}
+
+func (ctrl *VMQuotaController) updateStatus(vmQuota *quotav1alpha1.VirtualMachineQuota) error {
+	objs, err := ctrl.VMIInformer.GetIndexer().ByIndex(cache.NamespaceIndex, vmQuota.Namespace)
+	if err != nil {
+		return err
+	}
+	var vmis []*virtv1.VirtualMachineInstance
+	for _, obj := range objs {
+		vmis = append(vmis, obj.(*virtv1.VirtualMachineInstance))
+	}
+	usage := quota.Usage(vmis)
+
+	// Like a ResourceQuota only the resources with a hard limit are reported
+	status := quotav1alpha1.VirtualMachineQuotaStatus{
+		Hard: vmQuota.Spec.Hard.DeepCopy(),
+		Used: k8sv1.ResourceList{},
+	}
+	for name := range vmQuota.Spec.Hard {
+		if used, ok := usage[name]; ok {
+			status.Used[name] = used
+		}
+	}
+
+	if equality.Semantic.DeepEqual(vmQuota.Status, status) {
+		return nil
+	}
+
+	vmQuotaCopy := vmQuota.DeepCopy()
+	vmQuotaCopy.Status = status
+	_, err = ctrl.Client.VirtualMachineQuota(vmQuota.Namespace).UpdateStatus(context.Background(), vmQuotaCopy, metav1.UpdateOptions{})
+	return err
+}
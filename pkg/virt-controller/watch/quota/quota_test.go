@@ -0,0 +1,189 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package quota
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	virtv1 "kubevirt.io/api/core/v1"
+	quotav1alpha1 "kubevirt.io/api/quota/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("VirtualMachineQuota controller", func() {
+	const namespace = "tenant"
+
+	var (
+		controller *VMQuotaController
+		client     *kubevirtfake.Clientset
+		vmQuota    *quotav1alpha1.VirtualMachineQuota
+	)
+
+	newVMI := func(name string, cores uint32, memory string, phase virtv1.VirtualMachineInstancePhase) *virtv1.VirtualMachineInstance {
+		guest := resource.MustParse(memory)
+		return &virtv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: virtv1.VirtualMachineInstanceSpec{
+				Domain: virtv1.DomainSpec{
+					CPU:    &virtv1.CPU{Cores: cores},
+					Memory: &virtv1.Memory{Guest: &guest},
+				},
+			},
+			Status: virtv1.VirtualMachineInstanceStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	addVMI := func(vmi *virtv1.VirtualMachineInstance) {
+		Expect(controller.VMIInformer.GetStore().Add(vmi)).To(Succeed())
+	}
+
+	sync := func() *quotav1alpha1.VirtualMachineQuota {
+		key, err := cache.MetaNamespaceKeyFunc(vmQuota)
+		Expect(err).ToNot(HaveOccurred())
+		controller.queue.Add(key)
+		Expect(controller.Execute()).To(BeTrue())
+		updated, err := client.QuotaV1alpha1().VirtualMachineQuotas(namespace).Get(context.Background(), vmQuota.Name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return updated
+	}
+
+	BeforeEach(func() {
+		vmQuota = &quotav1alpha1.VirtualMachineQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "quota",
+				Namespace: namespace,
+			},
+			Spec: quotav1alpha1.VirtualMachineQuotaSpec{
+				Hard: k8sv1.ResourceList{
+					quotav1alpha1.ResourceVCPUs:       resource.MustParse("10"),
+					quotav1alpha1.ResourceGuestMemory: resource.MustParse("40Gi"),
+				},
+			},
+		}
+
+		client = kubevirtfake.NewSimpleClientset(vmQuota)
+		virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+		virtClient.EXPECT().VirtualMachineQuota(namespace).Return(client.QuotaV1alpha1().VirtualMachineQuotas(namespace)).AnyTimes()
+
+		indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+		vmQuotaInformer, _ := testutils.NewFakeInformerWithIndexersFor(&quotav1alpha1.VirtualMachineQuota{}, indexers)
+		vmiInformer, _ := testutils.NewFakeInformerWithIndexersFor(&virtv1.VirtualMachineInstance{}, indexers)
+		controller = &VMQuotaController{
+			Client:          virtClient,
+			VMQuotaInformer: vmQuotaInformer,
+			VMIInformer:     vmiInformer,
+		}
+		Expect(controller.Init()).To(Succeed())
+		Expect(vmQuotaInformer.GetStore().Add(vmQuota)).To(Succeed())
+	})
+
+	It("should report the guest resources of the VMIs in the namespace", func() {
+		addVMI(newVMI("vmi1", 2, "4Gi", virtv1.Running))
+		addVMI(newVMI("vmi2", 4, "8Gi", virtv1.Scheduling))
+
+		updated := sync()
+		Expect(updated.Status.Hard).To(Equal(vmQuota.Spec.Hard))
+		Expect(updated.Status.Used).To(HaveLen(2))
+		Expect(updated.Status.Used.Name(quotav1alpha1.ResourceVCPUs, resource.DecimalSI).Value()).To(Equal(int64(6)))
+		Expect(updated.Status.Used.Name(quotav1alpha1.ResourceGuestMemory, resource.BinarySI).Value()).To(Equal(int64(12 * 1024 * 1024 * 1024)))
+	})
+
+	It("should not count VMIs in a final phase or in other namespaces", func() {
+		addVMI(newVMI("running", 2, "4Gi", virtv1.Running))
+		addVMI(newVMI("succeeded", 4, "8Gi", virtv1.Succeeded))
+		other := newVMI("other", 8, "16Gi", virtv1.Running)
+		other.Namespace = "other"
+		addVMI(other)
+
+		updated := sync()
+		Expect(updated.Status.Used.Name(quotav1alpha1.ResourceVCPUs, resource.DecimalSI).Value()).To(Equal(int64(2)))
+		Expect(updated.Status.Used.Name(quotav1alpha1.ResourceGuestMemory, resource.BinarySI).Value()).To(Equal(int64(4 * 1024 * 1024 * 1024)))
+	})
+
+	It("should not update an unchanged status", func() {
+		addVMI(newVMI("vmi1", 2, "4Gi", virtv1.Running))
+		vmQuota = sync()
+		Expect(controller.VMQuotaInformer.GetStore().Update(vmQuota)).To(Succeed())
+		client.ClearActions()
+
+		sync()
+		for _, action := range client.Actions() {
+			Expect(action.GetVerb()).ToNot(Equal("update"))
+		}
+	})
+
+	It("should recalculate the usage periodically", func() {
+		mockQueue := testutils.NewMockWorkQueue(controller.queue)
+		controller.queue = mockQueue
+		sync()
+		Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+	})
+
+	It("should only enqueue a quota if its status does not track the hard limits", func() {
+		updated := vmQuota.DeepCopy()
+		updated.Status.Hard = vmQuota.Spec.Hard.DeepCopy()
+		updated.Status.Used = k8sv1.ResourceList{quotav1alpha1.ResourceVCPUs: resource.MustParse("2")}
+		controller.updateVMQuota(vmQuota, updated)
+		Expect(controller.queue.Len()).To(BeZero())
+
+		updated.Spec.Hard[quotav1alpha1.ResourceVCPUs] = resource.MustParse("20")
+		controller.updateVMQuota(vmQuota, updated)
+		Expect(controller.queue.Len()).To(Equal(1))
+	})
+
+	It("should enqueue the quotas in the namespace of a VMI", func() {
+		controller.handleVMI(newVMI("vmi1", 2, "4Gi", virtv1.Running))
+		Expect(controller.queue.Len()).To(Equal(1))
+
+		other := newVMI("other", 2, "4Gi", virtv1.Running)
+		other.Namespace = "other"
+		controller.queue = testutils.NewMockWorkQueue(controller.queue)
+		controller.handleVMI(other)
+		Expect(controller.queue.Len()).To(Equal(1))
+	})
+
+	It("should only enqueue the quotas if the accounted resources of a VMI changed", func() {
+		vmi := newVMI("vmi1", 2, "4Gi", virtv1.Running)
+		updatedVMI := vmi.DeepCopy()
+		updatedVMI.Labels = map[string]string{"updated": "true"}
+		controller.updateVMI(vmi, updatedVMI)
+		Expect(controller.queue.Len()).To(BeZero())
+
+		updatedVMI.Status.Phase = virtv1.Failed
+		controller.updateVMI(vmi, updatedVMI)
+		Expect(controller.queue.Len()).To(Equal(1))
+	})
+})
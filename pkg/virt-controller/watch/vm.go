@@ -56,6 +56,7 @@ import (
 	"k8s.io/utils/trace"
 
 	virtv1 "kubevirt.io/api/core/v1"
+	apiinstancetype "kubevirt.io/api/instancetype"
 	instancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
@@ -114,10 +115,15 @@ const (
 	HotPlugNetworkInterfaceErrorReason = "HotPlugNetworkInterfaceError"
 	AffinityChangeErrorReason          = "AffinityChangeError"
 	HotPlugMemoryErrorReason           = "HotPlugMemoryError"
+	IOTuneChangeErrorReason            = "IOTuneChangeError"
+	inferFromVolumeFailedReason        = "InferFromVolumeFailed"
 )
 
 const defaultMaxCrashLoopBackoffDelaySeconds = 300
 
+// imageLabelsLookupRequeueInterval is how often a VM is checked while the labels of its containerDisk are looked up
+const imageLabelsLookupRequeueInterval = 2 * time.Second
+
 func NewVMController(vmiInformer cache.SharedIndexInformer,
 	vmInformer cache.SharedIndexInformer,
 	dataVolumeInformer cache.SharedIndexInformer,
@@ -761,6 +767,57 @@ func (c *VMController) handleAffinityChangeRequest(vm *virtv1.VirtualMachine, vm
 	return nil
 }
 
+func (c *VMController) handleIOTuneChangeRequest(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if vmi == nil || vmi.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if vm.Spec.LiveUpdateFeatures == nil || vm.Spec.LiveUpdateFeatures.IOTune == nil {
+		return nil
+	}
+
+	vmDisks := map[string]virtv1.Disk{}
+	for _, disk := range vm.Spec.Template.Spec.Domain.Devices.Disks {
+		vmDisks[disk.Name] = disk
+	}
+	newDisks := make([]virtv1.Disk, len(vmi.Spec.Domain.Devices.Disks))
+	for i, disk := range vmi.Spec.Domain.Devices.Disks {
+		newDisks[i] = *disk.DeepCopy()
+		// Hotplugged disks which are not part of the VM keep their limits
+		if vmDisk, exists := vmDisks[disk.Name]; exists {
+			newDisks[i].IOTune = vmDisk.IOTune.DeepCopy()
+		}
+	}
+
+	if equality.Semantic.DeepEqual(vmi.Spec.Domain.Devices.Disks, newDisks) {
+		return nil
+	}
+
+	if migrations.IsMigrating(vmi) {
+		return fmt.Errorf("disk I/O limits can not be changed while VMI is migrating")
+	}
+
+	oldDisksJSON, err := json.Marshal(vmi.Spec.Domain.Devices.Disks)
+	if err != nil {
+		return err
+	}
+	newDisksJSON, err := json.Marshal(newDisks)
+	if err != nil {
+		return err
+	}
+
+	testDisks := fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/devices/disks", "value": %s}`, string(oldDisksJSON))
+	updateDisks := fmt.Sprintf(`{ "op": "replace", "path": "/spec/domain/devices/disks", "value": %s}`, string(newDisksJSON))
+	patch := fmt.Sprintf("[%s, %s]", testDisks, updateDisks)
+
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(context.Background(), vmi.Name, types.JSONPatchType, []byte(patch), &v1.PatchOptions{})
+	if err != nil {
+		log.Log.Object(vmi).Errorf("unable to patch vmi to update disk I/O limits: %v", err)
+		return err
+	}
+	return nil
+}
+
 func (c *VMController) handleMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
 	if vm.Status.MemoryDumpRequest == nil {
 		return nil
@@ -2491,6 +2548,7 @@ func (c *VMController) syncConditions(vm *virtv1.VirtualMachine, vmi *virtv1.Vir
 	// ready condition is handled differently as it persists regardless if vmi exists or not
 	c.syncReadyConditionFromVMI(vm, vmi)
 	c.processFailureCondition(vm, vmi, syncErr)
+	c.processInferFromVolumeFailureCondition(vm, syncErr)
 
 	// nothing to do if vmi hasn't been created yet.
 	if vmi == nil {
@@ -2499,8 +2557,9 @@ func (c *VMController) syncConditions(vm *virtv1.VirtualMachine, vmi *virtv1.Vir
 
 	// sync VMI conditions, ignore list represents conditions that are not synced generically
 	syncIgnoreMap := map[string]interface{}{
-		string(virtv1.VirtualMachineReady):   nil,
-		string(virtv1.VirtualMachineFailure): nil,
+		string(virtv1.VirtualMachineReady):                  nil,
+		string(virtv1.VirtualMachineFailure):                nil,
+		string(virtv1.VirtualMachineInferFromVolumeFailure): nil,
 	}
 	vmiCondMap := make(map[string]interface{})
 
@@ -2558,6 +2617,69 @@ func (c *VMController) processFailureCondition(vm *virtv1.VirtualMachine, vmi *v
 	return
 }
 
+// inferInstancetypeAndPreference infers the instancetype and preference from containerDisks and updates the
+// matchers of the VirtualMachine. The VirtualMachine is not started until the inference succeeded. Image labels
+// are looked up in the background, pending is returned until they are available.
+func (c *VMController) inferInstancetypeAndPreference(vm *virtv1.VirtualMachine) (_ *virtv1.VirtualMachine, pending bool, _ syncError) {
+	vmCopy := vm.DeepCopy()
+	if err := c.instancetypeMethods.InferDefaultInstancetype(vmCopy); errors.Is(err, instancetype.ErrImageLabelsPending) {
+		return vm, true, nil
+	} else if err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, inferFromVolumeFailedReason, "Error encountered while inferring the instancetype: %v", err)
+		return vm, false, &syncErrorImpl{err, inferFromVolumeFailedReason}
+	}
+	if err := c.instancetypeMethods.InferDefaultPreference(vmCopy); errors.Is(err, instancetype.ErrImageLabelsPending) {
+		return vm, true, nil
+	} else if err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, inferFromVolumeFailedReason, "Error encountered while inferring the preference: %v", err)
+		return vm, false, &syncErrorImpl{err, inferFromVolumeFailedReason}
+	}
+	updatedVM, err := c.clientset.VirtualMachine(vmCopy.Namespace).Update(context.Background(), vmCopy)
+	if err != nil {
+		return vm, false, &syncErrorImpl{fmt.Errorf("Error encountered when trying to update vm with the inferred instancetype and preference: %v", err), inferFromVolumeFailedReason}
+	}
+	return updatedVM, false, nil
+}
+
+// processInferFromVolumeFailureCondition reports the failures of instancetype and preference inferences. Ignored
+// failures are recorded in annotations of the VirtualMachine, other failures keep the VirtualMachine from starting.
+func (c *VMController) processInferFromVolumeFailureCondition(vm *virtv1.VirtualMachine, syncErr syncError) {
+	vmConditionManager := controller.NewVirtualMachineConditionManager()
+
+	var messages []string
+	if syncErr != nil && syncErr.Reason() == inferFromVolumeFailedReason {
+		messages = append(messages, syncErr.Error())
+	}
+	if message, exists := vm.Annotations[apiinstancetype.InstancetypeInferenceFailureAnnotation]; exists {
+		messages = append(messages, "instancetype: "+message)
+	}
+	if message, exists := vm.Annotations[apiinstancetype.PreferenceInferenceFailureAnnotation]; exists {
+		messages = append(messages, "preference: "+message)
+	}
+
+	if len(messages) == 0 {
+		if vmConditionManager.HasCondition(vm, virtv1.VirtualMachineInferFromVolumeFailure) {
+			vmConditionManager.RemoveCondition(vm, virtv1.VirtualMachineInferFromVolumeFailure)
+		}
+		return
+	}
+
+	message := strings.Join(messages, "; ")
+	cond := vmConditionManager.GetCondition(vm, virtv1.VirtualMachineInferFromVolumeFailure)
+	if cond != nil && cond.Message == message {
+		return
+	}
+	// The condition is only replaced by UpdateCondition when its reason changes
+	vmConditionManager.RemoveCondition(vm, virtv1.VirtualMachineInferFromVolumeFailure)
+	vmConditionManager.UpdateCondition(vm, &virtv1.VirtualMachineCondition{
+		Type:               virtv1.VirtualMachineInferFromVolumeFailure,
+		Reason:             inferFromVolumeFailedReason,
+		Message:            message,
+		LastTransitionTime: v1.Now(),
+		Status:             k8score.ConditionTrue,
+	})
+}
+
 func (c *VMController) isTrimFirstChangeRequestNeeded(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) (clearChangeRequest bool) {
 	if len(vm.Status.StateChangeRequests) == 0 {
 		return false
@@ -2771,12 +2893,25 @@ func (c *VMController) sync(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachin
 		return vm, nil, err
 	}
 
+	// The mutating webhook leaves the inference from containerDisks to the controller, as it requires querying the registry
+	if instancetype.IsInferencePending(vm) {
+		var lookupPending bool
+		vm, lookupPending, syncErr = c.inferInstancetypeAndPreference(vm)
+		if lookupPending {
+			log.Log.Object(vm).V(3).Info("Waiting for the image labels to infer the instancetype and preference")
+			c.Queue.AddAfter(key, imageLabelsLookupRequeueInterval)
+			return vm, nil, nil
+		}
+	}
+
 	// Ensure we have ControllerRevisions of any instancetype or preferences referenced by the VM
-	err = c.instancetypeMethods.StoreControllerRevisions(vm)
-	if err != nil {
-		log.Log.Object(vm).Infof("Failed to store Instancetype ControllerRevisions for VirtualMachine: %s/%s", vm.Namespace, vm.Name)
-		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateVirtualMachineReason, "Error encountered while storing Instancetype ControllerRevisions: %v", err)
-		syncErr = &syncErrorImpl{fmt.Errorf("Error encountered while storing Instancetype ControllerRevisions: %v", err), FailedCreateVirtualMachineReason}
+	if syncErr == nil {
+		err = c.instancetypeMethods.StoreControllerRevisions(vm)
+		if err != nil {
+			log.Log.Object(vm).Infof("Failed to store Instancetype ControllerRevisions for VirtualMachine: %s/%s", vm.Namespace, vm.Name)
+			c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateVirtualMachineReason, "Error encountered while storing Instancetype ControllerRevisions: %v", err)
+			syncErr = &syncErrorImpl{fmt.Errorf("Error encountered while storing Instancetype ControllerRevisions: %v", err), FailedCreateVirtualMachineReason}
+		}
 	}
 
 	if syncErr == nil {
@@ -2843,6 +2978,10 @@ func (c *VMController) sync(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachin
 			syncErr = &syncErrorImpl{fmt.Errorf("Error encountered while handling node affinity change request: %v", err), AffinityChangeErrorReason}
 		}
 
+		if err := c.handleIOTuneChangeRequest(vmCopy, vmi); err != nil {
+			syncErr = &syncErrorImpl{fmt.Errorf("Error encountered while handling disk I/O limits change request: %v", err), IOTuneChangeErrorReason}
+		}
+
 		if err := c.handleMemoryHotplugRequest(vmCopy, vmi); err != nil {
 			syncErr = &syncErrorImpl{
 				err:    fmt.Errorf("error encountered while handling memory hotplug requests: %v", err),
@@ -2965,8 +3104,10 @@ func (c *VMController) handleMemoryHotplugRequest(vm *virtv1.VirtualMachine, vmi
 		return fmt.Errorf("memory hotplug is not allowed while VMI is migrating")
 	}
 
+	// The guest may still be plugging or unplugging memory blocks, so the
+	// memory requests follow the difference between the requested sizes.
 	newMemoryReq := vm.Spec.Template.Spec.Domain.Memory.Guest.DeepCopy()
-	newMemoryReq.Sub(*vmi.Status.Memory.GuestCurrent)
+	newMemoryReq.Sub(*guestMemory)
 	newMemoryReq.Add(*vmi.Spec.Domain.Resources.Requests.Memory())
 
 	guestTest := fmt.Sprintf(`{ "op": "test", "path": "/spec/domain/memory/guest", "value": "%s"}`, vmi.Spec.Domain.Memory.Guest.String())
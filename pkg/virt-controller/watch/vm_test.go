@@ -2415,6 +2415,117 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		It("should add InferFromVolumeFailure condition from the ignored inference failures", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Annotations[instancetypeapi.InstancetypeInferenceFailureAnnotation] = "unable to find required label on image"
+			vm.Annotations[instancetypeapi.PreferenceInferenceFailureAnnotation] = "unable to look up labels of image"
+			addVirtualMachine(vm)
+
+			markAsReady(vmi)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, obj interface{}) {
+				objVM := obj.(*virtv1.VirtualMachine)
+				cond := virtcontroller.NewVirtualMachineConditionManager().
+					GetCondition(objVM, virtv1.VirtualMachineInferFromVolumeFailure)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+				Expect(cond.Reason).To(Equal("InferFromVolumeFailed"))
+				Expect(cond.Message).To(Equal("instancetype: unable to find required label on image; preference: unable to look up labels of image"))
+			}).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		It("should update the VM with the instancetype and preference inferred from a containerDisk", func() {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Spec.Instancetype = &virtv1.InstancetypeMatcher{InferFromVolume: "disk"}
+			vm.Spec.Preference = &virtv1.PreferenceMatcher{InferFromVolume: "disk"}
+			addVirtualMachine(vm)
+
+			instancetypeMethods.InferDefaultInstancetypeFunc = func(vm *virtv1.VirtualMachine) error {
+				vm.Spec.Instancetype = &virtv1.InstancetypeMatcher{Name: "inferred-instancetype"}
+				return nil
+			}
+			instancetypeMethods.InferDefaultPreferenceFunc = func(vm *virtv1.VirtualMachine) error {
+				vm.Spec.Preference = &virtv1.PreferenceMatcher{Name: "inferred-preference"}
+				return nil
+			}
+
+			vmInterface.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(func(ctx context.Context, arg interface{}) (*virtv1.VirtualMachine, error) {
+				updatedVM := arg.(*virtv1.VirtualMachine)
+				Expect(updatedVM.Spec.Instancetype).To(Equal(&virtv1.InstancetypeMatcher{Name: "inferred-instancetype"}))
+				Expect(updatedVM.Spec.Preference).To(Equal(&virtv1.PreferenceMatcher{Name: "inferred-preference"}))
+				return updatedVM, nil
+			})
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		It("should not start the VM and requeue it while the image labels are looked up", func() {
+			vm, _ := DefaultVirtualMachine(true)
+			vm.Spec.Instancetype = &virtv1.InstancetypeMatcher{InferFromVolume: "disk"}
+			addVirtualMachine(vm)
+
+			instancetypeMethods.InferDefaultInstancetypeFunc = func(_ *virtv1.VirtualMachine) error {
+				return fmt.Errorf("unable to infer instancetype from volume disk: %w", instancetype.ErrImageLabelsPending)
+			}
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, obj interface{}) {
+				objVM := obj.(*virtv1.VirtualMachine)
+				Expect(virtcontroller.NewVirtualMachineConditionManager().
+					HasCondition(objVM, virtv1.VirtualMachineInferFromVolumeFailure)).To(BeFalse())
+			}).Return(vm, nil).AnyTimes()
+
+			controller.Execute()
+			Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(BeZero())
+		})
+
+		It("should not start the VM and report the failure when the inference from a containerDisk fails", func() {
+			vm, _ := DefaultVirtualMachine(true)
+			vm.Spec.Instancetype = &virtv1.InstancetypeMatcher{InferFromVolume: "disk"}
+			addVirtualMachine(vm)
+
+			instancetypeMethods.InferDefaultInstancetypeFunc = func(_ *virtv1.VirtualMachine) error {
+				return fmt.Errorf("unable to look up labels of image")
+			}
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, obj interface{}) {
+				objVM := obj.(*virtv1.VirtualMachine)
+				cond := virtcontroller.NewVirtualMachineConditionManager().
+					GetCondition(objVM, virtv1.VirtualMachineInferFromVolumeFailure)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+				Expect(cond.Message).To(Equal("unable to look up labels of image"))
+			}).Return(vm, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, "InferFromVolumeFailed")
+		})
+
+		It("should remove InferFromVolumeFailure condition when no inference failure is recorded", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
+				Type:   virtv1.VirtualMachineInferFromVolumeFailure,
+				Status: k8sv1.ConditionTrue,
+			})
+			addVirtualMachine(vm)
+
+			markAsReady(vmi)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().UpdateStatus(context.Background(), gomock.Any()).Do(func(ctx context.Context, obj interface{}) {
+				objVM := obj.(*virtv1.VirtualMachine)
+				cond := virtcontroller.NewVirtualMachineConditionManager().
+					GetCondition(objVM, virtv1.VirtualMachineInferFromVolumeFailure)
+				Expect(cond).To(BeNil())
+			}).Return(vm, nil)
+
+			controller.Execute()
+		})
+
 		It("should back off if a sync error occurs", func() {
 			vm, vmi := DefaultVirtualMachine(false)
 
@@ -5151,6 +5262,56 @@ var _ = Describe("VirtualMachine", func() {
 					Expect(err).ToNot(HaveOccurred())
 				})
 
+				It("should patch VMI when memory hot-unplug is requested", func() {
+					vm, _ := DefaultVirtualMachine(true)
+					newMemory := resource.MustParse("64Mi")
+					vm.Spec.Template.Spec.Domain.Memory = &virtv1.Memory{Guest: &newMemory}
+					vm.Spec.LiveUpdateFeatures = &virtv1.LiveUpdateFeatures{
+						Memory: &virtv1.LiveUpdateMemory{
+							MaxGuest: &maxGuestFromSpec,
+						},
+					}
+
+					vmi := api.NewMinimalVMI(vm.Name)
+					guestAtBoot := resource.MustParse("32Mi")
+					guestMemory := resource.MustParse("128Mi")
+					// the guest did not plug all of the requested memory yet
+					guestCurrent := resource.MustParse("96Mi")
+					vmi.Spec.Domain.Memory = &virtv1.Memory{Guest: &guestMemory}
+
+					memReqBuffer := resource.MustParse("100Mi")
+					memoryRequest := guestMemory.DeepCopy()
+					memoryRequest.Add(memReqBuffer)
+					vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory] = memoryRequest
+
+					vmi.Status.Memory = &virtv1.MemoryStatus{
+						GuestAtBoot:    &guestAtBoot,
+						GuestCurrent:   &guestCurrent,
+						GuestRequested: &guestMemory,
+					}
+
+					vmiInterface.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, gomock.Any(), &metav1.PatchOptions{}).Do(func(ctx context.Context, name, patchType, patch, opts interface{}, subs ...interface{}) {
+						originalVMIBytes, err := json.Marshal(vmi)
+						Expect(err).ToNot(HaveOccurred())
+
+						patchJSON, err := jsonpatch.DecodePatch(patch.([]byte))
+						Expect(err).ToNot(HaveOccurred())
+						newVMIBytes, err := patchJSON.Apply(originalVMIBytes)
+						Expect(err).ToNot(HaveOccurred())
+
+						var newVMI *virtv1.VirtualMachineInstance
+						Expect(json.Unmarshal(newVMIBytes, &newVMI)).To(Succeed())
+
+						expectedMemReq := newMemory.DeepCopy()
+						expectedMemReq.Add(memReqBuffer)
+
+						Expect(newVMI.Spec.Domain.Memory.Guest.Value()).To(Equal(newMemory.Value()))
+						Expect(newVMI.Spec.Domain.Resources.Requests.Memory().Value()).To(Equal(expectedMemReq.Value()))
+					})
+
+					Expect(controller.handleMemoryHotplugRequest(vm, vmi)).To(Succeed())
+				})
+
 				It("should not patch VMI if memory hotplug is already in progress", func() {
 					vm, _ := DefaultVirtualMachine(true)
 					newMemory := resource.MustParse("128Mi")
@@ -5230,6 +5391,76 @@ var _ = Describe("VirtualMachine", func() {
 					Expect(err).ToNot(HaveOccurred())
 				})
 			})
+
+			Context("IOTune", func() {
+				newIOTune := func(iops int64) *virtv1.DiskIOTune {
+					return &virtv1.DiskIOTune{Total: &virtv1.DiskIOLimits{IOPS: &iops}}
+				}
+
+				It("should patch the VMI disks when the I/O limits changed", func() {
+					vm, vmi := DefaultVirtualMachine(true)
+					vm.Spec.LiveUpdateFeatures = &virtv1.LiveUpdateFeatures{IOTune: &virtv1.LiveUpdateIOTune{}}
+					vm.Spec.Template.Spec.Domain.Devices.Disks = []virtv1.Disk{
+						{Name: "disk0", IOTune: newIOTune(200)},
+						{Name: "disk1"},
+					}
+					vmi.Spec.Domain.Devices.Disks = []virtv1.Disk{
+						{Name: "disk0", IOTune: newIOTune(100)},
+						{Name: "disk1", IOTune: newIOTune(100)},
+						{Name: "hotplugged", IOTune: newIOTune(100)},
+					}
+
+					vmiInterface.EXPECT().Patch(context.Background(), vmi.Name, types.JSONPatchType, gomock.Any(), &metav1.PatchOptions{}).Do(func(ctx context.Context, name, patchType, patch, opts interface{}, subs ...interface{}) {
+						originalVMIBytes, err := json.Marshal(vmi)
+						Expect(err).ToNot(HaveOccurred())
+						patchJSON, err := jsonpatch.DecodePatch(patch.([]byte))
+						Expect(err).ToNot(HaveOccurred())
+						newVMIBytes, err := patchJSON.Apply(originalVMIBytes)
+						Expect(err).ToNot(HaveOccurred())
+
+						var newVMI *virtv1.VirtualMachineInstance
+						Expect(json.Unmarshal(newVMIBytes, &newVMI)).To(Succeed())
+						Expect(newVMI.Spec.Domain.Devices.Disks).To(Equal([]virtv1.Disk{
+							{Name: "disk0", IOTune: newIOTune(200)},
+							{Name: "disk1"},
+							{Name: "hotplugged", IOTune: newIOTune(100)},
+						}))
+					})
+
+					Expect(controller.handleIOTuneChangeRequest(vm, vmi)).To(Succeed())
+				})
+
+				It("should not patch the VMI when the I/O limits did not change", func() {
+					vm, vmi := DefaultVirtualMachine(true)
+					vm.Spec.LiveUpdateFeatures = &virtv1.LiveUpdateFeatures{IOTune: &virtv1.LiveUpdateIOTune{}}
+					vm.Spec.Template.Spec.Domain.Devices.Disks = []virtv1.Disk{{Name: "disk0", IOTune: newIOTune(100)}}
+					vmi.Spec.Domain.Devices.Disks = []virtv1.Disk{{Name: "disk0", IOTune: newIOTune(100)}}
+
+					Expect(controller.handleIOTuneChangeRequest(vm, vmi)).To(Succeed())
+				})
+
+				It("should not patch the VMI when the live update of I/O limits is not enabled", func() {
+					vm, vmi := DefaultVirtualMachine(true)
+					vm.Spec.LiveUpdateFeatures = &virtv1.LiveUpdateFeatures{Affinity: &virtv1.LiveUpdateAffinity{}}
+					vm.Spec.Template.Spec.Domain.Devices.Disks = []virtv1.Disk{{Name: "disk0", IOTune: newIOTune(200)}}
+					vmi.Spec.Domain.Devices.Disks = []virtv1.Disk{{Name: "disk0", IOTune: newIOTune(100)}}
+
+					Expect(controller.handleIOTuneChangeRequest(vm, vmi)).To(Succeed())
+				})
+
+				It("should not patch the VMI if a migration is in progress", func() {
+					vm, vmi := DefaultVirtualMachine(true)
+					vm.Spec.LiveUpdateFeatures = &virtv1.LiveUpdateFeatures{IOTune: &virtv1.LiveUpdateIOTune{}}
+					vm.Spec.Template.Spec.Domain.Devices.Disks = []virtv1.Disk{{Name: "disk0", IOTune: newIOTune(200)}}
+					vmi.Spec.Domain.Devices.Disks = []virtv1.Disk{{Name: "disk0", IOTune: newIOTune(100)}}
+					migrationStart := metav1.Now()
+					vmi.Status.MigrationState = &virtv1.VirtualMachineInstanceMigrationState{
+						StartTimestamp: &migrationStart,
+					}
+
+					Expect(controller.handleIOTuneChangeRequest(vm, vmi)).To(HaveOccurred())
+				})
+			})
 		})
 
 		Context("CPU topology", func() {
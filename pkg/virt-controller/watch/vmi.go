@@ -36,6 +36,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -57,6 +58,7 @@ import (
 	storagetypes "kubevirt.io/kubevirt/pkg/storage/types"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/hardware"
+	"kubevirt.io/kubevirt/pkg/util/migrations"
 	traceUtils "kubevirt.io/kubevirt/pkg/util/trace"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
@@ -725,7 +727,7 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 		}
 
 		if c.requireMemoryHotplug(vmiCopy) {
-			c.syncMemoryHotplug(vmiCopy)
+			c.syncMemoryHotplug(vmiCopy, pod)
 		}
 
 	case vmi.IsScheduled():
@@ -1744,7 +1746,7 @@ func getHotplugVolumes(vmi *virtv1.VirtualMachineInstance, virtlauncherPod *k8sv
 		podVolumeMap[podVolume.Name] = podVolume
 	}
 	for _, vmiVolume := range vmiVolumes {
-		if _, ok := podVolumeMap[vmiVolume.Name]; !ok && (vmiVolume.DataVolume != nil || vmiVolume.PersistentVolumeClaim != nil || vmiVolume.MemoryDump != nil) {
+		if _, ok := podVolumeMap[vmiVolume.Name]; !ok && (vmiVolume.DataVolume != nil || vmiVolume.PersistentVolumeClaim != nil || vmiVolume.MemoryDump != nil || vmiVolume.Backup != nil) {
 			hotplugVolumes = append(hotplugVolumes, vmiVolume.DeepCopy())
 		}
 	}
@@ -2153,6 +2155,12 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 					ClaimName: volume.Name,
 				}
 			}
+			if volume.Backup != nil && status.BackupVolume == nil {
+				status.BackupVolume = &virtv1.DomainBackupInfo{
+					BackupName:      volume.Backup.BackupName,
+					IncrementalFrom: volume.Backup.IncrementalFrom,
+				}
+			}
 			if attachmentPod == nil {
 				if !c.volumeReady(status.Phase) {
 					status.HotplugVolume.AttachPodUID = ""
@@ -2179,7 +2187,7 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 			}
 		}
 
-		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil || volume.VolumeSource.MemoryDump != nil {
+		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil || volume.VolumeSource.MemoryDump != nil || volume.VolumeSource.Backup != nil {
 
 			pvcName := storagetypes.PVCNameFromVirtVolume(&volume)
 
@@ -2324,7 +2332,7 @@ func (c *VMIController) updateInterfaceStatus(vmi *virtv1.VirtualMachineInstance
 			return fmt.Errorf("could not find the pod interface name for network [%s]", network.Name)
 		}
 
-		_, exists := indexedMultusStatusIfaces[podIfaceName]
+		podIfaceStatus, exists := indexedMultusStatusIfaces[podIfaceName]
 		switch {
 		case exists && vmiIfaceStatus == nil:
 			vmi.Status.Interfaces = append(vmi.Status.Interfaces, virtv1.VirtualMachineInstanceNetworkInterface{
@@ -2336,11 +2344,32 @@ func (c *VMIController) updateInterfaceStatus(vmi *virtv1.VirtualMachineInstance
 		case !exists && vmiIfaceStatus != nil:
 			vmiIfaceStatus.InfoSource = vmispec.RemoveInfoSource(vmiIfaceStatus.InfoSource, vmispec.InfoSourceMultusStatus)
 		}
+
+		if exists {
+			updateInterfaceStatusIPsFromMultus(vmi, network.Name, podIfaceStatus)
+		}
 	}
 
 	return nil
 }
 
+// updateInterfaceStatusIPsFromMultus reports the IPs of a bridge bound secondary interface as seen by multus.
+// Guests without an agent report no IPs at all, the IPs reported by the guest agent take precedence.
+// The IPs are reconciled on every sync, so that changes of the pod network-status annotation are reflected.
+// This is done by the virt-controller since virt-handler does not have access to the pod annotations.
+func updateInterfaceStatusIPsFromMultus(vmi *virtv1.VirtualMachineInstance, networkName string, podIfaceStatus networkv1.NetworkStatus) {
+	iface := vmispec.LookupInterfaceByName(vmi.Spec.Domain.Devices.Interfaces, networkName)
+	if iface == nil || iface.Bridge == nil || len(podIfaceStatus.IPs) == 0 {
+		return
+	}
+	vmiIfaceStatus := vmispec.LookupInterfaceStatusByName(vmi.Status.Interfaces, networkName)
+	if vmiIfaceStatus == nil || vmispec.ContainsInfoSource(vmiIfaceStatus.InfoSource, vmispec.InfoSourceGuestAgent) {
+		return
+	}
+	vmiIfaceStatus.IP = podIfaceStatus.IPs[0]
+	vmiIfaceStatus.IPs = podIfaceStatus.IPs
+}
+
 func generateInterfaceStatusPatchRequest(oldInterfaceStatus []byte, newInterfaceStatus []byte) []string {
 	return []string{
 		fmt.Sprintf(`{ "op": "test", "path": "/status/interfaces", "value": %s }`, string(oldInterfaceStatus)),
@@ -2388,7 +2417,7 @@ func (c *VMIController) requireMemoryHotplug(vmi *virtv1.VirtualMachineInstance)
 	return vmi.Spec.Domain.Memory.Guest.Value() != vmi.Status.Memory.GuestRequested.Value()
 }
 
-func (c *VMIController) syncMemoryHotplug(vmi *virtv1.VirtualMachineInstance) {
+func (c *VMIController) syncMemoryHotplug(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) {
 	c.syncHotplugCondition(vmi, virtv1.VirtualMachineInstanceMemoryChange)
 	// store additionalGuestMemoryOverheadRatio
 	overheadRatio := c.clusterConfig.GetConfig().AdditionalGuestMemoryOverheadRatio
@@ -2398,4 +2427,33 @@ func (c *VMIController) syncMemoryHotplug(vmi *virtv1.VirtualMachineInstance) {
 		}
 		vmi.Labels[virtv1.MemoryHotplugOverheadRatioLabel] = *overheadRatio
 	}
+
+	// When the launcher pod already requests enough memory, e.g. because memory gets unplugged,
+	// the guest memory is resized in place by virt-handler instead of migrating the VMI to a bigger pod.
+	if _, exists := vmi.Labels[virtv1.VirtualMachinePodMemoryRequestsLabel]; exists || migrations.IsMigrating(vmi) {
+		return
+	}
+	if podMemReq, fits := c.podFitsGuestMemory(vmi, pod, overheadRatio); fits {
+		if vmi.Labels == nil {
+			vmi.Labels = map[string]string{}
+		}
+		vmi.Labels[virtv1.VirtualMachinePodMemoryRequestsLabel] = podMemReq
+		log.Log.Object(vmi).V(4).Infof("resizing guest memory in place to %s", vmi.Spec.Domain.Memory.Guest.String())
+	}
+}
+
+func (c *VMIController) podFitsGuestMemory(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod, overheadRatio *string) (string, bool) {
+	podMemReqStr, err := getTargetPodMemoryRequests(pod)
+	if err != nil {
+		return "", false
+	}
+	podMemReq, err := resource.ParseQuantity(podMemReqStr)
+	if err != nil {
+		return "", false
+	}
+
+	requiredMemory := services.GetMemoryOverhead(vmi, c.clusterConfig.GetClusterCPUArch(), overheadRatio)
+	requiredMemory.Add(*vmi.Spec.Domain.Resources.Requests.Memory())
+
+	return podMemReqStr, podMemReq.Cmp(requiredMemory) >= 0
 }
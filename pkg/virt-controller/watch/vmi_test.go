@@ -2101,10 +2101,69 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 				kvCR.Spec.Configuration.AdditionalGuestMemoryOverheadRatio = &overheadRatio
 				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvCR)
 
-				controller.syncMemoryHotplug(vmi)
+				controller.syncMemoryHotplug(vmi, NewPodForVirtualMachine(vmi, k8sv1.PodRunning))
 
 				Expect(vmi.Labels).To(HaveKeyWithValue(virtv1.MemoryHotplugOverheadRatioLabel, overheadRatio))
 			})
+
+			Context("when the launcher pod is checked for in-place resizing", func() {
+				var vmi *virtv1.VirtualMachineInstance
+
+				newPodWithMemoryRequests := func(memory string) *k8sv1.Pod {
+					pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+					pod.Spec.Containers = []k8sv1.Container{{
+						Name: "compute",
+						Resources: k8sv1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse(memory)},
+						},
+					}}
+					return pod
+				}
+
+				BeforeEach(func() {
+					currentGuestMemory := resource.MustParse("1Gi")
+					requestedGuestMemory := resource.MustParse("512Mi")
+					maxGuestMemory := resource.MustParse("2Gi")
+
+					vmi = NewPendingVirtualMachine("testvmi")
+					vmi.Status.Phase = virtv1.Running
+					vmi.Status.Memory = &virtv1.MemoryStatus{
+						GuestAtBoot:    &requestedGuestMemory,
+						GuestCurrent:   &currentGuestMemory,
+						GuestRequested: &currentGuestMemory,
+					}
+					vmi.Spec.Domain.Memory = &virtv1.Memory{
+						Guest:    &requestedGuestMemory,
+						MaxGuest: &maxGuestMemory,
+					}
+					vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{k8sv1.ResourceMemory: requestedGuestMemory}
+				})
+
+				It("should resize memory in place when the launcher pod requests enough memory", func() {
+					controller.syncMemoryHotplug(vmi, newPodWithMemoryRequests("4Gi"))
+
+					Expect(kvcontroller.VMIHasHotplugMemory(vmi)).To(BeTrue())
+					Expect(vmi.Labels).To(HaveKeyWithValue(virtv1.VirtualMachinePodMemoryRequestsLabel, "4Gi"))
+					Expect(kvcontroller.VMIHasInPlaceMemoryChange(vmi)).To(BeTrue())
+				})
+
+				It("should require a migration when the launcher pod does not request enough memory", func() {
+					controller.syncMemoryHotplug(vmi, newPodWithMemoryRequests("512Mi"))
+
+					Expect(kvcontroller.VMIHasHotplugMemory(vmi)).To(BeTrue())
+					Expect(vmi.Labels).ToNot(HaveKey(virtv1.VirtualMachinePodMemoryRequestsLabel))
+					Expect(kvcontroller.VMIHasInPlaceMemoryChange(vmi)).To(BeFalse())
+				})
+
+				It("should not resize memory in place while the VMI is migrating", func() {
+					now := metav1.Now()
+					vmi.Status.MigrationState = &virtv1.VirtualMachineInstanceMigrationState{StartTimestamp: &now}
+
+					controller.syncMemoryHotplug(vmi, newPodWithMemoryRequests("4Gi"))
+
+					Expect(vmi.Labels).ToNot(HaveKey(virtv1.VirtualMachinePodMemoryRequestsLabel))
+				})
+			})
 		})
 	})
 
@@ -3467,6 +3526,61 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 					PodVmIfaceStatus{
 						vmIfaceStatus: simpleIfaceStatus(ifaceName),
 					}),
+				Entry("VMI with a bridge interface reports the IPs of the pod interface",
+					withBridgeBinding(newVMIWithOneIface(api.NewMinimalVMI(vmName), networkName, ifaceName)),
+					PodVmIfaceStatus{
+						vmIfaceStatus: &virtv1.VirtualMachineInstanceNetworkInterface{
+							Name:       ifaceName,
+							InfoSource: vmispec.InfoSourceMultusStatus,
+							IP:         "10.10.10.2",
+							IPs:        []string{"10.10.10.2", "fd10:10::2"},
+						},
+						podIfaceStatus: &networkv1.NetworkStatus{
+							Name:      networkName,
+							Interface: "pod7e0055a6880",
+							IPs:       []string{"10.10.10.2", "fd10:10::2"},
+						},
+					}),
+				Entry("VMI with a bridge interface updates stale IPs of the pod interface",
+					withBridgeBinding(newVMIWithMultusIPs(newVMIWithOneIface(api.NewMinimalVMI(vmName), networkName, ifaceName), ifaceName, "10.10.10.4")),
+					PodVmIfaceStatus{
+						vmIfaceStatus: &virtv1.VirtualMachineInstanceNetworkInterface{
+							Name:       ifaceName,
+							InfoSource: vmispec.InfoSourceMultusStatus,
+							IP:         "10.10.10.2",
+							IPs:        []string{"10.10.10.2"},
+						},
+						podIfaceStatus: &networkv1.NetworkStatus{
+							Name:      networkName,
+							Interface: "pod7e0055a6880",
+							IPs:       []string{"10.10.10.2"},
+						},
+					}),
+				Entry("VMI with a bridge interface keeps the IPs reported by the guest agent",
+					withBridgeBinding(newVMIWithGuestAgentIPs(newVMIWithOneIface(api.NewMinimalVMI(vmName), networkName, ifaceName), ifaceName, "10.10.10.3")),
+					PodVmIfaceStatus{
+						vmIfaceStatus: &virtv1.VirtualMachineInstanceNetworkInterface{
+							Name:       ifaceName,
+							InfoSource: vmispec.NewInfoSource(vmispec.InfoSourceDomain, vmispec.InfoSourceGuestAgent, vmispec.InfoSourceMultusStatus),
+							IP:         "10.10.10.3",
+							IPs:        []string{"10.10.10.3"},
+						},
+						podIfaceStatus: &networkv1.NetworkStatus{
+							Name:      networkName,
+							Interface: "pod7e0055a6880",
+							IPs:       []string{"10.10.10.2"},
+						},
+					}),
+				Entry("VMI with a non bridge interface does not report the IPs of the pod interface",
+					newVMIWithOneIface(api.NewMinimalVMI(vmName), networkName, ifaceName),
+					PodVmIfaceStatus{
+						vmIfaceStatus: readyHotpluggedIfaceStatus(ifaceName),
+						podIfaceStatus: &networkv1.NetworkStatus{
+							Name:      networkName,
+							Interface: "pod7e0055a6880",
+							IPs:       []string{"10.10.10.2"},
+						},
+					}),
 			)
 		})
 	})
@@ -3796,3 +3910,32 @@ func newVMIWithGuestAgentInterface(vmi *virtv1.VirtualMachineInstance, ifaceName
 	})
 	return vmi
 }
+
+func withBridgeBinding(vmi *virtv1.VirtualMachineInstance) *virtv1.VirtualMachineInstance {
+	for i := range vmi.Spec.Domain.Devices.Interfaces {
+		vmi.Spec.Domain.Devices.Interfaces[i].InterfaceBindingMethod = virtv1.InterfaceBindingMethod{
+			Bridge: &virtv1.InterfaceBridge{},
+		}
+	}
+	return vmi
+}
+
+func newVMIWithMultusIPs(vmi *virtv1.VirtualMachineInstance, ifaceName string, ips ...string) *virtv1.VirtualMachineInstance {
+	vmi.Status.Interfaces = append(vmi.Status.Interfaces, virtv1.VirtualMachineInstanceNetworkInterface{
+		Name:       ifaceName,
+		InfoSource: vmispec.InfoSourceMultusStatus,
+		IP:         ips[0],
+		IPs:        ips,
+	})
+	return vmi
+}
+
+func newVMIWithGuestAgentIPs(vmi *virtv1.VirtualMachineInstance, ifaceName string, ips ...string) *virtv1.VirtualMachineInstance {
+	vmi.Status.Interfaces = append(vmi.Status.Interfaces, virtv1.VirtualMachineInstanceNetworkInterface{
+		Name:       ifaceName,
+		InfoSource: vmispec.InfoSourceDomainAndGA,
+		IP:         ips[0],
+		IPs:        ips,
+	})
+	return vmi
+}
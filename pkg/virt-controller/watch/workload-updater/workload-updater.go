@@ -319,7 +319,7 @@ func (c *WorkloadUpdateController) isOutdated(vmi *virtv1.VirtualMachineInstance
 func isHotplugInProgress(vmi *virtv1.VirtualMachineInstance) bool {
 	condManager := controller.NewVirtualMachineInstanceConditionManager()
 	return condManager.HasCondition(vmi, virtv1.VirtualMachineInstanceVCPUChange) ||
-		condManager.HasCondition(vmi, virtv1.VirtualMachineInstanceMemoryChange)
+		(condManager.HasCondition(vmi, virtv1.VirtualMachineInstanceMemoryChange) && !controller.VMIHasInPlaceMemoryChange(vmi))
 }
 
 func (c *WorkloadUpdateController) doesRequireMigration(vmi *virtv1.VirtualMachineInstance) bool {
@@ -455,6 +455,19 @@ var _ = Describe("Workload Updater", func() {
 
 			Expect(controller.doesRequireMigration(vmi)).To(BeTrue())
 		})
+
+		It("VMI does not need to be migrated when memory is resized in place", func() {
+			vmi := api.NewMinimalVMI("testvm")
+			vmi.Labels = map[string]string{v1.VirtualMachinePodMemoryRequestsLabel: "4Gi"}
+
+			condition := v1.VirtualMachineInstanceCondition{
+				Type:   v1.VirtualMachineInstanceMemoryChange,
+				Status: k8sv1.ConditionTrue,
+			}
+			virtcontroller.NewVirtualMachineInstanceConditionManager().UpdateCondition(vmi, &condition)
+
+			Expect(controller.doesRequireMigration(vmi)).To(BeFalse())
+		})
 	})
 
 	AfterEach(func() {
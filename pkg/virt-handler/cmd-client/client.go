@@ -81,6 +81,17 @@ type MigrationOptions struct {
 	ParallelMigrationThreads *uint
 }
 
+type BackupOptions struct {
+	// Name of the backup, also used as the name of the checkpoint created along with it
+	Name string
+	// IncrementalFrom is the name of the checkpoint to take an incremental backup from
+	IncrementalFrom string
+	// TargetDir is the directory the backup files are written to
+	TargetDir string
+	// TimeoutSeconds is the time the backup job may take before it is aborted
+	TimeoutSeconds int64
+}
+
 type LauncherClient interface {
 	SyncVirtualMachine(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
 	PauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
@@ -113,6 +124,7 @@ type LauncherClient interface {
 	GetLaunchMeasurement(*v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error)
 	InjectLaunchSecret(*v1.VirtualMachineInstance, *v1.SEVSecretOptions) error
 	SyncVirtualMachineMemory(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
+	BackupVirtualMachine(vmi *v1.VirtualMachineInstance, options *BackupOptions) error
 }
 
 type VirtLauncherClient struct {
@@ -856,6 +868,32 @@ func (c *VirtLauncherClient) InjectLaunchSecret(vmi *v1.VirtualMachineInstance,
 	return handleError(err, "InjectLaunchSecret", response)
 }
 
+func (c *VirtLauncherClient) BackupVirtualMachine(vmi *v1.VirtualMachineInstance, options *BackupOptions) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.BackupRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+
+	response, err := c.v1client.BackupVirtualMachine(ctx, request)
+
+	return handleError(err, "BackupVirtualMachine", response)
+}
+
 func (c *VirtLauncherClient) SyncVirtualMachineMemory(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error {
 	return c.genericSendVMICmd("SyncVirtualMachineMemory", c.v1client.SyncVirtualMachineMemory, vmi, options)
 }
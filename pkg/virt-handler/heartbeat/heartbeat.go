@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,10 +13,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	utilwait "k8s.io/apimachinery/pkg/util/wait"
 	k8scli "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/api/core/v1"
+	memoryv1alpha1 "kubevirt.io/api/memory/v1alpha1"
 	"kubevirt.io/client-go/log"
 
+	"kubevirt.io/kubevirt/pkg/monitoring/nodememory"
 	virtutil "kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	device_manager "kubevirt.io/kubevirt/pkg/virt-handler/device-manager"
@@ -31,14 +35,20 @@ type HeartBeat struct {
 	cpuManagerPaths           []string
 	devicePluginPollIntervall time.Duration
 	devicePluginWaitTimeout   time.Duration
+	memoryPolicyStore         cache.Store
+	vmiStore                  cache.Store
+	memoryPolicy              *memoryv1alpha1.NodeMemoryPolicy
+	memoryPolicyLock          sync.Mutex
 }
 
-func NewHeartBeat(clientset k8scli.CoreV1Interface, deviceManager device_manager.DeviceControllerInterface, clusterConfig *virtconfig.ClusterConfig, host string) *HeartBeat {
+func NewHeartBeat(clientset k8scli.CoreV1Interface, deviceManager device_manager.DeviceControllerInterface, clusterConfig *virtconfig.ClusterConfig, host string, memoryPolicyStore cache.Store, vmiStore cache.Store) *HeartBeat {
 	return &HeartBeat{
 		clientset:               clientset,
 		deviceManagerController: deviceManager,
 		clusterConfig:           clusterConfig,
 		host:                    host,
+		memoryPolicyStore:       memoryPolicyStore,
+		vmiStore:                vmiStore,
 		// This is a temporary workaround until k8s bug #66525 is resolved
 		cpuManagerPaths:           []string{virtutil.CPUManagerPath, virtutil.CPUManagerOS3Path},
 		devicePluginPollIntervall: 1 * time.Second,
@@ -141,7 +151,15 @@ func (h *HeartBeat) do() {
 		log.DefaultLogger().Reason(err).Errorf("Can't get node %s", h.host)
 		return
 	}
-	ksmEnabled, ksmEnabledByUs := handleKSM(node, h.clusterConfig)
+	memoryPolicy := nodeMemoryPolicy(h.memoryPolicyStore, node)
+	h.setMemoryPolicy(memoryPolicy)
+	ksmEnabled, ksmEnabledByUs := handleKSM(node, h.clusterConfig, memoryPolicy)
+
+	overcommitPercent := memoryOvercommitPercent(node, h.vmiStore)
+	if overcommitLimitReached(memoryPolicy, overcommitPercent) {
+		kubevirtSchedulable = "false"
+	}
+	h.updateMemoryPolicyMetrics(memoryPolicy, ksmEnabled, overcommitPercent)
 
 	data = []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%s", "%s": "%t", "%s": "%t"}, "annotations": {"%s": %s, "%s": "%t"}}}`,
 		v1.NodeSchedulable, kubevirtSchedulable,
@@ -156,6 +174,8 @@ func (h *HeartBeat) do() {
 		return
 	}
 
+	h.patchMemoryPolicyAnnotations(memoryPolicy, overcommitPercent)
+
 	// A configuration of mediated devices types on this node depends on the existing node labels
 	// and a MediatedDevicesConfiguration in KubeVirt CR.
 	// When labels change we should initialize a refresh to create/remove mdev types and start/stop
@@ -167,6 +187,47 @@ func (h *HeartBeat) do() {
 	log.DefaultLogger().V(4).Infof("Heartbeat sent")
 }
 
+// MemoryPolicy returns the NodeMemoryPolicy applied on the node by the last heartbeat
+func (h *HeartBeat) MemoryPolicy() *memoryv1alpha1.NodeMemoryPolicy {
+	h.memoryPolicyLock.Lock()
+	defer h.memoryPolicyLock.Unlock()
+	return h.memoryPolicy
+}
+
+func (h *HeartBeat) setMemoryPolicy(policy *memoryv1alpha1.NodeMemoryPolicy) {
+	h.memoryPolicyLock.Lock()
+	defer h.memoryPolicyLock.Unlock()
+	h.memoryPolicy = policy
+}
+
+func (h *HeartBeat) updateMemoryPolicyMetrics(policy *memoryv1alpha1.NodeMemoryPolicy, ksmEnabled bool, overcommitPercent int64) {
+	nodememory.SetKSMRunning(h.host, ksmEnabled)
+	nodememory.SetMemoryOvercommitPercent(h.host, float64(overcommitPercent))
+	if policy == nil {
+		nodememory.SetMemoryPolicy(h.host, "")
+		nodememory.SetMaxMemoryOvercommitPercent(h.host, nil)
+		return
+	}
+	nodememory.SetMemoryPolicy(h.host, policy.Name)
+	nodememory.SetMaxMemoryOvercommitPercent(h.host, policy.Spec.MaxMemoryOvercommitPercent)
+}
+
+func (h *HeartBeat) patchMemoryPolicyAnnotations(policy *memoryv1alpha1.NodeMemoryPolicy, overcommitPercent int64) {
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": memoryPolicyAnnotations(policy, overcommitPercent),
+		},
+	})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("Can't create the memory policy annotations patch for node %s", h.host)
+		return
+	}
+	_, err = h.clientset.Nodes().Patch(context.Background(), h.host, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("Can't patch the memory policy annotations of node %s", h.host)
+	}
+}
+
 func (h *HeartBeat) isCPUManagerEnabled(cpuManagerPaths []string) bool {
 	var cpuManagerOptions map[string]interface{}
 	cpuManagerPath, err := detectCPUManagerFile(cpuManagerPaths)
@@ -32,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	kubevirtv1 "kubevirt.io/api/core/v1"
+	memoryv1alpha1 "kubevirt.io/api/memory/v1alpha1"
 	"kubevirt.io/client-go/log"
 
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -245,16 +246,62 @@ func getIntParam(node *v1.Node, param string, defaultValue, lowerBound, upperBou
 	return boundCheck(value, defaultValue, lowerBound, upperBound, fmt.Sprintf("%s override value out of bounds", param))
 }
 
+func getPolicyParam(value *int32, defaultValue, lowerBound, upperBound int, param string) int {
+	if value == nil {
+		return defaultValue
+	}
+
+	return boundCheck(int(*value), defaultValue, lowerBound, upperBound, fmt.Sprintf("NodeMemoryPolicy %s value out of bounds", param))
+}
+
+func setKSMParamsFromAnnotations(node *v1.Node) {
+	pagesBoost = getIntParam(node, kubevirtv1.KSMPagesBoostOverride, pagesBoostDefault, 0, math.MaxInt)
+	pagesDecay = getIntParam(node, kubevirtv1.KSMPagesDecayOverride, pagesDecayDefault, math.MinInt, 0)
+	nPagesMin = getIntParam(node, kubevirtv1.KSMPagesMinOverride, nPagesMinDefault, 0, math.MaxInt)
+	nPagesMax = getIntParam(node, kubevirtv1.KSMPagesMaxOverride, nPagesMaxDefault, nPagesMin, math.MaxInt)
+	nPagesInit = getIntParam(node, kubevirtv1.KSMPagesInitOverride, nPagesInitDefault, nPagesMin, nPagesMax)
+	sleepMsBaseline = uint64(getIntParam(node, kubevirtv1.KSMSleepMsBaselineOverride, sleepMsBaselineDefault, 1, math.MaxInt))
+	freePercent = getFloatParam(node, kubevirtv1.KSMFreePercentOverride, freePercentDefault, 0, 1)
+}
+
+// setKSMParamsFromPolicy uses the KSM tuning of a NodeMemoryPolicy, the policy expresses
+// the decay as a positive number of pages and the free memory threshold in percent
+func setKSMParamsFromPolicy(ksmPolicy *memoryv1alpha1.KSMPolicy) {
+	pagesBoost = getPolicyParam(ksmPolicy.PagesBoost, pagesBoostDefault, 0, math.MaxInt, "pagesBoost")
+	pagesDecay = -getPolicyParam(ksmPolicy.PagesDecay, -pagesDecayDefault, 0, math.MaxInt, "pagesDecay")
+	nPagesMin = getPolicyParam(ksmPolicy.PagesMin, nPagesMinDefault, 0, math.MaxInt, "pagesMin")
+	nPagesMax = getPolicyParam(ksmPolicy.PagesMax, nPagesMaxDefault, nPagesMin, math.MaxInt, "pagesMax")
+	nPagesInit = getPolicyParam(ksmPolicy.PagesInit, nPagesInitDefault, nPagesMin, nPagesMax, "pagesInit")
+	sleepMsBaseline = uint64(getPolicyParam(ksmPolicy.SleepMsBaseline, sleepMsBaselineDefault, 1, math.MaxInt, "sleepMsBaseline"))
+	freePercent = freePercentDefault
+	if ksmPolicy.FreePercent != nil {
+		freePercent = float32(getPolicyParam(ksmPolicy.FreePercent, freePercentDefault*100, 0, 100, "freePercent")) / 100
+	}
+}
+
 // handleKSM will update the ksm of the node (if available) based on the kv configuration and
 // will set the outcome value to the n.KSM struct
 // If the node labels match the selector terms, the ksm will be enabled.
 // Empty Selector will enable ksm for every node
-func handleKSM(node *v1.Node, clusterConfig *virtconfig.ClusterConfig) (bool, bool) {
+// A NodeMemoryPolicy with a KSM section takes precedence over the kv configuration and the node annotations.
+func handleKSM(node *v1.Node, clusterConfig *virtconfig.ClusterConfig, policy *memoryv1alpha1.NodeMemoryPolicy) (bool, bool) {
 	available, running := loadKSM()
 	if !available {
 		return running, false
 	}
 
+	if policy != nil && policy.Spec.KSM != nil {
+		if !policy.Spec.KSM.Enabled {
+			if disableKSM(node, running) {
+				return false, false
+			} else {
+				return running, false
+			}
+		}
+		setKSMParamsFromPolicy(policy.Spec.KSM)
+		return runKSM(running)
+	}
+
 	ksmConfig := clusterConfig.GetKSMConfiguration()
 	if ksmConfig == nil {
 		if disableKSM(node, running) {
@@ -278,14 +325,11 @@ func handleKSM(node *v1.Node, clusterConfig *virtconfig.ClusterConfig) (bool, bo
 		}
 	}
 
-	pagesBoost = getIntParam(node, kubevirtv1.KSMPagesBoostOverride, pagesBoostDefault, 0, math.MaxInt)
-	pagesDecay = getIntParam(node, kubevirtv1.KSMPagesDecayOverride, pagesDecayDefault, math.MinInt, 0)
-	nPagesMin = getIntParam(node, kubevirtv1.KSMPagesMinOverride, nPagesMinDefault, 0, math.MaxInt)
-	nPagesMax = getIntParam(node, kubevirtv1.KSMPagesMaxOverride, nPagesMaxDefault, nPagesMin, math.MaxInt)
-	nPagesInit = getIntParam(node, kubevirtv1.KSMPagesInitOverride, nPagesInitDefault, nPagesMin, nPagesMax)
-	sleepMsBaseline = uint64(getIntParam(node, kubevirtv1.KSMSleepMsBaselineOverride, sleepMsBaselineDefault, 1, math.MaxInt))
-	freePercent = getFloatParam(node, kubevirtv1.KSMFreePercentOverride, freePercentDefault, 0, 1)
+	setKSMParamsFromAnnotations(node)
+	return runKSM(running)
+}
 
+func runKSM(running bool) (bool, bool) {
 	ksm, err := calculateNewRunSleepAndPages(running)
 	if err != nil {
 		log.DefaultLogger().Reason(err).Errorf("An error occurred while calculating the new KSM values")
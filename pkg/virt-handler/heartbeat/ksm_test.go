@@ -37,7 +37,10 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
 	kubevirtv1 "kubevirt.io/api/core/v1"
+	memoryv1alpha1 "kubevirt.io/api/memory/v1alpha1"
 
 	"kubevirt.io/kubevirt/pkg/testutils"
 
@@ -124,7 +127,7 @@ var _ = Describe("KSM", func() {
 		}
 		fakeClient := fake.NewSimpleClientset(node)
 		createCustomMemInfo(false)
-		heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(virtconfig.CPUManager), "mynode")
+		heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(virtconfig.CPUManager), "mynode", newStore(), newStore())
 
 		heartbeat.do()
 		node, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), "mynode", metav1.GetOptions{})
@@ -190,7 +193,7 @@ var _ = Describe("KSM", func() {
 			err := os.WriteFile(filepath.Join(fakeSysKSMDir, "run"), []byte(initialKsmValue), 0644)
 			Expect(err).ToNot(HaveOccurred())
 			createCustomMemInfo(true)
-			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), clusterConfig, "mynode")
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), clusterConfig, "mynode", newStore(), newStore())
 
 			heartbeat.do()
 
@@ -252,7 +255,7 @@ var _ = Describe("KSM", func() {
 			}
 			fakeClient := fake.NewSimpleClientset(node)
 			createCustomMemInfo(false)
-			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), clusterConfig, "mynode")
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), clusterConfig, "mynode", newStore(), newStore())
 
 			By("running a first heartbeat and expecting no change")
 			heartbeat.do()
@@ -320,7 +323,7 @@ var _ = Describe("KSM", func() {
 			}
 			fakeClient := fake.NewSimpleClientset(node)
 			createCustomMemInfo(false)
-			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), clusterConfig, "mynode")
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), clusterConfig, "mynode", newStore(), newStore())
 
 			By("running a first heartbeat and expecting the right values")
 			heartbeat.do()
@@ -349,5 +352,74 @@ var _ = Describe("KSM", func() {
 			expected.running = false
 			expectKSMState(expected)
 		})
+
+		Context("and a NodeMemoryPolicy with KSM tuning matches the node", func() {
+			var policyStore cache.Store
+
+			BeforeEach(func() {
+				policyStore = newStore()
+				Expect(policyStore.Add(&memoryv1alpha1.NodeMemoryPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "dense"},
+					Spec: memoryv1alpha1.NodeMemoryPolicySpec{
+						KSM: &memoryv1alpha1.KSMPolicy{
+							Enabled:         true,
+							PagesMin:        pointer.Int32(166),
+							PagesInit:       pointer.Int32(200),
+							SleepMsBaseline: pointer.Int32(1213),
+							FreePercent:     pointer.Int32(100),
+						},
+					},
+				})).To(Succeed())
+			})
+
+			It("should use the policy values instead of ksmConfiguration and the override annotations", func() {
+				clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKV(kv)
+				node := &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "mynode",
+						Labels: map[string]string{"test_label": "false"},
+						Annotations: map[string]string{
+							kubevirtv1.KSMPagesInitOverride:       "1011",
+							kubevirtv1.KSMSleepMsBaselineOverride: "50",
+						},
+					},
+				}
+				fakeClient := fake.NewSimpleClientset(node)
+				createCustomMemInfo(false)
+				heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), clusterConfig, "mynode", policyStore, newStore())
+
+				heartbeat.do()
+				expectKSMState(ksmState{
+					running: true,
+					sleep:   1213 * (16 * 1024 * 1024) / (memTotal - memAvailableNoPressure),
+					pages:   200,
+				})
+
+				node, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), "mynode", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(node.Labels).To(HaveKeyWithValue(kubevirtv1.KSMEnabledLabel, "true"))
+				Expect(node.Annotations).To(HaveKeyWithValue(kubevirtv1.KSMHandlerManagedAnnotation, "true"))
+			})
+
+			It("should disable KSM if the policy disables it", func() {
+				policyStore.List()[0].(*memoryv1alpha1.NodeMemoryPolicy).Spec.KSM.Enabled = false
+				clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKV(kv)
+				node := &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "mynode",
+						Labels:      map[string]string{"test_label": "true"},
+						Annotations: map[string]string{kubevirtv1.KSMHandlerManagedAnnotation: "true"},
+					},
+				}
+				fakeClient := fake.NewSimpleClientset(node)
+				err := os.WriteFile(filepath.Join(fakeSysKSMDir, "run"), []byte("1\n"), 0644)
+				Expect(err).ToNot(HaveOccurred())
+				createCustomMemInfo(true)
+				heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), clusterConfig, "mynode", policyStore, newStore())
+
+				heartbeat.do()
+				expectKSMState(ksmState{running: false})
+			})
+		})
 	})
 })
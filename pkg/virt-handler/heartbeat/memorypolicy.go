@@ -0,0 +1,134 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package heartbeat
+
+import (
+	"sort"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	memoryv1alpha1 "kubevirt.io/api/memory/v1alpha1"
+	"kubevirt.io/client-go/log"
+)
+
+const conflictingPoliciesWarningFmt = "NodeMemoryPolicies %v match node %s, applying %s"
+
+// nodeMemoryPolicy returns the NodeMemoryPolicy which applies to the node.
+// If several policies match, the one with the alphabetically first name wins.
+func nodeMemoryPolicy(store cache.Store, node *v1.Node) *memoryv1alpha1.NodeMemoryPolicy {
+	var matching []*memoryv1alpha1.NodeMemoryPolicy
+	for _, obj := range store.List() {
+		policy := obj.(*memoryv1alpha1.NodeMemoryPolicy)
+		selector := labels.Everything()
+		if policy.Spec.NodeSelector != nil {
+			var err error
+			selector, err = metav1.LabelSelectorAsSelector(policy.Spec.NodeSelector)
+			if err != nil {
+				log.DefaultLogger().Reason(err).Errorf("Invalid node selector in NodeMemoryPolicy %s", policy.Name)
+				continue
+			}
+		}
+		if selector.Matches(labels.Set(node.Labels)) {
+			matching = append(matching, policy)
+		}
+	}
+
+	if len(matching) == 0 {
+		return nil
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Name < matching[j].Name
+	})
+	if len(matching) > 1 {
+		var names []string
+		for _, policy := range matching {
+			names = append(names, policy.Name)
+		}
+		log.DefaultLogger().Warningf(conflictingPoliciesWarningFmt, names, node.Name, matching[0].Name)
+	}
+	return matching[0]
+}
+
+func guestMemory(vmi *kubevirtv1.VirtualMachineInstance) int64 {
+	if vmi.Status.Memory != nil && vmi.Status.Memory.GuestCurrent != nil {
+		return vmi.Status.Memory.GuestCurrent.Value()
+	}
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		return vmi.Spec.Domain.Memory.Guest.Value()
+	}
+	if memory, ok := vmi.Spec.Domain.Resources.Requests[v1.ResourceMemory]; ok {
+		return memory.Value()
+	}
+	if memory, ok := vmi.Spec.Domain.Resources.Limits[v1.ResourceMemory]; ok {
+		return memory.Value()
+	}
+	return 0
+}
+
+// memoryOvercommitPercent returns the guest memory of the VMIs running on the node in percent
+// of the allocatable memory of the node
+func memoryOvercommitPercent(node *v1.Node, vmiStore cache.Store) int64 {
+	allocatable := node.Status.Allocatable.Memory()
+	if allocatable.IsZero() {
+		return 0
+	}
+
+	var guest int64
+	for _, obj := range vmiStore.List() {
+		vmi := obj.(*kubevirtv1.VirtualMachineInstance)
+		if vmi.IsFinal() || vmi.Status.NodeName != node.Name {
+			continue
+		}
+		guest += guestMemory(vmi)
+	}
+	return guest * 100 / allocatable.Value()
+}
+
+func overcommitLimitReached(policy *memoryv1alpha1.NodeMemoryPolicy, overcommitPercent int64) bool {
+	if policy == nil || policy.Spec.MaxMemoryOvercommitPercent == nil {
+		return false
+	}
+	return overcommitPercent >= int64(*policy.Spec.MaxMemoryOvercommitPercent)
+}
+
+// memoryPolicyAnnotations reports the effective memory policy state of the node.
+// Annotations which do not apply are set to nil, to be removed from the node.
+func memoryPolicyAnnotations(policy *memoryv1alpha1.NodeMemoryPolicy, overcommitPercent int64) map[string]*string {
+	annotations := map[string]*string{
+		memoryv1alpha1.NodeMemoryPolicyAnnotation:                 nil,
+		memoryv1alpha1.NodeMemoryOvercommitLimitReachedAnnotation: nil,
+	}
+	if policy == nil {
+		return annotations
+	}
+
+	annotations[memoryv1alpha1.NodeMemoryPolicyAnnotation] = pointer.String(policy.Name)
+	if policy.Spec.MaxMemoryOvercommitPercent != nil {
+		annotations[memoryv1alpha1.NodeMemoryOvercommitLimitReachedAnnotation] = pointer.String(strconv.FormatBool(overcommitLimitReached(policy, overcommitPercent)))
+	}
+	return annotations
+}
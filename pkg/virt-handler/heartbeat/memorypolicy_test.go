@@ -0,0 +1,205 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package heartbeat
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	memoryv1alpha1 "kubevirt.io/api/memory/v1alpha1"
+	"kubevirt.io/client-go/api"
+)
+
+var _ = Describe("NodeMemoryPolicy", func() {
+
+	newPolicy := func(name string, selector *metav1.LabelSelector) *memoryv1alpha1.NodeMemoryPolicy {
+		return &memoryv1alpha1.NodeMemoryPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: memoryv1alpha1.NodeMemoryPolicySpec{
+				NodeSelector: selector,
+			},
+		}
+	}
+
+	newVMI := func(name, nodeName, memory string) *kubevirtv1.VirtualMachineInstance {
+		vmi := api.NewMinimalVMI(name)
+		vmi.Status.NodeName = nodeName
+		vmi.Status.Phase = kubevirtv1.Running
+		guest := resource.MustParse(memory)
+		vmi.Spec.Domain.Memory = &kubevirtv1.Memory{Guest: &guest}
+		return vmi
+	}
+
+	newNode := func(allocatable string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "mynode",
+				Labels: map[string]string{"pool": "dense"},
+			},
+			Status: v1.NodeStatus{
+				Allocatable: v1.ResourceList{
+					v1.ResourceMemory: resource.MustParse(allocatable),
+				},
+			},
+		}
+	}
+
+	storeWith := func(objs ...interface{}) cache.Store {
+		store := newStore()
+		for _, obj := range objs {
+			Expect(store.Add(obj)).To(Succeed())
+		}
+		return store
+	}
+
+	DescribeTable("should select the policy", func(policies []interface{}, expected string) {
+		policy := nodeMemoryPolicy(storeWith(policies...), newNode("8Gi"))
+		if expected == "" {
+			Expect(policy).To(BeNil())
+		} else {
+			Expect(policy).ToNot(BeNil())
+			Expect(policy.Name).To(Equal(expected))
+		}
+	},
+		Entry("without any policy", nil, ""),
+		Entry("matching the node labels",
+			[]interface{}{
+				newPolicy("sparse", &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "sparse"}}),
+				newPolicy("dense", &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "dense"}}),
+			}, "dense"),
+		Entry("without a node selector", []interface{}{newPolicy("all", nil)}, "all"),
+		Entry("with the alphabetically first name if several match",
+			[]interface{}{
+				newPolicy("b-dense", &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "dense"}}),
+				newPolicy("a-all", nil),
+			}, "a-all"),
+		Entry("not matching the node labels",
+			[]interface{}{newPolicy("sparse", &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "sparse"}})}, ""),
+	)
+
+	It("should calculate the memory overcommit of the VMIs running on the node", func() {
+		finished := newVMI("finished", "mynode", "4Gi")
+		finished.Status.Phase = kubevirtv1.Succeeded
+		vmiStore := storeWith(
+			newVMI("first", "mynode", "4Gi"),
+			newVMI("second", "mynode", "8Gi"),
+			newVMI("other", "othernode", "8Gi"),
+			finished,
+		)
+		Expect(memoryOvercommitPercent(newNode("8Gi"), vmiStore)).To(BeEquivalentTo(150))
+		Expect(memoryOvercommitPercent(newNode("0"), vmiStore)).To(BeEquivalentTo(0))
+	})
+
+	Context("on heartbeat", func() {
+		var fakeClient *fake.Clientset
+
+		getNode := func() *v1.Node {
+			node, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), "mynode", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			return node
+		}
+
+		BeforeEach(func() {
+			fakeClient = fake.NewSimpleClientset(newNode("8Gi"))
+		})
+
+		It("should not annotate the node without a policy", func() {
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(), "mynode", newStore(), newStore())
+			heartbeat.do()
+
+			node := getNode()
+			Expect(node.Labels).To(HaveKeyWithValue(kubevirtv1.NodeSchedulable, "true"))
+			Expect(node.Annotations).ToNot(HaveKey(memoryv1alpha1.NodeMemoryPolicyAnnotation))
+			Expect(node.Annotations).ToNot(HaveKey(memoryv1alpha1.NodeMemoryOvercommitLimitReachedAnnotation))
+			Expect(node.Status.Conditions).To(BeEmpty())
+			Expect(heartbeat.MemoryPolicy()).To(BeNil())
+		})
+
+		It("should report the applied policy and keep the node schedulable below the overcommit limit", func() {
+			policy := newPolicy("dense", nil)
+			policy.Spec.MaxMemoryOvercommitPercent = pointer.Int32(200)
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(), "mynode",
+				storeWith(policy), storeWith(newVMI("first", "mynode", "12Gi")))
+			heartbeat.do()
+
+			node := getNode()
+			Expect(node.Labels).To(HaveKeyWithValue(kubevirtv1.NodeSchedulable, "true"))
+			Expect(node.Annotations).To(HaveKeyWithValue(memoryv1alpha1.NodeMemoryPolicyAnnotation, "dense"))
+			Expect(node.Annotations).To(HaveKeyWithValue(memoryv1alpha1.NodeMemoryOvercommitLimitReachedAnnotation, "false"))
+			Expect(heartbeat.MemoryPolicy()).To(Equal(policy))
+		})
+
+		It("should mark the node unschedulable once the overcommit limit is reached", func() {
+			policy := newPolicy("dense", nil)
+			policy.Spec.MaxMemoryOvercommitPercent = pointer.Int32(150)
+			vmiStore := storeWith(newVMI("first", "mynode", "12Gi"))
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(), "mynode", storeWith(policy), vmiStore)
+			heartbeat.do()
+
+			node := getNode()
+			Expect(node.Labels).To(HaveKeyWithValue(kubevirtv1.NodeSchedulable, "false"))
+			Expect(node.Annotations).To(HaveKeyWithValue(memoryv1alpha1.NodeMemoryOvercommitLimitReachedAnnotation, "true"))
+
+			By("removing the VMI from the node")
+			Expect(vmiStore.Delete(newVMI("first", "mynode", "12Gi"))).To(Succeed())
+			heartbeat.do()
+
+			node = getNode()
+			Expect(node.Labels).To(HaveKeyWithValue(kubevirtv1.NodeSchedulable, "true"))
+			Expect(node.Annotations).To(HaveKeyWithValue(memoryv1alpha1.NodeMemoryOvercommitLimitReachedAnnotation, "false"))
+		})
+
+		It("should not report the overcommit limit of a policy without one", func() {
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(), "mynode",
+				storeWith(newPolicy("dense", nil)), newStore())
+			heartbeat.do()
+
+			node := getNode()
+			Expect(node.Annotations).To(HaveKeyWithValue(memoryv1alpha1.NodeMemoryPolicyAnnotation, "dense"))
+			Expect(node.Annotations).ToNot(HaveKey(memoryv1alpha1.NodeMemoryOvercommitLimitReachedAnnotation))
+		})
+
+		It("should remove the annotations once the policy is removed", func() {
+			policy := newPolicy("dense", nil)
+			policy.Spec.MaxMemoryOvercommitPercent = pointer.Int32(200)
+			policyStore := storeWith(policy)
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(), "mynode", policyStore, newStore())
+			heartbeat.do()
+			Expect(getNode().Annotations).To(HaveKeyWithValue(memoryv1alpha1.NodeMemoryPolicyAnnotation, "dense"))
+
+			Expect(policyStore.Delete(policy)).To(Succeed())
+			heartbeat.do()
+
+			node := getNode()
+			Expect(node.Annotations).ToNot(HaveKey(memoryv1alpha1.NodeMemoryPolicyAnnotation))
+			Expect(node.Annotations).ToNot(HaveKey(memoryv1alpha1.NodeMemoryOvercommitLimitReachedAnnotation))
+			Expect(heartbeat.MemoryPolicy()).To(BeNil())
+		})
+	})
+})
@@ -128,6 +128,7 @@ func (n *NodeLabeller) loadDomCapabilities() error {
 
 	n.hostCapabilities.items = usableModels
 	n.SEV = hostDomCapabilities.SEV
+	n.LaunchSecurity = hostDomCapabilities.LaunchSecurity
 
 	return nil
 }
@@ -209,6 +210,24 @@ func (n *NodeLabeller) getDomCapabilities() (HostDomCapabilities, error) {
 		hostDomCapabilities.SEV.SupportedES = "no"
 	}
 
+	hostDomCapabilities.LaunchSecurity.SupportedSNP = "no"
+	hostDomCapabilities.LaunchSecurity.SupportedTDX = "no"
+	if hostDomCapabilities.LaunchSecurity.Supported == "yes" {
+		for _, enum := range hostDomCapabilities.LaunchSecurity.Enums {
+			if enum.Name != "sectype" {
+				continue
+			}
+			for _, secType := range enum.Values {
+				switch secType {
+				case "sev-snp":
+					hostDomCapabilities.LaunchSecurity.SupportedSNP = "yes"
+				case "tdx":
+					hostDomCapabilities.LaunchSecurity.SupportedTDX = "yes"
+				}
+			}
+		}
+	}
+
 	return hostDomCapabilities, err
 }
 
@@ -211,6 +211,22 @@ var _ = Describe("Node-labeller config", func() {
 		)
 	})
 
+	Context("return correct launch security capabilities", func() {
+		DescribeTable("for SEV-SNP and TDX",
+			func(domCapabilitiesFileName, supportedSNP, supportedTDX string) {
+				nlController.domCapabilitiesFileName = domCapabilitiesFileName
+				err := nlController.loadDomCapabilities()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(nlController.LaunchSecurity.SupportedSNP).To(Equal(supportedSNP))
+				Expect(nlController.LaunchSecurity.SupportedTDX).To(Equal(supportedTDX))
+			},
+			Entry("when SEV-SNP is supported", "domcapabilities_snp.xml", "yes", "no"),
+			Entry("when TDX is supported", "domcapabilities_tdx.xml", "no", "yes"),
+			Entry("when launch security is not reported", "domcapabilities_sev.xml", "no", "no"),
+		)
+	})
+
 	It("Make sure proper labels are removed on removeLabellerLabels()", func() {
 		node := &k8sv1.Node{
 			ObjectMeta: metav1.ObjectMeta{
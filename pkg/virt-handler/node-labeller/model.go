@@ -38,8 +38,9 @@ type cpuInfo struct {
 
 // HostDomCapabilities represents structure for parsing output of virsh capabilities
 type HostDomCapabilities struct {
-	CPU CPU              `xml:"cpu"`
-	SEV SEVConfiguration `xml:"features>sev"`
+	CPU            CPU                         `xml:"cpu"`
+	SEV            SEVConfiguration            `xml:"features>sev"`
+	LaunchSecurity LaunchSecurityConfiguration `xml:"features>launchSecurity"`
 }
 
 // CPU represents slice of cpu modes
@@ -98,3 +99,15 @@ type SEVConfiguration struct {
 	MaxESGuests     uint   `xml:"maxESGuests"`
 	SupportedES     string `xml:"-"`
 }
+
+type LaunchSecurityConfiguration struct {
+	Supported    string `xml:"supported,attr"`
+	Enums        []Enum `xml:"enum"`
+	SupportedSNP string `xml:"-"`
+	SupportedTDX string `xml:"-"`
+}
+
+type Enum struct {
+	Name   string   `xml:"name,attr"`
+	Values []string `xml:"value"`
+}
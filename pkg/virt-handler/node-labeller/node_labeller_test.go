@@ -147,6 +147,20 @@ var _ = Describe("Node-labeller ", func() {
 		Expect(res).To(BeTrue())
 	})
 
+	It("should add SEV-SNP label", func() {
+		nlController.LaunchSecurity.SupportedSNP = "yes"
+		testutils.ExpectNodePatch(kubeClient, kubevirtv1.SEVSNPLabel)
+		res := nlController.execute()
+		Expect(res).To(BeTrue())
+	})
+
+	It("should add TDX label", func() {
+		nlController.LaunchSecurity.SupportedTDX = "yes"
+		testutils.ExpectNodePatch(kubeClient, kubevirtv1.TDXLabel)
+		res := nlController.execute()
+		Expect(res).To(BeTrue())
+	})
+
 	It("should add usable cpu model labels for the host cpu model", func() {
 		testutils.ExpectNodePatch(kubeClient,
 			kubevirtv1.HostModelCPULabel+"Skylake-Client-IBRS",
@@ -2,6 +2,7 @@ package virthandler
 
 import (
 	v1 "kubevirt.io/api/core/v1"
+	memoryv1alpha1 "kubevirt.io/api/memory/v1alpha1"
 
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 
@@ -47,6 +48,25 @@ func virtualMachineOptions(
 	return options
 }
 
+// applyNodeMemoryPolicy overrides the cluster wide balloon settings with the ones of the
+// NodeMemoryPolicy applied on the node
+func applyNodeMemoryPolicy(options *cmdv1.VirtualMachineOptions, policy *memoryv1alpha1.NodeMemoryPolicy) {
+	if policy == nil {
+		return
+	}
+	if balloon := policy.Spec.Balloon; balloon != nil {
+		if balloon.StatsPeriod != nil {
+			options.MemBalloonStatsPeriod = *balloon.StatsPeriod
+		}
+		if balloon.TargetGuestMemoryPercent != nil {
+			options.BalloonTargetPercent = uint32(*balloon.TargetGuestMemoryPercent)
+		}
+	}
+	if policy.Spec.FreePageReporting != nil && options.ClusterConfig != nil {
+		options.ClusterConfig.FreePageReportingDisabled = !*policy.Spec.FreePageReporting
+	}
+}
+
 func capabilitiesToTopology(capabilities *api.Capabilities) *cmdv1.Topology {
 	topology := &cmdv1.Topology{}
 	if capabilities == nil {
@@ -0,0 +1,65 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virthandler
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	memoryv1alpha1 "kubevirt.io/api/memory/v1alpha1"
+
+	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+)
+
+var _ = Describe("VirtualMachineOptions", func() {
+	DescribeTable("should apply the NodeMemoryPolicy", func(policy *memoryv1alpha1.NodeMemoryPolicy, expectedPeriod uint32, expectedFreePageReportingDisabled bool) {
+		options := &cmdv1.VirtualMachineOptions{
+			MemBalloonStatsPeriod: 10,
+			ClusterConfig:         &cmdv1.ClusterConfig{FreePageReportingDisabled: false},
+		}
+		applyNodeMemoryPolicy(options, policy)
+		Expect(options.MemBalloonStatsPeriod).To(Equal(expectedPeriod))
+		Expect(options.ClusterConfig.FreePageReportingDisabled).To(Equal(expectedFreePageReportingDisabled))
+	},
+		Entry("without a policy", nil, uint32(10), false),
+		Entry("without memory balloon settings", &memoryv1alpha1.NodeMemoryPolicy{}, uint32(10), false),
+		Entry("with a balloon statistics period", &memoryv1alpha1.NodeMemoryPolicy{
+			Spec: memoryv1alpha1.NodeMemoryPolicySpec{
+				Balloon: &memoryv1alpha1.BalloonPolicy{StatsPeriod: pointer.Uint32(0)},
+			},
+		}, uint32(0), false),
+		Entry("with free page reporting disabled", &memoryv1alpha1.NodeMemoryPolicy{
+			Spec: memoryv1alpha1.NodeMemoryPolicySpec{
+				FreePageReporting: pointer.Bool(false),
+			},
+		}, uint32(10), true),
+	)
+
+	It("should apply the balloon target of the NodeMemoryPolicy", func() {
+		options := &cmdv1.VirtualMachineOptions{}
+		applyNodeMemoryPolicy(options, &memoryv1alpha1.NodeMemoryPolicy{
+			Spec: memoryv1alpha1.NodeMemoryPolicySpec{
+				Balloon: &memoryv1alpha1.BalloonPolicy{TargetGuestMemoryPercent: pointer.Int32(80)},
+			},
+		})
+		Expect(options.BalloonTargetPercent).To(Equal(uint32(80)))
+	})
+})
@@ -260,6 +260,25 @@ func (lh *LifecycleHandler) SEVFetchCertChainHandler(request *restful.Request, r
 	response.WriteEntity(sevPlatformInfo)
 }
 
+func (lh *LifecycleHandler) SNPFetchCertChainHandler(request *restful.Request, response *restful.Response) {
+	vmi, client, err := lh.getVMILauncherClient(request, response)
+	if err != nil {
+		return
+	}
+
+	log.Log.Object(vmi).Infof("Retrieving SEV-SNP platform info")
+
+	// The chip ID and certificate chain of the host are reported by the same libvirt call as for SEV
+	sevPlatformInfo, err := client.GetSEVInfo()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get SEV-SNP platform info")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(sevPlatformInfo)
+}
+
 func (lh *LifecycleHandler) SEVQueryLaunchMeasurementHandler(request *restful.Request, response *restful.Response) {
 	vmi, client, err := lh.getVMILauncherClient(request, response)
 	if err != nil {
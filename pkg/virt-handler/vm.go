@@ -193,6 +193,7 @@ func NewController(
 	vmiTargetInformer cache.SharedIndexInformer,
 	domainInformer cache.SharedInformer,
 	gracefulShutdownInformer cache.SharedIndexInformer,
+	nodeMemoryPolicyInformer cache.SharedIndexInformer,
 	watchdogTimeoutSeconds int,
 	maxDevices int,
 	clusterConfig *virtconfig.ClusterConfig,
@@ -269,7 +270,7 @@ func NewController(
 
 	c.launcherClients = virtcache.LauncherClientInfoByVMI{}
 
-	c.netConf = netsetup.NewNetConf()
+	c.netConf = netsetup.NewNetConf(clusterConfig.GetQoSTrafficMark)
 	c.netStat = netsetup.NewNetStat()
 
 	c.downwardMetricsManager = downwardMetricsManager
@@ -295,7 +296,7 @@ func NewController(
 		device_manager.PermanentHostDevicePlugins(maxDevices, permissions),
 		clusterConfig,
 		clientset.CoreV1())
-	c.heartBeat = heartbeat.NewHeartBeat(clientset.CoreV1(), c.deviceManagerController, clusterConfig, host)
+	c.heartBeat = heartbeat.NewHeartBeat(clientset.CoreV1(), c.deviceManagerController, clusterConfig, host, nodeMemoryPolicyInformer.GetStore(), vmiSourceInformer.GetStore())
 
 	return c, nil
 }
@@ -924,6 +925,10 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 				volumeStatus, tmpNeedsRefresh = d.updateMemoryDumpInfo(vmi, volumeStatus, domain)
 				needsRefresh = needsRefresh || tmpNeedsRefresh
 			}
+			if volumeStatus.BackupVolume != nil {
+				volumeStatus, tmpNeedsRefresh = d.updateBackupInfo(vmi, volumeStatus, domain)
+				needsRefresh = needsRefresh || tmpNeedsRefresh
+			}
 			newStatuses = append(newStatuses, volumeStatus)
 			newStatusMap[volumeStatus.Name] = volumeStatus
 		}
@@ -1013,12 +1018,33 @@ func (d *VirtualMachineController) updateLiveMigrationConditions(vmi *v1.Virtual
 		}
 	}
 
+	d.updateStorageLiveMigrationCondition(vmi, liveMigrationCondition, condManager)
+
 	evictable := migrations.VMIMigratableOnEviction(d.clusterConfig, vmi)
 	if evictable && liveMigrationCondition.Status == k8sv1.ConditionFalse {
 		d.recorder.Eventf(vmi, k8sv1.EventTypeWarning, v1.Migrated.String(), "EvictionStrategy is set but vmi is not migratable; %s", liveMigrationCondition.Message)
 	}
 }
 
+// updateStorageLiveMigrationCondition reports whether a VMI which can not be live migrated because of its volumes
+// can be migrated once these volumes are copied to new claims by a volume migration
+func (d *VirtualMachineController) updateStorageLiveMigrationCondition(vmi *v1.VirtualMachineInstance, liveMigrationCondition *v1.VirtualMachineInstanceCondition, condManager *controller.VirtualMachineInstanceConditionManager) {
+	if liveMigrationCondition.Reason != v1.VirtualMachineInstanceReasonDisksNotMigratable {
+		if condManager.HasCondition(vmi, v1.VirtualMachineInstanceIsStorageLiveMigratable) {
+			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceIsStorageLiveMigratable)
+		}
+		return
+	}
+
+	storageLiveMigrationCondition := d.calculateDomainMigrationCondition(vmi)
+	storageLiveMigrationCondition.Type = v1.VirtualMachineInstanceIsStorageLiveMigratable
+	cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceIsStorageLiveMigratable)
+	if cond == nil || !equality.Semantic.DeepEqual(cond, storageLiveMigrationCondition) {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceIsStorageLiveMigratable)
+		vmi.Status.Conditions = append(vmi.Status.Conditions, *storageLiveMigrationCondition)
+	}
+}
+
 func (d *VirtualMachineController) updateGuestAgentConditions(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) error {
 
 	// Update the condition when GA is connected
@@ -1152,6 +1178,53 @@ func (d *VirtualMachineController) updateMemoryDumpInfo(vmi *v1.VirtualMachineIn
 	return volumeStatus, needsRefresh
 }
 
+func (d *VirtualMachineController) updateBackupInfo(vmi *v1.VirtualMachineInstance, volumeStatus v1.VolumeStatus, domain *api.Domain) (v1.VolumeStatus, bool) {
+	needsRefresh := false
+	switch volumeStatus.Phase {
+	case v1.HotplugVolumeMounted:
+		needsRefresh = true
+		log.Log.Object(vmi).V(3).Infof("Backup volume %s attached, marking it in progress", volumeStatus.Name)
+		volumeStatus.Phase = v1.BackupVolumeInProgress
+		volumeStatus.Message = fmt.Sprintf("Backup Volume %s is attached, starting backup %s", volumeStatus.Name, volumeStatus.BackupVolume.BackupName)
+		volumeStatus.Reason = VolumeMountedToPodReason
+	case v1.BackupVolumeInProgress:
+		backupMetadata := domain.Spec.Metadata.KubeVirt.Backup
+		if backupMetadata == nil || backupMetadata.Name != volumeStatus.BackupVolume.BackupName {
+			// backup wasnt triggered yet
+			return volumeStatus, needsRefresh
+		}
+		needsRefresh = true
+		if backupMetadata.StartTimestamp != nil {
+			volumeStatus.BackupVolume.StartTimestamp = backupMetadata.StartTimestamp
+		}
+		if backupMetadata.EndTimestamp != nil && backupMetadata.Failed {
+			log.Log.Object(vmi).Errorf("Backup to pvc %s failed: %v", volumeStatus.Name, backupMetadata.FailureReason)
+			volumeStatus.Message = fmt.Sprintf("Backup to pvc %s failed: %v", volumeStatus.Name, backupMetadata.FailureReason)
+			volumeStatus.Phase = v1.BackupVolumeFailed
+			volumeStatus.BackupVolume.EndTimestamp = backupMetadata.EndTimestamp
+		} else if backupMetadata.Completed {
+			log.Log.Object(vmi).V(3).Infof("Marking backup to volume %s has completed", volumeStatus.Name)
+			volumeStatus.Phase = v1.BackupVolumeCompleted
+			volumeStatus.Message = fmt.Sprintf("Backup to Volume %s has completed successfully", volumeStatus.Name)
+			volumeStatus.Reason = VolumeReadyReason
+			volumeStatus.BackupVolume.EndTimestamp = backupMetadata.EndTimestamp
+			volumeStatus.BackupVolume.IncrementalFrom = backupMetadata.IncrementalFrom
+			volumeStatus.BackupVolume.Disks = nil
+			if backupMetadata.Disks != nil {
+				for _, disk := range backupMetadata.Disks.Disks {
+					volumeStatus.BackupVolume.Disks = append(volumeStatus.BackupVolume.Disks, v1.BackupDiskInfo{
+						Name:           disk.Name,
+						TargetFileName: disk.FileName,
+						Incremental:    disk.Incremental,
+					})
+				}
+			}
+		}
+	}
+
+	return volumeStatus, needsRefresh
+}
+
 func (d *VirtualMachineController) updateFSFreezeStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
 
 	if domain == nil || domain.Status.FSFreezeStatus.Status == "" {
@@ -1328,6 +1401,10 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 		return err
 	}
 
+	if domain != nil && vmi.IsRunning() && controller.VMIHasInPlaceMemoryChange(vmi) {
+		d.resizeMemoryInPlace(vmi)
+	}
+
 	// Calculate the new VirtualMachineInstance state based on what libvirt reported
 	err = d.setVmPhaseForStatusReason(domain, vmi)
 	if err != nil {
@@ -1485,39 +1562,48 @@ func (d *VirtualMachineController) calculateLiveMigrationCondition(vmi *v1.Virtu
 		return newNonMigratableCondition(err.Error(), v1.VirtualMachineInstanceReasonDisksNotMigratable), isBlockMigration
 	}
 
-	err = d.checkNetworkInterfacesForMigration(vmi)
+	return d.calculateDomainMigrationCondition(vmi), isBlockMigration
+}
+
+// calculateDomainMigrationCondition checks everything but the volumes which can prevent the VMI from being live migrated
+func (d *VirtualMachineController) calculateDomainMigrationCondition(vmi *v1.VirtualMachineInstance) *v1.VirtualMachineInstanceCondition {
+	err := d.checkNetworkInterfacesForMigration(vmi)
 	if err != nil {
-		return newNonMigratableCondition(err.Error(), v1.VirtualMachineInstanceReasonInterfaceNotMigratable), isBlockMigration
+		return newNonMigratableCondition(err.Error(), v1.VirtualMachineInstanceReasonInterfaceNotMigratable)
 	}
 
 	if err := d.isHostModelMigratable(vmi); err != nil {
-		return newNonMigratableCondition(err.Error(), v1.VirtualMachineInstanceReasonCPUModeNotMigratable), isBlockMigration
+		return newNonMigratableCondition(err.Error(), v1.VirtualMachineInstanceReasonCPUModeNotMigratable)
 	}
 
 	if util.IsVMIVirtiofsEnabled(vmi) {
-		return newNonMigratableCondition("VMI uses virtiofs", v1.VirtualMachineInstanceReasonVirtIOFSNotMigratable), isBlockMigration
+		return newNonMigratableCondition("VMI uses virtiofs", v1.VirtualMachineInstanceReasonVirtIOFSNotMigratable)
 	}
 
 	if vmiContainsPCIHostDevice(vmi) {
-		return newNonMigratableCondition("VMI uses a PCI host devices", v1.VirtualMachineInstanceReasonHostDeviceNotMigratable), isBlockMigration
+		return newNonMigratableCondition("VMI uses a PCI host devices", v1.VirtualMachineInstanceReasonHostDeviceNotMigratable)
 	}
 
 	if util.IsSEVVMI(vmi) {
-		return newNonMigratableCondition("VMI uses SEV", v1.VirtualMachineInstanceReasonSEVNotMigratable), isBlockMigration
+		return newNonMigratableCondition("VMI uses SEV", v1.VirtualMachineInstanceReasonSEVNotMigratable)
+	}
+
+	if util.IsSEVSNPVMI(vmi) || util.IsTDXVMI(vmi) {
+		return newNonMigratableCondition("VMI uses SEV-SNP or TDX", v1.VirtualMachineInstanceReasonConfidentialComputingNotMigratable)
 	}
 
 	if reservation.HasVMIPersistentReservation(vmi) {
-		return newNonMigratableCondition("VMI uses SCSI persitent reservation", v1.VirtualMachineInstanceReasonPRNotMigratable), isBlockMigration
+		return newNonMigratableCondition("VMI uses SCSI persitent reservation", v1.VirtualMachineInstanceReasonPRNotMigratable)
 	}
 
 	if tscRequirement := topology.GetTscFrequencyRequirement(vmi); !topology.AreTSCFrequencyTopologyHintsDefined(vmi) && tscRequirement.Type == topology.RequiredForMigration {
-		return newNonMigratableCondition(tscRequirement.Reason, v1.VirtualMachineInstanceReasonNoTSCFrequencyMigratable), isBlockMigration
+		return newNonMigratableCondition(tscRequirement.Reason, v1.VirtualMachineInstanceReasonNoTSCFrequencyMigratable)
 	}
 
 	return &v1.VirtualMachineInstanceCondition{
 		Type:   v1.VirtualMachineInstanceIsMigratable,
 		Status: k8sv1.ConditionTrue,
-	}, isBlockMigration
+	}
 }
 
 func vmiContainsPCIHostDevice(vmi *v1.VirtualMachineInstance) bool {
@@ -2402,7 +2488,8 @@ func (d *VirtualMachineController) checkNetworkInterfacesForMigration(vmi *v1.Vi
 	}
 
 	_, allowPodBridgeNetworkLiveMigration := vmi.Annotations[v1.AllowPodBridgeNetworkLiveMigrationAnnotation]
-	if allowPodBridgeNetworkLiveMigration && netvmispec.IsPodNetworkWithBridgeBindingInterface(vmi.Spec.Networks, ifaces) {
+	_, preservePodNetworkAddresses := vmi.Annotations[v1.PreservePodNetworkAddressesAnnotation]
+	if (allowPodBridgeNetworkLiveMigration || preservePodNetworkAddresses) && netvmispec.IsPodNetworkWithBridgeBindingInterface(vmi.Spec.Networks, ifaces) {
 		return nil
 	}
 	if netvmispec.IsPodNetworkWithMasqueradeBindingInterface(vmi.Spec.Networks, ifaces) {
@@ -2499,7 +2586,7 @@ func (d *VirtualMachineController) handleTargetMigrationProxy(vmi *v1.VirtualMac
 	baseDir := fmt.Sprintf(filepath.Join(d.virtLauncherFSRunDirPattern, "kubevirt"), res.Pid())
 	migrationTargetSockets = append(migrationTargetSockets, socketFile)
 
-	isBlockMigration := vmi.Status.MigrationMethod == v1.BlockMigration
+	isBlockMigration := migrations.IsBlockMigration(vmi)
 	migrationPortsRange := migrationproxy.GetMigrationPortsList(isBlockMigration)
 	for _, port := range migrationPortsRange {
 		key := migrationproxy.ConstructProxyKey(string(vmi.UID), port)
@@ -2949,7 +3036,7 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 			return fmt.Errorf("preparing host-disks failed: %v", err)
 		}
 
-		if virtutil.IsSEVVMI(vmi) {
+		if virtutil.IsSEVVMI(vmi) || virtutil.IsSEVSNPVMI(vmi) {
 			sevDevice, err := safepath.JoinNoFollow(virtLauncherRootMount, filepath.Join("dev", "sev"))
 			if err != nil {
 				return err
@@ -3000,6 +3087,10 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 			return err
 		}
 
+		if err := d.startBackup(vmi); err != nil {
+			return err
+		}
+
 		isolationRes, err := d.podIsolationDetector.Detect(vmi)
 		if err != nil {
 			return fmt.Errorf(failedDetectIsolationFmt, err)
@@ -3034,6 +3125,7 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 	period := d.clusterConfig.GetMemBalloonStatsPeriod()
 
 	options := virtualMachineOptions(smbios, period, preallocatedVolumes, d.capabilities, disksInfo, d.clusterConfig)
+	applyNodeMemoryPolicy(options, d.heartBeat.MemoryPolicy())
 
 	err = client.SyncVirtualMachine(vmi, options)
 	if err != nil {
@@ -3150,6 +3242,33 @@ func (d *VirtualMachineController) getMemoryDump(vmi *v1.VirtualMachineInstance)
 	return nil
 }
 
+func (d *VirtualMachineController) startBackup(vmi *v1.VirtualMachineInstance) error {
+	const errMsgPrefix = "failed to start backup"
+
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.BackupVolume == nil || volumeStatus.Phase != v1.BackupVolumeInProgress {
+			continue
+		}
+		client, err := d.getVerifiedLauncherClient(vmi)
+		if err != nil {
+			return fmt.Errorf("%s: %v", errMsgPrefix, err)
+		}
+
+		log.Log.V(3).Object(vmi).Info("sending backup command")
+		err = client.BackupVirtualMachine(vmi, &cmdclient.BackupOptions{
+			Name:            volumeStatus.BackupVolume.BackupName,
+			IncrementalFrom: volumeStatus.BackupVolume.IncrementalFrom,
+			TargetDir:       hotplugdisk.GetVolumeMountDir(volumeStatus.Name),
+			TimeoutSeconds:  d.clusterConfig.GetVMBackupTimeoutSeconds(),
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %v", errMsgPrefix, err)
+		}
+	}
+
+	return nil
+}
+
 func (d *VirtualMachineController) processVmUpdate(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
 
 	isUnresponsive, isInitialized, err := d.isLauncherClientUnresponsive(vmi)
@@ -3523,6 +3642,21 @@ func (d *VirtualMachineController) hotplugMemory(vmi *v1.VirtualMachineInstance,
 	return nil
 }
 
+// resizeMemoryInPlace updates the virtio-mem device of a running domain whose launcher pod
+// already requests enough memory for the new guest memory size.
+func (d *VirtualMachineController) resizeMemoryInPlace(vmi *v1.VirtualMachineInstance) {
+	client, err := d.getVerifiedLauncherClient(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to resize guest memory in place")
+		return
+	}
+
+	if err := d.hotplugMemory(vmi, client); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to resize guest memory in place")
+		d.recorder.Event(vmi, k8sv1.EventTypeWarning, err.Error(), "failed to update guest memory")
+	}
+}
+
 func parseLibvirtQuantity(value int64, unit string) *resource.Quantity {
 	switch unit {
 	case "b", "bytes":
@@ -3555,6 +3689,17 @@ func (d *VirtualMachineController) updateMemoryInfo(vmi *v1.VirtualMachineInstan
 		vmi.Status.Memory = &v1.MemoryStatus{}
 	}
 	currentGuest := parseLibvirtQuantity(int64(domain.Spec.CurrentMemory.Value), domain.Spec.CurrentMemory.Unit)
+
+	// With virtio-mem the guest plugs and unplugs memory blocks over time,
+	// the device reports how much of the requested memory is plugged so far.
+	if memoryDevice := domain.Spec.Devices.Memory; memoryDevice != nil && memoryDevice.Target != nil &&
+		memoryDevice.Target.Current.Unit != "" && vmi.Status.Memory.GuestAtBoot != nil {
+		if pluggedMemory := parseLibvirtQuantity(int64(memoryDevice.Target.Current.Value), memoryDevice.Target.Current.Unit); pluggedMemory != nil {
+			currentGuest = resource.NewQuantity(vmi.Status.Memory.GuestAtBoot.Value(), resource.BinarySI)
+			currentGuest.Add(*pluggedMemory)
+		}
+	}
+
 	vmi.Status.Memory.GuestCurrent = currentGuest
 	return nil
 }
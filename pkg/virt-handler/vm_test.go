@@ -71,6 +71,7 @@ import (
 	notifyclient "kubevirt.io/kubevirt/pkg/virt-launcher/notify-client"
 
 	v1 "kubevirt.io/api/core/v1"
+	memoryv1alpha1 "kubevirt.io/api/memory/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/precond"
 
@@ -98,6 +99,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 	var domainSource *framework.FakeControllerSource
 	var domainInformer cache.SharedIndexInformer
 	var gracefulShutdownInformer cache.SharedIndexInformer
+	var nodeMemoryPolicyInformer cache.SharedIndexInformer
 	var mockQueue *testutils.MockWorkQueue
 	var mockWatchdog *MockWatchdog
 	var mockGracefulShutdown *MockGracefulShutdown
@@ -174,6 +176,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 		vmiTargetInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 		domainInformer, domainSource = testutils.NewFakeInformerFor(&api.Domain{})
 		gracefulShutdownInformer, _ = testutils.NewFakeInformerFor(&api.Domain{})
+		nodeMemoryPolicyInformer, _ = testutils.NewFakeInformerFor(&memoryv1alpha1.NodeMemoryPolicy{})
 		recorder = record.NewFakeRecorder(100)
 		recorder.IncludeObject = true
 
@@ -220,6 +223,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			vmiTargetInformer,
 			domainInformer,
 			gracefulShutdownInformer,
+			nodeMemoryPolicyInformer,
 			1,
 			10,
 			config,
@@ -2685,10 +2689,10 @@ var _ = Describe("VirtualMachineInstance", func() {
 			testutils.ExpectEvent(recorder, fmt.Sprintf("cannot migrate VMI which does not use masquerade to connect to the pod network or bridge with %s VM annotation", v1.AllowPodBridgeNetworkLiveMigrationAnnotation))
 		})
 		Context("with AllowLiveMigrationBridgePodNetwork annotation", func() {
-			It("should allow to live-migrate if the VMI use bridge to connect to the pod network", func() {
+			DescribeTable("should allow to live-migrate if the VMI use bridge to connect to the pod network", func(annotation string) {
 				vmi := api2.NewMinimalVMI("testvmi")
 
-				vmi.Annotations = map[string]string{v1.AllowPodBridgeNetworkLiveMigrationAnnotation: ""}
+				vmi.Annotations = map[string]string{annotation: ""}
 
 				strategy := v1.EvictionStrategyLiveMigrate
 				vmi.Spec.EvictionStrategy = &strategy
@@ -2703,7 +2707,10 @@ var _ = Describe("VirtualMachineInstance", func() {
 					Status: k8sv1.ConditionTrue,
 				}))
 				Expect(vmi.Status.MigrationMethod).To(Equal(v1.LiveMigration))
-			})
+			},
+				Entry("allowing the migration", v1.AllowPodBridgeNetworkLiveMigrationAnnotation),
+				Entry("preserving the pod network addresses", v1.PreservePodNetworkAddressesAnnotation),
+			)
 		})
 
 		Context("check that migration is not supported when using Host Devices", func() {
@@ -2769,6 +2776,48 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonSEVNotMigratable))
 		})
 
+		DescribeTable("should not be allowed to live-migrate if the VMI uses", func(launchSecurity *v1.LaunchSecurity) {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.LaunchSecurity = launchSecurity
+
+			condition, isBlockMigration := controller.calculateLiveMigrationCondition(vmi)
+			Expect(isBlockMigration).To(BeFalse())
+			Expect(condition.Type).To(Equal(v1.VirtualMachineInstanceIsMigratable))
+			Expect(condition.Status).To(Equal(k8sv1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonConfidentialComputingNotMigratable))
+		},
+			Entry("SEV-SNP", &v1.LaunchSecurity{SNP: &v1.SEVSNP{}}),
+			Entry("TDX", &v1.LaunchSecurity{TDX: &v1.TDX{}}),
+		)
+
+		DescribeTable("should report the storage live migration condition of a VMI with non-shared volumes", func(launchSecurity *v1.LaunchSecurity, expectedStatus k8sv1.ConditionStatus, expectedReason string) {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.LaunchSecurity = launchSecurity
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "hostdisk",
+				VolumeSource: v1.VolumeSource{
+					HostDisk: &v1.HostDisk{Path: "/disk.img", Type: v1.HostDiskExistsOrCreate},
+				},
+			}}
+
+			conditionManager := virtcontroller.NewVirtualMachineInstanceConditionManager()
+			controller.updateLiveMigrationConditions(vmi, conditionManager)
+			migratable := conditionManager.GetCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
+			Expect(migratable).ToNot(BeNil())
+			Expect(migratable.Reason).To(Equal(v1.VirtualMachineInstanceReasonDisksNotMigratable))
+			storageMigratable := conditionManager.GetCondition(vmi, v1.VirtualMachineInstanceIsStorageLiveMigratable)
+			Expect(storageMigratable).ToNot(BeNil())
+			Expect(storageMigratable.Status).To(Equal(expectedStatus))
+			Expect(storageMigratable.Reason).To(Equal(expectedReason))
+
+			vmi.Spec.Volumes = nil
+			controller.updateLiveMigrationConditions(vmi, conditionManager)
+			Expect(conditionManager.HasCondition(vmi, v1.VirtualMachineInstanceIsStorageLiveMigratable)).To(BeFalse())
+		},
+			Entry("if nothing else prevents the migration", nil, k8sv1.ConditionTrue, ""),
+			Entry("if something else prevents the migration", &v1.LaunchSecurity{SEV: &v1.SEV{}}, k8sv1.ConditionFalse, v1.VirtualMachineInstanceReasonSEVNotMigratable),
+		)
+
 		It("should not be allowed to live-migrate if the VMI uses SCSI persistent reservation", func() {
 			vmi := api2.NewMinimalVMI("testvmi")
 
@@ -3065,6 +3114,77 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			controller.Execute()
 		})
+
+		It("should report the memory plugged by virtio-mem as current guest memory", func() {
+			initialMemory := resource.MustParse("1Gi")
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.Status.Memory = &v1.MemoryStatus{
+				GuestAtBoot:  &initialMemory,
+				GuestCurrent: &initialMemory,
+			}
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Spec.CurrentMemory = &api.Memory{Value: 3 * 1024 * 1024, Unit: "KiB"}
+			domain.Spec.Devices.Memory = &api.MemoryDevice{
+				Model: "virtio-mem",
+				Target: &api.MemoryTarget{
+					Size:      api.Memory{Value: 2 * 1024 * 1024, Unit: "KiB"},
+					Requested: api.Memory{Value: 2 * 1024 * 1024, Unit: "KiB"},
+					Current:   api.Memory{Value: 512 * 1024, Unit: "KiB"},
+				},
+			}
+
+			Expect(controller.updateMemoryInfo(vmi, domain)).To(Succeed())
+			Expect(vmi.Status.Memory.GuestCurrent.Cmp(resource.MustParse("1536Mi"))).To(BeZero())
+		})
+
+		It("should resize guest memory in place when the launcher pod requests enough memory", func() {
+			vmiConditions := virtcontroller.NewVirtualMachineInstanceConditionManager()
+			initialMemory := resource.MustParse("1Gi")
+			currentMemory := resource.MustParse("2Gi")
+			requestedMemory := resource.MustParse("1536Mi")
+
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &requestedMemory}
+			vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory] = requestedMemory
+			vmi.Status.Memory = &v1.MemoryStatus{
+				GuestAtBoot:    &initialMemory,
+				GuestCurrent:   &currentMemory,
+				GuestRequested: &currentMemory,
+			}
+			vmi.Labels = map[string]string{
+				v1.VirtualMachinePodMemoryRequestsLabel: "4Gi",
+			}
+			vmiConditions.UpdateCondition(vmi, &v1.VirtualMachineInstanceCondition{
+				Type:   v1.VirtualMachineInstanceMemoryChange,
+				Status: k8sv1.ConditionTrue,
+			})
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().Ping()
+			client.EXPECT().SyncVirtualMachineMemory(gomock.Any(), gomock.Any())
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+
+			vmiInterface.EXPECT().Update(context.Background(), gomock.Any()).Do(func(ctx context.Context, arg interface{}) {
+				updatedVMI := arg.(*v1.VirtualMachineInstance)
+				Expect(vmiConditions.HasCondition(updatedVMI, v1.VirtualMachineInstanceMemoryChange)).To(BeFalse())
+				Expect(updatedVMI.Labels).ToNot(HaveKey(v1.VirtualMachinePodMemoryRequestsLabel))
+				Expect(updatedVMI.Status.Memory.GuestRequested).To(Equal(&requestedMemory))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
 	})
 
 	Context("VirtualMachineInstance controller gets informed about disk information", func() {
@@ -145,6 +145,7 @@ type InterfaceStatus struct {
 type SEVNodeParameters struct {
 	PDH       string
 	CertChain string
+	ChipID    string
 }
 
 type Timezone struct {
@@ -369,6 +370,7 @@ type KubeVirtMetadata struct {
 	Migration        *MigrationMetadata        `xml:"migration,omitempty"`
 	AccessCredential *AccessCredentialMetadata `xml:"accessCredential,omitempty"`
 	MemoryDump       *MemoryDumpMetadata       `xml:"memoryDump,omitempty"`
+	Backup           *BackupMetadata           `xml:"backup,omitempty"`
 }
 
 type AccessCredentialMetadata struct {
@@ -385,6 +387,27 @@ type MemoryDumpMetadata struct {
 	FailureReason  string       `xml:"failureReason,omitempty"`
 }
 
+type BackupMetadata struct {
+	Name            string               `xml:"name,omitempty"`
+	IncrementalFrom string               `xml:"incrementalFrom,omitempty"`
+	StartTimestamp  *metav1.Time         `xml:"startTimestamp,omitempty"`
+	EndTimestamp    *metav1.Time         `xml:"endTimestamp,omitempty"`
+	Completed       bool                 `xml:"completed,omitempty"`
+	Failed          bool                 `xml:"failed,omitempty"`
+	FailureReason   string               `xml:"failureReason,omitempty"`
+	Disks           *BackupDisksMetadata `xml:"disks,omitempty"`
+}
+
+type BackupDisksMetadata struct {
+	Disks []BackupDiskMetadata `xml:"disk"`
+}
+
+type BackupDiskMetadata struct {
+	Name        string `xml:"name,attr"`
+	FileName    string `xml:"fileName,attr"`
+	Incremental bool   `xml:"incremental,attr,omitempty"`
+}
+
 type MigrationMetadata struct {
 	UID            types.UID        `xml:"uid,omitempty"`
 	StartTimestamp *metav1.Time     `xml:"startTimestamp,omitempty"`
@@ -518,9 +541,15 @@ type TPM struct {
 }
 
 type TPMBackend struct {
-	Type            string `xml:"type,attr"`
-	Version         string `xml:"version,attr"`
-	PersistentState string `xml:"persistent_state,attr,omitempty"`
+	Type            string                `xml:"type,attr"`
+	Version         string                `xml:"version,attr"`
+	PersistentState string                `xml:"persistent_state,attr,omitempty"`
+	Encryption      *TPMBackendEncryption `xml:"encryption,omitempty"`
+}
+
+type TPMBackendEncryption struct {
+	// UUID of the libvirt secret holding the passphrase the state is encrypted with
+	Secret string `xml:"secret,attr"`
 }
 
 // RedirectedDevice describes a device to be redirected
@@ -650,6 +679,22 @@ type Disk struct {
 	Capacity           *int64         `xml:"capacity,omitempty"`
 	ExpandDisksEnabled bool           `xml:"expandDisksEnabled,omitempty"`
 	Shareable          *Shareable     `xml:"shareable,omitempty"`
+	IOTune             *DiskIOTune    `xml:"iotune,omitempty"`
+}
+
+type DiskIOTune struct {
+	TotalBytesSec    uint64 `xml:"total_bytes_sec,omitempty"`
+	ReadBytesSec     uint64 `xml:"read_bytes_sec,omitempty"`
+	WriteBytesSec    uint64 `xml:"write_bytes_sec,omitempty"`
+	TotalIopsSec     uint64 `xml:"total_iops_sec,omitempty"`
+	ReadIopsSec      uint64 `xml:"read_iops_sec,omitempty"`
+	WriteIopsSec     uint64 `xml:"write_iops_sec,omitempty"`
+	TotalBytesSecMax uint64 `xml:"total_bytes_sec_max,omitempty"`
+	ReadBytesSecMax  uint64 `xml:"read_bytes_sec_max,omitempty"`
+	WriteBytesSecMax uint64 `xml:"write_bytes_sec_max,omitempty"`
+	TotalIopsSecMax  uint64 `xml:"total_iops_sec_max,omitempty"`
+	ReadIopsSecMax   uint64 `xml:"read_iops_sec_max,omitempty"`
+	WriteIopsSecMax  uint64 `xml:"write_iops_sec_max,omitempty"`
 }
 
 type DiskAuth struct {
@@ -1003,6 +1048,12 @@ type LaunchSecurity struct {
 	Policy          string `xml:"policy,omitempty"`
 	DHCert          string `xml:"dhCert,omitempty"`
 	Session         string `xml:"session,omitempty"`
+	// TDX quote generation service used by the guest to obtain attestation quotes
+	QuoteGenerationService *QuoteGenerationService `xml:"quoteGenerationService,omitempty"`
+}
+
+type QuoteGenerationService struct {
+	Path string `xml:"path,attr,omitempty"`
 }
 
 //END LaunchSecurity --------------------
@@ -1126,6 +1177,7 @@ type MemBalloon struct {
 	Stats             *Stats            `xml:"stats,omitempty"`
 	Address           *Address          `xml:"address,omitempty"`
 	Driver            *MemBalloonDriver `xml:"driver,omitempty"`
+	Autodeflate       string            `xml:"autodeflate,attr,omitempty"`
 	FreePageReporting string            `xml:"freePageReporting,attr,omitempty"`
 }
 
@@ -1179,16 +1231,64 @@ type IOThreads struct {
 type SecretUsage struct {
 	Type   string `xml:"type,attr"`
 	Target string `xml:"target,omitempty"`
+	Name   string `xml:"name,omitempty"`
 }
 
 type SecretSpec struct {
 	XMLName     xml.Name    `xml:"secret"`
 	Ephemeral   string      `xml:"ephemeral,attr"`
 	Private     string      `xml:"private,attr"`
+	UUID        string      `xml:"uuid,omitempty"`
 	Description string      `xml:"description,omitempty"`
 	Usage       SecretUsage `xml:"usage,omitempty"`
 }
 
+// DomainBackup represents a libvirt push mode backup job definition
+type DomainBackup struct {
+	XMLName     xml.Name           `xml:"domainbackup"`
+	Mode        string             `xml:"mode,attr,omitempty"`
+	Incremental string             `xml:"incremental,omitempty"`
+	Disks       *DomainBackupDisks `xml:"disks,omitempty"`
+}
+
+type DomainBackupDisks struct {
+	Disks []DomainBackupDisk `xml:"disk"`
+}
+
+type DomainBackupDisk struct {
+	Name       string                  `xml:"name,attr"`
+	Backup     string                  `xml:"backup,attr,omitempty"`
+	BackupMode string                  `xml:"backupmode,attr,omitempty"`
+	Type       string                  `xml:"type,attr,omitempty"`
+	Target     *DomainBackupDiskTarget `xml:"target,omitempty"`
+	Driver     *DomainBackupDiskDriver `xml:"driver,omitempty"`
+}
+
+type DomainBackupDiskTarget struct {
+	File string `xml:"file,attr"`
+}
+
+type DomainBackupDiskDriver struct {
+	Type string `xml:"type,attr"`
+}
+
+// DomainCheckpoint represents a libvirt checkpoint, tracking the blocks changed
+// on the disks since it was taken by the means of QEMU dirty bitmaps
+type DomainCheckpoint struct {
+	XMLName xml.Name               `xml:"domaincheckpoint"`
+	Name    string                 `xml:"name"`
+	Disks   *DomainCheckpointDisks `xml:"disks,omitempty"`
+}
+
+type DomainCheckpointDisks struct {
+	Disks []DomainCheckpointDisk `xml:"disk"`
+}
+
+type DomainCheckpointDisk struct {
+	Name       string `xml:"name,attr"`
+	Checkpoint string `xml:"checkpoint,attr"`
+}
+
 func NewMinimalDomainSpec(vmiName string) *DomainSpec {
 	precond.MustNotBeEmpty(vmiName)
 	domain := &DomainSpec{}
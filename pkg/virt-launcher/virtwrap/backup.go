@@ -0,0 +1,317 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"libvirt.org/go/libvirt"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+const (
+	failedDomainBackup   = "Domain backup failed"
+	maxConcurrentBackups = 1
+	// backupPollInterval is the interval in which the backup job is polled for completion
+	backupPollInterval = 1 * time.Second
+	// defaultBackupJobTimeout is used if the backup request does not carry a timeout
+	defaultBackupJobTimeout = 12 * time.Hour
+)
+
+// BackupVirtualMachine starts a push mode backup of the persistent disks of the VMI into
+// options.TargetDir. Along with the backup a checkpoint named after the backup is created,
+// which later backups can use to only copy the blocks which changed in between.
+func (l *LibvirtDomainManager) BackupVirtualMachine(vmi *v1.VirtualMachineInstance, options *cmdclient.BackupOptions) error {
+	select {
+	case l.backupInProgress <- struct{}{}:
+	default:
+		log.Log.Object(vmi).Infof("backup is in progress")
+		return nil
+	}
+
+	go func() {
+		defer func() { <-l.backupInProgress }()
+		if err := l.backup(vmi, options); err != nil {
+			log.Log.Object(vmi).Reason(err).Error(failedDomainBackup)
+		}
+	}()
+	return nil
+}
+
+func (l *LibvirtDomainManager) backup(vmi *v1.VirtualMachineInstance, options *cmdclient.BackupOptions) error {
+	logger := log.Log.Object(vmi)
+
+	if l.shouldSkipBackup(options.Name) {
+		return nil
+	}
+	l.initializeBackupMetadata(options.Name)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if dom == nil || err != nil {
+		l.setBackupResult(true, fmt.Sprintf("%s: %v", failedDomainBackup, err), "", nil)
+		return err
+	}
+	defer dom.Free()
+
+	incrementalFrom, disks, err := l.startBackup(dom, vmi, options)
+	if err != nil {
+		l.setBackupResult(true, fmt.Sprintf("%s: %v", failedDomainBackup, err), "", nil)
+		return err
+	}
+	logger.Infof("Started backup %s", options.Name)
+
+	if err := waitForBackupJob(dom, backupJobTimeout(options)); err != nil {
+		l.setBackupResult(true, fmt.Sprintf("%s: %v", failedDomainBackup, err), "", nil)
+		return err
+	}
+	logger.Infof("Completed backup %s successfully", options.Name)
+
+	// Only the checkpoint of the latest backup is needed as a base for the
+	// next incremental backup, drop the older ones to release their bitmaps
+	if err := removeCheckpointsExcept(dom, options.Name); err != nil {
+		logger.Reason(err).Warning("failed to remove outdated checkpoints")
+	}
+
+	l.setBackupResult(false, "", incrementalFrom, disks)
+	return nil
+}
+
+func backupJobTimeout(options *cmdclient.BackupOptions) time.Duration {
+	if options.TimeoutSeconds > 0 {
+		return time.Duration(options.TimeoutSeconds) * time.Second
+	}
+	return defaultBackupJobTimeout
+}
+
+func (l *LibvirtDomainManager) startBackup(dom cli.VirDomain, vmi *v1.VirtualMachineInstance, options *cmdclient.BackupOptions) (string, []api.BackupDiskMetadata, error) {
+	domSpec, err := getDomainSpec(dom)
+	if err != nil {
+		return "", nil, err
+	}
+
+	incrementalFrom := ""
+	if options.IncrementalFrom != "" {
+		exists, err := hasCheckpoint(dom, options.IncrementalFrom)
+		if err != nil {
+			return "", nil, err
+		}
+		if exists {
+			incrementalFrom = options.IncrementalFrom
+		} else {
+			log.Log.Object(vmi).Warningf("checkpoint %s not found, falling back to a full backup", options.IncrementalFrom)
+		}
+	}
+
+	backup, checkpoint, disks := generateBackupDefinitions(vmi, domSpec, options, incrementalFrom)
+	if len(disks) == 0 {
+		return "", nil, fmt.Errorf("no disks eligible for backup")
+	}
+
+	backupXML, err := xml.Marshal(backup)
+	if err != nil {
+		return "", nil, err
+	}
+	checkpointXML, err := xml.Marshal(checkpoint)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := dom.BackupBegin(string(backupXML), string(checkpointXML), 0); err != nil {
+		return "", nil, err
+	}
+
+	return incrementalFrom, disks, nil
+}
+
+// generateBackupDefinitions creates the libvirt backup and checkpoint definitions covering
+// all the writable persistent disks of the VMI
+func generateBackupDefinitions(vmi *v1.VirtualMachineInstance, domSpec *api.DomainSpec, options *cmdclient.BackupOptions, incrementalFrom string) (*api.DomainBackup, *api.DomainCheckpoint, []api.BackupDiskMetadata) {
+	persistentVolumes := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil || volume.DataVolume != nil {
+			persistentVolumes[volume.Name] = true
+		}
+	}
+
+	backupMode := "full"
+	if incrementalFrom != "" {
+		backupMode = "incremental"
+	}
+
+	backup := &api.DomainBackup{
+		Mode:        "push",
+		Incremental: incrementalFrom,
+		Disks:       &api.DomainBackupDisks{},
+	}
+	checkpoint := &api.DomainCheckpoint{
+		Name:  options.Name,
+		Disks: &api.DomainCheckpointDisks{},
+	}
+	var disks []api.BackupDiskMetadata
+
+	for _, disk := range domSpec.Devices.Disks {
+		if disk.Alias == nil || disk.Device != "disk" || disk.ReadOnly != nil {
+			continue
+		}
+		name := disk.Alias.GetName()
+		if !persistentVolumes[name] {
+			continue
+		}
+		fileName := fmt.Sprintf("%s-%s.qcow2", options.Name, name)
+		backup.Disks.Disks = append(backup.Disks.Disks, api.DomainBackupDisk{
+			Name:       disk.Target.Device,
+			Backup:     "yes",
+			BackupMode: backupMode,
+			Type:       "file",
+			Target:     &api.DomainBackupDiskTarget{File: filepath.Join(options.TargetDir, fileName)},
+			Driver:     &api.DomainBackupDiskDriver{Type: "qcow2"},
+		})
+		checkpoint.Disks.Disks = append(checkpoint.Disks.Disks, api.DomainCheckpointDisk{
+			Name:       disk.Target.Device,
+			Checkpoint: "bitmap",
+		})
+		disks = append(disks, api.BackupDiskMetadata{
+			Name:        name,
+			FileName:    fileName,
+			Incremental: incrementalFrom != "",
+		})
+	}
+
+	return backup, checkpoint, disks
+}
+
+// waitForBackupJob polls the backup job until it is done. A job still running after the timeout
+// is considered stuck and aborted, so it does not block later backups of the domain forever.
+func waitForBackupJob(dom cli.VirDomain, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		stats, err := dom.GetJobStats(0)
+		if err != nil {
+			return err
+		}
+		if stats.Type == libvirt.DOMAIN_JOB_NONE {
+			break
+		}
+		if time.Now().After(deadline) {
+			if err := dom.AbortJob(); err != nil {
+				return fmt.Errorf("backup job did not complete within %s and could not be aborted: %v", timeout, err)
+			}
+			return fmt.Errorf("backup job did not complete within %s and was aborted", timeout)
+		}
+		time.Sleep(backupPollInterval)
+	}
+
+	stats, err := dom.GetJobStats(libvirt.DOMAIN_JOB_STATS_COMPLETED)
+	if err != nil {
+		return err
+	}
+	switch stats.Type {
+	case libvirt.DOMAIN_JOB_COMPLETED:
+		return nil
+	case libvirt.DOMAIN_JOB_CANCELLED:
+		return fmt.Errorf("backup job was cancelled")
+	default:
+		return fmt.Errorf("backup job failed")
+	}
+}
+
+func hasCheckpoint(dom cli.VirDomain, name string) (bool, error) {
+	checkpoints, err := dom.ListAllCheckpoints(0)
+	if err != nil {
+		return false, err
+	}
+	found := false
+	for i := range checkpoints {
+		checkpointName, err := checkpoints[i].GetName()
+		if err == nil && checkpointName == name {
+			found = true
+		}
+		checkpoints[i].Free()
+	}
+	return found, nil
+}
+
+func removeCheckpointsExcept(dom cli.VirDomain, name string) error {
+	checkpoints, err := dom.ListAllCheckpoints(0)
+	if err != nil {
+		return err
+	}
+	var lastErr error
+	for i := range checkpoints {
+		checkpointName, err := checkpoints[i].GetName()
+		if err == nil && checkpointName != name {
+			err = checkpoints[i].Delete(0)
+		}
+		if err != nil {
+			lastErr = err
+		}
+		checkpoints[i].Free()
+	}
+	return lastErr
+}
+
+func (l *LibvirtDomainManager) shouldSkipBackup(name string) bool {
+	backupMetadata, _ := l.metadataCache.Backup.Load()
+	// backup still in progress or have just completed
+	// no need to trigger another one
+	return backupMetadata.Name == name
+}
+
+func (l *LibvirtDomainManager) initializeBackupMetadata(name string) {
+	l.metadataCache.Backup.WithSafeBlock(func(backupMetadata *api.BackupMetadata, initialized bool) {
+		now := metav1.Now()
+		*backupMetadata = api.BackupMetadata{
+			Name:           name,
+			StartTimestamp: &now,
+		}
+	})
+	log.Log.V(4).Infof("initialize backup metadata: %s", l.metadataCache.Backup.String())
+}
+
+func (l *LibvirtDomainManager) setBackupResult(failed bool, reason string, incrementalFrom string, disks []api.BackupDiskMetadata) {
+	l.metadataCache.Backup.WithSafeBlock(func(backupMetadata *api.BackupMetadata, initialized bool) {
+		if !initialized {
+			// nothing to report if backup metadata is empty
+			return
+		}
+
+		now := metav1.Now()
+		backupMetadata.Completed = true
+		backupMetadata.EndTimestamp = &now
+		backupMetadata.Failed = failed
+		backupMetadata.FailureReason = reason
+		backupMetadata.IncrementalFrom = incrementalFrom
+		if len(disks) > 0 {
+			backupMetadata.Disks = &api.BackupDisksMetadata{Disks: disks}
+		}
+	})
+	log.Log.V(4).Infof("set backup results in metadata: %s", l.metadataCache.Backup.String())
+}
@@ -66,6 +66,9 @@ type Connection interface {
 	GetDomainStats(statsTypes libvirt.DomainStatsTypes, l *stats.DomainJobInfo, flags libvirt.ConnectGetAllDomainStatsFlags) ([]*stats.DomainStats, error)
 	GetQemuVersion() (string, error)
 	GetSEVInfo() (*api.SEVNodeParameters, error)
+	// helper method, not found in libvirt
+	// Defines the secret and sets its value in one go
+	DefineSecret(spec *api.SecretSpec, value []byte) error
 }
 
 type Stream interface {
@@ -370,10 +373,34 @@ func (l *LibvirtConnection) GetSEVInfo() (*api.SEVNodeParameters, error) {
 	if params.CertChainSet {
 		sevNodeParameters.CertChain = params.CertChain
 	}
+	if params.CPU0IDSet {
+		sevNodeParameters.ChipID = params.CPU0ID
+	}
 
 	return sevNodeParameters, nil
 }
 
+func (l *LibvirtConnection) DefineSecret(spec *api.SecretSpec, value []byte) (err error) {
+	if err = l.reconnectIfNecessary(); err != nil {
+		return
+	}
+
+	secretXML, err := xml.Marshal(spec)
+	if err != nil {
+		return
+	}
+	secret, err := l.Connect.SecretDefineXML(string(secretXML), 0)
+	if err != nil {
+		l.checkConnectionLost(err)
+		return
+	}
+	defer secret.Free()
+
+	err = secret.SetValue(value, 0)
+	l.checkConnectionLost(err)
+	return
+}
+
 func (l *LibvirtConnection) GetDeviceAliasMap(domain *libvirt.Domain) (map[string]string, error) {
 	devAliasMap := make(map[string]string)
 
@@ -545,11 +572,14 @@ type VirDomain interface {
 	AuthorizedSSHKeysGet(user string, flags libvirt.DomainAuthorizedSSHKeysFlags) ([]string, error)
 	AuthorizedSSHKeysSet(user string, keys []string, flags libvirt.DomainAuthorizedSSHKeysFlags) error
 	AbortJob() error
+	BackupBegin(backupXML string, checkpointXML string, flags libvirt.DomainBackupBeginFlags) error
+	ListAllCheckpoints(flags libvirt.DomainCheckpointListFlags) ([]libvirt.DomainCheckpoint, error)
 	Free() error
 	CoreDumpWithFormat(to string, format libvirt.DomainCoreDumpFormat, flags libvirt.DomainCoreDumpFlags) error
 	PinVcpuFlags(vcpu uint, cpuMap []bool, flags libvirt.DomainModificationImpact) error
 	PinEmulator(cpumap []bool, flags libvirt.DomainModificationImpact) error
 	SetVcpusFlags(vcpu uint, flags libvirt.DomainVcpuFlags) error
+	SetBlockIoTune(disk string, params *libvirt.DomainBlockIoTuneParameters, flags libvirt.DomainModificationImpact) error
 	GetLaunchSecurityInfo(flags uint32) (*libvirt.DomainLaunchSecurityParameters, error)
 	SetLaunchSecurityState(params *libvirt.DomainLaunchSecurityStateParameters, flags uint32) error
 }
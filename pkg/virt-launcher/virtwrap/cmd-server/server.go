@@ -721,6 +721,29 @@ func (l *Launcher) InjectLaunchSecret(_ context.Context, request *cmdv1.InjectLa
 	return response, nil
 }
 
+func (l *Launcher) BackupVirtualMachine(_ context.Context, request *cmdv1.BackupRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	var options cmdclient.BackupOptions
+	if err := json.Unmarshal(request.Options, &options); err != nil {
+		response.Success = false
+		response.Message = "No valid backup options present in command server request"
+		return response, nil
+	}
+
+	if err := l.domainManager.BackupVirtualMachine(vmi, &options); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to start vmi backup")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	return response, nil
+}
+
 func (l *Launcher) SyncVirtualMachineMemory(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 	vmi, response := getVMIFromRequest(request.Vmi)
 	if !response.Success {
@@ -320,6 +320,17 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should start a vmi backup", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			options := &cmdclient.BackupOptions{
+				Name:            "backup-2",
+				IncrementalFrom: "backup-1",
+				TargetDir:       "path/to/backup/vol",
+			}
+			domainManager.EXPECT().BackupVirtualMachine(vmi, options).Return(nil)
+			Expect(client.BackupVirtualMachine(vmi, options)).To(Succeed())
+		})
+
 		It("should call UpdateGuestMemory", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domainManager.EXPECT().UpdateGuestMemory(vmi).Return(nil)
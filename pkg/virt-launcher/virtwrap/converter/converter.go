@@ -105,6 +105,8 @@ type EFIConfiguration struct {
 	EFICode      string
 	EFIVars      string
 	SecureLoader bool
+	// Stateless firmware is loaded as a ROM and has no NVRAM
+	Stateless bool
 }
 
 type ConverterContext struct {
@@ -124,6 +126,7 @@ type ConverterContext struct {
 	GPUHostDevices        []api.HostDevice
 	EFIConfiguration      *EFIConfiguration
 	MemBalloonStatsPeriod uint
+	BalloonTargetPercent  uint
 	UseVirtioTransitional bool
 	EphemeraldiskCreator  ephemeraldisk.EphemeralDiskCreatorInterface
 	VolumesDiscardIgnore  []string
@@ -254,10 +257,42 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 	if c.UseLaunchSecurity && disk.Target.Bus == v1.DiskBusVirtio {
 		disk.Driver.IOMMU = "on"
 	}
+	disk.IOTune = Convert_v1_DiskIOTune_To_api_DiskIOTune(diskDevice.IOTune)
 
 	return nil
 }
 
+func Convert_v1_DiskIOTune_To_api_DiskIOTune(ioTune *v1.DiskIOTune) *api.DiskIOTune {
+	if ioTune == nil {
+		return nil
+	}
+	apiIOTune := &api.DiskIOTune{}
+	apiIOTune.TotalBytesSec, apiIOTune.TotalBytesSecMax, apiIOTune.TotalIopsSec, apiIOTune.TotalIopsSecMax = convertDiskIOLimits(ioTune.Total)
+	apiIOTune.ReadBytesSec, apiIOTune.ReadBytesSecMax, apiIOTune.ReadIopsSec, apiIOTune.ReadIopsSecMax = convertDiskIOLimits(ioTune.Read)
+	apiIOTune.WriteBytesSec, apiIOTune.WriteBytesSecMax, apiIOTune.WriteIopsSec, apiIOTune.WriteIopsSecMax = convertDiskIOLimits(ioTune.Write)
+	return apiIOTune
+}
+
+// convertDiskIOLimits returns the bytes, burst bytes, IOPS and burst IOPS per second, unset limits are zero
+func convertDiskIOLimits(limits *v1.DiskIOLimits) (bytes, burstBytes, iops, burstIOPS uint64) {
+	if limits == nil {
+		return
+	}
+	if limits.BytesPerSecond != nil {
+		bytes = uint64(limits.BytesPerSecond.Value())
+	}
+	if limits.BurstBytesPerSecond != nil {
+		burstBytes = uint64(limits.BurstBytesPerSecond.Value())
+	}
+	if limits.IOPS != nil {
+		iops = uint64(*limits.IOPS)
+	}
+	if limits.BurstIOPS != nil {
+		burstIOPS = uint64(*limits.BurstIOPS)
+	}
+	return
+}
+
 // Get expected disk capacity - a minimum between the request and the PVC capacity.
 // Returns nil when we have insufficient data to calculate this minimum.
 func getDiskCapacity(pvcInfo *v1.PersistentVolumeClaimInfo) *int64 {
@@ -1239,6 +1274,29 @@ func isUSBNeeded(c *ConverterContext, vmi *v1.VirtualMachineInstance) bool {
 	return false
 }
 
+// setupBalloonTarget lets guests which opted in boot with the memory balloon inflated, so that they only
+// get the share of their memory requested by the NodeMemoryPolicy of the node. The balloon deflates
+// automatically once the guest runs out of memory.
+func setupBalloonTarget(vmi *v1.VirtualMachineInstance, domain *api.Domain, c *ConverterContext) {
+	if vmi.Annotations[v1.MemoryBalloonTargetEnabledAnnotation] != "true" {
+		return
+	}
+	if c.BalloonTargetPercent == 0 || c.BalloonTargetPercent >= 100 || domain.Spec.MaxMemory != nil {
+		return
+	}
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Hugepages != nil {
+		return
+	}
+	if autoattach := vmi.Spec.Domain.Devices.AutoattachMemBalloon; autoattach != nil && !*autoattach {
+		return
+	}
+	domain.Spec.CurrentMemory = &api.Memory{
+		Value: domain.Spec.Memory.Value * uint64(c.BalloonTargetPercent) / 100,
+		Unit:  domain.Spec.Memory.Unit,
+	}
+	domain.Spec.Devices.Ballooning.Autodeflate = "on"
+}
+
 func setupDomainMemory(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
 	if vmi.Spec.Domain.Memory == nil ||
 		vmi.Spec.Domain.Memory.MaxGuest == nil ||
@@ -1302,6 +1360,34 @@ func setupDomainMemory(vmi *v1.VirtualMachineInstance, domain *api.Domain) error
 	return nil
 }
 
+func convertLaunchSecurity(launchSecurity *v1.LaunchSecurity) *api.LaunchSecurity {
+	switch {
+	case launchSecurity.SNP != nil:
+		snpPolicyBits := launchsecurity.SEVSNPPolicyToBits(launchSecurity.SNP.Policy)
+		return &api.LaunchSecurity{
+			Type:   "sev-snp",
+			Policy: "0x" + strconv.FormatUint(snpPolicyBits, 16),
+		}
+	case launchSecurity.TDX != nil:
+		return &api.LaunchSecurity{
+			Type:   "tdx",
+			Policy: "0x" + strconv.FormatUint(launchsecurity.TDXPolicyToBits(), 16),
+			QuoteGenerationService: &api.QuoteGenerationService{
+				Path: launchsecurity.TDXQuoteGenerationSocket,
+			},
+		}
+	default:
+		sevPolicyBits := launchsecurity.SEVPolicyToBits(launchSecurity.SEV.Policy)
+		// Cbitpos and ReducedPhysBits will be filled automatically by libvirt from the domain capabilities
+		return &api.LaunchSecurity{
+			Type:    "sev",
+			Policy:  "0x" + strconv.FormatUint(uint64(sevPolicyBits), 16),
+			DHCert:  launchSecurity.SEV.DHCert,
+			Session: launchSecurity.SEV.Session,
+		}
+	}
+}
+
 func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInstance, domain *api.Domain, c *ConverterContext) (err error) {
 	var controllerDriver *api.ControllerDriver
 
@@ -1380,7 +1466,12 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			},
 		}
 
-		if util.IsEFIVMI(vmi) {
+		if util.IsEFIVMI(vmi) && c.EFIConfiguration.Stateless {
+			domain.Spec.OS.BootLoader = &api.Loader{
+				Path: c.EFIConfiguration.EFICode,
+				Type: "rom",
+			}
+		} else if util.IsEFIVMI(vmi) {
 			domain.Spec.OS.BootLoader = &api.Loader{
 				Path:     c.EFIConfiguration.EFICode,
 				ReadOnly: "yes",
@@ -1431,16 +1522,9 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		}
 
 	}
-	// Set SEV launch security parameters: https://libvirt.org/formatdomain.html#launch-security
+	// Set SEV, SEV-SNP or TDX launch security parameters: https://libvirt.org/formatdomain.html#launch-security
 	if c.UseLaunchSecurity {
-		sevPolicyBits := launchsecurity.SEVPolicyToBits(vmi.Spec.Domain.LaunchSecurity.SEV.Policy)
-		// Cbitpos and ReducedPhysBits will be filled automatically by libvirt from the domain capabilities
-		domain.Spec.LaunchSecurity = &api.LaunchSecurity{
-			Type:    "sev",
-			Policy:  "0x" + strconv.FormatUint(uint64(sevPolicyBits), 16),
-			DHCert:  vmi.Spec.Domain.LaunchSecurity.SEV.DHCert,
-			Session: vmi.Spec.Domain.LaunchSecurity.SEV.Session,
-		}
+		domain.Spec.LaunchSecurity = convertLaunchSecurity(vmi.Spec.Domain.LaunchSecurity)
 		controllerDriver = &api.ControllerDriver{
 			IOMMU: "on",
 		}
@@ -1713,6 +1797,7 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 
 	domain.Spec.Devices.Ballooning = &api.MemBalloon{}
 	ConvertV1ToAPIBalloning(&vmi.Spec.Domain.Devices, domain.Spec.Devices.Ballooning, c)
+	setupBalloonTarget(vmi, domain, c)
 
 	if vmi.Spec.Domain.Devices.Inputs != nil {
 		inputDevices := make([]api.Input, 0)
@@ -1994,6 +2079,12 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			//   we decided to introduce them together. Ultimately, we should use tpm-crb for all cases,
 			//   as it is now the generally preferred model
 			domain.Spec.Devices.TPMs[0].Model = "tpm-crb"
+			if encryption := vmi.Spec.Domain.Devices.TPM.Encryption; encryption != nil && encryption.SecretRef != nil {
+				// The secret is defined by virt-launcher under the VMI UID, which stays the same on migration targets
+				domain.Spec.Devices.TPMs[0].Backend.Encryption = &api.TPMBackendEncryption{
+					Secret: string(vmi.UID),
+				}
+			}
 		}
 	}
 
@@ -1354,6 +1354,51 @@ var _ = Describe("Converter", func() {
 			Entry("ErrorPolicy equal to report", kubevirtpointer.P(v1.DiskErrorPolicyReport), "report"),
 			Entry("ErrorPolicy equal to enospace", kubevirtpointer.P(v1.DiskErrorPolicyEnospace), "enospace"),
 		)
+		DescribeTable("Should set the I/O limits", func(ioTune *v1.DiskIOTune, expected *api.DiskIOTune) {
+			vmi.Spec.Domain.Devices.Disks[0] = v1.Disk{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{
+						Bus: v1.VirtIO,
+					},
+				},
+				IOTune: ioTune,
+			}
+			vmi.Spec.Volumes[0] = v1.Volume{
+				Name: "mydisk",
+				VolumeSource: v1.VolumeSource{
+					Ephemeral: &v1.EphemeralVolumeSource{
+						PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testclaim",
+						},
+					},
+				},
+			}
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.Disks[0].IOTune).To(Equal(expected))
+		},
+			Entry("IOTune not specified", nil, nil),
+			Entry("total limits", &v1.DiskIOTune{
+				Total: &v1.DiskIOLimits{
+					BytesPerSecond:      kubevirtpointer.P(resource.MustParse("10Mi")),
+					BurstBytesPerSecond: kubevirtpointer.P(resource.MustParse("20Mi")),
+					IOPS:                kubevirtpointer.P(int64(500)),
+					BurstIOPS:           kubevirtpointer.P(int64(1000)),
+				},
+			}, &api.DiskIOTune{
+				TotalBytesSec:    10485760,
+				TotalBytesSecMax: 20971520,
+				TotalIopsSec:     500,
+				TotalIopsSecMax:  1000,
+			}),
+			Entry("read and write limits", &v1.DiskIOTune{
+				Read:  &v1.DiskIOLimits{BytesPerSecond: kubevirtpointer.P(resource.MustParse("1M"))},
+				Write: &v1.DiskIOLimits{IOPS: kubevirtpointer.P(int64(100))},
+			}, &api.DiskIOTune{
+				ReadBytesSec: 1000000,
+				WriteIopsSec: 100,
+			}),
+		)
 
 	})
 	Context("Network convert", func() {
@@ -2322,6 +2367,27 @@ var _ = Describe("Converter", func() {
 			Expect(domainSpec.OS.NVRam.NVRam).To(Equal("/var/lib/libvirt/qemu/nvram/testvmi_VARS.fd"))
 		})
 
+		It("should load stateless EFI firmware as a ROM without NVRAM", func() {
+			c.EFIConfiguration = &EFIConfiguration{
+				EFICode:   "OVMF.amdsev.fd",
+				Stateless: true,
+			}
+
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				Bootloader: &v1.Bootloader{
+					EFI: &v1.EFI{
+						SecureBoot: pointer.BoolPtr(false),
+					},
+				},
+			}
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.OS.BootLoader.Type).To(Equal("rom"))
+			Expect(domainSpec.OS.BootLoader.ReadOnly).To(BeEmpty())
+			Expect(domainSpec.OS.BootLoader.Secure).To(BeEmpty())
+			Expect(path.Base(domainSpec.OS.BootLoader.Path)).To(Equal(c.EFIConfiguration.EFICode))
+			Expect(domainSpec.OS.NVRam).To(BeNil())
+		})
+
 		DescribeTable("display device should be set to", func(bootloader v1.Bootloader, enableFG bool, expectedDevice string) {
 			vmi.Spec.Domain.Firmware = &v1.Firmware{Bootloader: &bootloader}
 			c = &ConverterContext{
@@ -2571,6 +2637,48 @@ var _ = Describe("Converter", func() {
 				Expect(domain.Spec.Devices.Memory.Target.Block.Value).To(Equal(uint64(MemoryHotplugBlockAlignmentBytes)))
 				Expect(domain.Spec.Devices.Memory.Target.Block.Unit).To(Equal("b"))
 			})
+
+			It("should boot with the memory balloon inflated to the balloon target", func() {
+				vmi.Spec.Domain.Memory.MaxGuest = nil
+				vmi.Annotations = map[string]string{v1.MemoryBalloonTargetEnabledAnnotation: "true"}
+				domain.Spec.Devices.Ballooning = &api.MemBalloon{}
+				c.BalloonTargetPercent = 75
+				Expect(setupDomainMemory(vmi, domain)).To(Succeed())
+				setupBalloonTarget(vmi, domain, c)
+
+				Expect(domain.Spec.CurrentMemory).ToNot(BeNil())
+				Expect(domain.Spec.CurrentMemory.Unit).To(Equal("b"))
+				Expect(domain.Spec.CurrentMemory.Value).To(Equal(uint64(guestMemory.Value()) * 3 / 4))
+				Expect(domain.Spec.Devices.Ballooning.Autodeflate).To(Equal("on"))
+			})
+
+			DescribeTable("should not inflate the memory balloon", func(percent uint, updateVMI func(*v1.VirtualMachineInstance)) {
+				vmi.Spec.Domain.Memory.MaxGuest = nil
+				vmi.Annotations = map[string]string{v1.MemoryBalloonTargetEnabledAnnotation: "true"}
+				updateVMI(vmi)
+				domain.Spec.Devices.Ballooning = &api.MemBalloon{}
+				c.BalloonTargetPercent = percent
+				Expect(setupDomainMemory(vmi, domain)).To(Succeed())
+				setupBalloonTarget(vmi, domain, c)
+
+				Expect(domain.Spec.CurrentMemory).To(BeNil())
+				Expect(domain.Spec.Devices.Ballooning.Autodeflate).To(BeEmpty())
+			},
+				Entry("without opting in", uint(75), func(vmi *v1.VirtualMachineInstance) {
+					vmi.Annotations = nil
+				}),
+				Entry("without a balloon target", uint(0), func(*v1.VirtualMachineInstance) {}),
+				Entry("with a balloon target of 100 percent", uint(100), func(*v1.VirtualMachineInstance) {}),
+				Entry("with memory hotplug", uint(75), func(vmi *v1.VirtualMachineInstance) {
+					vmi.Spec.Domain.Memory.MaxGuest = &maxGuestMemory
+				}),
+				Entry("with hugepages", uint(75), func(vmi *v1.VirtualMachineInstance) {
+					vmi.Spec.Domain.Memory.Hugepages = &v1.Hugepages{PageSize: "2Mi"}
+				}),
+				Entry("without a memory balloon", uint(75), func(vmi *v1.VirtualMachineInstance) {
+					vmi.Spec.Domain.Devices.AutoattachMemBalloon = pointer.Bool(false)
+				}),
+			)
 		})
 	})
 
@@ -2639,6 +2747,47 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.LaunchSecurity.Policy).To(Equal("0x" + strconv.FormatUint(uint64(sev.SEVPolicyNoDebug|sev.SEVPolicyEncryptedState), 16)))
 		})
 
+		It("should set LaunchSecurity domain element with 'sev-snp' type and default policy bits", func() {
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
+				SNP: &v1.SEVSNP{},
+			}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(BeNil())
+			Expect(domain.Spec.LaunchSecurity).To(Equal(&api.LaunchSecurity{
+				Type:   "sev-snp",
+				Policy: "0x30000",
+			}))
+		})
+
+		It("should set LaunchSecurity domain element with 'sev-snp' type without the SMT policy bit", func() {
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
+				SNP: &v1.SEVSNP{
+					Policy: &v1.SEVSNPPolicy{
+						SMT: pointer.Bool(false),
+					},
+				},
+			}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(BeNil())
+			Expect(domain.Spec.LaunchSecurity).ToNot(BeNil())
+			Expect(domain.Spec.LaunchSecurity.Policy).To(Equal("0x" + strconv.FormatUint(sev.SEVSNPPolicyReserved, 16)))
+		})
+
+		It("should set LaunchSecurity domain element with 'tdx' type and the quote generation service", func() {
+			vmi.Spec.Domain.LaunchSecurity = &v1.LaunchSecurity{
+				TDX: &v1.TDX{},
+			}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).ToNot(BeNil())
+			Expect(domain.Spec.LaunchSecurity).To(Equal(&api.LaunchSecurity{
+				Type:   "tdx",
+				Policy: "0x10000000",
+				QuoteGenerationService: &api.QuoteGenerationService{
+					Path: sev.TDXQuoteGenerationSocket,
+				},
+			}))
+		})
+
 		It("should set IOMMU attribute of the RngDriver", func() {
 			rng := &api.Rng{}
 			Expect(Convert_v1_Rng_To_api_Rng(&v1.Rng{}, rng, c)).To(Succeed())
@@ -2787,6 +2936,44 @@ var _ = Describe("Converter", func() {
 		)
 	})
 
+	Context("with TPM", func() {
+		var vmi *v1.VirtualMachineInstance
+		c := &ConverterContext{AllowEmulation: true}
+
+		BeforeEach(func() {
+			vmi = kvapi.NewMinimalVMI("testvmi")
+			vmi.UID = "f3e7e4a1-6f6e-4c8f-9d3b-2f5e0c1a7b9d"
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		})
+
+		DescribeTable("should render the TPM backend", func(tpm *v1.TPMDevice, expectedTPM api.TPM) {
+			vmi.Spec.Domain.Devices.TPM = tpm
+			domain := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domain.Devices.TPMs).To(ConsistOf(expectedTPM))
+		},
+			Entry("without persistence", &v1.TPMDevice{}, api.TPM{
+				Model:   "tpm-tis",
+				Backend: api.TPMBackend{Type: "emulator", Version: "2.0"},
+			}),
+			Entry("with persistence", &v1.TPMDevice{Persistent: pointer.Bool(true)}, api.TPM{
+				Model:   "tpm-crb",
+				Backend: api.TPMBackend{Type: "emulator", Version: "2.0", PersistentState: "yes"},
+			}),
+			Entry("with encrypted persistent state", &v1.TPMDevice{
+				Persistent: pointer.Bool(true),
+				Encryption: &v1.TPMStateEncryption{SecretRef: &k8sv1.LocalObjectReference{Name: "tpm-key"}},
+			}, api.TPM{
+				Model: "tpm-crb",
+				Backend: api.TPMBackend{
+					Type:            "emulator",
+					Version:         "2.0",
+					PersistentState: "yes",
+					Encryption:      &api.TPMBackendEncryption{Secret: "f3e7e4a1-6f6e-4c8f-9d3b-2f5e0c1a7b9d"},
+				},
+			}),
+		)
+	})
+
 	Context("with Paused strategy", func() {
 		var (
 			vmi *v1.VirtualMachineInstance
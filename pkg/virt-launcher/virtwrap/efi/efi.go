@@ -33,6 +33,8 @@ const (
 	EFIVarsSecureBoot = "OVMF_VARS.secboot.fd"
 	EFICodeSEV        = "OVMF_CODE.cc.fd"
 	EFIVarsSEV        = EFIVars
+	EFICodeSNP        = "OVMF.amdsev.fd"
+	EFICodeTDX        = "OVMF.inteltdx.fd"
 )
 
 type EFIEnvironment struct {
@@ -42,6 +44,8 @@ type EFIEnvironment struct {
 	varsSecureBoot string
 	codeSEV        string
 	varsSEV        string
+	codeSNP        string
+	codeTDX        string
 }
 
 func (e *EFIEnvironment) Bootable(secureBoot, sev bool) bool {
@@ -74,6 +78,16 @@ func (e *EFIEnvironment) EFIVars(secureBoot, sev bool) string {
 	}
 }
 
+// EFICodeSNP returns the stateless AmdSev firmware, which SEV-SNP guests boot from without NVRAM
+func (e *EFIEnvironment) EFICodeSNP() string {
+	return e.codeSNP
+}
+
+// EFICodeTDX returns the stateless firmware which TDX guests boot from without NVRAM
+func (e *EFIEnvironment) EFICodeTDX() string {
+	return e.codeTDX
+}
+
 func DetectEFIEnvironment(arch, ovmfPath string) *EFIEnvironment {
 	if arch == "arm64" {
 		codeArm64 := getEFIBinaryIfExists(ovmfPath, EFICodeAARCH64)
@@ -102,6 +116,10 @@ func DetectEFIEnvironment(arch, ovmfPath string) *EFIEnvironment {
 	codeWithSEV := getEFIBinaryIfExists(ovmfPath, EFICodeSEV)
 	varsWithSEV := getEFIBinaryIfExists(ovmfPath, EFIVarsSEV)
 
+	// detect stateless EFI with SEV-SNP and TDX
+	codeWithSNP := getEFIBinaryIfExists(ovmfPath, EFICodeSNP)
+	codeWithTDX := getEFIBinaryIfExists(ovmfPath, EFICodeTDX)
+
 	return &EFIEnvironment{
 		codeSecureBoot: codeWithSB,
 		varsSecureBoot: varsWithSB,
@@ -109,6 +127,8 @@ func DetectEFIEnvironment(arch, ovmfPath string) *EFIEnvironment {
 		vars:           vars,
 		codeSEV:        codeWithSEV,
 		varsSEV:        varsWithSEV,
+		codeSNP:        codeWithSNP,
+		codeTDX:        codeWithTDX,
 	}
 }
 
@@ -105,4 +105,27 @@ var _ = Describe("EFI environment detection", func() {
 		Expect(efiEnv.EFIVars(!secureBootEnabled, sevEnabled)).To(Equal(varsSEV))
 		Expect(efiEnv.EFIVars(!secureBootEnabled, !sevEnabled)).To(Equal(varsSEV)) // same as EFIVars
 	})
+
+	It("SEV-SNP and TDX EFI Roms", func() {
+		ovmfPath := createEFIRoms(EFICodeSNP, EFICodeTDX)
+		defer os.RemoveAll(ovmfPath)
+
+		efiEnv := DetectEFIEnvironment("x86_64", ovmfPath)
+		Expect(efiEnv).ToNot(BeNil())
+
+		Expect(efiEnv.EFICodeSNP()).To(Equal(filepath.Join(ovmfPath, EFICodeSNP)))
+		Expect(efiEnv.EFICodeTDX()).To(Equal(filepath.Join(ovmfPath, EFICodeTDX)))
+		Expect(efiEnv.Bootable(!secureBootEnabled, sevEnabled)).To(BeFalse())
+	})
+
+	It("SEV-SNP and TDX EFI Roms not available", func() {
+		ovmfPath := createEFIRoms(EFICodeSEV, EFIVarsSEV)
+		defer os.RemoveAll(ovmfPath)
+
+		efiEnv := DetectEFIEnvironment("x86_64", ovmfPath)
+		Expect(efiEnv).ToNot(BeNil())
+
+		Expect(efiEnv.EFICodeSNP()).To(BeEmpty())
+		Expect(efiEnv.EFICodeTDX()).To(BeEmpty())
+	})
 })
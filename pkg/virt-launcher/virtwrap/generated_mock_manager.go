@@ -348,3 +348,13 @@ func (_m *MockDomainManager) UpdateGuestMemory(vmi *v1.VirtualMachineInstance) e
 func (_mr *_MockDomainManagerRecorder) UpdateGuestMemory(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateGuestMemory", arg0)
 }
+
+func (_m *MockDomainManager) BackupVirtualMachine(_param0 *v1.VirtualMachineInstance, _param1 *cmd_client.BackupOptions) error {
+	ret := _m.ctrl.Call(_m, "BackupVirtualMachine", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) BackupVirtualMachine(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BackupVirtualMachine", arg0, arg1)
+}
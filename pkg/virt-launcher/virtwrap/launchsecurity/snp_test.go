@@ -0,0 +1,52 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023
+ *
+ */
+
+package launchsecurity_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/launchsecurity"
+)
+
+var _ = Describe("LaunchSecurity: AMD Secure Encrypted Virtualization with Secure Nested Paging (SEV-SNP)", func() {
+	Context("SEV-SNP policy conversion", func() {
+		const defaultBits = launchsecurity.SEVSNPPolicyReserved | launchsecurity.SEVSNPPolicySMT
+
+		It("should set the reserved bit and allow SMT by default", func() {
+			Expect(launchsecurity.SEVSNPPolicyToBits(nil)).To(Equal(defaultBits))
+			Expect(launchsecurity.SEVSNPPolicyToBits(&v1.SEVSNPPolicy{})).To(Equal(defaultBits))
+			Expect(launchsecurity.SEVSNPPolicyToBits(&v1.SEVSNPPolicy{SMT: pointer.Bool(true)})).To(Equal(defaultBits))
+		})
+
+		It("should clear the SMT bit when SMT is disallowed", func() {
+			Expect(launchsecurity.SEVSNPPolicyToBits(&v1.SEVSNPPolicy{SMT: pointer.Bool(false)})).To(Equal(launchsecurity.SEVSNPPolicyReserved))
+		})
+
+		It("should never set Debug", func() {
+			Expect(launchsecurity.SEVSNPPolicyToBits(nil) & launchsecurity.SEVSNPPolicyDebug).To(BeZero())
+			Expect(launchsecurity.SEVSNPPolicyToBits(&v1.SEVSNPPolicy{SMT: pointer.Bool(false)}) & launchsecurity.SEVSNPPolicyDebug).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,37 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023
+ *
+ */
+
+package launchsecurity
+
+const (
+	// TD attribute bits as defined in Intel TDX module specification
+	TDXPolicyDebug         uint64 = (1 << 0)
+	TDXPolicySEPTVEDisable uint64 = (1 << 28)
+)
+
+const (
+	// Host directory and socket of the TDX Quote Generation Service (QGS)
+	TDXQuoteGenerationDir    = "/var/run/tdx-qgs"
+	TDXQuoteGenerationSocket = TDXQuoteGenerationDir + "/qgs.socket"
+)
+
+func TDXPolicyToBits() uint64 {
+	// Debug is never allowed, EPT violations are not converted to #VE in the guest
+	return TDXPolicySEPTVEDisable
+}
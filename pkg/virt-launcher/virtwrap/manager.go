@@ -76,6 +76,7 @@ import (
 	netsetup "kubevirt.io/kubevirt/pkg/network/setup"
 	netsriov "kubevirt.io/kubevirt/pkg/network/sriov"
 	netvmispec "kubevirt.io/kubevirt/pkg/network/vmispec"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	kutil "kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/metadata"
 	accesscredentials "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/access-credentials"
@@ -139,6 +140,7 @@ type DomainManager interface {
 	GetLaunchMeasurement(*v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error)
 	InjectLaunchSecret(*v1.VirtualMachineInstance, *v1.SEVSecretOptions) error
 	UpdateGuestMemory(vmi *v1.VirtualMachineInstance) error
+	BackupVirtualMachine(*v1.VirtualMachineInstance, *cmdclient.BackupOptions) error
 }
 
 type LibvirtDomainManager struct {
@@ -153,6 +155,7 @@ type LibvirtDomainManager struct {
 
 	hotplugHostDevicesInProgress chan struct{}
 	memoryDumpInProgress         chan struct{}
+	backupInProgress             chan struct{}
 
 	virtShareDir             string
 	ephemeralDiskDir         string
@@ -219,6 +222,7 @@ func newLibvirtDomainManager(connection cli.Connection, virtShareDir, ephemeralD
 
 	manager.hotplugHostDevicesInProgress = make(chan struct{}, maxConcurrentHotplugHostDevices)
 	manager.memoryDumpInProgress = make(chan struct{}, maxConcurrentMemoryDumps)
+	manager.backupInProgress = make(chan struct{}, maxConcurrentBackups)
 	manager.credManager = accesscredentials.NewManager(connection, &manager.domainModifyLock, metadataCache)
 
 	return &manager, nil
@@ -749,6 +753,10 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 		return domain, fmt.Errorf("creating secret disks failed: %v", err)
 	}
 
+	if err := l.defineTPMStateEncryptionSecret(vmi); err != nil {
+		return domain, fmt.Errorf("defining the TPM state encryption secret failed: %v", err)
+	}
+
 	// create Sysprep disks if they exists
 	if err := config.CreateSysprepDisks(vmi, generateEmptyIsos); err != nil {
 		return domain, fmt.Errorf("creating sysprep disks failed: %v", err)
@@ -866,6 +874,33 @@ func shouldExpandOffline(disk api.Disk) bool {
 	return true
 }
 
+// defineTPMStateEncryptionSecret hands the key of the Secret referenced by the TPM device over to libvirt,
+// which passes it to swtpm for encrypting the persisted TPM state
+func (l *LibvirtDomainManager) defineTPMStateEncryptionSecret(vmi *v1.VirtualMachineInstance) error {
+	if !backendstorage.HasEncryptedTPMState(&vmi.Spec) {
+		return nil
+	}
+
+	keyPath := filepath.Join(config.GetSecretSourcePath(backendstorage.TPMEncryptionVolumeName), backendstorage.TPMEncryptionSecretKey)
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return err
+	}
+	if len(key) == 0 {
+		return fmt.Errorf("the TPM state encryption key %s is empty", keyPath)
+	}
+
+	return l.virConn.DefineSecret(&api.SecretSpec{
+		Ephemeral: "yes",
+		Private:   "yes",
+		UUID:      string(vmi.UID),
+		Usage: api.SecretUsage{
+			Type: "vtpm",
+			Name: fmt.Sprintf("%s/%s", vmi.Namespace, vmi.Name),
+		},
+	}, key)
+}
+
 func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineInstance, allowEmulation bool, options *cmdv1.VirtualMachineOptions, isMigrationTarget bool) (*converter.ConverterContext, error) {
 
 	logger := log.Log.Object(vmi)
@@ -914,15 +949,33 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 		secureBoot := vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot
 		sev := kutil.IsSEVVMI(vmi)
 
-		if !l.efiEnvironment.Bootable(secureBoot, sev) {
-			log.Log.Errorf("EFI OVMF roms missing for booting in EFI mode with SecureBoot=%v, SEV=%v", secureBoot, sev)
-			return nil, fmt.Errorf("EFI OVMF roms missing for booting in EFI mode with SecureBoot=%v, SEV=%v", secureBoot, sev)
-		}
+		switch {
+		case kutil.IsSEVSNPVMI(vmi), kutil.IsTDXVMI(vmi):
+			// SEV-SNP and TDX guests boot from stateless firmware, so that their measurement does not depend on NVRAM
+			technology, code := "SEV-SNP", l.efiEnvironment.EFICodeSNP()
+			if kutil.IsTDXVMI(vmi) {
+				technology, code = "TDX", l.efiEnvironment.EFICodeTDX()
+			}
+			if code == "" {
+				log.Log.Errorf("EFI OVMF rom missing for booting in EFI mode with %s", technology)
+				return nil, fmt.Errorf("EFI OVMF rom missing for booting in EFI mode with %s", technology)
+			}
 
-		efiConf = &converter.EFIConfiguration{
-			EFICode:      l.efiEnvironment.EFICode(secureBoot, sev),
-			EFIVars:      l.efiEnvironment.EFIVars(secureBoot, sev),
-			SecureLoader: secureBoot,
+			efiConf = &converter.EFIConfiguration{
+				EFICode:   code,
+				Stateless: true,
+			}
+		default:
+			if !l.efiEnvironment.Bootable(secureBoot, sev) {
+				log.Log.Errorf("EFI OVMF roms missing for booting in EFI mode with SecureBoot=%v, SEV=%v", secureBoot, sev)
+				return nil, fmt.Errorf("EFI OVMF roms missing for booting in EFI mode with SecureBoot=%v, SEV=%v", secureBoot, sev)
+			}
+
+			efiConf = &converter.EFIConfiguration{
+				EFICode:      l.efiEnvironment.EFICode(secureBoot, sev),
+				EFIVars:      l.efiEnvironment.EFIVars(secureBoot, sev),
+				SecureLoader: secureBoot,
+			}
 		}
 	}
 
@@ -938,7 +991,7 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 		UseVirtioTransitional: vmi.Spec.Domain.Devices.UseVirtioTransitional != nil && *vmi.Spec.Domain.Devices.UseVirtioTransitional,
 		PermanentVolumes:      permanentVolumes,
 		EphemeraldiskCreator:  l.ephemeralDiskCreator,
-		UseLaunchSecurity:     kutil.IsSEVVMI(vmi),
+		UseLaunchSecurity:     kutil.IsLaunchSecurityVMI(vmi),
 		FreePageReporting:     isFreePageReportingEnabled(false, vmi),
 		SerialConsoleLog:      isSerialConsoleLogEnabled(false, vmi),
 	}
@@ -952,6 +1005,7 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 			c.Topology = options.Topology
 		}
 		c.MemBalloonStatsPeriod = uint(options.MemBalloonStatsPeriod)
+		c.BalloonTargetPercent = uint(options.BalloonTargetPercent)
 		// Add preallocated and thick-provisioned volumes for which we need to avoid the discard=unmap option
 		c.VolumesDiscardIgnore = options.PreallocatedVolumes
 
@@ -1166,6 +1220,11 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 	}
 
 	if vmi.IsRunning() {
+		if err := updateDiskIOTune(dom, oldSpec.Devices.Disks, domain.Spec.Devices.Disks); err != nil {
+			logger.Reason(err).Error("updating disk I/O limits")
+			return nil, err
+		}
+
 		networkInterfaceManager := newVirtIOInterfaceManager(
 			dom, netsetup.NewVMNetworkConfigurator(vmi, cache.CacheCreator{}))
 		if err := networkInterfaceManager.hotplugVirtioInterface(vmi, &api.Domain{Spec: *oldSpec}, domain); err != nil {
@@ -1180,6 +1239,68 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 	return oldSpec, nil
 }
 
+// updateDiskIOTune applies the changed I/O limits of the attached disks to the domain,
+// disks which are not attached yet get their limits with the attachment.
+func updateDiskIOTune(dom cli.VirDomain, oldDisks, newDisks []api.Disk) error {
+	oldIOTunes := map[string]api.DiskIOTune{}
+	for _, disk := range oldDisks {
+		oldIOTunes[disk.Target.Device] = diskIOTuneOrEmpty(disk)
+	}
+	for _, disk := range newDisks {
+		oldIOTune, attached := oldIOTunes[disk.Target.Device]
+		if !attached {
+			continue
+		}
+		newIOTune := diskIOTuneOrEmpty(disk)
+		if oldIOTune == newIOTune {
+			continue
+		}
+		log.Log.V(1).Infof("Updating I/O limits of disk %s, target %s", disk.Alias.GetName(), disk.Target.Device)
+		err := dom.SetBlockIoTune(disk.Target.Device, blockIoTuneParameters(newIOTune), affectDomainLiveAndConfigLibvirtFlags)
+		if err != nil {
+			return fmt.Errorf("failed to update I/O limits of disk %s: %v", disk.Alias.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func diskIOTuneOrEmpty(disk api.Disk) api.DiskIOTune {
+	if disk.IOTune == nil {
+		return api.DiskIOTune{}
+	}
+	return *disk.IOTune
+}
+
+// blockIoTuneParameters sets all the limits, libvirt removes the limits which are zero
+func blockIoTuneParameters(ioTune api.DiskIOTune) *libvirt.DomainBlockIoTuneParameters {
+	return &libvirt.DomainBlockIoTuneParameters{
+		TotalBytesSecSet:    true,
+		TotalBytesSec:       ioTune.TotalBytesSec,
+		ReadBytesSecSet:     true,
+		ReadBytesSec:        ioTune.ReadBytesSec,
+		WriteBytesSecSet:    true,
+		WriteBytesSec:       ioTune.WriteBytesSec,
+		TotalIopsSecSet:     true,
+		TotalIopsSec:        ioTune.TotalIopsSec,
+		ReadIopsSecSet:      true,
+		ReadIopsSec:         ioTune.ReadIopsSec,
+		WriteIopsSecSet:     true,
+		WriteIopsSec:        ioTune.WriteIopsSec,
+		TotalBytesSecMaxSet: true,
+		TotalBytesSecMax:    ioTune.TotalBytesSecMax,
+		ReadBytesSecMaxSet:  true,
+		ReadBytesSecMax:     ioTune.ReadBytesSecMax,
+		WriteBytesSecMaxSet: true,
+		WriteBytesSecMax:    ioTune.WriteBytesSecMax,
+		TotalIopsSecMaxSet:  true,
+		TotalIopsSecMax:     ioTune.TotalIopsSecMax,
+		ReadIopsSecMaxSet:   true,
+		ReadIopsSecMax:      ioTune.ReadIopsSecMax,
+		WriteIopsSecMaxSet:  true,
+		WriteIopsSecMax:     ioTune.WriteIopsSecMax,
+	}
+}
+
 func getSourceFile(disk api.Disk) string {
 	file := disk.Source.File
 	if disk.Source.File == "" {
@@ -2065,6 +2186,7 @@ func (l *LibvirtDomainManager) GetSEVInfo() (*v1.SEVPlatformInfo, error) {
 	return &v1.SEVPlatformInfo{
 		PDH:       sevNodeParameters.PDH,
 		CertChain: sevNodeParameters.CertChain,
+		ChipID:    sevNodeParameters.ChipID,
 	}, nil
 }
 
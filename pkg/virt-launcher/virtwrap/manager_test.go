@@ -53,9 +53,11 @@ import (
 	v1 "kubevirt.io/api/core/v1"
 
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
+	"kubevirt.io/kubevirt/pkg/config"
 	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	"kubevirt.io/kubevirt/pkg/ephemeral-disk/fake"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 	"kubevirt.io/kubevirt/pkg/util/net/ip"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/metadata"
@@ -449,6 +451,42 @@ var _ = Describe("Manager", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(newspec).ToNot(BeNil())
 		})
+		It("should define the TPM state encryption secret before starting a new VirtualMachineInstance", func() {
+			secretSourceDir := config.SecretSourceDir
+			config.SecretSourceDir = GinkgoT().TempDir()
+			DeferCleanup(func() { config.SecretSourceDir = secretSourceDir })
+			keyDir := config.GetSecretSourcePath(backendstorage.TPMEncryptionVolumeName)
+			Expect(os.MkdirAll(keyDir, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(keyDir, backendstorage.TPMEncryptionSecretKey), []byte("passphrase"), 0600)).To(Succeed())
+
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.UID = "f3e7e4a1-6f6e-4c8f-9d3b-2f5e0c1a7b9d"
+			vmi.Spec.Domain.Devices.TPM = &v1.TPMDevice{
+				Persistent: pointer.Bool(true),
+				Encryption: &v1.TPMStateEncryption{SecretRef: &k8sv1.LocalObjectReference{Name: "tpm-key"}},
+			}
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(nil, libvirt.Error{Code: libvirt.ERR_NO_DOMAIN})
+
+			domainSpec := expectedDomainFor(vmi)
+			Expect(domainSpec.Devices.TPMs[0].Backend.Encryption).To(Equal(&api.TPMBackendEncryption{Secret: string(vmi.UID)}))
+
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).ToNot(HaveOccurred())
+			defineSecret := mockConn.EXPECT().DefineSecret(&api.SecretSpec{
+				Ephemeral: "yes",
+				Private:   "yes",
+				UUID:      string(vmi.UID),
+				Usage:     api.SecretUsage{Type: "vtpm", Name: testNamespace + "/" + testVmName},
+			}, []byte("passphrase")).Return(nil)
+			mockConn.EXPECT().DomainDefineXML(string(xml)).After(defineSecret).DoAndReturn(mockDomainWithFreeExpectation)
+			mockDomain.EXPECT().GetState().Return(libvirt.DOMAIN_SHUTDOWN, 1, nil)
+			mockDomain.EXPECT().CreateWithFlags(libvirt.DOMAIN_NONE).Return(nil)
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).MaxTimes(2).Return(string(xml), nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+			newspec, err := manager.SyncVMI(vmi, true, &cmdv1.VirtualMachineOptions{VirtualMachineSMBios: &cmdv1.SMBios{}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newspec).ToNot(BeNil())
+		})
 		It("should define and start a new VirtualMachineInstance with StartStrategy paused", func() {
 			vmi := newVMI(testNamespace, testVmName)
 			strategy := v1.StartStrategyPaused
@@ -687,6 +725,152 @@ var _ = Describe("Manager", func() {
 				return memoryDump.Failed
 			}, 5*time.Second).Should(BeTrue(), "failed memory dump result wasn't set")
 		})
+		Context("backup", func() {
+			const (
+				testBackupName = "backup-2"
+				testBackupDir  = "/path/to/backup"
+			)
+
+			newBackupVMI := func() *v1.VirtualMachineInstance {
+				vmi := newVMI(testNamespace, testVmName)
+				vmi.Spec.Volumes = []v1.Volume{
+					{
+						Name: "rootdisk",
+						VolumeSource: v1.VolumeSource{
+							ContainerDisk: &v1.ContainerDiskSource{Image: "image"},
+						},
+					},
+					{
+						Name: "pvcdisk",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+							},
+						},
+					},
+				}
+				return vmi
+			}
+
+			expectBackupDomainXML := func() {
+				domainSpec := api.NewMinimalDomainSpec(testDomainName)
+				domainSpec.Devices.Disks = []api.Disk{
+					{Device: "disk", Target: api.DiskTarget{Device: "vda"}, Alias: api.NewUserDefinedAlias("rootdisk")},
+					{Device: "disk", Target: api.DiskTarget{Device: "vdb"}, Alias: api.NewUserDefinedAlias("pvcdisk")},
+				}
+				domainXML, err := xml.MarshalIndent(domainSpec, "", "\t")
+				Expect(err).ToNot(HaveOccurred())
+				mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(domainXML), nil)
+			}
+
+			expectBackupBegin := func(backupMode string) {
+				mockDomain.EXPECT().BackupBegin(gomock.Any(), gomock.Any(), libvirt.DomainBackupBeginFlags(0)).DoAndReturn(
+					func(backupXML, checkpointXML string, _ libvirt.DomainBackupBeginFlags) error {
+						backup := &api.DomainBackup{}
+						Expect(xml.Unmarshal([]byte(backupXML), backup)).To(Succeed())
+						Expect(backup.Mode).To(Equal("push"))
+						Expect(backup.Disks.Disks).To(HaveLen(1))
+						Expect(backup.Disks.Disks[0].Name).To(Equal("vdb"))
+						Expect(backup.Disks.Disks[0].BackupMode).To(Equal(backupMode))
+						Expect(backup.Disks.Disks[0].Target.File).To(Equal(testBackupDir + "/" + testBackupName + "-pvcdisk.qcow2"))
+
+						checkpoint := &api.DomainCheckpoint{}
+						Expect(xml.Unmarshal([]byte(checkpointXML), checkpoint)).To(Succeed())
+						Expect(checkpoint.Name).To(Equal(testBackupName))
+						Expect(checkpoint.Disks.Disks).To(ConsistOf(api.DomainCheckpointDisk{Name: "vdb", Checkpoint: "bitmap"}))
+						return nil
+					})
+			}
+
+			It("should update domain with backup info when completed successfully", func() {
+				mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+				expectBackupDomainXML()
+				expectBackupBegin("full")
+				mockDomain.EXPECT().GetJobStats(libvirt.DomainGetJobStatsFlags(0)).Return(&libvirt.DomainJobInfo{Type: libvirt.DOMAIN_JOB_NONE}, nil)
+				mockDomain.EXPECT().GetJobStats(libvirt.DOMAIN_JOB_STATS_COMPLETED).Return(&libvirt.DomainJobInfo{Type: libvirt.DOMAIN_JOB_COMPLETED}, nil)
+				mockDomain.EXPECT().ListAllCheckpoints(libvirt.DomainCheckpointListFlags(0)).Return(nil, nil)
+
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				vmi := newBackupVMI()
+				options := &cmdclient.BackupOptions{Name: testBackupName, TargetDir: testBackupDir}
+				Expect(manager.BackupVirtualMachine(vmi, options)).To(Succeed())
+				// Expect extra call to backup not to impact
+				Expect(manager.BackupVirtualMachine(vmi, options)).To(Succeed())
+
+				Eventually(func() bool {
+					backup, _ := metadataCache.Backup.Load()
+					return backup.Completed
+				}, 5*time.Second, 2).Should(BeTrue())
+				backup, _ := metadataCache.Backup.Load()
+				Expect(backup.Failed).To(BeFalse())
+				Expect(backup.IncrementalFrom).To(BeEmpty())
+				Expect(backup.Disks.Disks).To(ConsistOf(api.BackupDiskMetadata{Name: "pvcdisk", FileName: testBackupName + "-pvcdisk.qcow2"}))
+			})
+
+			It("should fall back to a full backup if the base checkpoint does not exist", func() {
+				mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+				expectBackupDomainXML()
+				mockDomain.EXPECT().ListAllCheckpoints(libvirt.DomainCheckpointListFlags(0)).Times(2).Return(nil, nil)
+				expectBackupBegin("full")
+				mockDomain.EXPECT().GetJobStats(libvirt.DomainGetJobStatsFlags(0)).Return(&libvirt.DomainJobInfo{Type: libvirt.DOMAIN_JOB_NONE}, nil)
+				mockDomain.EXPECT().GetJobStats(libvirt.DOMAIN_JOB_STATS_COMPLETED).Return(&libvirt.DomainJobInfo{Type: libvirt.DOMAIN_JOB_COMPLETED}, nil)
+
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				options := &cmdclient.BackupOptions{Name: testBackupName, IncrementalFrom: "backup-1", TargetDir: testBackupDir}
+				Expect(manager.BackupVirtualMachine(newBackupVMI(), options)).To(Succeed())
+
+				Eventually(func() bool {
+					backup, _ := metadataCache.Backup.Load()
+					return backup.Completed
+				}, 5*time.Second, 2).Should(BeTrue())
+				backup, _ := metadataCache.Backup.Load()
+				Expect(backup.Failed).To(BeFalse())
+				Expect(backup.IncrementalFrom).To(BeEmpty())
+				Expect(backup.Disks.Disks).To(ConsistOf(api.BackupDiskMetadata{Name: "pvcdisk", FileName: testBackupName + "-pvcdisk.qcow2"}))
+			})
+
+			It("should update domain with backup info if the backup job failed", func() {
+				mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+				expectBackupDomainXML()
+				expectBackupBegin("full")
+				mockDomain.EXPECT().GetJobStats(libvirt.DomainGetJobStatsFlags(0)).Return(&libvirt.DomainJobInfo{Type: libvirt.DOMAIN_JOB_NONE}, nil)
+				mockDomain.EXPECT().GetJobStats(libvirt.DOMAIN_JOB_STATS_COMPLETED).Return(&libvirt.DomainJobInfo{Type: libvirt.DOMAIN_JOB_FAILED}, nil)
+
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				options := &cmdclient.BackupOptions{Name: testBackupName, TargetDir: testBackupDir}
+				Expect(manager.BackupVirtualMachine(newBackupVMI(), options)).To(Succeed())
+
+				Eventually(func() bool {
+					backup, _ := metadataCache.Backup.Load()
+					return backup.Failed
+				}, 5*time.Second).Should(BeTrue(), "failed backup result wasn't set")
+				backup, _ := metadataCache.Backup.Load()
+				Expect(backup.FailureReason).To(ContainSubstring("backup job failed"))
+			})
+
+			It("should abort the backup job if it does not complete in time", func() {
+				mockConn.EXPECT().LookupDomainByName(testDomainName).DoAndReturn(mockDomainWithFreeExpectation)
+				expectBackupDomainXML()
+				expectBackupBegin("full")
+				mockDomain.EXPECT().GetJobStats(libvirt.DomainGetJobStatsFlags(0)).Return(&libvirt.DomainJobInfo{Type: libvirt.DOMAIN_JOB_UNBOUNDED}, nil).MinTimes(2)
+				mockDomain.EXPECT().AbortJob().Return(nil)
+
+				manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+				options := &cmdclient.BackupOptions{Name: testBackupName, TargetDir: testBackupDir, TimeoutSeconds: 1}
+				Expect(manager.BackupVirtualMachine(newBackupVMI(), options)).To(Succeed())
+
+				Eventually(func() bool {
+					backup, _ := metadataCache.Backup.Load()
+					return backup.Failed
+				}, 5*time.Second).Should(BeTrue(), "failed backup result wasn't set")
+				backup, _ := metadataCache.Backup.Load()
+				Expect(backup.FailureReason).To(ContainSubstring("was aborted"))
+			})
+		})
 		It("should pause a VirtualMachineInstance", func() {
 			vmi := newVMI(testNamespace, testVmName)
 
@@ -1281,6 +1465,7 @@ var _ = Describe("Manager", func() {
 			sevNodeParameters := &api.SEVNodeParameters{
 				PDH:       "AAABBBCCC",
 				CertChain: "DDDEEEFFF",
+				ChipID:    "GGGHHHIII",
 			}
 
 			mockConn.EXPECT().GetSEVInfo().Return(sevNodeParameters, nil)
@@ -1290,6 +1475,7 @@ var _ = Describe("Manager", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(sevPlatfomrInfo.PDH).To(Equal(sevNodeParameters.PDH))
 			Expect(sevPlatfomrInfo.CertChain).To(Equal(sevNodeParameters.CertChain))
+			Expect(sevPlatfomrInfo.ChipID).To(Equal(sevNodeParameters.ChipID))
 		})
 
 		It("should return a VirtualMachineInstance launch measurement", func() {
@@ -1345,6 +1531,35 @@ var _ = Describe("Manager", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should update the I/O limits of attached disks", func() {
+			newDisk := func(device string, ioTune *api.DiskIOTune) api.Disk {
+				return api.Disk{
+					Target: api.DiskTarget{Bus: v1.DiskBusVirtio, Device: device},
+					Alias:  api.NewUserDefinedAlias(device),
+					IOTune: ioTune,
+				}
+			}
+			oldDisks := []api.Disk{
+				newDisk("vda", nil),
+				newDisk("vdb", &api.DiskIOTune{TotalIopsSec: 100}),
+				newDisk("vdc", &api.DiskIOTune{ReadBytesSec: 1024}),
+			}
+			newDisks := []api.Disk{
+				newDisk("vda", &api.DiskIOTune{TotalBytesSec: 1048576, TotalBytesSecMax: 2097152}),
+				newDisk("vdb", nil),
+				newDisk("vdc", &api.DiskIOTune{ReadBytesSec: 1024}),
+				newDisk("vdd", &api.DiskIOTune{WriteIopsSec: 10}),
+			}
+
+			expectedParams := blockIoTuneParameters(api.DiskIOTune{})
+			expectedParams.TotalBytesSec = 1048576
+			expectedParams.TotalBytesSecMax = 2097152
+			mockDomain.EXPECT().SetBlockIoTune("vda", expectedParams, affectDomainLiveAndConfigLibvirtFlags).Return(nil)
+			mockDomain.EXPECT().SetBlockIoTune("vdb", blockIoTuneParameters(api.DiskIOTune{}), affectDomainLiveAndConfigLibvirtFlags).Return(nil)
+
+			Expect(updateDiskIOTune(mockDomain, oldDisks, newDisks)).To(Succeed())
+		})
+
 		Context("Memory hotplug", func() {
 			var vmi *v1.VirtualMachineInstance
 			var manager *LibvirtDomainManager
@@ -1923,6 +2138,45 @@ var _ = Describe("Manager", func() {
 			copyDisks := getDiskTargetsForMigration(mockDomain, vmi)
 			Expect(copyDisks).Should(ConsistOf("vdb", "vdd"))
 		})
+		It("should copy the disks of volumes migrated to a new claim", func() {
+			_true := true
+			vmi := newVMI(testNamespace, testVmName)
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testblock",
+						}},
+					},
+				},
+				{
+					Name: "myvolumehost",
+					VolumeSource: v1.VolumeSource{
+						HostDisk: &v1.HostDisk{
+							Path:     "/var/run/kubevirt-private/vmi-disks/volume3/disk.img",
+							Type:     v1.HostDiskExistsOrCreate,
+							Capacity: resource.MustParse("1Gi"),
+							Shared:   &_true,
+						},
+					},
+				},
+			}
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigratedVolumes: []v1.StorageMigratedVolumeInfo{
+					{VolumeName: "myvolume", SourceClaimName: "testblock", DestinationClaimName: "testblock-dst"},
+				},
+			}
+			userData := "fake\nuser\ndata\n"
+			networkData := "FakeNetwork"
+			addCloudInitDisk(vmi, userData, networkData)
+
+			mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(embedMigrationDomain, nil)
+
+			copyDisks := getDiskTargetsForMigration(mockDomain, vmi)
+			Expect(copyDisks).Should(ConsistOf("vda", "vdb", "vdd"))
+			Expect(isBlockMigration(vmi)).To(BeTrue())
+		})
 		AfterEach(func() {
 			ip.GetLoopbackAddress = funcPreviousValue
 		})
@@ -1002,6 +1002,19 @@ var CRDsValidation map[string]string = map[string]string{
               description: PullPolicy describes a policy for if/when to pull a container
                 image
               type: string
+            instancetypeInferenceRegistries:
+              description: InstancetypeInferenceRegistries lists the registries, like
+                quay.io or registry.example.com:5000, which virt-controller may contact
+                to read the labels of containerDisk images when inferring the instancetype
+                or preference of a VirtualMachine. Inference from containerDisks of
+                other registries fails, it is disabled when the list is empty. The
+                registries are contacted over HTTPS directly from virt-controller,
+                which only trusts its system CA bundle. Registry mirrors and certificate
+                authorities configured on the nodes are not used.
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: atomic
             ksmConfiguration:
               description: KSMConfiguration holds the information regarding the enabling
                 the KSM in the nodes (if available).
@@ -1173,6 +1186,25 @@ var CRDsValidation map[string]string = map[string]string{
                     layer of live migration encryption provided by KubeVirt. This
                     is usually a bad idea. Defaults to false
                   type: boolean
+                evacuationOrder:
+                  description: EvacuationOrder defines in which order VirtualMachineInstances
+                    with the same evacuation priority are migrated away from a drained
+                    node. Defaults to Default, which keeps the order in which the
+                    VirtualMachineInstances are listed
+                  enum:
+                  - Default
+                  - SmallestMemoryFirst
+                  - LargestMemoryFirst
+                  type: string
+                evacuationTargetNodeSelector:
+                  additionalProperties:
+                    type: string
+                  description: EvacuationTargetNodeSelector restricts the nodes evacuated
+                    VirtualMachineInstances are migrated to. It is added to the node
+                    selector of every evacuation migration. VirtualMachineInstances whose
+                    node selector requires a different value for one of the keys are not
+                    evacuated and are reported in the NodeEvacuation status.
+                  type: object
                 matchSELinuxLevelOnMigration:
                   description: By default, the SELinux level of target virt-launcher
                     pods is forced to the level of the source virt-launcher. When
@@ -1243,6 +1275,14 @@ var CRDsValidation map[string]string = map[string]string{
                   type: boolean
                 permitSlirpInterface:
                   type: boolean
+                qosTrafficMark:
+                  description: QoSTrafficMark is the packet mark used in the pod network
+                    namespace to tell the traffic sent by a masqueraded guest apart
+                    from the traffic of the pod itself when the outbound QoS of the
+                    guest is shaped. It has to be changed if another component of
+                    the pod network uses the same mark. Defaults to 19286 (0x4b56).
+                  format: int32
+                  type: integer
               type: object
             obsoleteCPUModels:
               additionalProperties:
@@ -1476,11 +1516,38 @@ var CRDsValidation map[string]string = map[string]string{
                     if AutoattachSerialConsole is disabled.
                   type: object
               type: object
+            vmBackupTimeoutSeconds:
+              description: VMBackupTimeoutSeconds is the time in seconds a VirtualMachineBackup
+                may take before it is aborted and reported as failed. Defaults to
+                43200 (12 hours).
+              format: int64
+              type: integer
+            vmStateRetentionPolicy:
+              description: VMStateRetentionPolicy defines what happens to the PVCs
+                preserving VM state when the VirtualMachine is deleted. Delete removes
+                them together with the VirtualMachine, Retain keeps them around. Retained
+                PVCs are not reused by a new VirtualMachine with the same name, they
+                have to be deleted before it can start. Defaults to Delete.
+              enum:
+              - Delete
+              - Retain
+              type: string
             vmStateStorageClass:
               description: VMStateStorageClass is the name of the storage class to
                 use for the PVCs created to preserve VM state, like TPM. The storage
-                class must support RWX in filesystem mode.
+                class should support RWX in filesystem mode, VMs whose state is kept
+                on a RWO volume can not be live migrated.
               type: string
+            vmStateStorageSize:
+              anyOf:
+              - type: integer
+              - type: string
+              description: VMStateStorageSize is the size requested for the PVCs created
+                to preserve VM state. Existing PVCs are expanded when the size is raised
+                and their storage class allows volume expansion, they are never shrunk.
+                Defaults to 10Mi.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
             webhookConfiguration:
               description: ReloadableComponentConfiguration holds all generic k8s
                 configuration options which can be reloaded by components without
@@ -3587,6 +3654,231 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"nodeevacuation": `openAPIV3Schema:
+  description: NodeEvacuation reports the progress of evacuating the VirtualMachineInstances
+    from a node. It is maintained by the evacuation controller and has the same name
+    as the node.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    status:
+      nullable: true
+      properties:
+        completionTimestamp:
+          description: CompletionTimestamp is the time the last VirtualMachineInstance
+            left the node
+          format: date-time
+          nullable: true
+          type: string
+        evacuatedVMIs:
+          description: EvacuatedVMIs is the number of VirtualMachineInstances which
+            already left the node
+          type: integer
+        migratingVMIs:
+          description: MigratingVMIs is the number of VirtualMachineInstances with
+            an unfinished migration
+          type: integer
+        nodeSelectorConflictVMIs:
+          description: NodeSelectorConflictVMIs lists the VirtualMachineInstances
+            blocking the evacuation because their node selector conflicts with the
+            evacuation target node selector, in namespace/name format
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
+        nonMigratableVMIs:
+          description: NonMigratableVMIs lists the VirtualMachineInstances blocking
+            the evacuation because they can not be live migrated, in namespace/name
+            format
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
+        pendingVMIs:
+          description: PendingVMIs is the number of VirtualMachineInstances waiting
+            for a migration slot
+          type: integer
+        phase:
+          description: NodeEvacuationPhase is the phase of a node evacuation
+          type: string
+        startTimestamp:
+          description: StartTimestamp is the time the evacuation of the node was first
+            observed
+          format: date-time
+          nullable: true
+          type: string
+        totalVMIs:
+          description: TotalVMIs is the number of VirtualMachineInstances which had
+            to be evacuated
+          type: integer
+      type: object
+  type: object
+`,
+	"nodememorypolicy": `openAPIV3Schema:
+  description: NodeMemoryPolicy describes the memory density profile of a pool of
+    nodes. virt-handler applies the policy on every node matched by its node selector.
+    When several policies match a node, the one with the alphabetically first name
+    is applied.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      properties:
+        balloon:
+          description: Balloon configures the memory balloon of the VirtualMachineInstances
+            started on the nodes.
+          properties:
+            statsPeriod:
+              description: StatsPeriod is the interval in seconds in which the balloon
+                driver reports the guest memory statistics. 0 disables the statistics.
+                If not set, the cluster wide setting is used.
+              format: int32
+              type: integer
+            targetGuestMemoryPercent:
+              description: TargetGuestMemoryPercent is the share, in percent, of the
+                guest memory the VirtualMachineInstances started on the nodes boot
+                with. The memory balloon holds back the remainder, which lets the
+                nodes run more guests, and deflates once a guest runs out of memory.
+                Only VirtualMachineInstances with the kubevirt.io/memory-balloon-target-enabled
+                annotation set to "true" are affected. If not set, the balloon is
+                not inflated. VirtualMachineInstances without a memory balloon, with
+                hugepages or with memory hotplug are not affected.
+              format: int32
+              maximum: 100
+              minimum: 10
+              type: integer
+          type: object
+        freePageReporting:
+          description: FreePageReporting enables or disables free page reporting for
+            the VirtualMachineInstances started on the nodes. If not set, the cluster
+            wide setting is used.
+          type: boolean
+        ksm:
+          description: KSM configures Kernel Samepage Merging on the nodes. If not
+            set, the KSMConfiguration of the KubeVirt CR and the KSM node annotations
+            are used.
+          properties:
+            enabled:
+              description: Enabled lets virt-handler manage KSM on the nodes. When
+                set to false, KSM is disabled if it was enabled by virt-handler.
+              type: boolean
+            freePercent:
+              description: FreePercent is the percentage of available memory below
+                which the node is considered to be under memory pressure
+              format: int32
+              maximum: 100
+              minimum: 0
+              type: integer
+            pagesBoost:
+              description: PagesBoost is the number of pages added to pages_to_scan
+                on every heartbeat under memory pressure
+              format: int32
+              minimum: 0
+              type: integer
+            pagesDecay:
+              description: PagesDecay is the number of pages removed from pages_to_scan
+                on every heartbeat without memory pressure
+              format: int32
+              minimum: 0
+              type: integer
+            pagesInit:
+              description: PagesInit is the value of pages_to_scan when KSM gets started
+              format: int32
+              minimum: 0
+              type: integer
+            pagesMax:
+              description: PagesMax is the upper bound of pages_to_scan
+              format: int32
+              minimum: 0
+              type: integer
+            pagesMin:
+              description: PagesMin is the lower bound of pages_to_scan
+              format: int32
+              minimum: 0
+              type: integer
+            sleepMsBaseline:
+              description: SleepMsBaseline is the sleep_millisecs of a 16GiB node
+                under memory pressure, it is scaled down on nodes with more memory
+              format: int32
+              minimum: 1
+              type: integer
+          required:
+          - enabled
+          type: object
+        maxMemoryOvercommitPercent:
+          description: MaxMemoryOvercommitPercent is the maximum ratio, in percent,
+            of the guest memory of the VirtualMachineInstances on a node to the allocatable
+            memory of the node. Once it is reached the node is marked as unschedulable
+            for new VirtualMachineInstances. 100 means no overcommit. If not set,
+            no limit is enforced.
+          format: int32
+          minimum: 10
+          type: integer
+        nodeSelector:
+          description: NodeSelector selects the nodes the policy applies to. If not
+            set, the policy applies to all nodes.
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements.
+                The requirements are ANDed.
+              items:
+                description: A label selector requirement is a selector that contains
+                  values, a key, and an operator that relates the key and values.
+                properties:
+                  key:
+                    description: key is the label key that the selector applies to.
+                    type: string
+                  operator:
+                    description: operator represents a key's relationship to a set
+                      of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                    type: string
+                  values:
+                    description: values is an array of string values. If the operator
+                      is In or NotIn, the values array must be non-empty. If the operator
+                      is Exists or DoesNotExist, the values array must be empty. This
+                      array is replaced during a strategic merge patch.
+                    items:
+                      type: string
+                    type: array
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: matchLabels is a map of {key,value} pairs. A single {key,value}
+                in the matchLabels map is equivalent to an element of matchExpressions,
+                whose key field is "key", the operator is "In", and the values array
+                contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+      type: object
+  required:
+  - spec
+  type: object
 `,
 	"virtualmachine": `openAPIV3Schema:
   description: VirtualMachine handles the VirtualMachines that are not running or
@@ -4340,6 +4632,10 @@ var CRDsValidation map[string]string = map[string]string{
                   format: int32
                   type: integer
               type: object
+            ioTune:
+              description: IOTune allows live updating the I/O limits of the virtual
+                machines disks
+              type: object
             memory:
               description: MemoryLiveUpdateConfiguration defines the live update memory
                 features for the VirtualMachine
@@ -5731,6 +6027,109 @@ var CRDsValidation map[string]string = map[string]string{
                                   should be used. Supported values are: native, default,
                                   threads.'
                                 type: string
+                              ioTune:
+                                description: IOTune limits the I/O of the disk. The
+                                  limits can be changed on a running VirtualMachine
+                                  when its liveUpdateFeatures contain ioTune.
+                                properties:
+                                  read:
+                                    description: Read limits the read operations.
+                                    properties:
+                                      burstBytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BurstBytesPerSecond allows to
+                                          exceed the throughput limit up to this value
+                                          for short periods. Requires bytesPerSecond.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burstIOPS:
+                                        description: BurstIOPS allows to exceed the
+                                          operations limit up to this value for short
+                                          periods. Requires iops.
+                                        format: int64
+                                        type: integer
+                                      bytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BytesPerSecond limits the throughput
+                                          in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      iops:
+                                        description: IOPS limits the operations per
+                                          second.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                  total:
+                                    description: Total limits the sum of read and
+                                      write operations.
+                                    properties:
+                                      burstBytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BurstBytesPerSecond allows to
+                                          exceed the throughput limit up to this value
+                                          for short periods. Requires bytesPerSecond.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burstIOPS:
+                                        description: BurstIOPS allows to exceed the
+                                          operations limit up to this value for short
+                                          periods. Requires iops.
+                                        format: int64
+                                        type: integer
+                                      bytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BytesPerSecond limits the throughput
+                                          in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      iops:
+                                        description: IOPS limits the operations per
+                                          second.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                  write:
+                                    description: Write limits the write operations.
+                                    properties:
+                                      burstBytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BurstBytesPerSecond allows to
+                                          exceed the throughput limit up to this value
+                                          for short periods. Requires bytesPerSecond.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burstIOPS:
+                                        description: BurstIOPS allows to exceed the
+                                          operations limit up to this value for short
+                                          periods. Requires iops.
+                                        format: int64
+                                        type: integer
+                                      bytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BytesPerSecond limits the throughput
+                                          in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      iops:
+                                        description: IOPS limits the operations per
+                                          second.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -5916,6 +6315,27 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: If specified will pass option 67
                                       to interface's DHCP server
                                     type: string
+                                  dnsServers:
+                                    description: If specified will replace the DNS
+                                      servers of the pod network. IPv4 servers are
+                                      passed to the VM via DHCP option 6, IPv6 servers
+                                      via DHCPv6 option 23.
+                                    items:
+                                      type: string
+                                    type: array
+                                  domainName:
+                                    description: If specified will pass the domain
+                                      name to the VM via DHCP option 15. Defaults
+                                      to the domain name derived from the search domains.
+                                    type: string
+                                  mtu:
+                                    description: If specified will pass the MTU to
+                                      the VM via DHCP option 26 instead of the MTU
+                                      of the pod network. It cannot exceed the MTU
+                                      of the pod network, larger values are lowered
+                                      to it.
+                                    format: int32
+                                    type: integer
                                   ntpServers:
                                     description: If specified will pass the configured
                                       NTP server to the VM via DHCP option 042.
@@ -5942,11 +6362,123 @@ var CRDsValidation map[string]string = map[string]string{
                                       - value
                                       type: object
                                     type: array
+                                  routes:
+                                    description: If specified will pass the configured
+                                      static routes to the VM via DHCP option 121,
+                                      in addition to the routes of the pod network.
+                                      Only IPv4 routes are supported.
+                                    items:
+                                      description: DHCPRoute is a static route which
+                                        is passed to the VM via DHCP.
+                                      properties:
+                                        destination:
+                                          description: Destination is the destination
+                                            network of the route in CIDR notation,
+                                            e.g. 10.10.0.0/16
+                                          type: string
+                                        gateway:
+                                          description: Gateway is the IPv4 address
+                                            of the next hop.
+                                          type: string
+                                      required:
+                                      - destination
+                                      - gateway
+                                      type: object
+                                    type: array
+                                  searchDomains:
+                                    description: If specified will replace the DNS
+                                      search domains of the pod network. They are
+                                      passed to the VM via DHCP option 119 and DHCPv6
+                                      option 24.
+                                    items:
+                                      type: string
+                                    type: array
                                   tftpServerName:
                                     description: If specified will pass option 66
                                       to interface's DHCP server
                                     type: string
                                 type: object
+                              firewall:
+                                description: Firewall filters the traffic of the interface
+                                  inside the virt-launcher pod. Supported only with
+                                  the masquerade and passt bindings.
+                                properties:
+                                  egress:
+                                    description: Egress rules are applied to the connections
+                                      initiated by the guest.
+                                    items:
+                                      description: FirewallRule matches traffic by
+                                        its remote network, protocol and port. Fields
+                                        which are not set match any traffic.
+                                      properties:
+                                        action:
+                                          description: Action is taken on the traffic
+                                            which matches the rule.
+                                          type: string
+                                        cidr:
+                                          description: CIDR is the remote network,
+                                            the source of ingress and the destination
+                                            of egress traffic.
+                                          type: string
+                                        endPort:
+                                          description: EndPort makes the rule match
+                                            the range of ports from Port to EndPort.
+                                          format: int32
+                                          type: integer
+                                        port:
+                                          description: Port is the destination port,
+                                            only valid with the TCP, UDP and SCTP
+                                            protocols.
+                                          format: int32
+                                          type: integer
+                                        protocol:
+                                          description: Protocol is one of TCP, UDP,
+                                            SCTP or ICMP.
+                                          type: string
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                  ingress:
+                                    description: Ingress rules are applied to the
+                                      connections initiated towards the guest. With
+                                      the passt binding they only apply to the ports
+                                      listed in the Ports of the interface, which
+                                      have to be set.
+                                    items:
+                                      description: FirewallRule matches traffic by
+                                        its remote network, protocol and port. Fields
+                                        which are not set match any traffic.
+                                      properties:
+                                        action:
+                                          description: Action is taken on the traffic
+                                            which matches the rule.
+                                          type: string
+                                        cidr:
+                                          description: CIDR is the remote network,
+                                            the source of ingress and the destination
+                                            of egress traffic.
+                                          type: string
+                                        endPort:
+                                          description: EndPort makes the rule match
+                                            the range of ports from Port to EndPort.
+                                          format: int32
+                                          type: integer
+                                        port:
+                                          description: Port is the destination port,
+                                            only valid with the TCP, UDP and SCTP
+                                            protocols.
+                                          format: int32
+                                          type: integer
+                                        protocol:
+                                          description: Protocol is one of TCP, UDP,
+                                            SCTP or ICMP.
+                                          type: string
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                type: object
                               macAddress:
                                 description: 'Interface MAC address. For example:
                                   de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
@@ -6007,21 +6539,91 @@ var CRDsValidation map[string]string = map[string]string{
                                   - port
                                   type: object
                                 type: array
-                              slirp:
-                                description: InterfaceSlirp connects to a given network
-                                  using QEMU user networking mode.
-                                type: object
-                              sriov:
-                                description: InterfaceSRIOV connects to a given network
-                                  by passing-through an SR-IOV PCI device via vfio.
-                                type: object
-                              state:
-                                description: State represents the requested operational
-                                  state of the interface. The (only) value supported
-                                  is 'absent', expressing a request to remove the
-                                  interface.
-                                type: string
-                              tag:
+                              qos:
+                                description: QoS limits the bandwidth of the interface.
+                                  Supported only with the bridge and masquerade
+                                  bindings.
+                                properties:
+                                  inbound:
+                                    description: Inbound limits the traffic received
+                                      by the guest.
+                                    properties:
+                                      average:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Average is the rate the traffic
+                                          is shaped to, in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes
+                                          which can be sent at the peak rate.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      peak:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Peak is the maximum rate at which
+                                          bursts are sent, in bytes per second. Must
+                                          not be lower than the average.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - average
+                                    type: object
+                                  outbound:
+                                    description: Outbound limits the traffic sent
+                                      by the guest.
+                                    properties:
+                                      average:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Average is the rate the traffic
+                                          is shaped to, in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes
+                                          which can be sent at the peak rate.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      peak:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Peak is the maximum rate at which
+                                          bursts are sent, in bytes per second. Must
+                                          not be lower than the average.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - average
+                                    type: object
+                                type: object
+                              slirp:
+                                description: InterfaceSlirp connects to a given network
+                                  using QEMU user networking mode.
+                                type: object
+                              sriov:
+                                description: InterfaceSRIOV connects to a given network
+                                  by passing-through an SR-IOV PCI device via vfio.
+                                type: object
+                              state:
+                                description: State represents the requested operational
+                                  state of the interface. The (only) value supported
+                                  is 'absent', expressing a request to remove the
+                                  interface.
+                                type: string
+                              tag:
                                 description: If specified, the virtual network interface
                                   address and its tag will be provided to the guest
                                   via config drive
@@ -6065,6 +6667,24 @@ var CRDsValidation map[string]string = map[string]string{
                         tpm:
                           description: Whether to emulate a TPM device.
                           properties:
+                            encryption:
+                              description: Encryption configures the key used to encrypt
+                                the persisted TPM state. Requires Persistent to be
+                                set to true.
+                              properties:
+                                secretRef:
+                                  description: SecretRef references a Secret in the
+                                    namespace of the VMI. The value of its "key" entry
+                                    is used as the passphrase encrypting the TPM state.
+                                  properties:
+                                    name:
+                                      description: 'Name of the referent. More info:
+                                        https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                        TODO: Add other useful fields. apiVersion,
+                                        kind, uid?'
+                                      type: string
+                                  type: object
+                              type: object
                             persistent:
                               description: Persistent indicates the state of the TPM
                                 device should be kept accross reboots Defaults to
@@ -6429,6 +7049,27 @@ var CRDsValidation map[string]string = map[string]string{
                               description: Base64 encoded session blob.
                               type: string
                           type: object
+                        snp:
+                          description: AMD Secure Encrypted Virtualization with Secure
+                            Nested Paging (SEV-SNP).
+                          properties:
+                            policy:
+                              description: 'Guest policy flags as defined in the AMD
+                                SEV-SNP firmware ABI specification. Note: due to security
+                                reasons it is not allowed to enable guest debugging.
+                                Therefore the Debug flag is not exposed to users and
+                                is always false.'
+                              properties:
+                                smt:
+                                  description: Allow the guest to run on hosts with
+                                    simultaneous multithreading (SMT) enabled. Defaults
+                                    to true.
+                                  type: boolean
+                              type: object
+                          type: object
+                        tdx:
+                          description: Intel Trust Domain Extensions (TDX).
+                          type: object
                       type: object
                     machine:
                       description: Machine type.
@@ -7064,6 +7705,38 @@ var CRDsValidation map[string]string = map[string]string{
                   items:
                     description: Volume represents a named volume in a vmi.
                     properties:
+                      backup:
+                        description: Backup is attached to the virt launcher and is
+                          populated with a backup of the vmi disks
+                        properties:
+                          backupName:
+                            description: BackupName is the name of the backup, it
+                              is also used as the name of the checkpoint created along
+                              with the backup
+                            type: string
+                          claimName:
+                            description: 'claimName is the name of a PersistentVolumeClaim
+                              in the same namespace as the pod using this volume.
+                              More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                            type: string
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          incrementalFrom:
+                            description: IncrementalFrom is the name of the checkpoint
+                              the backup only contains the changes since, a full backup
+                              is taken if it is empty or the checkpoint does not exist
+                              anymore
+                            type: string
+                          readOnly:
+                            description: readOnly Will force the ReadOnly setting
+                              in VolumeMounts. Default false.
+                            type: boolean
+                        required:
+                        - backupName
+                        - claimName
+                        type: object
                       cloudInitConfigDrive:
                         description: 'CloudInitConfigDrive represents a cloud-init
                           Config Drive user-data source. The Config Drive data will
@@ -7704,6 +8377,105 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'IO specifies which QEMU disk IO mode should
                           be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the I/O of the disk. The limits
+                          can be changed on a running VirtualMachine when its liveUpdateFeatures
+                          contain ioTune.
+                        properties:
+                          read:
+                            description: Read limits the read operations.
+                            properties:
+                              burstBytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BurstBytesPerSecond allows to exceed
+                                  the throughput limit up to this value for short
+                                  periods. Requires bytesPerSecond.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burstIOPS:
+                                description: BurstIOPS allows to exceed the operations
+                                  limit up to this value for short periods. Requires
+                                  iops.
+                                format: int64
+                                type: integer
+                              bytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BytesPerSecond limits the throughput
+                                  in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              iops:
+                                description: IOPS limits the operations per second.
+                                format: int64
+                                type: integer
+                            type: object
+                          total:
+                            description: Total limits the sum of read and write operations.
+                            properties:
+                              burstBytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BurstBytesPerSecond allows to exceed
+                                  the throughput limit up to this value for short
+                                  periods. Requires bytesPerSecond.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burstIOPS:
+                                description: BurstIOPS allows to exceed the operations
+                                  limit up to this value for short periods. Requires
+                                  iops.
+                                format: int64
+                                type: integer
+                              bytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BytesPerSecond limits the throughput
+                                  in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              iops:
+                                description: IOPS limits the operations per second.
+                                format: int64
+                                type: integer
+                            type: object
+                          write:
+                            description: Write limits the write operations.
+                            properties:
+                              burstBytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BurstBytesPerSecond allows to exceed
+                                  the throughput limit up to this value for short
+                                  periods. Requires bytesPerSecond.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burstIOPS:
+                                description: BurstIOPS allows to exceed the operations
+                                  limit up to this value for short periods. Requires
+                                  iops.
+                                format: int64
+                                type: integer
+                              bytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BytesPerSecond limits the throughput
+                                  in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              iops:
+                                description: IOPS limits the operations per second.
+                                format: int64
+                                type: integer
+                            type: object
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -7848,6 +8620,156 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachinebackup": `openAPIV3Schema:
+  description: VirtualMachineBackup defines the operation of backing up the disks
+    of a running VM. Changed block tracking allows to only back up the blocks changed
+    since the previous backup.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineBackupSpec is the spec for a VirtualMachineBackup
+        resource
+      properties:
+        pvcName:
+          description: PVCName is the name of a filesystem mode PersistentVolumeClaim
+            in the namespace of the backup, every disk is written to it as a qcow2
+            file
+          type: string
+        source:
+          description: Source is the VirtualMachine to back up, it has to be running
+          properties:
+            apiGroup:
+              description: APIGroup is the group for the resource being referenced.
+                If APIGroup is not specified, the specified Kind must be in the core
+                API group. For any other third-party types, APIGroup is required.
+              type: string
+            kind:
+              description: Kind is the type of resource being referenced
+              type: string
+            name:
+              description: Name is the name of resource being referenced
+              type: string
+          required:
+          - kind
+          - name
+          type: object
+        type:
+          description: Type of the backup, an Incremental backup only contains the
+            blocks changed since the last successful backup of the source. It falls
+            back to a Full backup if there is none. Defaults to Full
+          type: string
+      required:
+      - pvcName
+      - source
+      type: object
+    status:
+      description: VirtualMachineBackupStatus is the status for a VirtualMachineBackup
+        resource
+      properties:
+        baseCheckpointName:
+          description: BaseCheckpointName is the checkpoint an incremental backup
+            was taken relative to
+          type: string
+        checkpointName:
+          description: CheckpointName is the name of the checkpoint created with the
+            backup, a later incremental backup of the source contains the changes
+            since this checkpoint
+          type: string
+        completionTime:
+          format: date-time
+          nullable: true
+          type: string
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for Conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        disks:
+          description: Disks lists the files in the target PVC the disks were backed
+            up to
+          items:
+            description: BackupDisk is the backup of a single disk
+            properties:
+              fileName:
+                description: FileName is the name of the qcow2 file in the target
+                  PVC
+                type: string
+              incremental:
+                description: Incremental is true if the file only contains the blocks
+                  changed since the base checkpoint, the file of the previous backup
+                  of the disk has to be used as its backing file
+                type: boolean
+              volumeName:
+                description: VolumeName is the name of the backed up volume of the
+                  VM
+                type: string
+            required:
+            - fileName
+            - volumeName
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        error:
+          description: Error is the last error encountered during the snapshot/restore
+          properties:
+            message:
+              type: string
+            time:
+              format: date-time
+              type: string
+          type: object
+        phase:
+          description: VirtualMachineBackupPhase is the current phase of the VirtualMachineBackup
+          type: string
+        sourceUID:
+          description: UID is a type that holds unique ID values, including UUIDs.  Because
+            we don't ONLY use UUIDs, this is an alias to string.  Being a type captures
+            intent and helps make sure that UIDs and names do not get conflated.
+          type: string
+        startTime:
+          format: date-time
+          nullable: true
+          type: string
+        type:
+          description: Type is the type of the backup which was taken
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
 `,
 	"virtualmachineclone": `openAPIV3Schema:
   description: VirtualMachineClone is a CRD that clones one VM into another.
@@ -8147,6 +9069,25 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Base64 encoded session blob.
                   type: string
               type: object
+            snp:
+              description: AMD Secure Encrypted Virtualization with Secure Nested
+                Paging (SEV-SNP).
+              properties:
+                policy:
+                  description: 'Guest policy flags as defined in the AMD SEV-SNP firmware
+                    ABI specification. Note: due to security reasons it is not allowed
+                    to enable guest debugging. Therefore the Debug flag is not exposed
+                    to users and is always false.'
+                  properties:
+                    smt:
+                      description: Allow the guest to run on hosts with simultaneous
+                        multithreading (SMT) enabled. Defaults to true.
+                      type: boolean
+                  type: object
+              type: object
+            tdx:
+              description: Intel Trust Domain Extensions (TDX).
+              type: object
           type: object
         memory:
           description: Required Memory related attributes of the instancetype.
@@ -8465,6 +9406,21 @@ var CRDsValidation map[string]string = map[string]string{
               description: PreferredTPM optionally defines the preferred TPM device
                 to be used.
               properties:
+                encryption:
+                  description: Encryption configures the key used to encrypt the persisted
+                    TPM state. Requires Persistent to be set to true.
+                  properties:
+                    secretRef:
+                      description: SecretRef references a Secret in the namespace
+                        of the VMI. The value of its "key" entry is used as the passphrase
+                        encrypting the TPM state.
+                      properties:
+                        name:
+                          description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                            TODO: Add other useful fields. apiVersion, kind, uid?'
+                          type: string
+                      type: object
+                  type: object
                 persistent:
                   description: Persistent indicates the state of the TPM device should
                     be kept accross reboots Defaults to false
@@ -8867,6 +9823,29 @@ var CRDsValidation map[string]string = map[string]string{
               description: VirtualMachineExportLink contains a list of volumes available
                 for export, as well as the URLs to obtain these volumes
               properties:
+                bundles:
+                  description: Bundles is a list of available bundles containing the
+                    VM definition and all of its volumes
+                  items:
+                    description: VirtualMachineExportBundle contains the format and
+                      URL of a bundle of the exported VM
+                    properties:
+                      format:
+                        description: Format is the format of the bundle at the specified
+                          URL
+                        type: string
+                      url:
+                        description: Url is the url of the endpoint that returns the
+                          bundle
+                        type: string
+                    required:
+                    - format
+                    - url
+                    type: object
+                  type: array
+                  x-kubernetes-list-map-keys:
+                  - format
+                  x-kubernetes-list-type: map
                 cert:
                   description: Cert is the public CA certificate base64 encoded
                   type: string
@@ -8898,6 +9877,32 @@ var CRDsValidation map[string]string = map[string]string{
                     description: VirtualMachineExportVolume contains the name and
                       available formats for the exported volume
                     properties:
+                      checksums:
+                        description: Checksums contains the URLs of the checksums
+                          of the volume content
+                        items:
+                          description: VirtualMachineExportVolumeChecksum contains
+                            the algorithm and the URL to get the checksum of the exported
+                            volume
+                          properties:
+                            algorithm:
+                              description: Algorithm is the algorithm used to calculate
+                                the checksum
+                              type: string
+                            url:
+                              description: Url is the url that returns the checksum
+                                in the format of the sha256sum utility, the checksum
+                                is calculated on the first request, which can take
+                                a while on big volumes
+                              type: string
+                          required:
+                          - algorithm
+                          - url
+                          type: object
+                        type: array
+                        x-kubernetes-list-map-keys:
+                        - algorithm
+                        x-kubernetes-list-type: map
                       formats:
                         items:
                           description: VirtualMachineExportVolumeFormat contains the
@@ -8936,6 +9941,29 @@ var CRDsValidation map[string]string = map[string]string{
               description: VirtualMachineExportLink contains a list of volumes available
                 for export, as well as the URLs to obtain these volumes
               properties:
+                bundles:
+                  description: Bundles is a list of available bundles containing the
+                    VM definition and all of its volumes
+                  items:
+                    description: VirtualMachineExportBundle contains the format and
+                      URL of a bundle of the exported VM
+                    properties:
+                      format:
+                        description: Format is the format of the bundle at the specified
+                          URL
+                        type: string
+                      url:
+                        description: Url is the url of the endpoint that returns the
+                          bundle
+                        type: string
+                    required:
+                    - format
+                    - url
+                    type: object
+                  type: array
+                  x-kubernetes-list-map-keys:
+                  - format
+                  x-kubernetes-list-type: map
                 cert:
                   description: Cert is the public CA certificate base64 encoded
                   type: string
@@ -8967,6 +9995,32 @@ var CRDsValidation map[string]string = map[string]string{
                     description: VirtualMachineExportVolume contains the name and
                       available formats for the exported volume
                     properties:
+                      checksums:
+                        description: Checksums contains the URLs of the checksums
+                          of the volume content
+                        items:
+                          description: VirtualMachineExportVolumeChecksum contains
+                            the algorithm and the URL to get the checksum of the exported
+                            volume
+                          properties:
+                            algorithm:
+                              description: Algorithm is the algorithm used to calculate
+                                the checksum
+                              type: string
+                            url:
+                              description: Url is the url that returns the checksum
+                                in the format of the sha256sum utility, the checksum
+                                is calculated on the first request, which can take
+                                a while on big volumes
+                              type: string
+                          required:
+                          - algorithm
+                          - url
+                          type: object
+                        type: array
+                        x-kubernetes-list-map-keys:
+                        - algorithm
+                        x-kubernetes-list-type: map
                       formats:
                         items:
                           description: VirtualMachineExportVolumeFormat contains the
@@ -10282,6 +11336,105 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'IO specifies which QEMU disk IO mode should
                           be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the I/O of the disk. The limits
+                          can be changed on a running VirtualMachine when its liveUpdateFeatures
+                          contain ioTune.
+                        properties:
+                          read:
+                            description: Read limits the read operations.
+                            properties:
+                              burstBytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BurstBytesPerSecond allows to exceed
+                                  the throughput limit up to this value for short
+                                  periods. Requires bytesPerSecond.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burstIOPS:
+                                description: BurstIOPS allows to exceed the operations
+                                  limit up to this value for short periods. Requires
+                                  iops.
+                                format: int64
+                                type: integer
+                              bytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BytesPerSecond limits the throughput
+                                  in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              iops:
+                                description: IOPS limits the operations per second.
+                                format: int64
+                                type: integer
+                            type: object
+                          total:
+                            description: Total limits the sum of read and write operations.
+                            properties:
+                              burstBytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BurstBytesPerSecond allows to exceed
+                                  the throughput limit up to this value for short
+                                  periods. Requires bytesPerSecond.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burstIOPS:
+                                description: BurstIOPS allows to exceed the operations
+                                  limit up to this value for short periods. Requires
+                                  iops.
+                                format: int64
+                                type: integer
+                              bytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BytesPerSecond limits the throughput
+                                  in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              iops:
+                                description: IOPS limits the operations per second.
+                                format: int64
+                                type: integer
+                            type: object
+                          write:
+                            description: Write limits the write operations.
+                            properties:
+                              burstBytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BurstBytesPerSecond allows to exceed
+                                  the throughput limit up to this value for short
+                                  periods. Requires bytesPerSecond.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burstIOPS:
+                                description: BurstIOPS allows to exceed the operations
+                                  limit up to this value for short periods. Requires
+                                  iops.
+                                format: int64
+                                type: integer
+                              bytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BytesPerSecond limits the throughput
+                                  in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              iops:
+                                description: IOPS limits the operations per second.
+                                format: int64
+                                type: integer
+                            type: object
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -10459,6 +11612,25 @@ var CRDsValidation map[string]string = map[string]string{
                             description: If specified will pass option 67 to interface's
                               DHCP server
                             type: string
+                          dnsServers:
+                            description: If specified will replace the DNS servers
+                              of the pod network. IPv4 servers are passed to the VM
+                              via DHCP option 6, IPv6 servers via DHCPv6 option 23.
+                            items:
+                              type: string
+                            type: array
+                          domainName:
+                            description: If specified will pass the domain name to
+                              the VM via DHCP option 15. Defaults to the domain name
+                              derived from the search domains.
+                            type: string
+                          mtu:
+                            description: If specified will pass the MTU to the VM
+                              via DHCP option 26 instead of the MTU of the pod network.
+                              It cannot exceed the MTU of the pod network, larger
+                              values are lowered to it.
+                            format: int32
+                            type: integer
                           ntpServers:
                             description: If specified will pass the configured NTP
                               server to the VM via DHCP option 042.
@@ -10485,11 +11657,116 @@ var CRDsValidation map[string]string = map[string]string{
                               - value
                               type: object
                             type: array
+                          routes:
+                            description: If specified will pass the configured static
+                              routes to the VM via DHCP option 121, in addition to
+                              the routes of the pod network. Only IPv4 routes are
+                              supported.
+                            items:
+                              description: DHCPRoute is a static route which is passed
+                                to the VM via DHCP.
+                              properties:
+                                destination:
+                                  description: Destination is the destination network
+                                    of the route in CIDR notation, e.g. 10.10.0.0/16
+                                  type: string
+                                gateway:
+                                  description: Gateway is the IPv4 address of the
+                                    next hop.
+                                  type: string
+                              required:
+                              - destination
+                              - gateway
+                              type: object
+                            type: array
+                          searchDomains:
+                            description: If specified will replace the DNS search
+                              domains of the pod network. They are passed to the VM
+                              via DHCP option 119 and DHCPv6 option 24.
+                            items:
+                              type: string
+                            type: array
                           tftpServerName:
                             description: If specified will pass option 66 to interface's
                               DHCP server
                             type: string
                         type: object
+                      firewall:
+                        description: Firewall filters the traffic of the interface
+                          inside the virt-launcher pod. Supported only with the masquerade
+                          and passt bindings.
+                        properties:
+                          egress:
+                            description: Egress rules are applied to the connections
+                              initiated by the guest.
+                            items:
+                              description: FirewallRule matches traffic by its remote
+                                network, protocol and port. Fields which are not set
+                                match any traffic.
+                              properties:
+                                action:
+                                  description: Action is taken on the traffic which
+                                    matches the rule.
+                                  type: string
+                                cidr:
+                                  description: CIDR is the remote network, the source
+                                    of ingress and the destination of egress traffic.
+                                  type: string
+                                endPort:
+                                  description: EndPort makes the rule match the range
+                                    of ports from Port to EndPort.
+                                  format: int32
+                                  type: integer
+                                port:
+                                  description: Port is the destination port, only
+                                    valid with the TCP, UDP and SCTP protocols.
+                                  format: int32
+                                  type: integer
+                                protocol:
+                                  description: Protocol is one of TCP, UDP, SCTP or
+                                    ICMP.
+                                  type: string
+                              required:
+                              - action
+                              type: object
+                            type: array
+                          ingress:
+                            description: Ingress rules are applied to the connections
+                              initiated towards the guest. With the passt binding
+                              they only apply to the ports listed in the Ports of
+                              the interface, which have to be set.
+                            items:
+                              description: FirewallRule matches traffic by its remote
+                                network, protocol and port. Fields which are not set
+                                match any traffic.
+                              properties:
+                                action:
+                                  description: Action is taken on the traffic which
+                                    matches the rule.
+                                  type: string
+                                cidr:
+                                  description: CIDR is the remote network, the source
+                                    of ingress and the destination of egress traffic.
+                                  type: string
+                                endPort:
+                                  description: EndPort makes the rule match the range
+                                    of ports from Port to EndPort.
+                                  format: int32
+                                  type: integer
+                                port:
+                                  description: Port is the destination port, only
+                                    valid with the TCP, UDP and SCTP protocols.
+                                  format: int32
+                                  type: integer
+                                protocol:
+                                  description: Protocol is one of TCP, UDP, SCTP or
+                                    ICMP.
+                                  type: string
+                              required:
+                              - action
+                              type: object
+                            type: array
+                        type: object
                       macAddress:
                         description: 'Interface MAC address. For example: de:ad:00:00:be:af
                           or DE-AD-00-00-BE-AF.'
@@ -10547,6 +11824,74 @@ var CRDsValidation map[string]string = map[string]string{
                           - port
                           type: object
                         type: array
+                      qos:
+                        description: QoS limits the bandwidth of the interface. Supported
+                          only with the bridge and masquerade bindings.
+                        properties:
+                          inbound:
+                            description: Inbound limits the traffic received by the
+                              guest.
+                            properties:
+                              average:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Average is the rate the traffic is shaped
+                                  to, in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can
+                                  be sent at the peak rate.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              peak:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Peak is the maximum rate at which bursts
+                                  are sent, in bytes per second. Must not be lower
+                                  than the average.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - average
+                            type: object
+                          outbound:
+                            description: Outbound limits the traffic sent by the guest.
+                            properties:
+                              average:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Average is the rate the traffic is shaped
+                                  to, in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can
+                                  be sent at the peak rate.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              peak:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Peak is the maximum rate at which bursts
+                                  are sent, in bytes per second. Must not be lower
+                                  than the average.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - average
+                            type: object
+                        type: object
                       slirp:
                         description: InterfaceSlirp connects to a given network using
                           QEMU user networking mode.
@@ -10601,6 +11946,21 @@ var CRDsValidation map[string]string = map[string]string{
                 tpm:
                   description: Whether to emulate a TPM device.
                   properties:
+                    encryption:
+                      description: Encryption configures the key used to encrypt the
+                        persisted TPM state. Requires Persistent to be set to true.
+                      properties:
+                        secretRef:
+                          description: SecretRef references a Secret in the namespace
+                            of the VMI. The value of its "key" entry is used as the
+                            passphrase encrypting the TPM state.
+                          properties:
+                            name:
+                              description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                TODO: Add other useful fields. apiVersion, kind, uid?'
+                              type: string
+                          type: object
+                      type: object
                     persistent:
                       description: Persistent indicates the state of the TPM device
                         should be kept accross reboots Defaults to false
@@ -10933,6 +12293,25 @@ var CRDsValidation map[string]string = map[string]string{
                       description: Base64 encoded session blob.
                       type: string
                   type: object
+                snp:
+                  description: AMD Secure Encrypted Virtualization with Secure Nested
+                    Paging (SEV-SNP).
+                  properties:
+                    policy:
+                      description: 'Guest policy flags as defined in the AMD SEV-SNP
+                        firmware ABI specification. Note: due to security reasons
+                        it is not allowed to enable guest debugging. Therefore the
+                        Debug flag is not exposed to users and is always false.'
+                      properties:
+                        smt:
+                          description: Allow the guest to run on hosts with simultaneous
+                            multithreading (SMT) enabled. Defaults to true.
+                          type: boolean
+                      type: object
+                  type: object
+                tdx:
+                  description: Intel Trust Domain Extensions (TDX).
+                  type: object
               type: object
             machine:
               description: Machine type.
@@ -11533,6 +12912,36 @@ var CRDsValidation map[string]string = map[string]string{
           items:
             description: Volume represents a named volume in a vmi.
             properties:
+              backup:
+                description: Backup is attached to the virt launcher and is populated
+                  with a backup of the vmi disks
+                properties:
+                  backupName:
+                    description: BackupName is the name of the backup, it is also
+                      used as the name of the checkpoint created along with the backup
+                    type: string
+                  claimName:
+                    description: 'claimName is the name of a PersistentVolumeClaim
+                      in the same namespace as the pod using this volume. More info:
+                      https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                    type: string
+                  hotpluggable:
+                    description: Hotpluggable indicates whether the volume can be
+                      hotplugged and hotunplugged.
+                    type: boolean
+                  incrementalFrom:
+                    description: IncrementalFrom is the name of the checkpoint the
+                      backup only contains the changes since, a full backup is taken
+                      if it is empty or the checkpoint does not exist anymore
+                    type: string
+                  readOnly:
+                    description: readOnly Will force the ReadOnly setting in VolumeMounts.
+                      Default false.
+                    type: boolean
+                required:
+                - backupName
+                - claimName
+                type: object
               cloudInitConfigDrive:
                 description: 'CloudInitConfigDrive represents a cloud-init Config
                   Drive user-data source. The Config Drive data will be added as a
@@ -12117,6 +13526,32 @@ var CRDsValidation map[string]string = map[string]string{
             failed:
               description: Indicates that the migration failed
               type: boolean
+            migratedVolumes:
+              description: The list of volumes whose storage is copied to a new PersistentVolumeClaim
+                during the migration
+              items:
+                description: StorageMigratedVolumeInfo tracks the information about
+                  the source and destination claim of a migrated volume
+                properties:
+                  destinationClaimName:
+                    description: DestinationClaimName is the name of the PersistentVolumeClaim
+                      the volume is copied to
+                    type: string
+                  sourceClaimName:
+                    description: SourceClaimName is the name of the PersistentVolumeClaim
+                      the volume is copied from
+                    type: string
+                  volumeName:
+                    description: VolumeName is the name of the volume that is being
+                      migrated
+                    type: string
+                required:
+                - destinationClaimName
+                - sourceClaimName
+                - volumeName
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
             migrationConfiguration:
               description: Migration configurations to apply
               properties:
@@ -12155,6 +13590,25 @@ var CRDsValidation map[string]string = map[string]string{
                     layer of live migration encryption provided by KubeVirt. This
                     is usually a bad idea. Defaults to false
                   type: boolean
+                evacuationOrder:
+                  description: EvacuationOrder defines in which order VirtualMachineInstances
+                    with the same evacuation priority are migrated away from a drained
+                    node. Defaults to Default, which keeps the order in which the
+                    VirtualMachineInstances are listed
+                  enum:
+                  - Default
+                  - SmallestMemoryFirst
+                  - LargestMemoryFirst
+                  type: string
+                evacuationTargetNodeSelector:
+                  additionalProperties:
+                    type: string
+                  description: EvacuationTargetNodeSelector restricts the nodes evacuated
+                    VirtualMachineInstances are migrated to. It is added to the node
+                    selector of every evacuation migration. VirtualMachineInstances whose
+                    node selector requires a different value for one of the keys are not
+                    evacuated and are reported in the NodeEvacuation status.
+                  type: object
                 matchSELinuxLevelOnMigration:
                   description: By default, the SELinux level of target virt-launcher
                     pods is forced to the level of the source virt-launcher. When
@@ -12297,6 +13751,27 @@ var CRDsValidation map[string]string = map[string]string{
           description: A brief CamelCase message indicating details about why the
             VMI is in this state. e.g. 'NodeUnresponsive'
           type: string
+        resourceUsage:
+          description: ResourceUsage is the resource utilization of the guest as
+            last sampled by virt-handler. It is only reported for VMIs which belong
+            to a VirtualMachinePool.
+          properties:
+            cpuUtilizationPercentage:
+              description: CPUUtilizationPercentage is the average utilization of
+                all vCPUs of the guest in between the last two samples, in percent
+              format: int32
+              type: integer
+            lastSampleTime:
+              description: LastSampleTime is the time the utilization was sampled
+              format: date-time
+              type: string
+            memoryUtilizationPercentage:
+              description: MemoryUtilizationPercentage is the share of the guest
+                memory which is in use, in percent. It is only reported when the guest
+                exposes the memory statistics through the balloon driver
+              format: int32
+              type: integer
+          type: object
         runtimeUser:
           description: RuntimeUser is used to determine what user will be used in
             launcher
@@ -12322,6 +13797,50 @@ var CRDsValidation map[string]string = map[string]string{
             description: VolumeStatus represents information about the status of volumes
               attached to the VirtualMachineInstance.
             properties:
+              backupVolume:
+                description: If the volume is backup volume, this will contain the
+                  backup info.
+                properties:
+                  backupName:
+                    description: BackupName is the name of the backup and of the checkpoint
+                      created along with it
+                    type: string
+                  disks:
+                    description: Disks lists the files the disks were backed up to
+                    items:
+                      description: BackupDiskInfo represents the backup of a single
+                        disk
+                      properties:
+                        incremental:
+                          description: Incremental is true if the file only contains
+                            the changes since the base checkpoint
+                          type: boolean
+                        name:
+                          description: Name is the name of the backed up volume
+                          type: string
+                        targetFileName:
+                          description: TargetFileName is the name of the qcow2 file
+                            the volume was backed up to
+                          type: string
+                      required:
+                      - name
+                      - targetFileName
+                      type: object
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  endTimestamp:
+                    description: EndTimestamp is the time when the backup completed
+                    format: date-time
+                    type: string
+                  incrementalFrom:
+                    description: IncrementalFrom is the checkpoint the backup was
+                      taken relative to, empty for a full backup
+                    type: string
+                  startTimestamp:
+                    description: StartTimestamp is the time when the backup started
+                    format: date-time
+                    type: string
+                type: object
               hotplugVolume:
                 description: If the volume is hotplug, this will contain the hotplug
                   status.
@@ -12452,6 +13971,39 @@ var CRDsValidation map[string]string = map[string]string{
       type: object
     spec:
       properties:
+        addedNodeSelector:
+          additionalProperties:
+            type: string
+          description: AddedNodeSelector is an additional selector that can be used
+            to complement a NodeSelector or NodeAffinity as set on the VM to restrict
+            the set of allowed target nodes for a migration. In case of key collisions,
+            values set on the VM objects are going to be preserved to ensure that
+            addedNodeSelector can only restrict but not bypass constraints already
+            set on the VM object.
+          type: object
+        migratedVolumes:
+          description: MigratedVolumes is the list of volumes whose storage is copied
+            to a new PersistentVolumeClaim while the VMI is live migrated. Once the
+            migration succeeds, the volume sources of the VMI and of the owning VirtualMachine
+            are switched to the destination claims.
+          items:
+            description: MigratedVolume describes a volume to copy to a different
+              PersistentVolumeClaim during a migration
+            properties:
+              destinationClaimName:
+                description: DestinationClaimName is the name of the PersistentVolumeClaim,
+                  in the namespace of the VMI, the volume is copied to
+                type: string
+              volumeName:
+                description: VolumeName is the name of the VMI volume to migrate.
+                  It must be backed by a PersistentVolumeClaim or a DataVolume
+                type: string
+            required:
+            - destinationClaimName
+            - volumeName
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         vmiName:
           description: The name of the VMI to perform the migration on. VMI must exist
             in the migration objects namespace
@@ -12485,6 +14037,33 @@ var CRDsValidation map[string]string = map[string]string{
             - type
             type: object
           type: array
+        migratedVolumes:
+          description: MigratedVolumes reports the progress of the volumes copied
+            to new PersistentVolumeClaims
+          items:
+            description: MigratedVolumeStatus reports the progress of a volume copied
+              to a new PersistentVolumeClaim during a migration
+            properties:
+              destinationClaimName:
+                description: DestinationClaimName is the name of the PersistentVolumeClaim
+                  the volume is copied to
+                type: string
+              phase:
+                description: Phase is the progress of the volume migration
+                type: string
+              sourceClaimName:
+                description: SourceClaimName is the name of the PersistentVolumeClaim
+                  the volume is copied from
+                type: string
+              volumeName:
+                description: VolumeName is the name of the migrated volume
+                type: string
+            required:
+            - destinationClaimName
+            - volumeName
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         migrationState:
           description: Represents the status of a live migration
           properties:
@@ -12505,6 +14084,32 @@ var CRDsValidation map[string]string = map[string]string{
             failed:
               description: Indicates that the migration failed
               type: boolean
+            migratedVolumes:
+              description: The list of volumes whose storage is copied to a new PersistentVolumeClaim
+                during the migration
+              items:
+                description: StorageMigratedVolumeInfo tracks the information about
+                  the source and destination claim of a migrated volume
+                properties:
+                  destinationClaimName:
+                    description: DestinationClaimName is the name of the PersistentVolumeClaim
+                      the volume is copied to
+                    type: string
+                  sourceClaimName:
+                    description: SourceClaimName is the name of the PersistentVolumeClaim
+                      the volume is copied from
+                    type: string
+                  volumeName:
+                    description: VolumeName is the name of the volume that is being
+                      migrated
+                    type: string
+                required:
+                - destinationClaimName
+                - sourceClaimName
+                - volumeName
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
             migrationConfiguration:
               description: Migration configurations to apply
               properties:
@@ -12543,6 +14148,25 @@ var CRDsValidation map[string]string = map[string]string{
                     layer of live migration encryption provided by KubeVirt. This
                     is usually a bad idea. Defaults to false
                   type: boolean
+                evacuationOrder:
+                  description: EvacuationOrder defines in which order VirtualMachineInstances
+                    with the same evacuation priority are migrated away from a drained
+                    node. Defaults to Default, which keeps the order in which the
+                    VirtualMachineInstances are listed
+                  enum:
+                  - Default
+                  - SmallestMemoryFirst
+                  - LargestMemoryFirst
+                  type: string
+                evacuationTargetNodeSelector:
+                  additionalProperties:
+                    type: string
+                  description: EvacuationTargetNodeSelector restricts the nodes evacuated
+                    VirtualMachineInstances are migrated to. It is added to the node
+                    selector of every evacuation migration. VirtualMachineInstances whose
+                    node selector requires a different value for one of the keys are not
+                    evacuated and are reported in the NodeEvacuation status.
+                  type: object
                 matchSELinuxLevelOnMigration:
                   description: By default, the SELinux level of target virt-launcher
                     pods is forced to the level of the source virt-launcher. When
@@ -13011,6 +14635,105 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'IO specifies which QEMU disk IO mode should
                           be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the I/O of the disk. The limits
+                          can be changed on a running VirtualMachine when its liveUpdateFeatures
+                          contain ioTune.
+                        properties:
+                          read:
+                            description: Read limits the read operations.
+                            properties:
+                              burstBytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BurstBytesPerSecond allows to exceed
+                                  the throughput limit up to this value for short
+                                  periods. Requires bytesPerSecond.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burstIOPS:
+                                description: BurstIOPS allows to exceed the operations
+                                  limit up to this value for short periods. Requires
+                                  iops.
+                                format: int64
+                                type: integer
+                              bytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BytesPerSecond limits the throughput
+                                  in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              iops:
+                                description: IOPS limits the operations per second.
+                                format: int64
+                                type: integer
+                            type: object
+                          total:
+                            description: Total limits the sum of read and write operations.
+                            properties:
+                              burstBytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BurstBytesPerSecond allows to exceed
+                                  the throughput limit up to this value for short
+                                  periods. Requires bytesPerSecond.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burstIOPS:
+                                description: BurstIOPS allows to exceed the operations
+                                  limit up to this value for short periods. Requires
+                                  iops.
+                                format: int64
+                                type: integer
+                              bytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BytesPerSecond limits the throughput
+                                  in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              iops:
+                                description: IOPS limits the operations per second.
+                                format: int64
+                                type: integer
+                            type: object
+                          write:
+                            description: Write limits the write operations.
+                            properties:
+                              burstBytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BurstBytesPerSecond allows to exceed
+                                  the throughput limit up to this value for short
+                                  periods. Requires bytesPerSecond.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burstIOPS:
+                                description: BurstIOPS allows to exceed the operations
+                                  limit up to this value for short periods. Requires
+                                  iops.
+                                format: int64
+                                type: integer
+                              bytesPerSecond:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: BytesPerSecond limits the throughput
+                                  in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              iops:
+                                description: IOPS limits the operations per second.
+                                format: int64
+                                type: integer
+                            type: object
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -13188,6 +14911,25 @@ var CRDsValidation map[string]string = map[string]string{
                             description: If specified will pass option 67 to interface's
                               DHCP server
                             type: string
+                          dnsServers:
+                            description: If specified will replace the DNS servers
+                              of the pod network. IPv4 servers are passed to the VM
+                              via DHCP option 6, IPv6 servers via DHCPv6 option 23.
+                            items:
+                              type: string
+                            type: array
+                          domainName:
+                            description: If specified will pass the domain name to
+                              the VM via DHCP option 15. Defaults to the domain name
+                              derived from the search domains.
+                            type: string
+                          mtu:
+                            description: If specified will pass the MTU to the VM
+                              via DHCP option 26 instead of the MTU of the pod network.
+                              It cannot exceed the MTU of the pod network, larger
+                              values are lowered to it.
+                            format: int32
+                            type: integer
                           ntpServers:
                             description: If specified will pass the configured NTP
                               server to the VM via DHCP option 042.
@@ -13214,11 +14956,116 @@ var CRDsValidation map[string]string = map[string]string{
                               - value
                               type: object
                             type: array
+                          routes:
+                            description: If specified will pass the configured static
+                              routes to the VM via DHCP option 121, in addition to
+                              the routes of the pod network. Only IPv4 routes are
+                              supported.
+                            items:
+                              description: DHCPRoute is a static route which is passed
+                                to the VM via DHCP.
+                              properties:
+                                destination:
+                                  description: Destination is the destination network
+                                    of the route in CIDR notation, e.g. 10.10.0.0/16
+                                  type: string
+                                gateway:
+                                  description: Gateway is the IPv4 address of the
+                                    next hop.
+                                  type: string
+                              required:
+                              - destination
+                              - gateway
+                              type: object
+                            type: array
+                          searchDomains:
+                            description: If specified will replace the DNS search
+                              domains of the pod network. They are passed to the VM
+                              via DHCP option 119 and DHCPv6 option 24.
+                            items:
+                              type: string
+                            type: array
                           tftpServerName:
                             description: If specified will pass option 66 to interface's
                               DHCP server
                             type: string
                         type: object
+                      firewall:
+                        description: Firewall filters the traffic of the interface
+                          inside the virt-launcher pod. Supported only with the masquerade
+                          and passt bindings.
+                        properties:
+                          egress:
+                            description: Egress rules are applied to the connections
+                              initiated by the guest.
+                            items:
+                              description: FirewallRule matches traffic by its remote
+                                network, protocol and port. Fields which are not set
+                                match any traffic.
+                              properties:
+                                action:
+                                  description: Action is taken on the traffic which
+                                    matches the rule.
+                                  type: string
+                                cidr:
+                                  description: CIDR is the remote network, the source
+                                    of ingress and the destination of egress traffic.
+                                  type: string
+                                endPort:
+                                  description: EndPort makes the rule match the range
+                                    of ports from Port to EndPort.
+                                  format: int32
+                                  type: integer
+                                port:
+                                  description: Port is the destination port, only
+                                    valid with the TCP, UDP and SCTP protocols.
+                                  format: int32
+                                  type: integer
+                                protocol:
+                                  description: Protocol is one of TCP, UDP, SCTP or
+                                    ICMP.
+                                  type: string
+                              required:
+                              - action
+                              type: object
+                            type: array
+                          ingress:
+                            description: Ingress rules are applied to the connections
+                              initiated towards the guest. With the passt binding
+                              they only apply to the ports listed in the Ports of
+                              the interface, which have to be set.
+                            items:
+                              description: FirewallRule matches traffic by its remote
+                                network, protocol and port. Fields which are not set
+                                match any traffic.
+                              properties:
+                                action:
+                                  description: Action is taken on the traffic which
+                                    matches the rule.
+                                  type: string
+                                cidr:
+                                  description: CIDR is the remote network, the source
+                                    of ingress and the destination of egress traffic.
+                                  type: string
+                                endPort:
+                                  description: EndPort makes the rule match the range
+                                    of ports from Port to EndPort.
+                                  format: int32
+                                  type: integer
+                                port:
+                                  description: Port is the destination port, only
+                                    valid with the TCP, UDP and SCTP protocols.
+                                  format: int32
+                                  type: integer
+                                protocol:
+                                  description: Protocol is one of TCP, UDP, SCTP or
+                                    ICMP.
+                                  type: string
+                              required:
+                              - action
+                              type: object
+                            type: array
+                        type: object
                       macAddress:
                         description: 'Interface MAC address. For example: de:ad:00:00:be:af
                           or DE-AD-00-00-BE-AF.'
@@ -13276,6 +15123,74 @@ var CRDsValidation map[string]string = map[string]string{
                           - port
                           type: object
                         type: array
+                      qos:
+                        description: QoS limits the bandwidth of the interface. Supported
+                          only with the bridge and masquerade bindings.
+                        properties:
+                          inbound:
+                            description: Inbound limits the traffic received by the
+                              guest.
+                            properties:
+                              average:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Average is the rate the traffic is shaped
+                                  to, in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can
+                                  be sent at the peak rate.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              peak:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Peak is the maximum rate at which bursts
+                                  are sent, in bytes per second. Must not be lower
+                                  than the average.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - average
+                            type: object
+                          outbound:
+                            description: Outbound limits the traffic sent by the guest.
+                            properties:
+                              average:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Average is the rate the traffic is shaped
+                                  to, in bytes per second.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can
+                                  be sent at the peak rate.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              peak:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Peak is the maximum rate at which bursts
+                                  are sent, in bytes per second. Must not be lower
+                                  than the average.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - average
+                            type: object
+                        type: object
                       slirp:
                         description: InterfaceSlirp connects to a given network using
                           QEMU user networking mode.
@@ -13330,6 +15245,21 @@ var CRDsValidation map[string]string = map[string]string{
                 tpm:
                   description: Whether to emulate a TPM device.
                   properties:
+                    encryption:
+                      description: Encryption configures the key used to encrypt the
+                        persisted TPM state. Requires Persistent to be set to true.
+                      properties:
+                        secretRef:
+                          description: SecretRef references a Secret in the namespace
+                            of the VMI. The value of its "key" entry is used as the
+                            passphrase encrypting the TPM state.
+                          properties:
+                            name:
+                              description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                TODO: Add other useful fields. apiVersion, kind, uid?'
+                              type: string
+                          type: object
+                      type: object
                     persistent:
                       description: Persistent indicates the state of the TPM device
                         should be kept accross reboots Defaults to false
@@ -13662,6 +15592,25 @@ var CRDsValidation map[string]string = map[string]string{
                       description: Base64 encoded session blob.
                       type: string
                   type: object
+                snp:
+                  description: AMD Secure Encrypted Virtualization with Secure Nested
+                    Paging (SEV-SNP).
+                  properties:
+                    policy:
+                      description: 'Guest policy flags as defined in the AMD SEV-SNP
+                        firmware ABI specification. Note: due to security reasons
+                        it is not allowed to enable guest debugging. Therefore the
+                        Debug flag is not exposed to users and is always false.'
+                      properties:
+                        smt:
+                          description: Allow the guest to run on hosts with simultaneous
+                            multithreading (SMT) enabled. Defaults to true.
+                          type: boolean
+                      type: object
+                  type: object
+                tdx:
+                  description: Intel Trust Domain Extensions (TDX).
+                  type: object
               type: object
             machine:
               description: Machine type.
@@ -15186,6 +17135,109 @@ var CRDsValidation map[string]string = map[string]string{
                                   should be used. Supported values are: native, default,
                                   threads.'
                                 type: string
+                              ioTune:
+                                description: IOTune limits the I/O of the disk. The
+                                  limits can be changed on a running VirtualMachine
+                                  when its liveUpdateFeatures contain ioTune.
+                                properties:
+                                  read:
+                                    description: Read limits the read operations.
+                                    properties:
+                                      burstBytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BurstBytesPerSecond allows to
+                                          exceed the throughput limit up to this value
+                                          for short periods. Requires bytesPerSecond.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burstIOPS:
+                                        description: BurstIOPS allows to exceed the
+                                          operations limit up to this value for short
+                                          periods. Requires iops.
+                                        format: int64
+                                        type: integer
+                                      bytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BytesPerSecond limits the throughput
+                                          in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      iops:
+                                        description: IOPS limits the operations per
+                                          second.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                  total:
+                                    description: Total limits the sum of read and
+                                      write operations.
+                                    properties:
+                                      burstBytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BurstBytesPerSecond allows to
+                                          exceed the throughput limit up to this value
+                                          for short periods. Requires bytesPerSecond.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burstIOPS:
+                                        description: BurstIOPS allows to exceed the
+                                          operations limit up to this value for short
+                                          periods. Requires iops.
+                                        format: int64
+                                        type: integer
+                                      bytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BytesPerSecond limits the throughput
+                                          in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      iops:
+                                        description: IOPS limits the operations per
+                                          second.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                  write:
+                                    description: Write limits the write operations.
+                                    properties:
+                                      burstBytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BurstBytesPerSecond allows to
+                                          exceed the throughput limit up to this value
+                                          for short periods. Requires bytesPerSecond.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burstIOPS:
+                                        description: BurstIOPS allows to exceed the
+                                          operations limit up to this value for short
+                                          periods. Requires iops.
+                                        format: int64
+                                        type: integer
+                                      bytesPerSecond:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: BytesPerSecond limits the throughput
+                                          in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      iops:
+                                        description: IOPS limits the operations per
+                                          second.
+                                        format: int64
+                                        type: integer
+                                    type: object
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -15371,6 +17423,27 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: If specified will pass option 67
                                       to interface's DHCP server
                                     type: string
+                                  dnsServers:
+                                    description: If specified will replace the DNS
+                                      servers of the pod network. IPv4 servers are
+                                      passed to the VM via DHCP option 6, IPv6 servers
+                                      via DHCPv6 option 23.
+                                    items:
+                                      type: string
+                                    type: array
+                                  domainName:
+                                    description: If specified will pass the domain
+                                      name to the VM via DHCP option 15. Defaults
+                                      to the domain name derived from the search domains.
+                                    type: string
+                                  mtu:
+                                    description: If specified will pass the MTU to
+                                      the VM via DHCP option 26 instead of the MTU
+                                      of the pod network. It cannot exceed the MTU
+                                      of the pod network, larger values are lowered
+                                      to it.
+                                    format: int32
+                                    type: integer
                                   ntpServers:
                                     description: If specified will pass the configured
                                       NTP server to the VM via DHCP option 042.
@@ -15393,15 +17466,127 @@ var CRDsValidation map[string]string = map[string]string{
                                             the Option provided Required.
                                           type: string
                                       required:
-                                      - option
-                                      - value
+                                      - option
+                                      - value
+                                      type: object
+                                    type: array
+                                  routes:
+                                    description: If specified will pass the configured
+                                      static routes to the VM via DHCP option 121,
+                                      in addition to the routes of the pod network.
+                                      Only IPv4 routes are supported.
+                                    items:
+                                      description: DHCPRoute is a static route which
+                                        is passed to the VM via DHCP.
+                                      properties:
+                                        destination:
+                                          description: Destination is the destination
+                                            network of the route in CIDR notation,
+                                            e.g. 10.10.0.0/16
+                                          type: string
+                                        gateway:
+                                          description: Gateway is the IPv4 address
+                                            of the next hop.
+                                          type: string
+                                      required:
+                                      - destination
+                                      - gateway
                                       type: object
                                     type: array
+                                  searchDomains:
+                                    description: If specified will replace the DNS
+                                      search domains of the pod network. They are
+                                      passed to the VM via DHCP option 119 and DHCPv6
+                                      option 24.
+                                    items:
+                                      type: string
+                                    type: array
                                   tftpServerName:
                                     description: If specified will pass option 66
                                       to interface's DHCP server
                                     type: string
                                 type: object
+                              firewall:
+                                description: Firewall filters the traffic of the interface
+                                  inside the virt-launcher pod. Supported only with
+                                  the masquerade and passt bindings.
+                                properties:
+                                  egress:
+                                    description: Egress rules are applied to the connections
+                                      initiated by the guest.
+                                    items:
+                                      description: FirewallRule matches traffic by
+                                        its remote network, protocol and port. Fields
+                                        which are not set match any traffic.
+                                      properties:
+                                        action:
+                                          description: Action is taken on the traffic
+                                            which matches the rule.
+                                          type: string
+                                        cidr:
+                                          description: CIDR is the remote network,
+                                            the source of ingress and the destination
+                                            of egress traffic.
+                                          type: string
+                                        endPort:
+                                          description: EndPort makes the rule match
+                                            the range of ports from Port to EndPort.
+                                          format: int32
+                                          type: integer
+                                        port:
+                                          description: Port is the destination port,
+                                            only valid with the TCP, UDP and SCTP
+                                            protocols.
+                                          format: int32
+                                          type: integer
+                                        protocol:
+                                          description: Protocol is one of TCP, UDP,
+                                            SCTP or ICMP.
+                                          type: string
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                  ingress:
+                                    description: Ingress rules are applied to the
+                                      connections initiated towards the guest. With
+                                      the passt binding they only apply to the ports
+                                      listed in the Ports of the interface, which
+                                      have to be set.
+                                    items:
+                                      description: FirewallRule matches traffic by
+                                        its remote network, protocol and port. Fields
+                                        which are not set match any traffic.
+                                      properties:
+                                        action:
+                                          description: Action is taken on the traffic
+                                            which matches the rule.
+                                          type: string
+                                        cidr:
+                                          description: CIDR is the remote network,
+                                            the source of ingress and the destination
+                                            of egress traffic.
+                                          type: string
+                                        endPort:
+                                          description: EndPort makes the rule match
+                                            the range of ports from Port to EndPort.
+                                          format: int32
+                                          type: integer
+                                        port:
+                                          description: Port is the destination port,
+                                            only valid with the TCP, UDP and SCTP
+                                            protocols.
+                                          format: int32
+                                          type: integer
+                                        protocol:
+                                          description: Protocol is one of TCP, UDP,
+                                            SCTP or ICMP.
+                                          type: string
+                                      required:
+                                      - action
+                                      type: object
+                                    type: array
+                                type: object
                               macAddress:
                                 description: 'Interface MAC address. For example:
                                   de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
@@ -15462,6 +17647,76 @@ var CRDsValidation map[string]string = map[string]string{
                                   - port
                                   type: object
                                 type: array
+                              qos:
+                                description: QoS limits the bandwidth of the interface.
+                                  Supported only with the bridge and masquerade
+                                  bindings.
+                                properties:
+                                  inbound:
+                                    description: Inbound limits the traffic received
+                                      by the guest.
+                                    properties:
+                                      average:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Average is the rate the traffic
+                                          is shaped to, in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes
+                                          which can be sent at the peak rate.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      peak:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Peak is the maximum rate at which
+                                          bursts are sent, in bytes per second. Must
+                                          not be lower than the average.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - average
+                                    type: object
+                                  outbound:
+                                    description: Outbound limits the traffic sent
+                                      by the guest.
+                                    properties:
+                                      average:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Average is the rate the traffic
+                                          is shaped to, in bytes per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes
+                                          which can be sent at the peak rate.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      peak:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Peak is the maximum rate at which
+                                          bursts are sent, in bytes per second. Must
+                                          not be lower than the average.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - average
+                                    type: object
+                                type: object
                               slirp:
                                 description: InterfaceSlirp connects to a given network
                                   using QEMU user networking mode.
@@ -15520,6 +17775,24 @@ var CRDsValidation map[string]string = map[string]string{
                         tpm:
                           description: Whether to emulate a TPM device.
                           properties:
+                            encryption:
+                              description: Encryption configures the key used to encrypt
+                                the persisted TPM state. Requires Persistent to be
+                                set to true.
+                              properties:
+                                secretRef:
+                                  description: SecretRef references a Secret in the
+                                    namespace of the VMI. The value of its "key" entry
+                                    is used as the passphrase encrypting the TPM state.
+                                  properties:
+                                    name:
+                                      description: 'Name of the referent. More info:
+                                        https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                        TODO: Add other useful fields. apiVersion,
+                                        kind, uid?'
+                                      type: string
+                                  type: object
+                              type: object
                             persistent:
                               description: Persistent indicates the state of the TPM
                                 device should be kept accross reboots Defaults to
@@ -15884,6 +18157,27 @@ var CRDsValidation map[string]string = map[string]string{
                               description: Base64 encoded session blob.
                               type: string
                           type: object
+                        snp:
+                          description: AMD Secure Encrypted Virtualization with Secure
+                            Nested Paging (SEV-SNP).
+                          properties:
+                            policy:
+                              description: 'Guest policy flags as defined in the AMD
+                                SEV-SNP firmware ABI specification. Note: due to security
+                                reasons it is not allowed to enable guest debugging.
+                                Therefore the Debug flag is not exposed to users and
+                                is always false.'
+                              properties:
+                                smt:
+                                  description: Allow the guest to run on hosts with
+                                    simultaneous multithreading (SMT) enabled. Defaults
+                                    to true.
+                                  type: boolean
+                              type: object
+                          type: object
+                        tdx:
+                          description: Intel Trust Domain Extensions (TDX).
+                          type: object
                       type: object
                     machine:
                       description: Machine type.
@@ -16519,6 +18813,38 @@ var CRDsValidation map[string]string = map[string]string{
                   items:
                     description: Volume represents a named volume in a vmi.
                     properties:
+                      backup:
+                        description: Backup is attached to the virt launcher and is
+                          populated with a backup of the vmi disks
+                        properties:
+                          backupName:
+                            description: BackupName is the name of the backup, it
+                              is also used as the name of the checkpoint created along
+                              with the backup
+                            type: string
+                          claimName:
+                            description: 'claimName is the name of a PersistentVolumeClaim
+                              in the same namespace as the pod using this volume.
+                              More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                            type: string
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          incrementalFrom:
+                            description: IncrementalFrom is the name of the checkpoint
+                              the backup only contains the changes since, a full backup
+                              is taken if it is empty or the checkpoint does not exist
+                              anymore
+                            type: string
+                          readOnly:
+                            description: readOnly Will force the ReadOnly setting
+                              in VolumeMounts. Default false.
+                            type: boolean
+                        required:
+                        - backupName
+                        - claimName
+                        type: object
                       cloudInitConfigDrive:
                         description: 'CloudInitConfigDrive represents a cloud-init
                           Config Drive user-data source. The Config Drive data will
@@ -17138,6 +19464,25 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Base64 encoded session blob.
                   type: string
               type: object
+            snp:
+              description: AMD Secure Encrypted Virtualization with Secure Nested
+                Paging (SEV-SNP).
+              properties:
+                policy:
+                  description: 'Guest policy flags as defined in the AMD SEV-SNP firmware
+                    ABI specification. Note: due to security reasons it is not allowed
+                    to enable guest debugging. Therefore the Debug flag is not exposed
+                    to users and is always false.'
+                  properties:
+                    smt:
+                      description: Allow the guest to run on hosts with simultaneous
+                        multithreading (SMT) enabled. Defaults to true.
+                      type: boolean
+                  type: object
+              type: object
+            tdx:
+              description: Intel Trust Domain Extensions (TDX).
+              type: object
           type: object
         memory:
           description: Required Memory related attributes of the instancetype.
@@ -17210,6 +19555,39 @@ var CRDsValidation map[string]string = map[string]string{
       type: object
     spec:
       properties:
+        autoscaler:
+          description: Autoscaler adjusts the replica count of the pool based on the
+            resource utilization of its VMIs.
+          properties:
+            maxReplicas:
+              description: MaxReplicas is the upper limit for the number of replicas.
+              format: int32
+              type: integer
+            minReplicas:
+              description: MinReplicas is the lower limit for the number of replicas.
+                Defaults to 1.
+              format: int32
+              type: integer
+            scaleDownStabilizationSeconds:
+              description: ScaleDownStabilizationSeconds is the time the utilization
+                has to stay below the target before the pool is scaled in. Defaults
+                to 300.
+              format: int32
+              type: integer
+            targetCPUUtilizationPercentage:
+              description: TargetCPUUtilizationPercentage is the target average CPU
+                utilization of the running VMIs, in percent of their vCPUs.
+              format: int32
+              type: integer
+            targetMemoryUtilizationPercentage:
+              description: TargetMemoryUtilizationPercentage is the target average
+                memory utilization of the running VMIs, in percent of their guest
+                memory.
+              format: int32
+              type: integer
+          required:
+          - maxReplicas
+          type: object
         paused:
           description: Indicates that the pool is paused.
           type: boolean
@@ -17218,6 +19596,24 @@ var CRDsValidation map[string]string = map[string]string{
             explicit zero and not specified. Defaults to 1.
           format: int32
           type: integer
+        scaleInPolicy:
+          description: ScaleInPolicy defines which VMs are removed first when the
+            pool scales in.
+          properties:
+            order:
+              description: Order in which VMs with otherwise equal preference are
+                removed. One of Random, Oldest or Newest. Defaults to Random.
+              type: string
+            preferNoLoggedInUsers:
+              description: PreferNoLoggedInUsers removes VMs without users logged
+                into the guest, as reported by the guest agent, before VMs with logged
+                in users or without a connected agent.
+              type: boolean
+            preferNotReady:
+              description: PreferNotReady removes VMs which are not ready before ready
+                ones.
+              type: boolean
+          type: object
         selector:
           description: Label selector for pods. Existing Poolss whose pods are selected
             by this will be the ones affected by this deployment.
@@ -17258,6 +19654,24 @@ var CRDsValidation map[string]string = map[string]string{
                 contains only "value". The requirements are ANDed.
               type: object
           type: object
+        updateStrategy:
+          description: UpdateStrategy defines how the VMs of the pool are updated
+            when the VirtualMachineTemplate changes.
+          properties:
+            maxUnavailable:
+              anyOf:
+              - type: integer
+              - type: string
+              description: MaxUnavailable is the maximum number of VMs which can be
+                unavailable while outdated VMIs are restarted. Value can be an absolute
+                number or a percentage of the desired replicas. When not set all outdated
+                VMIs are restarted at once.
+              x-kubernetes-int-or-string: true
+            type:
+              description: Type of the update strategy. One of Proactive, Opportunistic
+                or LiveUpdate. Defaults to Proactive.
+              type: string
+          type: object
         virtualMachineTemplate:
           description: Template describes the VM that will be created.
           properties:
@@ -18045,6 +20459,10 @@ var CRDsValidation map[string]string = map[string]string{
                           format: int32
                           type: integer
                       type: object
+                    ioTune:
+                      description: IOTune allows live updating the I/O limits of the
+                        virtual machines disks
+                      type: object
                     memory:
                       description: MemoryLiveUpdateConfiguration defines the live
                         update memory features for the VirtualMachine
@@ -19556,6 +21974,116 @@ var CRDsValidation map[string]string = map[string]string{
                                           IO mode should be used. Supported values
                                           are: native, default, threads.'
                                         type: string
+                                      ioTune:
+                                        description: IOTune limits the I/O of the
+                                          disk. The limits can be changed on a running
+                                          VirtualMachine when its liveUpdateFeatures
+                                          contain ioTune.
+                                        properties:
+                                          read:
+                                            description: Read limits the read operations.
+                                            properties:
+                                              burstBytesPerSecond:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: BurstBytesPerSecond allows
+                                                  to exceed the throughput limit up
+                                                  to this value for short periods.
+                                                  Requires bytesPerSecond.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              burstIOPS:
+                                                description: BurstIOPS allows to exceed
+                                                  the operations limit up to this
+                                                  value for short periods. Requires
+                                                  iops.
+                                                format: int64
+                                                type: integer
+                                              bytesPerSecond:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: BytesPerSecond limits
+                                                  the throughput in bytes per second.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              iops:
+                                                description: IOPS limits the operations
+                                                  per second.
+                                                format: int64
+                                                type: integer
+                                            type: object
+                                          total:
+                                            description: Total limits the sum of read
+                                              and write operations.
+                                            properties:
+                                              burstBytesPerSecond:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: BurstBytesPerSecond allows
+                                                  to exceed the throughput limit up
+                                                  to this value for short periods.
+                                                  Requires bytesPerSecond.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              burstIOPS:
+                                                description: BurstIOPS allows to exceed
+                                                  the operations limit up to this
+                                                  value for short periods. Requires
+                                                  iops.
+                                                format: int64
+                                                type: integer
+                                              bytesPerSecond:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: BytesPerSecond limits
+                                                  the throughput in bytes per second.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              iops:
+                                                description: IOPS limits the operations
+                                                  per second.
+                                                format: int64
+                                                type: integer
+                                            type: object
+                                          write:
+                                            description: Write limits the write operations.
+                                            properties:
+                                              burstBytesPerSecond:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: BurstBytesPerSecond allows
+                                                  to exceed the throughput limit up
+                                                  to this value for short periods.
+                                                  Requires bytesPerSecond.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              burstIOPS:
+                                                description: BurstIOPS allows to exceed
+                                                  the operations limit up to this
+                                                  value for short periods. Requires
+                                                  iops.
+                                                format: int64
+                                                type: integer
+                                              bytesPerSecond:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: BytesPerSecond limits
+                                                  the throughput in bytes per second.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              iops:
+                                                description: IOPS limits the operations
+                                                  per second.
+                                                format: int64
+                                                type: integer
+                                            type: object
+                                        type: object
                                       lun:
                                         description: Attach a volume as a LUN to the
                                           vmi.
@@ -19753,6 +22281,29 @@ var CRDsValidation map[string]string = map[string]string{
                                             description: If specified will pass option
                                               67 to interface's DHCP server
                                             type: string
+                                          dnsServers:
+                                            description: If specified will replace
+                                              the DNS servers of the pod network.
+                                              IPv4 servers are passed to the VM via
+                                              DHCP option 6, IPv6 servers via DHCPv6
+                                              option 23.
+                                            items:
+                                              type: string
+                                            type: array
+                                          domainName:
+                                            description: If specified will pass the
+                                              domain name to the VM via DHCP option
+                                              15. Defaults to the domain name derived
+                                              from the search domains.
+                                            type: string
+                                          mtu:
+                                            description: If specified will pass the
+                                              MTU to the VM via DHCP option 26 instead
+                                              of the MTU of the pod network. It cannot
+                                              exceed the MTU of the pod network, larger
+                                              values are lowered to it.
+                                            format: int32
+                                            type: integer
                                           ntpServers:
                                             description: If specified will pass the
                                               configured NTP server to the VM via
@@ -19781,11 +22332,132 @@ var CRDsValidation map[string]string = map[string]string{
                                               - value
                                               type: object
                                             type: array
+                                          routes:
+                                            description: If specified will pass the
+                                              configured static routes to the VM via
+                                              DHCP option 121, in addition to the
+                                              routes of the pod network. Only IPv4
+                                              routes are supported.
+                                            items:
+                                              description: DHCPRoute is a static route
+                                                which is passed to the VM via DHCP.
+                                              properties:
+                                                destination:
+                                                  description: Destination is the
+                                                    destination network of the route
+                                                    in CIDR notation, e.g. 10.10.0.0/16
+                                                  type: string
+                                                gateway:
+                                                  description: Gateway is the IPv4
+                                                    address of the next hop.
+                                                  type: string
+                                              required:
+                                              - destination
+                                              - gateway
+                                              type: object
+                                            type: array
+                                          searchDomains:
+                                            description: If specified will replace
+                                              the DNS search domains of the pod network.
+                                              They are passed to the VM via DHCP option
+                                              119 and DHCPv6 option 24.
+                                            items:
+                                              type: string
+                                            type: array
                                           tftpServerName:
                                             description: If specified will pass option
                                               66 to interface's DHCP server
                                             type: string
                                         type: object
+                                      firewall:
+                                        description: Firewall filters the traffic
+                                          of the interface inside the virt-launcher
+                                          pod. Supported only with the masquerade
+                                          and passt bindings.
+                                        properties:
+                                          egress:
+                                            description: Egress rules are applied
+                                              to the connections initiated by the
+                                              guest.
+                                            items:
+                                              description: FirewallRule matches traffic
+                                                by its remote network, protocol and
+                                                port. Fields which are not set match
+                                                any traffic.
+                                              properties:
+                                                action:
+                                                  description: Action is taken on
+                                                    the traffic which matches the
+                                                    rule.
+                                                  type: string
+                                                cidr:
+                                                  description: CIDR is the remote
+                                                    network, the source of ingress
+                                                    and the destination of egress
+                                                    traffic.
+                                                  type: string
+                                                endPort:
+                                                  description: EndPort makes the rule
+                                                    match the range of ports from
+                                                    Port to EndPort.
+                                                  format: int32
+                                                  type: integer
+                                                port:
+                                                  description: Port is the destination
+                                                    port, only valid with the TCP,
+                                                    UDP and SCTP protocols.
+                                                  format: int32
+                                                  type: integer
+                                                protocol:
+                                                  description: Protocol is one of
+                                                    TCP, UDP, SCTP or ICMP.
+                                                  type: string
+                                              required:
+                                              - action
+                                              type: object
+                                            type: array
+                                          ingress:
+                                            description: Ingress rules are applied
+                                              to the connections initiated towards
+                                              the guest.
+                                            items:
+                                              description: FirewallRule matches traffic
+                                                by its remote network, protocol and
+                                                port. Fields which are not set match
+                                                any traffic.
+                                              properties:
+                                                action:
+                                                  description: Action is taken on
+                                                    the traffic which matches the
+                                                    rule.
+                                                  type: string
+                                                cidr:
+                                                  description: CIDR is the remote
+                                                    network, the source of ingress
+                                                    and the destination of egress
+                                                    traffic.
+                                                  type: string
+                                                endPort:
+                                                  description: EndPort makes the rule
+                                                    match the range of ports from
+                                                    Port to EndPort.
+                                                  format: int32
+                                                  type: integer
+                                                port:
+                                                  description: Port is the destination
+                                                    port, only valid with the TCP,
+                                                    UDP and SCTP protocols.
+                                                  format: int32
+                                                  type: integer
+                                                protocol:
+                                                  description: Protocol is one of
+                                                    TCP, UDP, SCTP or ICMP.
+                                                  type: string
+                                              required:
+                                              - action
+                                              type: object
+                                            type: array
+                                        type: object
                                       macAddress:
                                         description: 'Interface MAC address. For example:
                                           de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
@@ -19852,6 +22524,82 @@ var CRDsValidation map[string]string = map[string]string{
                                           - port
                                           type: object
                                         type: array
+                                      qos:
+                                        description: QoS limits the bandwidth of the
+                                          interface. Supported only with the bridge,
+                                          masquerade and macvtap bindings.
+                                        properties:
+                                          inbound:
+                                            description: Inbound limits the traffic
+                                              received by the guest.
+                                            properties:
+                                              average:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Average is the rate the
+                                                  traffic is shaped to, in bytes per
+                                                  second.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              burst:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Burst is the amount of
+                                                  bytes which can be sent at the peak
+                                                  rate.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              peak:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Peak is the maximum rate
+                                                  at which bursts are sent, in bytes
+                                                  per second. Must not be lower than
+                                                  the average.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                            required:
+                                            - average
+                                            type: object
+                                          outbound:
+                                            description: Outbound limits the traffic
+                                              sent by the guest.
+                                            properties:
+                                              average:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Average is the rate the
+                                                  traffic is shaped to, in bytes per
+                                                  second.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              burst:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Burst is the amount of
+                                                  bytes which can be sent at the peak
+                                                  rate.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              peak:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Peak is the maximum rate
+                                                  at which bursts are sent, in bytes
+                                                  per second. Must not be lower than
+                                                  the average.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                            required:
+                                            - average
+                                            type: object
+                                        type: object
                                       slirp:
                                         description: InterfaceSlirp connects to a
                                           given network using QEMU user networking
@@ -19916,6 +22664,25 @@ var CRDsValidation map[string]string = map[string]string{
                                 tpm:
                                   description: Whether to emulate a TPM device.
                                   properties:
+                                    encryption:
+                                      description: Encryption configures the key used
+                                        to encrypt the persisted TPM state. Requires
+                                        Persistent to be set to true.
+                                      properties:
+                                        secretRef:
+                                          description: SecretRef references a Secret
+                                            in the namespace of the VMI. The value
+                                            of its "key" entry is used as the passphrase
+                                            encrypting the TPM state.
+                                          properties:
+                                            name:
+                                              description: 'Name of the referent.
+                                                More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                                TODO: Add other useful fields. apiVersion,
+                                                kind, uid?'
+                                              type: string
+                                          type: object
+                                      type: object
                                     persistent:
                                       description: Persistent indicates the state
                                         of the TPM device should be kept accross reboots
@@ -20297,6 +23064,28 @@ var CRDsValidation map[string]string = map[string]string{
                                       description: Base64 encoded session blob.
                                       type: string
                                   type: object
+                                snp:
+                                  description: AMD Secure Encrypted Virtualization
+                                    with Secure Nested Paging (SEV-SNP).
+                                  properties:
+                                    policy:
+                                      description: 'Guest policy flags as defined
+                                        in the AMD SEV-SNP firmware ABI specification.
+                                        Note: due to security reasons it is not allowed
+                                        to enable guest debugging. Therefore the Debug
+                                        flag is not exposed to users and is always
+                                        false.'
+                                      properties:
+                                        smt:
+                                          description: Allow the guest to run on hosts
+                                            with simultaneous multithreading (SMT)
+                                            enabled. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                  type: object
+                                tdx:
+                                  description: Intel Trust Domain Extensions (TDX).
+                                  type: object
                               type: object
                             machine:
                               description: Machine type.
@@ -20973,6 +23762,38 @@ var CRDsValidation map[string]string = map[string]string{
                           items:
                             description: Volume represents a named volume in a vmi.
                             properties:
+                              backup:
+                                description: Backup is attached to the virt launcher
+                                  and is populated with a backup of the vmi disks
+                                properties:
+                                  backupName:
+                                    description: BackupName is the name of the backup,
+                                      it is also used as the name of the checkpoint
+                                      created along with the backup
+                                    type: string
+                                  claimName:
+                                    description: 'claimName is the name of a PersistentVolumeClaim
+                                      in the same namespace as the pod using this
+                                      volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                    type: string
+                                  hotpluggable:
+                                    description: Hotpluggable indicates whether the
+                                      volume can be hotplugged and hotunplugged.
+                                    type: boolean
+                                  incrementalFrom:
+                                    description: IncrementalFrom is the name of the
+                                      checkpoint the backup only contains the changes
+                                      since, a full backup is taken if it is empty
+                                      or the checkpoint does not exist anymore
+                                    type: string
+                                  readOnly:
+                                    description: readOnly Will force the ReadOnly
+                                      setting in VolumeMounts. Default false.
+                                    type: boolean
+                                required:
+                                - backupName
+                                - claimName
+                                type: object
                               cloudInitConfigDrive:
                                 description: 'CloudInitConfigDrive represents a cloud-init
                                   Config Drive user-data source. The Config Drive
@@ -21446,12 +24267,27 @@ var CRDsValidation map[string]string = map[string]string{
           description: Canonical form of the label selector for HPA which consumes
             it through the scale subresource.
           type: string
+        lastScaleTime:
+          description: LastScaleTime is the last time the autoscaler changed the replica
+            count of the pool.
+          format: date-time
+          nullable: true
+          type: string
         readyReplicas:
           format: int32
           type: integer
         replicas:
           format: int32
           type: integer
+        updateRevision:
+          description: UpdateRevision is the name of the pool revision the VMs are
+            updated to.
+          type: string
+        updatedReplicas:
+          description: UpdatedReplicas is the number of VMs whose spec and running
+            VMI match the update revision.
+          format: int32
+          type: integer
       type: object
   required:
   - spec
@@ -21721,6 +24557,21 @@ var CRDsValidation map[string]string = map[string]string{
               description: PreferredTPM optionally defines the preferred TPM device
                 to be used.
               properties:
+                encryption:
+                  description: Encryption configures the key used to encrypt the persisted
+                    TPM state. Requires Persistent to be set to true.
+                  properties:
+                    secretRef:
+                      description: SecretRef references a Secret in the namespace
+                        of the VMI. The value of its "key" entry is used as the passphrase
+                        encrypting the TPM state.
+                      properties:
+                        name:
+                          description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                            TODO: Add other useful fields. apiVersion, kind, uid?'
+                          type: string
+                      type: object
+                  type: object
                 persistent:
                   description: Persistent indicates the state of the TPM device should
                     be kept accross reboots Defaults to false
@@ -22032,6 +24883,63 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachinequota": `openAPIV3Schema:
+  description: VirtualMachineQuota limits the guest resources of the VirtualMachineInstances
+    in a namespace. In contrast to a ResourceQuota only the resources visible to the
+    guest are counted, the overhead of the virt-launcher pod is not.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      properties:
+        hard:
+          additionalProperties:
+            anyOf:
+            - type: integer
+            - type: string
+            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+            x-kubernetes-int-or-string: true
+          description: Hard is the set of enforced limits. The supported resources
+            are vcpus, guest.memory and virtualmachineinstances.
+          type: object
+      type: object
+    status:
+      properties:
+        hard:
+          additionalProperties:
+            anyOf:
+            - type: integer
+            - type: string
+            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+            x-kubernetes-int-or-string: true
+          description: Hard is the set of enforced limits observed when the usage
+            was last calculated
+          type: object
+        used:
+          additionalProperties:
+            anyOf:
+            - type: integer
+            - type: string
+            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+            x-kubernetes-int-or-string: true
+          description: Used is the sum of the guest resources of the VirtualMachineInstances
+            in the namespace
+          type: object
+      type: object
+  required:
+  - spec
+  type: object
 `,
 	"virtualmachinerestore": `openAPIV3Schema:
   description: VirtualMachineRestore defines the operation of restoring a VM
@@ -23124,6 +26032,10 @@ var CRDsValidation map[string]string = map[string]string{
                               format: int32
                               type: integer
                           type: object
+                        ioTune:
+                          description: IOTune allows live updating the I/O limits
+                            of the virtual machines disks
+                          type: object
                         memory:
                           description: MemoryLiveUpdateConfiguration defines the live
                             update memory features for the VirtualMachine
@@ -24717,6 +27629,121 @@ var CRDsValidation map[string]string = map[string]string{
                                               disk IO mode should be used. Supported
                                               values are: native, default, threads.'
                                             type: string
+                                          ioTune:
+                                            description: IOTune limits the I/O of
+                                              the disk. The limits can be changed
+                                              on a running VirtualMachine when its
+                                              liveUpdateFeatures contain ioTune.
+                                            properties:
+                                              read:
+                                                description: Read limits the read
+                                                  operations.
+                                                properties:
+                                                  burstBytesPerSecond:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: BurstBytesPerSecond
+                                                      allows to exceed the throughput
+                                                      limit up to this value for short
+                                                      periods. Requires bytesPerSecond.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  burstIOPS:
+                                                    description: BurstIOPS allows
+                                                      to exceed the operations limit
+                                                      up to this value for short periods.
+                                                      Requires iops.
+                                                    format: int64
+                                                    type: integer
+                                                  bytesPerSecond:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: BytesPerSecond limits
+                                                      the throughput in bytes per
+                                                      second.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  iops:
+                                                    description: IOPS limits the operations
+                                                      per second.
+                                                    format: int64
+                                                    type: integer
+                                                type: object
+                                              total:
+                                                description: Total limits the sum
+                                                  of read and write operations.
+                                                properties:
+                                                  burstBytesPerSecond:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: BurstBytesPerSecond
+                                                      allows to exceed the throughput
+                                                      limit up to this value for short
+                                                      periods. Requires bytesPerSecond.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  burstIOPS:
+                                                    description: BurstIOPS allows
+                                                      to exceed the operations limit
+                                                      up to this value for short periods.
+                                                      Requires iops.
+                                                    format: int64
+                                                    type: integer
+                                                  bytesPerSecond:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: BytesPerSecond limits
+                                                      the throughput in bytes per
+                                                      second.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  iops:
+                                                    description: IOPS limits the operations
+                                                      per second.
+                                                    format: int64
+                                                    type: integer
+                                                type: object
+                                              write:
+                                                description: Write limits the write
+                                                  operations.
+                                                properties:
+                                                  burstBytesPerSecond:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: BurstBytesPerSecond
+                                                      allows to exceed the throughput
+                                                      limit up to this value for short
+                                                      periods. Requires bytesPerSecond.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  burstIOPS:
+                                                    description: BurstIOPS allows
+                                                      to exceed the operations limit
+                                                      up to this value for short periods.
+                                                      Requires iops.
+                                                    format: int64
+                                                    type: integer
+                                                  bytesPerSecond:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: BytesPerSecond limits
+                                                      the throughput in bytes per
+                                                      second.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  iops:
+                                                    description: IOPS limits the operations
+                                                      per second.
+                                                    format: int64
+                                                    type: integer
+                                                type: object
+                                            type: object
                                           lun:
                                             description: Attach a volume as a LUN
                                               to the vmi.
@@ -24924,6 +27951,30 @@ var CRDsValidation map[string]string = map[string]string{
                                                 description: If specified will pass
                                                   option 67 to interface's DHCP server
                                                 type: string
+                                              dnsServers:
+                                                description: If specified will replace
+                                                  the DNS servers of the pod network.
+                                                  IPv4 servers are passed to the VM
+                                                  via DHCP option 6, IPv6 servers
+                                                  via DHCPv6 option 23.
+                                                items:
+                                                  type: string
+                                                type: array
+                                              domainName:
+                                                description: If specified will pass
+                                                  the domain name to the VM via DHCP
+                                                  option 15. Defaults to the domain
+                                                  name derived from the search domains.
+                                                type: string
+                                              mtu:
+                                                description: If specified will pass
+                                                  the MTU to the VM via DHCP option
+                                                  26 instead of the MTU of the pod
+                                                  network. It cannot exceed the MTU
+                                                  of the pod network, larger values
+                                                  are lowered to it.
+                                                format: int32
+                                                type: integer
                                               ntpServers:
                                                 description: If specified will pass
                                                   the configured NTP server to the
@@ -24953,11 +28004,135 @@ var CRDsValidation map[string]string = map[string]string{
                                                   - value
                                                   type: object
                                                 type: array
+                                              routes:
+                                                description: If specified will pass
+                                                  the configured static routes to
+                                                  the VM via DHCP option 121, in addition
+                                                  to the routes of the pod network.
+                                                  Only IPv4 routes are supported.
+                                                items:
+                                                  description: DHCPRoute is a static
+                                                    route which is passed to the VM
+                                                    via DHCP.
+                                                  properties:
+                                                    destination:
+                                                      description: Destination is
+                                                        the destination network of
+                                                        the route in CIDR notation,
+                                                        e.g. 10.10.0.0/16
+                                                      type: string
+                                                    gateway:
+                                                      description: Gateway is the
+                                                        IPv4 address of the next hop.
+                                                      type: string
+                                                  required:
+                                                  - destination
+                                                  - gateway
+                                                  type: object
+                                                type: array
+                                              searchDomains:
+                                                description: If specified will replace
+                                                  the DNS search domains of the pod
+                                                  network. They are passed to the
+                                                  VM via DHCP option 119 and DHCPv6
+                                                  option 24.
+                                                items:
+                                                  type: string
+                                                type: array
                                               tftpServerName:
                                                 description: If specified will pass
                                                   option 66 to interface's DHCP server
                                                 type: string
                                             type: object
+                                          firewall:
+                                            description: Firewall filters the traffic
+                                              of the interface inside the virt-launcher
+                                              pod. Supported only with the masquerade
+                                              and passt bindings.
+                                            properties:
+                                              egress:
+                                                description: Egress rules are applied
+                                                  to the connections initiated by
+                                                  the guest.
+                                                items:
+                                                  description: FirewallRule matches
+                                                    traffic by its remote network,
+                                                    protocol and port. Fields which
+                                                    are not set match any traffic.
+                                                  properties:
+                                                    action:
+                                                      description: Action is taken
+                                                        on the traffic which matches
+                                                        the rule.
+                                                      type: string
+                                                    cidr:
+                                                      description: CIDR is the remote
+                                                        network, the source of ingress
+                                                        and the destination of egress
+                                                        traffic.
+                                                      type: string
+                                                    endPort:
+                                                      description: EndPort makes the
+                                                        rule match the range of ports
+                                                        from Port to EndPort.
+                                                      format: int32
+                                                      type: integer
+                                                    port:
+                                                      description: Port is the destination
+                                                        port, only valid with the
+                                                        TCP, UDP and SCTP protocols.
+                                                      format: int32
+                                                      type: integer
+                                                    protocol:
+                                                      description: Protocol is one
+                                                        of TCP, UDP, SCTP or ICMP.
+                                                      type: string
+                                                  required:
+                                                  - action
+                                                  type: object
+                                                type: array
+                                              ingress:
+                                                description: Ingress rules are applied
+                                                  to the connections initiated towards
+                                                  the guest.
+                                                items:
+                                                  description: FirewallRule matches
+                                                    traffic by its remote network,
+                                                    protocol and port. Fields which
+                                                    are not set match any traffic.
+                                                  properties:
+                                                    action:
+                                                      description: Action is taken
+                                                        on the traffic which matches
+                                                        the rule.
+                                                      type: string
+                                                    cidr:
+                                                      description: CIDR is the remote
+                                                        network, the source of ingress
+                                                        and the destination of egress
+                                                        traffic.
+                                                      type: string
+                                                    endPort:
+                                                      description: EndPort makes the
+                                                        rule match the range of ports
+                                                        from Port to EndPort.
+                                                      format: int32
+                                                      type: integer
+                                                    port:
+                                                      description: Port is the destination
+                                                        port, only valid with the
+                                                        TCP, UDP and SCTP protocols.
+                                                      format: int32
+                                                      type: integer
+                                                    protocol:
+                                                      description: Protocol is one
+                                                        of TCP, UDP, SCTP or ICMP.
+                                                      type: string
+                                                  required:
+                                                  - action
+                                                  type: object
+                                                type: array
+                                            type: object
                                           macAddress:
                                             description: 'Interface MAC address. For
                                               example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
@@ -25028,6 +28203,82 @@ var CRDsValidation map[string]string = map[string]string{
                                               - port
                                               type: object
                                             type: array
+                                          qos:
+                                            description: QoS limits the bandwidth
+                                              of the interface. Supported only with
+                                              the bridge and masquerade bindings.
+                                            properties:
+                                              inbound:
+                                                description: Inbound limits the traffic
+                                                  received by the guest.
+                                                properties:
+                                                  average:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Average is the rate
+                                                      the traffic is shaped to, in
+                                                      bytes per second.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  burst:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Burst is the amount
+                                                      of bytes which can be sent at
+                                                      the peak rate.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  peak:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Peak is the maximum
+                                                      rate at which bursts are sent,
+                                                      in bytes per second. Must not
+                                                      be lower than the average.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                required:
+                                                - average
+                                                type: object
+                                              outbound:
+                                                description: Outbound limits the traffic
+                                                  sent by the guest.
+                                                properties:
+                                                  average:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Average is the rate
+                                                      the traffic is shaped to, in
+                                                      bytes per second.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  burst:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Burst is the amount
+                                                      of bytes which can be sent at
+                                                      the peak rate.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  peak:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Peak is the maximum
+                                                      rate at which bursts are sent,
+                                                      in bytes per second. Must not
+                                                      be lower than the average.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                required:
+                                                - average
+                                                type: object
+                                            type: object
                                           slirp:
                                             description: InterfaceSlirp connects to
                                               a given network using QEMU user networking
@@ -25093,6 +28344,26 @@ var CRDsValidation map[string]string = map[string]string{
                                     tpm:
                                       description: Whether to emulate a TPM device.
                                       properties:
+                                        encryption:
+                                          description: Encryption configures the key
+                                            used to encrypt the persisted TPM state.
+                                            Requires Persistent to be set to true.
+                                          properties:
+                                            secretRef:
+                                              description: SecretRef references a
+                                                Secret in the namespace of the VMI.
+                                                The value of its "key" entry is used
+                                                as the passphrase encrypting the TPM
+                                                state.
+                                              properties:
+                                                name:
+                                                  description: 'Name of the referent.
+                                                    More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                                    TODO: Add other useful fields.
+                                                    apiVersion, kind, uid?'
+                                                  type: string
+                                              type: object
+                                          type: object
                                         persistent:
                                           description: Persistent indicates the state
                                             of the TPM device should be kept accross
@@ -25486,6 +28757,28 @@ var CRDsValidation map[string]string = map[string]string{
                                           description: Base64 encoded session blob.
                                           type: string
                                       type: object
+                                    snp:
+                                      description: AMD Secure Encrypted Virtualization
+                                        with Secure Nested Paging (SEV-SNP).
+                                      properties:
+                                        policy:
+                                          description: 'Guest policy flags as defined
+                                            in the AMD SEV-SNP firmware ABI specification.
+                                            Note: due to security reasons it is not
+                                            allowed to enable guest debugging. Therefore
+                                            the Debug flag is not exposed to users
+                                            and is always false.'
+                                          properties:
+                                            smt:
+                                              description: Allow the guest to run
+                                                on hosts with simultaneous multithreading
+                                                (SMT) enabled. Defaults to true.
+                                              type: boolean
+                                          type: object
+                                      type: object
+                                    tdx:
+                                      description: Intel Trust Domain Extensions (TDX).
+                                      type: object
                                   type: object
                                 machine:
                                   description: Machine type.
@@ -26189,6 +29482,39 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Volume represents a named volume in a
                                   vmi.
                                 properties:
+                                  backup:
+                                    description: Backup is attached to the virt launcher
+                                      and is populated with a backup of the vmi disks
+                                    properties:
+                                      backupName:
+                                        description: BackupName is the name of the
+                                          backup, it is also used as the name of the
+                                          checkpoint created along with the backup
+                                        type: string
+                                      claimName:
+                                        description: 'claimName is the name of a PersistentVolumeClaim
+                                          in the same namespace as the pod using this
+                                          volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                        type: string
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      incrementalFrom:
+                                        description: IncrementalFrom is the name of
+                                          the checkpoint the backup only contains
+                                          the changes since, a full backup is taken
+                                          if it is empty or the checkpoint does not
+                                          exist anymore
+                                        type: string
+                                      readOnly:
+                                        description: readOnly Will force the ReadOnly
+                                          setting in VolumeMounts. Default false.
+                                        type: boolean
+                                    required:
+                                    - backupName
+                                    - claimName
+                                    type: object
                                   cloudInitConfigDrive:
                                     description: 'CloudInitConfigDrive represents
                                       a cloud-init Config Drive user-data source.
@@ -26898,6 +30224,112 @@ var CRDsValidation map[string]string = map[string]string{
                                       mode should be used. Supported values are: native,
                                       default, threads.'
                                     type: string
+                                  ioTune:
+                                    description: IOTune limits the I/O of the disk.
+                                      The limits can be changed on a running VirtualMachine
+                                      when its liveUpdateFeatures contain ioTune.
+                                    properties:
+                                      read:
+                                        description: Read limits the read operations.
+                                        properties:
+                                          burstBytesPerSecond:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: BurstBytesPerSecond allows
+                                              to exceed the throughput limit up to
+                                              this value for short periods. Requires
+                                              bytesPerSecond.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          burstIOPS:
+                                            description: BurstIOPS allows to exceed
+                                              the operations limit up to this value
+                                              for short periods. Requires iops.
+                                            format: int64
+                                            type: integer
+                                          bytesPerSecond:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: BytesPerSecond limits the
+                                              throughput in bytes per second.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          iops:
+                                            description: IOPS limits the operations
+                                              per second.
+                                            format: int64
+                                            type: integer
+                                        type: object
+                                      total:
+                                        description: Total limits the sum of read
+                                          and write operations.
+                                        properties:
+                                          burstBytesPerSecond:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: BurstBytesPerSecond allows
+                                              to exceed the throughput limit up to
+                                              this value for short periods. Requires
+                                              bytesPerSecond.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          burstIOPS:
+                                            description: BurstIOPS allows to exceed
+                                              the operations limit up to this value
+                                              for short periods. Requires iops.
+                                            format: int64
+                                            type: integer
+                                          bytesPerSecond:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: BytesPerSecond limits the
+                                              throughput in bytes per second.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          iops:
+                                            description: IOPS limits the operations
+                                              per second.
+                                            format: int64
+                                            type: integer
+                                        type: object
+                                      write:
+                                        description: Write limits the write operations.
+                                        properties:
+                                          burstBytesPerSecond:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: BurstBytesPerSecond allows
+                                              to exceed the throughput limit up to
+                                              this value for short periods. Requires
+                                              bytesPerSecond.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          burstIOPS:
+                                            description: BurstIOPS allows to exceed
+                                              the operations limit up to this value
+                                              for short periods. Requires iops.
+                                            format: int64
+                                            type: integer
+                                          bytesPerSecond:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: BytesPerSecond limits the
+                                              throughput in bytes per second.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          iops:
+                                            description: IOPS limits the operations
+                                              per second.
+                                            format: int64
+                                            type: integer
+                                        type: object
+                                    type: object
                                   lun:
                                     description: Attach a volume as a LUN to the vmi.
                                     properties:
@@ -27328,5 +30760,156 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachinesnapshotschedule": `openAPIV3Schema:
+  description: VirtualMachineSnapshotSchedule periodically snapshots the VMs matched
+    by its selector
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule
+        resource
+      properties:
+        deletionPolicy:
+          description: DeletionPolicy is passed on to the created VirtualMachineSnapshots
+          type: string
+        failureDeadline:
+          description: FailureDeadline is passed on to the created VirtualMachineSnapshots
+          type: string
+        retention:
+          description: Retention defines which of the snapshots created by this schedule
+            are kept. If not set all snapshots are kept.
+          properties:
+            keepDaily:
+              description: KeepDaily is the number of days, starting with the most
+                recent one, for which the last succeeded snapshot of the day is kept
+              format: int32
+              type: integer
+            keepLast:
+              description: KeepLast is the number of most recent succeeded snapshots
+                to keep
+              format: int32
+              type: integer
+          type: object
+        schedule:
+          description: Schedule in standard five field cron format, e.g. "0 */6 *
+            * *". The macros @yearly, @monthly, @weekly, @daily and @hourly are supported
+            as well. Times are evaluated in UTC.
+          type: string
+        selector:
+          description: Selector selects the VirtualMachines in the namespace of the
+            schedule which get snapshotted
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements.
+                The requirements are ANDed.
+              items:
+                description: A label selector requirement is a selector that contains
+                  values, a key, and an operator that relates the key and values.
+                properties:
+                  key:
+                    description: key is the label key that the selector applies to.
+                    type: string
+                  operator:
+                    description: operator represents a key's relationship to a set
+                      of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                    type: string
+                  values:
+                    description: values is an array of string values. If the operator
+                      is In or NotIn, the values array must be non-empty. If the operator
+                      is Exists or DoesNotExist, the values array must be empty. This
+                      array is replaced during a strategic merge patch.
+                    items:
+                      type: string
+                    type: array
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: matchLabels is a map of {key,value} pairs. A single {key,value}
+                in the matchLabels map is equivalent to an element of matchExpressions,
+                whose key field is "key", the operator is "In", and the values array
+                contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+        suspend:
+          description: Suspend stops the creation of new snapshots, retention is still
+            applied
+          type: boolean
+      required:
+      - schedule
+      - selector
+      type: object
+    status:
+      description: VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule
+        resource
+      properties:
+        conditions:
+          items:
+            description: Condition defines conditions
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                description: ConditionType is the const type for Conditions
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        lastFailedTime:
+          description: LastFailedTime is the schedule time of the last run in which
+            at least one snapshot failed
+          format: date-time
+          nullable: true
+          type: string
+        lastScheduleTime:
+          description: LastScheduleTime is the time of the last scheduled run
+          format: date-time
+          nullable: true
+          type: string
+        lastSuccessfulTime:
+          description: LastSuccessfulTime is the schedule time of the last run in
+            which all snapshots succeeded
+          format: date-time
+          nullable: true
+          type: string
+        nextScheduleTime:
+          description: NextScheduleTime is the time of the next scheduled run
+          format: date-time
+          nullable: true
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
 `,
 }
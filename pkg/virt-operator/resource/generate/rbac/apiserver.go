@@ -29,7 +29,9 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 
 	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/api/memory"
 	"kubevirt.io/api/migrations"
+	"kubevirt.io/api/quota"
 )
 
 const (
@@ -256,6 +258,39 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					memory.GroupName,
+				},
+				Resources: []string{
+					memory.ResourceNodeMemoryPolicyPlural,
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					quota.GroupName,
+				},
+				Resources: []string{
+					quota.ResourceVMQuotaPlural,
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					quota.GroupName,
+				},
+				Resources: []string{
+					quota.ResourceVMQuotaPlural + "/status",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
 			{
 				APIGroups: []string{
 					"apps",
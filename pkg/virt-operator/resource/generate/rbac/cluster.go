@@ -27,6 +27,7 @@ import (
 
 	virtv1 "kubevirt.io/api/core/v1"
 
+	"kubevirt.io/api/memory"
 	"kubevirt.io/api/migrations"
 )
 
@@ -37,15 +38,19 @@ const (
 	GroupNameClone         = "clone.kubevirt.io"
 	GroupNameInstancetype  = "instancetype.kubevirt.io"
 	GroupNamePool          = "pool.kubevirt.io"
+	GroupNameQuota         = "quota.kubevirt.io"
 	NameDefault            = "kubevirt.io:default"
 	VMInstancesGuestOSInfo = "virtualmachineinstances/guestosinfo"
 	VMInstancesFileSysList = "virtualmachineinstances/filesystemlist"
 	VMInstancesUserList    = "virtualmachineinstances/userlist"
 
+	VMPoolsScale = "virtualmachinepools/scale"
+
 	VMInstancesSEVFetchCertChain         = "virtualmachineinstances/sev/fetchcertchain"
 	VMInstancesSEVQueryLaunchMeasurement = "virtualmachineinstances/sev/querylaunchmeasurement"
 	VMInstancesSEVSetupSession           = "virtualmachineinstances/sev/setupsession"
 	VMInstancesSEVInjectLaunchSecret     = "virtualmachineinstances/sev/injectlaunchsecret"
+	VMInstancesSNPFetchCertChain         = "virtualmachineinstances/snp/fetchcertchain"
 )
 
 func GetAllCluster() []runtime.Object {
@@ -155,6 +160,7 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					VMInstancesUserList,
 					VMInstancesSEVFetchCertChain,
 					VMInstancesSEVQueryLaunchMeasurement,
+					VMInstancesSNPFetchCertChain,
 				},
 				Verbs: []string{
 					"get",
@@ -203,6 +209,7 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/removevolume",
 					"virtualmachines/migrate",
 					"virtualmachines/memorydump",
+					"virtualmachines/upgrade-instancetype",
 				},
 				Verbs: []string{
 					"update",
@@ -242,6 +249,8 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotschedules",
+					"virtualmachinebackups",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
@@ -269,6 +278,17 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
 				},
 			},
+			{
+				APIGroups: []string{
+					GroupNameQuota,
+				},
+				Resources: []string{
+					"virtualmachinequotas",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					GroupNameInstancetype,
@@ -289,6 +309,7 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachinepools",
+					VMPoolsScale,
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
@@ -305,6 +326,17 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					memory.GroupName,
+				},
+				Resources: []string{
+					memory.ResourceNodeMemoryPolicyPlural,
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 		},
 	}
 }
@@ -337,6 +369,7 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					VMInstancesUserList,
 					VMInstancesSEVFetchCertChain,
 					VMInstancesSEVQueryLaunchMeasurement,
+					VMInstancesSNPFetchCertChain,
 				},
 				Verbs: []string{
 					"get",
@@ -385,6 +418,7 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/removevolume",
 					"virtualmachines/migrate",
 					"virtualmachines/memorydump",
+					"virtualmachines/upgrade-instancetype",
 				},
 				Verbs: []string{
 					"update",
@@ -424,6 +458,8 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotschedules",
+					"virtualmachinebackups",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch",
@@ -451,6 +487,17 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					GroupNameQuota,
+				},
+				Resources: []string{
+					"virtualmachinequotas",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					GroupNameInstancetype,
@@ -471,6 +518,7 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachinepools",
+					VMPoolsScale,
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch",
@@ -498,6 +546,17 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					memory.GroupName,
+				},
+				Resources: []string{
+					memory.ResourceNodeMemoryPolicyPlural,
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 		},
 	}
 }
@@ -527,6 +586,7 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					VMInstancesUserList,
 					VMInstancesSEVFetchCertChain,
 					VMInstancesSEVQueryLaunchMeasurement,
+					VMInstancesSNPFetchCertChain,
 				},
 				Verbs: []string{
 					"get",
@@ -566,6 +626,8 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"virtualmachinesnapshots",
 					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
+					"virtualmachinesnapshotschedules",
+					"virtualmachinebackups",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
@@ -593,6 +655,17 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					GroupNameQuota,
+				},
+				Resources: []string{
+					"virtualmachinequotas",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					GroupNameInstancetype,
@@ -613,6 +686,7 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachinepools",
+					VMPoolsScale,
 				},
 				Verbs: []string{
 					"get", "list", "watch",
@@ -629,6 +703,17 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					memory.GroupName,
+				},
+				Resources: []string{
+					memory.ResourceNodeMemoryPolicyPlural,
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 		},
 	}
 }
@@ -32,6 +32,7 @@ import (
 
 	virtv1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/api/migrations"
+	"kubevirt.io/api/quota"
 )
 
 func GetAllController(namespace string) []runtime.Object {
@@ -380,6 +381,17 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"update",
 				},
 			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					VMInstancesUserList,
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
 			{
 				APIGroups: []string{
 					"cdi.kubevirt.io",
@@ -491,6 +503,18 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					migrations.GroupName,
+				},
+				Resources: []string{
+					migrations.ResourceNodeEvacuations,
+					migrations.ResourceNodeEvacuations + "/status",
+				},
+				Verbs: []string{
+					"get", "list", "watch", "create", "update", "patch", "delete",
+				},
+			},
 			{
 				APIGroups: []string{
 					clone.GroupName,
@@ -504,6 +528,18 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch", "update", "patch", "delete",
 				},
 			},
+			{
+				APIGroups: []string{
+					quota.GroupName,
+				},
+				Resources: []string{
+					quota.ResourceVMQuotaPlural,
+					quota.ResourceVMQuotaPlural + "/status",
+				},
+				Verbs: []string{
+					"get", "list", "watch", "update", "patch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"",
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	virtv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/api/memory"
 	"kubevirt.io/api/migrations"
 
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
@@ -156,6 +157,17 @@ func newHandlerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					memory.GroupName,
+				},
+				Resources: []string{
+					memory.ResourceNodeMemoryPolicyPlural,
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 		},
 	}
 }
@@ -0,0 +1,193 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/client-go/tools/clientcmd"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_UPGRADE_INSTANCETYPE = "upgrade-instancetype"
+	selectorArg                  = "selector"
+	selectorArgShort             = "l"
+)
+
+var selector string
+
+func NewUpgradeInstancetypeCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "upgrade-instancetype (VM)",
+		Short:   "Upgrade the instancetype and preference of a virtual machine to their latest revisions.",
+		Example: usageUpgradeInstancetype(),
+		Args:    upgradeInstancetypeArgs(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_UPGRADE_INSTANCETYPE, clientConfig: clientConfig}
+			return c.upgradeInstancetypeRun(args, cmd)
+		},
+	}
+	cmd.Flags().StringVarP(&selector, selectorArg, selectorArgShort, "", "Upgrade all virtual machines matching the label selector instead of a single virtual machine.")
+	cmd.Flags().BoolVar(&dryRun, dryRunArg, false, dryRunCommandUsage)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func (o *Command) upgradeInstancetypeRun(args []string, cmd *cobra.Command) error {
+	virtClient, namespace, err := GetNamespaceAndClient(o.clientConfig)
+	if err != nil {
+		return err
+	}
+
+	var vms []v1.VirtualMachine
+	if selector != "" {
+		vmList, err := virtClient.VirtualMachine(namespace).List(context.Background(), &metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return fmt.Errorf("error listing VirtualMachines in namespace %s: %v", namespace, err)
+		}
+		for _, vm := range vmList.Items {
+			if vm.Spec.Instancetype != nil || vm.Spec.Preference != nil {
+				vms = append(vms, vm)
+			}
+		}
+		if len(vms) == 0 {
+			cmd.Printf("No VirtualMachines with an instancetype or preference match the selector %s\n", selector)
+			return nil
+		}
+	} else {
+		vm, err := virtClient.VirtualMachine(namespace).Get(context.Background(), args[0], &metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting VirtualMachine %s: %v", args[0], err)
+		}
+		vms = append(vms, *vm)
+	}
+
+	failed := 0
+	for i := range vms {
+		if err := upgradeInstancetype(cmd, virtClient, &vms[i]); err != nil {
+			if len(vms) == 1 {
+				return err
+			}
+			cmd.PrintErrln(err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to upgrade %d of %d VirtualMachines", failed, len(vms))
+	}
+	return nil
+}
+
+func upgradeInstancetype(cmd *cobra.Command, virtClient kubecli.KubevirtClient, vm *v1.VirtualMachine) error {
+	options := &v1.UpgradeInstancetypeOptions{DryRun: setDryRunOption(dryRun)}
+
+	upgradedVM, err := virtClient.VirtualMachine(vm.Namespace).UpgradeInstancetype(context.Background(), vm.Name, options)
+	if err != nil {
+		return fmt.Errorf("error upgrading the instancetype of VirtualMachine %s: %v", vm.Name, err)
+	}
+
+	instancetypeChanged := vm.Spec.Instancetype != nil && vm.Spec.Instancetype.RevisionName != upgradedVM.Spec.Instancetype.RevisionName
+	preferenceChanged := vm.Spec.Preference != nil && vm.Spec.Preference.RevisionName != upgradedVM.Spec.Preference.RevisionName
+	if !instancetypeChanged && !preferenceChanged {
+		cmd.Printf("VirtualMachine %s already uses the latest instancetype and preference revisions\n", vm.Name)
+		return nil
+	}
+
+	action := "was upgraded"
+	if dryRun {
+		action = "would be upgraded"
+	}
+	if instancetypeChanged {
+		cmd.Printf("VirtualMachine %s %s from instancetype revision %q to %q\n", vm.Name, action,
+			vm.Spec.Instancetype.RevisionName, upgradedVM.Spec.Instancetype.RevisionName)
+	}
+	if preferenceChanged {
+		cmd.Printf("VirtualMachine %s %s from preference revision %q to %q\n", vm.Name, action,
+			vm.Spec.Preference.RevisionName, upgradedVM.Spec.Preference.RevisionName)
+	}
+
+	if dryRun {
+		return printUpgradeDiff(cmd, virtClient, vm, upgradedVM)
+	}
+	return nil
+}
+
+// printUpgradeDiff shows how the expanded VirtualMachineInstance spec changes with the upgrade. The revisions of a
+// dry run are not stored, so the latest instancetype and preference are expanded directly instead.
+func printUpgradeDiff(cmd *cobra.Command, virtClient kubecli.KubevirtClient, vm, upgradedVM *v1.VirtualMachine) error {
+	expandedVM, err := virtClient.VirtualMachine(vm.Namespace).GetWithExpandedSpec(context.Background(), vm.Name)
+	if err != nil {
+		return fmt.Errorf("error expanding VirtualMachine %s: %v", vm.Name, err)
+	}
+
+	latestVM := upgradedVM.DeepCopy()
+	if latestVM.Spec.Instancetype != nil {
+		latestVM.Spec.Instancetype.RevisionName = ""
+	}
+	if latestVM.Spec.Preference != nil {
+		latestVM.Spec.Preference.RevisionName = ""
+	}
+	expandedLatestVM, err := virtClient.ExpandSpec(vm.Namespace).ForVirtualMachine(latestVM)
+	if err != nil {
+		return fmt.Errorf("error expanding VirtualMachine %s with the latest instancetype and preference: %v", vm.Name, err)
+	}
+
+	specDiff := diff.ObjectReflectDiff(expandedVM.Spec.Template.Spec, expandedLatestVM.Spec.Template.Spec)
+	if specDiff == "<no diffs>" {
+		cmd.Printf("The expanded spec of VirtualMachine %s does not change\n", vm.Name)
+		return nil
+	}
+	cmd.Printf("Changes to the expanded spec of VirtualMachine %s:\n%s\n", vm.Name, specDiff)
+	return nil
+}
+
+func usageUpgradeInstancetype() string {
+	return `  # Upgrade the instancetype and preference of a virtual machine called 'myvm' to their latest revisions:
+  {{ProgramName}} upgrade-instancetype myvm
+
+  # Show the changes an upgrade would make to a virtual machine called 'myvm':
+  {{ProgramName}} upgrade-instancetype myvm --dry-run
+
+  # Upgrade all virtual machines with the label 'app=web':
+  {{ProgramName}} upgrade-instancetype --selector app=web`
+}
+
+func upgradeInstancetypeArgs() cobra.PositionalArgs {
+	return func(_ *cobra.Command, args []string) error {
+		if selector != "" {
+			if len(args) != 0 {
+				return fmt.Errorf("error invalid arguments - VirtualMachine name and selector are mutually exclusive")
+			}
+			return nil
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("error invalid arguments - VirtualMachine name or selector must be provided")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vm_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	"kubevirt.io/kubevirt/tests/clientcmd"
+)
+
+var _ = Describe("Upgrade instancetype command", func() {
+	var vm *v1.VirtualMachine
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var expandSpecInterface *kubecli.MockExpandSpecInterface
+	var ctrl *gomock.Controller
+	const vmName = "testvm"
+
+	upgraded := func(vm *v1.VirtualMachine) *v1.VirtualMachine {
+		upgradedVM := vm.DeepCopy()
+		upgradedVM.Spec.Instancetype.RevisionName = "instancetype-revision-2"
+		return upgradedVM
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		expandSpecInterface = kubecli.NewMockExpandSpecInterface(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+
+		vm = kubecli.NewMinimalVM(vmName)
+		vm.Namespace = k8smetav1.NamespaceDefault
+		vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{}
+		vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+			Name:         "instancetype",
+			RevisionName: "instancetype-revision-1",
+		}
+	})
+
+	DescribeTable("should fail with invalid arguments", func(expectedErr string, args ...string) {
+		cmd := clientcmd.NewRepeatableVirtctlCommand(append([]string{"upgrade-instancetype"}, args...)...)
+		Expect(cmd()).To(MatchError(expectedErr))
+	},
+		Entry("without VM name or selector", "error invalid arguments - VirtualMachine name or selector must be provided"),
+		Entry("with VM name and selector", "error invalid arguments - VirtualMachine name and selector are mutually exclusive", vmName, "--selector", "app=web"),
+	)
+
+	It("should upgrade the instancetype of a VM", func() {
+		vmInterface.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+		vmInterface.EXPECT().UpgradeInstancetype(context.Background(), vmName, &v1.UpgradeInstancetypeOptions{}).Return(upgraded(vm), nil)
+
+		cmd := clientcmd.NewRepeatableVirtctlCommandWithOut("upgrade-instancetype", vmName)
+		out, err := cmd()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring(`VirtualMachine testvm was upgraded from instancetype revision "instancetype-revision-1" to "instancetype-revision-2"`))
+	})
+
+	It("should report when a VM already uses the latest revisions", func() {
+		vmInterface.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+		vmInterface.EXPECT().UpgradeInstancetype(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+
+		cmd := clientcmd.NewRepeatableVirtctlCommandWithOut("upgrade-instancetype", vmName)
+		out, err := cmd()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("VirtualMachine testvm already uses the latest instancetype and preference revisions"))
+	})
+
+	It("should show the changes to the expanded spec with --dry-run", func() {
+		expandedVM := vm.DeepCopy()
+		expandedVM.Spec.Template.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+			k8sv1.ResourceMemory: resource.MustParse("1Gi"),
+		}
+		expandedLatestVM := vm.DeepCopy()
+		expandedLatestVM.Spec.Template.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+			k8sv1.ResourceMemory: resource.MustParse("2Gi"),
+		}
+
+		vmInterface.EXPECT().Get(context.Background(), vmName, gomock.Any()).Return(vm, nil)
+		vmInterface.EXPECT().UpgradeInstancetype(context.Background(), vmName, &v1.UpgradeInstancetypeOptions{
+			DryRun: []string{k8smetav1.DryRunAll},
+		}).Return(upgraded(vm), nil)
+		vmInterface.EXPECT().GetWithExpandedSpec(context.Background(), vmName).Return(expandedVM, nil)
+		kubecli.MockKubevirtClientInstance.EXPECT().ExpandSpec(k8smetav1.NamespaceDefault).Return(expandSpecInterface)
+		expandSpecInterface.EXPECT().ForVirtualMachine(gomock.Any()).DoAndReturn(func(latestVM *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+			Expect(latestVM.Spec.Instancetype.RevisionName).To(BeEmpty())
+			return expandedLatestVM, nil
+		})
+
+		cmd := clientcmd.NewRepeatableVirtctlCommandWithOut("upgrade-instancetype", vmName, "--dry-run")
+		out, err := cmd()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(And(
+			ContainSubstring(`VirtualMachine testvm would be upgraded from instancetype revision "instancetype-revision-1" to "instancetype-revision-2"`),
+			ContainSubstring("Changes to the expanded spec of VirtualMachine testvm"),
+		))
+	})
+
+	It("should upgrade all VMs with an instancetype matching the selector", func() {
+		otherVM := kubecli.NewMinimalVM("othervm")
+		otherVM.Namespace = k8smetav1.NamespaceDefault
+		otherVM.Spec.Instancetype = vm.Spec.Instancetype.DeepCopy()
+		withoutInstancetypeVM := kubecli.NewMinimalVM("plainvm")
+
+		vmInterface.EXPECT().List(context.Background(), &k8smetav1.ListOptions{LabelSelector: "app=web"}).Return(&v1.VirtualMachineList{
+			Items: []v1.VirtualMachine{*vm, *otherVM, *withoutInstancetypeVM},
+		}, nil)
+		vmInterface.EXPECT().UpgradeInstancetype(context.Background(), vmName, gomock.Any()).Return(upgraded(vm), nil)
+		vmInterface.EXPECT().UpgradeInstancetype(context.Background(), otherVM.Name, gomock.Any()).Return(nil, fmt.Errorf("conflict"))
+
+		cmd := clientcmd.NewRepeatableVirtctlCommandWithOut("upgrade-instancetype", "--selector", "app=web")
+		out, err := cmd()
+		Expect(err).To(MatchError("failed to upgrade 1 of 2 VirtualMachines"))
+		Expect(string(out)).To(ContainSubstring("VirtualMachine testvm was upgraded"))
+	})
+})
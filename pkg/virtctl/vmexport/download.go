@@ -0,0 +1,426 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package vmexport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/cheggaaa/pb/v3"
+
+	exportv1 "kubevirt.io/api/export/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+// downloadProgress is stored next to the output file while a raw volume is downloaded with range requests
+type downloadProgress struct {
+	// Size is the size of the volume
+	Size int64 `json:"size"`
+	// Parts are the ranges of the volume which are downloaded concurrently
+	Parts []downloadPart `json:"parts"`
+}
+
+type downloadPart struct {
+	// Offset is the first byte of the part which was not downloaded yet
+	Offset int64 `json:"offset"`
+	// End is the last byte of the part
+	End int64 `json:"end"`
+}
+
+// rangeDownloader downloads a raw volume to a file with range requests. The volume is split into one part per
+// connection, and a part is requested again from where it stopped when the connection drops. The progress is
+// stored in progressFile, so an interrupted download can be continued with --resume.
+type rangeDownloader struct {
+	client       kubecli.KubevirtClient
+	vmexport     *exportv1.VirtualMachineExport
+	vmeInfo      *VMExportInfo
+	downloadUrl  string
+	output       *os.File
+	progressFile string
+
+	size     int64
+	bar      *pb.ProgressBar
+	lock     sync.Mutex
+	progress downloadProgress
+	unsaved  int64
+}
+
+func (d *rangeDownloader) download() error {
+	// Only request the first byte to learn the size of the volume
+	resp, err := HandleHTTPRequest(d.client, d.vmexport, d.downloadUrl, d.vmeInfo.Insecure, d.vmeInfo.ServiceURL, map[string]string{rangeHeader: "bytes=0-0"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// The server ignored the range, so the whole volume is in the response
+		return d.downloadWithoutRanges(resp)
+	case http.StatusPartialContent, http.StatusRequestedRangeNotSatisfiable:
+		d.size, err = parseContentRangeSize(resp.Header.Get("Content-Range"))
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	if err := d.initProgress(); err != nil {
+		return err
+	}
+
+	barTemplate := fmt.Sprintf(`{{ "Downloading file:" }} {{counters . }} {{ cycle . %s }} {{speed . }}`, progressBarCycle)
+	d.bar = pb.ProgressBarTemplate(barTemplate).Start64(d.size)
+	defer d.bar.Finish()
+	d.bar.SetCurrent(d.downloaded())
+
+	errs := make([]error, len(d.progress.Parts))
+	wg := sync.WaitGroup{}
+	for i := range d.progress.Parts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = d.downloadPart(&d.progress.Parts[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		d.lock.Lock()
+		saveErr := d.saveProgress()
+		d.lock.Unlock()
+		if saveErr != nil {
+			return fmt.Errorf("%v, unable to save the download progress: %v", err, saveErr)
+		}
+		return fmt.Errorf("%v, run the command again with %s to continue the download", err, RESUME_FLAG)
+	}
+
+	if err := os.Remove(d.progressFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (d *rangeDownloader) downloadWithoutRanges(resp *http.Response) error {
+	if err := d.output.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := d.output.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	counter := &countingWriter{w: d.output}
+	if err := copyFileWithProgressBar(counter, resp, false); err != nil {
+		return err
+	}
+	d.size = counter.n
+	return nil
+}
+
+// initProgress loads the progress of an interrupted download or splits the volume into parts
+func (d *rangeDownloader) initProgress() error {
+	if d.vmeInfo.Resume {
+		data, err := os.ReadFile(d.progressFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &d.progress); err != nil {
+				return fmt.Errorf("unable to read the download progress from %s: %v", d.progressFile, err)
+			}
+			if d.progress.Size == d.size {
+				return d.output.Truncate(d.size)
+			}
+			fmt.Printf("The size of the volume changed, starting the download over\n")
+		}
+	}
+
+	// Parts which only contain zeros are not written, so any previous content has to be dropped
+	if err := d.output.Truncate(0); err != nil {
+		return err
+	}
+	if err := d.output.Truncate(d.size); err != nil {
+		return err
+	}
+
+	d.progress = downloadProgress{Size: d.size}
+	parallel := int64(d.vmeInfo.Parallel)
+	if parallel < 1 {
+		parallel = 1
+	}
+	partSize := (d.size + parallel - 1) / parallel
+	for offset := int64(0); offset < d.size; offset += partSize {
+		end := offset + partSize - 1
+		if end >= d.size {
+			end = d.size - 1
+		}
+		d.progress.Parts = append(d.progress.Parts, downloadPart{Offset: offset, End: end})
+	}
+	return nil
+}
+
+// downloadPart requests the remaining bytes of the part until it is complete, or until
+// it was requested maxDownloadRetries times in a row without making progress
+func (d *rangeDownloader) downloadPart(part *downloadPart) error {
+	failures := 0
+	for {
+		d.lock.Lock()
+		offset, end := part.Offset, part.End
+		d.lock.Unlock()
+		if offset > end {
+			return nil
+		}
+
+		progressed, err := d.downloadRange(part, offset, end)
+		if err == nil {
+			continue
+		}
+		if progressed {
+			failures = 0
+		}
+		failures++
+		if failures > maxDownloadRetries {
+			return err
+		}
+		fmt.Printf("Download of bytes %d-%d interrupted, retrying: %v\n", offset, end, err)
+		time.Sleep(time.Duration(failures) * DownloadRetryInterval)
+	}
+}
+
+func (d *rangeDownloader) downloadRange(part *downloadPart, offset, end int64) (bool, error) {
+	resp, err := HandleHTTPRequest(d.client, d.vmexport, d.downloadUrl, d.vmeInfo.Insecure, d.vmeInfo.ServiceURL, map[string]string{rangeHeader: fmt.Sprintf("bytes=%d-%d", offset, end)})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return false, fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	progressed := false
+	buf := make([]byte, downloadBufferSize)
+	for offset <= end {
+		if remaining := end - offset + 1; remaining < int64(len(buf)) {
+			buf = buf[:remaining]
+		}
+		n, err := io.ReadFull(resp.Body, buf)
+		if n > 0 {
+			// Skipping zeros keeps the output file sparse, the file was truncated to the size of the volume
+			if !isZero(buf[:n]) {
+				if _, err := d.output.WriteAt(buf[:n], offset); err != nil {
+					return progressed, err
+				}
+			}
+			offset += int64(n)
+			progressed = true
+			if err := d.advance(part, offset, n); err != nil {
+				return progressed, err
+			}
+		}
+		if err != nil {
+			return progressed, err
+		}
+	}
+	return progressed, nil
+}
+
+// advance records the progress of the part and saves it from time to time
+func (d *rangeDownloader) advance(part *downloadPart, offset int64, n int) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	part.Offset = offset
+	d.bar.Add(n)
+	d.unsaved += int64(n)
+	if d.unsaved < progressSaveInterval {
+		return nil
+	}
+	d.unsaved = 0
+	return d.saveProgress()
+}
+
+func (d *rangeDownloader) downloaded() int64 {
+	downloaded := d.progress.Size
+	for _, part := range d.progress.Parts {
+		downloaded -= part.End - part.Offset + 1
+	}
+	return downloaded
+}
+
+// saveProgress has to be called with the lock held
+func (d *rangeDownloader) saveProgress() error {
+	data, err := json.Marshal(d.progress)
+	if err != nil {
+		return err
+	}
+	tmpFile := d.progressFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, d.progressFile)
+}
+
+// parseContentRangeSize returns the complete length from a Content-Range header, like "bytes 0-0/1024" or "bytes */1024"
+func parseContentRangeSize(contentRange string) (int64, error) {
+	i := strings.LastIndex(contentRange, "/")
+	if !strings.HasPrefix(contentRange, "bytes ") || i < 0 {
+		return 0, fmt.Errorf("invalid Content-Range header %q", contentRange)
+	}
+	size, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Content-Range header %q: %v", contentRange, err)
+	}
+	return size, nil
+}
+
+func isZero(buf []byte) bool {
+	for _, b := range buf {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type checksumResult struct {
+	checksum string
+	err      error
+}
+
+// requestChecksum gets the checksum of the volume in the background
+func requestChecksum(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, checksumUrl string) <-chan checksumResult {
+	result := make(chan checksumResult, 1)
+	go func() {
+		checksum, err := getChecksum(client, vmexport, vmeInfo, checksumUrl)
+		result <- checksumResult{checksum: checksum, err: err}
+	}()
+	return result
+}
+
+// getChecksum requests the checksum until the export server has finished calculating it
+func getChecksum(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, checksumUrl string) (string, error) {
+	deadline := preparingDeadline(client)
+	for {
+		checksum, retryAfter, err := requestChecksumOnce(client, vmexport, vmeInfo, checksumUrl)
+		if err != nil || retryAfter == 0 {
+			return checksum, err
+		}
+		if time.Now().Add(retryAfter).After(deadline) {
+			return "", fmt.Errorf("timed out waiting for the export server to calculate the checksum")
+		}
+		time.Sleep(retryAfter)
+	}
+}
+
+// preparingDeadline returns the time until which requests the export server is still preparing are repeated
+func preparingDeadline(client kubecli.KubevirtClient) time.Time {
+	timeout := preparingWaitTotal
+	if config := client.Config(); config != nil && config.Timeout > 0 {
+		timeout = config.Timeout
+	}
+	return time.Now().Add(timeout)
+}
+
+func requestChecksumOnce(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, checksumUrl string) (string, time.Duration, error) {
+	resp, err := HandleHTTPRequest(client, vmexport, checksumUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusAccepted {
+		return "", retryAfter(resp), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("bad status: %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", 0, err
+	}
+	// The checksum is in the format of sha256sum, followed by the file name
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return "", 0, fmt.Errorf("empty checksum")
+	}
+	return strings.ToLower(fields[0]), 0, nil
+}
+
+// verifyChecksum waits for the checksum of the server and compares it to the checksum of the downloaded volume
+func verifyChecksum(expected <-chan checksumResult, checksum string) error {
+	result := <-expected
+	if result.err != nil {
+		return fmt.Errorf("unable to get the checksum of the volume: %v", result.err)
+	}
+	if result.checksum != checksum {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", result.checksum, checksum)
+	}
+	return nil
+}
+
+// requestVolume requests the volume until the export server has finished preparing it
+func requestVolume(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo, downloadUrl string) (*http.Response, error) {
+	deadline := preparingDeadline(client)
+	waiting := false
+	for {
+		resp, err := HandleHTTPRequest(client, vmexport, downloadUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, nil)
+		if err != nil || resp.StatusCode != http.StatusAccepted {
+			return resp, err
+		}
+		resp.Body.Close()
+		interval := retryAfter(resp)
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the export server to prepare the volume")
+		}
+		if !waiting && vmeInfo.OutputFile != "" {
+			fmt.Println("Waiting for the export server to prepare the volume")
+		}
+		waiting = true
+		time.Sleep(interval)
+	}
+}
+
+// retryAfter returns the time to wait before repeating a request the export server is still preparing
+func retryAfter(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return RetryAfterInterval
+}
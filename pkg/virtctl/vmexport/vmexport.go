@@ -22,8 +22,10 @@ package vmexport
 import (
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log"
@@ -69,6 +71,7 @@ const (
 	VOLUME_FLAG         = "--volume"
 	VM_FLAG             = "--vm"
 	SNAPSHOT_FLAG       = "--snapshot"
+	BACKUP_FLAG         = "--backup"
 	INSECURE_FLAG       = "--insecure"
 	KEEP_FLAG           = "--keep-vme"
 	FORMAT_FLAG         = "--format"
@@ -80,6 +83,8 @@ const (
 	INCLUDE_SECRET_FLAG = "--include-secret"
 	PORT_FORWARD_FLAG   = "--port-forward"
 	LOCAL_PORT_FLAG     = "--local-port"
+	PARALLEL_FLAG       = "--parallel"
+	RESUME_FLAG         = "--resume"
 
 	// Possible output format for manifests
 	OUTPUT_FORMAT_JSON = "json"
@@ -97,6 +102,9 @@ const (
 	processingWaitInterval = 2 * time.Second
 	// processingWaitTotal is the maximum time used to wait for a virtualMachineExport to be ready
 	processingWaitTotal = 2 * time.Minute
+	// preparingWaitTotal is the maximum time used to wait for the export server to prepare a checksum or a volume,
+	// unless a different timeout is set with --request-timeout
+	preparingWaitTotal = time.Hour
 
 	// exportTokenHeader is the http header used to download the exported volume using the secret token
 	exportTokenHeader = "x-kubevirt-export-token"
@@ -105,12 +113,23 @@ const (
 	// secretTokenLenght is the lenght of the randomly generated token
 	secretTokenLenght = 20
 
+	// rangeHeader is the http header used to request a part of the exported volume
+	rangeHeader = "Range"
+	// maxDownloadRetries is the number of times a part of the volume is requested again without making progress
+	maxDownloadRetries = 5
+	// downloadBufferSize is the size of the buffer used to write the parts of the volume to the output file
+	downloadBufferSize = 1024 * 1024
+	// progressSaveInterval is the amount of downloaded bytes after which the download progress is stored
+	progressSaveInterval = 64 * 1024 * 1024
+	// progressFileSuffix is appended to the output file name to get the file which stores the download progress
+	progressFileSuffix = ".progress"
+
 	// ErrRequiredFlag serves as error message when a mandatory flag is missing
 	ErrRequiredFlag = "need to specify the '%s' flag when using '%s'"
 	// ErrIncompatibleFlag serves as error message when an incompatible flag is used
 	ErrIncompatibleFlag = "the '%s' flag is incompatible with '%s'"
 	// ErrRequiredExportType serves as error message when no export kind is provided
-	ErrRequiredExportType = "need to specify export kind when attempting to create a VirtualMachineExport [--pvc|--vm|--snapshot]"
+	ErrRequiredExportType = "need to specify export kind when attempting to create a VirtualMachineExport [--pvc|--vm|--snapshot|--backup]"
 	// ErrIncompatibleExportType serves as error message when an export kind is provided with an incompatible argument
 	ErrIncompatibleExportType = "should not specify export kind"
 	// ErrIncompatibleExportTypeManifest serves as error message when a PVC kind is defined when getting manifest
@@ -126,6 +145,7 @@ var (
 	// Flags
 	vm                   string
 	snapshot             string
+	backup               string
 	pvc                  string
 	outputFile           string
 	insecure             bool
@@ -140,6 +160,8 @@ var (
 	volumeName           string
 	ttl                  string
 	manifestOutputFormat string
+	parallel             int
+	resume               bool
 )
 
 type exportFunc func(client kubecli.KubevirtClient, vmeInfo *VMExportInfo) error
@@ -154,6 +176,14 @@ type exportCompleteFunc func(kubecli.KubevirtClient, *VMExportInfo, time.Duratio
 // Useful for unit tests.
 var ExportProcessingComplete exportCompleteFunc = waitForVirtualMachineExport
 
+// DownloadRetryInterval is the time waited before requesting a part of the volume again, it grows with every failed attempt.
+// Useful for unit tests.
+var DownloadRetryInterval = time.Second
+
+// RetryAfterInterval is the time waited before requesting a checksum or a volume that the export server is
+// still preparing, unless the export server suggests a different interval. Useful for unit tests.
+var RetryAfterInterval = 5 * time.Second
+
 type VMExportInfo struct {
 	ShouldCreate   bool
 	Insecure       bool
@@ -163,6 +193,8 @@ type VMExportInfo struct {
 	Decompress     bool
 	PortForward    bool
 	LocalPort      string
+	Parallel       int
+	Resume         bool
 	OutputFile     string
 	OutputWriter   io.Writer
 	VolumeName     string
@@ -218,6 +250,9 @@ func usage() string {
 	# Create a VirtualMachineExport to export a volume from a virtual machine snapshot
 	{{ProgramName}} vmexport create snap1-export --snapshot=snap1
   
+	# Create a VirtualMachineExport to export the disks of a virtual machine backup
+	{{ProgramName}} vmexport create backup1-export --backup=backup1
+
 	# Create a VirtualMachineExport to export a volume from a PVC
 	{{ProgramName}} vmexport create pvc1-export --pvc=pvc1
   
@@ -227,6 +262,9 @@ func usage() string {
 	# Download a volume from an already existing VirtualMachineExport (--volume is optional when only one volume is available)
 	{{ProgramName}} vmexport download vm1-export --volume=volume1 --output=disk.img.gz
 
+	# Download a raw volume using 4 connections, the download can be continued with --resume if it gets interrupted
+	{{ProgramName}} vmexport download vm1-export --volume=volume1 --output=disk.img --format=raw --parallel=4
+
 	# Download a volume as before but through local port 5410
 	{{ProgramName}} vmexport download vm1-export --volume=volume1 --output=disk.img.gz --port-forward --local-port=5410
   
@@ -256,8 +294,9 @@ func NewVirtualMachineExportCommand(clientConfig clientcmd.ClientConfig) *cobra.
 
 	cmd.Flags().StringVar(&vm, "vm", "", "Sets VirtualMachine as vmexport kind and specifies the vm name.")
 	cmd.Flags().StringVar(&snapshot, "snapshot", "", "Sets VirtualMachineSnapshot as vmexport kind and specifies the snapshot name.")
+	cmd.Flags().StringVar(&backup, "backup", "", "Sets VirtualMachineBackup as vmexport kind and specifies the backup name.")
 	cmd.Flags().StringVar(&pvc, "pvc", "", "Sets PersistentVolumeClaim as vmexport kind and specifies the PVC name.")
-	cmd.MarkFlagsMutuallyExclusive("vm", "snapshot", "pvc")
+	cmd.MarkFlagsMutuallyExclusive("vm", "snapshot", "backup", "pvc")
 	cmd.Flags().StringVar(&outputFile, "output", "", "Specifies the output path of the volume to be downloaded.")
 	cmd.Flags().StringVar(&volumeName, "volume", "", "Specifies the volume to be downloaded.")
 	cmd.Flags().StringVar(&format, "format", "", "Used to specify the format of the downloaded image. There's two options: gzip (default) and raw.")
@@ -270,6 +309,8 @@ func NewVirtualMachineExportCommand(clientConfig clientcmd.ClientConfig) *cobra.
 	cmd.Flags().StringVar(&localPort, "local-port", "0", "Defines the specific port to be used in port-forward.")
 	cmd.Flags().BoolVar(&includeSecret, "include-secret", false, "When used with manifest and set to true include a secret that contains proper headers for CDI to import using the manifest")
 	cmd.Flags().BoolVar(&exportManifest, "manifest", false, "Instead of downloading a volume, retrieve the VM manifest")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "When used with the 'download' option, specifies the number of connections used to download the raw volume. Requires --output.")
+	cmd.Flags().BoolVar(&resume, "resume", false, "When used with the 'download' option, continues an interrupted download of the raw volume to the output file. Requires --output.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
 	return cmd
@@ -346,9 +387,13 @@ func (c *command) parseExportArguments(args []string, vmeInfo *VMExportInfo) err
 func (c *command) initVMExportInfo(vmeInfo *VMExportInfo) error {
 	vmeInfo.ExportSource = getExportSource()
 	vmeInfo.OutputFile = outputFile
-	// User wants the output in a file, create
+	// User wants the output in a file, create it or keep its content when resuming a download
 	if outputFile != "" {
-		output, err := os.Create(vmeInfo.OutputFile)
+		flags := os.O_RDWR | os.O_CREATE
+		if !resume {
+			flags |= os.O_TRUNC
+		}
+		output, err := os.OpenFile(vmeInfo.OutputFile, flags, 0666)
 		if err != nil {
 			return err
 		}
@@ -368,6 +413,8 @@ func (c *command) initVMExportInfo(vmeInfo *VMExportInfo) error {
 	vmeInfo.OutputFormat = manifestOutputFormat
 	vmeInfo.IncludeSecret = includeSecret
 	vmeInfo.ExportManifest = exportManifest
+	vmeInfo.Parallel = parallel
+	vmeInfo.Resume = resume
 	if portForward {
 		vmeInfo.PortForward = portForward
 		vmeInfo.Insecure = true
@@ -547,25 +594,78 @@ func getVirtualMachineManifest(client kubecli.KubevirtClient, vmexport *exportv1
 // downloadVolume handles the process of downloading the requested volume from a VirtualMachineExport
 func downloadVolume(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) error {
 	// Extract the URL from the vmexport
-	downloadUrl, err := GetUrlFromVirtualMachineExport(vmexport, vmeInfo)
+	volume, format, err := getVolumeFormatFromVirtualMachineExport(vmexport, vmeInfo)
 	if err != nil {
 		return err
 	}
-
-	resp, err := HandleHTTPRequest(client, vmexport, downloadUrl, vmeInfo.Insecure, vmeInfo.ServiceURL, nil)
+	downloadUrl, err := replaceUrlWithServiceUrl(format.Url, vmeInfo)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	// Check server response
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("bad status: %s", resp.Status)
+	// The checksum is calculated on the raw volume, so it can only be verified when the output is raw.
+	// It is requested right away, as the server calculates it while the volume is being downloaded.
+	var checksum <-chan checksumResult
+	if format.Format == exportv1.KubeVirtRaw || (format.Format == exportv1.KubeVirtGz && vmeInfo.Decompress) {
+		for _, c := range volume.Checksums {
+			if c.Algorithm != exportv1.SHA256 {
+				continue
+			}
+			checksumUrl, err := replaceUrlWithServiceUrl(c.Url, vmeInfo)
+			if err != nil {
+				return err
+			}
+			checksum = requestChecksum(client, vmexport, vmeInfo, checksumUrl)
+		}
 	}
 
-	// Lastly, copy the file to the expected output
-	if err := copyFileWithProgressBar(vmeInfo.OutputWriter, resp, vmeInfo.Decompress); err != nil {
-		return err
+	hash := sha256.New()
+	if output, ok := vmeInfo.OutputWriter.(*os.File); ok && vmeInfo.OutputFile != "" && format.Format == exportv1.KubeVirtRaw {
+		// Raw volumes support range requests, which allow to download the volume over multiple
+		// connections and to continue the download after the connection drops
+		d := &rangeDownloader{
+			client:       client,
+			vmexport:     vmexport,
+			vmeInfo:      vmeInfo,
+			downloadUrl:  downloadUrl,
+			output:       output,
+			progressFile: vmeInfo.OutputFile + progressFileSuffix,
+		}
+		if err := d.download(); err != nil {
+			return err
+		}
+		if checksum != nil {
+			fmt.Println("Calculating checksum of the downloaded volume")
+			if _, err := io.Copy(hash, io.NewSectionReader(output, 0, d.size)); err != nil {
+				return err
+			}
+		}
+	} else {
+		resp, err := requestVolume(client, vmexport, vmeInfo, downloadUrl)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		// Check server response
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("bad status: %s", resp.Status)
+		}
+
+		// Lastly, copy the file to the expected output
+		output := vmeInfo.OutputWriter
+		if checksum != nil {
+			output = io.MultiWriter(output, hash)
+		}
+		if err := copyFileWithProgressBar(output, resp, vmeInfo.Decompress); err != nil {
+			return err
+		}
+	}
+
+	if checksum != nil {
+		if err := verifyChecksum(checksum, hex.EncodeToString(hash.Sum(nil))); err != nil {
+			return err
+		}
 	}
 
 	// Prevent this output ending up in the stdout
@@ -589,12 +689,18 @@ func replaceUrlWithServiceUrl(manifestUrl string, vmeInfo *VMExportInfo) (string
 
 // GetUrlFromVirtualMachineExport inspects the VirtualMachineExport status to fetch the extected URL
 func GetUrlFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) (string, error) {
-	var (
-		downloadUrl string
-		err         error
-		format      exportv1.VirtualMachineExportVolumeFormat
-		links       *exportv1.VirtualMachineExportLink
-	)
+	_, format, err := getVolumeFormatFromVirtualMachineExport(vmexport, vmeInfo)
+	if err != nil {
+		return "", err
+	}
+	return replaceUrlWithServiceUrl(format.Url, vmeInfo)
+}
+
+// getVolumeFormatFromVirtualMachineExport inspects the VirtualMachineExport status to find the requested volume and the format to download.
+// By default, the compressed format is preferred, the raw format is used for parallel and resumable downloads as it supports range requests.
+// Backups are only exported as qcow2 images, which are downloaded as they are.
+func getVolumeFormatFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vmeInfo *VMExportInfo) (*exportv1.VirtualMachineExportVolume, *exportv1.VirtualMachineExportVolumeFormat, error) {
+	var links *exportv1.VirtualMachineExportLink
 
 	if vmeInfo.ServiceURL == "" && vmexport.Status.Links != nil && vmexport.Status.Links.External != nil {
 		links = vmexport.Status.Links.External
@@ -602,41 +708,44 @@ func GetUrlFromVirtualMachineExport(vmexport *exportv1.VirtualMachineExport, vme
 		links = vmexport.Status.Links.Internal
 	}
 	if links == nil || len(links.Volumes) <= 0 {
-		return "", fmt.Errorf("unable to access the volume info from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
+		return nil, nil, fmt.Errorf("unable to access the volume info from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
 	}
 	volumeNumber := len(links.Volumes)
 	if volumeNumber > 1 && vmeInfo.VolumeName == "" {
-		return "", fmt.Errorf("detected more than one downloadable volume in '%s/%s' VirtualMachineExport: Select the expected volume using the --volume flag", vmexport.Namespace, vmexport.Name)
+		return nil, nil, fmt.Errorf("detected more than one downloadable volume in '%s/%s' VirtualMachineExport: Select the expected volume using the --volume flag", vmexport.Namespace, vmexport.Name)
 	}
-	for _, exportVolume := range links.Volumes {
+	for i, exportVolume := range links.Volumes {
 		// Access the requested volume
 		if volumeNumber == 1 || exportVolume.Name == vmeInfo.VolumeName {
-			for _, format = range exportVolume.Formats {
-				if format.Format == exportv1.KubeVirtGz || format.Format == exportv1.ArchiveGz || format.Format == exportv1.KubeVirtRaw {
-					downloadUrl, err = replaceUrlWithServiceUrl(format.Url, vmeInfo)
-					if err != nil {
-						return "", err
+			var compressed, raw, qcow2 *exportv1.VirtualMachineExportVolumeFormat
+			for j, format := range exportVolume.Formats {
+				switch format.Format {
+				case exportv1.KubeVirtGz, exportv1.ArchiveGz:
+					if compressed == nil {
+						compressed = &exportVolume.Formats[j]
 					}
+				case exportv1.KubeVirtRaw:
+					raw = &exportVolume.Formats[j]
+				case exportv1.KubeVirtQcow2, exportv1.KubeVirtQcow2Incremental:
+					qcow2 = &exportVolume.Formats[j]
 				}
-				// By default, we always attempt to find and get the compressed file URL,
-				// so we only break the loop when one is found.
-				if format.Format == exportv1.KubeVirtGz || format.Format == exportv1.ArchiveGz {
-					break
-				}
+			}
+			if raw != nil && (compressed == nil || vmeInfo.Parallel > 1 || vmeInfo.Resume) {
+				// No need to decompress file if format is not gzip
+				vmeInfo.Decompress = false
+				return &links.Volumes[i], raw, nil
+			}
+			if compressed != nil {
+				return &links.Volumes[i], compressed, nil
+			}
+			if qcow2 != nil {
+				vmeInfo.Decompress = false
+				return &links.Volumes[i], qcow2, nil
 			}
 		}
 	}
 
-	// No need to decompress file if format is not gzip
-	if format.Format == exportv1.KubeVirtRaw {
-		vmeInfo.Decompress = false
-	}
-
-	if downloadUrl == "" {
-		return "", fmt.Errorf("unable to get a valid URL from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
-	}
-
-	return downloadUrl, nil
+	return nil, nil, fmt.Errorf("unable to get a valid URL from '%s/%s' VirtualMachineExport", vmexport.Namespace, vmexport.Name)
 }
 
 // GetManifestUrlsFromVirtualMachineExport retrieves the manifest URLs from VirtualMachineExport status
@@ -852,6 +961,13 @@ func getExportSource() k8sv1.TypedLocalObjectReference {
 			Name:     snapshot,
 		}
 	}
+	if backup != "" {
+		exportSource = k8sv1.TypedLocalObjectReference{
+			APIGroup: &snapshotv1.SchemeGroupVersion.Group,
+			Kind:     "VirtualMachineBackup",
+			Name:     backup,
+		}
+	}
 	if pvc != "" {
 		exportSource = k8sv1.TypedLocalObjectReference{
 			APIGroup: &k8sv1.SchemeGroupVersion.Group,
@@ -865,7 +981,7 @@ func getExportSource() k8sv1.TypedLocalObjectReference {
 
 // handleCreateFlags ensures that only compatible flag combinations are used with 'create'
 func handleCreateFlags() error {
-	if vm == "" && snapshot == "" && pvc == "" {
+	if vm == "" && snapshot == "" && backup == "" && pvc == "" {
 		return fmt.Errorf(ErrRequiredExportType)
 	}
 
@@ -893,13 +1009,19 @@ func handleCreateFlags() error {
 	if serviceUrl != "" {
 		return fmt.Errorf(ErrIncompatibleFlag, SERVICE_URL_FLAG, CREATE)
 	}
+	if parallel != 1 {
+		return fmt.Errorf(ErrIncompatibleFlag, PARALLEL_FLAG, CREATE)
+	}
+	if resume {
+		return fmt.Errorf(ErrIncompatibleFlag, RESUME_FLAG, CREATE)
+	}
 
 	return nil
 }
 
 // handleDeleteFlags ensures that only compatible flag combinations are used with 'delete'
 func handleDeleteFlags() error {
-	if vm != "" || snapshot != "" || pvc != "" {
+	if vm != "" || snapshot != "" || backup != "" || pvc != "" {
 		return fmt.Errorf(ErrIncompatibleExportType)
 	}
 
@@ -927,6 +1049,12 @@ func handleDeleteFlags() error {
 	if serviceUrl != "" {
 		return fmt.Errorf(ErrIncompatibleFlag, SERVICE_URL_FLAG, DELETE)
 	}
+	if parallel != 1 {
+		return fmt.Errorf(ErrIncompatibleFlag, PARALLEL_FLAG, DELETE)
+	}
+	if resume {
+		return fmt.Errorf(ErrIncompatibleFlag, RESUME_FLAG, DELETE)
+	}
 
 	return nil
 }
@@ -934,7 +1062,7 @@ func handleDeleteFlags() error {
 // handleDownloadFlags ensures that only compatible flag combinations are used with 'download'
 func handleDownloadFlags() error {
 	// We assume that the vmexport should be created if a source has been specified
-	if hasSource := vm != "" || snapshot != "" || pvc != ""; hasSource {
+	if hasSource := vm != "" || snapshot != "" || backup != "" || pvc != ""; hasSource {
 		shouldCreate = true
 	}
 
@@ -949,6 +1077,21 @@ func handleDownloadFlags() error {
 		return fmt.Errorf(ErrInvalidValue, FORMAT_FLAG, "gzip/raw")
 	}
 
+	if parallel < 1 {
+		return fmt.Errorf(ErrInvalidValue, PARALLEL_FLAG, "positive numbers")
+	}
+
+	if parallel > 1 {
+		if err := handleRangeDownloadFlags(PARALLEL_FLAG); err != nil {
+			return err
+		}
+	}
+	if resume {
+		if err := handleRangeDownloadFlags(RESUME_FLAG); err != nil {
+			return err
+		}
+	}
+
 	if exportManifest {
 		if volumeName != "" {
 			return fmt.Errorf(ErrIncompatibleFlag, VOLUME_FLAG, MANIFEST_FLAG)
@@ -962,11 +1105,28 @@ func handleDownloadFlags() error {
 		if pvc != "" {
 			return fmt.Errorf(ErrIncompatibleFlag, PVC_FLAG, MANIFEST_FLAG)
 		}
+		if backup != "" {
+			return fmt.Errorf(ErrIncompatibleFlag, BACKUP_FLAG, MANIFEST_FLAG)
+		}
 	}
 
 	return nil
 }
 
+// handleRangeDownloadFlags ensures that the flags which need range requests are used with a raw volume written to a file
+func handleRangeDownloadFlags(flag string) error {
+	if outputFile == "" {
+		return fmt.Errorf(ErrRequiredFlag, OUTPUT_FLAG, flag)
+	}
+	if format == GZIP_FORMAT {
+		return fmt.Errorf(ErrIncompatibleFlag, flag, FORMAT_FLAG+"="+GZIP_FORMAT)
+	}
+	if exportManifest {
+		return fmt.Errorf(ErrIncompatibleFlag, flag, MANIFEST_FLAG)
+	}
+	return nil
+}
+
 // getExportSecretName builds the name of the token secret based on the virtualMachineExport object
 func getExportSecretName(vmexportName string) string {
 	return fmt.Sprintf("secret-%s", vmexportName)
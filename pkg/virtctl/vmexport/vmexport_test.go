@@ -2,11 +2,18 @@ package vmexport_test
 
 import (
 	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -17,8 +24,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakek8sclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/testing"
 	exportv1 "kubevirt.io/api/export/v1alpha1"
+	snapshotv1 "kubevirt.io/api/snapshot/v1alpha1"
 	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
 
 	"kubevirt.io/client-go/kubecli"
@@ -44,6 +53,7 @@ var _ = Describe("vmexport", func() {
 		kubeClient     *fakek8sclient.Clientset
 		vmExportClient *kubevirtfake.Clientset
 		server         *httptest.Server
+		restConfig     *rest.Config
 	)
 
 	BeforeEach(func() {
@@ -53,7 +63,7 @@ var _ = Describe("vmexport", func() {
 
 		kubeClient = fakek8sclient.NewSimpleClientset()
 		vmExportClient = kubevirtfake.NewSimpleClientset()
-
+		restConfig = &rest.Config{}
 	})
 
 	setflag := func(flag, parameter string) string {
@@ -83,6 +93,7 @@ var _ = Describe("vmexport", func() {
 		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
 		kubecli.MockKubevirtClientInstance.EXPECT().StorageV1().Return(kubeClient.StorageV1()).AnyTimes()
 		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineExport(metav1.NamespaceDefault).Return(vmExportClient.ExportV1alpha1().VirtualMachineExports(metav1.NamespaceDefault)).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().Config().DoAndReturn(func() *rest.Config { return restConfig }).AnyTimes()
 
 		addDefaultReactors()
 
@@ -268,7 +279,7 @@ var _ = Describe("vmexport", func() {
 			cmd := clientcmd.NewRepeatableVirtctlCommand(args...)
 			err := cmd()
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).Should(Equal("if any flags in the group [vm snapshot pvc] are set none of the others can be; [pvc snapshot vm] were all set"))
+			Expect(err.Error()).Should(Equal("if any flags in the group [vm snapshot backup pvc] are set none of the others can be; [pvc snapshot vm] were all set"))
 		})
 
 		DescribeTable("Invalid arguments/flags", func(errString string, args ...string) {
@@ -288,10 +299,17 @@ var _ = Describe("vmexport", func() {
 			Entry("Using 'delete' with invalid flag", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.INSECURE_FLAG, virtctlvmexport.DELETE), virtctlvmexport.DELETE, vmexportName, virtctlvmexport.INSECURE_FLAG),
 			Entry("More arguments than expected download and 'manifest'", "argument validation failed", virtctlvmexport.DOWNLOAD, virtctlvmexport.DELETE, virtctlvmexport.MANIFEST_FLAG, vmexportName),
 			Entry("Using 'manifest' with pvc flag", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PVC_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.PVC_FLAG, "test")),
+			Entry("Using 'manifest' with backup flag", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.BACKUP_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.BACKUP_FLAG, "test")),
 			Entry("Using 'manifest' with volume type", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.VOLUME_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.VM_FLAG, "test"), setflag(virtctlvmexport.VOLUME_FLAG, "volume")),
 			Entry("Using 'manifest' with invalid output_format_flag", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.OUTPUT_FORMAT_FLAG, "json/yaml"), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.MANIFEST_FLAG, setflag(virtctlvmexport.OUTPUT_FORMAT_FLAG, "invalid")),
 			Entry("Using 'port-forward' with invalid port", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.LOCAL_PORT_FLAG, "valid port numbers"), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.PORT_FORWARD_FLAG, setflag(virtctlvmexport.LOCAL_PORT_FLAG, "test")),
 			Entry("Using 'format' with invalid download format", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.FORMAT_FLAG, "gzip/raw"), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.FORMAT_FLAG, "test")),
+			Entry("Using 'create' with parallel flag", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.PARALLEL_FLAG, virtctlvmexport.CREATE), virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.PVC_FLAG, "test"), setflag(virtctlvmexport.PARALLEL_FLAG, "2")),
+			Entry("Using 'delete' with resume flag", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.RESUME_FLAG, virtctlvmexport.DELETE), virtctlvmexport.DELETE, vmexportName, virtctlvmexport.RESUME_FLAG),
+			Entry("Using 'parallel' with invalid value", fmt.Sprintf(virtctlvmexport.ErrInvalidValue, virtctlvmexport.PARALLEL_FLAG, "positive numbers"), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PARALLEL_FLAG, "0")),
+			Entry("Using 'parallel' without output", fmt.Sprintf(virtctlvmexport.ErrRequiredFlag, virtctlvmexport.OUTPUT_FLAG, virtctlvmexport.PARALLEL_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.PARALLEL_FLAG, "2")),
+			Entry("Using 'resume' with gzip format", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.RESUME_FLAG, virtctlvmexport.FORMAT_FLAG+"="+virtctlvmexport.GZIP_FORMAT), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.RESUME_FLAG, setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img"), setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.GZIP_FORMAT)),
+			Entry("Using 'resume' with manifest", fmt.Sprintf(virtctlvmexport.ErrIncompatibleFlag, virtctlvmexport.RESUME_FLAG, virtctlvmexport.MANIFEST_FLAG), virtctlvmexport.DOWNLOAD, vmexportName, virtctlvmexport.RESUME_FLAG, setflag(virtctlvmexport.OUTPUT_FLAG, "disk.img"), virtctlvmexport.MANIFEST_FLAG),
 		)
 
 		AfterEach(func() {
@@ -312,6 +330,24 @@ var _ = Describe("vmexport", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("VirtualMachineExport of a backup is created succesfully", func() {
+			vmExportClient.Fake.PrependReactor("create", "virtualmachineexports", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				create, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+				vme, ok := create.GetObject().(*exportv1.VirtualMachineExport)
+				Expect(ok).To(BeTrue())
+				Expect(vme.Spec.Source).To(Equal(k8sv1.TypedLocalObjectReference{
+					APIGroup: &snapshotv1.SchemeGroupVersion.Group,
+					Kind:     "VirtualMachineBackup",
+					Name:     "test-backup",
+				}))
+				return true, vme, nil
+			})
+			cmd := clientcmd.NewRepeatableVirtctlCommand(commandName, virtctlvmexport.CREATE, vmexportName, setflag(virtctlvmexport.BACKUP_FLAG, "test-backup"))
+			err := cmd()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		// Delete tests
 		It("VirtualMachineExport is deleted succesfully", func() {
 			utils.HandleVMExportDelete(vmExportClient, vmexportName)
@@ -544,6 +580,47 @@ var _ = Describe("vmexport", func() {
 			Expect(url).Should(Equal("raw"))
 		})
 
+		DescribeTable("Should get the qcow2 URL of a backup", func(format exportv1.ExportVolumeFormat) {
+			vmeinfo.Decompress = true
+			vmExport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
+			vmExport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name:    volumeName,
+					Formats: utils.GetExportVolumeFormat("backup.qcow2", format),
+				},
+			}, secretName)
+			url, err := virtctlvmexport.GetUrlFromVirtualMachineExport(vmExport, vmeinfo)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).Should(Equal("backup.qcow2"))
+			Expect(vmeinfo.Decompress).To(BeFalse())
+		},
+			Entry("full", exportv1.KubeVirtQcow2),
+			Entry("incremental", exportv1.KubeVirtQcow2Incremental),
+		)
+
+		It("Should get raw URL for parallel downloads", func() {
+			vmeinfo.Parallel = 2
+			vmExport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
+			vmExport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name: volumeName,
+					Formats: []exportv1.VirtualMachineExportVolumeFormat{
+						{
+							Format: exportv1.KubeVirtGz,
+							Url:    "compressed",
+						},
+						{
+							Format: exportv1.KubeVirtRaw,
+							Url:    "raw",
+						},
+					},
+				},
+			}, secretName)
+			url, err := virtctlvmexport.GetUrlFromVirtualMachineExport(vmExport, vmeinfo)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).Should(Equal("raw"))
+		})
+
 		It("Should not get any URL when there's no valid options", func() {
 			vmExport := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
 			vmExport.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
@@ -769,4 +846,269 @@ var _ = Describe("vmexport", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+	Context("Range downloads", func() {
+		const volumeSize = 3 * 1024 * 1024
+
+		var (
+			orgHttpFunc      virtctlvmexport.HandleHTTPRequestFunc
+			orgRetryInterval time.Duration
+			orgRetryAfter    time.Duration
+			rangeServer      *httptest.Server
+			data             []byte
+			checksum         string
+			outputFile       string
+			requestLock      sync.Mutex
+			ranges           []string
+			// interruptAfter returns the number of bytes after which the response is cut off, or -1
+			interruptAfter func(rangeHeader string) int
+			// failRequest decides if the server fails the request
+			failRequest func(rangeHeader string) bool
+			// checksumPending is the number of checksum requests answered with 202
+			checksumPending int
+			// volumePending is the number of compressed volume requests answered with 202
+			volumePending int
+		)
+
+		downloadArgs := func(args ...string) []string {
+			return append([]string{commandName, virtctlvmexport.DOWNLOAD, vmexportName, setflag(virtctlvmexport.VOLUME_FLAG, volumeName), setflag(virtctlvmexport.OUTPUT_FLAG, outputFile)}, args...)
+		}
+
+		BeforeEach(func() {
+			orgHttpFunc = virtctlvmexport.HandleHTTPRequest
+			orgRetryInterval = virtctlvmexport.DownloadRetryInterval
+			virtctlvmexport.DownloadRetryInterval = time.Millisecond
+			orgRetryAfter = virtctlvmexport.RetryAfterInterval
+			virtctlvmexport.RetryAfterInterval = time.Millisecond
+			checksumPending = 0
+			volumePending = 0
+			testInit(http.StatusOK)
+
+			// Data, zeros and data again
+			data = make([]byte, volumeSize)
+			for i := 0; i < volumeSize/3; i++ {
+				data[i] = byte(i % 251)
+				data[2*volumeSize/3+i] = byte(i % 241)
+			}
+			sum := sha256.Sum256(data)
+			checksum = hex.EncodeToString(sum[:])
+			outputFile = filepath.Join(GinkgoT().TempDir(), "disk.img")
+			ranges = nil
+			interruptAfter = func(string) int { return -1 }
+			failRequest = func(string) bool { return false }
+
+			rangeServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, ".sha256") {
+					requestLock.Lock()
+					pending := checksumPending > 0
+					if pending {
+						checksumPending--
+					}
+					requestLock.Unlock()
+					if pending {
+						w.WriteHeader(http.StatusAccepted)
+						return
+					}
+					fmt.Fprintf(w, "%s  disk.img\n", checksum)
+					return
+				}
+				if strings.HasSuffix(r.URL.Path, ".gz") {
+					requestLock.Lock()
+					pending := volumePending > 0
+					if pending {
+						volumePending--
+					}
+					requestLock.Unlock()
+					if pending {
+						w.Header().Set("Retry-After", "0")
+						w.WriteHeader(http.StatusAccepted)
+						return
+					}
+					gw := gzip.NewWriter(w)
+					gw.Write(data)
+					gw.Close()
+					return
+				}
+				rangeHeader := r.Header.Get("Range")
+				requestLock.Lock()
+				ranges = append(ranges, rangeHeader)
+				fail := failRequest(rangeHeader)
+				interrupt := interruptAfter(rangeHeader)
+				requestLock.Unlock()
+				if fail {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				if interrupt >= 0 {
+					w = &interruptingWriter{ResponseWriter: w, remaining: interrupt}
+				}
+				http.ServeContent(w, r, "disk.img", time.Time{}, bytes.NewReader(data))
+			}))
+			virtctlvmexport.HandleHTTPRequest = func(client kubecli.KubevirtClient, vmexport *exportv1.VirtualMachineExport, downloadUrl string, insecure bool, exportURL string, headers map[string]string) (*http.Response, error) {
+				req, err := http.NewRequest("GET", downloadUrl, nil)
+				if err != nil {
+					return nil, err
+				}
+				for k, v := range headers {
+					req.Header.Set(k, v)
+				}
+				return rangeServer.Client().Do(req)
+			}
+
+			vme := utils.VMExportSpecPVC(vmexportName, metav1.NamespaceDefault, "test-pvc", secretName)
+			vme.Status = utils.GetVMEStatus([]exportv1.VirtualMachineExportVolume{
+				{
+					Name: volumeName,
+					Formats: []exportv1.VirtualMachineExportVolumeFormat{
+						{
+							Format: exportv1.KubeVirtRaw,
+							Url:    rangeServer.URL + "/disk.img",
+						},
+						{
+							Format: exportv1.KubeVirtGz,
+							Url:    rangeServer.URL + "/disk.img.gz",
+						},
+					},
+					Checksums: []exportv1.VirtualMachineExportVolumeChecksum{
+						{
+							Algorithm: exportv1.SHA256,
+							Url:       rangeServer.URL + "/disk.img.sha256",
+						},
+					},
+				},
+			}, secretName)
+			utils.HandleVMExportGet(vmExportClient, vme, vmexportName)
+		})
+
+		AfterEach(func() {
+			virtctlvmexport.HandleHTTPRequest = orgHttpFunc
+			virtctlvmexport.DownloadRetryInterval = orgRetryInterval
+			virtctlvmexport.RetryAfterInterval = orgRetryAfter
+			rangeServer.Close()
+			testDone()
+		})
+
+		expectDownloadedVolume := func() {
+			downloaded, err := os.ReadFile(outputFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes.Equal(downloaded, data)).To(BeTrue())
+			Expect(outputFile + ".progress").ToNot(BeAnExistingFile())
+		}
+
+		It("should download the raw volume with multiple connections", func() {
+			cmd := clientcmd.NewRepeatableVirtctlCommand(downloadArgs(setflag(virtctlvmexport.PARALLEL_FLAG, "3"))...)
+			Expect(cmd()).To(Succeed())
+			expectDownloadedVolume()
+			Expect(ranges).To(ConsistOf("bytes=0-0", "bytes=0-1048575", "bytes=1048576-2097151", "bytes=2097152-3145727"))
+		})
+
+		It("should continue a part when the connection drops", func() {
+			interrupted := false
+			interruptAfter = func(rangeHeader string) int {
+				if rangeHeader == "bytes=2097152-3145727" && !interrupted {
+					interrupted = true
+					return 100000
+				}
+				return -1
+			}
+			cmd := clientcmd.NewRepeatableVirtctlCommand(downloadArgs(setflag(virtctlvmexport.PARALLEL_FLAG, "3"))...)
+			Expect(cmd()).To(Succeed())
+			expectDownloadedVolume()
+			Expect(ranges).To(ContainElement(MatchRegexp(`^bytes=(2[1-9]|3)[0-9]{5,}-3145727$`)))
+		})
+
+		It("should resume an interrupted download", func() {
+			interrupted := false
+			interruptAfter = func(rangeHeader string) int {
+				if rangeHeader == "bytes=0-1572863" && !interrupted {
+					interrupted = true
+					return 100000
+				}
+				return -1
+			}
+			failRequest = func(rangeHeader string) bool {
+				return interrupted && strings.HasSuffix(rangeHeader, "-1572863")
+			}
+			cmd := clientcmd.NewRepeatableVirtctlCommand(downloadArgs(setflag(virtctlvmexport.PARALLEL_FLAG, "2"))...)
+			err := cmd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("run the command again with --resume to continue the download"))
+			Expect(outputFile + ".progress").To(BeAnExistingFile())
+
+			requestLock.Lock()
+			ranges = nil
+			failRequest = func(string) bool { return false }
+			requestLock.Unlock()
+			cmd = clientcmd.NewRepeatableVirtctlCommand(downloadArgs(virtctlvmexport.RESUME_FLAG)...)
+			Expect(cmd()).To(Succeed())
+			expectDownloadedVolume()
+			Expect(ranges).To(HaveLen(2))
+			Expect(ranges[0]).To(Equal("bytes=0-0"))
+			Expect(ranges[1]).To(MatchRegexp(`^bytes=[1-9][0-9]*-1572863$`))
+		})
+
+		It("should fail when the checksum does not match", func() {
+			checksum = strings.Repeat("0", 64)
+			cmd := clientcmd.NewRepeatableVirtctlCommand(downloadArgs(setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.RAW_FORMAT), setflag(virtctlvmexport.PARALLEL_FLAG, "2"))...)
+			err := cmd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("checksum mismatch"))
+		})
+
+		It("should wait for the checksum while it is being calculated", func() {
+			checksumPending = 3
+			cmd := clientcmd.NewRepeatableVirtctlCommand(downloadArgs(setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.RAW_FORMAT), setflag(virtctlvmexport.PARALLEL_FLAG, "2"))...)
+			Expect(cmd()).To(Succeed())
+			expectDownloadedVolume()
+			Expect(checksumPending).To(BeZero())
+		})
+
+		It("should stop waiting for the checksum after the request timeout", func() {
+			checksumPending = math.MaxInt32
+			restConfig.Timeout = 50 * time.Millisecond
+			cmd := clientcmd.NewRepeatableVirtctlCommand(downloadArgs(setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.RAW_FORMAT), setflag(virtctlvmexport.PARALLEL_FLAG, "2"))...)
+			err := cmd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("timed out waiting for the export server to calculate the checksum"))
+		})
+
+		It("should wait for the volume while the export server prepares it", func() {
+			volumePending = 2
+			cmd := clientcmd.NewRepeatableVirtctlCommand(downloadArgs(setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.GZIP_FORMAT))...)
+			Expect(cmd()).To(Succeed())
+			Expect(volumePending).To(BeZero())
+			f, err := os.Open(outputFile)
+			Expect(err).ToNot(HaveOccurred())
+			defer f.Close()
+			gr, err := gzip.NewReader(f)
+			Expect(err).ToNot(HaveOccurred())
+			downloaded, err := io.ReadAll(gr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes.Equal(downloaded, data)).To(BeTrue())
+		})
+
+		It("should stop waiting for the volume after the request timeout", func() {
+			volumePending = math.MaxInt32
+			restConfig.Timeout = 50 * time.Millisecond
+			cmd := clientcmd.NewRepeatableVirtctlCommand(downloadArgs(setflag(virtctlvmexport.FORMAT_FLAG, virtctlvmexport.GZIP_FORMAT))...)
+			err := cmd()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("timed out waiting for the export server to prepare the volume"))
+		})
+	})
 })
+
+// interruptingWriter drops the connection after writing the remaining bytes
+type interruptingWriter struct {
+	http.ResponseWriter
+	remaining int
+}
+
+func (w *interruptingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.remaining {
+		w.ResponseWriter.Write(p[:w.remaining])
+		w.ResponseWriter.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	w.remaining -= len(p)
+	return w.ResponseWriter.Write(p)
+}
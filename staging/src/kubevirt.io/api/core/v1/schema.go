@@ -535,6 +535,19 @@ type TPMDevice struct {
 	// Persistent indicates the state of the TPM device should be kept accross reboots
 	// Defaults to false
 	Persistent *bool `json:"persistent,omitempty"`
+	// Encryption configures the key used to encrypt the persisted TPM state.
+	// Requires Persistent to be set to true.
+	// +optional
+	Encryption *TPMStateEncryption `json:"encryption,omitempty"`
+}
+
+// TPMStateEncryption specifies where the key used to encrypt the persisted TPM state is taken from.
+// Exactly one source has to be set.
+type TPMStateEncryption struct {
+	// SecretRef references a Secret in the namespace of the VMI.
+	// The value of its "key" entry is used as the passphrase encrypting the TPM state.
+	// +optional
+	SecretRef *v1.LocalObjectReference `json:"secretRef,omitempty"`
 }
 
 type InputBus string
@@ -647,6 +660,42 @@ type Disk struct {
 	// If specified, it can change the default error policy (stop) for the disk
 	// +optional
 	ErrorPolicy *DiskErrorPolicy `json:"errorPolicy,omitempty"`
+	// IOTune limits the I/O of the disk.
+	// The limits can be changed on a running VirtualMachine when its liveUpdateFeatures contain ioTune.
+	// +optional
+	IOTune *DiskIOTune `json:"ioTune,omitempty"`
+}
+
+// DiskIOTune limits the I/O of a disk.
+// A total limit can't be combined with a read or write limit of the same kind.
+type DiskIOTune struct {
+	// Total limits the sum of read and write operations.
+	// +optional
+	Total *DiskIOLimits `json:"total,omitempty"`
+	// Read limits the read operations.
+	// +optional
+	Read *DiskIOLimits `json:"read,omitempty"`
+	// Write limits the write operations.
+	// +optional
+	Write *DiskIOLimits `json:"write,omitempty"`
+}
+
+// DiskIOLimits limits the throughput and the operations per second of a disk.
+type DiskIOLimits struct {
+	// BytesPerSecond limits the throughput in bytes per second.
+	// +optional
+	BytesPerSecond *resource.Quantity `json:"bytesPerSecond,omitempty"`
+	// BurstBytesPerSecond allows to exceed the throughput limit up to this value for short periods.
+	// Requires bytesPerSecond.
+	// +optional
+	BurstBytesPerSecond *resource.Quantity `json:"burstBytesPerSecond,omitempty"`
+	// IOPS limits the operations per second.
+	// +optional
+	IOPS *int64 `json:"iops,omitempty"`
+	// BurstIOPS allows to exceed the operations limit up to this value for short periods.
+	// Requires iops.
+	// +optional
+	BurstIOPS *int64 `json:"burstIOPS,omitempty"`
 }
 
 // CustomBlockSize represents the desired logical and physical block size for a VM disk.
@@ -697,6 +746,12 @@ type DiskTarget struct {
 type LaunchSecurity struct {
 	// AMD Secure Encrypted Virtualization (SEV).
 	SEV *SEV `json:"sev,omitempty"`
+	// AMD Secure Encrypted Virtualization with Secure Nested Paging (SEV-SNP).
+	// +optional
+	SNP *SEVSNP `json:"snp,omitempty"`
+	// Intel Trust Domain Extensions (TDX).
+	// +optional
+	TDX *TDX `json:"tdx,omitempty"`
 }
 
 type SEV struct {
@@ -722,6 +777,23 @@ type SEVPolicy struct {
 type SEVAttestation struct {
 }
 
+type SEVSNP struct {
+	// Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.
+	// Note: due to security reasons it is not allowed to enable guest debugging. Therefore the Debug flag is not exposed to users and is always false.
+	// +optional
+	Policy *SEVSNPPolicy `json:"policy,omitempty"`
+}
+
+type SEVSNPPolicy struct {
+	// Allow the guest to run on hosts with simultaneous multithreading (SMT) enabled.
+	// Defaults to true.
+	// +optional
+	SMT *bool `json:"smt,omitempty"`
+}
+
+type TDX struct {
+}
+
 type LunTarget struct {
 	// Bus indicates the type of disk device to emulate.
 	// supported values: virtio, sata, scsi.
@@ -828,6 +900,8 @@ type VolumeSource struct {
 	DownwardMetrics *DownwardMetricsVolumeSource `json:"downwardMetrics,omitempty"`
 	// MemoryDump is attached to the virt launcher and is populated with a memory dump of the vmi
 	MemoryDump *MemoryDumpVolumeSource `json:"memoryDump,omitempty"`
+	// Backup is attached to the virt launcher and is populated with a backup of the vmi disks
+	Backup *BackupVolumeSource `json:"backup,omitempty"`
 }
 
 // HotplugVolumeSource Represents the source of a volume to mount which are capable
@@ -871,6 +945,20 @@ type MemoryDumpVolumeSource struct {
 	PersistentVolumeClaimVolumeSource `json:",inline"`
 }
 
+type BackupVolumeSource struct {
+	// PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
+	// Directly attached to the virt launcher
+	// +optional
+	PersistentVolumeClaimVolumeSource `json:",inline"`
+	// BackupName is the name of the backup, it is also used as the name of the
+	// checkpoint created along with the backup
+	BackupName string `json:"backupName"`
+	// IncrementalFrom is the name of the checkpoint the backup only contains the changes since,
+	// a full backup is taken if it is empty or the checkpoint does not exist anymore
+	// +optional
+	IncrementalFrom string `json:"incrementalFrom,omitempty"`
+}
+
 type EphemeralVolumeSource struct {
 	// PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.
 	// Directly attached to the vmi via qemu.
@@ -1258,8 +1346,79 @@ type Interface struct {
 	// The (only) value supported is `absent`, expressing a request to remove the interface.
 	// +optional
 	State InterfaceState `json:"state,omitempty"`
+	// QoS limits the bandwidth of the interface.
+	// Supported only with the bridge and masquerade bindings.
+	// +optional
+	QoS *InterfaceQoS `json:"qos,omitempty"`
+	// Firewall filters the traffic of the interface inside the virt-launcher pod.
+	// Supported only with the masquerade and passt bindings.
+	// +optional
+	Firewall *InterfaceFirewall `json:"firewall,omitempty"`
 }
 
+// InterfaceQoS limits the traffic of an interface, the directions are seen from the guest.
+type InterfaceQoS struct {
+	// Inbound limits the traffic received by the guest.
+	// +optional
+	Inbound *InterfaceBandwidth `json:"inbound,omitempty"`
+	// Outbound limits the traffic sent by the guest.
+	// +optional
+	Outbound *InterfaceBandwidth `json:"outbound,omitempty"`
+}
+
+// InterfaceBandwidth limits the traffic of an interface in one direction.
+type InterfaceBandwidth struct {
+	// Average is the rate the traffic is shaped to, in bytes per second.
+	Average resource.Quantity `json:"average"`
+	// Peak is the maximum rate at which bursts are sent, in bytes per second.
+	// Must not be lower than the average.
+	// +optional
+	Peak *resource.Quantity `json:"peak,omitempty"`
+	// Burst is the amount of bytes which can be sent at the peak rate.
+	// +optional
+	Burst *resource.Quantity `json:"burst,omitempty"`
+}
+
+// InterfaceFirewall lists the rules which are applied to the traffic of an interface.
+// The rules of a direction are evaluated in order and the first matching rule decides.
+// Traffic which matches none of the rules is allowed, replies to allowed traffic are always allowed.
+type InterfaceFirewall struct {
+	// Ingress rules are applied to the connections initiated towards the guest.
+	// With the passt binding they only apply to the ports listed in the Ports of the interface,
+	// which have to be set.
+	// +optional
+	Ingress []FirewallRule `json:"ingress,omitempty"`
+	// Egress rules are applied to the connections initiated by the guest.
+	// +optional
+	Egress []FirewallRule `json:"egress,omitempty"`
+}
+
+// FirewallRule matches traffic by its remote network, protocol and port.
+// Fields which are not set match any traffic.
+type FirewallRule struct {
+	// Action is taken on the traffic which matches the rule.
+	Action FirewallAction `json:"action"`
+	// CIDR is the remote network, the source of ingress and the destination of egress traffic.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+	// Protocol is one of TCP, UDP, SCTP or ICMP.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+	// Port is the destination port, only valid with the TCP, UDP and SCTP protocols.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// EndPort makes the rule match the range of ports from Port to EndPort.
+	// +optional
+	EndPort int32 `json:"endPort,omitempty"`
+}
+
+type FirewallAction string
+
+const (
+	FirewallActionAllow FirewallAction = "Allow"
+	FirewallActionDeny  FirewallAction = "Deny"
+)
+
 type InterfaceState string
 
 const (
@@ -1280,6 +1439,26 @@ type DHCPOptions struct {
 	// If specified will pass extra DHCP options for private use, range: 224-254
 	// +optional
 	PrivateOptions []DHCPPrivateOptions `json:"privateOptions,omitempty"`
+	// If specified will pass the configured static routes to the VM via DHCP option 121,
+	// in addition to the routes of the pod network. Only IPv4 routes are supported.
+	// +optional
+	Routes []DHCPRoute `json:"routes,omitempty"`
+	// If specified will replace the DNS servers of the pod network. IPv4 servers are passed to the VM
+	// via DHCP option 6, IPv6 servers via DHCPv6 option 23.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+	// If specified will replace the DNS search domains of the pod network. They are passed to the VM
+	// via DHCP option 119 and DHCPv6 option 24.
+	// +optional
+	SearchDomains []string `json:"searchDomains,omitempty"`
+	// If specified will pass the domain name to the VM via DHCP option 15.
+	// Defaults to the domain name derived from the search domains.
+	// +optional
+	DomainName string `json:"domainName,omitempty"`
+	// If specified will pass the MTU to the VM via DHCP option 26 instead of the MTU of the pod network.
+	// It cannot exceed the MTU of the pod network, larger values are lowered to it.
+	// +optional
+	MTU int32 `json:"mtu,omitempty"`
 }
 
 func (d *DHCPOptions) UnmarshalJSON(data []byte) error {
@@ -1296,6 +1475,21 @@ func (d *DHCPOptions) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	for i, dnsServer := range dhcpOptionsAlias.DNSServers {
+		if sanitizedIP, err := sanitizeIP(dnsServer); err == nil {
+			dhcpOptionsAlias.DNSServers[i] = sanitizedIP
+		}
+	}
+
+	for i, route := range dhcpOptionsAlias.Routes {
+		if sanitizedCIDR, err := sanitizeCIDR(route.Destination); err == nil {
+			dhcpOptionsAlias.Routes[i].Destination = sanitizedCIDR
+		}
+		if sanitizedIP, err := sanitizeIP(route.Gateway); err == nil {
+			dhcpOptionsAlias.Routes[i].Gateway = sanitizedIP
+		}
+	}
+
 	*d = DHCPOptions(dhcpOptionsAlias)
 	return nil
 }
@@ -1310,6 +1504,14 @@ type DHCPPrivateOptions struct {
 	Value string `json:"value"`
 }
 
+// DHCPRoute is a static route which is passed to the VM via DHCP.
+type DHCPRoute struct {
+	// Destination is the destination network of the route in CIDR notation, e.g. 10.10.0.0/16
+	Destination string `json:"destination"`
+	// Gateway is the IPv4 address of the next hop.
+	Gateway string `json:"gateway"`
+}
+
 // Represents the method which will be used to connect the interface to the guest.
 // Only one of its members may be specified.
 type InterfaceBindingMethod struct {
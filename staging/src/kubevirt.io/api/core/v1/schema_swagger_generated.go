@@ -281,6 +281,14 @@ func (SoundDevice) SwaggerDoc() map[string]string {
 func (TPMDevice) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"persistent": "Persistent indicates the state of the TPM device should be kept accross reboots\nDefaults to false",
+		"encryption": "Encryption configures the key used to encrypt the persisted TPM state.\nRequires Persistent to be set to true.\n+optional",
+	}
+}
+
+func (TPMStateEncryption) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "TPMStateEncryption specifies where the key used to encrypt the persisted TPM state is taken from.\nExactly one source has to be set.",
+		"secretRef": "SecretRef references a Secret in the namespace of the VMI.\nThe value of its \"key\" entry is used as the passphrase encrypting the TPM state.\n+optional",
 	}
 }
 
@@ -344,6 +352,26 @@ func (Disk) SwaggerDoc() map[string]string {
 		"blockSize":         "If specified, the virtual disk will be presented with the given block sizes.\n+optional",
 		"shareable":         "If specified the disk is made sharable and multiple write from different VMs are permitted\n+optional",
 		"errorPolicy":       "If specified, it can change the default error policy (stop) for the disk\n+optional",
+		"ioTune":            "IOTune limits the I/O of the disk.\nThe limits can be changed on a running VirtualMachine when its liveUpdateFeatures contain ioTune.\n+optional",
+	}
+}
+
+func (DiskIOTune) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "DiskIOTune limits the I/O of a disk.\nA total limit can't be combined with a read or write limit of the same kind.",
+		"total": "Total limits the sum of read and write operations.\n+optional",
+		"read":  "Read limits the read operations.\n+optional",
+		"write": "Write limits the write operations.\n+optional",
+	}
+}
+
+func (DiskIOLimits) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "DiskIOLimits limits the throughput and the operations per second of a disk.",
+		"bytesPerSecond":      "BytesPerSecond limits the throughput in bytes per second.\n+optional",
+		"burstBytesPerSecond": "BurstBytesPerSecond allows to exceed the throughput limit up to this value for short periods.\nRequires bytesPerSecond.\n+optional",
+		"iops":                "IOPS limits the operations per second.\n+optional",
+		"burstIOPS":           "BurstIOPS allows to exceed the operations limit up to this value for short periods.\nRequires iops.\n+optional",
 	}
 }
 
@@ -379,6 +407,8 @@ func (DiskTarget) SwaggerDoc() map[string]string {
 func (LaunchSecurity) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"sev": "AMD Secure Encrypted Virtualization (SEV).",
+		"snp": "AMD Secure Encrypted Virtualization with Secure Nested Paging (SEV-SNP).\n+optional",
+		"tdx": "Intel Trust Domain Extensions (TDX).\n+optional",
 	}
 }
 
@@ -401,6 +431,22 @@ func (SEVAttestation) SwaggerDoc() map[string]string {
 	return map[string]string{}
 }
 
+func (SEVSNP) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"policy": "Guest policy flags as defined in the AMD SEV-SNP firmware ABI specification.\nNote: due to security reasons it is not allowed to enable guest debugging. Therefore the Debug flag is not exposed to users and is always false.\n+optional",
+	}
+}
+
+func (SEVSNPPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"smt": "Allow the guest to run on hosts with simultaneous multithreading (SMT) enabled.\nDefaults to true.\n+optional",
+	}
+}
+
+func (TDX) SwaggerDoc() map[string]string {
+	return map[string]string{}
+}
+
 func (LunTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"bus":         "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
@@ -442,6 +488,7 @@ func (VolumeSource) SwaggerDoc() map[string]string {
 		"serviceAccount":        "ServiceAccountVolumeSource represents a reference to a service account.\nThere can only be one volume of this type!\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/\n+optional",
 		"downwardMetrics":       "DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest\nmetrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.",
 		"memoryDump":            "MemoryDump is attached to the virt launcher and is populated with a memory dump of the vmi",
+		"backup":                "Backup is attached to the virt launcher and is populated with a backup of the vmi disks",
 	}
 }
 
@@ -471,6 +518,13 @@ func (MemoryDumpVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{}
 }
 
+func (BackupVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"backupName":      "BackupName is the name of the backup, it is also used as the name of the\ncheckpoint created along with the backup",
+		"incrementalFrom": "IncrementalFrom is the name of the checkpoint the backup only contains the changes since,\na full backup is taken if it is empty or the checkpoint does not exist anymore\n+optional",
+	}
+}
+
 func (EphemeralVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"persistentVolumeClaim": "PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.\nDirectly attached to the vmi via qemu.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims\n+optional",
@@ -664,6 +718,44 @@ func (Interface) SwaggerDoc() map[string]string {
 		"tag":         "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
 		"acpiIndex":   "If specified, the ACPI index is used to provide network interface device naming, that is stable across changes\nin PCI addresses assigned to the device.\nThis value is required to be unique across all devices and be between 1 and (16*1024-1).\n+optional",
 		"state":       "State represents the requested operational state of the interface.\nThe (only) value supported is `absent`, expressing a request to remove the interface.\n+optional",
+		"qos":         "QoS limits the bandwidth of the interface.\nSupported only with the bridge and masquerade bindings.\n+optional",
+		"firewall":    "Firewall filters the traffic of the interface inside the virt-launcher pod.\nSupported only with the masquerade and passt bindings.\n+optional",
+	}
+}
+
+func (InterfaceQoS) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "InterfaceQoS limits the traffic of an interface, the directions are seen from the guest.",
+		"inbound":  "Inbound limits the traffic received by the guest.\n+optional",
+		"outbound": "Outbound limits the traffic sent by the guest.\n+optional",
+	}
+}
+
+func (InterfaceBandwidth) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "InterfaceBandwidth limits the traffic of an interface in one direction.",
+		"average": "Average is the rate the traffic is shaped to, in bytes per second.",
+		"peak":    "Peak is the maximum rate at which bursts are sent, in bytes per second.\nMust not be lower than the average.\n+optional",
+		"burst":   "Burst is the amount of bytes which can be sent at the peak rate.\n+optional",
+	}
+}
+
+func (InterfaceFirewall) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "InterfaceFirewall lists the rules which are applied to the traffic of an interface.\nThe rules of a direction are evaluated in order and the first matching rule decides.\nTraffic which matches none of the rules is allowed, replies to allowed traffic are always allowed.",
+		"ingress": "Ingress rules are applied to the connections initiated towards the guest.\nWith the passt binding they only apply to the ports listed in the Ports of the interface,\nwhich have to be set.\n+optional",
+		"egress":  "Egress rules are applied to the connections initiated by the guest.\n+optional",
+	}
+}
+
+func (FirewallRule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "FirewallRule matches traffic by its remote network, protocol and port.\nFields which are not set match any traffic.",
+		"action":   "Action is taken on the traffic which matches the rule.",
+		"cidr":     "CIDR is the remote network, the source of ingress and the destination of egress traffic.\n+optional",
+		"protocol": "Protocol is one of TCP, UDP, SCTP or ICMP.\n+optional",
+		"port":     "Port is the destination port, only valid with the TCP, UDP and SCTP protocols.\n+optional",
+		"endPort":  "EndPort makes the rule match the range of ports from Port to EndPort.\n+optional",
 	}
 }
 
@@ -674,6 +766,11 @@ func (DHCPOptions) SwaggerDoc() map[string]string {
 		"tftpServerName": "If specified will pass option 66 to interface's DHCP server\n+optional",
 		"ntpServers":     "If specified will pass the configured NTP server to the VM via DHCP option 042.\n+optional",
 		"privateOptions": "If specified will pass extra DHCP options for private use, range: 224-254\n+optional",
+		"routes":         "If specified will pass the configured static routes to the VM via DHCP option 121,\nin addition to the routes of the pod network. Only IPv4 routes are supported.\n+optional",
+		"dnsServers":     "If specified will replace the DNS servers of the pod network. IPv4 servers are passed to the VM\nvia DHCP option 6, IPv6 servers via DHCPv6 option 23.\n+optional",
+		"searchDomains":  "If specified will replace the DNS search domains of the pod network. They are passed to the VM\nvia DHCP option 119 and DHCPv6 option 24.\n+optional",
+		"domainName":     "If specified will pass the domain name to the VM via DHCP option 15.\nDefaults to the domain name derived from the search domains.\n+optional",
+		"mtu":            "If specified will pass the MTU to the VM via DHCP option 26 instead of the MTU of the pod network.\nIt cannot exceed the MTU of the pod network, larger values are lowered to it.\n+optional",
 	}
 }
 
@@ -685,6 +782,14 @@ func (DHCPPrivateOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (DHCPRoute) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "DHCPRoute is a static route which is passed to the VM via DHCP.",
+		"destination": "Destination is the destination network of the route in CIDR notation, e.g. 10.10.0.0/16",
+		"gateway":     "Gateway is the IPv4 address of the next hop.",
+	}
+}
+
 func (InterfaceBindingMethod) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "Represents the method which will be used to connect the interface to the guest.\nOnly one of its members may be specified.",
@@ -70,6 +70,16 @@ type VirtualMachineInstanceList struct {
 
 type EvictionStrategy string
 
+// VMStateRetentionPolicy defines the lifecycle of the PVCs preserving VM state
+type VMStateRetentionPolicy string
+
+const (
+	// VMStateRetentionPolicyDelete deletes the VM state PVCs together with the VirtualMachine
+	VMStateRetentionPolicyDelete VMStateRetentionPolicy = "Delete"
+	// VMStateRetentionPolicyRetain keeps the VM state PVCs after the VirtualMachine is deleted
+	VMStateRetentionPolicyRetain VMStateRetentionPolicy = "Retain"
+)
+
 type StartStrategy string
 
 const (
@@ -284,6 +294,11 @@ type VirtualMachineInstanceStatus struct {
 	// Memory shows various informations about the VirtualMachine memory.
 	// +optional
 	Memory *MemoryStatus `json:"memory,omitempty"`
+
+	// ResourceUsage is the resource utilization of the guest as last sampled by virt-handler.
+	// It is only reported for VMIs which belong to a VirtualMachinePool.
+	// +optional
+	ResourceUsage *VirtualMachineInstanceResourceUsageStatus `json:"resourceUsage,omitempty"`
 }
 
 // PersistentVolumeClaimInfo contains the relavant information virt-handler needs cached about a PVC
@@ -336,6 +351,8 @@ type VolumeStatus struct {
 	Size int64 `json:"size,omitempty"`
 	// If the volume is memorydump volume, this will contain the memorydump info.
 	MemoryDumpVolume *DomainMemoryDumpInfo `json:"memoryDumpVolume,omitempty"`
+	// If the volume is backup volume, this will contain the backup info.
+	BackupVolume *DomainBackupInfo `json:"backupVolume,omitempty"`
 }
 
 // DomainMemoryDumpInfo represents the memory dump information
@@ -350,6 +367,32 @@ type DomainMemoryDumpInfo struct {
 	TargetFileName string `json:"targetFileName,omitempty"`
 }
 
+// DomainBackupInfo represents the backup information
+type DomainBackupInfo struct {
+	// StartTimestamp is the time when the backup started
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// EndTimestamp is the time when the backup completed
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+	// BackupName is the name of the backup and of the checkpoint created along with it
+	BackupName string `json:"backupName,omitempty"`
+	// IncrementalFrom is the checkpoint the backup was taken relative to, empty for a full backup
+	IncrementalFrom string `json:"incrementalFrom,omitempty"`
+	// Disks lists the files the disks were backed up to
+	// +listType=atomic
+	Disks []BackupDiskInfo `json:"disks,omitempty"`
+}
+
+// BackupDiskInfo represents the backup of a single disk
+type BackupDiskInfo struct {
+	// Name is the name of the backed up volume
+	Name string `json:"name"`
+	// TargetFileName is the name of the qcow2 file the volume was backed up to
+	TargetFileName string `json:"targetFileName"`
+	// Incremental is true if the file only contains the changes since the base checkpoint
+	// +optional
+	Incremental bool `json:"incremental,omitempty"`
+}
+
 // HotplugVolumeStatus represents the hotplug status of the volume
 type HotplugVolumeStatus struct {
 	// AttachPodName is the name of the pod used to attach the volume to the node.
@@ -382,6 +425,12 @@ const (
 	MemoryDumpVolumeInProgress VolumePhase = "MemoryDumpInProgress"
 	// MemoryDumpVolumeInProgress means that the volume for the memory dump was attached, and now the command is being triggered
 	MemoryDumpVolumeFailed VolumePhase = "MemoryDumpFailed"
+	// BackupVolumeInProgress means that the volume for the backup was attached, and now the backup is being taken
+	BackupVolumeInProgress VolumePhase = "BackupInProgress"
+	// BackupVolumeCompleted means that the requested backup was completed and the disk backups are ready in the volume
+	BackupVolumeCompleted VolumePhase = "BackupCompleted"
+	// BackupVolumeFailed means that the requested backup failed
+	BackupVolumeFailed VolumePhase = "BackupFailed"
 )
 
 func (v *VirtualMachineInstance) IsScheduling() bool {
@@ -501,6 +550,9 @@ const (
 
 	// Indicates whether the VMI is live migratable
 	VirtualMachineInstanceIsMigratable VirtualMachineInstanceConditionType = "LiveMigratable"
+	// Indicates whether a VMI which is not live migratable because of its volumes can be live migrated
+	// once these volumes are copied to new claims by a volume migration
+	VirtualMachineInstanceIsStorageLiveMigratable VirtualMachineInstanceConditionType = "StorageLiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's disks collection
 	VirtualMachineInstanceReasonDisksNotMigratable = "DisksNotLiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's network interfaces collection
@@ -515,6 +567,8 @@ const (
 	VirtualMachineInstanceReasonHostDeviceNotMigratable = "HostDeviceNotLiveMigratable"
 	// Reason means that VMI is not live migratable because it uses Secure Encrypted Virtualization (SEV)
 	VirtualMachineInstanceReasonSEVNotMigratable = "SEVNotLiveMigratable"
+	// Reason means that VMI is not live migratable because it uses SEV-SNP or TDX confidential computing
+	VirtualMachineInstanceReasonConfidentialComputingNotMigratable = "ConfidentialComputingNotLiveMigratable"
 	// Reason means that VMI is not live migratable because it uses HyperV Reenlightenment while TSC Frequency is not available
 	VirtualMachineInstanceReasonNoTSCFrequencyMigratable = "NoTSCFrequencyNotLiveMigratable"
 	// Reason means that VMI is not live migratable because it requested SCSI persitent reservation
@@ -687,6 +741,21 @@ type VirtualMachineInstanceMigrationState struct {
 	// If the VMI requires dedicated CPUs, this field will
 	// hold the numa topology on the target node
 	TargetNodeTopology string `json:"targetNodeTopology,omitempty"`
+	// The list of volumes whose storage is copied to a new PersistentVolumeClaim
+	// during the migration
+	// +optional
+	// +listType=atomic
+	MigratedVolumes []StorageMigratedVolumeInfo `json:"migratedVolumes,omitempty"`
+}
+
+// StorageMigratedVolumeInfo tracks the information about the source and destination claim of a migrated volume
+type StorageMigratedVolumeInfo struct {
+	// VolumeName is the name of the volume that is being migrated
+	VolumeName string `json:"volumeName"`
+	// SourceClaimName is the name of the PersistentVolumeClaim the volume is copied from
+	SourceClaimName string `json:"sourceClaimName"`
+	// DestinationClaimName is the name of the PersistentVolumeClaim the volume is copied to
+	DestinationClaimName string `json:"destinationClaimName"`
 }
 
 type MigrationAbortStatus string
@@ -797,6 +866,10 @@ const (
 	// This annotation indicates that a migration is the result of an
 	// automated evacuation
 	EvacuationMigrationAnnotation string = "kubevirt.io/evacuationMigration"
+	// This annotation sets the order in which VirtualMachineInstances are
+	// evacuated from a drained node. VirtualMachineInstances with a higher
+	// integer value are migrated first. Defaults to 0.
+	EvacuationPriorityAnnotation string = "kubevirt.io/evacuation-priority"
 	// This annotation indicates that a migration is the result of an
 	// automated workload update
 	WorkloadUpdateMigrationAnnotation string = "kubevirt.io/workloadUpdateMigration"
@@ -923,6 +996,12 @@ const (
 	// SEVESLabel marks the node as capable of running workloads with SEV-ES
 	SEVESLabel string = "kubevirt.io/sev-es"
 
+	// SEVSNPLabel marks the node as capable of running workloads with SEV-SNP
+	SEVSNPLabel string = "kubevirt.io/sev-snp"
+
+	// TDXLabel marks the node as capable of running workloads with TDX
+	TDXLabel string = "kubevirt.io/tdx"
+
 	// KSMEnabledLabel marks the node as KSM enabled
 	KSMEnabledLabel string = "kubevirt.io/ksm-enabled"
 
@@ -965,6 +1044,13 @@ const (
 	// vm has the pod networking bind with a bridge
 	AllowPodBridgeNetworkLiveMigrationAnnotation string = "kubevirt.io/allow-pod-bridge-network-live-migration"
 
+	// PreservePodNetworkAddressesAnnotation allow to run live migration when the vm has the pod
+	// networking bind with a bridge. The migration target pod requests the IPs and the MAC address
+	// of the source pod from the CNI, which has to support it. The addresses are requested through the
+	// multus default network annotation, so the default network has to be a network attachment definition,
+	// either the multus cluster default network or one selected by the v1.multus-cni.io/default-network annotation.
+	PreservePodNetworkAddressesAnnotation string = "kubevirt.io/preserve-pod-network-addresses"
+
 	// VirtualMachineGenerationAnnotation is the generation of a Virtual Machine.
 	VirtualMachineGenerationAnnotation string = "kubevirt.io/vm-generation"
 
@@ -980,6 +1066,11 @@ const (
 	// in which freePageReporting is always disabled.
 	FreePageReportingDisabledAnnotation string = "kubevirt.io/free-page-reporting-disabled"
 
+	// MemoryBalloonTargetEnabledAnnotation lets the vmi opt-in to boot with the memory balloon
+	// inflated to the balloon target of the NodeMemoryPolicy of its node.
+	// VMIs without this annotation always boot with all of their guest memory.
+	MemoryBalloonTargetEnabledAnnotation string = "kubevirt.io/memory-balloon-target-enabled"
+
 	// VirtualMachinePodCPULimitsLabel indicates VMI pod CPU resource limits
 	VirtualMachinePodCPULimitsLabel string = "kubevirt.io/vmi-pod-cpu-resource-limits"
 	// VirtualMachinePodMemoryRequestsLabel indicates VMI pod Memory resource requests
@@ -1253,6 +1344,33 @@ type VirtualMachineInstanceMigrationList struct {
 type VirtualMachineInstanceMigrationSpec struct {
 	// The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace
 	VMIName string `json:"vmiName,omitempty" valid:"required"`
+
+	// AddedNodeSelector is an additional selector that can be used to
+	// complement a NodeSelector or NodeAffinity as set on the VM
+	// to restrict the set of allowed target nodes for a migration.
+	// In case of key collisions, values set on the VM objects
+	// are going to be preserved to ensure that addedNodeSelector
+	// can only restrict but not bypass constraints already set on the VM object.
+	// +optional
+	AddedNodeSelector map[string]string `json:"addedNodeSelector,omitempty"`
+
+	// MigratedVolumes is the list of volumes whose storage is copied to a new
+	// PersistentVolumeClaim while the VMI is live migrated. Once the migration
+	// succeeds, the volume sources of the VMI and of the owning VirtualMachine
+	// are switched to the destination claims.
+	// +optional
+	// +listType=atomic
+	MigratedVolumes []MigratedVolume `json:"migratedVolumes,omitempty"`
+}
+
+// MigratedVolume describes a volume to copy to a different PersistentVolumeClaim during a migration
+type MigratedVolume struct {
+	// VolumeName is the name of the VMI volume to migrate. It must be backed by
+	// a PersistentVolumeClaim or a DataVolume
+	VolumeName string `json:"volumeName"`
+	// DestinationClaimName is the name of the PersistentVolumeClaim, in the
+	// namespace of the VMI, the volume is copied to
+	DestinationClaimName string `json:"destinationClaimName"`
 }
 
 // VirtualMachineInstanceMigrationPhaseTransitionTimestamp gives a timestamp in relation to when a phase is set on a vmi
@@ -1273,8 +1391,42 @@ type VirtualMachineInstanceMigrationStatus struct {
 	PhaseTransitionTimestamps []VirtualMachineInstanceMigrationPhaseTransitionTimestamp `json:"phaseTransitionTimestamps,omitempty"`
 	// Represents the status of a live migration
 	MigrationState *VirtualMachineInstanceMigrationState `json:"migrationState,omitempty"`
+	// MigratedVolumes reports the progress of the volumes copied to new PersistentVolumeClaims
+	// +optional
+	// +listType=atomic
+	MigratedVolumes []MigratedVolumeStatus `json:"migratedVolumes,omitempty"`
 }
 
+// MigratedVolumeStatus reports the progress of a volume copied to a new PersistentVolumeClaim during a migration
+type MigratedVolumeStatus struct {
+	// VolumeName is the name of the migrated volume
+	VolumeName string `json:"volumeName"`
+	// SourceClaimName is the name of the PersistentVolumeClaim the volume is copied from
+	// +optional
+	SourceClaimName string `json:"sourceClaimName,omitempty"`
+	// DestinationClaimName is the name of the PersistentVolumeClaim the volume is copied to
+	DestinationClaimName string `json:"destinationClaimName"`
+	// Phase is the progress of the volume migration
+	// +optional
+	Phase MigratedVolumePhase `json:"phase,omitempty"`
+}
+
+// MigratedVolumePhase is the progress of a volume copied to a new PersistentVolumeClaim
+type MigratedVolumePhase string
+
+const (
+	// MigratedVolumePending means the copy of the volume did not start yet
+	MigratedVolumePending MigratedVolumePhase = "Pending"
+	// MigratedVolumeCopying means the volume is copied while the VMI is migrated
+	MigratedVolumeCopying MigratedVolumePhase = "Copying"
+	// MigratedVolumeCopied means the migration succeeded, the VMI does not use the destination claim yet
+	MigratedVolumeCopied MigratedVolumePhase = "Copied"
+	// MigratedVolumeSwitched means the VMI uses the destination claim
+	MigratedVolumeSwitched MigratedVolumePhase = "Switched"
+	// MigratedVolumeFailed means the migration failed, the VMI keeps using the source claim
+	MigratedVolumeFailed MigratedVolumePhase = "Failed"
+)
+
 // VirtualMachineInstanceMigrationPhase is a label for the condition of a VirtualMachineInstanceMigration at the current time.
 type VirtualMachineInstanceMigrationPhase string
 
@@ -1613,6 +1765,10 @@ const (
 	// VirtualMachinePaused is added in a virtual machine when its vmi
 	// signals with its own condition that it is paused.
 	VirtualMachinePaused VirtualMachineConditionType = "Paused"
+
+	// VirtualMachineInferFromVolumeFailure is added in a virtual machine when the inference
+	// of its instancetype or preference from a volume failed and was ignored.
+	VirtualMachineInferFromVolumeFailure VirtualMachineConditionType = "InferFromVolumeFailure"
 )
 
 type HostDiskType string
@@ -2113,6 +2269,19 @@ type MigrateOptions struct {
 	DryRun []string `json:"dryRun,omitempty" protobuf:"bytes,1,rep,name=dryRun"`
 }
 
+// UpgradeInstancetypeOptions may be provided on upgrade-instancetype request.
+type UpgradeInstancetypeOptions struct {
+	metav1.TypeMeta `json:",inline"`
+	// When present, indicates that modifications should not be
+	// persisted. An invalid or unrecognized dryRun directive will
+	// result in an error response and no further processing of the
+	// request. Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
 // VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -2153,6 +2322,22 @@ type VirtualMachineInstanceGuestOSUserList struct {
 	Items           []VirtualMachineInstanceGuestOSUser `json:"items"`
 }
 
+// VirtualMachineInstanceResourceUsageStatus is the resource utilization of a running VMI,
+// periodically sampled by virt-handler on the host
+type VirtualMachineInstanceResourceUsageStatus struct {
+	// CPUUtilizationPercentage is the average utilization of all vCPUs of the guest
+	// in between the last two samples, in percent
+	// +optional
+	CPUUtilizationPercentage *int32 `json:"cpuUtilizationPercentage,omitempty"`
+	// MemoryUtilizationPercentage is the share of the guest memory which is in use, in percent.
+	// It is only reported when the guest exposes the memory statistics through the balloon driver
+	// +optional
+	MemoryUtilizationPercentage *int32 `json:"memoryUtilizationPercentage,omitempty"`
+	// LastSampleTime is the time the utilization was sampled
+	// +optional
+	LastSampleTime metav1.Time `json:"lastSampleTime,omitempty"`
+}
+
 // VirtualMachineGuestOSUser is the single user of the guest os
 type VirtualMachineInstanceGuestOSUser struct {
 	UserName  string  `json:"userName"`
@@ -2349,9 +2534,36 @@ type KubeVirtConfiguration struct {
 	SeccompConfiguration           *SeccompConfiguration             `json:"seccompConfiguration,omitempty"`
 
 	// VMStateStorageClass is the name of the storage class to use for the PVCs created to preserve VM state, like TPM.
-	// The storage class must support RWX in filesystem mode.
-	VMStateStorageClass   string                 `json:"vmStateStorageClass,omitempty"`
-	VirtualMachineOptions *VirtualMachineOptions `json:"virtualMachineOptions,omitempty"`
+	// The storage class should support RWX in filesystem mode, VMs whose state is kept on a RWO volume
+	// can not be live migrated.
+	VMStateStorageClass string `json:"vmStateStorageClass,omitempty"`
+	// VMStateRetentionPolicy defines what happens to the PVCs preserving VM state when the VirtualMachine is deleted.
+	// Delete removes them together with the VirtualMachine, Retain keeps them around.
+	// Retained PVCs are not reused by a new VirtualMachine with the same name, they have to be deleted before it can start.
+	// Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	VMStateRetentionPolicy VMStateRetentionPolicy `json:"vmStateRetentionPolicy,omitempty"`
+	// VMStateStorageSize is the size requested for the PVCs created to preserve VM state.
+	// Existing PVCs are expanded when the size is raised and their storage class allows volume
+	// expansion, they are never shrunk. Defaults to 10Mi.
+	// +optional
+	VMStateStorageSize *resource.Quantity `json:"vmStateStorageSize,omitempty"`
+	// VMBackupTimeoutSeconds is the time in seconds a VirtualMachineBackup may take before it
+	// is aborted and reported as failed. Defaults to 43200 (12 hours).
+	// +optional
+	VMBackupTimeoutSeconds *int64                 `json:"vmBackupTimeoutSeconds,omitempty"`
+	VirtualMachineOptions  *VirtualMachineOptions `json:"virtualMachineOptions,omitempty"`
+
+	// InstancetypeInferenceRegistries lists the registries, like quay.io or registry.example.com:5000, which
+	// virt-controller may contact to read the labels of containerDisk images when inferring the instancetype
+	// or preference of a VirtualMachine. Inference from containerDisks of other registries fails, it is
+	// disabled when the list is empty.
+	// The registries are contacted over HTTPS directly from virt-controller, which only trusts its system CA
+	// bundle. Registry mirrors and certificate authorities configured on the nodes are not used.
+	// +listType=atomic
+	// +optional
+	InstancetypeInferenceRegistries []string `json:"instancetypeInferenceRegistries,omitempty"`
 
 	// KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).
 	KSMConfiguration *KSMConfiguration `json:"ksmConfiguration,omitempty"`
@@ -2518,8 +2730,32 @@ type MigrationConfiguration struct {
 	// That will ensure the target virt-launcher doesn't share categories with another pod on the node.
 	// However, migrations will fail when using RWX volumes that don't automatically deal with SELinux levels.
 	MatchSELinuxLevelOnMigration *bool `json:"matchSELinuxLevelOnMigration,omitempty"`
+	// EvacuationOrder defines in which order VirtualMachineInstances with the same evacuation
+	// priority are migrated away from a drained node. Defaults to Default, which keeps the order
+	// in which the VirtualMachineInstances are listed
+	// +kubebuilder:validation:Enum=Default;SmallestMemoryFirst;LargestMemoryFirst
+	// +optional
+	EvacuationOrder *EvacuationOrder `json:"evacuationOrder,omitempty"`
+	// EvacuationTargetNodeSelector restricts the nodes evacuated VirtualMachineInstances are
+	// migrated to. It is added to the node selector of every evacuation migration.
+	// VirtualMachineInstances whose node selector requires a different value for one of the
+	// keys are not evacuated and are reported in the NodeEvacuation status.
+	// +optional
+	EvacuationTargetNodeSelector map[string]string `json:"evacuationTargetNodeSelector,omitempty"`
 }
 
+// EvacuationOrder defines how evacuation candidates of the same priority are ordered
+type EvacuationOrder string
+
+const (
+	// EvacuationOrderDefault migrates the VirtualMachineInstances in the order they are listed
+	EvacuationOrderDefault EvacuationOrder = "Default"
+	// EvacuationOrderSmallestMemoryFirst migrates the VirtualMachineInstances with the least guest memory first
+	EvacuationOrderSmallestMemoryFirst EvacuationOrder = "SmallestMemoryFirst"
+	// EvacuationOrderLargestMemoryFirst migrates the VirtualMachineInstances with the most guest memory first
+	EvacuationOrderLargestMemoryFirst EvacuationOrder = "LargestMemoryFirst"
+)
+
 // DiskVerification holds container disks verification limits
 type DiskVerification struct {
 	MemoryLimit *resource.Quantity `json:"memoryLimit"`
@@ -2671,6 +2907,11 @@ type NetworkConfiguration struct {
 	PermitSlirpInterface              *bool                             `json:"permitSlirpInterface,omitempty"`
 	PermitBridgeInterfaceOnPodNetwork *bool                             `json:"permitBridgeInterfaceOnPodNetwork,omitempty"`
 	Binding                           map[string]InterfaceBindingPlugin `json:"binding,omitempty"`
+	// QoSTrafficMark is the packet mark used in the pod network namespace to tell the traffic sent by a
+	// masqueraded guest apart from the traffic of the pod itself when the outbound QoS of the guest is shaped.
+	// It has to be changed if another component of the pod network uses the same mark. Defaults to 19286 (0x4b56).
+	// +optional
+	QoSTrafficMark *uint32 `json:"qosTrafficMark,omitempty"`
 }
 
 type InterfaceBindingPlugin struct {
@@ -2815,10 +3056,15 @@ type LiveUpdateFeatures struct {
 	// MemoryLiveUpdateConfiguration defines the live update memory features for the VirtualMachine
 	// +optional
 	Memory *LiveUpdateMemory `json:"memory,omitempty"`
+	// IOTune allows live updating the I/O limits of the virtual machines disks
+	// +optional
+	IOTune *LiveUpdateIOTune `json:"ioTune,omitempty"`
 }
 
 type LiveUpdateAffinity struct{}
 
+type LiveUpdateIOTune struct{}
+
 type LiveUpdateCPU struct {
 	// The maximum amount of sockets that can be hot-plugged to the Virtual Machine
 	MaxSockets *uint32 `json:"maxSockets,omitempty" optional:"true"`
@@ -2854,6 +3100,10 @@ type SEVPlatformInfo struct {
 	PDH string `json:"pdh,omitempty"`
 	// Base64 encoded SEV certificate chain.
 	CertChain string `json:"certChain,omitempty"`
+	// Base64 encoded unique ID of the host's AMD secure processor.
+	// Used by SEV-SNP guest owners to retrieve the VCEK certificate.
+	// +optional
+	ChipID string `json:"chipID,omitempty"`
 }
 
 // SEVMeasurementInfo contains information about the guest launch measurement.
@@ -81,6 +81,7 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"machine":                       "Machine shows the final resulting qemu machine type. This can be different\nthan the machine type selected in the spec, due to qemus machine type alias mechanism.\n+optional",
 		"currentCPUTopology":            "CurrentCPUTopology specifies the current CPU topology used by the VM workload.\nCurrent topology may differ from the desired topology in the spec while CPU hotplug\ntakes place.",
 		"memory":                        "Memory shows various informations about the VirtualMachine memory.\n+optional",
+		"resourceUsage":                 "ResourceUsage is the resource utilization of the guest as last sampled by virt-handler.\nIt is only reported for VMIs which belong to a VirtualMachinePool.\n+optional",
 	}
 }
 
@@ -108,6 +109,7 @@ func (VolumeStatus) SwaggerDoc() map[string]string {
 		"hotplugVolume":             "If the volume is hotplug, this will contain the hotplug status.",
 		"size":                      "Represents the size of the volume",
 		"memoryDumpVolume":          "If the volume is memorydump volume, this will contain the memorydump info.",
+		"backupVolume":              "If the volume is backup volume, this will contain the backup info.",
 	}
 }
 
@@ -121,6 +123,26 @@ func (DomainMemoryDumpInfo) SwaggerDoc() map[string]string {
 	}
 }
 
+func (DomainBackupInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "DomainBackupInfo represents the backup information",
+		"startTimestamp":  "StartTimestamp is the time when the backup started",
+		"endTimestamp":    "EndTimestamp is the time when the backup completed",
+		"backupName":      "BackupName is the name of the backup and of the checkpoint created along with it",
+		"incrementalFrom": "IncrementalFrom is the checkpoint the backup was taken relative to, empty for a full backup",
+		"disks":           "Disks lists the files the disks were backed up to\n+listType=atomic",
+	}
+}
+
+func (BackupDiskInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "BackupDiskInfo represents the backup of a single disk",
+		"name":           "Name is the name of the backed up volume",
+		"targetFileName": "TargetFileName is the name of the qcow2 file the volume was backed up to",
+		"incremental":    "Incremental is true if the file only contains the changes since the base checkpoint\n+optional",
+	}
+}
+
 func (HotplugVolumeStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":              "HotplugVolumeStatus represents the hotplug status of the volume",
@@ -191,6 +213,16 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 		"migrationConfiguration":         "Migration configurations to apply",
 		"targetCPUSet":                   "If the VMI requires dedicated CPUs, this field will\nhold the dedicated CPU set on the target node\n+listType=atomic",
 		"targetNodeTopology":             "If the VMI requires dedicated CPUs, this field will\nhold the numa topology on the target node",
+		"migratedVolumes":                "The list of volumes whose storage is copied to a new PersistentVolumeClaim\nduring the migration\n+optional\n+listType=atomic",
+	}
+}
+
+func (StorageMigratedVolumeInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "StorageMigratedVolumeInfo tracks the information about the source and destination claim of a migrated volume",
+		"volumeName":           "VolumeName is the name of the volume that is being migrated",
+		"sourceClaimName":      "SourceClaimName is the name of the PersistentVolumeClaim the volume is copied from",
+		"destinationClaimName": "DestinationClaimName is the name of the PersistentVolumeClaim the volume is copied to",
 	}
 }
 
@@ -270,7 +302,17 @@ func (VirtualMachineInstanceMigrationList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceMigrationSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"vmiName": "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"vmiName":           "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"addedNodeSelector": "AddedNodeSelector is an additional selector that can be used to\ncomplement a NodeSelector or NodeAffinity as set on the VM\nto restrict the set of allowed target nodes for a migration.\nIn case of key collisions, values set on the VM objects\nare going to be preserved to ensure that addedNodeSelector\ncan only restrict but not bypass constraints already set on the VM object.\n+optional",
+		"migratedVolumes":   "MigratedVolumes is the list of volumes whose storage is copied to a new\nPersistentVolumeClaim while the VMI is live migrated. Once the migration\nsucceeds, the volume sources of the VMI and of the owning VirtualMachine\nare switched to the destination claims.\n+optional\n+listType=atomic",
+	}
+}
+
+func (MigratedVolume) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "MigratedVolume describes a volume to copy to a different PersistentVolumeClaim during a migration",
+		"volumeName":           "VolumeName is the name of the VMI volume to migrate. It must be backed by\na PersistentVolumeClaim or a DataVolume",
+		"destinationClaimName": "DestinationClaimName is the name of the PersistentVolumeClaim, in the\nnamespace of the VMI, the volume is copied to",
 	}
 }
 
@@ -287,6 +329,17 @@ func (VirtualMachineInstanceMigrationStatus) SwaggerDoc() map[string]string {
 		"":                          "VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.",
 		"phaseTransitionTimestamps": "PhaseTransitionTimestamp is the timestamp of when the last phase change occurred\n+listType=atomic\n+optional",
 		"migrationState":            "Represents the status of a live migration",
+		"migratedVolumes":           "MigratedVolumes reports the progress of the volumes copied to new PersistentVolumeClaims\n+optional\n+listType=atomic",
+	}
+}
+
+func (MigratedVolumeStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "MigratedVolumeStatus reports the progress of a volume copied to a new PersistentVolumeClaim during a migration",
+		"volumeName":           "VolumeName is the name of the migrated volume",
+		"sourceClaimName":      "SourceClaimName is the name of the PersistentVolumeClaim the volume is copied from\n+optional",
+		"destinationClaimName": "DestinationClaimName is the name of the PersistentVolumeClaim the volume is copied to",
+		"phase":                "Phase is the progress of the volume migration\n+optional",
 	}
 }
 
@@ -569,6 +622,13 @@ func (MigrateOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (UpgradeInstancetypeOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "UpgradeInstancetypeOptions may be provided on upgrade-instancetype request.",
+		"dryRun": "When present, indicates that modifications should not be\npersisted. An invalid or unrecognized dryRun directive will\nresult in an error response and no further processing of the\nrequest. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
 func (VirtualMachineInstanceGuestAgentInfo) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                  "VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
@@ -595,6 +655,15 @@ func (VirtualMachineInstanceGuestOSUserList) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineInstanceResourceUsageStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                            "VirtualMachineInstanceResourceUsageStatus is the resource utilization of a running VMI,\nperiodically sampled by virt-handler on the host",
+		"cpuUtilizationPercentage":    "CPUUtilizationPercentage is the average utilization of all vCPUs of the guest\nin between the last two samples, in percent\n+optional",
+		"memoryUtilizationPercentage": "MemoryUtilizationPercentage is the share of the guest memory which is in use, in percent.\nIt is only reported when the guest exposes the memory statistics through the balloon driver\n+optional",
+		"lastSampleTime":              "LastSampleTime is the time the utilization was sampled\n+optional",
+	}
+}
+
 func (VirtualMachineInstanceGuestOSUser) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "VirtualMachineGuestOSUser is the single user of the guest os",
@@ -697,7 +766,11 @@ func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 		"additionalGuestMemoryOverheadRatio": "AdditionalGuestMemoryOverheadRatio can be used to increase the virtualization infrastructure\noverhead. This is useful, since the calculation of this overhead is not accurate and cannot\nbe entirely known in advance. The ratio that is being set determines by which factor to increase\nthe overhead calculated by Kubevirt. A higher ratio means that the VMs would be less compromised\nby node pressures, but would mean that fewer VMs could be scheduled to a node.\nIf not set, the default is 1.",
 		"supportContainerResources":          "+listType=map\n+listMapKey=type\nSupportContainerResources specifies the resource requirements for various types of supporting containers such as container disks/virtiofs/sidecars and hotplug attachment pods. If omitted a sensible default will be supplied.",
 		"supportedGuestAgentVersions":        "deprecated",
-		"vmStateStorageClass":                "VMStateStorageClass is the name of the storage class to use for the PVCs created to preserve VM state, like TPM.\nThe storage class must support RWX in filesystem mode.",
+		"vmStateStorageClass":                "VMStateStorageClass is the name of the storage class to use for the PVCs created to preserve VM state, like TPM.\nThe storage class should support RWX in filesystem mode, VMs whose state is kept on a RWO volume\ncan not be live migrated.",
+		"vmStateRetentionPolicy":             "VMStateRetentionPolicy defines what happens to the PVCs preserving VM state when the VirtualMachine is deleted.\nDelete removes them together with the VirtualMachine, Retain keeps them around.\nRetained PVCs are not reused by a new VirtualMachine with the same name, they have to be deleted before it can start.\nDefaults to Delete.\n+kubebuilder:validation:Enum=Delete;Retain\n+optional",
+		"vmStateStorageSize":                 "VMStateStorageSize is the size requested for the PVCs created to preserve VM state.\nExisting PVCs are expanded when the size is raised and their storage class allows volume\nexpansion, they are never shrunk. Defaults to 10Mi.\n+optional",
+		"vmBackupTimeoutSeconds":             "VMBackupTimeoutSeconds is the time in seconds a VirtualMachineBackup may take before it\nis aborted and reported as failed. Defaults to 43200 (12 hours).\n+optional",
+		"instancetypeInferenceRegistries":    "InstancetypeInferenceRegistries lists the registries, like quay.io or registry.example.com:5000, which\nvirt-controller may contact to read the labels of containerDisk images when inferring the instancetype\nor preference of a VirtualMachine. Inference from containerDisks of other registries fails, it is\ndisabled when the list is empty.\nThe registries are contacted over HTTPS directly from virt-controller, which only trusts its system CA\nbundle. Registry mirrors and certificate authorities configured on the nodes are not used.\n+listType=atomic\n+optional",
 		"ksmConfiguration":                   "KSMConfiguration holds the information regarding the enabling the KSM in the nodes (if available).",
 		"autoCPULimitNamespaceLabelSelector": "When set, AutoCPULimitNamespaceLabelSelector will set a CPU limit on virt-launcher for VMIs running inside\nnamespaces that match the label selector.\nThe CPU limit will equal the number of requested vCPUs.\nThis setting does not apply to VMIs with dedicated CPUs.",
 		"liveUpdateConfiguration":            "LiveUpdateConfiguration holds defaults for live update features",
@@ -780,6 +853,8 @@ func (MigrationConfiguration) SwaggerDoc() map[string]string {
 		"disableTLS":                        "When set to true, DisableTLS will disable the additional layer of live migration encryption\nprovided by KubeVirt. This is usually a bad idea. Defaults to false",
 		"network":                           "Network is the name of the CNI network to use for live migrations. By default, migrations go\nthrough the pod network.",
 		"matchSELinuxLevelOnMigration":      "By default, the SELinux level of target virt-launcher pods is forced to the level of the source virt-launcher.\nWhen set to true, MatchSELinuxLevelOnMigration lets the CRI auto-assign a random level to the target.\nThat will ensure the target virt-launcher doesn't share categories with another pod on the node.\nHowever, migrations will fail when using RWX volumes that don't automatically deal with SELinux levels.",
+		"evacuationOrder":                   "EvacuationOrder defines in which order VirtualMachineInstances with the same evacuation\npriority are migrated away from a drained node. Defaults to Default, which keeps the order\nin which the VirtualMachineInstances are listed\n+kubebuilder:validation:Enum=Default;SmallestMemoryFirst;LargestMemoryFirst\n+optional",
+		"evacuationTargetNodeSelector":      "EvacuationTargetNodeSelector restricts the nodes evacuated VirtualMachineInstances are\nmigrated to. It is added to the node selector of every evacuation migration.\nVirtualMachineInstances whose node selector requires a different value for one of the\nkeys are not evacuated and are reported in the NodeEvacuation status.\n+optional",
 	}
 }
 
@@ -873,7 +948,8 @@ func (KSMConfiguration) SwaggerDoc() map[string]string {
 
 func (NetworkConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "NetworkConfiguration holds network options",
+		"":               "NetworkConfiguration holds network options",
+		"qosTrafficMark": "QoSTrafficMark is the packet mark used in the pod network namespace to tell the traffic sent by a\nmasqueraded guest apart from the traffic of the pod itself when the outbound QoS of the guest is shaped.\nIt has to be changed if another component of the pod network uses the same mark. Defaults to 19286 (0x4b56).\n+optional",
 	}
 }
 
@@ -929,6 +1005,7 @@ func (LiveUpdateFeatures) SwaggerDoc() map[string]string {
 		"cpu":      "LiveUpdateCPU holds hotplug configuration for the CPU resource.\nEmpty struct indicates that default will be used for maxSockets.\nDefault is specified on cluster level.\nAbsence of the struct means opt-out from CPU hotplug functionality.",
 		"affinity": "Affinity allows live updating the virtual machines node affinity",
 		"memory":   "MemoryLiveUpdateConfiguration defines the live update memory features for the VirtualMachine\n+optional",
+		"ioTune":   "IOTune allows live updating the I/O limits of the virtual machines disks\n+optional",
 	}
 }
 
@@ -936,6 +1013,10 @@ func (LiveUpdateAffinity) SwaggerDoc() map[string]string {
 	return map[string]string{}
 }
 
+func (LiveUpdateIOTune) SwaggerDoc() map[string]string {
+	return map[string]string{}
+}
+
 func (LiveUpdateCPU) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"maxSockets": "The maximum amount of sockets that can be hot-plugged to the Virtual Machine",
@@ -961,6 +1042,7 @@ func (SEVPlatformInfo) SwaggerDoc() map[string]string {
 		"":          "SEVPlatformInfo contains information about the AMD SEV features for the node.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
 		"pdh":       "Base64 encoded platform Diffie-Hellman key.",
 		"certChain": "Base64 encoded SEV certificate chain.",
+		"chipID":    "Base64 encoded unique ID of the host's AMD secure processor.\nUsed by SEV-SNP guest owners to retrieve the VCEK certificate.\n+optional",
 	}
 }
 
@@ -76,6 +76,22 @@ func (in *VirtualMachineExport) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportBundle) DeepCopyInto(out *VirtualMachineExportBundle) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportBundle.
+func (in *VirtualMachineExportBundle) DeepCopy() *VirtualMachineExportBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineExportLink) DeepCopyInto(out *VirtualMachineExportLink) {
 	*out = *in
@@ -91,6 +107,11 @@ func (in *VirtualMachineExportLink) DeepCopyInto(out *VirtualMachineExportLink)
 		*out = make([]VirtualMachineExportManifest, len(*in))
 		copy(*out, *in)
 	}
+	if in.Bundles != nil {
+		in, out := &in.Bundles, &out.Bundles
+		*out = make([]VirtualMachineExportBundle, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -256,6 +277,11 @@ func (in *VirtualMachineExportVolume) DeepCopyInto(out *VirtualMachineExportVolu
 		*out = make([]VirtualMachineExportVolumeFormat, len(*in))
 		copy(*out, *in)
 	}
+	if in.Checksums != nil {
+		in, out := &in.Checksums, &out.Checksums
+		*out = make([]VirtualMachineExportVolumeChecksum, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -269,6 +295,22 @@ func (in *VirtualMachineExportVolume) DeepCopy() *VirtualMachineExportVolume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineExportVolumeChecksum) DeepCopyInto(out *VirtualMachineExportVolumeChecksum) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineExportVolumeChecksum.
+func (in *VirtualMachineExportVolumeChecksum) DeepCopy() *VirtualMachineExportVolumeChecksum {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineExportVolumeChecksum)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineExportVolumeFormat) DeepCopyInto(out *VirtualMachineExportVolumeFormat) {
 	*out = *in
@@ -139,6 +139,12 @@ type VirtualMachineExportLink struct {
 	// +listMapKey=type
 	// +optional
 	Manifests []VirtualMachineExportManifest `json:"manifests,omitempty"`
+
+	// Bundles is a list of available bundles containing the VM definition and all of its volumes
+	// +listType=map
+	// +listMapKey=format
+	// +optional
+	Bundles []VirtualMachineExportBundle `json:"bundles,omitempty"`
 }
 
 // VirtualMachineExportManifest contains the type and URL of the exported manifest
@@ -159,6 +165,22 @@ const (
 	AuthHeader ExportManifestType = "auth-header-secret"
 )
 
+// VirtualMachineExportBundle contains the format and URL of a bundle of the exported VM
+type VirtualMachineExportBundle struct {
+	// Format is the format of the bundle at the specified URL
+	Format ExportBundleFormat `json:"format"`
+
+	// Url is the url of the endpoint that returns the bundle
+	Url string `json:"url"`
+}
+
+type ExportBundleFormat string
+
+const (
+	// OVA is an OVF descriptor generated from the VM definition, together with the VM disks in streamOptimized VMDK format
+	OVA ExportBundleFormat = "ova"
+)
+
 // VirtualMachineExportVolume contains the name and available formats for the exported volume
 type VirtualMachineExportVolume struct {
 	// Name is the name of the exported volume
@@ -167,6 +189,11 @@ type VirtualMachineExportVolume struct {
 	// +listMapKey=format
 	// +optional
 	Formats []VirtualMachineExportVolumeFormat `json:"formats,omitempty"`
+	// Checksums contains the URLs of the checksums of the volume content
+	// +listType=map
+	// +listMapKey=algorithm
+	// +optional
+	Checksums []VirtualMachineExportVolumeChecksum `json:"checksums,omitempty"`
 }
 
 type ExportVolumeFormat string
@@ -180,6 +207,13 @@ const (
 	Dir ExportVolumeFormat = "dir"
 	// ArchiveGz is a tarred and gzipped version of the root of a PersistentVolumeClaim
 	ArchiveGz ExportVolumeFormat = "tar.gz"
+	// KubeVirtQcow2 is the volume in qcow2 format, regions which only contain zeros are not allocated
+	KubeVirtQcow2 ExportVolumeFormat = "qcow2"
+	// KubeVirtQcow2Compressed is the volume in qcow2 format with compressed clusters
+	KubeVirtQcow2Compressed ExportVolumeFormat = "qcow2-compressed"
+	// KubeVirtQcow2Incremental is a qcow2 image of an incremental backup, it only contains the blocks
+	// changed since the previous backup whose image has to be used as its backing file
+	KubeVirtQcow2Incremental ExportVolumeFormat = "qcow2-incremental"
 )
 
 // VirtualMachineExportVolumeFormat contains the format type and URL to get the volume in that format
@@ -190,6 +224,22 @@ type VirtualMachineExportVolumeFormat struct {
 	Url string `json:"url"`
 }
 
+type ExportChecksumAlgorithm string
+
+const (
+	// SHA256 is the SHA-256 digest of the volume content in RAW format
+	SHA256 ExportChecksumAlgorithm = "sha256"
+)
+
+// VirtualMachineExportVolumeChecksum contains the algorithm and the URL to get the checksum of the exported volume
+type VirtualMachineExportVolumeChecksum struct {
+	// Algorithm is the algorithm used to calculate the checksum
+	Algorithm ExportChecksumAlgorithm `json:"algorithm"`
+	// Url is the url that returns the checksum in the format of the sha256sum utility, the checksum is calculated
+	// on the first request, which can take a while on big volumes
+	Url string `json:"url"`
+}
+
 // ConditionType is the const type for Conditions
 type ConditionType string
 
@@ -51,6 +51,7 @@ func (VirtualMachineExportLink) SwaggerDoc() map[string]string {
 		"cert":      "Cert is the public CA certificate base64 encoded",
 		"volumes":   "Volumes is a list of available volumes to export\n+listType=map\n+listMapKey=name\n+optional",
 		"manifests": "Manifests is a list of available manifests for the export\n+listType=map\n+listMapKey=type\n+optional",
+		"bundles":   "Bundles is a list of available bundles containing the VM definition and all of its volumes\n+listType=map\n+listMapKey=format\n+optional",
 	}
 }
 
@@ -62,11 +63,20 @@ func (VirtualMachineExportManifest) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineExportBundle) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineExportBundle contains the format and URL of a bundle of the exported VM",
+		"format": "Format is the format of the bundle at the specified URL",
+		"url":    "Url is the url of the endpoint that returns the bundle",
+	}
+}
+
 func (VirtualMachineExportVolume) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":        "VirtualMachineExportVolume contains the name and available formats for the exported volume",
-		"name":    "Name is the name of the exported volume",
-		"formats": "+listType=map\n+listMapKey=format\n+optional",
+		"":          "VirtualMachineExportVolume contains the name and available formats for the exported volume",
+		"name":      "Name is the name of the exported volume",
+		"formats":   "+listType=map\n+listMapKey=format\n+optional",
+		"checksums": "Checksums contains the URLs of the checksums of the volume content\n+listType=map\n+listMapKey=algorithm\n+optional",
 	}
 }
 
@@ -78,6 +88,14 @@ func (VirtualMachineExportVolumeFormat) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineExportVolumeChecksum) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineExportVolumeChecksum contains the algorithm and the URL to get the checksum of the exported volume",
+		"algorithm": "Algorithm is the algorithm used to calculate the checksum",
+		"url":       "Url is the url that returns the checksum in the format of the sha256sum utility, the checksum is calculated\non the first request, which can take a while on big volumes",
+	}
+}
+
 func (Condition) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                   "Condition defines conditions",
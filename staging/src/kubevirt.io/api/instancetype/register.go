@@ -43,6 +43,11 @@ const (
 	DefaultPreferenceKindLabel   = "instancetype.kubevirt.io/default-preference-kind"
 )
 
+const (
+	InstancetypeInferenceFailureAnnotation = "instancetype.kubevirt.io/instancetype-inference-failure"
+	PreferenceInferenceFailureAnnotation   = "instancetype.kubevirt.io/preference-inference-failure"
+)
+
 const (
 	ControllerRevisionObjectGenerationLabel = "instancetype.kubevirt.io/object-generation"
 	ControllerRevisionObjectKindLabel       = "instancetype.kubevirt.io/object-kind"
@@ -0,0 +1,151 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NodeMemoryPolicyAnnotation is set by virt-handler on the node to the name of the NodeMemoryPolicy it applies
+	NodeMemoryPolicyAnnotation = "memory.kubevirt.io/policy"
+	// NodeMemoryOvercommitLimitReachedAnnotation is set by virt-handler on the node when the applied NodeMemoryPolicy
+	// has a maximum memory overcommit. It reports whether the guest memory of the VirtualMachineInstances on the node
+	// reached it.
+	NodeMemoryOvercommitLimitReachedAnnotation = "memory.kubevirt.io/overcommit-limit-reached"
+)
+
+// NodeMemoryPolicy describes the memory density profile of a pool of nodes.
+// virt-handler applies the policy on every node matched by its node selector.
+// When several policies match a node, the one with the alphabetically first name is applied.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +genclient
+// +genclient:nonNamespaced
+type NodeMemoryPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodeMemoryPolicySpec `json:"spec" valid:"required"`
+}
+
+type NodeMemoryPolicySpec struct {
+	// NodeSelector selects the nodes the policy applies to. If not set, the policy applies to all nodes.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// KSM configures Kernel Samepage Merging on the nodes.
+	// If not set, the KSMConfiguration of the KubeVirt CR and the KSM node annotations are used.
+	// +optional
+	KSM *KSMPolicy `json:"ksm,omitempty"`
+
+	// FreePageReporting enables or disables free page reporting for the VirtualMachineInstances
+	// started on the nodes. If not set, the cluster wide setting is used.
+	// +optional
+	FreePageReporting *bool `json:"freePageReporting,omitempty"`
+
+	// Balloon configures the memory balloon of the VirtualMachineInstances started on the nodes.
+	// +optional
+	Balloon *BalloonPolicy `json:"balloon,omitempty"`
+
+	// MaxMemoryOvercommitPercent is the maximum ratio, in percent, of the guest memory of the
+	// VirtualMachineInstances on a node to the allocatable memory of the node. Once it is reached
+	// the node is marked as unschedulable for new VirtualMachineInstances.
+	// 100 means no overcommit. If not set, no limit is enforced.
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	MaxMemoryOvercommitPercent *int32 `json:"maxMemoryOvercommitPercent,omitempty"`
+}
+
+// KSMPolicy configures how virt-handler tunes Kernel Samepage Merging on a node.
+// Unset values fall back to the defaults of virt-handler.
+type KSMPolicy struct {
+	// Enabled lets virt-handler manage KSM on the nodes. When set to false, KSM is disabled
+	// if it was enabled by virt-handler.
+	Enabled bool `json:"enabled"`
+
+	// PagesBoost is the number of pages added to pages_to_scan on every heartbeat under memory pressure
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PagesBoost *int32 `json:"pagesBoost,omitempty"`
+
+	// PagesDecay is the number of pages removed from pages_to_scan on every heartbeat without memory pressure
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PagesDecay *int32 `json:"pagesDecay,omitempty"`
+
+	// PagesMin is the lower bound of pages_to_scan
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PagesMin *int32 `json:"pagesMin,omitempty"`
+
+	// PagesMax is the upper bound of pages_to_scan
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PagesMax *int32 `json:"pagesMax,omitempty"`
+
+	// PagesInit is the value of pages_to_scan when KSM gets started
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PagesInit *int32 `json:"pagesInit,omitempty"`
+
+	// SleepMsBaseline is the sleep_millisecs of a 16GiB node under memory pressure,
+	// it is scaled down on nodes with more memory
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SleepMsBaseline *int32 `json:"sleepMsBaseline,omitempty"`
+
+	// FreePercent is the percentage of available memory below which the node is considered
+	// to be under memory pressure
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	FreePercent *int32 `json:"freePercent,omitempty"`
+}
+
+// BalloonPolicy configures the memory balloon of the VirtualMachineInstances started on a node
+type BalloonPolicy struct {
+	// StatsPeriod is the interval in seconds in which the balloon driver reports the guest memory
+	// statistics. 0 disables the statistics. If not set, the cluster wide setting is used.
+	// +optional
+	StatsPeriod *uint32 `json:"statsPeriod,omitempty"`
+
+	// TargetGuestMemoryPercent is the share, in percent, of the guest memory the VirtualMachineInstances
+	// started on the nodes boot with. The memory balloon holds back the remainder, which lets the nodes
+	// run more guests, and deflates once a guest runs out of memory. Only VirtualMachineInstances with the
+	// kubevirt.io/memory-balloon-target-enabled annotation set to "true" are affected. If not set, the balloon
+	// is not inflated. VirtualMachineInstances without a memory balloon, with hugepages or with memory
+	// hotplug are not affected.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TargetGuestMemoryPercent *int32 `json:"targetGuestMemoryPercent,omitempty"`
+}
+
+// NodeMemoryPolicyList is a list of NodeMemoryPolicy resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NodeMemoryPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// +listType=atomic
+	Items []NodeMemoryPolicy `json:"items"`
+}
@@ -25,4 +25,5 @@ const (
 	Version   = "v1alpha1"
 
 	ResourceMigrationPolicies = "migrationpolicies"
+	ResourceNodeEvacuations   = "nodeevacuations"
 )
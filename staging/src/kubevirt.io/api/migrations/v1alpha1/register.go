@@ -37,6 +37,8 @@ var (
 	// GroupVersionKind
 	MigrationPolicyKind     = schema.GroupVersionKind{Group: migrations.GroupName, Version: migrations.Version, Kind: "MigrationPolicy"}
 	MigrationPolicyListKind = schema.GroupVersionKind{Group: migrations.GroupName, Version: migrations.Version, Kind: "MigrationPolicyList"}
+	NodeEvacuationKind      = schema.GroupVersionKind{Group: migrations.GroupName, Version: migrations.Version, Kind: "NodeEvacuation"}
+	NodeEvacuationListKind  = schema.GroupVersionKind{Group: migrations.GroupName, Version: migrations.Version, Kind: "NodeEvacuationList"}
 )
 
 // Kind takes an unqualified kind and returns back a Group qualified GroupKind
@@ -60,7 +62,9 @@ var (
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&MigrationPolicy{},
-		&MigrationPolicyList{})
+		&MigrationPolicyList{},
+		&NodeEvacuation{},
+		&NodeEvacuationList{})
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
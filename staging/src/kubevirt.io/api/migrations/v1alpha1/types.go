@@ -76,6 +76,76 @@ type MigrationPolicyList struct {
 	Items []MigrationPolicy `json:"items"`
 }
 
+// NodeEvacuation reports the progress of evacuating the VirtualMachineInstances from a node.
+// It is maintained by the evacuation controller and has the same name as the node.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +genclient
+// +genclient:nonNamespaced
+type NodeEvacuation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +nullable
+	Status NodeEvacuationStatus `json:"status,omitempty"`
+}
+
+// NodeEvacuationPhase is the phase of a node evacuation
+type NodeEvacuationPhase string
+
+const (
+	// NodeEvacuationEvacuating means that VirtualMachineInstances are still migrated away from the node
+	NodeEvacuationEvacuating NodeEvacuationPhase = "Evacuating"
+	// NodeEvacuationCompleted means that no VirtualMachineInstance which has to be evacuated is left on the node
+	NodeEvacuationCompleted NodeEvacuationPhase = "Completed"
+)
+
+type NodeEvacuationStatus struct {
+	// +optional
+	Phase NodeEvacuationPhase `json:"phase,omitempty"`
+	// StartTimestamp is the time the evacuation of the node was first observed
+	// +optional
+	// +nullable
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// CompletionTimestamp is the time the last VirtualMachineInstance left the node
+	// +optional
+	// +nullable
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+	// TotalVMIs is the number of VirtualMachineInstances which had to be evacuated
+	// +optional
+	TotalVMIs int `json:"totalVMIs,omitempty"`
+	// EvacuatedVMIs is the number of VirtualMachineInstances which already left the node
+	// +optional
+	EvacuatedVMIs int `json:"evacuatedVMIs,omitempty"`
+	// MigratingVMIs is the number of VirtualMachineInstances with an unfinished migration
+	// +optional
+	MigratingVMIs int `json:"migratingVMIs,omitempty"`
+	// PendingVMIs is the number of VirtualMachineInstances waiting for a migration slot
+	// +optional
+	PendingVMIs int `json:"pendingVMIs,omitempty"`
+	// NonMigratableVMIs lists the VirtualMachineInstances blocking the evacuation because
+	// they can not be live migrated, in namespace/name format
+	// +optional
+	// +listType=atomic
+	NonMigratableVMIs []string `json:"nonMigratableVMIs,omitempty"`
+	// NodeSelectorConflictVMIs lists the VirtualMachineInstances blocking the evacuation because
+	// their node selector conflicts with the evacuation target node selector, in namespace/name format
+	// +optional
+	// +listType=atomic
+	NodeSelectorConflictVMIs []string `json:"nodeSelectorConflictVMIs,omitempty"`
+}
+
+// NodeEvacuationList is a list of NodeEvacuation
+//
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NodeEvacuationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// +listType=atomic
+	Items []NodeEvacuation `json:"items"`
+}
+
 // GetMigrationConfByPolicy returns a new migration configuration. The new configuration attributes will be overridden
 // by the migration policy if the specified attributes were defined for this policy. Otherwise they wouldn't change.
 // The boolean returned value indicates if any changes were made to the configurations.
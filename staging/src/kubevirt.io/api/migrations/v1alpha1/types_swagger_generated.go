@@ -35,3 +35,31 @@ func (MigrationPolicyList) SwaggerDoc() map[string]string {
 		"items": "+listType=atomic",
 	}
 }
+
+func (NodeEvacuation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "NodeEvacuation reports the progress of evacuating the VirtualMachineInstances from a node.\nIt is maintained by the evacuation controller and has the same name as the node.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true\n+genclient\n+genclient:nonNamespaced",
+		"status": "+nullable",
+	}
+}
+
+func (NodeEvacuationStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"phase":                    "+optional",
+		"startTimestamp":           "StartTimestamp is the time the evacuation of the node was first observed\n+optional\n+nullable",
+		"completionTimestamp":      "CompletionTimestamp is the time the last VirtualMachineInstance left the node\n+optional\n+nullable",
+		"totalVMIs":                "TotalVMIs is the number of VirtualMachineInstances which had to be evacuated\n+optional",
+		"evacuatedVMIs":            "EvacuatedVMIs is the number of VirtualMachineInstances which already left the node\n+optional",
+		"migratingVMIs":            "MigratingVMIs is the number of VirtualMachineInstances with an unfinished migration\n+optional",
+		"pendingVMIs":              "PendingVMIs is the number of VirtualMachineInstances waiting for a migration slot\n+optional",
+		"nonMigratableVMIs":        "NonMigratableVMIs lists the VirtualMachineInstances blocking the evacuation because\nthey can not be live migrated, in namespace/name format\n+optional\n+listType=atomic",
+		"nodeSelectorConflictVMIs": "NodeSelectorConflictVMIs lists the VirtualMachineInstances blocking the evacuation because\ntheir node selector conflicts with the evacuation target node selector, in namespace/name format\n+optional\n+listType=atomic",
+	}
+}
+
+func (NodeEvacuationList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "NodeEvacuationList is a list of NodeEvacuation\n\n+k8s:openapi-gen=true\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "+listType=atomic",
+	}
+}
@@ -22,12 +22,18 @@ package v1alpha1
 import (
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	virtv1 "kubevirt.io/api/core/v1"
 )
 
 const (
 	VirtualMachinePoolKind = "VirtualMachinePool"
+
+	// VirtualMachinePoolDeletionCostAnnotation can be set on the VMs of a pool to influence
+	// which VMs are removed first when the pool scales in. VMs with a lower cost are removed
+	// first. The value has to be an integer, VMs without the annotation have a cost of 0.
+	VirtualMachinePoolDeletionCostAnnotation = "pool.kubevirt.io/deletion-cost"
 )
 
 // VirtualMachinePool resource contains a VirtualMachine configuration
@@ -36,6 +42,8 @@ const (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +k8s:openapi-gen=true
 // +genclient
+// +genclient:method=GetScale,verb=get,subresource=scale,result=k8s.io/api/autoscaling/v1.Scale
+// +genclient:method=UpdateScale,verb=update,subresource=scale,input=k8s.io/api/autoscaling/v1.Scale,result=k8s.io/api/autoscaling/v1.Scale
 type VirtualMachinePool struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -89,6 +97,116 @@ type VirtualMachinePoolStatus struct {
 
 	// Canonical form of the label selector for HPA which consumes it through the scale subresource.
 	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// UpdateRevision is the name of the pool revision the VMs are updated to.
+	// +optional
+	UpdateRevision string `json:"updateRevision,omitempty"`
+
+	// UpdatedReplicas is the number of VMs whose spec and running VMI match the update revision.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// LastScaleTime is the last time the autoscaler changed the replica count of the pool.
+	// +optional
+	// +nullable
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type VirtualMachinePoolUpdateStrategyType string
+
+const (
+	// VirtualMachinePoolProactiveUpdate updates the VMs and restarts their outdated VMIs,
+	// respecting maxUnavailable.
+	VirtualMachinePoolProactiveUpdate VirtualMachinePoolUpdateStrategyType = "Proactive"
+
+	// VirtualMachinePoolOpportunisticUpdate only updates the VMs. Outdated VMIs pick up
+	// the new template the next time they are restarted.
+	VirtualMachinePoolOpportunisticUpdate VirtualMachinePoolUpdateStrategyType = "Opportunistic"
+
+	// VirtualMachinePoolLiveUpdate updates the VMs and lets the VM controller apply the changes
+	// to the running VMIs when they are covered by the VM liveUpdateFeatures. All other
+	// outdated VMIs are restarted like with the Proactive strategy.
+	VirtualMachinePoolLiveUpdate VirtualMachinePoolUpdateStrategyType = "LiveUpdate"
+)
+
+// +k8s:openapi-gen=true
+type VirtualMachinePoolUpdateStrategy struct {
+	// Type of the update strategy. One of Proactive, Opportunistic or LiveUpdate.
+	// Defaults to Proactive.
+	// +optional
+	Type VirtualMachinePoolUpdateStrategyType `json:"type,omitempty"`
+
+	// MaxUnavailable is the maximum number of VMs which can be unavailable while
+	// outdated VMIs are restarted. Value can be an absolute number or a percentage
+	// of the desired replicas. When not set all outdated VMIs are restarted at once.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type VirtualMachinePoolScaleInOrder string
+
+const (
+	// VirtualMachinePoolScaleInRandom removes the VMs in random order.
+	VirtualMachinePoolScaleInRandom VirtualMachinePoolScaleInOrder = "Random"
+
+	// VirtualMachinePoolScaleInOldest removes the oldest VMs first.
+	VirtualMachinePoolScaleInOldest VirtualMachinePoolScaleInOrder = "Oldest"
+
+	// VirtualMachinePoolScaleInNewest removes the newest VMs first.
+	VirtualMachinePoolScaleInNewest VirtualMachinePoolScaleInOrder = "Newest"
+)
+
+// VirtualMachinePoolScaleInPolicy defines which VMs are removed first when the pool scales in.
+// The deletion cost annotation of the VMs always takes precedence, followed by the
+// preferences and finally the order.
+//
+// +k8s:openapi-gen=true
+type VirtualMachinePoolScaleInPolicy struct {
+	// Order in which VMs with otherwise equal preference are removed.
+	// One of Random, Oldest or Newest. Defaults to Random.
+	// +optional
+	Order VirtualMachinePoolScaleInOrder `json:"order,omitempty"`
+
+	// PreferNotReady removes VMs which are not ready before ready ones.
+	// +optional
+	PreferNotReady bool `json:"preferNotReady,omitempty"`
+
+	// PreferNoLoggedInUsers removes VMs without users logged into the guest, as reported
+	// by the guest agent, before VMs with logged in users or without a connected agent.
+	// +optional
+	PreferNoLoggedInUsers bool `json:"preferNoLoggedInUsers,omitempty"`
+}
+
+// VirtualMachinePoolAutoscaler scales the pool based on the resource utilization
+// of its running VMIs. The desired replica count is calculated in the same way as
+// by the HorizontalPodAutoscaler. It must not be combined with an external
+// autoscaler acting on the scale subresource of the same pool.
+//
+// +k8s:openapi-gen=true
+type VirtualMachinePoolAutoscaler struct {
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas.
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the target average CPU utilization
+	// of the running VMIs, in percent of their vCPUs.
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage is the target average memory utilization
+	// of the running VMIs, in percent of their guest memory.
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// ScaleDownStabilizationSeconds is the time the utilization has to stay below
+	// the target before the pool is scaled in. Defaults to 300.
+	// +optional
+	ScaleDownStabilizationSeconds *int32 `json:"scaleDownStabilizationSeconds,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -108,6 +226,20 @@ type VirtualMachinePoolSpec struct {
 	// Indicates that the pool is paused.
 	// +optional
 	Paused bool `json:"paused,omitempty" protobuf:"varint,7,opt,name=paused"`
+
+	// UpdateStrategy defines how the VMs of the pool are updated when the
+	// VirtualMachineTemplate changes.
+	// +optional
+	UpdateStrategy *VirtualMachinePoolUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// ScaleInPolicy defines which VMs are removed first when the pool scales in.
+	// +optional
+	ScaleInPolicy *VirtualMachinePoolScaleInPolicy `json:"scaleInPolicy,omitempty"`
+
+	// Autoscaler adjusts the replica count of the pool based on the resource
+	// utilization of its VMIs.
+	// +optional
+	Autoscaler *VirtualMachinePoolAutoscaler `json:"autoscaler,omitempty"`
 }
 
 // VirtualMachinePoolList is a list of VirtualMachinePool resources.
@@ -4,7 +4,7 @@ package v1alpha1
 
 func (VirtualMachinePool) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "VirtualMachinePool resource contains a VirtualMachine configuration\nthat can be used to replicate multiple VirtualMachine resources.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true\n+genclient",
+		"": "VirtualMachinePool resource contains a VirtualMachine configuration\nthat can be used to replicate multiple VirtualMachine resources.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true\n+genclient\n+genclient:method=GetScale,verb=get,subresource=scale,result=k8s.io/api/autoscaling/v1.Scale\n+genclient:method=UpdateScale,verb=update,subresource=scale,input=k8s.io/api/autoscaling/v1.Scale,result=k8s.io/api/autoscaling/v1.Scale",
 	}
 }
 
@@ -26,9 +26,40 @@ func (VirtualMachinePoolCondition) SwaggerDoc() map[string]string {
 
 func (VirtualMachinePoolStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "+k8s:openapi-gen=true",
-		"conditions":    "+listType=atomic",
-		"labelSelector": "Canonical form of the label selector for HPA which consumes it through the scale subresource.",
+		"":                "+k8s:openapi-gen=true",
+		"conditions":      "+listType=atomic",
+		"labelSelector":   "Canonical form of the label selector for HPA which consumes it through the scale subresource.",
+		"updateRevision":  "UpdateRevision is the name of the pool revision the VMs are updated to.\n+optional",
+		"updatedReplicas": "UpdatedReplicas is the number of VMs whose spec and running VMI match the update revision.\n+optional",
+		"lastScaleTime":   "LastScaleTime is the last time the autoscaler changed the replica count of the pool.\n+optional\n+nullable",
+	}
+}
+
+func (VirtualMachinePoolUpdateStrategy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "+k8s:openapi-gen=true",
+		"type":           "Type of the update strategy. One of Proactive, Opportunistic or LiveUpdate.\nDefaults to Proactive.\n+optional",
+		"maxUnavailable": "MaxUnavailable is the maximum number of VMs which can be unavailable while\noutdated VMIs are restarted. Value can be an absolute number or a percentage\nof the desired replicas. When not set all outdated VMIs are restarted at once.\n+optional",
+	}
+}
+
+func (VirtualMachinePoolScaleInPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "VirtualMachinePoolScaleInPolicy defines which VMs are removed first when the pool scales in.\nThe deletion cost annotation of the VMs always takes precedence, followed by the\npreferences and finally the order.\n\n+k8s:openapi-gen=true",
+		"order":                 "Order in which VMs with otherwise equal preference are removed.\nOne of Random, Oldest or Newest. Defaults to Random.\n+optional",
+		"preferNotReady":        "PreferNotReady removes VMs which are not ready before ready ones.\n+optional",
+		"preferNoLoggedInUsers": "PreferNoLoggedInUsers removes VMs without users logged into the guest, as reported\nby the guest agent, before VMs with logged in users or without a connected agent.\n+optional",
+	}
+}
+
+func (VirtualMachinePoolAutoscaler) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                  "VirtualMachinePoolAutoscaler scales the pool based on the resource utilization\nof its running VMIs. The desired replica count is calculated in the same way as\nby the HorizontalPodAutoscaler. It must not be combined with an external\nautoscaler acting on the scale subresource of the same pool.\n\n+k8s:openapi-gen=true",
+		"minReplicas":                       "MinReplicas is the lower limit for the number of replicas. Defaults to 1.\n+optional",
+		"maxReplicas":                       "MaxReplicas is the upper limit for the number of replicas.",
+		"targetCPUUtilizationPercentage":    "TargetCPUUtilizationPercentage is the target average CPU utilization\nof the running VMIs, in percent of their vCPUs.\n+optional",
+		"targetMemoryUtilizationPercentage": "TargetMemoryUtilizationPercentage is the target average memory utilization\nof the running VMIs, in percent of their guest memory.\n+optional",
+		"scaleDownStabilizationSeconds":     "ScaleDownStabilizationSeconds is the time the utilization has to stay below\nthe target before the pool is scaled in. Defaults to 300.\n+optional",
 	}
 }
 
@@ -39,6 +70,9 @@ func (VirtualMachinePoolSpec) SwaggerDoc() map[string]string {
 		"selector":               "Label selector for pods. Existing Poolss whose pods are\nselected by this will be the ones affected by this deployment.",
 		"virtualMachineTemplate": "Template describes the VM that will be created.",
 		"paused":                 "Indicates that the pool is paused.\n+optional",
+		"updateStrategy":         "UpdateStrategy defines how the VMs of the pool are updated when the\nVirtualMachineTemplate changes.\n+optional",
+		"scaleInPolicy":          "ScaleInPolicy defines which VMs are removed first when the pool scales in.\n+optional",
+		"autoscaler":             "Autoscaler adjusts the replica count of the pool based on the resource\nutilization of its VMIs.\n+optional",
 	}
 }
 
@@ -0,0 +1,76 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ResourceVCPUs is the number of guest vCPUs of the running VirtualMachineInstances
+	ResourceVCPUs corev1.ResourceName = "vcpus"
+	// ResourceGuestMemory is the guest memory of the running VirtualMachineInstances
+	ResourceGuestMemory corev1.ResourceName = "guest.memory"
+	// ResourceVirtualMachineInstances is the number of running VirtualMachineInstances
+	ResourceVirtualMachineInstances corev1.ResourceName = "virtualmachineinstances"
+)
+
+// VirtualMachineQuota limits the guest resources of the VirtualMachineInstances in a namespace.
+// In contrast to a ResourceQuota only the resources visible to the guest are counted, the
+// overhead of the virt-launcher pod is not.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +genclient
+type VirtualMachineQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineQuotaSpec   `json:"spec" valid:"required"`
+	Status VirtualMachineQuotaStatus `json:"status,omitempty"`
+}
+
+type VirtualMachineQuotaSpec struct {
+	// Hard is the set of enforced limits. The supported resources are vcpus, guest.memory
+	// and virtualmachineinstances.
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+}
+
+type VirtualMachineQuotaStatus struct {
+	// Hard is the set of enforced limits observed when the usage was last calculated
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+
+	// Used is the sum of the guest resources of the VirtualMachineInstances in the namespace
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
+}
+
+// VirtualMachineQuotaList is a list of VirtualMachineQuota resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// +listType=atomic
+	Items []VirtualMachineQuota `json:"items"`
+}
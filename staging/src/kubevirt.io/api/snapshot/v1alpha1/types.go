@@ -361,3 +361,211 @@ type VirtualMachineRestoreList struct {
 
 	Items []VirtualMachineRestore `json:"items"`
 }
+
+// VirtualMachineSnapshotScheduleLabel is the label put on the VirtualMachineSnapshots
+// created by a VirtualMachineSnapshotSchedule, its value is the name of the schedule
+const VirtualMachineSnapshotScheduleLabel = "snapshot.kubevirt.io/schedule"
+
+// VirtualMachineSnapshotSchedule periodically snapshots the VMs matched by its selector
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineSnapshotScheduleSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineSnapshotScheduleStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource
+type VirtualMachineSnapshotScheduleSpec struct {
+	// Schedule in standard five field cron format, e.g. "0 */6 * * *".
+	// The macros @yearly, @monthly, @weekly, @daily and @hourly are supported as well.
+	// Times are evaluated in UTC.
+	Schedule string `json:"schedule"`
+
+	// Selector selects the VirtualMachines in the namespace of the schedule which get snapshotted
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Retention defines which of the snapshots created by this schedule are kept.
+	// If not set all snapshots are kept.
+	// +optional
+	Retention *SnapshotRetentionPolicy `json:"retention,omitempty"`
+
+	// Suspend stops the creation of new snapshots, retention is still applied
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// DeletionPolicy is passed on to the created VirtualMachineSnapshots
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// FailureDeadline is passed on to the created VirtualMachineSnapshots
+	// +optional
+	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+}
+
+// SnapshotRetentionPolicy defines which scheduled snapshots of a VM are kept.
+// A snapshot is kept if any of the rules selects it.
+type SnapshotRetentionPolicy struct {
+	// KeepLast is the number of most recent succeeded snapshots to keep
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// KeepDaily is the number of days, starting with the most recent one, for which
+	// the last succeeded snapshot of the day is kept
+	// +optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource
+type VirtualMachineSnapshotScheduleStatus struct {
+	// LastScheduleTime is the time of the last scheduled run
+	// +optional
+	// +nullable
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime is the time of the next scheduled run
+	// +optional
+	// +nullable
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the schedule time of the last run in which all snapshots succeeded
+	// +optional
+	// +nullable
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// LastFailedTime is the schedule time of the last run in which at least one snapshot failed
+	// +optional
+	// +nullable
+	LastFailedTime *metav1.Time `json:"lastFailedTime,omitempty"`
+
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineSnapshotScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineSnapshotSchedule `json:"items"`
+}
+
+// VirtualMachineBackup defines the operation of backing up the disks of a running VM.
+// Changed block tracking allows to only back up the blocks changed since the previous backup.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineBackupSpec `json:"spec"`
+
+	// +optional
+	Status *VirtualMachineBackupStatus `json:"status,omitempty"`
+}
+
+// BackupType is the type of a VirtualMachineBackup
+type BackupType string
+
+const (
+	// FullBackup contains the whole content of the disks
+	FullBackup BackupType = "Full"
+
+	// IncrementalBackup contains the blocks changed since the checkpoint of the previous backup
+	IncrementalBackup BackupType = "Incremental"
+)
+
+// VirtualMachineBackupSpec is the spec for a VirtualMachineBackup resource
+type VirtualMachineBackupSpec struct {
+	// Source is the VirtualMachine to back up, it has to be running
+	Source corev1.TypedLocalObjectReference `json:"source"`
+
+	// PVCName is the name of a filesystem mode PersistentVolumeClaim in the namespace of
+	// the backup, every disk is written to it as a qcow2 file
+	PVCName string `json:"pvcName"`
+
+	// Type of the backup, an Incremental backup only contains the blocks changed since the
+	// last successful backup of the source. It falls back to a Full backup if there is none.
+	// Defaults to Full
+	// +optional
+	Type *BackupType `json:"type,omitempty"`
+}
+
+// VirtualMachineBackupPhase is the current phase of the VirtualMachineBackup
+type VirtualMachineBackupPhase string
+
+const (
+	BackupPending    VirtualMachineBackupPhase = "Pending"
+	BackupInProgress VirtualMachineBackupPhase = "InProgress"
+	BackupSucceeded  VirtualMachineBackupPhase = "Succeeded"
+	BackupFailed     VirtualMachineBackupPhase = "Failed"
+)
+
+// VirtualMachineBackupStatus is the status for a VirtualMachineBackup resource
+type VirtualMachineBackupStatus struct {
+	// +optional
+	SourceUID *types.UID `json:"sourceUID,omitempty"`
+
+	// +optional
+	Phase VirtualMachineBackupPhase `json:"phase,omitempty"`
+
+	// Type is the type of the backup which was taken
+	// +optional
+	Type BackupType `json:"type,omitempty"`
+
+	// CheckpointName is the name of the checkpoint created with the backup, a later
+	// incremental backup of the source contains the changes since this checkpoint
+	// +optional
+	CheckpointName *string `json:"checkpointName,omitempty"`
+
+	// BaseCheckpointName is the checkpoint an incremental backup was taken relative to
+	// +optional
+	BaseCheckpointName *string `json:"baseCheckpointName,omitempty"`
+
+	// +optional
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Disks lists the files in the target PVC the disks were backed up to
+	// +optional
+	// +listType=atomic
+	Disks []BackupDisk `json:"disks,omitempty"`
+
+	// +optional
+	Error *Error `json:"error,omitempty"`
+
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// BackupDisk is the backup of a single disk
+type BackupDisk struct {
+	// VolumeName is the name of the backed up volume of the VM
+	VolumeName string `json:"volumeName"`
+
+	// FileName is the name of the qcow2 file in the target PVC
+	FileName string `json:"fileName"`
+
+	// Incremental is true if the file only contains the blocks changed since the base checkpoint,
+	// the file of the previous backup of the disk has to be used as its backing file
+	// +optional
+	Incremental bool `json:"incremental,omitempty"`
+}
+
+// VirtualMachineBackupList is a list of VirtualMachineBackup resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachineBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachineBackup `json:"items"`
+}
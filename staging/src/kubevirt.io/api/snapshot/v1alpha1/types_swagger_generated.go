@@ -168,3 +168,94 @@ func (VirtualMachineRestoreList) SwaggerDoc() map[string]string {
 		"": "VirtualMachineRestoreList is a list of VirtualMachineRestore resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
 	}
 }
+
+func (VirtualMachineSnapshotSchedule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineSnapshotSchedule periodically snapshots the VMs matched by its selector\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineSnapshotScheduleSpec is the spec for a VirtualMachineSnapshotSchedule resource",
+		"schedule":        "Schedule in standard five field cron format, e.g. \"0 */6 * * *\".\nThe macros @yearly, @monthly, @weekly, @daily and @hourly are supported as well.\nTimes are evaluated in UTC.",
+		"selector":        "Selector selects the VirtualMachines in the namespace of the schedule which get snapshotted",
+		"retention":       "Retention defines which of the snapshots created by this schedule are kept.\nIf not set all snapshots are kept.\n+optional",
+		"suspend":         "Suspend stops the creation of new snapshots, retention is still applied\n+optional",
+		"deletionPolicy":  "DeletionPolicy is passed on to the created VirtualMachineSnapshots\n+optional",
+		"failureDeadline": "FailureDeadline is passed on to the created VirtualMachineSnapshots\n+optional",
+	}
+}
+
+func (SnapshotRetentionPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "SnapshotRetentionPolicy defines which scheduled snapshots of a VM are kept.\nA snapshot is kept if any of the rules selects it.",
+		"keepLast":  "KeepLast is the number of most recent succeeded snapshots to keep\n+optional",
+		"keepDaily": "KeepDaily is the number of days, starting with the most recent one, for which\nthe last succeeded snapshot of the day is kept\n+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "VirtualMachineSnapshotScheduleStatus is the status for a VirtualMachineSnapshotSchedule resource",
+		"lastScheduleTime":   "LastScheduleTime is the time of the last scheduled run\n+optional\n+nullable",
+		"nextScheduleTime":   "NextScheduleTime is the time of the next scheduled run\n+optional\n+nullable",
+		"lastSuccessfulTime": "LastSuccessfulTime is the schedule time of the last run in which all snapshots succeeded\n+optional\n+nullable",
+		"lastFailedTime":     "LastFailedTime is the schedule time of the last run in which at least one snapshot failed\n+optional\n+nullable",
+		"conditions":         "+optional",
+	}
+}
+
+func (VirtualMachineSnapshotScheduleList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSnapshotScheduleList is a list of VirtualMachineSnapshotSchedule resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
+
+func (VirtualMachineBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachineBackup defines the operation of backing up the disks of a running VM.\nChanged block tracking allows to only back up the blocks changed since the previous backup.\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional",
+	}
+}
+
+func (VirtualMachineBackupSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "VirtualMachineBackupSpec is the spec for a VirtualMachineBackup resource",
+		"source":  "Source is the VirtualMachine to back up, it has to be running",
+		"pvcName": "PVCName is the name of a filesystem mode PersistentVolumeClaim in the namespace of\nthe backup, every disk is written to it as a qcow2 file",
+		"type":    "Type of the backup, an Incremental backup only contains the blocks changed since the\nlast successful backup of the source. It falls back to a Full backup if there is none.\nDefaults to Full\n+optional",
+	}
+}
+
+func (VirtualMachineBackupStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "VirtualMachineBackupStatus is the status for a VirtualMachineBackup resource",
+		"sourceUID":          "+optional",
+		"phase":              "+optional",
+		"type":               "Type is the type of the backup which was taken\n+optional",
+		"checkpointName":     "CheckpointName is the name of the checkpoint created with the backup, a later\nincremental backup of the source contains the changes since this checkpoint\n+optional",
+		"baseCheckpointName": "BaseCheckpointName is the checkpoint an incremental backup was taken relative to\n+optional",
+		"startTime":          "+optional\n+nullable",
+		"completionTime":     "+optional\n+nullable",
+		"disks":              "Disks lists the files in the target PVC the disks were backed up to\n+optional\n+listType=atomic",
+		"error":              "+optional",
+		"conditions":         "+optional",
+	}
+}
+
+func (BackupDisk) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "BackupDisk is the backup of a single disk",
+		"volumeName":  "VolumeName is the name of the backed up volume of the VM",
+		"fileName":    "FileName is the name of the qcow2 file in the target PVC",
+		"incremental": "Incremental is true if the file only contains the blocks changed since the base checkpoint,\nthe file of the previous backup of the disk has to be used as its backing file\n+optional",
+	}
+}
+
+func (VirtualMachineBackupList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineBackupList is a list of VirtualMachineBackup resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
@@ -32,6 +32,10 @@ func (c *FakeMigrationsV1alpha1) MigrationPolicies() v1alpha1.MigrationPolicyInt
 	return &FakeMigrationPolicies{c}
 }
 
+func (c *FakeMigrationsV1alpha1) NodeEvacuations() v1alpha1.NodeEvacuationInterface {
+	return &FakeNodeEvacuations{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeMigrationsV1alpha1) RESTClient() rest.Interface {
@@ -27,6 +27,7 @@ import (
 type MigrationsV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	MigrationPoliciesGetter
+	NodeEvacuationsGetter
 }
 
 // MigrationsV1alpha1Client is used to interact with features provided by the migrations.kubevirt.io group.
@@ -38,6 +39,10 @@ func (c *MigrationsV1alpha1Client) MigrationPolicies() MigrationPolicyInterface
 	return newMigrationPolicies(c)
 }
 
+func (c *MigrationsV1alpha1Client) NodeEvacuations() NodeEvacuationInterface {
+	return newNodeEvacuations(c)
+}
+
 // NewForConfig creates a new MigrationsV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*MigrationsV1alpha1Client, error) {
 	config := *c
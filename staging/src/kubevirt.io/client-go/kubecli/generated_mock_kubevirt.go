@@ -76,8 +76,10 @@ import (
 	v1alpha19 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/clone/v1alpha1"
 	v1alpha110 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/export/v1alpha1"
 	v1beta116 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/instancetype/v1beta1"
+	v1alpha115 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/memory/v1alpha1"
 	v1alpha111 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/migrations/v1alpha1"
 	v1alpha112 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
+	v1alpha114 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/quota/v1alpha1"
 	v1alpha113 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 	versioned2 "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned"
 	versioned3 "kubevirt.io/client-go/generated/prometheus-operator/clientset/versioned"
@@ -205,6 +207,26 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineRestore(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineRestore", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineSnapshotSchedule(namespace string) v1alpha113.VirtualMachineSnapshotScheduleInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineSnapshotSchedule", namespace)
+	ret0, _ := ret[0].(v1alpha113.VirtualMachineSnapshotScheduleInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineSnapshotSchedule(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineSnapshotSchedule", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineBackup(namespace string) v1alpha113.VirtualMachineBackupInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineBackup", namespace)
+	ret0, _ := ret[0].(v1alpha113.VirtualMachineBackupInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineBackup(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineBackup", arg0)
+}
+
 func (_m *MockKubevirtClient) VirtualMachineExport(namespace string) v1alpha110.VirtualMachineExportInterface {
 	ret := _m.ctrl.Call(_m, "VirtualMachineExport", namespace)
 	ret0, _ := ret[0].(v1alpha110.VirtualMachineExportInterface)
@@ -265,6 +287,16 @@ func (_mr *_MockKubevirtClientRecorder) MigrationPolicy() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "MigrationPolicy")
 }
 
+func (_m *MockKubevirtClient) NodeEvacuation() v1alpha111.NodeEvacuationInterface {
+	ret := _m.ctrl.Call(_m, "NodeEvacuation")
+	ret0, _ := ret[0].(v1alpha111.NodeEvacuationInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) NodeEvacuation() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NodeEvacuation")
+}
+
 func (_m *MockKubevirtClient) ExpandSpec(namespace string) ExpandSpecInterface {
 	ret := _m.ctrl.Call(_m, "ExpandSpec", namespace)
 	ret0, _ := ret[0].(ExpandSpecInterface)
@@ -295,6 +327,26 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineClone(arg0 interface{}) *g
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineClone", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineQuota(namespace string) v1alpha114.VirtualMachineQuotaInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineQuota", namespace)
+	ret0, _ := ret[0].(v1alpha114.VirtualMachineQuotaInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineQuota(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineQuota", arg0)
+}
+
+func (_m *MockKubevirtClient) NodeMemoryPolicy() v1alpha115.NodeMemoryPolicyInterface {
+	ret := _m.ctrl.Call(_m, "NodeMemoryPolicy")
+	ret0, _ := ret[0].(v1alpha115.NodeMemoryPolicyInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) NodeMemoryPolicy() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NodeMemoryPolicy")
+}
+
 func (_m *MockKubevirtClient) ClusterProfiler() *ClusterProfiler {
 	ret := _m.ctrl.Call(_m, "ClusterProfiler")
 	ret0, _ := ret[0].(*ClusterProfiler)
@@ -1309,6 +1361,17 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SEVInjectLaunchSecret(a
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SEVInjectLaunchSecret", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) SNPFetchCertChain(name string) (v120.SEVPlatformInfo, error) {
+	ret := _m.ctrl.Call(_m, "SNPFetchCertChain", name)
+	ret0, _ := ret[0].(v120.SEVPlatformInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SNPFetchCertChain(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SNPFetchCertChain", arg0)
+}
+
 // Mock of ReplicaSetInterface interface
 type MockReplicaSetInterface struct {
 	ctrl     *gomock.Controller
@@ -1770,6 +1833,17 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) RemoveMemoryDump(arg0, arg1 int
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveMemoryDump", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInterface) UpgradeInstancetype(ctx context.Context, name string, upgradeOptions *v120.UpgradeInstancetypeOptions) (*v120.VirtualMachine, error) {
+	ret := _m.ctrl.Call(_m, "UpgradeInstancetype", ctx, name, upgradeOptions)
+	ret0, _ := ret[0].(*v120.VirtualMachine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) UpgradeInstancetype(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpgradeInstancetype", arg0, arg1, arg2)
+}
+
 // Mock of VirtualMachineInstanceMigrationInterface interface
 type MockVirtualMachineInstanceMigrationInterface struct {
 	ctrl     *gomock.Controller
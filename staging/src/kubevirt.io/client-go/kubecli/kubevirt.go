@@ -52,8 +52,10 @@ import (
 	generatedclient "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
 	vmexportv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/export/v1alpha1"
 	instancetypev1beta1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/instancetype/v1beta1"
+	memoryv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/memory/v1alpha1"
 	migrationsv1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/migrations/v1alpha1"
 	poolv1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
+	quotav1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/quota/v1alpha1"
 	vmsnapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 	networkclient "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned"
 	promclient "kubevirt.io/client-go/generated/prometheus-operator/clientset/versioned"
@@ -71,15 +73,20 @@ type KubevirtClient interface {
 	VirtualMachineSnapshot(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotInterface
 	VirtualMachineSnapshotContent(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotContentInterface
 	VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface
+	VirtualMachineSnapshotSchedule(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotScheduleInterface
+	VirtualMachineBackup(namespace string) vmsnapshotv1alpha1.VirtualMachineBackupInterface
 	VirtualMachineExport(namespace string) vmexportv1alpha1.VirtualMachineExportInterface
 	VirtualMachineInstancetype(namespace string) instancetypev1beta1.VirtualMachineInstancetypeInterface
 	VirtualMachineClusterInstancetype() instancetypev1beta1.VirtualMachineClusterInstancetypeInterface
 	VirtualMachinePreference(namespace string) instancetypev1beta1.VirtualMachinePreferenceInterface
 	VirtualMachineClusterPreference() instancetypev1beta1.VirtualMachineClusterPreferenceInterface
 	MigrationPolicy() migrationsv1.MigrationPolicyInterface
+	NodeEvacuation() migrationsv1.NodeEvacuationInterface
 	ExpandSpec(namespace string) ExpandSpecInterface
 	ServerVersion() ServerVersionInterface
 	VirtualMachineClone(namespace string) clonev1alpha1.VirtualMachineCloneInterface
+	VirtualMachineQuota(namespace string) quotav1alpha1.VirtualMachineQuotaInterface
+	NodeMemoryPolicy() memoryv1alpha1.NodeMemoryPolicyInterface
 	ClusterProfiler() *ClusterProfiler
 	GuestfsVersion() *GuestfsVersion
 	RestClient() *rest.RESTClient
@@ -174,6 +181,14 @@ func (k kubevirt) VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.Vir
 	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineRestores(namespace)
 }
 
+func (k kubevirt) VirtualMachineSnapshotSchedule(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotScheduleInterface {
+	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineSnapshotSchedules(namespace)
+}
+
+func (k kubevirt) VirtualMachineBackup(namespace string) vmsnapshotv1alpha1.VirtualMachineBackupInterface {
+	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineBackups(namespace)
+}
+
 func (k kubevirt) VirtualMachineExport(namespace string) vmexportv1alpha1.VirtualMachineExportInterface {
 	return k.generatedKubeVirtClient.ExportV1alpha1().VirtualMachineExports(namespace)
 }
@@ -206,6 +221,10 @@ func (k kubevirt) MigrationPolicy() migrationsv1.MigrationPolicyInterface {
 	return k.generatedKubeVirtClient.MigrationsV1alpha1().MigrationPolicies()
 }
 
+func (k kubevirt) NodeEvacuation() migrationsv1.NodeEvacuationInterface {
+	return k.generatedKubeVirtClient.MigrationsV1alpha1().NodeEvacuations()
+}
+
 func (k kubevirt) MigrationPolicyClient() *migrationsv1.MigrationsV1alpha1Client {
 	return k.migrationsClient
 }
@@ -218,6 +237,14 @@ func (k kubevirt) VirtualMachineCloneClient() *clonev1alpha1.CloneV1alpha1Client
 	return k.cloneClient // TODO ihol3 delete function? who's using it?
 }
 
+func (k kubevirt) VirtualMachineQuota(namespace string) quotav1alpha1.VirtualMachineQuotaInterface {
+	return k.generatedKubeVirtClient.QuotaV1alpha1().VirtualMachineQuotas(namespace)
+}
+
+func (k kubevirt) NodeMemoryPolicy() memoryv1alpha1.NodeMemoryPolicyInterface {
+	return k.generatedKubeVirtClient.MemoryV1alpha1().NodeMemoryPolicies()
+}
+
 type StreamOptions struct {
 	In  io.Reader
 	Out io.Writer
@@ -256,6 +283,7 @@ type VirtualMachineInstanceInterface interface {
 	SEVQueryLaunchMeasurement(name string) (v1.SEVMeasurementInfo, error)
 	SEVSetupSession(name string, sevSessionOptions *v1.SEVSessionOptions) error
 	SEVInjectLaunchSecret(name string, sevSecretOptions *v1.SEVSecretOptions) error
+	SNPFetchCertChain(name string) (v1.SEVPlatformInfo, error)
 }
 
 type ReplicaSetInterface interface {
@@ -303,6 +331,7 @@ type VirtualMachineInterface interface {
 	PortForward(name string, port int, protocol string) (StreamInterface, error)
 	MemoryDump(ctx context.Context, name string, memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest) error
 	RemoveMemoryDump(ctx context.Context, name string) error
+	UpgradeInstancetype(ctx context.Context, name string, upgradeOptions *v1.UpgradeInstancetypeOptions) (*v1.VirtualMachine, error)
 }
 
 type VirtualMachineInstanceMigrationInterface interface {
@@ -252,6 +252,21 @@ func (v *vm) MemoryDump(ctx context.Context, name string, memoryDumpRequest *v1.
 	return v.restClient.Put().AbsPath(uri).Body([]byte(JSON)).Do(ctx).Error()
 }
 
+func (v *vm) UpgradeInstancetype(ctx context.Context, name string, upgradeOptions *v1.UpgradeInstancetypeOptions) (*v1.VirtualMachine, error) {
+	uri := fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion, v.namespace, name, "upgrade-instancetype")
+
+	optsJson, err := json.Marshal(upgradeOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradedVm := &v1.VirtualMachine{}
+	err = v.restClient.Put().AbsPath(uri).Body(optsJson).Do(ctx).Into(upgradedVm)
+	upgradedVm.SetGroupVersionKind(v1.VirtualMachineGroupVersionKind)
+
+	return upgradedVm, err
+}
+
 func (v *vm) RemoveMemoryDump(ctx context.Context, name string) error {
 	uri := fmt.Sprintf(vmSubresourceURLFmt, v1.ApiStorageVersion, v.namespace, name, "removememorydump")
 
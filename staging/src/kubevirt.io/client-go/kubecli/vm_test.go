@@ -246,6 +246,30 @@ var _ = Describe("Kubevirt VirtualMachine Client", func() {
 		Entry("with proxied server URL", proxyPath),
 	)
 
+	DescribeTable("should upgrade the instancetype of a VirtualMachine", func(proxyPath string) {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		vm := NewMinimalVM("testvm")
+		vm.Spec.Instancetype = &v1.InstancetypeMatcher{
+			Name:         "instancetype",
+			RevisionName: "revision",
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", path.Join(proxyPath, subVMPath, "upgrade-instancetype")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+		))
+		upgradedVM, err := client.VirtualMachine(k8sv1.NamespaceDefault).UpgradeInstancetype(context.Background(), "testvm",
+			&v1.UpgradeInstancetypeOptions{DryRun: []string{k8smetav1.DryRunAll}})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upgradedVM).To(Equal(vm))
+	},
+		Entry("with regular server URL", ""),
+		Entry("with proxied server URL", proxyPath),
+	)
+
 	AfterEach(func() {
 		server.Close()
 	})
@@ -538,3 +538,10 @@ func (v *vmis) SEVInjectLaunchSecret(name string, sevSecretOptions *v1.SEVSecret
 	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "sev/injectlaunchsecret")
 	return v.restClient.Put().RequestURI(uri).Body(body).Do(context.Background()).Error()
 }
+
+func (v *vmis) SNPFetchCertChain(name string) (v1.SEVPlatformInfo, error) {
+	sevPlatformInfo := v1.SEVPlatformInfo{}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "snp/fetchcertchain")
+	err := v.restClient.Get().RequestURI(uri).Do(context.Background()).Into(&sevPlatformInfo)
+	return sevPlatformInfo, err
+}
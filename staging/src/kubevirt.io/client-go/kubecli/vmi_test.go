@@ -450,6 +450,25 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(fetchedInfo).To(Equal(sevPlatformIfo), "fetched info should be the same as passed in")
 	})
 
+	It("should fetch SEV-SNP platform info via subresource", func() {
+		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		sevPlatformIfo := v1.SEVPlatformInfo{
+			CertChain: "CCCDDD",
+			ChipID:    "EEEFFF",
+		}
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", path.Join(subVMIPath, "snp/fetchcertchain")),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, sevPlatformIfo),
+		))
+		fetchedInfo, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).SNPFetchCertChain("testvm")
+
+		Expect(err).ToNot(HaveOccurred(), "should fetch info normally")
+		Expect(fetchedInfo).To(Equal(sevPlatformIfo), "fetched info should be the same as passed in")
+	})
+
 	It("should query SEV launch measurement info via subresource", func() {
 		client, err := GetKubevirtClientFromFlags(server.URL()+proxyPath, "")
 		Expect(err).ToNot(HaveOccurred())
@@ -0,0 +1,76 @@
+/*
+ * This file is part of the kubevirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2023 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/tests"
+	"kubevirt.io/kubevirt/tests/exec"
+	"kubevirt.io/kubevirt/tests/framework/kubevirt"
+	"kubevirt.io/kubevirt/tests/libvmi"
+	"kubevirt.io/kubevirt/tests/libwait"
+	"kubevirt.io/kubevirt/tests/testsuite"
+)
+
+var _ = SIGDescribe("Interface QoS", func() {
+	DescribeTable("should shape the bandwidth in the virt-launcher pod", func(iface v1.Interface, podLinkName, tapLinkName string) {
+		iface.QoS = &v1.InterfaceQoS{
+			Inbound:  &v1.InterfaceBandwidth{Average: resource.MustParse("1Mi")},
+			Outbound: &v1.InterfaceBandwidth{Average: resource.MustParse("100Ki")},
+		}
+		vmi := libvmi.NewAlpine(
+			libvmi.WithInterface(iface),
+			libvmi.WithNetwork(v1.DefaultPodNetwork()),
+		)
+		vmi, err := kubevirt.Client().VirtualMachineInstance(testsuite.GetTestNamespace(nil)).Create(context.Background(), vmi)
+		Expect(err).ToNot(HaveOccurred())
+		vmi = libwait.WaitForSuccessfulVMIStart(vmi)
+		vmiPod := tests.GetRunningPodByVirtualMachineInstance(vmi, vmi.Namespace)
+
+		By("checking the traffic sent by the guest is shaped on the pod link")
+		Expect(linkQdisc(vmiPod, podLinkName)).To(ContainSubstring("qdisc tbf 1: root"))
+
+		By("checking the traffic received by the guest is shaped on the tap device")
+		Expect(linkQdisc(vmiPod, tapLinkName)).To(ContainSubstring("qdisc tbf 1: root"))
+	},
+		Entry("with bridge binding", libvmi.InterfaceDeviceWithBridgeBinding(v1.DefaultPodNetwork().Name), "eth0-nic", "tap0"),
+		Entry("with masquerade binding", libvmi.InterfaceDeviceWithMasqueradeBinding(), "eth0", "tap0"),
+	)
+})
+
+func linkQdisc(pod *k8sv1.Pod, linkName string) string {
+	out, err := exec.ExecuteCommandOnPod(
+		kubevirt.Client(),
+		pod,
+		"compute",
+		[]string{"tc", "qdisc", "show", "dev", linkName},
+	)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	return out
+}
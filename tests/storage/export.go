@@ -1248,43 +1248,43 @@ var _ = SIGDescribe("Export", func() {
 		Expect(vmExport.Status.Links).ToNot(BeNil())
 		Expect(vmExport.Status.Links.Internal).NotTo(BeNil())
 		Expect(vmExport.Status.Links.Internal.Cert).NotTo(BeEmpty())
-		Expect(vmExport.Status.Links.Internal.Volumes).To(HaveLen(len(expectedVolumeFormats) / 2))
+		volumeFormats := []exportv1.VirtualMachineExportVolumeFormat{}
 		for _, volume := range vmExport.Status.Links.Internal.Volumes {
-			Expect(volume.Formats).To(HaveLen(2))
-			Expect(expectedVolumeFormats).To(ContainElements(volume.Formats))
+			volumeFormats = append(volumeFormats, volume.Formats...)
 		}
+		Expect(volumeFormats).To(ConsistOf(expectedVolumeFormats))
 	}
 
-	verifyMultiKubevirtInternal := func(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName1, volumeName2 string) {
-		verifyLinksInternal(vmExport,
-			exportv1.VirtualMachineExportVolumeFormat{
+	kubevirtVolumeFormats := func(exportName, namespace, volumeName string) []exportv1.VirtualMachineExportVolumeFormat {
+		volumeUrl := fmt.Sprintf("https://%s.%s.svc/volumes/%s", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName)
+		return []exportv1.VirtualMachineExportVolumeFormat{
+			{
 				Format: exportv1.KubeVirtRaw,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName1),
+				Url:    volumeUrl + "/disk.img",
 			},
-			exportv1.VirtualMachineExportVolumeFormat{
+			{
 				Format: exportv1.KubeVirtGz,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img.gz", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName1),
+				Url:    volumeUrl + "/disk.img.gz",
 			},
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtRaw,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName2),
+			{
+				Format: exportv1.KubeVirtQcow2,
+				Url:    volumeUrl + "/disk.qcow2",
 			},
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtGz,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img.gz", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName2),
-			})
+			{
+				Format: exportv1.KubeVirtQcow2Compressed,
+				Url:    volumeUrl + "/disk-compressed.qcow2",
+			},
+		}
+	}
+
+	verifyMultiKubevirtInternal := func(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName1, volumeName2 string) {
+		verifyLinksInternal(vmExport, append(
+			kubevirtVolumeFormats(exportName, namespace, volumeName1),
+			kubevirtVolumeFormats(exportName, namespace, volumeName2)...)...)
 	}
 
 	verifyKubevirtInternal := func(vmExport *exportv1.VirtualMachineExport, exportName, namespace, volumeName string) {
-		verifyLinksInternal(vmExport,
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtRaw,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName),
-			},
-			exportv1.VirtualMachineExportVolumeFormat{
-				Format: exportv1.KubeVirtGz,
-				Url:    fmt.Sprintf("https://%s.%s.svc/volumes/%s/disk.img.gz", fmt.Sprintf("%s-%s", exportPrefix, exportName), namespace, volumeName),
-			})
+		verifyLinksInternal(vmExport, kubevirtVolumeFormats(exportName, namespace, volumeName)...)
 	}
 
 	It("should create export from VMSnapshot", func() {
@@ -184,6 +184,26 @@ func getMetricsNotIncludeInEndpointByDefault() metricList {
 			description: "Indication for a virt-operator that is ready to take the lead.",
 			mType:       "Gauge",
 		},
+		{
+			name:        "kubevirt_node_memory_policy_info",
+			description: "The NodeMemoryPolicy applied on the node.",
+			mType:       "Gauge",
+		},
+		{
+			name:        "kubevirt_node_ksm_running",
+			description: "Indicates whether KSM is running on the node.",
+			mType:       "Gauge",
+		},
+		{
+			name:        "kubevirt_node_memory_overcommit_percent",
+			description: "The guest memory of the VirtualMachineInstances on the node in percent of the allocatable memory of the node.",
+			mType:       "Gauge",
+		},
+		{
+			name:        "kubevirt_node_memory_overcommit_max_percent",
+			description: "The maximum memory overcommit of the NodeMemoryPolicy applied on the node.",
+			mType:       "Gauge",
+		},
 	}
 
 	for _, rule := range components.GetRecordingRules("") {